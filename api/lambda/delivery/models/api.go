@@ -50,6 +50,10 @@ type LambdaInput struct {
 	DispatchAlerts []*DispatchAlertsInput `json:"Records"`
 	DeliverAlert   *DeliverAlertInput     `json:"deliverAlert"`
 	SendTestAlert  *SendTestAlertInput    `json:"sendTestAlert"`
+	SendDigests    *SendDigestsInput      `json:"sendDigests"`
+
+	ListDeadLetters      *ListDeadLettersInput      `json:"listDeadLetters"`
+	RedeliverDeadLetters *RedeliverDeadLettersInput `json:"redeliverDeadLetters"`
 }
 
 // SendTestAlertInput sends a dummy alert to the specified destinations
@@ -62,15 +66,94 @@ type LambdaInput struct {
 // }
 type SendTestAlertInput struct {
 	OutputIds []string `json:"outputIds" validate:"gt=0,dive,uuid4"`
+	// Preview renders the notification template of each destination for the dummy alert instead of sending it
+	Preview bool `json:"preview,omitempty"`
 }
 
 // SendTestAlertOutput holds only the attributes we want to return to the user
 type SendTestAlertOutput struct {
-	OutputID     string    `json:"outputId"`
-	Message      string    `json:"message"`
-	StatusCode   int       `json:"statusCode"`
-	Success      bool      `json:"success"`
-	DispatchedAt time.Time `json:"dispatchedAt"`
+	OutputID     string               `json:"outputId"`
+	Message      string               `json:"message"`
+	StatusCode   int                  `json:"statusCode"`
+	Success      bool                 `json:"success"`
+	DispatchedAt time.Time            `json:"dispatchedAt"`
+	Preview      *NotificationPreview `json:"preview,omitempty"`
+}
+
+// NotificationPreview is a notification template rendered for the dummy alert.
+// An empty title or body means the destination uses the default layout.
+type NotificationPreview struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// SendDigestsInput sends the digest of each output in digest mode whose interval has elapsed.
+// It is invoked on a schedule.
+//
+// Example:
+// {
+//     "sendDigests": {}
+// }
+type SendDigestsInput struct{}
+
+// DeadLetterFilter selects failed deliveries by destination, rule or policy and time of failure.
+// Empty fields match all failed deliveries.
+type DeadLetterFilter struct {
+	OutputIds  []string   `json:"outputIds" validate:"omitempty,dive,uuid4"`
+	AnalysisID string     `json:"analysisId"`
+	Since      *time.Time `json:"since"`
+	Until      *time.Time `json:"until"`
+}
+
+// ListDeadLettersInput lists the deliveries which failed after all retries
+//
+// Example:
+// {
+//     "listDeadLetters": {
+//         "outputIds": ["198bdbc5-5d94-4d59-8c93-f2bab86359f5"],
+//         "since": "2020-12-01T00:00:00Z"
+//     }
+// }
+type ListDeadLettersInput struct {
+	DeadLetterFilter
+}
+
+// ListDeadLettersOutput holds the failed deliveries matching the filter
+type ListDeadLettersOutput struct {
+	DeadLetters []*DeadLetter `json:"deadLetters"`
+}
+
+// DeadLetter is a delivery of an alert to a destination which failed after all retries
+type DeadLetter struct {
+	OutputID   string    `json:"outputId"`
+	AlertID    string    `json:"alertId"`
+	AnalysisID string    `json:"analysisId"`
+	FailedAt   time.Time `json:"failedAt"`
+	StatusCode int       `json:"statusCode"`
+	Message    string    `json:"message"`
+	RetryCount int       `json:"retryCount"`
+	Alert      *Alert    `json:"alert"`
+}
+
+// RedeliverDeadLettersInput queues the failed deliveries matching the filter to be delivered again,
+// e.g. once an outage of a destination is over.
+//
+// Example:
+// {
+//     "redeliverDeadLetters": {
+//         "outputIds": ["198bdbc5-5d94-4d59-8c93-f2bab86359f5"],
+//         "analysisId": "AWS.CloudTrail.RootActivity",
+//         "since": "2020-12-01T00:00:00Z",
+//         "until": "2020-12-01T06:00:00Z"
+//     }
+// }
+type RedeliverDeadLettersInput struct {
+	DeadLetterFilter
+}
+
+// RedeliverDeadLettersOutput holds the number of failed deliveries queued to be delivered again
+type RedeliverDeadLettersOutput struct {
+	Redelivered int `json:"redelivered"`
 }
 
 // DeliverAlertInput sends an alert to the specified destinations
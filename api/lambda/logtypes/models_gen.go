@@ -36,10 +36,26 @@ type LogTypesAPI interface {
 
 	ListCustomLogs() (ListCustomLogsResponse, error)
 
+	InferSchema(input InferSchemaInput) (InferSchemaResponse, error)
+
+	GetLookupTable(input GetLookupTableInput) (GetLookupTableResponse, error)
+
+	PutLookupTable(input PutLookupTableInput) (PutLookupTableResponse, error)
+
+	DelLookupTable(input DelLookupTableInput) (DelLookupTableResponse, error)
+
+	ListLookupTables() (ListLookupTablesResponse, error)
+
 	ListManagedSchemaUpdates(input ListManagedSchemaUpdatesInput) (ListManagedSchemaUpdatesResponse, error)
 
 	UpdateManagedSchemas(input UpdateManagedSchemasInput) (UpdateManagedSchemasResponse, error)
 
+	ListSchemaRevisions(input ListSchemaRevisionsInput) (ListSchemaRevisionsResponse, error)
+
+	DiffSchemaRevisions(input DiffSchemaRevisionsInput) (DiffSchemaRevisionsResponse, error)
+
+	RollbackCustomLog(input RollbackCustomLogInput) (RollbackCustomLogResponse, error)
+
 	GetSchema(input GetSchemaInput) (GetSchemaResponse, error)
 }
 
@@ -53,8 +69,16 @@ type LogTypesAPIPayload struct {
 	PutCustomLog             *PutCustomLogInput
 	DelCustomLog             *DelCustomLogInput
 	ListCustomLogs           *struct{}
+	InferSchema              *InferSchemaInput
+	GetLookupTable           *GetLookupTableInput
+	PutLookupTable           *PutLookupTableInput
+	DelLookupTable           *DelLookupTableInput
+	ListLookupTables         *struct{}
 	ListManagedSchemaUpdates *ListManagedSchemaUpdatesInput
 	UpdateManagedSchemas     *UpdateManagedSchemasInput
+	ListSchemaRevisions      *ListSchemaRevisionsInput
+	DiffSchemaRevisions      *DiffSchemaRevisionsInput
+	RollbackCustomLog        *RollbackCustomLogInput
 	GetSchema                *GetSchemaInput
 }
 
@@ -70,6 +94,38 @@ type DelCustomLogResponse struct {
 	} `json:"error,omitempty" description:"The delete record"`
 }
 
+type DelLookupTableInput struct {
+	Name     string `json:"name" validate:"required" description:"The lookup table name"`
+	Revision int64  `json:"revision" validate:"min=1" description:"Lookup table record revision"`
+}
+
+type DelLookupTableResponse struct {
+	Error struct {
+		Code    string `json:"code" validate:"required"`
+		Message string `json:"message" validate:"required"`
+	} `json:"error,omitempty" description:"An error that occurred during the operation"`
+}
+
+type DiffSchemaRevisionsInput struct {
+	Name string `json:"name" validate:"required" description:"The schema id"`
+	From int64  `json:"from" validate:"required,min=1" description:"The revision to compare from"`
+	To   int64  `json:"to" validate:"required,min=1" description:"The revision to compare to"`
+}
+
+type DiffSchemaRevisionsResponse struct {
+	Changes []struct {
+		Type     string      `json:"type" description:"The type of change"`
+		Path     []string    `json:"path" description:"The path to the changed value in the schema"`
+		From     interface{} `json:"from,omitempty" description:"The value before the change"`
+		To       interface{} `json:"to,omitempty" description:"The value after the change"`
+		Breaking string      `json:"breaking,omitempty" description:"The reason the change is not compatible with existing Glue columns (field omitted if the change is compatible)"`
+	} `json:"changes" description:"The changes required to go from one revision to the other"`
+	Error struct {
+		Code    string `json:"code" validate:"required"`
+		Message string `json:"message" validate:"required"`
+	} `json:"error,omitempty" description:"An error that occurred during the operation"`
+}
+
 type GetCustomLogInput struct {
 	LogType string `json:"logType" validate:"required,startswith=Custom." description:"The log type id"`
 }
@@ -93,6 +149,29 @@ type GetCustomLogResponse struct {
 	} `json:"error,omitempty" description:"An error that occurred while fetching the record"`
 }
 
+type GetLookupTableInput struct {
+	Name string `json:"name" validate:"required" description:"The lookup table name"`
+}
+
+type GetLookupTableResponse struct {
+	Record struct {
+		Name        string    `json:"name" dynamodbav:"name" validate:"required" description:"The lookup table name"`
+		Revision    int64     `json:"revision" validate:"required,min=1" description:"Lookup table record revision"`
+		UpdatedAt   time.Time `json:"updatedAt" description:"Last update timestamp of the record"`
+		CreatedAt   time.Time `json:"createdAt" description:"Creation timestamp of the record"`
+		Description string    `json:"description" description:"Lookup table description"`
+		S3Bucket    string    `json:"s3Bucket" validate:"required" description:"The S3 bucket of the table data"`
+		S3Key       string    `json:"s3Key" validate:"required" description:"The S3 object key of the table data"`
+		Format      string    `json:"format" validate:"required,oneof=csv json" description:"The format of the table data (csv or json)"`
+		KeyField    string    `json:"keyField" validate:"required" description:"The field of each row that is matched against event values"`
+		Disabled    bool      `json:"disabled,omitempty" dynamodbav:"IsDeleted" description:"Lookup table is deleted"`
+	} `json:"record,omitempty" description:"The lookup table record (field omitted if an error occurred)"`
+	Error struct {
+		Code    string `json:"code" validate:"required"`
+		Message string `json:"message" validate:"required"`
+	} `json:"error,omitempty" description:"An error that occurred while fetching the record"`
+}
+
 type GetSchemaInput struct {
 	Name string `json:"name" validate:"required" description:"The schema id"`
 }
@@ -116,6 +195,29 @@ type GetSchemaResponse struct {
 	} `json:"error,omitempty" description:"An error that occurred while fetching the record"`
 }
 
+type InferSchemaInput struct {
+	S3Bucket   string `json:"s3Bucket" validate:"required" description:"The S3 bucket of the sample data"`
+	S3Prefix   string `json:"s3Prefix" description:"The S3 key prefix of the sample data objects"`
+	RoleARN    string `json:"roleARN,omitempty" description:"The role to assume to read the sample data (ie the log processing role of a source)"`
+	MaxObjects int    `json:"maxObjects,omitempty" validate:"omitempty,min=1,max=100" description:"Maximum number of objects to read (defaults to 10)"`
+	MaxLines   int    `json:"maxLines,omitempty" validate:"omitempty,min=1,max=10000" description:"Maximum number of lines to read across all objects (defaults to 1000)"`
+}
+
+type InferSchemaResponse struct {
+	Spec       string `json:"logSpec,omitempty" description:"The draft schema spec in YAML format (field omitted if an error occurred)"`
+	NumObjects int    `json:"numObjects" description:"The number of sample objects read"`
+	NumLines   int    `json:"numLines" description:"The number of sample lines read"`
+	Failures   []struct {
+		S3Key   string `json:"s3Key" description:"The S3 key of the sample object"`
+		Line    int    `json:"line" description:"The line number in the sample object"`
+		Message string `json:"message" description:"The reason the line failed to parse"`
+	} `json:"failures,omitempty" description:"Sample lines that fail to parse using the draft schema"`
+	Error struct {
+		Code    string `json:"code" validate:"required"`
+		Message string `json:"message" validate:"required"`
+	} `json:"error,omitempty" description:"An error that occurred during the operation"`
+}
+
 type ListAvailableLogTypesResponse struct {
 	LogTypes []string `json:"logTypes"`
 }
@@ -147,6 +249,25 @@ type ListDeletedCustomLogsResponse struct {
 	} `json:"error,omitempty" description:"An error that occurred while fetching the list"`
 }
 
+type ListLookupTablesResponse struct {
+	Records []struct {
+		Name        string    `json:"name" dynamodbav:"name" validate:"required" description:"The lookup table name"`
+		Revision    int64     `json:"revision" validate:"required,min=1" description:"Lookup table record revision"`
+		UpdatedAt   time.Time `json:"updatedAt" description:"Last update timestamp of the record"`
+		CreatedAt   time.Time `json:"createdAt" description:"Creation timestamp of the record"`
+		Description string    `json:"description" description:"Lookup table description"`
+		S3Bucket    string    `json:"s3Bucket" validate:"required" description:"The S3 bucket of the table data"`
+		S3Key       string    `json:"s3Key" validate:"required" description:"The S3 object key of the table data"`
+		Format      string    `json:"format" validate:"required,oneof=csv json" description:"The format of the table data (csv or json)"`
+		KeyField    string    `json:"keyField" validate:"required" description:"The field of each row that is matched against event values"`
+		Disabled    bool      `json:"disabled,omitempty" dynamodbav:"IsDeleted" description:"Lookup table is deleted"`
+	} `json:"lookupTables" description:"Lookup table records stored"`
+	Error struct {
+		Code    string `json:"code" validate:"required"`
+		Message string `json:"message" validate:"required"`
+	} `json:"error,omitempty" description:"An error that occurred during the operation"`
+}
+
 type ListManagedSchemaUpdatesInput struct{}
 
 type ListManagedSchemaUpdatesResponse struct {
@@ -161,6 +282,29 @@ type ListManagedSchemaUpdatesResponse struct {
 	} `json:"error,omitempty" description:"An error that occurred while fetching the record"`
 }
 
+type ListSchemaRevisionsInput struct {
+	Name string `json:"name" validate:"required" description:"The schema id"`
+}
+
+type ListSchemaRevisionsResponse struct {
+	Records []struct {
+		Name         string    `json:"logType" dynamodbav:"logType" validate:"required" description:"The schema id"`
+		Revision     int64     `json:"revision" validate:"required,min=1" description:"Schema record revision"`
+		Release      string    `json:"release,omitempty" description:"Managed schema release version"`
+		UpdatedAt    time.Time `json:"updatedAt" description:"Last update timestamp of the record"`
+		CreatedAt    time.Time `json:"createdAt" description:"Creation timestamp of the record"`
+		Managed      bool      `json:"managed,omitempty" description:"Schema is managed by Panther"`
+		Disabled     bool      `json:"disabled,omitempty" dynamodbav:"IsDeleted"  description:"Log record is deleted"`
+		Description  string    `json:"description" description:"Log type description"`
+		ReferenceURL string    `json:"referenceURL" description:"A URL with reference docs for the schema"`
+		Spec         string    `json:"logSpec" dynamodbav:"logSpec" validate:"required" description:"The schema spec in YAML or JSON format"`
+	} `json:"records" description:"The revisions of the schema record in ascending order"`
+	Error struct {
+		Code    string `json:"code" validate:"required"`
+		Message string `json:"message" validate:"required"`
+	} `json:"error,omitempty" description:"An error that occurred during the operation"`
+}
+
 type PutCustomLogInput struct {
 	LogType      string `json:"logType" validate:"required,startswith=Custom." description:"The log type id"`
 	Revision     int64  `json:"revision,omitempty" validate:"omitempty,min=1" description:"Custom log record revision to update (if omitted a new record will be created)"`
@@ -188,6 +332,60 @@ type PutCustomLogResponse struct {
 	} `json:"error,omitempty" description:"An error that occurred during the operation"`
 }
 
+type PutLookupTableInput struct {
+	Name        string `json:"name" validate:"required" description:"The lookup table name"`
+	Revision    int64  `json:"revision,omitempty" validate:"omitempty,min=1" description:"Lookup table record revision to update (if omitted a new record will be created)"`
+	Description string `json:"description" description:"Lookup table description"`
+	S3Bucket    string `json:"s3Bucket" validate:"required" description:"The S3 bucket of the table data (must be the Panther input data bucket)"`
+	S3Key       string `json:"s3Key" validate:"required" description:"The S3 object key of the table data (must be under 'lookup_tables/')"`
+	Format      string `json:"format" validate:"required,oneof=csv json" description:"The format of the table data (csv or json)"`
+	KeyField    string `json:"keyField" validate:"required" description:"The field of each row that is matched against event values"`
+}
+
+type PutLookupTableResponse struct {
+	Record struct {
+		Name        string    `json:"name" dynamodbav:"name" validate:"required" description:"The lookup table name"`
+		Revision    int64     `json:"revision" validate:"required,min=1" description:"Lookup table record revision"`
+		UpdatedAt   time.Time `json:"updatedAt" description:"Last update timestamp of the record"`
+		CreatedAt   time.Time `json:"createdAt" description:"Creation timestamp of the record"`
+		Description string    `json:"description" description:"Lookup table description"`
+		S3Bucket    string    `json:"s3Bucket" validate:"required" description:"The S3 bucket of the table data"`
+		S3Key       string    `json:"s3Key" validate:"required" description:"The S3 object key of the table data"`
+		Format      string    `json:"format" validate:"required,oneof=csv json" description:"The format of the table data (csv or json)"`
+		KeyField    string    `json:"keyField" validate:"required" description:"The field of each row that is matched against event values"`
+		Disabled    bool      `json:"disabled,omitempty" dynamodbav:"IsDeleted" description:"Lookup table is deleted"`
+	} `json:"record,omitempty" description:"The modified record (field is omitted if an error occurred)"`
+	Error struct {
+		Code    string `json:"code" validate:"required"`
+		Message string `json:"message" validate:"required"`
+	} `json:"error,omitempty" description:"An error that occurred during the operation"`
+}
+
+type RollbackCustomLogInput struct {
+	LogType    string `json:"logType" validate:"required,startswith=Custom." description:"The log type id"`
+	Revision   int64  `json:"revision" validate:"required,min=1" description:"Custom log record revision to update"`
+	ToRevision int64  `json:"toRevision" validate:"required,min=1" description:"The revision to restore"`
+}
+
+type RollbackCustomLogResponse struct {
+	Result struct {
+		Name         string    `json:"logType" dynamodbav:"logType" validate:"required" description:"The schema id"`
+		Revision     int64     `json:"revision" validate:"required,min=1" description:"Schema record revision"`
+		Release      string    `json:"release,omitempty" description:"Managed schema release version"`
+		UpdatedAt    time.Time `json:"updatedAt" description:"Last update timestamp of the record"`
+		CreatedAt    time.Time `json:"createdAt" description:"Creation timestamp of the record"`
+		Managed      bool      `json:"managed,omitempty" description:"Schema is managed by Panther"`
+		Disabled     bool      `json:"disabled,omitempty" dynamodbav:"IsDeleted"  description:"Log record is deleted"`
+		Description  string    `json:"description" description:"Log type description"`
+		ReferenceURL string    `json:"referenceURL" description:"A URL with reference docs for the schema"`
+		Spec         string    `json:"logSpec" dynamodbav:"logSpec" validate:"required" description:"The schema spec in YAML or JSON format"`
+	} `json:"record,omitempty" description:"The modified record (field is omitted if an error occurred)"`
+	Error struct {
+		Code    string `json:"code" validate:"required"`
+		Message string `json:"message" validate:"required"`
+	} `json:"error,omitempty" description:"An error that occurred during the operation"`
+}
+
 type UpdateManagedSchemasInput struct {
 	Release     string `json:"release" validate:"required" description:"The release of the schema"`
 	ManifestURL string `json:"manifestURL,omitempty" validate:"omitempty,url" description:"The URL to download the manifest archive from"`
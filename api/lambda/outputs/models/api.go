@@ -18,6 +18,8 @@ package models
  * along with this program.  If not, see <https://www.gnu.org/licenses/>.
  */
 
+import "time"
+
 // LambdaInput is the invocation event expected by the Lambda function.
 //
 // Exactly one action must be specified.
@@ -48,6 +50,8 @@ type AddOutputInput struct {
 	OutputConfig       *OutputConfig `json:"outputConfig" validate:"required"`
 	DefaultForSeverity []*string     `json:"defaultForSeverity"`
 	AlertTypes         []string      `json:"alertTypes" validate:"omitempty,dive,oneof=RULE RULE_ERROR POLICY"`
+	RateLimit          *RateLimit    `json:"rateLimit,omitempty"`
+	Digest             *Digest       `json:"digest,omitempty"`
 }
 
 // AddOutputOutput returns a randomly generated UUID for the output.
@@ -90,6 +94,8 @@ type UpdateOutputInput struct {
 	OutputConfig       *OutputConfig `json:"outputConfig"`
 	DefaultForSeverity []*string     `json:"defaultForSeverity"`
 	AlertTypes         []string      `json:"alertTypes" validate:"omitempty,dive,oneof=RULE RULE_ERROR POLICY"`
+	RateLimit          *RateLimit    `json:"rateLimit,omitempty"`
+	Digest             *Digest       `json:"digest,omitempty"`
 }
 
 // UpdateOutputOutput returns the new updated output
@@ -168,6 +174,64 @@ type AlertOutput struct {
 
 	// DefaultForSeverity defines the alert severities that will be forwarded through this output
 	DefaultForSeverity []*string `json:"defaultForSeverity"`
+
+	// RateLimit restricts how many alerts are delivered to this output (nil means no limit)
+	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+
+	// Digest batches the alerts delivered to this output into periodic summaries (nil means no digest)
+	Digest *Digest `json:"digest,omitempty"`
+}
+
+const (
+	// RateLimitOverflowQueue delays the delivery of alerts over the rate limit until there is budget for them.
+	RateLimitOverflowQueue = "queue"
+	// RateLimitOverflowDigest rolls up alerts over the rate limit into a single summary notification.
+	RateLimitOverflowDigest = "digest"
+)
+
+// RateLimit is a token bucket rate limit for the alerts delivered to an output.
+//
+// Example:
+// {
+//     "alertsPerMinute": 10,
+//     "burst": 30,
+//     "overflow": "digest"
+// }
+type RateLimit struct {
+	// AlertsPerMinute is the sustained rate of alerts delivered to the output (0 disables the limit)
+	AlertsPerMinute int `json:"alertsPerMinute" validate:"min=0"`
+
+	// Burst is the number of alerts that can be delivered at once before the rate applies
+	Burst int `json:"burst" validate:"min=0"`
+
+	// Overflow is the action for alerts over the limit, one of "queue" (default) or "digest"
+	Overflow string `json:"overflow,omitempty" validate:"omitempty,oneof=queue digest"`
+}
+
+// Enabled checks if the rate limit applies.
+func (r *RateLimit) Enabled() bool {
+	return r != nil && r.AlertsPerMinute > 0
+}
+
+// Digest configures an output to receive a single summary of its alerts every interval.
+//
+// Example:
+// {
+//     "intervalMinutes": 60
+// }
+type Digest struct {
+	// IntervalMinutes is the time between two digests (0 disables the digest)
+	IntervalMinutes int `json:"intervalMinutes" validate:"omitempty,min=5,max=1440"`
+}
+
+// Enabled checks if alerts are delivered in digests.
+func (d *Digest) Enabled() bool {
+	return d != nil && d.IntervalMinutes > 0
+}
+
+// Interval returns the time between two digests.
+func (d *Digest) Interval() time.Duration {
+	return time.Duration(d.IntervalMinutes) * time.Minute
 }
 
 // OutputConfig contains the configuration for the output
@@ -201,11 +265,27 @@ type OutputConfig struct {
 
 	// CustomWebhook contains the configuration for a Custom Webhook alert output
 	CustomWebhook *CustomWebhookConfig `json:"customWebhook,omitempty"`
+
+	// Email contains the configuration for email (SMTP) alert output
+	Email *EmailConfig `json:"email,omitempty"`
+
+	// Splunk contains the configuration for Splunk HTTP Event Collector alert output
+	Splunk *SplunkConfig `json:"splunk,omitempty"`
+
+	// Elasticsearch contains the configuration for Elasticsearch alert output
+	Elasticsearch *ElasticsearchConfig `json:"elasticsearch,omitempty"`
+
+	// GoogleChat contains the configuration for Google Chat alert output
+	GoogleChat *GoogleChatConfig `json:"googleChat,omitempty"`
+
+	// ServiceNow contains the configuration for ServiceNow alert output
+	ServiceNow *ServiceNowConfig `json:"serviceNow,omitempty"`
 }
 
 // SlackConfig defines options for each Slack output.
 type SlackConfig struct {
-	WebhookURL string `json:"webhookURL" validate:"omitempty,url"` // https://hooks.slack.com/services/...
+	WebhookURL string                `json:"webhookURL" validate:"omitempty,url"` // https://hooks.slack.com/services/...
+	Template   *NotificationTemplate `json:"template,omitempty"`
 }
 
 // SnsConfig defines options for each SNS topic output
@@ -233,6 +313,8 @@ type JiraConfig struct {
 	AssigneeID string   `json:"assigneeId"`
 	Type       string   `json:"issueType"`
 	Labels     []string `json:"labels" validate:"required,dive,min=1"`
+
+	Template *NotificationTemplate `json:"template,omitempty"`
 }
 
 // OpsgenieConfig defines options for each Opsgenie output
@@ -243,7 +325,8 @@ type OpsgenieConfig struct {
 
 // MsTeamsConfig defines options for each MsTeams output
 type MsTeamsConfig struct {
-	WebhookURL string `json:"webhookURL" validate:"omitempty,url"`
+	WebhookURL string                `json:"webhookURL" validate:"omitempty,url"`
+	Template   *NotificationTemplate `json:"template,omitempty"`
 }
 
 // SqsConfig defines options for each Sqs topic output
@@ -260,4 +343,88 @@ type AsanaConfig struct {
 // CustomWebhookConfig defines options for each CustomWebhook output
 type CustomWebhookConfig struct {
 	WebhookURL string `json:"webhookURL" validate:"omitempty,url"`
+	// The body of the template is sent as the request body and must render valid JSON
+	Template *NotificationTemplate `json:"template,omitempty"`
+}
+
+// EmailConfig defines options for each email output
+type EmailConfig struct {
+	Host     string   `json:"host" validate:"omitempty,hostname|ip"`     // smtp.example.com
+	Port     int      `json:"port" validate:"omitempty,min=1,max=65535"` // Defaults to 587
+	UserName string   `json:"userName"`
+	Password string   `json:"password"`
+	From     string   `json:"from" validate:"omitempty,email"`
+	To       []string `json:"to" validate:"omitempty,min=1,dive,email"`
+}
+
+// SplunkConfig defines options for each Splunk HTTP Event Collector output
+type SplunkConfig struct {
+	URL        string `json:"url" validate:"omitempty,url"` // https://splunk.example.com:8088/services/collector/event
+	Token      string `json:"token"`
+	Index      string `json:"index"`
+	Source     string `json:"source"`
+	SourceType string `json:"sourceType"`
+}
+
+// ElasticsearchConfig defines options for each Elasticsearch output
+type ElasticsearchConfig struct {
+	URL   string `json:"url" validate:"omitempty,url"` // https://elasticsearch.example.com:9200
+	Index string `json:"index" validate:"omitempty,min=1,max=255"`
+	// Either basic authentication or an API key can be used
+	UserName string `json:"userName"`
+	Password string `json:"password"`
+	APIKey   string `json:"apiKey"`
+}
+
+// GoogleChatConfig defines options for each Google Chat output
+type GoogleChatConfig struct {
+	WebhookURL string `json:"webhookURL" validate:"omitempty,url"` // https://chat.googleapis.com/v1/spaces/...
+}
+
+// ServiceNowConfig defines options for each ServiceNow output
+type ServiceNowConfig struct {
+	InstanceURL     string `json:"instanceURL" validate:"omitempty,url"` // https://example.service-now.com
+	UserName        string `json:"userName"`
+	Password        string `json:"password"`
+	AssignmentGroup string `json:"assignmentGroup"`
+}
+
+// NotificationTemplate overrides the default layout of the notifications sent to an output.
+//
+// The title and body are Go text templates (https://golang.org/pkg/text/template/) which can reference
+// the fields of the notification (e.g. {{.Title}}, {{.Severity}}, {{.AlertContext.ip}}) and the
+// functions json, join, upper and lower. An empty title or body keeps the default layout.
+// Only Slack, MS Teams, Jira and custom webhook outputs support templates.
+// When updating an output, a template replaces the existing one as a whole and a template with an empty
+// title and body clears it. The existing template is kept if the template is omitted.
+//
+// Example:
+// {
+//     "title": "[{{.Severity}}] {{.Title}}",
+//     "body": "{{.Description}}\nSource IP: {{.AlertContext.sourceIp}}"
+// }
+type NotificationTemplate struct {
+	Title string `json:"title,omitempty" validate:"omitempty,max=1000,notificationTemplate"`
+	Body  string `json:"body,omitempty" validate:"omitempty,max=10000,notificationTemplate"`
+}
+
+// SupportsTemplate checks if the configured output renders notification templates.
+func (config *OutputConfig) SupportsTemplate() bool {
+	return config.Slack != nil || config.MsTeams != nil || config.Jira != nil || config.CustomWebhook != nil
+}
+
+// Template returns the notification template of the configured output, if any.
+func (config *OutputConfig) Template() *NotificationTemplate {
+	switch {
+	case config.Slack != nil:
+		return config.Slack.Template
+	case config.MsTeams != nil:
+		return config.MsTeams.Template
+	case config.Jira != nil:
+		return config.Jira.Template
+	case config.CustomWebhook != nil:
+		return config.CustomWebhook.Template
+	default:
+		return nil
+	}
 }
@@ -41,7 +41,7 @@ type AddResourceEntry struct {
 	Attributes      interface{} `json:"attributes" validate:"required"`
 	ID              string      `json:"id" validate:"required"`
 	IntegrationID   string      `json:"integrationId" validate:"uuid4"`
-	IntegrationType string      `json:"integrationType" validate:"oneof=aws"`
+	IntegrationType string      `json:"integrationType" validate:"oneof=aws gcp k8s"`
 	Type            string      `json:"type" validate:"required"`
 }
 
@@ -85,7 +85,7 @@ type ListResourcesInput struct {
 	IntegrationID string `json:"integrationId" validate:"omitempty,uuid4"`
 
 	// Only include resoures from this integration type
-	IntegrationType string `json:"integrationType" validate:"omitempty,oneof=aws"`
+	IntegrationType string `json:"integrationType" validate:"omitempty,oneof=aws gcp k8s"`
 
 	// Only include resources which match one of these resource types
 	Types []string `json:"types" validate:"omitempty,dive,required"`
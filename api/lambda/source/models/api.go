@@ -51,7 +51,7 @@ type LambdaInput struct {
 // CheckIntegrationInput is used to check the health of a potential configuration.
 type CheckIntegrationInput struct {
 	AWSAccountID     string `genericapi:"redact" json:"awsAccountId" validate:"omitempty,len=12,numeric"`
-	IntegrationType  string `json:"integrationType" validate:"oneof=aws-scan aws-s3 aws-sqs"`
+	IntegrationType  string `json:"integrationType" validate:"oneof=aws-scan aws-s3 aws-sqs http gcp-scan k8s-scan"`
 	IntegrationLabel string `json:"integrationLabel" validate:"required,integrationLabel"`
 
 	// Checks for cloudsec integrations
@@ -67,6 +67,15 @@ type CheckIntegrationInput struct {
 	// Checks for Sqs configuration
 	SqsConfig *SqsConfig `json:"sqsConfig,omitempty"`
 
+	// Checks for HTTP configuration
+	HTTPConfig *HTTPConfig `json:"httpConfig,omitempty"`
+
+	// Checks for GCP configuration
+	GCPConfig *GCPConfig `json:"gcpConfig,omitempty"`
+
+	// Checks for Kubernetes configuration
+	K8sConfig *K8sConfig `json:"k8sConfig,omitempty"`
+
 	// PantherVersion is the version of Panther that the source was created with. Must follow semver format.
 	PantherVersionStr string `json:"pantherVersion"`
 }
@@ -91,7 +100,7 @@ type PutIntegrationInput struct {
 // PutIntegrationSettings are all the settings for the new integration.
 type PutIntegrationSettings struct {
 	IntegrationLabel           string           `json:"integrationLabel" validate:"required,integrationLabel,excludesall='<>&\""`
-	IntegrationType            string           `json:"integrationType" validate:"oneof=aws-scan aws-s3 aws-sqs"`
+	IntegrationType            string           `json:"integrationType" validate:"oneof=aws-scan aws-s3 aws-sqs http gcp-scan k8s-scan"`
 	UserID                     string           `json:"userId" validate:"required,uuid4"`
 	AWSAccountID               string           `genericapi:"redact" json:"awsAccountId" validate:"omitempty,len=12,numeric"`
 	CWEEnabled                 *bool            `json:"cweEnabled"`
@@ -105,8 +114,12 @@ type PutIntegrationSettings struct {
 	S3PrefixLogTypes           S3PrefixLogtypes `json:"s3PrefixLogTypes,omitempty" validate:"omitempty,min=1"`
 	KmsKey                     string           `json:"kmsKey" validate:"omitempty,kmsKeyArn"`
 	ManagedBucketNotifications bool             `json:"managedBucketNotifications"`
+	EventFilters               []EventFilter    `json:"eventFilters,omitempty" validate:"omitempty,dive"`
 
-	SqsConfig *SqsConfig `json:"sqsConfig,omitempty"`
+	SqsConfig  *SqsConfig  `json:"sqsConfig,omitempty"`
+	HTTPConfig *HTTPConfig `json:"httpConfig,omitempty"`
+	GCPConfig  *GCPConfig  `json:"gcpConfig,omitempty"`
+	K8sConfig  *K8sConfig  `json:"k8sConfig,omitempty"`
 }
 
 //
@@ -115,7 +128,7 @@ type PutIntegrationSettings struct {
 
 // ListIntegrationsInput allows filtering by the IntegrationType field
 type ListIntegrationsInput struct {
-	IntegrationType *string `json:"integrationType" validate:"omitempty,oneof=aws-scan aws-s3 aws-sqs"`
+	IntegrationType *string `json:"integrationType" validate:"omitempty,oneof=aws-scan aws-s3 aws-sqs http gcp-scan k8s-scan"`
 }
 
 // UpdateIntegrationSettingsInput is used to update integration settings.
@@ -132,8 +145,12 @@ type UpdateIntegrationSettingsInput struct {
 	S3Bucket                string           `json:"s3Bucket" validate:"omitempty,min=1"`
 	S3PrefixLogTypes        S3PrefixLogtypes `json:"s3PrefixLogTypes,omitempty" validate:"omitempty,min=1"`
 	KmsKey                  string           `json:"kmsKey" validate:"omitempty,kmsKeyArn"`
+	EventFilters            []EventFilter    `json:"eventFilters,omitempty" validate:"omitempty,dive"`
 
-	SqsConfig *SqsConfig `json:"sqsConfig,omitempty"`
+	SqsConfig  *SqsConfig  `json:"sqsConfig,omitempty"`
+	HTTPConfig *HTTPConfig `json:"httpConfig,omitempty"`
+	GCPConfig  *GCPConfig  `json:"gcpConfig,omitempty"`
+	K8sConfig  *K8sConfig  `json:"k8sConfig,omitempty"`
 }
 
 // DeleteIntegrationInput is used to delete a specific item from the database.
@@ -20,11 +20,16 @@ package models
 
 import (
 	"fmt"
+	"net"
+	"regexp"
 	"strings"
 	"time"
 
+	"github.com/pkg/errors"
+
 	"github.com/panther-labs/panther/internal/compliance/snapshotlogs"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/processor/logstream"
 	"github.com/panther-labs/panther/pkg/stringset"
 )
 
@@ -84,6 +89,18 @@ type SourceIntegrationMetadata struct {
 
 	SqsConfig *SqsConfig `json:"sqsConfig,omitempty"`
 
+	HTTPConfig *HTTPConfig `json:"httpConfig,omitempty"`
+
+	GCPConfig *GCPConfig `json:"gcpConfig,omitempty"`
+
+	K8sConfig *K8sConfig `json:"k8sConfig,omitempty"`
+
+	// The ARN of the Secrets Manager secret holding the credentials of the source (HTTP, GCP and Kubernetes sources only)
+	CredentialsSecretARN string `json:"credentialsSecretArn,omitempty"`
+
+	// Optional rules to drop or sample log events after classification (log analysis sources only)
+	EventFilters []EventFilter `json:"eventFilters,omitempty"`
+
 	// PantherVersion is the version of Panther that the source was created with.
 	PantherVersion string `json:"pantherVersion,omitempty"`
 }
@@ -102,6 +119,25 @@ type ManagedS3Resources struct {
 type S3PrefixLogtypesMapping struct {
 	S3Prefix string   `json:"prefix"`
 	LogTypes []string `json:"logTypes" validate:"required,min=1"`
+	// Optional path to an array of log events in each JSON object under the prefix, i.e. `Records` or `data[*].events`.
+	// See logstream.ParseJSONArrayPath for the syntax.
+	JSONArrayPath string `json:"jsonArrayPath,omitempty"`
+}
+
+// JSONArrayPathElements parses the JSONArrayPath of the mapping.
+// It returns nil if no path is set and an empty non-nil slice if the objects are top-level JSON arrays.
+func (m *S3PrefixLogtypesMapping) JSONArrayPathElements() ([]string, error) {
+	if strings.TrimSpace(m.JSONArrayPath) == "" {
+		return nil, nil
+	}
+	path, err := logstream.ParseJSONArrayPath(m.JSONArrayPath)
+	if err != nil {
+		return nil, err
+	}
+	if path == nil {
+		path = []string{}
+	}
+	return path, nil
 }
 
 type S3PrefixLogtypes []S3PrefixLogtypesMapping
@@ -143,6 +179,11 @@ func (s *SourceIntegration) RequiredLogTypes() (logTypes []string) {
 		return s.S3PrefixLogTypes.LogTypes()
 	case IntegrationTypeSqs:
 		return s.SqsConfig.LogTypes
+	case IntegrationTypeHTTP:
+		return s.HTTPConfig.LogTypes
+	case IntegrationTypeGCPScan, IntegrationTypeK8sScan:
+		// GCP and Kubernetes snapshots are stored by the resources API, they don't produce logs
+		return nil
 	default:
 		// should not be reached
 		panic(fmt.Sprintf("Could not determine logtypes for source {id:%s label:%s type:%s}",
@@ -156,6 +197,10 @@ func (s *SourceIntegration) RequiredLogProcessingRole() string {
 		return s.LogProcessingRole
 	case IntegrationTypeSqs:
 		return s.SqsConfig.LogProcessingRole
+	case IntegrationTypeHTTP:
+		return s.HTTPConfig.LogProcessingRole
+	case IntegrationTypeGCPScan, IntegrationTypeK8sScan:
+		return ""
 	default:
 		panic("Unknown type " + typ)
 	}
@@ -171,6 +216,10 @@ func (s *SourceIntegration) S3Info() (bucket string, prefixes []string) {
 		return s.S3Bucket, s.S3PrefixLogTypes.S3Prefixes()
 	case IntegrationTypeSqs:
 		return s.SqsConfig.S3Bucket, []string{"forwarder"}
+	case IntegrationTypeHTTP:
+		return s.HTTPConfig.S3Bucket, []string{"http"}
+	case IntegrationTypeGCPScan, IntegrationTypeK8sScan:
+		return "", nil
 	default:
 		// should not be reached
 		panic(fmt.Sprintf("Could not determine s3 info for source {id:%s label:%s type:%s}",
@@ -199,6 +248,15 @@ type SourceIntegrationHealth struct {
 
 	// Checks for Sqs integrations
 	SqsStatus SourceIntegrationItemStatus `json:"sqsStatus"`
+
+	// Checks for HTTP integrations
+	HTTPStatus SourceIntegrationItemStatus `json:"httpStatus"`
+
+	// Checks for GCP integrations
+	GCPCredentialsStatus SourceIntegrationItemStatus `json:"gcpCredentialsStatus"`
+
+	// Checks for Kubernetes integrations
+	K8sCredentialsStatus SourceIntegrationItemStatus `json:"k8sCredentialsStatus"`
 }
 
 type SourceIntegrationItemStatus struct {
@@ -227,3 +285,115 @@ type SqsConfig struct {
 	// THe URL of the SQS queue
 	QueueURL string `json:"queueUrl"`
 }
+
+type HTTPConfig struct {
+	// The log types associated with the source. Needs to be set by UI.
+	LogTypes []string `json:"logTypes" validate:"required,min=1"`
+	// How incoming requests are authenticated, one of `sharedSecret` or `hmac`. Needs to be set by UI.
+	AuthMethod string `json:"authMethod" validate:"oneof=sharedSecret hmac"`
+	// The request header carrying the shared secret or the HMAC signature.
+	// Defaults to `X-Panther-Secret` for shared secrets and `X-Panther-Signature` for HMAC.
+	AuthHeader string `json:"authHeader,omitempty"`
+	// The shared secret or the HMAC key. Needs to be set by UI when creating the source, updates keep the
+	// existing secret if it is empty. The secret is stored in Secrets Manager and never returned when reading sources.
+	AuthSecret string `genericapi:"redact" json:"authSecret,omitempty" validate:"omitempty,min=16"`
+
+	// The Panther-internal S3 bucket where the data from this source will be available
+	S3Bucket string `json:"s3Bucket"`
+	// The Role that the log processor can use to access this data
+	LogProcessingRole string `json:"logProcessingRole"`
+}
+
+type GCPConfig struct {
+	// The ID of the Google Cloud project to scan. Needs to be set by UI.
+	ProjectID string `json:"projectId" validate:"required,min=6,max=30"`
+	// The JSON key of a service account with the Cloud Asset Viewer role on the project. Needs to be set by UI when
+	// creating the source, updates keep the existing key if it is empty. The key is stored in Secrets Manager and
+	// never returned when reading sources.
+	ServiceAccountKey string `genericapi:"redact" json:"serviceAccountKey,omitempty"`
+}
+
+type K8sConfig struct {
+	// The name of the cluster, used in the IDs of its resources. Needs to be set by UI.
+	ClusterName string `json:"clusterName" validate:"required,max=100,excludesall=/"`
+	// A kubeconfig with read access to the cluster. Either this or the EKS cluster ARN needs to be set by UI when
+	// creating the source, updates keep the existing kubeconfig if it is empty. The kubeconfig is stored in
+	// Secrets Manager and never returned when reading sources.
+	Kubeconfig string `genericapi:"redact" json:"kubeconfig,omitempty"`
+	// The ARN of an EKS cluster, read with the Panther audit role of the cluster account. The role needs
+	// to be mapped to a Kubernetes group with read access in the aws-auth ConfigMap of the cluster.
+	EKSClusterARN string `json:"eksClusterArn,omitempty"`
+}
+
+const (
+	HTTPDefaultSecretHeader    = "X-Panther-Secret"
+	HTTPDefaultSignatureHeader = "X-Panther-Signature"
+)
+
+// Header returns the request header the source expects to carry the credentials.
+func (c *HTTPConfig) Header() string {
+	if c.AuthHeader != "" {
+		return c.AuthHeader
+	}
+	if c.AuthMethod == HTTPAuthHMAC {
+		return HTTPDefaultSignatureHeader
+	}
+	return HTTPDefaultSecretHeader
+}
+
+// EventFilter is a rule to drop or sample the log events of a source.
+//
+// Filters are evaluated in order after an event is classified and the first matching filter decides
+// whether the event is kept. Events that do not match any filter are always kept.
+// An event matches a filter if it has the filter's log type (if set) and the value at Field
+// is one of Equals, is an IP address in one of CIDR or matches Regex (whichever is set).
+// A filter without a condition matches all events of its log type that have a value at Field (if set).
+type EventFilter struct {
+	// LogType limits the filter to events of a log type
+	LogType string `json:"logType,omitempty"`
+	// Field is the path to a field of the event using dots to separate nested fields, i.e. `request.method`
+	Field  string   `json:"field,omitempty" validate:"required_with=Equals CIDR Regex"`
+	Equals []string `json:"equals,omitempty"`
+	CIDR   []string `json:"cidr,omitempty"`
+	Regex  string   `json:"regex,omitempty"`
+	// SampleRatio is the fraction of matching events that are kept. Zero drops all matching events.
+	// It is required so that an omitted value does not silently drop events.
+	SampleRatio *float64 `json:"sampleRatio" validate:"required,min=0,max=1"`
+}
+
+// Validate checks that the filter condition is well-formed
+func (f *EventFilter) Validate() error {
+	conditions := 0
+	if len(f.Equals) > 0 {
+		conditions++
+	}
+	if len(f.CIDR) > 0 {
+		conditions++
+	}
+	if f.Regex != "" {
+		conditions++
+	}
+	if conditions > 1 {
+		return errors.New("only one of equals, cidr or regex can be set")
+	}
+	if conditions == 1 && f.Field == "" {
+		return errors.New("field is required")
+	}
+	for _, cidr := range f.CIDR {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.Wrapf(err, "invalid CIDR %q", cidr)
+		}
+	}
+	if f.Regex != "" {
+		if _, err := regexp.Compile(f.Regex); err != nil {
+			return errors.Wrapf(err, "invalid regex %q", f.Regex)
+		}
+	}
+	if f.SampleRatio == nil {
+		return errors.New("sample ratio is required")
+	}
+	if ratio := *f.SampleRatio; ratio < 0 || ratio > 1 {
+		return errors.Errorf("sample ratio %v is not between 0 and 1", ratio)
+	}
+	return nil
+}
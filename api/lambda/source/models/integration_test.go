@@ -26,9 +26,9 @@ import (
 
 func TestS3PrefixLogtypes_LongestPrefixMatch(t *testing.T) {
 	pl := S3PrefixLogtypes{
-		{"prefixA/", []string{"Log.A"}},
-		{"prefixA/prefixB", []string{"Log.B"}},
-		{"", []string{"Log.C"}},
+		{S3Prefix: "prefixA/", LogTypes: []string{"Log.A"}},
+		{S3Prefix: "prefixA/prefixB", LogTypes: []string{"Log.B"}},
+		{S3Prefix: "", LogTypes: []string{"Log.C"}},
 	}
 
 	testcases := []struct {
@@ -50,8 +50,8 @@ func TestS3PrefixLogtypes_LongestPrefixMatch(t *testing.T) {
 
 func TestS3PrefixLogtypes_LongestPrefixMatch_ReturnNil(t *testing.T) {
 	pl := S3PrefixLogtypes{
-		{"prefixA/", []string{"Log.A"}},
-		{"prefixA/prefixB", []string{"Log.B"}},
+		{S3Prefix: "prefixA/", LogTypes: []string{"Log.A"}},
+		{S3Prefix: "prefixA/prefixB", LogTypes: []string{"Log.B"}},
 	}
 
 	_, matched := pl.LongestPrefixMatch("logs/log.json")
@@ -59,3 +59,24 @@ func TestS3PrefixLogtypes_LongestPrefixMatch_ReturnNil(t *testing.T) {
 	// No prefix matched
 	require.False(t, matched)
 }
+
+func TestS3PrefixLogtypesMapping_JSONArrayPathElements(t *testing.T) {
+	m := S3PrefixLogtypesMapping{S3Prefix: "prefixA/", LogTypes: []string{"Log.A"}}
+	path, err := m.JSONArrayPathElements()
+	require.NoError(t, err)
+	require.Nil(t, path)
+
+	m.JSONArrayPath = "$"
+	path, err = m.JSONArrayPathElements()
+	require.NoError(t, err)
+	require.Equal(t, []string{}, path)
+
+	m.JSONArrayPath = "data[*].events"
+	path, err = m.JSONArrayPathElements()
+	require.NoError(t, err)
+	require.Equal(t, []string{"data", "*", "events"}, path)
+
+	m.JSONArrayPath = "data[*"
+	_, err = m.JSONArrayPathElements()
+	require.Error(t, err)
+}
@@ -25,6 +25,17 @@ const (
 	IntegrationTypeAWS3 = "aws-s3"
 	// IntegrationTypeSqs is integration type for pulling data from an SQS queue.
 	IntegrationTypeSqs = "aws-sqs"
+	// IntegrationTypeHTTP is the integration type for logs pushed to Panther over HTTP.
+	IntegrationTypeHTTP = "http"
+	// IntegrationTypeGCPScan is the integration type for snapshots in customer Google Cloud projects.
+	IntegrationTypeGCPScan = "gcp-scan"
+	// IntegrationTypeK8sScan is the integration type for snapshots of the objects in Kubernetes clusters.
+	IntegrationTypeK8sScan = "k8s-scan"
+
+	// HTTPAuthSharedSecret authenticates HTTP sources by comparing a request header to the source secret.
+	HTTPAuthSharedSecret = "sharedSecret"
+	// HTTPAuthHMAC authenticates HTTP sources by verifying an HMAC-SHA256 signature of the request body.
+	HTTPAuthHMAC = "hmac"
 
 	// StatusError is the string set in the database when an error occurs in a scan.
 	StatusError = "error"
@@ -144,9 +144,9 @@ func main() {
 	jsonAPI := common.ConfigForDataLakeWriters()
 
 	// Use the global registry
-	dest := destinations.CreateS3Destination(jsonAPI)
+	dest := destinations.CreateS3Destination(jsonAPI, registry.NativeLogTypesResolver())
 
-	newProcessor := processor.NewFactory(registry.NativeParsersResolver())
+	newProcessor := processor.NewFactory(registry.NativeParsersResolver(), nil)
 	err = processor.Process(context.Background(), streamChan, dest, newProcessor)
 	if err != nil {
 		log.Fatal(err)
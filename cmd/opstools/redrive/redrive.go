@@ -0,0 +1,167 @@
+package redrive
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/cmd/opstools/s3list"
+	"github.com/panther-labs/panther/cmd/opstools/s3queue"
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/quarantine"
+	"github.com/panther-labs/panther/internal/log_analysis/pantherdb"
+	"github.com/panther-labs/panther/pkg/awsretry"
+)
+
+const (
+	maxRetries = 7
+
+	// RedrivenTagKey is set on quarantine files once they are queued, holding the time they were re-driven.
+	// Tagged files are skipped so that overlapping runs do not duplicate data.
+	RedrivenTagKey = "panther-redriven"
+)
+
+type Input struct {
+	s3queue.DriverInput
+	Session *session.Session
+	// The processed data bucket
+	Bucket string
+	// Quarantined log lines are re-driven for the hourly partitions between Start and End
+	Start time.Time
+	End   time.Time
+	Stats s3list.Stats // passed in so we can get stats if canceled
+}
+
+// Redrive sends the files of the quarantine table to the log processor queue.
+// The log processor classifies the log lines in these files again, using the source they were quarantined from.
+//
+// The files stay in the quarantine table. Each file is tagged with RedrivenTagKey once all files were queued
+// and is not re-driven again by later runs. If queueing fails, no file is tagged and the files that were
+// already queued are re-driven again by the next run.
+func Redrive(ctx context.Context, input *Input) error {
+	clientsSession := input.Session.Copy(request.WithRetryer(aws.NewConfig().WithMaxRetries(maxRetries),
+		awsretry.NewConnectionErrRetryer(maxRetries)))
+	return redrive(ctx, s3.New(clientsSession), sqs.New(clientsSession), input)
+}
+
+func redrive(ctx context.Context, s3Client s3iface.S3API, sqsClient sqsiface.SQSAPI, input *Input) error {
+	if input.End.Before(input.Start) {
+		return errors.Errorf("end time %s is before start time %s", input.End, input.Start)
+	}
+	driver, err := s3queue.NewDriver(ctx, sqsClient, &input.DriverInput)
+	if err != nil {
+		return err
+	}
+
+	var listErr error
+	var queued []queuedFile
+	tableName := pantherdb.TableName(quarantine.TypeUnclassified)
+	for hour := input.Start.UTC().Truncate(time.Hour); !hour.After(input.End); hour = hour.Add(time.Hour) {
+		prefix := awsglue.PartitionPrefix(pantherdb.LogProcessingDatabase, tableName, awsglue.GlueTableHourly, hour)
+		listErr = s3list.ListPath(ctx, &s3list.Input{
+			Logger:   input.Logger,
+			S3Client: s3Client,
+			S3Path:   "s3://" + input.Bucket + "/" + prefix,
+			Write: func(event *events.S3Event) {
+				key := event.Records[0].S3.Object.Key
+				if listErr != nil || !isRedriveKey(key) {
+					return
+				}
+				tags, err := s3Client.GetObjectTaggingWithContext(ctx, &s3.GetObjectTaggingInput{
+					Bucket: &input.Bucket,
+					Key:    &key,
+				})
+				if err != nil {
+					listErr = errors.Wrapf(err, "failed to get tags of s3://%s/%s", input.Bucket, key)
+					return
+				}
+				if at := redrivenAt(tags.TagSet); at != "" {
+					input.Logger.Infof("skipping s3://%s/%s, re-driven at %s", input.Bucket, key, at)
+					return
+				}
+				driver.Write(event)
+				queued = append(queued, queuedFile{key: key, tags: tags.TagSet})
+			},
+			Done:  func() {},
+			Stats: &input.Stats,
+		})
+		if listErr != nil {
+			break
+		}
+	}
+	driver.Done()
+	if err := driver.Wait(); err != nil {
+		return err
+	}
+
+	// The files are tagged only after the driver sent all notifications
+	redriven := time.Now().UTC().Format(time.RFC3339)
+	for _, file := range queued {
+		// Keep the existing tags, PutObjectTagging replaces the whole set
+		tagSet := append(file.tags, &s3.Tag{
+			Key:   aws.String(RedrivenTagKey),
+			Value: aws.String(redriven),
+		})
+		_, err := s3Client.PutObjectTaggingWithContext(ctx, &s3.PutObjectTaggingInput{
+			Bucket:  &input.Bucket,
+			Key:     aws.String(file.key),
+			Tagging: &s3.Tagging{TagSet: tagSet},
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to tag re-driven file s3://%s/%s", input.Bucket, file.key)
+		}
+	}
+	return listErr
+}
+
+type queuedFile struct {
+	key  string
+	tags []*s3.Tag
+}
+
+func redrivenAt(tags []*s3.Tag) string {
+	for _, tag := range tags {
+		if aws.StringValue(tag.Key) == RedrivenTagKey {
+			return aws.StringValue(tag.Value)
+		}
+	}
+	return ""
+}
+
+// isRedriveKey checks if a key in the quarantine table is a file with log lines to re-drive.
+// Files starting with '_' are JSON copies of Parquet files written by earlier versions and are skipped.
+func isRedriveKey(key string) bool {
+	if strings.HasPrefix(path.Base(key), "_") {
+		return false
+	}
+	return strings.HasSuffix(key, ".json.gz") || quarantine.IsParquetObject(key)
+}
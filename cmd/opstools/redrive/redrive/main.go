@@ -0,0 +1,204 @@
+package main
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/cmd/opstools"
+	"github.com/panther-labs/panther/cmd/opstools/redrive"
+	"github.com/panther-labs/panther/cmd/opstools/s3queue"
+	"github.com/panther-labs/panther/pkg/prompt"
+)
+
+const (
+	banner = "re-drives quarantined log lines to the log processor queue, each quarantine file is re-driven only once"
+
+	hourLayout = "2006-01-02T15"
+	dayLayout  = "2006-01-02"
+)
+
+var (
+	REGION      = flag.String("region", "", "The Panther AWS region (optional, defaults to session env vars) where the queue exists.")
+	ACCOUNT     = flag.String("account", "", "The Panther AWS account id (optional, defaults to session account)")
+	BUCKET      = flag.String("bucket", "", "The Panther processed data bucket holding the quarantine table.")
+	START       = flag.String("start", "", "Re-drive log lines quarantined from this hour (UTC, e.g., 2020-10-01T13 or 2020-10-01).")
+	END         = flag.String("end", "", "Re-drive log lines quarantined up to this hour (optional, defaults to now).")
+	CONCURRENCY = flag.Int("concurrency", 50, "The number of concurrent sqs writer go routines")
+	TOQ         = flag.String("queue", "panther-input-data-notifications-queue", "The name of the log processor queue to send notifications.")
+	RATE        = flag.Float64("files-per-second", 0.0, "If non-zero, attempt to send at this rate of files per second")
+	INTERACTIVE = flag.Bool("interactive", true, "If true, prompt for required flags if not set")
+	DEBUG       = flag.Bool("debug", false, "Enable debug logging")
+
+	logger *zap.SugaredLogger
+
+	startTime, endTime time.Time
+)
+
+func main() {
+	opstools.SetUsage(banner)
+
+	flag.Parse()
+
+	logger = opstools.MustBuildLogger(*DEBUG)
+
+	sess, err := session.NewSession()
+	if err != nil {
+		logger.Fatal(err)
+		return
+	}
+
+	if *REGION != "" { //override
+		sess.Config.Region = REGION
+	} else {
+		REGION = sess.Config.Region
+	}
+
+	promptFlags()
+	validateFlags()
+
+	if *ACCOUNT == "" {
+		identity, err := sts.New(sess).GetCallerIdentity(&sts.GetCallerIdentityInput{})
+		if err != nil {
+			logger.Fatalf("failed to get caller identity: %v", err)
+		}
+		ACCOUNT = identity.Account
+	}
+
+	logger.Debugf("re-driving quarantined log lines from %s to %s in s3://%s to %s in %s",
+		startTime.Format(hourLayout), endTime.Format(hourLayout), *BUCKET, *TOQ, *REGION)
+
+	input := &redrive.Input{
+		DriverInput: s3queue.DriverInput{
+			Logger:         logger,
+			Account:        *ACCOUNT,
+			QueueName:      *TOQ,
+			Concurrency:    *CONCURRENCY,
+			FilesPerSecond: *RATE,
+		},
+		Session: sess,
+		Bucket:  *BUCKET,
+		Start:   startTime,
+		End:     endTime,
+	}
+
+	begin := time.Now()
+
+	// catch ^C
+	go func() {
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, syscall.SIGTERM, syscall.SIGINT)
+		caught := <-sig // wait for it
+		logger.Fatalf("caught %v, listed %d files (%.2fMB) in %v",
+			caught, input.Stats.NumFiles, float32(input.Stats.NumBytes)/(1024.0*1024.0), time.Since(begin))
+	}()
+
+	err = redrive.Redrive(context.TODO(), input)
+	if err != nil {
+		logger.Fatal(err)
+	} else {
+		logger.Infof("re-drove quarantined log lines from %d files (%.2fMB) to %s (%s) in %v",
+			input.Stats.NumFiles, float32(input.Stats.NumBytes)/(1024.0*1024.0), *TOQ, *REGION, time.Since(begin))
+	}
+}
+
+func promptFlags() {
+	if !*INTERACTIVE {
+		return
+	}
+
+	if *BUCKET == "" {
+		*BUCKET = prompt.Read("Please enter the processed data bucket name: ", prompt.NonemptyValidator)
+	}
+
+	if *START == "" {
+		*START = prompt.Read("Please enter the hour to start from (e.g., 2020-10-01T13): ", prompt.NonemptyValidator)
+	}
+}
+
+func validateFlags() {
+	var err error
+	defer func() {
+		if err != nil {
+			fmt.Printf("%s\n", err)
+			flag.Usage()
+			os.Exit(-2)
+		}
+	}()
+
+	if *CONCURRENCY <= 0 {
+		err = errors.New("-concurrency must be > 0")
+		return
+	}
+	// This ensures more continuous average activity for small FPS
+	if *RATE > 0 && float64(*CONCURRENCY) > *RATE {
+		*CONCURRENCY = int(*RATE)
+	}
+	if *RATE < 0.0 {
+		err = errors.New("-rate must be >= 0.0")
+		return
+	}
+
+	if *BUCKET == "" {
+		err = errors.New("-bucket not set")
+		return
+	}
+	if *TOQ == "" {
+		err = errors.New("-queue not set")
+		return
+	}
+
+	if *START == "" {
+		err = errors.New("-start not set")
+		return
+	}
+	if startTime, err = parseHour(*START); err != nil {
+		err = errors.Wrap(err, "invalid -start")
+		return
+	}
+	endTime = time.Now().UTC()
+	if *END != "" {
+		if endTime, err = parseHour(*END); err != nil {
+			err = errors.Wrap(err, "invalid -end")
+			return
+		}
+	}
+	if endTime.Before(startTime) {
+		err = errors.New("-end must be after -start")
+		return
+	}
+}
+
+func parseHour(value string) (time.Time, error) {
+	if tm, err := time.Parse(hourLayout, value); err == nil {
+		return tm, nil
+	}
+	return time.Parse(dayLayout, value)
+}
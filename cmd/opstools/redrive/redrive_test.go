@@ -0,0 +1,125 @@
+package redrive
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/cmd/opstools"
+	"github.com/panther-labs/panther/cmd/opstools/s3queue"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+const (
+	testAccount   = "012345678912"
+	testBucket    = "processed"
+	testQueueName = "testQueue"
+)
+
+func TestRedrive(t *testing.T) {
+	start := time.Date(2020, 10, 1, 13, 30, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	s3Client := &testutils.S3Mock{}
+	hourPrefix := func(prefix string) interface{} {
+		return mock.MatchedBy(func(input *s3.ListObjectsV2Input) bool {
+			return aws.StringValue(input.Bucket) == testBucket && aws.StringValue(input.Prefix) == prefix
+		})
+	}
+	page := &s3.ListObjectsV2Output{
+		Contents: []*s3.Object{
+			{
+				Size: aws.Int64(1),
+				Key:  aws.String("logs/unclassified/year=2020/month=10/day=01/hour=13/_20201001T133000Z-uuid4.json.gz"),
+			},
+			{
+				Size: aws.Int64(1),
+				Key:  aws.String("logs/unclassified/year=2020/month=10/day=01/hour=13/20201001T133000Z-uuid4.parquet"),
+			},
+			{
+				Size: aws.Int64(1),
+				Key:  aws.String("logs/unclassified/year=2020/month=10/day=01/hour=13/20201001T133500Z-uuid4.json.gz"),
+			},
+		},
+	}
+	objectKey := func(key string) func(*s3.GetObjectTaggingInput) bool {
+		return func(input *s3.GetObjectTaggingInput) bool {
+			return aws.StringValue(input.Key) == key
+		}
+	}
+	// The hidden JSON copy of the Parquet file is skipped and the JSON file was re-driven by an earlier run
+	s3Client.On("GetObjectTaggingWithContext", mock.Anything,
+		mock.MatchedBy(objectKey("logs/unclassified/year=2020/month=10/day=01/hour=13/20201001T133000Z-uuid4.parquet")), mock.Anything).
+		Return(&s3.GetObjectTaggingOutput{TagSet: []*s3.Tag{{Key: aws.String("owner"), Value: aws.String("ops")}}}, nil).Once()
+	s3Client.On("GetObjectTaggingWithContext", mock.Anything,
+		mock.MatchedBy(objectKey("logs/unclassified/year=2020/month=10/day=01/hour=13/20201001T133500Z-uuid4.json.gz")), mock.Anything).
+		Return(&s3.GetObjectTaggingOutput{TagSet: []*s3.Tag{
+			{Key: aws.String(RedrivenTagKey), Value: aws.String("2020-10-02T00:00:00Z")},
+		}}, nil).Once()
+	s3Client.On("PutObjectTaggingWithContext", mock.Anything, mock.MatchedBy(func(input *s3.PutObjectTaggingInput) bool {
+		tags := input.Tagging.TagSet
+		return aws.StringValue(input.Key) == "logs/unclassified/year=2020/month=10/day=01/hour=13/20201001T133000Z-uuid4.parquet" &&
+			len(tags) == 2 && aws.StringValue(tags[0].Key) == "owner" && aws.StringValue(tags[1].Key) == RedrivenTagKey
+	}), mock.Anything).Return(&s3.PutObjectTaggingOutput{}, nil).Once()
+	s3Client.On("ListObjectsV2Pages", hourPrefix("logs/unclassified/year=2020/month=10/day=01/hour=13/"), mock.Anything).
+		Return(page, nil).Once()
+	s3Client.On("ListObjectsV2Pages", hourPrefix("logs/unclassified/year=2020/month=10/day=01/hour=14/"), mock.Anything).
+		Return(&s3.ListObjectsV2Output{}, nil).Once()
+	sqsClient := &testutils.SqsMock{}
+	sqsClient.On("GetQueueUrl", mock.Anything).Return(&sqs.GetQueueUrlOutput{QueueUrl: aws.String("arn")}, nil).Once()
+	sqsClient.On("SendMessageBatch", mock.MatchedBy(func(input *sqs.SendMessageBatchInput) bool {
+		return len(input.Entries) == 1
+	})).Return(&sqs.SendMessageBatchOutput{}, nil).Once()
+
+	input := &Input{
+		DriverInput: s3queue.DriverInput{
+			Logger:      opstools.MustBuildLogger(false),
+			Account:     testAccount,
+			QueueName:   testQueueName,
+			Concurrency: 1,
+		},
+		Bucket: testBucket,
+		Start:  start,
+		End:    end,
+	}
+	err := redrive(context.TODO(), s3Client, sqsClient, input)
+	require.NoError(t, err)
+	s3Client.AssertExpectations(t)
+	sqsClient.AssertExpectations(t)
+	assert.Equal(t, uint64(3), input.Stats.NumFiles)
+}
+
+func TestRedriveInvalidRange(t *testing.T) {
+	start := time.Date(2020, 10, 1, 13, 30, 0, 0, time.UTC)
+	input := &Input{
+		Start: start,
+		End:   start.Add(-time.Hour),
+	}
+	err := redrive(context.TODO(), &testutils.S3Mock{}, &testutils.SqsMock{}, input)
+	require.Error(t, err)
+}
@@ -130,7 +130,11 @@ resource "aws_iam_role_policy" "panther_get_tags" {
         Effect : "Allow",
         Action : [
           "dynamodb:ListTagsOfResource",
+          "ecr:ListTagsForResource",
+          "es:ListTags",
           "kms:ListResourceTags",
+          "sqs:ListQueueTags",
+          "ssm:ListTagsForResource",
           "waf:ListTagsForResource",
           "waf-regional:ListTagsForResource"
         ],
@@ -140,6 +144,28 @@ resource "aws_iam_role_policy" "panther_get_tags" {
   })
 }
 
+resource "aws_iam_role_policy" "panther_get_resource_policies" {
+  count = var.include_audit_role ? 1 : 0
+  name  = "GetResourcePolicies"
+  role  = aws_iam_role.panther_audit[0].id
+
+  policy = jsonencode({
+    Version : "2012-10-17",
+    Statement : [
+      {
+        Effect : "Allow",
+        Action : [
+          "ecr:GetLifecyclePolicy",
+          "ecr:GetRepositoryPolicy",
+          "secretsmanager:GetResourcePolicy",
+          "sqs:ListDeadLetterSourceQueues"
+        ],
+        Resource : "*"
+      }
+    ]
+  })
+}
+
 
 ###############################################################
 # CloudFormation StackSet Execution Role
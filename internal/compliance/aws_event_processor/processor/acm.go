@@ -25,6 +25,10 @@ import (
 	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 )
 
+func init() {
+	registerClassifier("acm.amazonaws.com", classifyACM)
+}
+
 func classifyACM(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
 	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_awscertificatemanager.html
 	var certARN string
@@ -0,0 +1,87 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func init() {
+	registerClassifier("apigateway.amazonaws.com", classifyAPIGateway)
+}
+
+func classifyAPIGateway(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
+	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_amazonapigateway.html
+	//
+	// REST APIs (v1) are identified by restApiId and HTTP APIs (v2) by apiId. Both versions of the
+	// API share the event source and some event names (e.g. CreateStage).
+	var restAPIID, httpAPIID string
+	switch metadata.eventName {
+	case "CreateRestApi", "ImportRestApi":
+		restAPIID = detail.Get("responseElements.id").Str
+	case "CreateApi", "ImportApi":
+		httpAPIID = detail.Get("responseElements.apiId").Str
+	case "CreateDeployment",
+		"CreateStage",
+		"DeleteApi",
+		"DeleteRestApi",
+		"DeleteStage",
+		"PutRestApi",
+		"ReimportApi",
+		"UpdateApi",
+		"UpdateRestApi",
+		"UpdateStage":
+		restAPIID = detail.Get("requestParameters.restApiId").Str
+		httpAPIID = detail.Get("requestParameters.apiId").Str
+	default:
+		zap.L().Info("apigateway: encountered unknown event name", zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	// API Gateway ARNs do not contain the account ID
+	apiARN := arn.ARN{
+		Partition: "aws",
+		Service:   "apigateway",
+		Region:    metadata.region,
+	}
+	var resourceType string
+	switch {
+	case restAPIID != "":
+		apiARN.Resource = "/restapis/" + restAPIID
+		resourceType = schemas.ApiGatewayRestApiSchema
+	case httpAPIID != "":
+		apiARN.Resource = "/apis/" + httpAPIID
+		resourceType = schemas.ApiGatewayHttpApiSchema
+	default:
+		zap.L().Warn("apigateway: missing api id", zap.String("eventName", metadata.eventName), zap.Any("detail", detail))
+		return nil
+	}
+
+	return []*resourceChange{{
+		AwsAccountID: metadata.accountID,
+		Delete:       metadata.eventName == "DeleteRestApi" || metadata.eventName == "DeleteApi",
+		EventName:    metadata.eventName,
+		ResourceID:   apiARN.String(),
+		ResourceType: resourceType,
+	}}
+}
@@ -26,6 +26,10 @@ import (
 	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 )
 
+func init() {
+	registerClassifier("cloudformation.amazonaws.com", classifyCloudFormation)
+}
+
 func classifyCloudFormation(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
 	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_awscloudformation.html
 	stackARN := arn.ARN{
@@ -0,0 +1,73 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func init() {
+	registerClassifier("cloudfront.amazonaws.com", classifyCloudFront)
+}
+
+func classifyCloudFront(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
+	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_amazoncloudfront.html
+
+	// CloudFront event names carry the API version, e.g. UpdateDistribution2020_05_31
+	eventName := metadata.eventName
+	if i := strings.IndexAny(eventName, "0123456789"); i > 0 {
+		eventName = eventName[:i]
+	}
+
+	var distributionID string
+	switch eventName {
+	case "CreateDistribution", "CreateDistributionWithTags":
+		distributionID = detail.Get("responseElements.distribution.id").Str
+	case "DeleteDistribution", "UpdateDistribution":
+		distributionID = detail.Get("requestParameters.id").Str
+	default:
+		zap.L().Info("cloudfront: encountered unknown event name", zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	if distributionID == "" {
+		zap.L().Warn("cloudfront: missing distribution id", zap.String("eventName", metadata.eventName), zap.Any("detail", detail))
+		return nil
+	}
+	distributionARN := arn.ARN{
+		Partition: "aws",
+		Service:   "cloudfront",
+		AccountID: metadata.accountID,
+		Resource:  "distribution/" + distributionID,
+	}
+
+	return []*resourceChange{{
+		AwsAccountID: metadata.accountID,
+		Delete:       eventName == "DeleteDistribution",
+		EventName:    metadata.eventName,
+		ResourceID:   distributionARN.String(),
+		ResourceType: schemas.CloudFrontDistributionSchema,
+	}}
+}
@@ -26,6 +26,10 @@ import (
 	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 )
 
+func init() {
+	registerClassifier("logs.amazonaws.com", classifyCloudWatchLogGroup)
+}
+
 func classifyCloudWatchLogGroup(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
 	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_amazoncloudwatchlogs.html
 	logGroupARN := arn.ARN{
@@ -42,6 +46,10 @@ func classifyCloudWatchLogGroup(detail gjson.Result, metadata *CloudTrailMetadat
 		// Not technically the correct resourceID, see classifyCloudFormation for a more detailed
 		// explanation.
 		logGroupARN.Resource += detail.Get("requestParameters.logGroupName").Str
+	case "DeleteResourcePolicy", "PutResourcePolicy":
+		// Resource policies are account wide, they are not attached to a log group. These event
+		// names are shared with Secrets Manager so they can't be ignored globally.
+		return nil
 	default:
 		zap.L().Info("loggroup: encountered unknown event name", zap.String("eventName", metadata.eventName))
 		return nil
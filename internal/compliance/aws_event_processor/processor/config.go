@@ -27,6 +27,10 @@ import (
 	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 )
 
+func init() {
+	registerClassifier("config.amazonaws.com", classifyConfig)
+}
+
 func classifyConfig(_ gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
 	// We need to add more config resources, just a config recorder is too high level
 	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_awsconfig.html
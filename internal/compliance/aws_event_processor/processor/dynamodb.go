@@ -26,6 +26,10 @@ import (
 	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 )
 
+func init() {
+	registerClassifier("dynamodb.amazonaws.com", classifyDynamoDB)
+}
+
 func classifyDynamoDB(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
 	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_amazondynamodb.html
 	dynamoARN := arn.ARN{
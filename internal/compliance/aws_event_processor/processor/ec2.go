@@ -28,6 +28,10 @@ import (
 	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 )
 
+func init() {
+	registerClassifier("ec2.amazonaws.com", classifyEC2)
+}
+
 func classifyEC2(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
 	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_amazonec2.html
 
@@ -228,6 +232,10 @@ func classifyEC2(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceC
 			case strings.HasPrefix(id, "vpc-"):
 				resourceType = aws.Ec2VpcSchema
 				resourceID = "vpc/" + id
+			case strings.HasPrefix(id, "tgw-") && strings.Count(id, "-") == 1:
+				// Excludes transit gateway attachments (tgw-attach-) and route tables (tgw-rtb-)
+				resourceType = aws.Ec2TransitGatewaySchema
+				resourceID = "transit-gateway/" + id
 			default:
 				zap.L().Debug("ec2: unsupported resource", zap.String("AWS resourceID", id))
 				continue
@@ -339,6 +347,34 @@ func classifyEC2(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceC
 			Region:       metadata.region,
 			ResourceType: aws.Ec2VpcSchema,
 		}}
+	case "CreateTransitGateway":
+		ec2Type = aws.Ec2TransitGatewaySchema
+		// Newer EC2 APIs log nested request and response structures
+		ec2ARN.Resource = "transit-gateway/" + detail.Get("responseElements.CreateTransitGatewayResponse.transitGateway.transitGatewayId").Str
+	case "DeleteTransitGateway", "ModifyTransitGateway":
+		ec2Type = aws.Ec2TransitGatewaySchema
+		ec2ARN.Resource = "transit-gateway/" + detail.Get("requestParameters.*.TransitGatewayId").Str
+		deleteResource = metadata.eventName == "DeleteTransitGateway"
+	case "AcceptTransitGatewayPeeringAttachment", "AcceptTransitGatewayVpcAttachment", "AssociateTransitGatewayRouteTable",
+		"CreateTransitGatewayPeeringAttachment", "CreateTransitGatewayRouteTable", "CreateTransitGatewayVpcAttachment",
+		"DeleteTransitGatewayPeeringAttachment", "DeleteTransitGatewayRouteTable", "DeleteTransitGatewayVpcAttachment",
+		"DisableTransitGatewayRouteTablePropagation", "DisassociateTransitGatewayRouteTable",
+		"EnableTransitGatewayRouteTablePropagation", "ModifyTransitGatewayVpcAttachment",
+		"RejectTransitGatewayPeeringAttachment", "RejectTransitGatewayVpcAttachment":
+		// Transit gateway sub resources. Only the create requests reference the transit gateway, the
+		// rest reference the attachment or route table and we must scan all transit gateways.
+		ec2Type = aws.Ec2TransitGatewaySchema
+		if id := detail.Get("requestParameters.*.TransitGatewayId").Str; id != "" {
+			ec2ARN.Resource = "transit-gateway/" + id
+			break
+		}
+		return []*resourceChange{{
+			AwsAccountID: ec2ARN.AccountID,
+			Delete:       false,
+			EventName:    metadata.eventName,
+			Region:       metadata.region,
+			ResourceType: ec2Type,
+		}}
 	case "DeleteSnapshot", "ModifySnapshotAttribute":
 		// Volume sub resources
 		return []*resourceChange{{
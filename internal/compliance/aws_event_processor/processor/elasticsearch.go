@@ -0,0 +1,80 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func init() {
+	registerClassifier("es.amazonaws.com", classifyElasticsearch)
+}
+
+func classifyElasticsearch(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
+	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_amazonelasticsearchservice.html
+	//
+	// OpenSearch domains are managed through the same event source, with the new event names.
+	domainARN := arn.ARN{
+		Partition: "aws",
+		Service:   "es",
+		Region:    metadata.region,
+		AccountID: metadata.accountID,
+	}
+	switch metadata.eventName {
+	case "CancelElasticsearchServiceSoftwareUpdate",
+		"CancelServiceSoftwareUpdate",
+		"CreateDomain",
+		"CreateElasticsearchDomain",
+		"DeleteDomain",
+		"DeleteElasticsearchDomain",
+		"StartElasticsearchServiceSoftwareUpdate",
+		"StartServiceSoftwareUpdate",
+		"UpdateDomainConfig",
+		"UpdateElasticsearchDomainConfig",
+		"UpgradeDomain",
+		"UpgradeElasticsearchDomain":
+		if name := detail.Get("requestParameters.domainName").Str; name != "" {
+			domainARN.Resource = "domain/" + name
+		}
+	case "AddTags", "RemoveTags":
+		if parsed, err := arn.Parse(detail.Get("requestParameters.aRN").Str); err == nil {
+			domainARN = parsed
+		}
+	default:
+		zap.L().Info("es: encountered unknown event name", zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	if domainARN.Resource == "" {
+		zap.L().Warn("es: missing domain", zap.String("eventName", metadata.eventName), zap.Any("detail", detail))
+		return nil
+	}
+
+	return []*resourceChange{{
+		AwsAccountID: metadata.accountID,
+		Delete:       metadata.eventName == "DeleteElasticsearchDomain" || metadata.eventName == "DeleteDomain",
+		EventName:    metadata.eventName,
+		ResourceID:   domainARN.String(),
+		ResourceType: schemas.ElasticsearchDomainSchema,
+	}}
+}
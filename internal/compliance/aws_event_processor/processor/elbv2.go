@@ -28,6 +28,10 @@ import (
 	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 )
 
+func init() {
+	registerClassifier("elasticloadbalancing.amazonaws.com", classifyELBV2)
+}
+
 func classifyELBV2(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
 	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_elasticloadbalancingv2.html
 	var parseErr error
@@ -27,6 +27,10 @@ import (
 	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 )
 
+func init() {
+	registerClassifier("guardduty.amazonaws.com", classifyGuardDuty)
+}
+
 func classifyGuardDuty(_ gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
 	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_amazonguardduty.html
 	switch metadata.eventName {
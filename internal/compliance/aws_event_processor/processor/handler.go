@@ -20,9 +20,12 @@ package processor
 
 import (
 	"bufio"
+	"bytes"
 	"compress/gzip"
 	"io"
+	"io/ioutil"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-lambda-go/events"
@@ -38,6 +41,7 @@ import (
 
 	api "github.com/panther-labs/panther/api/lambda/resources/models"
 	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue/glueparquet"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/sources"
 	"github.com/panther-labs/panther/internal/log_analysis/notify"
 	"github.com/panther-labs/panther/pkg/awsbatch/sqsbatch"
@@ -173,6 +177,7 @@ func handleCloudTrail(cloudtrail gjson.Result, changes map[string]*resourceChang
 
 // handleS3Download processes an s3 Notification from the log analysis pipeline by downloading
 // the already processed CloudTrail logs and sending them to the CloudTrail classifier.
+// The processed logs are either gzipped JSON lines or Parquet files, depending on the data format of the data lake.
 //
 // Because this data has already been pre-processed, we assume it is in the correct format and return all errors.
 func handleS3Download(object *sources.S3ObjectInfo, changes map[string]*resourceChange) error {
@@ -183,6 +188,26 @@ func handleS3Download(object *sources.S3ObjectInfo, changes map[string]*resource
 	if err != nil {
 		return errors.Wrap(err, "error reading CloudTrail from S3")
 	}
+	defer logs.Body.Close()
+
+	if strings.HasSuffix(object.S3ObjectKey, ".parquet") {
+		// Parquet files need random access to read their metadata at the end of the file
+		data, err := ioutil.ReadAll(logs.Body)
+		if err != nil {
+			return errors.Wrap(err, "error reading Parquet file from s3")
+		}
+		reader, err := glueparquet.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return errors.Wrap(err, "error creating parquet reader for S3 output")
+		}
+		defer reader.Close()
+		for line := reader.Next(); line != nil; line = reader.Next() {
+			if err := handleProcessedLog(gjson.ParseBytes(line), changes); err != nil {
+				return err
+			}
+		}
+		return reader.Err()
+	}
 
 	reader, err := gzip.NewReader(bufio.NewReader(logs.Body))
 	if err != nil {
@@ -203,33 +228,34 @@ func handleS3Download(object *sources.S3ObjectInfo, changes map[string]*resource
 			break
 		}
 
-		// Parse the line and determine if we should continue
-		detail := gjson.Parse(line)
-		var metadata *CloudTrailMetadata
-		metadata, err = preprocessCloudTrailLog(detail)
-		if err != nil {
-			return err
-		}
-		if metadata == nil {
-			continue
-		}
-		if checkCWECache(generateSourceKey(metadata)) {
-			// If we're currently seeing CloudTrail via CWE, we don't process the duplicate data in S3
-			zap.L().Debug(
-				"skipping s3 notification in favor of CloudTrail via CWE",
-				zap.String("region", metadata.region),
-				zap.String("accountID", metadata.accountID),
-			)
-			continue
-		}
-
-		// Process the line
-		err = processCloudTrailLog(detail, metadata, changes)
+		err = handleProcessedLog(gjson.Parse(line), changes)
 	}
 
 	return err
 }
 
+// handleProcessedLog determines what scans if any need to be made as a result of a processed CloudTrail log
+func handleProcessedLog(detail gjson.Result, changes map[string]*resourceChange) error {
+	metadata, err := preprocessCloudTrailLog(detail)
+	if err != nil {
+		return err
+	}
+	if metadata == nil {
+		return nil
+	}
+	if checkCWECache(generateSourceKey(metadata)) {
+		// If we're currently seeing CloudTrail via CWE, we don't process the duplicate data in S3
+		zap.L().Debug(
+			"skipping s3 notification in favor of CloudTrail via CWE",
+			zap.String("region", metadata.region),
+			zap.String("accountID", metadata.accountID),
+		)
+		return nil
+	}
+
+	return processCloudTrailLog(detail, metadata, changes)
+}
+
 // generateSourceKey creates the key used for the cweAccounts cache for a given CloudTrail metadata struct
 func generateSourceKey(metadata *CloudTrailMetadata) string {
 	return metadata.accountID + "/" + metadata.region
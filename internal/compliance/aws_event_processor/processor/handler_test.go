@@ -41,6 +41,9 @@ import (
 
 	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue/glueparquet"
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue/glueschema"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/sources"
 	"github.com/panther-labs/panther/pkg/testutils"
 )
 
@@ -369,3 +372,40 @@ func TestHandleUpdate(t *testing.T) {
 	assert.Equal(t, 1, len(logs.FilterMessage("starting sqsbatch.SendMessageBatch").AllUntimed()))
 	assert.Equal(t, 1, len(logs.FilterMessage("invoking sqs.SendMessageBatch").AllUntimed()))
 }
+
+func TestLogProcessorCloudTrailParquet(t *testing.T) {
+	mockS3 := testutils.S3Mock{}
+	s3Client = &mockS3
+
+	var dataBuf bytes.Buffer
+	w, err := glueparquet.NewWriter(&dataBuf, []glueschema.Column{
+		{Name: "eventName", Type: glueschema.TypeString},
+		{Name: "eventSource", Type: glueschema.TypeString},
+		{Name: "eventTime", Type: glueschema.TypeTimestamp},
+		{Name: "awsRegion", Type: glueschema.TypeString},
+		{Name: "recipientAccountId", Type: glueschema.TypeString},
+		{Name: "userIdentity", Type: "struct<accountId:string>"},
+		{Name: "requestParameters", Type: glueschema.TypeString},
+	})
+	require.NoError(t, err)
+	require.NoError(t, w.WriteJSON([]byte(`{"eventName":"DeleteBucket","eventSource":"s3.amazonaws.com",`+
+		`"eventTime":"2020-03-28 21:54:30.000000000","awsRegion":"us-west-2","recipientAccountId":"888888888888",`+
+		`"userIdentity":{"accountId":"888888888888"},"requestParameters":{"bucketName":"panther-test"}}`)))
+	require.NoError(t, w.Close())
+	mockS3.On("GetObject", mock.Anything).Return(&s3.GetObjectOutput{
+		Body: ioutil.NopCloser(&dataBuf),
+	}, nil).Once()
+
+	changes := make(map[string]*resourceChange)
+	err = handleS3Download(&sources.S3ObjectInfo{
+		S3Bucket:    "processed",
+		S3ObjectKey: "logs/aws_cloudtrail/year=2020/month=03/day=28/hour=21/20200328T215430Z-uuid4.parquet",
+	}, changes)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	for _, change := range changes {
+		assert.Equal(t, "arn:aws:s3:::panther-test", change.ResourceID)
+		assert.True(t, change.Delete)
+	}
+	mockS3.AssertExpectations(t)
+}
@@ -26,6 +26,10 @@ import (
 	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 )
 
+func init() {
+	registerClassifier("iam.amazonaws.com", classifyIAM)
+}
+
 const rootUserName = "AWS ROOT USER"
 
 func classifyIAM(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
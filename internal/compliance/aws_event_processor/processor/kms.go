@@ -28,6 +28,10 @@ import (
 	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 )
 
+func init() {
+	registerClassifier("kms.amazonaws.com", classifyKMS)
+}
+
 func classifyKMS(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
 	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_awskeymanagementservice.html
 	var keyARN string
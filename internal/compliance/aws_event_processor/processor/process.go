@@ -24,6 +24,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/tidwall/gjson"
 	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
 )
 
 // CloudWatch events which require downstream processing are summarized with this struct.
@@ -39,30 +41,25 @@ type resourceChange struct {
 	ResourceType  string `json:"resourceType"`  // e.g. "AWS.S3.Bucket"
 }
 
-// Map each event source to the appropriate classifier function.
-//
-// The "classifier" takes a cloudtrail log and summarizes the required change.
+// classifier takes a CloudTrail log and summarizes the required change.
 // integrationID does not need to be set by the individual classifiers.
+type classifier func(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange
+
+// registerClassifier attaches the classifier of an event source to the resource types of the event source
+// in the resource type registry.
+func registerClassifier(eventSource string, fn classifier) {
+	resourcetypes.RegisterClassifier(eventSource, fn)
+}
+
 var (
-	classifiers = map[string]func(gjson.Result, *CloudTrailMetadata) []*resourceChange{
-		"acm.amazonaws.com":                  classifyACM,
-		"cloudformation.amazonaws.com":       classifyCloudFormation,
-		"cloudtrail.amazonaws.com":           classifyCloudTrail,
-		"config.amazonaws.com":               classifyConfig,
-		"dynamodb.amazonaws.com":             classifyDynamoDB,
-		"ec2.amazonaws.com":                  classifyEC2,
-		"ecs.amazonaws.com":                  classifyECS,
-		"elasticloadbalancing.amazonaws.com": classifyELBV2,
-		"guardduty.amazonaws.com":            classifyGuardDuty,
-		"iam.amazonaws.com":                  classifyIAM,
-		"kms.amazonaws.com":                  classifyKMS,
-		"lambda.amazonaws.com":               classifyLambda,
-		"logs.amazonaws.com":                 classifyCloudWatchLogGroup,
-		"rds.amazonaws.com":                  classifyRDS,
-		"redshift.amazonaws.com":             classifyRedshift,
-		"s3.amazonaws.com":                   classifyS3,
-		"waf.amazonaws.com":                  classifyWAF,
-		"waf-regional.amazonaws.com":         classifyWAFRegional,
+	// lookupClassifier returns the classifier of an event source from the resource type registry.
+	// Set as a variable to be overridden in testing.
+	lookupClassifier = func(eventSource string) (classifier, bool) {
+		fn, ok := resourcetypes.LookupClassifier(eventSource)
+		if !ok {
+			return nil, false
+		}
+		return fn.(classifier), true
 	}
 
 	// Events to ignore in the services we support
@@ -93,7 +90,6 @@ var (
 		"PutDestination":       {},
 		"PutDestinationPolicy": {},
 		"PutLogEvents":         {},
-		"PutResourcePolicy":    {},
 		"StartQuery":           {},
 		"StopQuery":            {},
 		"TestMetricFilter":     {},
@@ -147,6 +143,17 @@ var (
 		"SharedSnapshotCopyInitiated": {},
 		"SharedSnapshotVolumeCreated": {},
 
+		// ecr
+		"BatchCheckLayerAvailability": {},
+		"BatchDeleteImage":            {},
+		"BatchGetImage":               {},
+		"CompleteLayerUpload":         {},
+		"InitiateLayerUpload":         {},
+		"PutImage":                    {},
+		"StartImageScan":              {},
+		"StartLifecyclePolicyPreview": {},
+		"UploadLayerPart":             {},
+
 		// ecs
 		"DeleteAccountSetting":     {},
 		"DeregisterTaskDefinition": {},
@@ -197,6 +204,36 @@ var (
 		"DeleteVirtualMFADevice":         {}, // users. See (Enable/Disable)MFADevice for that.
 		"CreateInstanceProfile":          {},
 
+		// secretsmanager
+		"ValidateResourcePolicy": {},
+
+		// sns
+		"Publish":                   {},
+		"PublishBatch":              {},
+		"SetSubscriptionAttributes": {},
+
+		// sqs
+		"ChangeMessageVisibility":      {},
+		"ChangeMessageVisibilityBatch": {},
+		"DeleteMessage":                {},
+		"DeleteMessageBatch":           {},
+		"PurgeQueue":                   {},
+		"ReceiveMessage":               {},
+		"SendMessage":                  {},
+		"SendMessageBatch":             {},
+
+		// ssm
+		"CancelCommand":                   {},
+		"PutComplianceItems":              {},
+		"PutInventory":                    {},
+		"ResumeSession":                   {},
+		"SendCommand":                     {},
+		"StartAutomationExecution":        {},
+		"StartSession":                    {},
+		"TerminateSession":                {},
+		"UpdateInstanceAssociationStatus": {},
+		"UpdateInstanceInformation":       {},
+
 		// kms
 		"CreateGrant":                     {},
 		"Decrypt":                         {},
@@ -330,7 +367,7 @@ func preprocessCloudTrailLog(detail gjson.Result) (*CloudTrailMetadata, error) {
 	}
 
 	// Check if the service is supported
-	if _, ok := classifiers[eventSource.Str]; !ok {
+	if _, ok := lookupClassifier(eventSource.Str); !ok {
 		zap.L().Debug("ignoring event from unsupported source",
 			zap.String("eventSource", eventSource.Str),
 			zap.String("eventName", eventName.Str))
@@ -387,7 +424,7 @@ func processCloudTrailLog(detail gjson.Result, metadata *CloudTrailMetadata, cha
 	}
 
 	// Determine the AWS service the modified resource belongs to
-	classifier := classifiers[metadata.eventSource]
+	classify, _ := lookupClassifier(metadata.eventSource)
 
 	// Drop failed events, as they do not result in a resource change
 	if errorCode := detail.Get("errorCode").Str; errorCode != "" {
@@ -399,7 +436,7 @@ func processCloudTrailLog(detail gjson.Result, metadata *CloudTrailMetadata, cha
 	}
 
 	// Process the body
-	newChanges := classifier(detail, metadata)
+	newChanges := classify(detail, metadata)
 	eventTime := detail.Get("eventTime").Str
 	if len(newChanges) > 0 {
 		readOnly := detail.Get("readOnly")
@@ -414,6 +451,13 @@ func processCloudTrailLog(detail gjson.Result, metadata *CloudTrailMetadata, cha
 
 	// One event could require multiple scans (e.g. a new VPC peering connection between two VPCs)
 	for _, change := range newChanges {
+		if _, ok := resourcetypes.Lookup(change.ResourceType); !ok {
+			zap.L().Error("dropping change for unregistered resource type",
+				zap.String("eventSource", metadata.eventSource),
+				zap.String("eventName", metadata.eventName),
+				zap.String("resourceType", change.ResourceType))
+			continue
+		}
 		change.EventTime = eventTime
 		change.IntegrationID = integration.IntegrationID
 		zap.L().Info("resource scan required", zap.Any("changeDetail", change))
@@ -29,6 +29,7 @@ import (
 	"go.uber.org/zap/zaptest/observer"
 
 	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
 )
 
 var (
@@ -262,3 +263,348 @@ func TestProcessCloudTrail(t *testing.T) {
 	assert.Equal(t, expected, changeResults[expected.ResourceID+expected.ResourceType+expected.Region])
 	assert.Equal(t, expectedLogs, logs.AllUntimed())
 }
+
+// test the classifiers of services with a single resource type
+func TestClassifyCloudTrailResourceChanges(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Event  string
+		Expect []*resourceChange
+	}
+	event := func(source, name, fields string) string {
+		return `{
+"eventSource": "` + source + `",
+"eventName": "` + name + `",
+"awsRegion": "us-west-2",
+"userIdentity": { "accountId" : "111111111111" },
+` + fields + `
+}`
+	}
+	change := func(name, resourceType, resourceID string, del bool) *resourceChange {
+		return &resourceChange{
+			AwsAccountID: "111111111111",
+			Delete:       del,
+			EventName:    name,
+			ResourceID:   resourceID,
+			ResourceType: resourceType,
+		}
+	}
+	for _, tc := range []testCase{
+		{
+			Name:  "SQS CreateQueue",
+			Event: event("sqs.amazonaws.com", "CreateQueue", `"responseElements": {"queueUrl": "https://sqs.us-west-2.amazonaws.com/111111111111/queue"}`),
+			Expect: []*resourceChange{
+				change("CreateQueue", schemas.SqsQueueSchema, "arn:aws:sqs:us-west-2:111111111111:queue", false),
+			},
+		},
+		{
+			Name:  "SQS DeleteQueue",
+			Event: event("sqs.amazonaws.com", "DeleteQueue", `"requestParameters": {"queueUrl": "https://sqs.us-west-2.amazonaws.com/111111111111/queue"}`),
+			Expect: []*resourceChange{
+				change("DeleteQueue", schemas.SqsQueueSchema, "arn:aws:sqs:us-west-2:111111111111:queue", true),
+			},
+		},
+		{
+			Name:   "SQS invalid URL",
+			Event:  event("sqs.amazonaws.com", "SetQueueAttributes", `"requestParameters": {"queueUrl": "queue"}`),
+			Expect: nil,
+		},
+		{
+			Name:  "SNS Unsubscribe",
+			Event: event("sns.amazonaws.com", "Unsubscribe", `"requestParameters": {"subscriptionArn": "arn:aws:sns:us-west-2:111111111111:topic:2e1b3c5a"}`),
+			Expect: []*resourceChange{
+				change("Unsubscribe", schemas.SnsTopicSchema, "arn:aws:sns:us-west-2:111111111111:topic", false),
+			},
+		},
+		{
+			Name:  "SNS DeleteTopic",
+			Event: event("sns.amazonaws.com", "DeleteTopic", `"requestParameters": {"topicArn": "arn:aws:sns:us-west-2:111111111111:topic"}`),
+			Expect: []*resourceChange{
+				change("DeleteTopic", schemas.SnsTopicSchema, "arn:aws:sns:us-west-2:111111111111:topic", true),
+			},
+		},
+		{
+			Name: "Secrets Manager UpdateSecret",
+			Event: event("secretsmanager.amazonaws.com", "UpdateSecret",
+				`"requestParameters": {"secretId": "secret"}, "responseElements": {"aRN": "arn:aws:secretsmanager:us-west-2:111111111111:secret:secret-AbCdEf"}`),
+			Expect: []*resourceChange{
+				change("UpdateSecret", schemas.SecretsManagerSecretSchema, "arn:aws:secretsmanager:us-west-2:111111111111:secret:secret-AbCdEf", false),
+			},
+		},
+		{
+			Name: "Secrets Manager scheduled deletion",
+			Event: event("secretsmanager.amazonaws.com", "DeleteSecret",
+				`"requestParameters": {"secretId": "secret"}, "responseElements": {"aRN": "arn:aws:secretsmanager:us-west-2:111111111111:secret:secret-AbCdEf"}`),
+			Expect: []*resourceChange{
+				change("DeleteSecret", schemas.SecretsManagerSecretSchema, "arn:aws:secretsmanager:us-west-2:111111111111:secret:secret-AbCdEf", false),
+			},
+		},
+		{
+			Name: "Secrets Manager forced deletion",
+			Event: event("secretsmanager.amazonaws.com", "DeleteSecret",
+				`"requestParameters": {"secretId": "secret", "forceDeleteWithoutRecovery": true}, "responseElements": {"aRN": "arn:aws:secretsmanager:us-west-2:111111111111:secret:secret-AbCdEf"}`),
+			Expect: []*resourceChange{
+				change("DeleteSecret", schemas.SecretsManagerSecretSchema, "arn:aws:secretsmanager:us-west-2:111111111111:secret:secret-AbCdEf", true),
+			},
+		},
+		{
+			Name:  "Secrets Manager missing response",
+			Event: event("secretsmanager.amazonaws.com", "PutResourcePolicy", `"requestParameters": {"secretId": "secret"}`),
+			Expect: []*resourceChange{
+				{
+					AwsAccountID: "111111111111",
+					EventName:    "PutResourcePolicy",
+					Region:       "us-west-2",
+					ResourceType: schemas.SecretsManagerSecretSchema,
+				},
+			},
+		},
+		{
+			Name:  "SSM PutParameter",
+			Event: event("ssm.amazonaws.com", "PutParameter", `"requestParameters": {"name": "/path/to/parameter", "type": "SecureString"}`),
+			Expect: []*resourceChange{
+				change("PutParameter", schemas.SsmParameterSchema, "arn:aws:ssm:us-west-2:111111111111:parameter/path/to/parameter", false),
+			},
+		},
+		{
+			Name:  "SSM DeleteParameters",
+			Event: event("ssm.amazonaws.com", "DeleteParameters", `"requestParameters": {"names": ["a", "/b"]}`),
+			Expect: []*resourceChange{
+				change("DeleteParameters", schemas.SsmParameterSchema, "arn:aws:ssm:us-west-2:111111111111:parameter/a", true),
+				change("DeleteParameters", schemas.SsmParameterSchema, "arn:aws:ssm:us-west-2:111111111111:parameter/b", true),
+			},
+		},
+		{
+			Name:   "SSM tags of other resources",
+			Event:  event("ssm.amazonaws.com", "AddTagsToResource", `"requestParameters": {"resourceType": "Document", "resourceId": "document"}`),
+			Expect: nil,
+		},
+		{
+			Name:  "ECR PutImageTagMutability",
+			Event: event("ecr.amazonaws.com", "PutImageTagMutability", `"requestParameters": {"repositoryName": "path/to/repository", "imageTagMutability": "IMMUTABLE"}`),
+			Expect: []*resourceChange{
+				change("PutImageTagMutability", schemas.EcrRepositorySchema, "arn:aws:ecr:us-west-2:111111111111:repository/path/to/repository", false),
+			},
+		},
+		{
+			Name:  "ECR DeleteRepository",
+			Event: event("ecr.amazonaws.com", "DeleteRepository", `"requestParameters": {"repositoryName": "repository", "force": true}`),
+			Expect: []*resourceChange{
+				change("DeleteRepository", schemas.EcrRepositorySchema, "arn:aws:ecr:us-west-2:111111111111:repository/repository", true),
+			},
+		},
+		{
+			Name: "CloudFront CreateDistributionWithTags",
+			Event: event("cloudfront.amazonaws.com", "CreateDistributionWithTags2020_05_31",
+				`"responseElements": {"distribution": {"id": "EDFDVBD6EXAMPLE"}}`),
+			Expect: []*resourceChange{
+				change("CreateDistributionWithTags2020_05_31", schemas.CloudFrontDistributionSchema,
+					"arn:aws:cloudfront::111111111111:distribution/EDFDVBD6EXAMPLE", false),
+			},
+		},
+		{
+			Name:  "CloudFront DeleteDistribution",
+			Event: event("cloudfront.amazonaws.com", "DeleteDistribution2020_05_31", `"requestParameters": {"id": "EDFDVBD6EXAMPLE"}`),
+			Expect: []*resourceChange{
+				change("DeleteDistribution2020_05_31", schemas.CloudFrontDistributionSchema,
+					"arn:aws:cloudfront::111111111111:distribution/EDFDVBD6EXAMPLE", true),
+			},
+		},
+		{
+			Name:  "API Gateway CreateRestApi",
+			Event: event("apigateway.amazonaws.com", "CreateRestApi", `"responseElements": {"id": "a1b2c3d4e5"}`),
+			Expect: []*resourceChange{
+				change("CreateRestApi", schemas.ApiGatewayRestApiSchema, "arn:aws:apigateway:us-west-2::/restapis/a1b2c3d4e5", false),
+			},
+		},
+		{
+			Name:  "API Gateway UpdateStage of a REST API",
+			Event: event("apigateway.amazonaws.com", "UpdateStage", `"requestParameters": {"restApiId": "a1b2c3d4e5", "stageName": "prod"}`),
+			Expect: []*resourceChange{
+				change("UpdateStage", schemas.ApiGatewayRestApiSchema, "arn:aws:apigateway:us-west-2::/restapis/a1b2c3d4e5", false),
+			},
+		},
+		{
+			Name:  "API Gateway UpdateStage of an HTTP API",
+			Event: event("apigateway.amazonaws.com", "UpdateStage", `"requestParameters": {"apiId": "k1l2m3n4o5", "stageName": "$default"}`),
+			Expect: []*resourceChange{
+				change("UpdateStage", schemas.ApiGatewayHttpApiSchema, "arn:aws:apigateway:us-west-2::/apis/k1l2m3n4o5", false),
+			},
+		},
+		{
+			Name:  "API Gateway DeleteApi",
+			Event: event("apigateway.amazonaws.com", "DeleteApi", `"requestParameters": {"apiId": "k1l2m3n4o5"}`),
+			Expect: []*resourceChange{
+				change("DeleteApi", schemas.ApiGatewayHttpApiSchema, "arn:aws:apigateway:us-west-2::/apis/k1l2m3n4o5", true),
+			},
+		},
+		{
+			Name:  "Route53 CreateHostedZone",
+			Event: event("route53.amazonaws.com", "CreateHostedZone", `"responseElements": {"hostedZone": {"id": "/hostedzone/Z1D633PJN98FT9"}}`),
+			Expect: []*resourceChange{
+				change("CreateHostedZone", schemas.Route53HostedZoneSchema, "arn:aws:route53:::hostedzone/Z1D633PJN98FT9", false),
+			},
+		},
+		{
+			Name:  "Route53 ChangeResourceRecordSets",
+			Event: event("route53.amazonaws.com", "ChangeResourceRecordSets", `"requestParameters": {"hostedZoneId": "Z1D633PJN98FT9"}`),
+			Expect: []*resourceChange{
+				change("ChangeResourceRecordSets", schemas.Route53HostedZoneSchema, "arn:aws:route53:::hostedzone/Z1D633PJN98FT9", false),
+			},
+		},
+		{
+			Name:  "Route53 DeleteHostedZone",
+			Event: event("route53.amazonaws.com", "DeleteHostedZone", `"requestParameters": {"id": "Z1D633PJN98FT9"}`),
+			Expect: []*resourceChange{
+				change("DeleteHostedZone", schemas.Route53HostedZoneSchema, "arn:aws:route53:::hostedzone/Z1D633PJN98FT9", true),
+			},
+		},
+		{
+			Name:   "Route53 tags of other resources",
+			Event:  event("route53.amazonaws.com", "ChangeTagsForResource", `"requestParameters": {"resourceType": "healthcheck", "resourceId": "check"}`),
+			Expect: nil,
+		},
+		{
+			Name:  "EFS PutFileSystemPolicy",
+			Event: event("elasticfilesystem.amazonaws.com", "PutFileSystemPolicy", `"requestParameters": {"fileSystemId": "fs-01234567"}`),
+			Expect: []*resourceChange{
+				change("PutFileSystemPolicy", schemas.EfsFileSystemSchema, "arn:aws:elasticfilesystem:us-west-2:111111111111:file-system/fs-01234567", false),
+			},
+		},
+		{
+			Name:  "EFS DeleteMountTarget",
+			Event: event("elasticfilesystem.amazonaws.com", "DeleteMountTarget", `"requestParameters": {"mountTargetId": "fsmt-12340abc"}`),
+			Expect: []*resourceChange{
+				{
+					AwsAccountID: "111111111111",
+					EventName:    "DeleteMountTarget",
+					Region:       "us-west-2",
+					ResourceType: schemas.EfsFileSystemSchema,
+				},
+			},
+		},
+		{
+			Name:  "Elasticsearch UpdateElasticsearchDomainConfig",
+			Event: event("es.amazonaws.com", "UpdateElasticsearchDomainConfig", `"requestParameters": {"domainName": "domain"}`),
+			Expect: []*resourceChange{
+				change("UpdateElasticsearchDomainConfig", schemas.ElasticsearchDomainSchema, "arn:aws:es:us-west-2:111111111111:domain/domain", false),
+			},
+		},
+		{
+			Name:  "OpenSearch DeleteDomain",
+			Event: event("es.amazonaws.com", "DeleteDomain", `"requestParameters": {"domainName": "domain"}`),
+			Expect: []*resourceChange{
+				change("DeleteDomain", schemas.ElasticsearchDomainSchema, "arn:aws:es:us-west-2:111111111111:domain/domain", true),
+			},
+		},
+		{
+			Name:  "Elasticsearch AddTags",
+			Event: event("es.amazonaws.com", "AddTags", `"requestParameters": {"aRN": "arn:aws:es:us-west-2:111111111111:domain/domain"}`),
+			Expect: []*resourceChange{
+				change("AddTags", schemas.ElasticsearchDomainSchema, "arn:aws:es:us-west-2:111111111111:domain/domain", false),
+			},
+		},
+		{
+			Name: "EC2 CreateTransitGateway",
+			Event: event("ec2.amazonaws.com", "CreateTransitGateway",
+				`"responseElements": {"CreateTransitGatewayResponse": {"transitGateway": {"transitGatewayId": "tgw-0262a0e521EXAMPLE"}}}`),
+			Expect: []*resourceChange{
+				change("CreateTransitGateway", schemas.Ec2TransitGatewaySchema, "arn:aws:ec2:us-west-2:111111111111:transit-gateway/tgw-0262a0e521EXAMPLE", false),
+			},
+		},
+		{
+			Name: "EC2 DeleteTransitGateway",
+			Event: event("ec2.amazonaws.com", "DeleteTransitGateway",
+				`"requestParameters": {"DeleteTransitGatewayRequest": {"TransitGatewayId": "tgw-0262a0e521EXAMPLE"}}`),
+			Expect: []*resourceChange{
+				change("DeleteTransitGateway", schemas.Ec2TransitGatewaySchema, "arn:aws:ec2:us-west-2:111111111111:transit-gateway/tgw-0262a0e521EXAMPLE", true),
+			},
+		},
+		{
+			Name: "EC2 CreateTransitGatewayVpcAttachment",
+			Event: event("ec2.amazonaws.com", "CreateTransitGatewayVpcAttachment",
+				`"requestParameters": {"CreateTransitGatewayVpcAttachmentRequest": {"TransitGatewayId": "tgw-0262a0e521EXAMPLE", "VpcId": "vpc-6253a3f2"}}`),
+			Expect: []*resourceChange{
+				change("CreateTransitGatewayVpcAttachment", schemas.Ec2TransitGatewaySchema,
+					"arn:aws:ec2:us-west-2:111111111111:transit-gateway/tgw-0262a0e521EXAMPLE", false),
+			},
+		},
+		{
+			Name: "EC2 DeleteTransitGatewayVpcAttachment",
+			Event: event("ec2.amazonaws.com", "DeleteTransitGatewayVpcAttachment",
+				`"requestParameters": {"DeleteTransitGatewayVpcAttachmentRequest": {"TransitGatewayAttachmentId": "tgw-attach-0d2c54bdbEXAMPLE"}}`),
+			Expect: []*resourceChange{
+				{
+					AwsAccountID: "111111111111",
+					EventName:    "DeleteTransitGatewayVpcAttachment",
+					Region:       "us-west-2",
+					ResourceType: schemas.Ec2TransitGatewaySchema,
+				},
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			detail := gjson.Parse(tc.Event)
+			metadata, err := preprocessCloudTrailLog(detail)
+			require.NoError(t, err)
+			require.NotNil(t, metadata)
+			classify, ok := lookupClassifier(metadata.eventSource)
+			require.True(t, ok)
+			actual := classify(detail, metadata)
+			assert.Equal(t, tc.Expect, actual)
+			for _, change := range actual {
+				info, ok := resourcetypes.Lookup(change.ResourceType)
+				require.True(t, ok, "unregistered resource type %s", change.ResourceType)
+				assert.Contains(t, info.EventSources, metadata.eventSource)
+			}
+		})
+	}
+}
+
+// drop data plane events of the messaging, parameter and registry services
+func TestPreProcessIgnoredDataEvents(t *testing.T) {
+	for _, eventName := range []string{"SendMessage", "Publish", "PutImage", "UpdateInstanceInformation"} {
+		metadata, err := preprocessCloudTrailLog(gjson.Parse(`{"eventName": "` + eventName + `"}`))
+		require.NoError(t, err)
+		assert.Nil(t, metadata, eventName)
+	}
+}
+
+// every event source of the resource type registry must have a classifier
+func TestClassifiersMatchRegistry(t *testing.T) {
+	for _, info := range resourcetypes.RegisteredFor(resourcetypes.IntegrationTypeAWS) {
+		for _, eventSource := range info.EventSources {
+			_, ok := lookupClassifier(eventSource)
+			assert.True(t, ok, "missing classifier for event source %s", eventSource)
+			assert.NotNil(t, info.Classifier, "missing classifier for resource type %s", info.Name)
+		}
+	}
+}
+
+func TestProcessCloudTrailLogDropsUnregisteredResourceType(t *testing.T) {
+	accounts = exampleAccounts
+	metadata := &CloudTrailMetadata{
+		region:      "us-west-2",
+		accountID:   "888888888888",
+		eventName:   "CreateThing",
+		eventSource: "acm.amazonaws.com",
+	}
+	original := lookupClassifier
+	defer func() { lookupClassifier = original }()
+	lookupClassifier = func(string) (classifier, bool) {
+		return func(gjson.Result, *CloudTrailMetadata) []*resourceChange {
+			return []*resourceChange{
+				{AwsAccountID: "888888888888", ResourceID: "arn:aws:acm:us-west-2:888888888888:thing/1", ResourceType: "AWS.ACM.Thing"},
+				{AwsAccountID: "888888888888", ResourceID: "arn:aws:acm:us-west-2:888888888888:certificate/1", ResourceType: schemas.AcmCertificateSchema},
+			}
+		}, true
+	}
+
+	changes := exampleChanges()
+	require.NoError(t, processCloudTrailLog(gjson.Parse(`{"eventTime": "2019-08-01T04:43:00Z"}`), metadata, changes))
+	require.Len(t, changes, 1)
+	for _, change := range changes {
+		assert.Equal(t, schemas.AcmCertificateSchema, change.ResourceType)
+	}
+}
@@ -28,6 +28,10 @@ import (
 	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 )
 
+func init() {
+	registerClassifier("rds.amazonaws.com", classifyRDS)
+}
+
 func classifyRDS(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
 	if strings.HasSuffix(metadata.eventName, "DBCluster") || // 9 APIs
 		strings.HasSuffix(metadata.eventName, "ParameterGroup") || // 10 APIs
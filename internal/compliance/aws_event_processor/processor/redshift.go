@@ -28,6 +28,10 @@ import (
 	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 )
 
+func init() {
+	registerClassifier("redshift.amazonaws.com", classifyRedshift)
+}
+
 func classifyRedshift(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
 	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_amazonredshift.html
 	redshiftARN := arn.ARN{
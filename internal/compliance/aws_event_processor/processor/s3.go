@@ -26,6 +26,10 @@ import (
 	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 )
 
+func init() {
+	registerClassifier("s3.amazonaws.com", classifyS3)
+}
+
 func classifyS3(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
 	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_amazons3.html
 	bucketName := detail.Get("requestParameters.bucketName").Str
@@ -0,0 +1,79 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func init() {
+	registerClassifier("secretsmanager.amazonaws.com", classifySecretsManager)
+}
+
+func classifySecretsManager(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
+	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_awssecretsmanager.html
+	switch metadata.eventName {
+	case "CancelRotateSecret",
+		"CreateSecret",
+		"DeleteResourcePolicy",
+		"DeleteSecret",
+		"PutResourcePolicy",
+		"PutSecretValue",
+		"RestoreSecret",
+		"RotateSecret",
+		"UpdateSecret",
+		"UpdateSecretVersionStage":
+	default:
+		zap.L().Info("secretsmanager: encountered unknown event name", zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	// A secret scheduled for deletion can still be restored, so it is only deleted when forced
+	deleted := metadata.eventName == "DeleteSecret" &&
+		detail.Get("requestParameters.forceDeleteWithoutRecovery").Bool()
+
+	// The full ARN of the secret is in the response. The secretId in the request can be the name or a
+	// partial ARN of the secret, which we can't use as the resource ID.
+	secretARN := detail.Get("responseElements.aRN").Str
+	if _, err := arn.Parse(secretARN); err != nil {
+		if deleted {
+			zap.L().Warn("secretsmanager: missing arn", zap.String("eventName", metadata.eventName))
+			return nil
+		}
+		return []*resourceChange{{
+			AwsAccountID: metadata.accountID,
+			Delete:       false,
+			EventName:    metadata.eventName,
+			Region:       metadata.region,
+			ResourceType: schemas.SecretsManagerSecretSchema,
+		}}
+	}
+
+	return []*resourceChange{{
+		AwsAccountID: metadata.accountID,
+		Delete:       deleted,
+		EventName:    metadata.eventName,
+		ResourceID:   secretARN,
+		ResourceType: schemas.SecretsManagerSecretSchema,
+	}}
+}
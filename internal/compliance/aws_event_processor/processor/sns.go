@@ -0,0 +1,70 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func init() {
+	registerClassifier("sns.amazonaws.com", classifySNS)
+}
+
+func classifySNS(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
+	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_amazonsns.html
+	var topicARN string
+	switch metadata.eventName {
+	case "CreateTopic":
+		topicARN = detail.Get("responseElements.topicArn").Str
+	case "AddPermission",
+		"ConfirmSubscription",
+		"DeleteTopic",
+		"RemovePermission",
+		"SetTopicAttributes",
+		"Subscribe":
+		topicARN = detail.Get("requestParameters.topicArn").Str
+	case "Unsubscribe":
+		// arn:aws:sns:region:account-id:topic-name:subscription-id
+		subscriptionARN := detail.Get("requestParameters.subscriptionArn").Str
+		if i := strings.LastIndex(subscriptionARN, ":"); i > 0 {
+			topicARN = subscriptionARN[:i]
+		}
+	default:
+		zap.L().Info("sns: encountered unknown event name", zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	if topicARN == "" {
+		zap.L().Warn("sns: missing arn", zap.String("eventName", metadata.eventName), zap.Any("detail", detail))
+		return nil
+	}
+
+	return []*resourceChange{{
+		AwsAccountID: metadata.accountID,
+		Delete:       metadata.eventName == "DeleteTopic",
+		EventName:    metadata.eventName,
+		ResourceID:   topicARN,
+		ResourceType: schemas.SnsTopicSchema,
+	}}
+}
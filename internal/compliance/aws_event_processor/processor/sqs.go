@@ -0,0 +1,87 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func init() {
+	registerClassifier("sqs.amazonaws.com", classifySQS)
+}
+
+func classifySQS(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
+	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_amazonsqs.html
+	var queueURL string
+	switch metadata.eventName {
+	case "CreateQueue":
+		queueURL = detail.Get("responseElements.queueUrl").Str
+	case "AddPermission",
+		"DeleteQueue",
+		"RemovePermission",
+		"SetQueueAttributes",
+		"TagQueue",
+		"UntagQueue":
+		queueURL = detail.Get("requestParameters.queueUrl").Str
+	default:
+		zap.L().Info("sqs: encountered unknown event name", zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	queueARN := sqsQueueARN(queueURL, metadata.region)
+	if queueARN == "" {
+		zap.L().Warn("sqs: unable to parse queue url", zap.String("eventName", metadata.eventName), zap.String("queueUrl", queueURL))
+		return nil
+	}
+
+	return []*resourceChange{{
+		AwsAccountID: metadata.accountID,
+		Delete:       metadata.eventName == "DeleteQueue",
+		EventName:    metadata.eventName,
+		ResourceID:   queueARN,
+		ResourceType: schemas.SqsQueueSchema,
+	}}
+}
+
+// sqsQueueARN converts a queue URL of the form https://sqs.region.amazonaws.com/account-id/queue-name
+// to the ARN of the queue.
+func sqsQueueARN(queueURL, region string) string {
+	u, err := url.Parse(queueURL)
+	if err != nil {
+		return ""
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return ""
+	}
+	return arn.ARN{
+		Partition: "aws",
+		Service:   "sqs",
+		Region:    region,
+		AccountID: parts[0],
+		Resource:  parts[1],
+	}.String()
+}
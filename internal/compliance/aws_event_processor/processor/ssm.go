@@ -0,0 +1,89 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func init() {
+	registerClassifier("ssm.amazonaws.com", classifySSM)
+}
+
+func classifySSM(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
+	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_awssystemsmanager.html
+	//
+	// Parameters are the only SSM resource we scan
+	var names []string
+	switch metadata.eventName {
+	case "DeleteParameter", "LabelParameterVersion", "PutParameter", "UnlabelParameterVersion":
+		names = []string{detail.Get("requestParameters.name").Str}
+	case "DeleteParameters":
+		for _, name := range detail.Get("requestParameters.names").Array() {
+			names = append(names, name.Str)
+		}
+	case "AddTagsToResource", "RemoveTagsFromResource":
+		if detail.Get("requestParameters.resourceType").Str != "Parameter" {
+			return nil
+		}
+		names = []string{detail.Get("requestParameters.resourceId").Str}
+	default:
+		zap.L().Info("ssm: encountered unknown event name", zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	changes := make([]*resourceChange, 0, len(names))
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		// Parameters can be referenced by ARN as well as by name
+		parameterARN, err := arn.Parse(name)
+		if err != nil {
+			if !strings.HasPrefix(name, "/") {
+				name = "/" + name
+			}
+			parameterARN = arn.ARN{
+				Partition: "aws",
+				Service:   "ssm",
+				Region:    metadata.region,
+				AccountID: metadata.accountID,
+				Resource:  "parameter" + name,
+			}
+		}
+		changes = append(changes, &resourceChange{
+			AwsAccountID: metadata.accountID,
+			Delete:       metadata.eventName == "DeleteParameter" || metadata.eventName == "DeleteParameters",
+			EventName:    metadata.eventName,
+			ResourceID:   parameterARN.String(),
+			ResourceType: schemas.SsmParameterSchema,
+		})
+	}
+	if len(changes) == 0 {
+		zap.L().Warn("ssm: missing parameter name", zap.String("eventName", metadata.eventName), zap.Any("detail", detail))
+		return nil
+	}
+	return changes
+}
@@ -28,6 +28,10 @@ import (
 	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 )
 
+func init() {
+	registerClassifier("waf.amazonaws.com", classifyWAF)
+}
+
 func classifyWAF(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
 	// These cases are tough because they don't link these resources back to any attached Web ACLs,
 	// of which there could be several. Just scan all web ACLs for now until there is a link table
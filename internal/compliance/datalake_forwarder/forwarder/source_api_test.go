@@ -41,9 +41,7 @@ func TestSourceApiCache(t *testing.T) {
 	}
 
 	testInput := &sourceAPIModels.LambdaInput{
-		ListIntegrations: &sourceAPIModels.ListIntegrationsInput{
-			IntegrationType: aws.String(sourceAPIModels.IntegrationTypeAWSScan),
-		},
+		ListIntegrations: &sourceAPIModels.ListIntegrationsInput{},
 	}
 
 	expectedInputPayload, err := jsoniter.Marshal(testInput)
@@ -103,9 +101,7 @@ func TestSourceApiLabelNotFound(t *testing.T) {
 	mockClient := &testutils.LambdaMock{}
 
 	testInput := &sourceAPIModels.LambdaInput{
-		ListIntegrations: &sourceAPIModels.ListIntegrationsInput{
-			IntegrationType: aws.String(sourceAPIModels.IntegrationTypeAWSScan),
-		},
+		ListIntegrations: &sourceAPIModels.ListIntegrationsInput{},
 	}
 
 	expectedInputPayload, err := jsoniter.Marshal(testInput)
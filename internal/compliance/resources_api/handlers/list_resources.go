@@ -27,10 +27,12 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
 	compliancemodels "github.com/panther-labs/panther/api/lambda/compliance/models"
 	"github.com/panther-labs/panther/api/lambda/resources/models"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
 	"github.com/panther-labs/panther/pkg/gatewayapi"
 )
 
@@ -54,6 +56,9 @@ var (
 // ListResources returns a filtered list of resources.
 func (API) ListResources(input *models.ListResourcesInput) *events.APIGatewayProxyResponse {
 	setListDefaults(input)
+	if err := validateResourceTypes(input.Types); err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusBadRequest, Body: err.Error()}
+	}
 
 	scanInputs, err := buildListScan(input)
 	if err != nil {
@@ -83,6 +88,16 @@ func setListDefaults(input *models.ListResourcesInput) {
 	}
 }
 
+// Reject unknown resource types instead of silently returning no resources
+func validateResourceTypes(resourceTypes []string) error {
+	for _, resourceType := range resourceTypes {
+		if _, ok := resourcetypes.Lookup(resourceType); !ok {
+			return errors.Errorf("unknown resource type %s", resourceType)
+		}
+	}
+	return nil
+}
+
 func buildListScan(input *models.ListResourcesInput) ([]*dynamodb.ScanInput, error) {
 	var projection expression.ProjectionBuilder
 	for i, field := range input.Fields {
@@ -0,0 +1,54 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import "github.com/aws/aws-sdk-go/service/elasticsearchservice"
+
+const (
+	ElasticsearchDomainSchema = "AWS.Elasticsearch.Domain"
+)
+
+// ElasticsearchDomain contains all information about an Elasticsearch (OpenSearch) domain
+type ElasticsearchDomain struct {
+	// Generic resource fields
+	GenericAWSResource
+	GenericResource
+
+	// Fields embedded from elasticsearchservice.ElasticsearchDomainStatus
+	AccessPolicies              *string
+	AdvancedOptions             map[string]*string
+	AdvancedSecurityOptions     *elasticsearchservice.AdvancedSecurityOptions
+	CognitoOptions              *elasticsearchservice.CognitoOptions
+	Created                     *bool
+	Deleted                     *bool
+	DomainEndpointOptions       *elasticsearchservice.DomainEndpointOptions
+	EBSOptions                  *elasticsearchservice.EBSOptions
+	ElasticsearchClusterConfig  *elasticsearchservice.ElasticsearchClusterConfig
+	ElasticsearchVersion        *string
+	EncryptionAtRestOptions     *elasticsearchservice.EncryptionAtRestOptions
+	Endpoint                    *string
+	Endpoints                   map[string]*string
+	LogPublishingOptions        map[string]*elasticsearchservice.LogPublishingOption
+	NodeToNodeEncryptionOptions *elasticsearchservice.NodeToNodeEncryptionOptions
+	Processing                  *bool
+	ServiceSoftwareOptions      *elasticsearchservice.ServiceSoftwareOptions
+	SnapshotOptions             *elasticsearchservice.SnapshotOptions
+	UpgradeProcessing           *bool
+	VPCOptions                  *elasticsearchservice.VPCDerivedInfo
+}
@@ -0,0 +1,53 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+const (
+	SnsTopicSchema = "AWS.SNS.Topic"
+)
+
+// SnsTopic contains all information about an SNS topic
+type SnsTopic struct {
+	// Generic resource fields
+	GenericAWSResource
+	GenericResource
+
+	// Fields parsed from sns.GetTopicAttributesOutput
+	//
+	// The subscription counts are intentionally left out, the subscriptions are listed below.
+	ContentBasedDeduplication *bool
+	DeliveryPolicy            *string
+	DisplayName               *string
+	EffectiveDeliveryPolicy   *string
+	FifoTopic                 *bool
+	KmsMasterKeyId            *string
+	Owner                     *string
+	Policy                    *string
+
+	// Additional fields
+	Subscriptions []*SnsSubscription
+}
+
+// SnsSubscription contains the information about a subscription to an SNS topic
+type SnsSubscription struct {
+	Endpoint        *string
+	Owner           *string
+	Protocol        *string
+	SubscriptionArn *string
+}
@@ -0,0 +1,57 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+)
+
+const (
+	SqsQueueSchema = "AWS.SQS.Queue"
+)
+
+// SqsQueue contains all information about an SQS queue
+type SqsQueue struct {
+	// Generic resource fields
+	GenericAWSResource
+	GenericResource
+
+	// Fields parsed from sqs.GetQueueAttributesOutput
+	//
+	// The approximate message counts are intentionally left out, they change constantly and would
+	// generate a new resource history entry on every scan.
+	ContentBasedDeduplication     *bool
+	DeduplicationScope            *string
+	DelaySeconds                  *int64
+	FifoQueue                     *bool
+	FifoThroughputLimit           *string
+	KmsDataKeyReusePeriodSeconds  *int64
+	KmsMasterKeyId                *string
+	LastModifiedTimestamp         *time.Time
+	MaximumMessageSize            *int64
+	MessageRetentionPeriod        *int64
+	Policy                        *string
+	ReceiveMessageWaitTimeSeconds *int64
+	RedrivePolicy                 *string
+	VisibilityTimeout             *int64
+
+	// Additional fields
+	QueueUrl               *string
+	DeadLetterSourceQueues []*string
+}
@@ -0,0 +1,50 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/ssm"
+)
+
+const (
+	SsmParameterSchema = "AWS.SSM.Parameter"
+)
+
+// SsmParameter contains all information about an SSM parameter.
+//
+// The parameter value is never read by the snapshot poller.
+type SsmParameter struct {
+	// Generic resource fields
+	GenericAWSResource
+	GenericResource
+
+	// Fields embedded from ssm.ParameterMetadata
+	AllowedPattern   *string
+	DataType         *string
+	Description      *string
+	KeyId            *string
+	LastModifiedDate *time.Time
+	LastModifiedUser *string
+	Policies         []*ssm.ParameterInlinePolicy
+	Tier             *string
+	Type             *string
+	Version          *int64
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/arn"
 
 	resourcesapimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
 )
 
 // Used to populate the GenericAWSResource.Region field for global AWS resources
@@ -111,3 +112,29 @@ func (r *ResourcePollerInput) ShouldIgnoreResource(resourceID string) (ignore bo
 
 // ResourcePoller represents a function to poll a specific AWS resource.
 type ResourcePoller func(input *ResourcePollerInput) ([]resourcesapimodels.AddResourceEntry, *string, error)
+
+// ARNResourcePoller represents a function to poll a single AWS resource whose ID is its ARN.
+type ARNResourcePoller func(input *ResourcePollerInput, resourceARN arn.ARN, entry *pollermodels.ScanEntry) (interface{}, error)
+
+// IDResourcePoller represents a function to poll a single AWS resource whose ID is not its ARN.
+type IDResourcePoller func(input *ResourcePollerInput, id *ParsedResourceID, entry *pollermodels.ScanEntry) (interface{}, error)
+
+// ParsedResourceID is a custom resource ID of the form accountID:region:resourceType
+type ParsedResourceID struct {
+	AccountID string
+	Region    string
+	Schema    string
+}
+
+// ParseResourceID parses a custom resource ID, it returns nil if the ID is not in the expected format
+func ParseResourceID(resourceID string) *ParsedResourceID {
+	parsedResourceID := strings.Split(resourceID, ":")
+	if len(parsedResourceID) != 3 {
+		return nil
+	}
+	return &ParsedResourceID{
+		AccountID: parsedResourceID[0],
+		Region:    parsedResourceID[1],
+		Schema:    parsedResourceID[2],
+	}
+}
@@ -0,0 +1,58 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+const (
+	ComputeFirewallSchema = "GCP.Compute.Firewall"
+)
+
+// ComputeFirewall contains all information about a VPC firewall rule
+type ComputeFirewall struct {
+	// Generic resource fields
+	GenericGCPResource
+	GenericResource
+
+	// Fields parsed from the compute/v1 Firewall resource
+	Allowed               []*ComputeFirewallRule
+	CreationTimestamp     *string
+	Denied                []*ComputeFirewallRule
+	Description           *string
+	DestinationRanges     []*string
+	Direction             *string
+	Disabled              *bool
+	LogConfig             *ComputeFirewallLogConfig
+	Network               *string
+	Priority              *int64
+	SelfLink              *string
+	SourceRanges          []*string
+	SourceServiceAccounts []*string
+	SourceTags            []*string
+	TargetServiceAccounts []*string
+	TargetTags            []*string
+}
+
+type ComputeFirewallRule struct {
+	IPProtocol *string
+	Ports      []*string
+}
+
+type ComputeFirewallLogConfig struct {
+	Enable   *bool
+	Metadata *string
+}
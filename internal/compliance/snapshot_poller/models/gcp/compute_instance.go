@@ -0,0 +1,106 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+const (
+	ComputeInstanceSchema = "GCP.Compute.Instance"
+)
+
+// ComputeInstance contains all information about a Compute Engine VM instance
+type ComputeInstance struct {
+	// Generic resource fields
+	GenericGCPResource
+	GenericResource
+
+	// Fields parsed from the compute/v1 Instance resource
+	CanIPForward               *bool
+	ConfidentialInstanceConfig *ComputeConfidentialInstanceConfig
+	CPUPlatform                *string
+	CreationTimestamp          *string
+	DeletionProtection         *bool
+	Description                *string
+	Disks                      []*ComputeAttachedDisk
+	MachineType                *string
+	Metadata                   *ComputeMetadata
+	NetworkInterfaces          []*ComputeNetworkInterface
+	SelfLink                   *string
+	ServiceAccounts            []*ComputeServiceAccount
+	ShieldedInstanceConfig     *ComputeShieldedInstanceConfig
+	Status                     *string
+	Tags                       *ComputeTags
+	Zone                       *string
+}
+
+type ComputeConfidentialInstanceConfig struct {
+	EnableConfidentialCompute *bool
+}
+
+type ComputeAttachedDisk struct {
+	AutoDelete        *bool
+	Boot              *bool
+	DeviceName        *string
+	DiskEncryptionKey *ComputeCustomerEncryptionKey
+	Mode              *string
+	Source            *string
+	Type              *string
+}
+
+type ComputeCustomerEncryptionKey struct {
+	KmsKeyName *string
+	Sha256     *string
+}
+
+type ComputeMetadata struct {
+	Items []*ComputeMetadataItem
+}
+
+type ComputeMetadataItem struct {
+	Key   *string
+	Value *string
+}
+
+type ComputeNetworkInterface struct {
+	AccessConfigs []*ComputeAccessConfig
+	Name          *string
+	Network       *string
+	NetworkIP     *string
+	Subnetwork    *string
+}
+
+type ComputeAccessConfig struct {
+	Name        *string
+	NatIP       *string
+	NetworkTier *string
+	Type        *string
+}
+
+type ComputeServiceAccount struct {
+	Email  *string
+	Scopes []*string
+}
+
+type ComputeShieldedInstanceConfig struct {
+	EnableIntegrityMonitoring *bool
+	EnableSecureBoot          *bool
+	EnableVtpm                *bool
+}
+
+type ComputeTags struct {
+	Items []*string
+}
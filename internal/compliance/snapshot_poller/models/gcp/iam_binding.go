@@ -0,0 +1,58 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+const (
+	IAMBindingSchema = "GCP.IAM.Binding"
+)
+
+// IAMBinding is a single role binding of a project IAM policy
+type IAMBinding struct {
+	// Generic resource fields
+	GenericGCPResource
+	GenericResource
+
+	// Fields parsed from the IAM policy binding
+	Role      *string
+	Members   []*string
+	Condition *IAMCondition
+
+	// Additional fields
+	PolicyEtag    *string
+	PolicyVersion *int64
+}
+
+// IAMPolicy is the IAM policy attached to a GCP resource
+type IAMPolicy struct {
+	Bindings []*IAMPolicyBinding
+	Etag     *string
+	Version  *int64
+}
+
+type IAMPolicyBinding struct {
+	Condition *IAMCondition
+	Members   []*string
+	Role      *string
+}
+
+type IAMCondition struct {
+	Description *string
+	Expression  *string
+	Title       *string
+}
@@ -0,0 +1,92 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+const (
+	SQLInstanceSchema = "GCP.SQL.Instance"
+)
+
+// SQLInstance contains all information about a Cloud SQL instance
+type SQLInstance struct {
+	// Generic resource fields
+	GenericGCPResource
+	GenericResource
+
+	// Fields parsed from the sqladmin/v1beta4 DatabaseInstance resource
+	BackendType                 *string
+	ConnectionName              *string
+	DatabaseVersion             *string
+	DiskEncryptionConfiguration *SQLDiskEncryptionConfiguration
+	GceZone                     *string
+	InstanceType                *string
+	IPAddresses                 []*SQLIPMapping
+	Region                      *string
+	SelfLink                    *string
+	ServiceAccountEmailAddress  *string
+	Settings                    *SQLSettings
+	State                       *string
+}
+
+type SQLDiskEncryptionConfiguration struct {
+	KmsKeyName *string
+}
+
+type SQLIPMapping struct {
+	IPAddress *string
+	Type      *string
+}
+
+type SQLSettings struct {
+	ActivationPolicy          *string
+	AvailabilityType          *string
+	BackupConfiguration       *SQLBackupConfiguration
+	DatabaseFlags             []*SQLDatabaseFlag
+	DataDiskSizeGb            *string
+	DataDiskType              *string
+	DeletionProtectionEnabled *bool
+	IPConfiguration           *SQLIPConfiguration
+	Tier                      *string
+	UserLabels                map[string]*string
+}
+
+type SQLBackupConfiguration struct {
+	BinaryLogEnabled           *bool
+	Enabled                    *bool
+	Location                   *string
+	PointInTimeRecoveryEnabled *bool
+	StartTime                  *string
+}
+
+type SQLDatabaseFlag struct {
+	Name  *string
+	Value *string
+}
+
+type SQLIPConfiguration struct {
+	AuthorizedNetworks []*SQLACLEntry
+	IPv4Enabled        *bool
+	PrivateNetwork     *string
+	RequireSsl         *bool
+}
+
+type SQLACLEntry struct {
+	ExpirationTime *string
+	Name           *string
+	Value          *string
+}
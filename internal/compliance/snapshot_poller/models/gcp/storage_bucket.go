@@ -0,0 +1,75 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+const (
+	StorageBucketSchema = "GCP.Storage.Bucket"
+)
+
+// StorageBucket contains all information about a GCS bucket
+type StorageBucket struct {
+	// Generic resource fields
+	GenericGCPResource
+	GenericResource
+
+	// Fields parsed from the storage/v1 Bucket resource
+	DefaultEventBasedHold *bool
+	Encryption            *StorageBucketEncryption
+	IamConfiguration      *StorageBucketIamConfiguration
+	LocationType          *string
+	Logging               *StorageBucketLogging
+	ProjectNumber         *string
+	RetentionPolicy       *StorageBucketRetentionPolicy
+	SelfLink              *string
+	StorageClass          *string
+	Updated               *string
+	Versioning            *StorageBucketVersioning
+
+	// Additional fields
+	IamPolicy *IAMPolicy
+}
+
+type StorageBucketEncryption struct {
+	DefaultKmsKeyName *string
+}
+
+type StorageBucketIamConfiguration struct {
+	PublicAccessPrevention   *string
+	UniformBucketLevelAccess *StorageBucketUniformBucketLevelAccess
+}
+
+type StorageBucketUniformBucketLevelAccess struct {
+	Enabled    *bool
+	LockedTime *string
+}
+
+type StorageBucketLogging struct {
+	LogBucket       *string
+	LogObjectPrefix *string
+}
+
+type StorageBucketRetentionPolicy struct {
+	EffectiveTime   *string
+	IsLocked        *bool
+	RetentionPeriod *string
+}
+
+type StorageBucketVersioning struct {
+	Enabled *bool
+}
@@ -0,0 +1,84 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+// Used to populate the GenericGCPResource.Location field for global GCP resources
+const GlobalLocation = "global"
+
+// GenericResource contains the fields common to all Panther resources, shared with the AWS models
+type GenericResource = awsmodels.GenericResource
+
+// GenericGCPResource contains information that is standard across GCP resources
+type GenericGCPResource struct {
+	// Fields that generally need to be populated after building the snapshot
+	ProjectID *string `json:"ProjectId"` // The ID of the GCP project the resource resides in
+	Location  *string `json:"Location"`  // The region or zone of the resource, value of GlobalLocation if global
+
+	// Fields that can generally be populated while building the snapshot
+	AssetName *string            `json:"AssetName,omitempty"` // The Cloud Asset full resource name
+	ID        *string            `json:"Id,omitempty"`        // The GCP resource identifier
+	Name      *string            `json:"Name,omitempty"`      // The GCP resource name
+	Labels    map[string]*string // A standardized format for key/value resource labels
+}
+
+// ResourcePollerInput contains the metadata to request GCP resource info.
+type ResourcePollerInput struct {
+	IntegrationID           *string
+	ProjectID               *string
+	Timestamp               *time.Time
+	NextPageToken           *string
+	ResourceTypeIgnoreList  []string
+	ResourceRegexIgnoreList []string
+	CompiledRegexIgnoreList []*regexp.Regexp
+}
+
+func (r *ResourcePollerInput) CompileRegex() error {
+	r.CompiledRegexIgnoreList = make([]*regexp.Regexp, 0, len(r.ResourceRegexIgnoreList))
+
+	for _, glob := range r.ResourceRegexIgnoreList {
+		if glob == "" {
+			continue
+		}
+		// Same glob syntax as the AWS integrations, '*' is the only wildcard
+		regex := "^" + strings.ReplaceAll(regexp.QuoteMeta(glob), `\*`, `.*`) + "$"
+		compiledGlob, err := regexp.Compile(regex)
+		if err != nil {
+			return err
+		}
+		r.CompiledRegexIgnoreList = append(r.CompiledRegexIgnoreList, compiledGlob)
+	}
+	return nil
+}
+
+func (r *ResourcePollerInput) ShouldIgnoreResource(resourceID string) (ignore bool) {
+	for _, compiledRegex := range r.CompiledRegexIgnoreList {
+		if compiledRegex.MatchString(resourceID) {
+			return true
+		}
+	}
+	return false
+}
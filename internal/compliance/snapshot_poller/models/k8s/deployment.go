@@ -0,0 +1,63 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+const (
+	DeploymentSchema = "K8s.Apps.Deployment"
+)
+
+// Deployment contains all information about a Kubernetes deployment
+type Deployment struct {
+	// Generic resource fields
+	GenericK8sResource
+	GenericResource
+
+	// Fields parsed from the apps/v1 Deployment object
+	Spec   *DeploymentSpec
+	Status *DeploymentStatus
+}
+
+type DeploymentSpec struct {
+	Paused   *bool
+	Replicas *int64
+	Selector *LabelSelector
+	Strategy *DeploymentStrategy
+	Template *PodTemplateSpec
+}
+
+type DeploymentStrategy struct {
+	Type *string
+}
+
+type PodTemplateSpec struct {
+	Metadata *PodTemplateMetadata
+	Spec     *PodSpec
+}
+
+type PodTemplateMetadata struct {
+	Annotations map[string]*string
+	Labels      map[string]*string
+}
+
+type DeploymentStatus struct {
+	AvailableReplicas *int64
+	ReadyReplicas     *int64
+	Replicas          *int64
+	UpdatedReplicas   *int64
+}
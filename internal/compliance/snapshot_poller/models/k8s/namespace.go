@@ -0,0 +1,45 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+const (
+	NamespaceSchema = "K8s.Core.Namespace"
+)
+
+// Namespace contains all information about a Kubernetes namespace
+type Namespace struct {
+	// Generic resource fields
+	GenericK8sResource
+	GenericResource
+
+	// Fields parsed from the core/v1 Namespace object
+	Spec   *NamespaceSpec
+	Status *NamespaceStatus
+
+	// Additional fields
+	NetworkPolicies []*string // The names of the network policies in the namespace
+}
+
+type NamespaceSpec struct {
+	Finalizers []*string
+}
+
+type NamespaceStatus struct {
+	Phase *string
+}
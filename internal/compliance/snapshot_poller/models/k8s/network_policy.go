@@ -0,0 +1,67 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+const (
+	NetworkPolicySchema = "K8s.Networking.NetworkPolicy"
+)
+
+// NetworkPolicy contains all information about a Kubernetes network policy
+type NetworkPolicy struct {
+	// Generic resource fields
+	GenericK8sResource
+	GenericResource
+
+	// Fields parsed from the networking.k8s.io/v1 NetworkPolicy object
+	Spec *NetworkPolicySpec
+}
+
+type NetworkPolicySpec struct {
+	Egress      []*NetworkPolicyEgressRule
+	Ingress     []*NetworkPolicyIngressRule
+	PodSelector *LabelSelector
+	PolicyTypes []*string
+}
+
+type NetworkPolicyEgressRule struct {
+	Ports []*NetworkPolicyPort
+	To    []*NetworkPolicyPeer
+}
+
+type NetworkPolicyIngressRule struct {
+	From  []*NetworkPolicyPeer
+	Ports []*NetworkPolicyPort
+}
+
+type NetworkPolicyPeer struct {
+	IPBlock           *IPBlock
+	NamespaceSelector *LabelSelector
+	PodSelector       *LabelSelector
+}
+
+type IPBlock struct {
+	CIDR   *string
+	Except []*string
+}
+
+type NetworkPolicyPort struct {
+	EndPort  *int64
+	Port     interface{} // Either a port number or a named port
+	Protocol *string
+}
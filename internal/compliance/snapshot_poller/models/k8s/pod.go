@@ -0,0 +1,146 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+const (
+	PodSchema = "K8s.Core.Pod"
+)
+
+// Pod contains all information about a Kubernetes pod
+type Pod struct {
+	// Generic resource fields
+	GenericK8sResource
+	GenericResource
+
+	// Fields parsed from the core/v1 Pod object
+	OwnerReferences []*OwnerReference
+	Spec            *PodSpec
+	Status          *PodStatus
+}
+
+type OwnerReference struct {
+	APIVersion *string
+	Controller *bool
+	Kind       *string
+	Name       *string
+	UID        *string
+}
+
+// PodSpec is shared by pods and the pod templates of workloads
+type PodSpec struct {
+	AutomountServiceAccountToken *bool
+	Containers                   []*Container
+	EphemeralContainers          []*Container
+	HostIPC                      *bool
+	HostNetwork                  *bool
+	HostPID                      *bool
+	InitContainers               []*Container
+	NodeName                     *string
+	SecurityContext              *PodSecurityContext
+	ServiceAccountName           *string
+	Volumes                      []*Volume
+}
+
+type Container struct {
+	Args            []*string
+	Command         []*string
+	Image           *string
+	ImagePullPolicy *string
+	Name            *string
+	Ports           []*ContainerPort
+	Resources       *ResourceRequirements
+	SecurityContext *SecurityContext
+}
+
+type ContainerPort struct {
+	ContainerPort *int64
+	HostIP        *string
+	HostPort      *int64
+	Name          *string
+	Protocol      *string
+}
+
+type ResourceRequirements struct {
+	Limits   map[string]*string
+	Requests map[string]*string
+}
+
+type SecurityContext struct {
+	AllowPrivilegeEscalation *bool
+	Capabilities             *Capabilities
+	Privileged               *bool
+	ProcMount                *string
+	ReadOnlyRootFilesystem   *bool
+	RunAsGroup               *int64
+	RunAsNonRoot             *bool
+	RunAsUser                *int64
+	SeccompProfile           *SeccompProfile
+}
+
+type Capabilities struct {
+	Add  []*string
+	Drop []*string
+}
+
+type SeccompProfile struct {
+	LocalhostProfile *string
+	Type             *string
+}
+
+type PodSecurityContext struct {
+	FSGroup            *int64
+	RunAsGroup         *int64
+	RunAsNonRoot       *bool
+	RunAsUser          *int64
+	SeccompProfile     *SeccompProfile
+	SupplementalGroups []*int64
+}
+
+type Volume struct {
+	ConfigMap *ConfigMapVolumeSource
+	EmptyDir  *EmptyDirVolumeSource
+	HostPath  *HostPathVolumeSource
+	Name      *string
+	Secret    *SecretVolumeSource
+}
+
+type ConfigMapVolumeSource struct {
+	Name *string
+}
+
+type EmptyDirVolumeSource struct {
+	Medium    *string
+	SizeLimit *string
+}
+
+type HostPathVolumeSource struct {
+	Path *string
+	Type *string
+}
+
+type SecretVolumeSource struct {
+	SecretName *string
+}
+
+type PodStatus struct {
+	HostIP   *string
+	Phase    *string
+	PodIP    *string
+	QOSClass *string
+}
@@ -0,0 +1,73 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+const (
+	RoleSchema               = "K8s.RBAC.Role"
+	RoleBindingSchema        = "K8s.RBAC.RoleBinding"
+	ClusterRoleSchema        = "K8s.RBAC.ClusterRole"
+	ClusterRoleBindingSchema = "K8s.RBAC.ClusterRoleBinding"
+)
+
+// Role contains all information about a Kubernetes role or cluster role
+type Role struct {
+	// Generic resource fields
+	GenericK8sResource
+	GenericResource
+
+	// Fields parsed from the rbac.authorization.k8s.io/v1 Role and ClusterRole objects
+	AggregationRule *AggregationRule // Only set for aggregated cluster roles
+	Rules           []*PolicyRule
+}
+
+type AggregationRule struct {
+	ClusterRoleSelectors []*LabelSelector
+}
+
+type PolicyRule struct {
+	APIGroups       []*string
+	NonResourceURLs []*string
+	ResourceNames   []*string
+	Resources       []*string
+	Verbs           []*string
+}
+
+// RoleBinding contains all information about a Kubernetes role binding or cluster role binding
+type RoleBinding struct {
+	// Generic resource fields
+	GenericK8sResource
+	GenericResource
+
+	// Fields parsed from the rbac.authorization.k8s.io/v1 RoleBinding and ClusterRoleBinding objects
+	RoleRef  *RoleRef
+	Subjects []*Subject
+}
+
+type RoleRef struct {
+	APIGroup *string
+	Kind     *string
+	Name     *string
+}
+
+type Subject struct {
+	APIGroup  *string
+	Kind      *string
+	Name      *string
+	Namespace *string
+}
@@ -0,0 +1,45 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+const (
+	ServiceAccountSchema = "K8s.Core.ServiceAccount"
+)
+
+// ServiceAccount contains all information about a Kubernetes service account
+type ServiceAccount struct {
+	// Generic resource fields
+	GenericK8sResource
+	GenericResource
+
+	// Fields parsed from the core/v1 ServiceAccount object
+	AutomountServiceAccountToken *bool
+	ImagePullSecrets             []*LocalObjectReference
+	Secrets                      []*ObjectReference
+}
+
+type LocalObjectReference struct {
+	Name *string
+}
+
+type ObjectReference struct {
+	Kind      *string
+	Name      *string
+	Namespace *string
+}
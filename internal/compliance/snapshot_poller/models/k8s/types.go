@@ -0,0 +1,93 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+// GenericResource contains the fields common to all Panther resources, shared with the AWS models
+type GenericResource = awsmodels.GenericResource
+
+// GenericK8sResource contains information that is standard across Kubernetes objects
+type GenericK8sResource struct {
+	// Fields that generally need to be populated after building the snapshot
+	ClusterName *string // The name of the cluster in the Panther integration
+
+	// Fields populated from the object metadata
+	Namespace   *string            `json:"Namespace,omitempty"` // Not set for cluster scoped objects
+	ID          *string            `json:"Id,omitempty"`        // The object UID
+	Name        *string            `json:"Name,omitempty"`
+	Labels      map[string]*string `json:"Labels,omitempty"`
+	Annotations map[string]*string `json:"Annotations,omitempty"`
+}
+
+// ResourcePollerInput contains the metadata to request Kubernetes resource info.
+type ResourcePollerInput struct {
+	IntegrationID           *string
+	ClusterName             *string
+	Timestamp               *time.Time
+	NextPageToken           *string
+	ResourceTypeIgnoreList  []string
+	ResourceRegexIgnoreList []string
+	CompiledRegexIgnoreList []*regexp.Regexp
+}
+
+func (r *ResourcePollerInput) CompileRegex() error {
+	r.CompiledRegexIgnoreList = make([]*regexp.Regexp, 0, len(r.ResourceRegexIgnoreList))
+
+	for _, glob := range r.ResourceRegexIgnoreList {
+		if glob == "" {
+			continue
+		}
+		// Same glob syntax as the AWS integrations, '*' is the only wildcard
+		regex := "^" + strings.ReplaceAll(regexp.QuoteMeta(glob), `\*`, `.*`) + "$"
+		compiledGlob, err := regexp.Compile(regex)
+		if err != nil {
+			return err
+		}
+		r.CompiledRegexIgnoreList = append(r.CompiledRegexIgnoreList, compiledGlob)
+	}
+	return nil
+}
+
+func (r *ResourcePollerInput) ShouldIgnoreResource(resourceID string) (ignore bool) {
+	for _, compiledRegex := range r.CompiledRegexIgnoreList {
+		if compiledRegex.MatchString(resourceID) {
+			return true
+		}
+	}
+	return false
+}
+
+// LabelSelector selects objects by their labels
+type LabelSelector struct {
+	MatchExpressions []*LabelSelectorRequirement
+	MatchLabels      map[string]*string
+}
+
+type LabelSelectorRequirement struct {
+	Key      *string
+	Operator *string
+	Values   []*string
+}
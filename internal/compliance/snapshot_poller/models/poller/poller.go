@@ -28,13 +28,18 @@ type ScanMsg struct {
 // The poller can scan a single resource, all resources of a given type in a given region, or all
 // resources of a given type in all regions. The all region scan is accomplished by breaking the
 // request into multiple smaller requests, one per supported region.
+// GCP and Kubernetes scans set IntegrationType and ProjectID or ClusterName instead of AWSAccountID
+// and Region, they only support service scans of a resource type.
 type ScanEntry struct {
-	AWSAccountID  *string `json:"awsAccountId"`
-	IntegrationID *string `json:"integrationId"`
-	Region        *string `json:"region"`
-	ResourceID    *string `json:"resourceId"`
-	ResourceType  *string `json:"resourceType"`
-	NextPageToken *string `json:"nextPageToken"`
+	IntegrationType *string `json:"integrationType"` // One of aws, gcp or k8s, defaults to aws if not set
+	AWSAccountID    *string `json:"awsAccountId"`
+	ProjectID       *string `json:"projectId"`
+	ClusterName     *string `json:"clusterName"`
+	IntegrationID   *string `json:"integrationId"`
+	Region          *string `json:"region"`
+	ResourceID      *string `json:"resourceId"`
+	ResourceType    *string `json:"resourceType"`
+	NextPageToken   *string `json:"nextPageToken"`
 	// Added for optional filtering
 	Enabled                 *bool    `json:"enabled"`
 	RegionIgnoreList        []string `json:"regionIgnoreList"`
@@ -0,0 +1,347 @@
+package resourcetypes
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+// ResourcePollers are the functions that scan the resources of an AWS resource type
+type ResourcePollers struct {
+	// Scans the resources of the type in a region, only resource types without a parent have one
+	Service awsmodels.ResourcePoller
+	// Scans a single resource whose ID is its ARN
+	ARN awsmodels.ARNResourcePoller
+	// Scans a single resource whose ID is not its ARN
+	ID awsmodels.IDResourcePoller
+}
+
+const (
+	eventSourceACM                  = "acm.amazonaws.com"
+	eventSourceAPIGateway           = "apigateway.amazonaws.com"
+	eventSourceCloudFormation       = "cloudformation.amazonaws.com"
+	eventSourceCloudFront           = "cloudfront.amazonaws.com"
+	eventSourceCloudTrail           = "cloudtrail.amazonaws.com"
+	eventSourceCloudWatchLogs       = "logs.amazonaws.com"
+	eventSourceConfig               = "config.amazonaws.com"
+	eventSourceDynamoDB             = "dynamodb.amazonaws.com"
+	eventSourceEC2                  = "ec2.amazonaws.com"
+	eventSourceECR                  = "ecr.amazonaws.com"
+	eventSourceECS                  = "ecs.amazonaws.com"
+	eventSourceEFS                  = "elasticfilesystem.amazonaws.com"
+	eventSourceElasticLoadBalancing = "elasticloadbalancing.amazonaws.com"
+	eventSourceElasticsearch        = "es.amazonaws.com"
+	eventSourceGuardDuty            = "guardduty.amazonaws.com"
+	eventSourceIAM                  = "iam.amazonaws.com"
+	eventSourceKMS                  = "kms.amazonaws.com"
+	eventSourceLambda               = "lambda.amazonaws.com"
+	eventSourceRDS                  = "rds.amazonaws.com"
+	eventSourceRedshift             = "redshift.amazonaws.com"
+	eventSourceRoute53              = "route53.amazonaws.com"
+	eventSourceS3                   = "s3.amazonaws.com"
+	eventSourceSecretsManager       = "secretsmanager.amazonaws.com"
+	eventSourceSNS                  = "sns.amazonaws.com"
+	eventSourceSQS                  = "sqs.amazonaws.com"
+	eventSourceSSM                  = "ssm.amazonaws.com"
+	eventSourceWAF                  = "waf.amazonaws.com"
+	eventSourceWAFRegional          = "waf-regional.amazonaws.com"
+)
+
+var awsResourceTypes = []Info{
+	{
+		Name:         awsmodels.AcmCertificateSchema,
+		DisplayName:  "ACM Certificate",
+		ServiceID:    "acm",
+		EventSources: []string{eventSourceACM},
+	},
+	{
+		Name:         awsmodels.ApiGatewayHttpApiSchema,
+		DisplayName:  "API Gateway HTTP API",
+		ServiceID:    "apigateway",
+		EventSources: []string{eventSourceAPIGateway},
+	},
+	{
+		Name:         awsmodels.ApiGatewayRestApiSchema,
+		DisplayName:  "API Gateway REST API",
+		ServiceID:    "apigateway",
+		EventSources: []string{eventSourceAPIGateway},
+	},
+	{
+		Name:         awsmodels.CloudFormationStackSchema,
+		DisplayName:  "CloudFormation Stack",
+		ServiceID:    "cloudformation",
+		EventSources: []string{eventSourceCloudFormation},
+	},
+	{
+		Name:         awsmodels.CloudFrontDistributionSchema,
+		DisplayName:  "CloudFront Distribution",
+		ServiceID:    "cloudfront",
+		Global:       true,
+		EventSources: []string{eventSourceCloudFront},
+	},
+	{
+		Name:         awsmodels.CloudTrailSchema,
+		DisplayName:  "CloudTrail",
+		ServiceID:    "cloudtrail",
+		Global:       true, // Has a meta resource
+		EventSources: []string{eventSourceCloudTrail},
+	},
+	{
+		Name:        awsmodels.CloudTrailMetaSchema,
+		DisplayName: "CloudTrail Meta",
+		Global:      true,
+		Parent:      awsmodels.CloudTrailSchema,
+	},
+	{
+		Name:         awsmodels.CloudWatchLogGroupSchema,
+		DisplayName:  "CloudWatch Log Group",
+		ServiceID:    "logs",
+		EventSources: []string{eventSourceCloudWatchLogs},
+	},
+	{
+		Name:         awsmodels.ConfigServiceSchema,
+		DisplayName:  "Config Recorder",
+		ServiceID:    "config",
+		Global:       true, // Has a meta resource
+		EventSources: []string{eventSourceConfig},
+	},
+	{
+		Name:        awsmodels.ConfigServiceMetaSchema,
+		DisplayName: "Config Recorder Meta",
+		Global:      true,
+		Parent:      awsmodels.ConfigServiceSchema,
+	},
+	{
+		Name:         awsmodels.DynamoDBTableSchema,
+		DisplayName:  "DynamoDB Table",
+		ServiceID:    "dynamodb",
+		EventSources: []string{eventSourceDynamoDB},
+	},
+	{
+		Name:         awsmodels.Ec2AmiSchema,
+		DisplayName:  "EC2 AMI",
+		ServiceID:    "ec2",
+		EventSources: []string{eventSourceEC2},
+	},
+	{
+		Name:         awsmodels.Ec2InstanceSchema,
+		DisplayName:  "EC2 Instance",
+		ServiceID:    "ec2",
+		EventSources: []string{eventSourceEC2},
+	},
+	{
+		Name:         awsmodels.Ec2NetworkAclSchema,
+		DisplayName:  "EC2 Network ACL",
+		ServiceID:    "ec2",
+		EventSources: []string{eventSourceEC2},
+	},
+	{
+		Name:         awsmodels.Ec2SecurityGroupSchema,
+		DisplayName:  "EC2 Security Group",
+		ServiceID:    "ec2",
+		EventSources: []string{eventSourceEC2},
+	},
+	{
+		Name:         awsmodels.Ec2TransitGatewaySchema,
+		DisplayName:  "EC2 Transit Gateway",
+		ServiceID:    "ec2",
+		EventSources: []string{eventSourceEC2},
+	},
+	{
+		Name:         awsmodels.Ec2VolumeSchema,
+		DisplayName:  "EC2 Volume",
+		ServiceID:    "ec2",
+		EventSources: []string{eventSourceEC2},
+	},
+	{
+		Name:         awsmodels.Ec2VpcSchema,
+		DisplayName:  "EC2 VPC",
+		ServiceID:    "ec2",
+		EventSources: []string{eventSourceEC2},
+	},
+	{
+		Name:         awsmodels.EcrRepositorySchema,
+		DisplayName:  "ECR Repository",
+		ServiceID:    "ecr",
+		EventSources: []string{eventSourceECR},
+	},
+	{
+		Name:         awsmodels.EcsClusterSchema,
+		DisplayName:  "ECS Cluster",
+		ServiceID:    "ecs",
+		EventSources: []string{eventSourceECS},
+	},
+	{
+		Name:         awsmodels.EfsFileSystemSchema,
+		DisplayName:  "EFS File System",
+		ServiceID:    "efs", // SSM uses the short name rather than elasticfilesystem
+		EventSources: []string{eventSourceEFS},
+	},
+	{
+		// EKS clusters are not updated in real time, there is no classifier for their events
+		Name:        awsmodels.EksClusterSchema,
+		DisplayName: "EKS Cluster",
+		ServiceID:   "eks",
+	},
+	{
+		Name:         awsmodels.ElasticsearchDomainSchema,
+		DisplayName:  "Elasticsearch Domain",
+		ServiceID:    "es",
+		EventSources: []string{eventSourceElasticsearch},
+	},
+	{
+		Name:        awsmodels.Elbv2LoadBalancerSchema,
+		DisplayName: "ELBV2 Application Load Balancer",
+		// For every other service, the service name aligns with how SSM refers to the service. For
+		// just the elb and elbv2 service, this is not the case. AWS just had to do it to 'em.
+		ServiceID:    "elb",
+		EventSources: []string{eventSourceElasticLoadBalancing},
+	},
+	{
+		Name:         awsmodels.GuardDutySchema,
+		DisplayName:  "GuardDuty Detector",
+		ServiceID:    "guardduty",
+		Global:       true, // Has a meta resource
+		EventSources: []string{eventSourceGuardDuty},
+	},
+	{
+		Name:        awsmodels.GuardDutyMetaSchema,
+		DisplayName: "GuardDuty Detector Meta",
+		Global:      true,
+		Parent:      awsmodels.GuardDutySchema,
+	},
+	{
+		Name:         awsmodels.IAMGroupSchema,
+		DisplayName:  "IAM Group",
+		ServiceID:    "iam",
+		Global:       true,
+		EventSources: []string{eventSourceIAM},
+	},
+	{
+		Name:         awsmodels.IAMPolicySchema,
+		DisplayName:  "IAM Policy",
+		ServiceID:    "iam",
+		Global:       true,
+		EventSources: []string{eventSourceIAM},
+	},
+	{
+		Name:         awsmodels.IAMRoleSchema,
+		DisplayName:  "IAM Role",
+		ServiceID:    "iam",
+		Global:       true,
+		EventSources: []string{eventSourceIAM},
+	},
+	{
+		// The root user is scanned along with the IAM users, a service scan must not be done for it
+		Name:         awsmodels.IAMRootUserSchema,
+		DisplayName:  "IAM Root User",
+		ServiceID:    "iam",
+		Global:       true,
+		Parent:       awsmodels.IAMUserSchema,
+		EventSources: []string{eventSourceIAM},
+	},
+	{
+		Name:         awsmodels.IAMUserSchema,
+		DisplayName:  "IAM User",
+		ServiceID:    "iam",
+		Global:       true,
+		EventSources: []string{eventSourceIAM},
+	},
+	{
+		Name:         awsmodels.KmsKeySchema,
+		DisplayName:  "KMS Key",
+		ServiceID:    "kms",
+		EventSources: []string{eventSourceKMS},
+	},
+	{
+		Name:         awsmodels.LambdaFunctionSchema,
+		DisplayName:  "Lambda Function",
+		ServiceID:    "lambda",
+		EventSources: []string{eventSourceLambda},
+	},
+	{
+		Name:         awsmodels.PasswordPolicySchema,
+		DisplayName:  "Password Policy",
+		ServiceID:    "iam",
+		Global:       true,
+		EventSources: []string{eventSourceIAM},
+	},
+	{
+		Name:         awsmodels.RDSInstanceSchema,
+		DisplayName:  "RDS Instance",
+		ServiceID:    "rds",
+		EventSources: []string{eventSourceRDS},
+	},
+	{
+		Name:         awsmodels.RedshiftClusterSchema,
+		DisplayName:  "Redshift Cluster",
+		ServiceID:    "redshift",
+		EventSources: []string{eventSourceRedshift},
+	},
+	{
+		Name:         awsmodels.Route53HostedZoneSchema,
+		DisplayName:  "Route 53 Hosted Zone",
+		ServiceID:    "route53",
+		Global:       true,
+		EventSources: []string{eventSourceRoute53},
+	},
+	{
+		Name:         awsmodels.S3BucketSchema,
+		DisplayName:  "S3 Bucket",
+		ServiceID:    "s3",
+		EventSources: []string{eventSourceS3},
+	},
+	{
+		Name:         awsmodels.SecretsManagerSecretSchema,
+		DisplayName:  "Secrets Manager Secret",
+		ServiceID:    "secretsmanager",
+		EventSources: []string{eventSourceSecretsManager},
+	},
+	{
+		Name:         awsmodels.SnsTopicSchema,
+		DisplayName:  "SNS Topic",
+		ServiceID:    "sns",
+		EventSources: []string{eventSourceSNS},
+	},
+	{
+		Name:         awsmodels.SqsQueueSchema,
+		DisplayName:  "SQS Queue",
+		ServiceID:    "sqs",
+		EventSources: []string{eventSourceSQS},
+	},
+	{
+		Name:         awsmodels.SsmParameterSchema,
+		DisplayName:  "SSM Parameter",
+		ServiceID:    "ssm",
+		EventSources: []string{eventSourceSSM},
+	},
+	{
+		Name:         awsmodels.WafRegionalWebAclSchema,
+		DisplayName:  "WAF Regional Web ACL",
+		ServiceID:    "waf",
+		EventSources: []string{eventSourceWAFRegional},
+	},
+	{
+		Name:         awsmodels.WafWebAclSchema,
+		DisplayName:  "WAF Web ACL",
+		ServiceID:    "waf",
+		Global:       true,
+		EventSources: []string{eventSourceWAF},
+	},
+}
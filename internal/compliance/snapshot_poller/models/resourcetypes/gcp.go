@@ -0,0 +1,64 @@
+package resourcetypes
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+)
+
+// The Cloud Asset content types of GCP resource types
+const (
+	ContentTypeResource  = "RESOURCE"
+	ContentTypeIAMPolicy = "IAM_POLICY"
+)
+
+var gcpResourceTypes = []Info{
+	{
+		Name:        gcpmodels.ComputeFirewallSchema,
+		DisplayName: "Compute Firewall Rule",
+		AssetType:   "compute.googleapis.com/Firewall",
+		ContentType: ContentTypeResource,
+	},
+	{
+		Name:        gcpmodels.ComputeInstanceSchema,
+		DisplayName: "Compute Instance",
+		AssetType:   "compute.googleapis.com/Instance",
+		ContentType: ContentTypeResource,
+	},
+	{
+		// Each binding of the project IAM policy is a separate resource
+		Name:        gcpmodels.IAMBindingSchema,
+		DisplayName: "IAM Binding",
+		AssetType:   "cloudresourcemanager.googleapis.com/Project",
+		ContentType: ContentTypeIAMPolicy,
+	},
+	{
+		Name:        gcpmodels.SQLInstanceSchema,
+		DisplayName: "Cloud SQL Instance",
+		AssetType:   "sqladmin.googleapis.com/Instance",
+		ContentType: ContentTypeResource,
+	},
+	{
+		// The bucket IAM policies are read separately and added to each bucket
+		Name:        gcpmodels.StorageBucketSchema,
+		DisplayName: "GCS Bucket",
+		AssetType:   "storage.googleapis.com/Bucket",
+		ContentType: ContentTypeResource,
+	},
+}
@@ -0,0 +1,78 @@
+package resourcetypes
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	k8smodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/k8s"
+)
+
+var k8sResourceTypes = []Info{
+	{
+		Name:        k8smodels.ClusterRoleSchema,
+		DisplayName: "Kubernetes Cluster Role",
+		APIPath:     "/apis/rbac.authorization.k8s.io/v1/clusterroles",
+	},
+	{
+		Name:        k8smodels.ClusterRoleBindingSchema,
+		DisplayName: "Kubernetes Cluster Role Binding",
+		APIPath:     "/apis/rbac.authorization.k8s.io/v1/clusterrolebindings",
+	},
+	{
+		Name:        k8smodels.DeploymentSchema,
+		DisplayName: "Kubernetes Deployment",
+		APIPath:     "/apis/apps/v1/deployments",
+		Namespaced:  true,
+	},
+	{
+		// The names of the network policies of each namespace are added to the namespace
+		Name:        k8smodels.NamespaceSchema,
+		DisplayName: "Kubernetes Namespace",
+		APIPath:     "/api/v1/namespaces",
+	},
+	{
+		Name:        k8smodels.NetworkPolicySchema,
+		DisplayName: "Kubernetes Network Policy",
+		APIPath:     "/apis/networking.k8s.io/v1/networkpolicies",
+		Namespaced:  true,
+	},
+	{
+		Name:        k8smodels.PodSchema,
+		DisplayName: "Kubernetes Pod",
+		APIPath:     "/api/v1/pods",
+		Namespaced:  true,
+	},
+	{
+		Name:        k8smodels.RoleSchema,
+		DisplayName: "Kubernetes Role",
+		APIPath:     "/apis/rbac.authorization.k8s.io/v1/roles",
+		Namespaced:  true,
+	},
+	{
+		Name:        k8smodels.RoleBindingSchema,
+		DisplayName: "Kubernetes Role Binding",
+		APIPath:     "/apis/rbac.authorization.k8s.io/v1/rolebindings",
+		Namespaced:  true,
+	},
+	{
+		Name:        k8smodels.ServiceAccountSchema,
+		DisplayName: "Kubernetes Service Account",
+		APIPath:     "/api/v1/serviceaccounts",
+		Namespaced:  true,
+	},
+}
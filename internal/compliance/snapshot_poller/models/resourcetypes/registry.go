@@ -0,0 +1,207 @@
+package resourcetypes
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"sort"
+)
+
+// Info describes a resource type supported by Cloud Security.
+//
+// The registry is the single source of truth for the resource types of every integration type. The
+// snapshot pollers, the event processor, the resources API and the web frontend (through 'mage gen')
+// all read it. The snapshot poller and the event processor attach their functions to the AWS entries
+// when they start, see RegisterPollers and RegisterClassifier.
+//
+// Besides the common fields, each entry only sets the fields of its integration type.
+type Info struct {
+	// The resource type, e.g. AWS.S3.Bucket
+	Name string
+	// Human readable name of the resource type, e.g. S3 Bucket
+	DisplayName string
+	// The integration type that scans the resources of this type, one of aws, gcp or k8s
+	IntegrationType string
+
+	// AWS
+	//
+	// How AWS refers to the service in the SSM global infrastructure parameters, used to look up
+	// the regions where the service is available.
+	ServiceID string
+	// Resource types that are scanned once per account rather than once per region, either because
+	// the resource itself is not regional or because a "Meta" resource needs the full context of
+	// every resource to be updated.
+	Global bool
+	// Resource types that are built while scanning another (parent) resource type. They do not
+	// have a service scan of their own.
+	Parent string
+	// The CloudTrail event sources of the events that may change resources of this type
+	EventSources []string
+	// The functions that scan resources of this type, attached by the snapshot poller. They are
+	// nil in the other components that read the registry.
+	Pollers *ResourcePollers
+	// The function that classifies the CloudTrail events of EventSources, attached by the event
+	// processor. Its signature is private to the event processor.
+	Classifier interface{}
+
+	// GCP
+	//
+	// The Cloud Asset type, e.g. storage.googleapis.com/Bucket
+	AssetType string
+	// The Cloud Asset content type, RESOURCE for the resource metadata or IAM_POLICY for its IAM policy
+	ContentType string
+
+	// Kubernetes
+	//
+	// The path of the list endpoint across all namespaces, e.g. /api/v1/pods
+	APIPath string
+	// Whether the objects belong to a namespace, the others are cluster scoped
+	Namespaced bool
+}
+
+// The integration types of the resource types, as in the scan requests of the snapshot poller
+const (
+	IntegrationTypeAWS = "aws"
+	IntegrationTypeGCP = "gcp"
+	IntegrationTypeK8s = "k8s"
+)
+
+var (
+	registry = concat(
+		withIntegrationType(IntegrationTypeAWS, awsResourceTypes),
+		withIntegrationType(IntegrationTypeGCP, gcpResourceTypes),
+		withIntegrationType(IntegrationTypeK8s, k8sResourceTypes),
+	)
+
+	resourceTypesByName = indexResourceTypes(registry)
+
+	classifiersByEventSource = make(map[string]interface{})
+)
+
+func withIntegrationType(integrationType string, entries []Info) []Info {
+	for i := range entries {
+		entries[i].IntegrationType = integrationType
+	}
+	return entries
+}
+
+func concat(registries ...[]Info) []Info {
+	var entries []Info
+	for _, registry := range registries {
+		entries = append(entries, registry...)
+	}
+	return entries
+}
+
+func indexResourceTypes(registry []Info) map[string]*Info {
+	index := make(map[string]*Info, len(registry))
+	for i := range registry {
+		info := &registry[i]
+		if _, duplicate := index[info.Name]; duplicate {
+			panic("duplicate resource type " + info.Name)
+		}
+		index[info.Name] = info
+	}
+	return index
+}
+
+// Lookup returns the registry entry of a resource type
+func Lookup(name string) (*Info, bool) {
+	info, ok := resourceTypesByName[name]
+	return info, ok
+}
+
+// Registered returns all the entries of the registry, sorted by resource type name
+func Registered() []Info {
+	entries := make([]Info, len(registry))
+	copy(entries, registry)
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	return entries
+}
+
+// RegisteredFor returns the entries of the registry of an integration type, sorted by resource type name
+func RegisteredFor(integrationType string) []Info {
+	var entries []Info
+	for _, info := range Registered() {
+		if info.IntegrationType == integrationType {
+			entries = append(entries, info)
+		}
+	}
+	return entries
+}
+
+// Scannable returns the names of the resource types of an integration type that have a service scan
+func Scannable(integrationType string) []string {
+	var names []string
+	for _, info := range RegisteredFor(integrationType) {
+		if info.Parent == "" {
+			names = append(names, info.Name)
+		}
+	}
+	return names
+}
+
+// RegisterPollers attaches the poll functions of an AWS resource type to its registry entry.
+//
+// It panics if the resource type is not registered, or if the pollers do not match the entry.
+func RegisterPollers(name string, pollers *ResourcePollers) {
+	info, ok := resourceTypesByName[name]
+	if !ok || info.IntegrationType != IntegrationTypeAWS {
+		panic("pollers for unregistered AWS resource type " + name)
+	}
+	if info.Pollers != nil {
+		panic("duplicate pollers for resource type " + name)
+	}
+	if (pollers.Service == nil) != (info.Parent != "") {
+		panic("resource type " + name + " must have a service poller if and only if it has no parent")
+	}
+	if pollers.ARN != nil && pollers.ID != nil {
+		panic("resource type " + name + " has more than one individual poller")
+	}
+	info.Pollers = pollers
+}
+
+// RegisterClassifier attaches the classifier of a CloudTrail event source to the registry entries of
+// the resource types with that event source.
+//
+// It panics if no resource type has the event source, or if the event source already has a classifier.
+func RegisterClassifier(eventSource string, classifier interface{}) {
+	if _, duplicate := classifiersByEventSource[eventSource]; duplicate {
+		panic("duplicate classifier for event source " + eventSource)
+	}
+	for i := range registry {
+		info := &registry[i]
+		for _, source := range info.EventSources {
+			if source == eventSource {
+				info.Classifier = classifier
+				classifiersByEventSource[eventSource] = classifier
+			}
+		}
+	}
+	if _, ok := classifiersByEventSource[eventSource]; !ok {
+		panic("classifier for unregistered event source " + eventSource)
+	}
+}
+
+// LookupClassifier returns the classifier attached to the resource types of a CloudTrail event source
+func LookupClassifier(eventSource string) (interface{}, bool) {
+	classifier, ok := classifiersByEventSource[eventSource]
+	return classifier, ok
+}
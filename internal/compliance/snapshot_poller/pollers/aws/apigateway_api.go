@@ -0,0 +1,440 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/apigateway"
+	"github.com/aws/aws-sdk-go/service/apigateway/apigatewayiface"
+	"github.com/aws/aws-sdk-go/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go/service/apigatewayv2/apigatewayv2iface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
+)
+
+func init() {
+	resourcetypes.RegisterPollers(awsmodels.ApiGatewayHttpApiSchema, &resourcetypes.ResourcePollers{
+		Service: PollApiGatewayHttpApis,
+		ARN:     PollApiGatewayHttpApi,
+	})
+	resourcetypes.RegisterPollers(awsmodels.ApiGatewayRestApiSchema, &resourcetypes.ResourcePollers{
+		Service: PollApiGatewayRestApis,
+		ARN:     PollApiGatewayRestApi,
+	})
+}
+
+const (
+	apiGatewayRestApiPrefix = "/restapis/"
+	apiGatewayHttpApiPrefix = "/apis/"
+)
+
+// Set as variables to be overridden in testing
+var (
+	ApiGatewayClientFunc   = setupApiGatewayClient
+	ApiGatewayV2ClientFunc = setupApiGatewayV2Client
+)
+
+func setupApiGatewayClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return apigateway.New(sess, cfg)
+}
+
+func getApiGatewayClient(pollerResourceInput *awsmodels.ResourcePollerInput, region string) (apigatewayiface.APIGatewayAPI, error) {
+	client, err := getClient(pollerResourceInput, ApiGatewayClientFunc, "apigateway", region)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.(apigatewayiface.APIGatewayAPI), nil
+}
+
+func setupApiGatewayV2Client(sess *session.Session, cfg *aws.Config) interface{} {
+	return apigatewayv2.New(sess, cfg)
+}
+
+func getApiGatewayV2Client(pollerResourceInput *awsmodels.ResourcePollerInput, region string) (apigatewayv2iface.ApiGatewayV2API, error) {
+	client, err := getClient(pollerResourceInput, ApiGatewayV2ClientFunc, "apigatewayv2", region)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.(apigatewayv2iface.ApiGatewayV2API), nil
+}
+
+// apiGatewayARN builds the ARN of an API Gateway API
+//
+// API Gateway ARNs do not contain the account ID, e.g. arn:aws:apigateway:us-west-2::/restapis/a1b2c3d4e5
+func apiGatewayARN(partition, region, prefix, apiID string) string {
+	return arn.ARN{
+		Partition: partition,
+		Service:   "apigateway",
+		Region:    region,
+		Resource:  prefix + apiID,
+	}.String()
+}
+
+// PollApiGatewayRestApi polls a single API Gateway REST API resource
+func PollApiGatewayRestApi(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	_ *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getApiGatewayClient(pollerResourceInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	restAPIID := strings.TrimPrefix(resourceARN.Resource, apiGatewayRestApiPrefix)
+	restAPI, err := getRestApi(client, aws.String(restAPIID))
+	if err != nil || restAPI == nil {
+		return nil, err
+	}
+
+	snapshot, err := buildApiGatewayRestApiSnapshot(client, resourceARN.Partition, resourceARN.Region, restAPI)
+	if err != nil || snapshot == nil {
+		return nil, err
+	}
+	snapshot.AccountID = aws.String(pollerResourceInput.AuthSourceParsedARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// getRestApi returns a REST API, or nil if the API no longer exists
+func getRestApi(svc apigatewayiface.APIGatewayAPI, restAPIID *string) (*apigateway.RestApi, error) {
+	restAPI, err := svc.GetRestApi(&apigateway.GetRestApiInput{RestApiId: restAPIID})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == apigateway.ErrCodeNotFoundException {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", *restAPIID),
+				zap.String("resourceType", awsmodels.ApiGatewayRestApiSchema))
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "APIGateway.GetRestApi: %s", aws.StringValue(restAPIID))
+	}
+	return restAPI, nil
+}
+
+// listRestApis returns all the REST APIs in a region
+func listRestApis(svc apigatewayiface.APIGatewayAPI, nextMarker *string) (restAPIs []*apigateway.RestApi, marker *string, err error) {
+	err = svc.GetRestApisPages(&apigateway.GetRestApisInput{
+		Limit:    aws.Int64(int64(defaultBatchSize)),
+		Position: nextMarker,
+	},
+		func(page *apigateway.GetRestApisOutput, lastPage bool) bool {
+			return apiGatewayRestApiIterator(page, &restAPIs, &marker)
+		})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "APIGateway.GetRestApisPages")
+	}
+	return
+}
+
+func apiGatewayRestApiIterator(page *apigateway.GetRestApisOutput, restAPIs *[]*apigateway.RestApi, marker **string) bool {
+	*restAPIs = append(*restAPIs, page.Items...)
+	*marker = page.Position
+	return len(*restAPIs) < defaultBatchSize
+}
+
+// getRestApiStages returns all the stages of a REST API
+func getRestApiStages(svc apigatewayiface.APIGatewayAPI, restAPIID *string) ([]*apigateway.Stage, error) {
+	out, err := svc.GetStages(&apigateway.GetStagesInput{RestApiId: restAPIID})
+	if err != nil {
+		return nil, errors.Wrapf(err, "APIGateway.GetStages: %s", aws.StringValue(restAPIID))
+	}
+	return out.Item, nil
+}
+
+// buildApiGatewayRestApiSnapshot makes all the calls to build up a snapshot of a given REST API
+func buildApiGatewayRestApiSnapshot(
+	svc apigatewayiface.APIGatewayAPI,
+	partition, region string,
+	restAPI *apigateway.RestApi,
+) (*awsmodels.ApiGatewayRestApi, error) {
+
+	if restAPI == nil {
+		return nil, nil
+	}
+
+	restAPIARN := aws.String(apiGatewayARN(partition, region, apiGatewayRestApiPrefix, aws.StringValue(restAPI.Id)))
+	snapshot := &awsmodels.ApiGatewayRestApi{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   restAPIARN,
+			ResourceType: aws.String(awsmodels.ApiGatewayRestApiSchema),
+			TimeCreated:  restAPI.CreatedDate,
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  restAPIARN,
+			ID:   restAPI.Id,
+			Name: restAPI.Name,
+			Tags: restAPI.Tags,
+		},
+		ApiKeySource:              restAPI.ApiKeySource,
+		BinaryMediaTypes:          restAPI.BinaryMediaTypes,
+		Description:               restAPI.Description,
+		DisableExecuteApiEndpoint: restAPI.DisableExecuteApiEndpoint,
+		EndpointConfiguration:     restAPI.EndpointConfiguration,
+		MinimumCompressionSize:    restAPI.MinimumCompressionSize,
+		Policy:                    restAPI.Policy,
+		Version:                   restAPI.Version,
+		Warnings:                  restAPI.Warnings,
+	}
+
+	var err error
+	if snapshot.Stages, err = getRestApiStages(svc, restAPI.Id); err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// PollApiGatewayRestApis gathers information on each API Gateway REST API for an AWS account.
+func PollApiGatewayRestApis(pollerInput *awsmodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	zap.L().Debug("starting API Gateway REST API resource poller")
+
+	svc, err := getApiGatewayClient(pollerInput, *pollerInput.Region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Start with generating a list of all REST APIs
+	restAPIs, marker, err := listRestApis(svc, pollerInput.NextPageToken)
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "region: %s", *pollerInput.Region)
+	}
+
+	resources := make([]apimodels.AddResourceEntry, 0, len(restAPIs))
+	for _, restAPI := range restAPIs {
+		snapshot, err := buildApiGatewayRestApiSnapshot(svc, pollerInput.AuthSourceParsedARN.Partition, *pollerInput.Region, restAPI)
+		if err != nil {
+			return nil, nil, err
+		}
+		snapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		snapshot.Region = pollerInput.Region
+
+		resources = append(resources, apimodels.AddResourceEntry{
+			Attributes:      snapshot,
+			ID:              *snapshot.ResourceID,
+			IntegrationID:   *pollerInput.IntegrationID,
+			IntegrationType: integrationType,
+			Type:            awsmodels.ApiGatewayRestApiSchema,
+		})
+	}
+
+	return resources, marker, nil
+}
+
+// PollApiGatewayHttpApi polls a single API Gateway HTTP API resource
+func PollApiGatewayHttpApi(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	_ *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getApiGatewayV2Client(pollerResourceInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	httpAPIID := strings.TrimPrefix(resourceARN.Resource, apiGatewayHttpApiPrefix)
+	httpAPI, err := getHttpApi(client, aws.String(httpAPIID))
+	if err != nil || httpAPI == nil {
+		return nil, err
+	}
+
+	snapshot, err := buildApiGatewayHttpApiSnapshot(client, resourceARN.Partition, resourceARN.Region, httpAPI)
+	if err != nil || snapshot == nil {
+		return nil, err
+	}
+	snapshot.AccountID = aws.String(pollerResourceInput.AuthSourceParsedARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// getHttpApi returns an HTTP API, or nil if the API no longer exists
+//
+// WebSocket APIs share the same API and ARN format, they are not returned.
+func getHttpApi(svc apigatewayv2iface.ApiGatewayV2API, httpAPIID *string) (*apigatewayv2.Api, error) {
+	out, err := svc.GetApi(&apigatewayv2.GetApiInput{ApiId: httpAPIID})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == apigatewayv2.ErrCodeNotFoundException {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", *httpAPIID),
+				zap.String("resourceType", awsmodels.ApiGatewayHttpApiSchema))
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "APIGatewayV2.GetApi: %s", aws.StringValue(httpAPIID))
+	}
+	if aws.StringValue(out.ProtocolType) != apigatewayv2.ProtocolTypeHttp {
+		return nil, nil
+	}
+	return &apigatewayv2.Api{
+		ApiEndpoint:               out.ApiEndpoint,
+		ApiGatewayManaged:         out.ApiGatewayManaged,
+		ApiId:                     out.ApiId,
+		ApiKeySelectionExpression: out.ApiKeySelectionExpression,
+		CorsConfiguration:         out.CorsConfiguration,
+		CreatedDate:               out.CreatedDate,
+		Description:               out.Description,
+		DisableExecuteApiEndpoint: out.DisableExecuteApiEndpoint,
+		DisableSchemaValidation:   out.DisableSchemaValidation,
+		ImportInfo:                out.ImportInfo,
+		Name:                      out.Name,
+		ProtocolType:              out.ProtocolType,
+		RouteSelectionExpression:  out.RouteSelectionExpression,
+		Tags:                      out.Tags,
+		Version:                   out.Version,
+		Warnings:                  out.Warnings,
+	}, nil
+}
+
+// listHttpApis returns the HTTP APIs in a region
+//
+// The API Gateway V2 SDK does not provide a paginator for this call, so we follow the tokens ourselves.
+func listHttpApis(svc apigatewayv2iface.ApiGatewayV2API, nextMarker *string) (httpAPIs []*apigatewayv2.Api, marker *string, err error) {
+	marker = nextMarker
+	numAPIs := 0
+	for {
+		out, err := svc.GetApis(&apigatewayv2.GetApisInput{
+			MaxResults: aws.String(strconv.Itoa(defaultBatchSize)),
+			NextToken:  marker,
+		})
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "APIGatewayV2.GetApis")
+		}
+		for _, api := range out.Items {
+			if aws.StringValue(api.ProtocolType) == apigatewayv2.ProtocolTypeHttp {
+				httpAPIs = append(httpAPIs, api)
+			}
+		}
+		numAPIs += len(out.Items)
+		marker = out.NextToken
+		if marker == nil || numAPIs >= defaultBatchSize {
+			return httpAPIs, marker, nil
+		}
+	}
+}
+
+// getHttpApiStages returns all the stages of an HTTP API
+func getHttpApiStages(svc apigatewayv2iface.ApiGatewayV2API, httpAPIID *string) (stages []*apigatewayv2.Stage, err error) {
+	input := &apigatewayv2.GetStagesInput{ApiId: httpAPIID}
+	for {
+		out, err := svc.GetStages(input)
+		if err != nil {
+			return nil, errors.Wrapf(err, "APIGatewayV2.GetStages: %s", aws.StringValue(httpAPIID))
+		}
+		stages = append(stages, out.Items...)
+		if out.NextToken == nil {
+			return stages, nil
+		}
+		input.NextToken = out.NextToken
+	}
+}
+
+// buildApiGatewayHttpApiSnapshot makes all the calls to build up a snapshot of a given HTTP API
+func buildApiGatewayHttpApiSnapshot(
+	svc apigatewayv2iface.ApiGatewayV2API,
+	partition, region string,
+	httpAPI *apigatewayv2.Api,
+) (*awsmodels.ApiGatewayHttpApi, error) {
+
+	if httpAPI == nil {
+		return nil, nil
+	}
+
+	httpAPIARN := aws.String(apiGatewayARN(partition, region, apiGatewayHttpApiPrefix, aws.StringValue(httpAPI.ApiId)))
+	snapshot := &awsmodels.ApiGatewayHttpApi{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   httpAPIARN,
+			ResourceType: aws.String(awsmodels.ApiGatewayHttpApiSchema),
+			TimeCreated:  httpAPI.CreatedDate,
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  httpAPIARN,
+			ID:   httpAPI.ApiId,
+			Name: httpAPI.Name,
+			Tags: httpAPI.Tags,
+		},
+		ApiEndpoint:               httpAPI.ApiEndpoint,
+		ApiGatewayManaged:         httpAPI.ApiGatewayManaged,
+		CorsConfiguration:         httpAPI.CorsConfiguration,
+		Description:               httpAPI.Description,
+		DisableExecuteApiEndpoint: httpAPI.DisableExecuteApiEndpoint,
+		ImportInfo:                httpAPI.ImportInfo,
+		ProtocolType:              httpAPI.ProtocolType,
+		RouteSelectionExpression:  httpAPI.RouteSelectionExpression,
+		Version:                   httpAPI.Version,
+		Warnings:                  httpAPI.Warnings,
+	}
+
+	var err error
+	if snapshot.Stages, err = getHttpApiStages(svc, httpAPI.ApiId); err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// PollApiGatewayHttpApis gathers information on each API Gateway HTTP API for an AWS account.
+func PollApiGatewayHttpApis(pollerInput *awsmodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	zap.L().Debug("starting API Gateway HTTP API resource poller")
+
+	svc, err := getApiGatewayV2Client(pollerInput, *pollerInput.Region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Start with generating a list of all HTTP APIs
+	httpAPIs, marker, err := listHttpApis(svc, pollerInput.NextPageToken)
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "region: %s", *pollerInput.Region)
+	}
+
+	resources := make([]apimodels.AddResourceEntry, 0, len(httpAPIs))
+	for _, httpAPI := range httpAPIs {
+		snapshot, err := buildApiGatewayHttpApiSnapshot(svc, pollerInput.AuthSourceParsedARN.Partition, *pollerInput.Region, httpAPI)
+		if err != nil {
+			return nil, nil, err
+		}
+		snapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		snapshot.Region = pollerInput.Region
+
+		resources = append(resources, apimodels.AddResourceEntry{
+			Attributes:      snapshot,
+			ID:              *snapshot.ResourceID,
+			IntegrationID:   *pollerInput.IntegrationID,
+			IntegrationType: integrationType,
+			Type:            awsmodels.ApiGatewayHttpApiSchema,
+		})
+	}
+
+	return resources, marker, nil
+}
@@ -0,0 +1,219 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/apigateway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestApiGatewayRestApiList(t *testing.T) {
+	mockSvc := awstest.BuildMockApiGatewaySvc([]string{"GetRestApisPages"})
+
+	out, marker, err := listRestApis(mockSvc, nil)
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	assert.Len(t, out, 2)
+}
+
+func TestApiGatewayRestApiListError(t *testing.T) {
+	mockSvc := awstest.BuildMockApiGatewaySvcError([]string{"GetRestApisPages"})
+
+	out, marker, err := listRestApis(mockSvc, nil)
+	require.Error(t, err)
+	assert.Nil(t, marker)
+	assert.Nil(t, out)
+}
+
+// Test the iterator works on consecutive pages but stops at max page size
+func TestApiGatewayRestApiListIterator(t *testing.T) {
+	var restAPIs []*apigateway.RestApi
+	var marker *string
+
+	cont := apiGatewayRestApiIterator(awstest.ExampleGetRestApisOutput, &restAPIs, &marker)
+	assert.True(t, cont)
+	assert.Nil(t, marker)
+	assert.Len(t, restAPIs, 2)
+
+	for i := 2; i < 50; i++ {
+		cont = apiGatewayRestApiIterator(awstest.ExampleGetRestApisOutputContinue, &restAPIs, &marker)
+		assert.True(t, cont)
+		assert.NotNil(t, marker)
+		assert.Len(t, restAPIs, i*2)
+	}
+
+	cont = apiGatewayRestApiIterator(awstest.ExampleGetRestApisOutputContinue, &restAPIs, &marker)
+	assert.False(t, cont)
+	assert.NotNil(t, marker)
+	assert.Len(t, restAPIs, 100)
+}
+
+func TestBuildApiGatewayRestApiSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockApiGatewaySvcAll()
+
+	restAPI, err := buildApiGatewayRestApiSnapshot(mockSvc, "aws", "us-west-2", awstest.ExampleRestApi)
+	require.NoError(t, err)
+	assert.Equal(t, "arn:aws:apigateway:us-west-2::/restapis/a1b2c3d4e5", *restAPI.ARN)
+	assert.Equal(t, awstest.ExampleRestApiId, restAPI.ID)
+	assert.Equal(t, "example-rest-api", *restAPI.Name)
+	assert.Equal(t, "REGIONAL", *restAPI.EndpointConfiguration.Types[0])
+	assert.NotEmpty(t, restAPI.Policy)
+	require.Len(t, restAPI.Stages, 1)
+	assert.Equal(t, "prod", *restAPI.Stages[0].StageName)
+	assert.Equal(t, aws.String("Value1"), restAPI.Tags["Key1"])
+}
+
+func TestBuildApiGatewayRestApiSnapshotError(t *testing.T) {
+	mockSvc := awstest.BuildMockApiGatewaySvcAllError()
+
+	restAPI, err := buildApiGatewayRestApiSnapshot(mockSvc, "aws", "us-west-2", awstest.ExampleRestApi)
+	require.Error(t, err)
+	assert.Nil(t, restAPI)
+}
+
+func TestApiGatewayRestApiPoller(t *testing.T) {
+	awstest.MockApiGatewayForSetup = awstest.BuildMockApiGatewaySvcAll()
+
+	ApiGatewayClientFunc = awstest.SetupMockApiGateway
+
+	resources, marker, err := PollApiGatewayRestApis(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	assert.Len(t, resources, 2)
+}
+
+func TestApiGatewayRestApiPollerError(t *testing.T) {
+	resetCache()
+	awstest.MockApiGatewayForSetup = awstest.BuildMockApiGatewaySvcAllError()
+
+	ApiGatewayClientFunc = awstest.SetupMockApiGateway
+
+	resources, marker, err := PollApiGatewayRestApis(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, marker)
+	assert.Nil(t, resources)
+}
+
+// WebSocket APIs are listed by the same call but are not HTTP APIs
+func TestApiGatewayHttpApiList(t *testing.T) {
+	mockSvc := awstest.BuildMockApiGatewayV2Svc([]string{"GetApis"})
+
+	out, marker, err := listHttpApis(mockSvc, nil)
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	require.Len(t, out, 1)
+	assert.Equal(t, awstest.ExampleHttpApiId, out[0].ApiId)
+}
+
+func TestApiGatewayHttpApiListError(t *testing.T) {
+	mockSvc := awstest.BuildMockApiGatewayV2SvcError([]string{"GetApis"})
+
+	out, marker, err := listHttpApis(mockSvc, nil)
+	require.Error(t, err)
+	assert.Nil(t, marker)
+	assert.Nil(t, out)
+}
+
+func TestApiGatewayHttpApiGet(t *testing.T) {
+	mockSvc := awstest.BuildMockApiGatewayV2Svc([]string{"GetApi"})
+
+	httpAPI, err := getHttpApi(mockSvc, awstest.ExampleHttpApiId)
+	require.NoError(t, err)
+	assert.Equal(t, awstest.ExampleHttpApiId, httpAPI.ApiId)
+	assert.Equal(t, []*string{aws.String("*")}, httpAPI.CorsConfiguration.AllowOrigins)
+}
+
+func TestBuildApiGatewayHttpApiSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockApiGatewayV2SvcAll()
+
+	httpAPI, err := buildApiGatewayHttpApiSnapshot(mockSvc, "aws", "us-west-2", awstest.ExampleGetApisOutput.Items[0])
+	require.NoError(t, err)
+	assert.Equal(t, "arn:aws:apigateway:us-west-2::/apis/k1l2m3n4o5", *httpAPI.ARN)
+	assert.Equal(t, awstest.ExampleHttpApiId, httpAPI.ID)
+	assert.Equal(t, "HTTP", *httpAPI.ProtocolType)
+	require.Len(t, httpAPI.Stages, 1)
+	assert.True(t, *httpAPI.Stages[0].AutoDeploy)
+	assert.Equal(t, aws.String("Value1"), httpAPI.Tags["Key1"])
+}
+
+func TestBuildApiGatewayHttpApiSnapshotError(t *testing.T) {
+	mockSvc := awstest.BuildMockApiGatewayV2SvcAllError()
+
+	httpAPI, err := buildApiGatewayHttpApiSnapshot(mockSvc, "aws", "us-west-2", awstest.ExampleGetApisOutput.Items[0])
+	require.Error(t, err)
+	assert.Nil(t, httpAPI)
+}
+
+func TestApiGatewayHttpApiPoller(t *testing.T) {
+	awstest.MockApiGatewayV2ForSetup = awstest.BuildMockApiGatewayV2SvcAll()
+
+	ApiGatewayV2ClientFunc = awstest.SetupMockApiGatewayV2
+
+	resources, marker, err := PollApiGatewayHttpApis(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	assert.Len(t, resources, 1)
+}
+
+func TestApiGatewayHttpApiPollerError(t *testing.T) {
+	resetCache()
+	awstest.MockApiGatewayV2ForSetup = awstest.BuildMockApiGatewayV2SvcAllError()
+
+	ApiGatewayV2ClientFunc = awstest.SetupMockApiGatewayV2
+
+	resources, marker, err := PollApiGatewayHttpApis(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, marker)
+	assert.Nil(t, resources)
+}
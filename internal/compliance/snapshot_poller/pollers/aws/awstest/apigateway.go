@@ -0,0 +1,202 @@
+package awstest
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/apigateway"
+	"github.com/aws/aws-sdk-go/service/apigateway/apigatewayiface"
+	"github.com/stretchr/testify/mock"
+)
+
+// Example APIGateway API return values
+var (
+	ExampleRestApiId = aws.String("a1b2c3d4e5")
+
+	ExampleRestApi = &apigateway.RestApi{
+		ApiKeySource: aws.String("HEADER"),
+		CreatedDate:  &ExampleTime,
+		Description:  aws.String("Example REST API"),
+		EndpointConfiguration: &apigateway.EndpointConfiguration{
+			Types: []*string{aws.String("REGIONAL")},
+		},
+		Id:     ExampleRestApiId,
+		Name:   aws.String("example-rest-api"),
+		Policy: aws.String(`{"Version":"2012-10-17","Statement":[]}`),
+		Tags: map[string]*string{
+			"Key1": aws.String("Value1"),
+		},
+	}
+
+	ExampleGetRestApisOutput = &apigateway.GetRestApisOutput{
+		Items: []*apigateway.RestApi{
+			ExampleRestApi,
+			{
+				CreatedDate: &ExampleTime,
+				Id:          aws.String("f6g7h8i9j0"),
+				Name:        aws.String("example-rest-api-2"),
+			},
+		},
+	}
+
+	ExampleGetRestApisOutputContinue = &apigateway.GetRestApisOutput{
+		Items:    ExampleGetRestApisOutput.Items,
+		Position: aws.String("1"),
+	}
+
+	ExampleGetRestApiOutput = &apigateway.RestApi{
+		ApiKeySource:          ExampleRestApi.ApiKeySource,
+		CreatedDate:           ExampleRestApi.CreatedDate,
+		Description:           ExampleRestApi.Description,
+		EndpointConfiguration: ExampleRestApi.EndpointConfiguration,
+		Id:                    ExampleRestApi.Id,
+		Name:                  ExampleRestApi.Name,
+		Policy:                ExampleRestApi.Policy,
+		Tags:                  ExampleRestApi.Tags,
+	}
+
+	ExampleGetStagesOutput = &apigateway.GetStagesOutput{
+		Item: []*apigateway.Stage{
+			{
+				CacheClusterEnabled: aws.Bool(false),
+				DeploymentId:        aws.String("abc123"),
+				StageName:           aws.String("prod"),
+				TracingEnabled:      aws.Bool(true),
+				WebAclArn:           aws.String("arn:aws:wafv2:us-west-2:123456789012:regional/webacl/example/1234"),
+			},
+		},
+	}
+
+	svcApiGatewaySetupCalls = map[string]func(*MockApiGateway){
+		"GetRestApisPages": func(svc *MockApiGateway) {
+			svc.On("GetRestApisPages", mock.Anything).
+				Return(nil)
+		},
+		"GetRestApi": func(svc *MockApiGateway) {
+			svc.On("GetRestApi", mock.Anything).
+				Return(ExampleGetRestApiOutput, nil)
+		},
+		"GetStages": func(svc *MockApiGateway) {
+			svc.On("GetStages", mock.Anything).
+				Return(ExampleGetStagesOutput, nil)
+		},
+	}
+
+	svcApiGatewaySetupCallsError = map[string]func(*MockApiGateway){
+		"GetRestApisPages": func(svc *MockApiGateway) {
+			svc.On("GetRestApisPages", mock.Anything).
+				Return(errors.New("APIGateway.GetRestApisPages error"))
+		},
+		"GetRestApi": func(svc *MockApiGateway) {
+			svc.On("GetRestApi", mock.Anything).
+				Return(&apigateway.RestApi{},
+					errors.New("APIGateway.GetRestApi error"))
+		},
+		"GetStages": func(svc *MockApiGateway) {
+			svc.On("GetStages", mock.Anything).
+				Return(&apigateway.GetStagesOutput{},
+					errors.New("APIGateway.GetStages error"))
+		},
+	}
+
+	MockApiGatewayForSetup = &MockApiGateway{}
+)
+
+// APIGateway mock
+
+// SetupMockApiGateway is used to override the APIGateway Client initializer
+func SetupMockApiGateway(_ *session.Session, _ *aws.Config) interface{} {
+	return MockApiGatewayForSetup
+}
+
+// MockApiGateway is a mock APIGateway client
+type MockApiGateway struct {
+	apigatewayiface.APIGatewayAPI
+	mock.Mock
+}
+
+// BuildMockApiGatewaySvc builds and returns a MockApiGateway struct
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockApiGatewaySvc(funcs []string) (mockSvc *MockApiGateway) {
+	mockSvc = &MockApiGateway{}
+	for _, f := range funcs {
+		svcApiGatewaySetupCalls[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockApiGatewaySvcError builds and returns a MockApiGateway struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockApiGatewaySvcError(funcs []string) (mockSvc *MockApiGateway) {
+	mockSvc = &MockApiGateway{}
+	for _, f := range funcs {
+		svcApiGatewaySetupCallsError[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockApiGatewaySvcAll builds and returns a MockApiGateway struct
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockApiGatewaySvcAll() (mockSvc *MockApiGateway) {
+	mockSvc = &MockApiGateway{}
+	for _, f := range svcApiGatewaySetupCalls {
+		f(mockSvc)
+	}
+	return
+}
+
+// BuildMockApiGatewaySvcAllError builds and returns a MockApiGateway struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockApiGatewaySvcAllError() (mockSvc *MockApiGateway) {
+	mockSvc = &MockApiGateway{}
+	for _, f := range svcApiGatewaySetupCallsError {
+		f(mockSvc)
+	}
+	return
+}
+
+func (m *MockApiGateway) GetRestApisPages(
+	in *apigateway.GetRestApisInput,
+	paginationFunction func(*apigateway.GetRestApisOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	paginationFunction(ExampleGetRestApisOutput, true)
+	return args.Error(0)
+}
+
+func (m *MockApiGateway) GetRestApi(in *apigateway.GetRestApiInput) (*apigateway.RestApi, error) {
+	args := m.Called(in)
+	return args.Get(0).(*apigateway.RestApi), args.Error(1)
+}
+
+func (m *MockApiGateway) GetStages(in *apigateway.GetStagesInput) (*apigateway.GetStagesOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*apigateway.GetStagesOutput), args.Error(1)
+}
@@ -0,0 +1,194 @@
+package awstest
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go/service/apigatewayv2/apigatewayv2iface"
+	"github.com/stretchr/testify/mock"
+)
+
+// Example APIGatewayV2 API return values
+var (
+	ExampleHttpApiId = aws.String("k1l2m3n4o5")
+
+	ExampleGetApisOutput = &apigatewayv2.GetApisOutput{
+		Items: []*apigatewayv2.Api{
+			{
+				ApiEndpoint:  aws.String("https://k1l2m3n4o5.execute-api.us-west-2.amazonaws.com"),
+				ApiId:        ExampleHttpApiId,
+				CreatedDate:  &ExampleTime,
+				Name:         aws.String("example-http-api"),
+				ProtocolType: aws.String("HTTP"),
+				Tags: map[string]*string{
+					"Key1": aws.String("Value1"),
+				},
+			},
+			{
+				ApiEndpoint:  aws.String("wss://p6q7r8s9t0.execute-api.us-west-2.amazonaws.com"),
+				ApiId:        aws.String("p6q7r8s9t0"),
+				CreatedDate:  &ExampleTime,
+				Name:         aws.String("example-websocket-api"),
+				ProtocolType: aws.String("WEBSOCKET"),
+			},
+		},
+	}
+
+	ExampleGetApisOutputContinue = &apigatewayv2.GetApisOutput{
+		Items:     ExampleGetApisOutput.Items,
+		NextToken: aws.String("1"),
+	}
+
+	ExampleGetApiOutput = &apigatewayv2.GetApiOutput{
+		ApiEndpoint: aws.String("https://k1l2m3n4o5.execute-api.us-west-2.amazonaws.com"),
+		ApiId:       ExampleHttpApiId,
+		CorsConfiguration: &apigatewayv2.Cors{
+			AllowOrigins: []*string{aws.String("*")},
+		},
+		CreatedDate:  &ExampleTime,
+		Name:         aws.String("example-http-api"),
+		ProtocolType: aws.String("HTTP"),
+		Tags: map[string]*string{
+			"Key1": aws.String("Value1"),
+		},
+	}
+
+	ExampleGetStagesOutputV2 = &apigatewayv2.GetStagesOutput{
+		Items: []*apigatewayv2.Stage{
+			{
+				AccessLogSettings: &apigatewayv2.AccessLogSettings{
+					DestinationArn: aws.String("arn:aws:logs:us-west-2:123456789012:log-group:example-http-api"),
+				},
+				AutoDeploy: aws.Bool(true),
+				StageName:  aws.String("$default"),
+			},
+		},
+	}
+
+	svcApiGatewayV2SetupCalls = map[string]func(*MockApiGatewayV2){
+		"GetApis": func(svc *MockApiGatewayV2) {
+			svc.On("GetApis", mock.Anything).
+				Return(ExampleGetApisOutput, nil)
+		},
+		"GetApi": func(svc *MockApiGatewayV2) {
+			svc.On("GetApi", mock.Anything).
+				Return(ExampleGetApiOutput, nil)
+		},
+		"GetStages": func(svc *MockApiGatewayV2) {
+			svc.On("GetStages", mock.Anything).
+				Return(ExampleGetStagesOutputV2, nil)
+		},
+	}
+
+	svcApiGatewayV2SetupCallsError = map[string]func(*MockApiGatewayV2){
+		"GetApis": func(svc *MockApiGatewayV2) {
+			svc.On("GetApis", mock.Anything).
+				Return(&apigatewayv2.GetApisOutput{},
+					errors.New("APIGatewayV2.GetApis error"))
+		},
+		"GetApi": func(svc *MockApiGatewayV2) {
+			svc.On("GetApi", mock.Anything).
+				Return(&apigatewayv2.GetApiOutput{},
+					errors.New("APIGatewayV2.GetApi error"))
+		},
+		"GetStages": func(svc *MockApiGatewayV2) {
+			svc.On("GetStages", mock.Anything).
+				Return(&apigatewayv2.GetStagesOutput{},
+					errors.New("APIGatewayV2.GetStages error"))
+		},
+	}
+
+	MockApiGatewayV2ForSetup = &MockApiGatewayV2{}
+)
+
+// APIGatewayV2 mock
+
+// SetupMockApiGatewayV2 is used to override the APIGatewayV2 Client initializer
+func SetupMockApiGatewayV2(_ *session.Session, _ *aws.Config) interface{} {
+	return MockApiGatewayV2ForSetup
+}
+
+// MockApiGatewayV2 is a mock APIGatewayV2 client
+type MockApiGatewayV2 struct {
+	apigatewayv2iface.ApiGatewayV2API
+	mock.Mock
+}
+
+// BuildMockApiGatewayV2Svc builds and returns a MockApiGatewayV2 struct
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockApiGatewayV2Svc(funcs []string) (mockSvc *MockApiGatewayV2) {
+	mockSvc = &MockApiGatewayV2{}
+	for _, f := range funcs {
+		svcApiGatewayV2SetupCalls[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockApiGatewayV2SvcError builds and returns a MockApiGatewayV2 struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockApiGatewayV2SvcError(funcs []string) (mockSvc *MockApiGatewayV2) {
+	mockSvc = &MockApiGatewayV2{}
+	for _, f := range funcs {
+		svcApiGatewayV2SetupCallsError[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockApiGatewayV2SvcAll builds and returns a MockApiGatewayV2 struct
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockApiGatewayV2SvcAll() (mockSvc *MockApiGatewayV2) {
+	mockSvc = &MockApiGatewayV2{}
+	for _, f := range svcApiGatewayV2SetupCalls {
+		f(mockSvc)
+	}
+	return
+}
+
+// BuildMockApiGatewayV2SvcAllError builds and returns a MockApiGatewayV2 struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockApiGatewayV2SvcAllError() (mockSvc *MockApiGatewayV2) {
+	mockSvc = &MockApiGatewayV2{}
+	for _, f := range svcApiGatewayV2SetupCallsError {
+		f(mockSvc)
+	}
+	return
+}
+
+func (m *MockApiGatewayV2) GetApis(in *apigatewayv2.GetApisInput) (*apigatewayv2.GetApisOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*apigatewayv2.GetApisOutput), args.Error(1)
+}
+
+func (m *MockApiGatewayV2) GetApi(in *apigatewayv2.GetApiInput) (*apigatewayv2.GetApiOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*apigatewayv2.GetApiOutput), args.Error(1)
+}
+
+func (m *MockApiGatewayV2) GetStages(in *apigatewayv2.GetStagesInput) (*apigatewayv2.GetStagesOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*apigatewayv2.GetStagesOutput), args.Error(1)
+}
@@ -486,6 +486,73 @@ var (
 		},
 	}
 
+	ExampleTransitGatewayId = aws.String("tgw-0262a0e521EXAMPLE")
+
+	ExampleDescribeTransitGatewaysOutput = &ec2.DescribeTransitGatewaysOutput{
+		TransitGateways: []*ec2.TransitGateway{
+			{
+				CreationTime: &ExampleTime,
+				Description:  aws.String("Example transit gateway"),
+				Options: &ec2.TransitGatewayOptions{
+					AmazonSideAsn:                aws.Int64(64512),
+					AutoAcceptSharedAttachments:  aws.String("disable"),
+					DefaultRouteTableAssociation: aws.String("enable"),
+					DefaultRouteTablePropagation: aws.String("enable"),
+					DnsSupport:                   aws.String("enable"),
+					VpnEcmpSupport:               aws.String("enable"),
+				},
+				OwnerId:           aws.String("123456789012"),
+				State:             aws.String("available"),
+				TransitGatewayArn: aws.String("arn:aws:ec2:us-west-2:123456789012:transit-gateway/tgw-0262a0e521EXAMPLE"),
+				TransitGatewayId:  ExampleTransitGatewayId,
+				Tags: []*ec2.Tag{
+					{
+						Key:   aws.String("Name"),
+						Value: aws.String("example-tgw"),
+					},
+				},
+			},
+			{
+				CreationTime:      &ExampleTime,
+				OwnerId:           aws.String("123456789012"),
+				State:             aws.String("available"),
+				TransitGatewayArn: aws.String("arn:aws:ec2:us-west-2:123456789012:transit-gateway/tgw-0f2d6b0a43EXAMPLE"),
+				TransitGatewayId:  aws.String("tgw-0f2d6b0a43EXAMPLE"),
+			},
+		},
+	}
+
+	ExampleDescribeTransitGatewaysOutputContinue = &ec2.DescribeTransitGatewaysOutput{
+		TransitGateways: ExampleDescribeTransitGatewaysOutput.TransitGateways,
+		NextToken:       aws.String("1"),
+	}
+
+	ExampleDescribeTransitGatewayAttachmentsOutput = &ec2.DescribeTransitGatewayAttachmentsOutput{
+		TransitGatewayAttachments: []*ec2.TransitGatewayAttachment{
+			{
+				ResourceId:                 aws.String("vpc-6253a3f2"),
+				ResourceOwnerId:            aws.String("123456789012"),
+				ResourceType:               aws.String("vpc"),
+				State:                      aws.String("available"),
+				TransitGatewayAttachmentId: aws.String("tgw-attach-0d2c54bdbEXAMPLE"),
+				TransitGatewayId:           ExampleTransitGatewayId,
+				TransitGatewayOwnerId:      aws.String("123456789012"),
+			},
+		},
+	}
+
+	ExampleDescribeTransitGatewayRouteTablesOutput = &ec2.DescribeTransitGatewayRouteTablesOutput{
+		TransitGatewayRouteTables: []*ec2.TransitGatewayRouteTable{
+			{
+				DefaultAssociationRouteTable: aws.Bool(true),
+				DefaultPropagationRouteTable: aws.Bool(true),
+				State:                        aws.String("available"),
+				TransitGatewayId:             ExampleTransitGatewayId,
+				TransitGatewayRouteTableId:   aws.String("tgw-rtb-0b36edb9b8EXAMPLE"),
+			},
+		},
+	}
+
 	svcEC2SetupCalls = map[string]func(*MockEC2){
 		"DescribeInstancesPages": func(svc *MockEC2) {
 			svc.On("DescribeInstancesPages", mock.Anything).
@@ -543,6 +610,18 @@ var (
 			svc.On("DescribeSnapshotAttribute", mock.Anything).
 				Return(ExampleDescribeSnapshotAttribute, nil)
 		},
+		"DescribeTransitGatewaysPages": func(svc *MockEC2) {
+			svc.On("DescribeTransitGatewaysPages", mock.Anything).
+				Return(nil)
+		},
+		"DescribeTransitGatewayAttachmentsPages": func(svc *MockEC2) {
+			svc.On("DescribeTransitGatewayAttachmentsPages", mock.Anything).
+				Return(nil)
+		},
+		"DescribeTransitGatewayRouteTablesPages": func(svc *MockEC2) {
+			svc.On("DescribeTransitGatewayRouteTablesPages", mock.Anything).
+				Return(nil)
+		},
 	}
 
 	svcEC2SetupCallsError = map[string]func(*MockEC2){
@@ -597,6 +676,18 @@ var (
 			svc.On("DescribeRegions", mock.Anything).
 				Return(ExampleDescribeRegionsOutput, nil)
 		},
+		"DescribeTransitGatewaysPages": func(svc *MockEC2) {
+			svc.On("DescribeTransitGatewaysPages", mock.Anything).
+				Return(errors.New("EC2.DescribeTransitGatewaysPages error"))
+		},
+		"DescribeTransitGatewayAttachmentsPages": func(svc *MockEC2) {
+			svc.On("DescribeTransitGatewayAttachmentsPages", mock.Anything).
+				Return(errors.New("EC2.DescribeTransitGatewayAttachmentsPages error"))
+		},
+		"DescribeTransitGatewayRouteTablesPages": func(svc *MockEC2) {
+			svc.On("DescribeTransitGatewayRouteTablesPages", mock.Anything).
+				Return(errors.New("EC2.DescribeTransitGatewayRouteTablesPages error"))
+		},
 	}
 
 	MockEC2ForSetup = &MockEC2{}
@@ -786,6 +877,45 @@ func (m *MockEC2) DescribeSnapshotsPages(
 	return args.Error(0)
 }
 
+func (m *MockEC2) DescribeTransitGatewaysPages(
+	in *ec2.DescribeTransitGatewaysInput,
+	paginationFunction func(*ec2.DescribeTransitGatewaysOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	paginationFunction(ExampleDescribeTransitGatewaysOutput, true)
+	return args.Error(0)
+}
+
+func (m *MockEC2) DescribeTransitGatewayAttachmentsPages(
+	in *ec2.DescribeTransitGatewayAttachmentsInput,
+	paginationFunction func(*ec2.DescribeTransitGatewayAttachmentsOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	paginationFunction(ExampleDescribeTransitGatewayAttachmentsOutput, true)
+	return args.Error(0)
+}
+
+func (m *MockEC2) DescribeTransitGatewayRouteTablesPages(
+	in *ec2.DescribeTransitGatewayRouteTablesInput,
+	paginationFunction func(*ec2.DescribeTransitGatewayRouteTablesOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	paginationFunction(ExampleDescribeTransitGatewayRouteTablesOutput, true)
+	return args.Error(0)
+}
+
 func (m *MockEC2) DescribeRegions(in *ec2.DescribeRegionsInput) (*ec2.DescribeRegionsOutput, error) {
 	args := m.Called(in)
 	return args.Get(0).(*ec2.DescribeRegionsOutput), args.Error(1)
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/eks"
 	"github.com/aws/aws-sdk-go/service/eks/eksiface"
@@ -336,6 +337,16 @@ func (m *MockEks) DescribeCluster(in *eks.DescribeClusterInput) (*eks.DescribeCl
 	return args.Get(0).(*eks.DescribeClusterOutput), args.Error(1)
 }
 
+func (m *MockEks) DescribeClusterWithContext(
+	ctx aws.Context,
+	in *eks.DescribeClusterInput,
+	options ...request.Option,
+) (*eks.DescribeClusterOutput, error) {
+
+	args := m.Called(in)
+	return args.Get(0).(*eks.DescribeClusterOutput), args.Error(1)
+}
+
 func (m *MockEks) DescribeFargateProfile(in *eks.DescribeFargateProfileInput) (*eks.DescribeFargateProfileOutput, error) {
 	args := m.Called(in)
 	return args.Get(0).(*eks.DescribeFargateProfileOutput), args.Error(1)
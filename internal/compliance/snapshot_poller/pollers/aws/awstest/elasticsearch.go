@@ -0,0 +1,187 @@
+package awstest
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/elasticsearchservice"
+	"github.com/aws/aws-sdk-go/service/elasticsearchservice/elasticsearchserviceiface"
+	"github.com/stretchr/testify/mock"
+)
+
+// Example Elasticsearch API return values
+var (
+	ExampleDomainName = aws.String("example-domain")
+
+	ExampleListDomainNamesOutput = &elasticsearchservice.ListDomainNamesOutput{
+		DomainNames: []*elasticsearchservice.DomainInfo{
+			{DomainName: ExampleDomainName},
+			{DomainName: aws.String("example-domain-2")},
+		},
+	}
+
+	ExampleDescribeElasticsearchDomainsOutput = &elasticsearchservice.DescribeElasticsearchDomainsOutput{
+		DomainStatusList: []*elasticsearchservice.ElasticsearchDomainStatus{
+			{
+				ARN:            aws.String("arn:aws:es:us-west-2:123456789012:domain/example-domain"),
+				AccessPolicies: aws.String(`{"Version":"2012-10-17","Statement":[]}`),
+				Created:        aws.Bool(true),
+				Deleted:        aws.Bool(false),
+				DomainEndpointOptions: &elasticsearchservice.DomainEndpointOptions{
+					EnforceHTTPS:      aws.Bool(true),
+					TLSSecurityPolicy: aws.String("Policy-Min-TLS-1-2-2019-07"),
+				},
+				DomainId:             aws.String("123456789012/example-domain"),
+				DomainName:           ExampleDomainName,
+				ElasticsearchVersion: aws.String("7.9"),
+				EncryptionAtRestOptions: &elasticsearchservice.EncryptionAtRestOptions{
+					Enabled: aws.Bool(true),
+				},
+				Endpoint: aws.String("search-example-domain-abc123.us-west-2.es.amazonaws.com"),
+				NodeToNodeEncryptionOptions: &elasticsearchservice.NodeToNodeEncryptionOptions{
+					Enabled: aws.Bool(true),
+				},
+			},
+			{
+				ARN:        aws.String("arn:aws:es:us-west-2:123456789012:domain/example-domain-2"),
+				DomainId:   aws.String("123456789012/example-domain-2"),
+				DomainName: aws.String("example-domain-2"),
+			},
+		},
+	}
+
+	ExampleListTagsElasticsearch = &elasticsearchservice.ListTagsOutput{
+		TagList: []*elasticsearchservice.Tag{
+			{
+				Key:   aws.String("Key1"),
+				Value: aws.String("Value1"),
+			},
+		},
+	}
+
+	svcElasticsearchSetupCalls = map[string]func(*MockElasticsearch){
+		"ListDomainNames": func(svc *MockElasticsearch) {
+			svc.On("ListDomainNames", mock.Anything).
+				Return(ExampleListDomainNamesOutput, nil)
+		},
+		"DescribeElasticsearchDomains": func(svc *MockElasticsearch) {
+			svc.On("DescribeElasticsearchDomains", mock.Anything).
+				Return(ExampleDescribeElasticsearchDomainsOutput, nil)
+		},
+		"ListTags": func(svc *MockElasticsearch) {
+			svc.On("ListTags", mock.Anything).
+				Return(ExampleListTagsElasticsearch, nil)
+		},
+	}
+
+	svcElasticsearchSetupCallsError = map[string]func(*MockElasticsearch){
+		"ListDomainNames": func(svc *MockElasticsearch) {
+			svc.On("ListDomainNames", mock.Anything).
+				Return(&elasticsearchservice.ListDomainNamesOutput{},
+					errors.New("Elasticsearch.ListDomainNames error"))
+		},
+		"DescribeElasticsearchDomains": func(svc *MockElasticsearch) {
+			svc.On("DescribeElasticsearchDomains", mock.Anything).
+				Return(&elasticsearchservice.DescribeElasticsearchDomainsOutput{},
+					errors.New("Elasticsearch.DescribeElasticsearchDomains error"))
+		},
+		"ListTags": func(svc *MockElasticsearch) {
+			svc.On("ListTags", mock.Anything).
+				Return(&elasticsearchservice.ListTagsOutput{},
+					errors.New("Elasticsearch.ListTags error"))
+		},
+	}
+
+	MockElasticsearchForSetup = &MockElasticsearch{}
+)
+
+// Elasticsearch mock
+
+// SetupMockElasticsearch is used to override the Elasticsearch Client initializer
+func SetupMockElasticsearch(_ *session.Session, _ *aws.Config) interface{} {
+	return MockElasticsearchForSetup
+}
+
+// MockElasticsearch is a mock Elasticsearch client
+type MockElasticsearch struct {
+	elasticsearchserviceiface.ElasticsearchServiceAPI
+	mock.Mock
+}
+
+// BuildMockElasticsearchSvc builds and returns a MockElasticsearch struct
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockElasticsearchSvc(funcs []string) (mockSvc *MockElasticsearch) {
+	mockSvc = &MockElasticsearch{}
+	for _, f := range funcs {
+		svcElasticsearchSetupCalls[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockElasticsearchSvcError builds and returns a MockElasticsearch struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockElasticsearchSvcError(funcs []string) (mockSvc *MockElasticsearch) {
+	mockSvc = &MockElasticsearch{}
+	for _, f := range funcs {
+		svcElasticsearchSetupCallsError[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockElasticsearchSvcAll builds and returns a MockElasticsearch struct
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockElasticsearchSvcAll() (mockSvc *MockElasticsearch) {
+	mockSvc = &MockElasticsearch{}
+	for _, f := range svcElasticsearchSetupCalls {
+		f(mockSvc)
+	}
+	return
+}
+
+// BuildMockElasticsearchSvcAllError builds and returns a MockElasticsearch struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockElasticsearchSvcAllError() (mockSvc *MockElasticsearch) {
+	mockSvc = &MockElasticsearch{}
+	for _, f := range svcElasticsearchSetupCallsError {
+		f(mockSvc)
+	}
+	return
+}
+
+func (m *MockElasticsearch) ListDomainNames(in *elasticsearchservice.ListDomainNamesInput) (*elasticsearchservice.ListDomainNamesOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*elasticsearchservice.ListDomainNamesOutput), args.Error(1)
+}
+
+func (m *MockElasticsearch) DescribeElasticsearchDomains(in *elasticsearchservice.DescribeElasticsearchDomainsInput) (*elasticsearchservice.DescribeElasticsearchDomainsOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*elasticsearchservice.DescribeElasticsearchDomainsOutput), args.Error(1)
+}
+
+func (m *MockElasticsearch) ListTags(in *elasticsearchservice.ListTagsInput) (*elasticsearchservice.ListTagsOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*elasticsearchservice.ListTagsOutput), args.Error(1)
+}
@@ -0,0 +1,229 @@
+package awstest
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/stretchr/testify/mock"
+)
+
+// Example SQS API return values
+var (
+	ExampleQueueUrl = aws.String("https://sqs.us-west-2.amazonaws.com/123456789012/example-queue")
+
+	ExampleListQueuesOutput = &sqs.ListQueuesOutput{
+		QueueUrls: []*string{
+			ExampleQueueUrl,
+			aws.String("https://sqs.us-west-2.amazonaws.com/123456789012/example-dlq"),
+		},
+	}
+
+	ExampleListQueuesOutputContinue = &sqs.ListQueuesOutput{
+		QueueUrls: ExampleListQueuesOutput.QueueUrls,
+		NextToken: aws.String("1"),
+	}
+
+	ExampleGetQueueUrlOutput = &sqs.GetQueueUrlOutput{
+		QueueUrl: ExampleQueueUrl,
+	}
+
+	ExampleGetQueueAttributesOutput = &sqs.GetQueueAttributesOutput{
+		Attributes: map[string]*string{
+			"ApproximateNumberOfMessages":   aws.String("10"),
+			"CreatedTimestamp":              aws.String("1580000000"),
+			"DelaySeconds":                  aws.String("0"),
+			"KmsMasterKeyId":                aws.String("alias/aws/sqs"),
+			"KmsDataKeyReusePeriodSeconds":  aws.String("300"),
+			"LastModifiedTimestamp":         aws.String("1590000000"),
+			"MaximumMessageSize":            aws.String("262144"),
+			"MessageRetentionPeriod":        aws.String("345600"),
+			"Policy":                        aws.String(`{"Version":"2012-10-17","Statement":[]}`),
+			"QueueArn":                      aws.String("arn:aws:sqs:us-west-2:123456789012:example-queue"),
+			"ReceiveMessageWaitTimeSeconds": aws.String("20"),
+			"RedrivePolicy":                 aws.String(`{"deadLetterTargetArn":"arn:aws:sqs:us-west-2:123456789012:example-dlq","maxReceiveCount":10}`),
+			"VisibilityTimeout":             aws.String("30"),
+		},
+	}
+
+	ExampleListQueueTagsOutput = &sqs.ListQueueTagsOutput{
+		Tags: map[string]*string{
+			"Key1": aws.String("Value1"),
+		},
+	}
+
+	ExampleListDeadLetterSourceQueuesOutput = &sqs.ListDeadLetterSourceQueuesOutput{
+		QueueUrls: []*string{
+			aws.String("https://sqs.us-west-2.amazonaws.com/123456789012/example-source-queue"),
+		},
+	}
+
+	svcSqsSetupCalls = map[string]func(*MockSqs){
+		"ListQueuesPages": func(svc *MockSqs) {
+			svc.On("ListQueuesPages", mock.Anything).
+				Return(nil)
+		},
+		"GetQueueUrl": func(svc *MockSqs) {
+			svc.On("GetQueueUrl", mock.Anything).
+				Return(ExampleGetQueueUrlOutput, nil)
+		},
+		"GetQueueAttributes": func(svc *MockSqs) {
+			svc.On("GetQueueAttributes", mock.Anything).
+				Return(ExampleGetQueueAttributesOutput, nil)
+		},
+		"ListQueueTags": func(svc *MockSqs) {
+			svc.On("ListQueueTags", mock.Anything).
+				Return(ExampleListQueueTagsOutput, nil)
+		},
+		"ListDeadLetterSourceQueuesPages": func(svc *MockSqs) {
+			svc.On("ListDeadLetterSourceQueuesPages", mock.Anything).
+				Return(nil)
+		},
+	}
+
+	svcSqsSetupCallsError = map[string]func(*MockSqs){
+		"ListQueuesPages": func(svc *MockSqs) {
+			svc.On("ListQueuesPages", mock.Anything).
+				Return(errors.New("SQS.ListQueuesPages error"))
+		},
+		"GetQueueUrl": func(svc *MockSqs) {
+			svc.On("GetQueueUrl", mock.Anything).
+				Return(&sqs.GetQueueUrlOutput{},
+					errors.New("SQS.GetQueueUrl error"))
+		},
+		"GetQueueAttributes": func(svc *MockSqs) {
+			svc.On("GetQueueAttributes", mock.Anything).
+				Return(&sqs.GetQueueAttributesOutput{},
+					errors.New("SQS.GetQueueAttributes error"))
+		},
+		"ListQueueTags": func(svc *MockSqs) {
+			svc.On("ListQueueTags", mock.Anything).
+				Return(&sqs.ListQueueTagsOutput{},
+					errors.New("SQS.ListQueueTags error"))
+		},
+		"ListDeadLetterSourceQueuesPages": func(svc *MockSqs) {
+			svc.On("ListDeadLetterSourceQueuesPages", mock.Anything).
+				Return(errors.New("SQS.ListDeadLetterSourceQueuesPages error"))
+		},
+	}
+
+	MockSqsForSetup = &MockSqs{}
+)
+
+// SQS mock
+
+// SetupMockSqs is used to override the SQS Client initializer
+func SetupMockSqs(_ *session.Session, _ *aws.Config) interface{} {
+	return MockSqsForSetup
+}
+
+// MockSqs is a mock SQS client
+type MockSqs struct {
+	sqsiface.SQSAPI
+	mock.Mock
+}
+
+// BuildMockSqsSvc builds and returns a MockSqs struct
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockSqsSvc(funcs []string) (mockSvc *MockSqs) {
+	mockSvc = &MockSqs{}
+	for _, f := range funcs {
+		svcSqsSetupCalls[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockSqsSvcError builds and returns a MockSqs struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockSqsSvcError(funcs []string) (mockSvc *MockSqs) {
+	mockSvc = &MockSqs{}
+	for _, f := range funcs {
+		svcSqsSetupCallsError[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockSqsSvcAll builds and returns a MockSqs struct
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockSqsSvcAll() (mockSvc *MockSqs) {
+	mockSvc = &MockSqs{}
+	for _, f := range svcSqsSetupCalls {
+		f(mockSvc)
+	}
+	return
+}
+
+// BuildMockSqsSvcAllError builds and returns a MockSqs struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockSqsSvcAllError() (mockSvc *MockSqs) {
+	mockSvc = &MockSqs{}
+	for _, f := range svcSqsSetupCallsError {
+		f(mockSvc)
+	}
+	return
+}
+
+func (m *MockSqs) ListQueuesPages(
+	in *sqs.ListQueuesInput,
+	paginationFunction func(*sqs.ListQueuesOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	paginationFunction(ExampleListQueuesOutput, true)
+	return args.Error(0)
+}
+
+func (m *MockSqs) GetQueueUrl(in *sqs.GetQueueUrlInput) (*sqs.GetQueueUrlOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*sqs.GetQueueUrlOutput), args.Error(1)
+}
+
+func (m *MockSqs) GetQueueAttributes(in *sqs.GetQueueAttributesInput) (*sqs.GetQueueAttributesOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*sqs.GetQueueAttributesOutput), args.Error(1)
+}
+
+func (m *MockSqs) ListQueueTags(in *sqs.ListQueueTagsInput) (*sqs.ListQueueTagsOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*sqs.ListQueueTagsOutput), args.Error(1)
+}
+
+func (m *MockSqs) ListDeadLetterSourceQueuesPages(
+	in *sqs.ListDeadLetterSourceQueuesInput,
+	paginationFunction func(*sqs.ListDeadLetterSourceQueuesOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	paginationFunction(ExampleListDeadLetterSourceQueuesOutput, true)
+	return args.Error(0)
+}
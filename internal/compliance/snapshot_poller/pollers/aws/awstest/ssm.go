@@ -0,0 +1,184 @@
+package awstest
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+	"github.com/stretchr/testify/mock"
+)
+
+// Example SSM API return values
+var (
+	ExampleDescribeParametersOutput = &ssm.DescribeParametersOutput{
+		Parameters: []*ssm.ParameterMetadata{
+			{
+				DataType:         aws.String("text"),
+				Description:      aws.String("An example parameter"),
+				KeyId:            aws.String("alias/aws/ssm"),
+				LastModifiedDate: &ExampleTime,
+				LastModifiedUser: aws.String("arn:aws:iam::123456789012:user/example-user"),
+				Name:             aws.String("/example/parameter"),
+				Tier:             aws.String("Standard"),
+				Type:             aws.String("SecureString"),
+				Version:          aws.Int64(3),
+			},
+			{
+				DataType:         aws.String("text"),
+				LastModifiedDate: &ExampleTime,
+				Name:             aws.String("example-parameter"),
+				Tier:             aws.String("Standard"),
+				Type:             aws.String("String"),
+				Version:          aws.Int64(1),
+			},
+		},
+	}
+
+	ExampleDescribeParametersOutputContinue = &ssm.DescribeParametersOutput{
+		Parameters: ExampleDescribeParametersOutput.Parameters,
+		NextToken:  aws.String("1"),
+	}
+
+	ExampleListTagsForResourceSsm = &ssm.ListTagsForResourceOutput{
+		TagList: []*ssm.Tag{
+			{
+				Key:   aws.String("Key1"),
+				Value: aws.String("Value1"),
+			},
+		},
+	}
+
+	svcSsmSetupCalls = map[string]func(*MockSsm){
+		"DescribeParametersPages": func(svc *MockSsm) {
+			svc.On("DescribeParametersPages", mock.Anything).
+				Return(nil)
+		},
+		"DescribeParameters": func(svc *MockSsm) {
+			svc.On("DescribeParameters", mock.Anything).
+				Return(ExampleDescribeParametersOutput, nil)
+		},
+		"ListTagsForResource": func(svc *MockSsm) {
+			svc.On("ListTagsForResource", mock.Anything).
+				Return(ExampleListTagsForResourceSsm, nil)
+		},
+	}
+
+	svcSsmSetupCallsError = map[string]func(*MockSsm){
+		"DescribeParametersPages": func(svc *MockSsm) {
+			svc.On("DescribeParametersPages", mock.Anything).
+				Return(errors.New("SSM.DescribeParametersPages error"))
+		},
+		"DescribeParameters": func(svc *MockSsm) {
+			svc.On("DescribeParameters", mock.Anything).
+				Return(&ssm.DescribeParametersOutput{},
+					errors.New("SSM.DescribeParameters error"))
+		},
+		"ListTagsForResource": func(svc *MockSsm) {
+			svc.On("ListTagsForResource", mock.Anything).
+				Return(&ssm.ListTagsForResourceOutput{},
+					errors.New("SSM.ListTagsForResource error"))
+		},
+	}
+
+	MockSsmForSetup = &MockSsm{}
+)
+
+// SSM mock
+
+// SetupMockSsm is used to override the SSM Client initializer
+func SetupMockSsm(_ *session.Session, _ *aws.Config) interface{} {
+	return MockSsmForSetup
+}
+
+// MockSsm is a mock SSM client
+type MockSsm struct {
+	ssmiface.SSMAPI
+	mock.Mock
+}
+
+// BuildMockSsmSvc builds and returns a MockSsm struct
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockSsmSvc(funcs []string) (mockSvc *MockSsm) {
+	mockSvc = &MockSsm{}
+	for _, f := range funcs {
+		svcSsmSetupCalls[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockSsmSvcError builds and returns a MockSsm struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockSsmSvcError(funcs []string) (mockSvc *MockSsm) {
+	mockSvc = &MockSsm{}
+	for _, f := range funcs {
+		svcSsmSetupCallsError[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockSsmSvcAll builds and returns a MockSsm struct
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockSsmSvcAll() (mockSvc *MockSsm) {
+	mockSvc = &MockSsm{}
+	for _, f := range svcSsmSetupCalls {
+		f(mockSvc)
+	}
+	return
+}
+
+// BuildMockSsmSvcAllError builds and returns a MockSsm struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockSsmSvcAllError() (mockSvc *MockSsm) {
+	mockSvc = &MockSsm{}
+	for _, f := range svcSsmSetupCallsError {
+		f(mockSvc)
+	}
+	return
+}
+
+func (m *MockSsm) DescribeParametersPages(
+	in *ssm.DescribeParametersInput,
+	paginationFunction func(*ssm.DescribeParametersOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	paginationFunction(ExampleDescribeParametersOutput, true)
+	return args.Error(0)
+}
+
+func (m *MockSsm) DescribeParameters(in *ssm.DescribeParametersInput) (*ssm.DescribeParametersOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*ssm.DescribeParametersOutput), args.Error(1)
+}
+
+func (m *MockSsm) ListTagsForResource(in *ssm.ListTagsForResourceInput) (*ssm.ListTagsForResourceOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*ssm.ListTagsForResourceOutput), args.Error(1)
+}
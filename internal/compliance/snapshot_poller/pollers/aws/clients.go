@@ -27,32 +27,16 @@ import (
 	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/acm"
-	"github.com/aws/aws-sdk-go/service/cloudformation"
-	"github.com/aws/aws-sdk-go/service/cloudtrail"
-	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
-	"github.com/aws/aws-sdk-go/service/configservice"
-	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
-	"github.com/aws/aws-sdk-go/service/ecs"
-	"github.com/aws/aws-sdk-go/service/eks"
-	"github.com/aws/aws-sdk-go/service/guardduty"
-	"github.com/aws/aws-sdk-go/service/iam"
-	"github.com/aws/aws-sdk-go/service/kms"
-	"github.com/aws/aws-sdk-go/service/lambda"
-	"github.com/aws/aws-sdk-go/service/rds"
-	"github.com/aws/aws-sdk-go/service/redshift"
-	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/ssm"
 	"github.com/aws/aws-sdk-go/service/sts"
-	"github.com/aws/aws-sdk-go/service/waf"
-	"github.com/aws/aws-sdk-go/service/wafregional"
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
 	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
 	"github.com/panther-labs/panther/pkg/awsretry"
 )
 
@@ -74,58 +58,6 @@ var (
 	VerifyAssumedCredsFunc = verifyAssumedCreds
 	GetServiceRegionsFunc  = GetServiceRegions
 
-	// This maps the name we have given to a type of resource to the corresponding AWS name for the
-	// service that the resource type is a part of.
-	typeToIDMapping = map[string]string{
-		awsmodels.AcmCertificateSchema:      acm.ServiceName,
-		awsmodels.CloudFormationStackSchema: cloudformation.ServiceName,
-		awsmodels.CloudTrailSchema:          cloudtrail.ServiceName,
-		awsmodels.CloudWatchLogGroupSchema:  cloudwatchlogs.ServiceName,
-		awsmodels.ConfigServiceSchema:       configservice.ServiceName,
-		awsmodels.DynamoDBTableSchema:       dynamodb.ServiceName,
-		awsmodels.Ec2AmiSchema:              ec2.ServiceName,
-		awsmodels.Ec2InstanceSchema:         ec2.ServiceName,
-		awsmodels.Ec2NetworkAclSchema:       ec2.ServiceName,
-		awsmodels.Ec2SecurityGroupSchema:    ec2.ServiceName,
-		awsmodels.Ec2VolumeSchema:           ec2.ServiceName,
-		awsmodels.Ec2VpcSchema:              ec2.ServiceName,
-		awsmodels.EcsClusterSchema:          ecs.ServiceName,
-		awsmodels.EksClusterSchema:          eks.ServiceName,
-		// For every other service, the service name aligns with how SSM refers to the service. For
-		// just the elb and elbv2 service, this is not the case. AWS just had to do it to 'em.
-		awsmodels.Elbv2LoadBalancerSchema: "elb",
-		awsmodels.GuardDutySchema:         guardduty.ServiceName,
-		awsmodels.IAMGroupSchema:          iam.ServiceName,
-		awsmodels.IAMPolicySchema:         iam.ServiceName,
-		awsmodels.IAMRoleSchema:           iam.ServiceName,
-		awsmodels.IAMRootUserSchema:       iam.ServiceName,
-		awsmodels.IAMUserSchema:           iam.ServiceName,
-		awsmodels.KmsKeySchema:            kms.ServiceName,
-		awsmodels.LambdaFunctionSchema:    lambda.ServiceName,
-		awsmodels.PasswordPolicySchema:    iam.ServiceName,
-		awsmodels.RDSInstanceSchema:       rds.ServiceName,
-		awsmodels.RedshiftClusterSchema:   redshift.ServiceName,
-		awsmodels.S3BucketSchema:          s3.ServiceName,
-		awsmodels.WafRegionalWebAclSchema: waf.ServiceName,
-		awsmodels.WafWebAclSchema:         wafregional.ServiceName,
-	}
-
-	// These services do not support regional scans, either because the resource itself is not
-	// regional or because we construct a "Meta" resource that needs the full context of every
-	// resource to be updated.
-	globalOnlyTypes = map[string]struct{}{
-		awsmodels.CloudTrailSchema:     {}, // Has a meta resource
-		awsmodels.ConfigServiceSchema:  {}, // Has a meta resource
-		awsmodels.GuardDutySchema:      {}, // Has a meta resource
-		awsmodels.IAMGroupSchema:       {}, // Global service
-		awsmodels.IAMPolicySchema:      {}, // Global service
-		awsmodels.IAMRoleSchema:        {}, // Global service
-		awsmodels.IAMRootUserSchema:    {}, // Global service
-		awsmodels.IAMUserSchema:        {}, // Global service
-		awsmodels.PasswordPolicySchema: {}, // Global service
-		awsmodels.WafWebAclSchema:      {}, // Global service
-	}
-
 	// Used to cache region & account specific AWS clients
 	clientCache = make(map[clientKey]cachedClient)
 
@@ -170,7 +102,7 @@ func Setup() {
 func GetRegionsToScan(pollerInput *awsmodels.ResourcePollerInput, resourceType string) (regions []*string, err error) {
 	// For resources where we are always going to perform a full account scan anyways, just return a
 	// single region.
-	if _, ok := globalOnlyTypes[resourceType]; ok {
+	if info, ok := resourcetypes.Lookup(resourceType); ok && info.Global {
 		return []*string{&defaultRegion}, nil
 	}
 
@@ -181,10 +113,11 @@ func GetRegionsToScan(pollerInput *awsmodels.ResourcePollerInput, resourceType s
 // AWS for the given resource type.
 func GetServiceRegions(pollerInput *awsmodels.ResourcePollerInput, resourceType string) ([]*string, error) {
 	// Determine the service ID based on the resource type
-	serviceID, ok := typeToIDMapping[resourceType]
-	if !ok {
+	info, ok := resourcetypes.Lookup(resourceType)
+	if !ok || info.ServiceID == "" {
 		return nil, errors.Errorf("no service mapping for resource type %s", resourceType)
 	}
+	serviceID := info.ServiceID
 
 	// Lookup the regions that the account has enabled
 	ec2Svc, err := getClient(pollerInput, EC2ClientFunc, "ec2", defaultRegion)
@@ -286,7 +219,7 @@ func getClient(pollerInput *awsmodels.ResourcePollerInput,
 	return client, nil
 }
 
-//  assumes an IAM role associated with an AWS Snapshot Integration.
+// assumes an IAM role associated with an AWS Snapshot Integration.
 func assumeRole(pollerInput *awsmodels.ResourcePollerInput, sess *session.Session) *credentials.Credentials {
 	zap.L().Debug("assuming role", zap.String("roleArn", *pollerInput.AuthSource))
 
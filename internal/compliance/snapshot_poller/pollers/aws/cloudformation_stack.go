@@ -35,9 +35,17 @@ import (
 	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
 	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
 	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
 )
 
+func init() {
+	resourcetypes.RegisterPollers(awsmodels.CloudFormationStackSchema, &resourcetypes.ResourcePollers{
+		Service: PollCloudFormationStacks,
+		ARN:     PollCloudFormationStack,
+	})
+}
+
 const (
 	// Time to delay the requeue of a scan of a CloudFormation stack whose drift detection was in
 	// progress when this scan started.
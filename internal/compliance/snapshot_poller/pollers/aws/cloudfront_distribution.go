@@ -0,0 +1,237 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudfront"
+	"github.com/aws/aws-sdk-go/service/cloudfront/cloudfrontiface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+func init() {
+	resourcetypes.RegisterPollers(awsmodels.CloudFrontDistributionSchema, &resourcetypes.ResourcePollers{
+		Service: PollCloudFrontDistributions,
+		ARN:     PollCloudFrontDistribution,
+	})
+}
+
+// Set as variables to be overridden in testing
+var (
+	CloudFrontClientFunc = setupCloudFrontClient
+)
+
+func setupCloudFrontClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return cloudfront.New(sess, cfg)
+}
+
+func getCloudFrontClient(pollerResourceInput *awsmodels.ResourcePollerInput, region string) (cloudfrontiface.CloudFrontAPI, error) {
+	client, err := getClient(pollerResourceInput, CloudFrontClientFunc, "cloudfront", region)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.(cloudfrontiface.CloudFrontAPI), nil
+}
+
+// PollCloudFrontDistribution polls a single CloudFront distribution resource
+func PollCloudFrontDistribution(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	_ *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	// CloudFront is a global service, distributions do not have a region
+	client, err := getCloudFrontClient(pollerResourceInput, defaultRegion)
+	if err != nil {
+		return nil, err
+	}
+
+	distributionID := strings.TrimPrefix(resourceARN.Resource, "distribution/")
+	snapshot, err := buildCloudFrontDistributionSnapshot(client, aws.String(distributionID))
+	if err != nil || snapshot == nil {
+		return nil, err
+	}
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(awsmodels.GlobalRegion)
+	return snapshot, nil
+}
+
+// listDistributions returns the IDs of all CloudFront distributions in the account
+func listDistributions(cloudFrontSvc cloudfrontiface.CloudFrontAPI, nextMarker *string) (distributionIDs []*string, marker *string, err error) {
+	err = cloudFrontSvc.ListDistributionsPages(&cloudfront.ListDistributionsInput{
+		Marker:   nextMarker,
+		MaxItems: aws.Int64(int64(defaultBatchSize)),
+	},
+		func(page *cloudfront.ListDistributionsOutput, lastPage bool) bool {
+			return cloudFrontDistributionIterator(page, &distributionIDs, &marker)
+		})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "CloudFront.ListDistributionsPages")
+	}
+	return
+}
+
+func cloudFrontDistributionIterator(page *cloudfront.ListDistributionsOutput, distributionIDs *[]*string, marker **string) bool {
+	if page.DistributionList == nil {
+		return false
+	}
+	for _, distribution := range page.DistributionList.Items {
+		*distributionIDs = append(*distributionIDs, distribution.Id)
+	}
+	*marker = page.DistributionList.NextMarker
+	return len(*distributionIDs) < defaultBatchSize
+}
+
+// getDistribution returns a CloudFront distribution, or nil if the distribution no longer exists
+func getDistribution(cloudFrontSvc cloudfrontiface.CloudFrontAPI, distributionID *string) (*cloudfront.Distribution, error) {
+	out, err := cloudFrontSvc.GetDistribution(&cloudfront.GetDistributionInput{Id: distributionID})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == cloudfront.ErrCodeNoSuchDistribution {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", *distributionID),
+				zap.String("resourceType", awsmodels.CloudFrontDistributionSchema))
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "CloudFront.GetDistribution: %s", aws.StringValue(distributionID))
+	}
+	return out.Distribution, nil
+}
+
+// listTagsCloudFront returns the tags of a CloudFront distribution
+func listTagsCloudFront(cloudFrontSvc cloudfrontiface.CloudFrontAPI, distributionARN *string) ([]*cloudfront.Tag, error) {
+	out, err := cloudFrontSvc.ListTagsForResource(&cloudfront.ListTagsForResourceInput{Resource: distributionARN})
+	if err != nil {
+		return nil, errors.Wrapf(err, "CloudFront.ListTagsForResource: %s", aws.StringValue(distributionARN))
+	}
+	if out.Tags == nil {
+		return nil, nil
+	}
+	return out.Tags.Items, nil
+}
+
+// buildCloudFrontDistributionSnapshot makes all the calls to build up a snapshot of a given CloudFront distribution
+func buildCloudFrontDistributionSnapshot(
+	cloudFrontSvc cloudfrontiface.CloudFrontAPI, distributionID *string) (*awsmodels.CloudFrontDistribution, error) {
+
+	if distributionID == nil {
+		return nil, nil
+	}
+	distribution, err := getDistribution(cloudFrontSvc, distributionID)
+	if err != nil || distribution == nil {
+		return nil, err
+	}
+
+	snapshot := &awsmodels.CloudFrontDistribution{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   distribution.ARN,
+			ResourceType: aws.String(awsmodels.CloudFrontDistributionSchema),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  distribution.ARN,
+			ID:   distribution.Id,
+			Name: distribution.Id,
+		},
+		ActiveTrustedKeyGroups:        distribution.ActiveTrustedKeyGroups,
+		ActiveTrustedSigners:          distribution.ActiveTrustedSigners,
+		AliasICPRecordals:             distribution.AliasICPRecordals,
+		DomainName:                    distribution.DomainName,
+		InProgressInvalidationBatches: distribution.InProgressInvalidationBatches,
+		LastModifiedTime:              distribution.LastModifiedTime,
+		Status:                        distribution.Status,
+	}
+	if config := distribution.DistributionConfig; config != nil {
+		snapshot.Aliases = config.Aliases
+		snapshot.CacheBehaviors = config.CacheBehaviors
+		snapshot.Comment = config.Comment
+		snapshot.CustomErrorResponses = config.CustomErrorResponses
+		snapshot.DefaultCacheBehavior = config.DefaultCacheBehavior
+		snapshot.DefaultRootObject = config.DefaultRootObject
+		snapshot.Enabled = config.Enabled
+		snapshot.HttpVersion = config.HttpVersion
+		snapshot.IsIPV6Enabled = config.IsIPV6Enabled
+		snapshot.Logging = config.Logging
+		snapshot.OriginGroups = config.OriginGroups
+		snapshot.Origins = config.Origins
+		snapshot.PriceClass = config.PriceClass
+		snapshot.Restrictions = config.Restrictions
+		snapshot.ViewerCertificate = config.ViewerCertificate
+		snapshot.WebACLId = config.WebACLId
+	}
+
+	tags, err := listTagsCloudFront(cloudFrontSvc, distribution.ARN)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.Tags = utils.ParseTagSlice(tags)
+
+	return snapshot, nil
+}
+
+// PollCloudFrontDistributions gathers information on each CloudFront distribution for an AWS account.
+func PollCloudFrontDistributions(pollerInput *awsmodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	zap.L().Debug("starting CloudFront Distribution resource poller")
+
+	cloudFrontSvc, err := getCloudFrontClient(pollerInput, defaultRegion)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Start with generating a list of all distributions
+	distributionIDs, marker, err := listDistributions(cloudFrontSvc, pollerInput.NextPageToken)
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "region: global")
+	}
+
+	resources := make([]apimodels.AddResourceEntry, 0, len(distributionIDs))
+	for _, distributionID := range distributionIDs {
+		snapshot, err := buildCloudFrontDistributionSnapshot(cloudFrontSvc, distributionID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if snapshot == nil {
+			continue
+		}
+		snapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		snapshot.Region = aws.String(awsmodels.GlobalRegion)
+
+		resources = append(resources, apimodels.AddResourceEntry{
+			Attributes:      snapshot,
+			ID:              *snapshot.ResourceID,
+			IntegrationID:   *pollerInput.IntegrationID,
+			IntegrationType: integrationType,
+			Type:            awsmodels.CloudFrontDistributionSchema,
+		})
+	}
+
+	return resources, marker, nil
+}
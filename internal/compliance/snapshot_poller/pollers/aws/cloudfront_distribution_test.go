@@ -0,0 +1,131 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestCloudFrontDistributionList(t *testing.T) {
+	mockSvc := awstest.BuildMockCloudFrontSvc([]string{"ListDistributionsPages"})
+
+	out, marker, err := listDistributions(mockSvc, nil)
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	assert.Len(t, out, 2)
+}
+
+func TestCloudFrontDistributionListError(t *testing.T) {
+	mockSvc := awstest.BuildMockCloudFrontSvcError([]string{"ListDistributionsPages"})
+
+	out, marker, err := listDistributions(mockSvc, nil)
+	require.Error(t, err)
+	assert.Nil(t, marker)
+	assert.Nil(t, out)
+}
+
+// Test the iterator works on consecutive pages but stops at max page size
+func TestCloudFrontDistributionListIterator(t *testing.T) {
+	var distributionIDs []*string
+	var marker *string
+
+	cont := cloudFrontDistributionIterator(awstest.ExampleListDistributionsOutput, &distributionIDs, &marker)
+	assert.True(t, cont)
+	assert.Nil(t, marker)
+	assert.Len(t, distributionIDs, 2)
+
+	for i := 2; i < 50; i++ {
+		cont = cloudFrontDistributionIterator(awstest.ExampleListDistributionsOutputContinue, &distributionIDs, &marker)
+		assert.True(t, cont)
+		assert.NotNil(t, marker)
+		assert.Len(t, distributionIDs, i*2)
+	}
+
+	cont = cloudFrontDistributionIterator(awstest.ExampleListDistributionsOutputContinue, &distributionIDs, &marker)
+	assert.False(t, cont)
+	assert.NotNil(t, marker)
+	assert.Len(t, distributionIDs, 100)
+}
+
+func TestBuildCloudFrontDistributionSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockCloudFrontSvcAll()
+
+	distribution, err := buildCloudFrontDistributionSnapshot(mockSvc, awstest.ExampleDistributionId)
+	require.NoError(t, err)
+	assert.Equal(t, awstest.ExampleDistributionArn, distribution.ARN)
+	assert.Equal(t, awstest.ExampleDistributionId, distribution.Name)
+	assert.Equal(t, "Deployed", *distribution.Status)
+	assert.True(t, *distribution.Enabled)
+	assert.True(t, *distribution.Logging.Enabled)
+	assert.Equal(t, "redirect-to-https", *distribution.DefaultCacheBehavior.ViewerProtocolPolicy)
+	assert.Equal(t, aws.String("Value1"), distribution.Tags["Key1"])
+}
+
+func TestBuildCloudFrontDistributionSnapshotError(t *testing.T) {
+	mockSvc := awstest.BuildMockCloudFrontSvcAllError()
+
+	distribution, err := buildCloudFrontDistributionSnapshot(mockSvc, awstest.ExampleDistributionId)
+	require.Error(t, err)
+	assert.Nil(t, distribution)
+}
+
+func TestCloudFrontDistributionPoller(t *testing.T) {
+	awstest.MockCloudFrontForSetup = awstest.BuildMockCloudFrontSvcAll()
+
+	CloudFrontClientFunc = awstest.SetupMockCloudFront
+
+	resources, marker, err := PollCloudFrontDistributions(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	require.Len(t, resources, 2)
+	assert.Equal(t, awsmodels.GlobalRegion, *resources[0].Attributes.(*awsmodels.CloudFrontDistribution).Region)
+}
+
+func TestCloudFrontDistributionPollerError(t *testing.T) {
+	resetCache()
+	awstest.MockCloudFrontForSetup = awstest.BuildMockCloudFrontSvcAllError()
+
+	CloudFrontClientFunc = awstest.SetupMockCloudFront
+
+	resources, marker, err := PollCloudFrontDistributions(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, marker)
+	assert.Nil(t, resources)
+}
@@ -31,9 +31,17 @@ import (
 	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
 	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
 	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
 )
 
+func init() {
+	resourcetypes.RegisterPollers(awsmodels.CloudTrailSchema, &resourcetypes.ResourcePollers{
+		Service: PollCloudTrails,
+		ARN:     PollCloudTrailTrail,
+	})
+}
+
 var (
 	// CloudTrailClientFunc is the function it setup the CloudTrail client.
 	CloudTrailClientFunc = setupCloudTrailClient
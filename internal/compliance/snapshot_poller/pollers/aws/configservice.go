@@ -30,9 +30,17 @@ import (
 	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
 	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
 	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
 )
 
+func init() {
+	resourcetypes.RegisterPollers(awsmodels.ConfigServiceSchema, &resourcetypes.ResourcePollers{
+		Service: PollConfigServices,
+		ID:      PollConfigService,
+	})
+}
+
 // Set as variables to be overridden in testing
 var (
 	ConfigServiceClientFunc = setupConfigServiceClient
@@ -56,7 +64,7 @@ func getConfigServiceClient(pollerResourceInput *awsmodels.ResourcePollerInput,
 // PollConfigService polls a single AWS Config resource
 func PollConfigService(
 	pollerResourceInput *awsmodels.ResourcePollerInput,
-	parsedResourceID *utils.ParsedResourceID,
+	parsedResourceID *awsmodels.ParsedResourceID,
 	scanRequest *pollermodels.ScanEntry) (interface{}, error) {
 
 	configClient, err := getConfigServiceClient(pollerResourceInput, parsedResourceID.Region)
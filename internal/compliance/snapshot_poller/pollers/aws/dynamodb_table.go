@@ -35,9 +35,17 @@ import (
 	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
 	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
 	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
 )
 
+func init() {
+	resourcetypes.RegisterPollers(awsmodels.DynamoDBTableSchema, &resourcetypes.ResourcePollers{
+		Service: PollDynamoDBTables,
+		ARN:     PollDynamoDBTable,
+	})
+}
+
 const (
 	dynamoDBServiceNameSpace = "dynamodb"
 	// Several DynamoDB API calls (most noticeably the DescribeTimeToLive API call) have very low
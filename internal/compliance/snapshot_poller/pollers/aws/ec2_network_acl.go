@@ -32,9 +32,17 @@ import (
 	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
 	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
 	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
 )
 
+func init() {
+	resourcetypes.RegisterPollers(awsmodels.Ec2NetworkAclSchema, &resourcetypes.ResourcePollers{
+		Service: PollEc2NetworkAcls,
+		ARN:     PollEC2NetworkACL,
+	})
+}
+
 // PollEC2NetworkACL polls a single EC2 Network ACL resource
 func PollEC2NetworkACL(
 	pollerResourceInput *awsmodels.ResourcePollerInput,
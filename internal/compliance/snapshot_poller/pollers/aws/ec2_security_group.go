@@ -32,9 +32,17 @@ import (
 	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
 	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
 	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
 )
 
+func init() {
+	resourcetypes.RegisterPollers(awsmodels.Ec2SecurityGroupSchema, &resourcetypes.ResourcePollers{
+		Service: PollEc2SecurityGroups,
+		ARN:     PollEC2SecurityGroup,
+	})
+}
+
 // PollEC2SecurityGroup polls a single EC2 Security Group resource
 func PollEC2SecurityGroup(
 	pollerResourceInput *awsmodels.ResourcePollerInput,
@@ -0,0 +1,240 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+func init() {
+	resourcetypes.RegisterPollers(awsmodels.Ec2TransitGatewaySchema, &resourcetypes.ResourcePollers{
+		Service: PollEc2TransitGateways,
+		ARN:     PollEC2TransitGateway,
+	})
+}
+
+// PollEC2TransitGateway polls a single EC2 transit gateway resource
+func PollEC2TransitGateway(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	scanRequest *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	ec2Client, err := getEC2Client(pollerResourceInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	transitGatewayID := strings.TrimPrefix(resourceARN.Resource, "transit-gateway/")
+	transitGateway, err := getTransitGateway(ec2Client, aws.String(transitGatewayID))
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := buildEc2TransitGatewaySnapshot(ec2Client, transitGateway)
+	if err != nil || snapshot == nil {
+		return nil, err
+	}
+	snapshot.ResourceID = scanRequest.ResourceID
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// getTransitGateway returns a specific EC2 transit gateway, or nil if it no longer exists
+func getTransitGateway(svc ec2iface.EC2API, transitGatewayID *string) (transitGateway *ec2.TransitGateway, err error) {
+	err = svc.DescribeTransitGatewaysPages(&ec2.DescribeTransitGatewaysInput{
+		TransitGatewayIds: []*string{transitGatewayID},
+	}, func(page *ec2.DescribeTransitGatewaysOutput, lastPage bool) bool {
+		for _, tgw := range page.TransitGateways {
+			if aws.StringValue(tgw.TransitGatewayId) == aws.StringValue(transitGatewayID) {
+				transitGateway = tgw
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == "InvalidTransitGatewayID.NotFound" {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", *transitGatewayID),
+				zap.String("resourceType", awsmodels.Ec2TransitGatewaySchema))
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "EC2.DescribeTransitGatewaysPages: %s", aws.StringValue(transitGatewayID))
+	}
+	return transitGateway, nil
+}
+
+// describeTransitGateways returns all the transit gateways in a region
+func describeTransitGateways(ec2Svc ec2iface.EC2API, nextMarker *string) (transitGateways []*ec2.TransitGateway, marker *string, err error) {
+	err = ec2Svc.DescribeTransitGatewaysPages(
+		&ec2.DescribeTransitGatewaysInput{
+			NextToken:  nextMarker,
+			MaxResults: aws.Int64(int64(defaultBatchSize)),
+		},
+		func(page *ec2.DescribeTransitGatewaysOutput, lastPage bool) bool {
+			return ec2TransitGatewayIterator(page, &transitGateways, &marker)
+		})
+
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "EC2.DescribeTransitGatewaysPages")
+	}
+	return
+}
+
+func ec2TransitGatewayIterator(page *ec2.DescribeTransitGatewaysOutput, transitGateways *[]*ec2.TransitGateway, marker **string) bool {
+	*transitGateways = append(*transitGateways, page.TransitGateways...)
+	*marker = page.NextToken
+	return len(*transitGateways) < defaultBatchSize
+}
+
+// describeTransitGatewayAttachments returns all the attachments of a transit gateway
+func describeTransitGatewayAttachments(
+	ec2Svc ec2iface.EC2API, transitGatewayID *string) (attachments []*ec2.TransitGatewayAttachment, err error) {
+
+	err = ec2Svc.DescribeTransitGatewayAttachmentsPages(
+		&ec2.DescribeTransitGatewayAttachmentsInput{
+			Filters: []*ec2.Filter{
+				{
+					Name:   aws.String("transit-gateway-id"),
+					Values: []*string{transitGatewayID},
+				},
+			},
+		},
+		func(page *ec2.DescribeTransitGatewayAttachmentsOutput, lastPage bool) bool {
+			attachments = append(attachments, page.TransitGatewayAttachments...)
+			return true
+		})
+	if err != nil {
+		return nil, errors.Wrapf(err, "EC2.DescribeTransitGatewayAttachmentsPages: %s", aws.StringValue(transitGatewayID))
+	}
+	return
+}
+
+// describeTransitGatewayRouteTables returns all the route tables of a transit gateway
+func describeTransitGatewayRouteTables(
+	ec2Svc ec2iface.EC2API, transitGatewayID *string) (routeTables []*ec2.TransitGatewayRouteTable, err error) {
+
+	err = ec2Svc.DescribeTransitGatewayRouteTablesPages(
+		&ec2.DescribeTransitGatewayRouteTablesInput{
+			Filters: []*ec2.Filter{
+				{
+					Name:   aws.String("transit-gateway-id"),
+					Values: []*string{transitGatewayID},
+				},
+			},
+		},
+		func(page *ec2.DescribeTransitGatewayRouteTablesOutput, lastPage bool) bool {
+			routeTables = append(routeTables, page.TransitGatewayRouteTables...)
+			return true
+		})
+	if err != nil {
+		return nil, errors.Wrapf(err, "EC2.DescribeTransitGatewayRouteTablesPages: %s", aws.StringValue(transitGatewayID))
+	}
+	return
+}
+
+// buildEc2TransitGatewaySnapshot builds a full Ec2TransitGateway snapshot for a given transit gateway
+func buildEc2TransitGatewaySnapshot(ec2Svc ec2iface.EC2API, transitGateway *ec2.TransitGateway) (*awsmodels.Ec2TransitGateway, error) {
+	if transitGateway == nil {
+		return nil, nil
+	}
+	snapshot := &awsmodels.Ec2TransitGateway{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   transitGateway.TransitGatewayArn,
+			ResourceType: aws.String(awsmodels.Ec2TransitGatewaySchema),
+			TimeCreated:  transitGateway.CreationTime,
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  transitGateway.TransitGatewayArn,
+			ID:   transitGateway.TransitGatewayId,
+			Tags: utils.ParseTagSlice(transitGateway.Tags),
+		},
+
+		Description: transitGateway.Description,
+		Options:     transitGateway.Options,
+		OwnerId:     transitGateway.OwnerId,
+		State:       transitGateway.State,
+	}
+	// Transit gateways are most commonly referred to by the value of their Name tag
+	if name, ok := snapshot.Tags["Name"]; ok {
+		snapshot.Name = name
+	}
+
+	var err error
+	if snapshot.Attachments, err = describeTransitGatewayAttachments(ec2Svc, transitGateway.TransitGatewayId); err != nil {
+		return nil, err
+	}
+	if snapshot.RouteTables, err = describeTransitGatewayRouteTables(ec2Svc, transitGateway.TransitGatewayId); err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// PollEc2TransitGateways gathers information on each transit gateway in an AWS account.
+func PollEc2TransitGateways(pollerInput *awsmodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	zap.L().Debug("building EC2 transit gateway snapshots", zap.String("region", *pollerInput.Region))
+	ec2Svc, err := getEC2Client(pollerInput, *pollerInput.Region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Start with generating a list of all transit gateways
+	transitGateways, marker, err := describeTransitGateways(ec2Svc, pollerInput.NextPageToken)
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "region: %s", *pollerInput.Region)
+	}
+
+	resources := make([]apimodels.AddResourceEntry, 0, len(transitGateways))
+	for _, transitGateway := range transitGateways {
+		snapshot, err := buildEc2TransitGatewaySnapshot(ec2Svc, transitGateway)
+		if err != nil {
+			return nil, nil, err
+		}
+		snapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		snapshot.Region = pollerInput.Region
+
+		resources = append(resources, apimodels.AddResourceEntry{
+			Attributes:      snapshot,
+			ID:              *snapshot.ResourceID,
+			IntegrationID:   *pollerInput.IntegrationID,
+			IntegrationType: integrationType,
+			Type:            awsmodels.Ec2TransitGatewaySchema,
+		})
+	}
+
+	return resources, marker, nil
+}
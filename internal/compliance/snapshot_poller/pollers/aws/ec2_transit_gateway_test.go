@@ -0,0 +1,129 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestEc2TransitGatewayList(t *testing.T) {
+	mockSvc := awstest.BuildMockEC2Svc([]string{"DescribeTransitGatewaysPages"})
+
+	out, marker, err := describeTransitGateways(mockSvc, nil)
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	assert.Len(t, out, 2)
+}
+
+func TestEc2TransitGatewayListError(t *testing.T) {
+	mockSvc := awstest.BuildMockEC2SvcError([]string{"DescribeTransitGatewaysPages"})
+
+	out, marker, err := describeTransitGateways(mockSvc, nil)
+	require.Error(t, err)
+	assert.Nil(t, marker)
+	assert.Nil(t, out)
+}
+
+// Test the iterator works on consecutive pages but stops at max page size
+func TestEc2TransitGatewayListIterator(t *testing.T) {
+	var transitGateways []*ec2.TransitGateway
+	var marker *string
+
+	cont := ec2TransitGatewayIterator(awstest.ExampleDescribeTransitGatewaysOutput, &transitGateways, &marker)
+	assert.True(t, cont)
+	assert.Nil(t, marker)
+	assert.Len(t, transitGateways, 2)
+
+	for i := 2; i < 50; i++ {
+		cont = ec2TransitGatewayIterator(awstest.ExampleDescribeTransitGatewaysOutputContinue, &transitGateways, &marker)
+		assert.True(t, cont)
+		assert.NotNil(t, marker)
+		assert.Len(t, transitGateways, i*2)
+	}
+
+	cont = ec2TransitGatewayIterator(awstest.ExampleDescribeTransitGatewaysOutputContinue, &transitGateways, &marker)
+	assert.False(t, cont)
+	assert.NotNil(t, marker)
+	assert.Len(t, transitGateways, 100)
+}
+
+func TestBuildEc2TransitGatewaySnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockEC2SvcAll()
+
+	transitGateway, err := buildEc2TransitGatewaySnapshot(mockSvc, awstest.ExampleDescribeTransitGatewaysOutput.TransitGateways[0])
+	require.NoError(t, err)
+	assert.Equal(t, awstest.ExampleTransitGatewayId, transitGateway.ID)
+	assert.Equal(t, "available", *transitGateway.State)
+	assert.NotNil(t, transitGateway.Options)
+	assert.Len(t, transitGateway.Attachments, 1)
+	assert.Len(t, transitGateway.RouteTables, 1)
+}
+
+func TestBuildEc2TransitGatewaySnapshotError(t *testing.T) {
+	mockSvc := awstest.BuildMockEC2SvcAllError()
+
+	transitGateway, err := buildEc2TransitGatewaySnapshot(mockSvc, awstest.ExampleDescribeTransitGatewaysOutput.TransitGateways[0])
+	require.Error(t, err)
+	assert.Nil(t, transitGateway)
+}
+
+func TestEc2TransitGatewayPoller(t *testing.T) {
+	resetCache()
+	awstest.MockEC2ForSetup = awstest.BuildMockEC2SvcAll()
+
+	EC2ClientFunc = awstest.SetupMockEC2
+
+	resources, marker, err := PollEc2TransitGateways(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	assert.Len(t, resources, 2)
+}
+
+func TestEc2TransitGatewayPollerError(t *testing.T) {
+	resetCache()
+	awstest.MockEC2ForSetup = awstest.BuildMockEC2SvcAllError()
+
+	EC2ClientFunc = awstest.SetupMockEC2
+
+	resources, marker, err := PollEc2TransitGateways(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, marker)
+	assert.Nil(t, resources)
+}
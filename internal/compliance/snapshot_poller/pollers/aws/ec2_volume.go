@@ -32,9 +32,17 @@ import (
 	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
 	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
 	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
 )
 
+func init() {
+	resourcetypes.RegisterPollers(awsmodels.Ec2VolumeSchema, &resourcetypes.ResourcePollers{
+		Service: PollEc2Volumes,
+		ARN:     PollEC2Volume,
+	})
+}
+
 // PollEC2Volume polls a single EC2 Volume resource
 func PollEC2Volume(
 	pollerResourceInput *awsmodels.ResourcePollerInput,
@@ -0,0 +1,251 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/aws/aws-sdk-go/service/ecr/ecriface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+func init() {
+	resourcetypes.RegisterPollers(awsmodels.EcrRepositorySchema, &resourcetypes.ResourcePollers{
+		Service: PollEcrRepositories,
+		ARN:     PollEcrRepository,
+	})
+}
+
+// Set as variables to be overridden in testing
+var (
+	EcrClientFunc = setupEcrClient
+)
+
+func setupEcrClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return ecr.New(sess, cfg)
+}
+
+func getEcrClient(pollerResourceInput *awsmodels.ResourcePollerInput, region string) (ecriface.ECRAPI, error) {
+	client, err := getClient(pollerResourceInput, EcrClientFunc, "ecr", region)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.(ecriface.ECRAPI), nil
+}
+
+// PollEcrRepository polls a single ECR repository resource
+func PollEcrRepository(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	scanRequest *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getEcrClient(pollerResourceInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	// arn:aws:ecr:region:account-id:repository/repository-name
+	name := strings.TrimPrefix(resourceARN.Resource, "repository/")
+	repository, err := getRepository(client, name, resourceARN.AccountID)
+	if err != nil || repository == nil {
+		return nil, err
+	}
+
+	snapshot, err := buildEcrRepositorySnapshot(client, repository)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// getRepository returns a specific ECR repository
+func getRepository(ecrSvc ecriface.ECRAPI, name, registryID string) (*ecr.Repository, error) {
+	out, err := ecrSvc.DescribeRepositories(&ecr.DescribeRepositoriesInput{
+		RegistryId:      aws.String(registryID),
+		RepositoryNames: []*string{aws.String(name)},
+	})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == ecr.ErrCodeRepositoryNotFoundException {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", name),
+				zap.String("resourceType", awsmodels.EcrRepositorySchema))
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "ECR.DescribeRepositories: %s", name)
+	}
+	if len(out.Repositories) == 0 {
+		return nil, nil
+	}
+	return out.Repositories[0], nil
+}
+
+// describeRepositories returns all ECR repositories in the region
+func describeRepositories(ecrSvc ecriface.ECRAPI, nextMarker *string) (
+	repositories []*ecr.Repository, marker *string, err error) {
+
+	err = ecrSvc.DescribeRepositoriesPages(&ecr.DescribeRepositoriesInput{
+		NextToken:  nextMarker,
+		MaxResults: aws.Int64(int64(defaultBatchSize)),
+	},
+		func(page *ecr.DescribeRepositoriesOutput, lastPage bool) bool {
+			return ecrRepositoryIterator(page, &repositories, &marker)
+		})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "ECR.DescribeRepositoriesPages")
+	}
+	return
+}
+
+func ecrRepositoryIterator(page *ecr.DescribeRepositoriesOutput, repositories *[]*ecr.Repository, marker **string) bool {
+	*repositories = append(*repositories, page.Repositories...)
+	*marker = page.NextToken
+	return len(*repositories) < defaultBatchSize
+}
+
+// getRepositoryPolicy returns the policy attached to a repository, if one exists
+func getRepositoryPolicy(ecrSvc ecriface.ECRAPI, repository *ecr.Repository) (*string, error) {
+	out, err := ecrSvc.GetRepositoryPolicy(&ecr.GetRepositoryPolicyInput{
+		RegistryId:     repository.RegistryId,
+		RepositoryName: repository.RepositoryName,
+	})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == ecr.ErrCodeRepositoryPolicyNotFoundException {
+			zap.L().Debug("no ECR repository policy set", zap.String("repository", *repository.RepositoryName))
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "ECR.GetRepositoryPolicy: %s", aws.StringValue(repository.RepositoryName))
+	}
+	return out.PolicyText, nil
+}
+
+// getLifecyclePolicy returns the lifecycle policy of a repository, if one exists
+func getLifecyclePolicy(ecrSvc ecriface.ECRAPI, repository *ecr.Repository) (*string, error) {
+	out, err := ecrSvc.GetLifecyclePolicy(&ecr.GetLifecyclePolicyInput{
+		RegistryId:     repository.RegistryId,
+		RepositoryName: repository.RepositoryName,
+	})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == ecr.ErrCodeLifecyclePolicyNotFoundException {
+			zap.L().Debug("no ECR lifecycle policy set", zap.String("repository", *repository.RepositoryName))
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "ECR.GetLifecyclePolicy: %s", aws.StringValue(repository.RepositoryName))
+	}
+	return out.LifecyclePolicyText, nil
+}
+
+// listTagsEcr returns the tags of an ECR repository
+func listTagsEcr(ecrSvc ecriface.ECRAPI, repositoryARN *string) ([]*ecr.Tag, error) {
+	out, err := ecrSvc.ListTagsForResource(&ecr.ListTagsForResourceInput{ResourceArn: repositoryARN})
+	if err != nil {
+		return nil, errors.Wrapf(err, "ECR.ListTagsForResource: %s", aws.StringValue(repositoryARN))
+	}
+	return out.Tags, nil
+}
+
+// buildEcrRepositorySnapshot makes all the calls to build up a snapshot of a given ECR repository
+func buildEcrRepositorySnapshot(ecrSvc ecriface.ECRAPI, repository *ecr.Repository) (*awsmodels.EcrRepository, error) {
+	snapshot := &awsmodels.EcrRepository{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   repository.RepositoryArn,
+			ResourceType: aws.String(awsmodels.EcrRepositorySchema),
+			TimeCreated:  repository.CreatedAt,
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  repository.RepositoryArn,
+			Name: repository.RepositoryName,
+		},
+		EncryptionConfiguration:    repository.EncryptionConfiguration,
+		ImageScanningConfiguration: repository.ImageScanningConfiguration,
+		ImageTagMutability:         repository.ImageTagMutability,
+		RegistryId:                 repository.RegistryId,
+		RepositoryUri:              repository.RepositoryUri,
+	}
+
+	tags, err := listTagsEcr(ecrSvc, repository.RepositoryArn)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.Tags = utils.ParseTagSlice(tags)
+
+	if snapshot.Policy, err = getRepositoryPolicy(ecrSvc, repository); err != nil {
+		return nil, err
+	}
+	if snapshot.LifecyclePolicy, err = getLifecyclePolicy(ecrSvc, repository); err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// PollEcrRepositories gathers information on each ECR repository for an AWS account.
+func PollEcrRepositories(pollerInput *awsmodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	zap.L().Debug("starting ECR Repository resource poller")
+
+	ecrSvc, err := getEcrClient(pollerInput, *pollerInput.Region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Start with generating a list of all repositories
+	repositories, marker, err := describeRepositories(ecrSvc, pollerInput.NextPageToken)
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "region: %s", *pollerInput.Region)
+	}
+
+	resources := make([]apimodels.AddResourceEntry, 0, len(repositories))
+	for _, repository := range repositories {
+		repositorySnapshot, err := buildEcrRepositorySnapshot(ecrSvc, repository)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		repositorySnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		repositorySnapshot.Region = pollerInput.Region
+
+		resources = append(resources, apimodels.AddResourceEntry{
+			Attributes:      repositorySnapshot,
+			ID:              *repositorySnapshot.ResourceID,
+			IntegrationID:   *pollerInput.IntegrationID,
+			IntegrationType: integrationType,
+			Type:            awsmodels.EcrRepositorySchema,
+		})
+	}
+
+	return resources, marker, nil
+}
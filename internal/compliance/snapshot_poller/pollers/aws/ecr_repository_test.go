@@ -0,0 +1,173 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestEcrRepositoryDescribe(t *testing.T) {
+	mockSvc := awstest.BuildMockEcrSvc([]string{"DescribeRepositoriesPages"})
+
+	out, marker, err := describeRepositories(mockSvc, nil)
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	assert.Len(t, out, 2)
+}
+
+func TestEcrRepositoryDescribeError(t *testing.T) {
+	mockSvc := awstest.BuildMockEcrSvcError([]string{"DescribeRepositoriesPages"})
+
+	out, marker, err := describeRepositories(mockSvc, nil)
+	require.Error(t, err)
+	assert.Nil(t, marker)
+	assert.Nil(t, out)
+}
+
+// Test the iterator works on consecutive pages but stops at max page size
+func TestEcrRepositoryListIterator(t *testing.T) {
+	var repositories []*ecr.Repository
+	var marker *string
+
+	cont := ecrRepositoryIterator(awstest.ExampleDescribeRepositoriesOutput, &repositories, &marker)
+	assert.True(t, cont)
+	assert.Nil(t, marker)
+	assert.Len(t, repositories, 2)
+
+	for i := 2; i < 50; i++ {
+		cont = ecrRepositoryIterator(awstest.ExampleDescribeRepositoriesOutputContinue, &repositories, &marker)
+		assert.True(t, cont)
+		assert.NotNil(t, marker)
+		assert.Len(t, repositories, i*2)
+	}
+
+	cont = ecrRepositoryIterator(awstest.ExampleDescribeRepositoriesOutputContinue, &repositories, &marker)
+	assert.False(t, cont)
+	assert.NotNil(t, marker)
+	assert.Len(t, repositories, 100)
+}
+
+func TestBuildEcrRepositorySnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockEcrSvcAll()
+
+	repository, err := buildEcrRepositorySnapshot(mockSvc, awstest.ExampleEcrRepository)
+	require.NoError(t, err)
+	assert.Equal(t, "example-repository", *repository.Name)
+	assert.Equal(t, "MUTABLE", *repository.ImageTagMutability)
+	assert.True(t, *repository.ImageScanningConfiguration.ScanOnPush)
+	assert.NotEmpty(t, repository.Policy)
+	assert.NotEmpty(t, repository.LifecyclePolicy)
+	assert.Equal(t, aws.String("Value1"), repository.Tags["Key1"])
+}
+
+func TestBuildEcrRepositorySnapshotNoPolicies(t *testing.T) {
+	mockSvc := awstest.BuildMockEcrSvc([]string{"ListTagsForResource"})
+	mockSvc.On("GetRepositoryPolicy", mock.Anything).Return(&ecr.GetRepositoryPolicyOutput{},
+		awserr.New(ecr.ErrCodeRepositoryPolicyNotFoundException, "not found", nil))
+	mockSvc.On("GetLifecyclePolicy", mock.Anything).Return(&ecr.GetLifecyclePolicyOutput{},
+		awserr.New(ecr.ErrCodeLifecyclePolicyNotFoundException, "not found", nil))
+
+	repository, err := buildEcrRepositorySnapshot(mockSvc, awstest.ExampleEcrRepository)
+	require.NoError(t, err)
+	assert.Nil(t, repository.Policy)
+	assert.Nil(t, repository.LifecyclePolicy)
+}
+
+func TestBuildEcrRepositorySnapshotError(t *testing.T) {
+	mockSvc := awstest.BuildMockEcrSvcAllError()
+
+	repository, err := buildEcrRepositorySnapshot(mockSvc, awstest.ExampleEcrRepository)
+	require.Error(t, err)
+	assert.Nil(t, repository)
+}
+
+func TestPollEcrRepository(t *testing.T) {
+	resetCache()
+	awstest.MockEcrForSetup = awstest.BuildMockEcrSvcAll()
+
+	EcrClientFunc = awstest.SetupMockEcr
+
+	resourceARN, err := arn.Parse("arn:aws:ecr:us-west-2:123456789012:repository/example/nested")
+	require.NoError(t, err)
+	repository, err := PollEcrRepository(
+		&awsmodels.ResourcePollerInput{
+			AuthSource:          &awstest.ExampleAuthSource,
+			AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+			IntegrationID:       awstest.ExampleIntegrationID,
+			Timestamp:           &awstest.ExampleTime,
+		},
+		resourceARN,
+		&pollermodels.ScanEntry{ResourceID: aws.String(resourceARN.String())},
+	)
+	require.NoError(t, err)
+	require.NotNil(t, repository)
+	awstest.MockEcrForSetup.AssertCalled(t, "DescribeRepositories", &ecr.DescribeRepositoriesInput{
+		RegistryId:      aws.String("123456789012"),
+		RepositoryNames: []*string{aws.String("example/nested")},
+	})
+}
+
+func TestEcrRepositoryPoller(t *testing.T) {
+	awstest.MockEcrForSetup = awstest.BuildMockEcrSvcAll()
+
+	EcrClientFunc = awstest.SetupMockEcr
+
+	resources, marker, err := PollEcrRepositories(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	assert.Len(t, resources, 2)
+}
+
+func TestEcrRepositoryPollerError(t *testing.T) {
+	resetCache()
+	awstest.MockEcrForSetup = awstest.BuildMockEcrSvcAllError()
+
+	EcrClientFunc = awstest.SetupMockEcr
+
+	resources, marker, err := PollEcrRepositories(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, marker)
+	assert.Nil(t, resources)
+}
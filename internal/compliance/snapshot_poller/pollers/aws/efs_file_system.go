@@ -0,0 +1,272 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/efs"
+	"github.com/aws/aws-sdk-go/service/efs/efsiface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+func init() {
+	resourcetypes.RegisterPollers(awsmodels.EfsFileSystemSchema, &resourcetypes.ResourcePollers{
+		Service: PollEfsFileSystems,
+		ARN:     PollEfsFileSystem,
+	})
+}
+
+// Set as variables to be overridden in testing
+var (
+	EfsClientFunc = setupEfsClient
+)
+
+func setupEfsClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return efs.New(sess, cfg)
+}
+
+func getEfsClient(pollerResourceInput *awsmodels.ResourcePollerInput, region string) (efsiface.EFSAPI, error) {
+	client, err := getClient(pollerResourceInput, EfsClientFunc, "efs", region)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.(efsiface.EFSAPI), nil
+}
+
+// PollEfsFileSystem polls a single EFS file system resource
+func PollEfsFileSystem(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	_ *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getEfsClient(pollerResourceInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	fileSystemID := strings.TrimPrefix(resourceARN.Resource, "file-system/")
+	fileSystem, err := getFileSystem(client, aws.String(fileSystemID))
+	if err != nil || fileSystem == nil {
+		return nil, err
+	}
+
+	snapshot, err := buildEfsFileSystemSnapshot(client, fileSystem)
+	if err != nil || snapshot == nil {
+		return nil, err
+	}
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// getFileSystem returns a specific EFS file system, or nil if the file system no longer exists
+func getFileSystem(efsSvc efsiface.EFSAPI, fileSystemID *string) (fileSystem *efs.FileSystemDescription, err error) {
+	err = efsSvc.DescribeFileSystemsPages(&efs.DescribeFileSystemsInput{FileSystemId: fileSystemID},
+		func(page *efs.DescribeFileSystemsOutput, lastPage bool) bool {
+			for _, fs := range page.FileSystems {
+				if aws.StringValue(fs.FileSystemId) == aws.StringValue(fileSystemID) {
+					fileSystem = fs
+					return false
+				}
+			}
+			return true
+		})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == efs.ErrCodeFileSystemNotFound {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", *fileSystemID),
+				zap.String("resourceType", awsmodels.EfsFileSystemSchema))
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "EFS.DescribeFileSystemsPages: %s", aws.StringValue(fileSystemID))
+	}
+	return fileSystem, nil
+}
+
+// describeFileSystems returns all the EFS file systems in a region
+func describeFileSystems(efsSvc efsiface.EFSAPI, nextMarker *string) (fileSystems []*efs.FileSystemDescription, marker *string, err error) {
+	err = efsSvc.DescribeFileSystemsPages(&efs.DescribeFileSystemsInput{
+		Marker:   nextMarker,
+		MaxItems: aws.Int64(int64(defaultBatchSize)),
+	},
+		func(page *efs.DescribeFileSystemsOutput, lastPage bool) bool {
+			return efsFileSystemIterator(page, &fileSystems, &marker)
+		})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "EFS.DescribeFileSystemsPages")
+	}
+	return
+}
+
+func efsFileSystemIterator(page *efs.DescribeFileSystemsOutput, fileSystems *[]*efs.FileSystemDescription, marker **string) bool {
+	*fileSystems = append(*fileSystems, page.FileSystems...)
+	*marker = page.NextMarker
+	return len(*fileSystems) < defaultBatchSize
+}
+
+// getFileSystemPolicy returns the resource policy of a file system, or nil if it has none
+func getFileSystemPolicy(efsSvc efsiface.EFSAPI, fileSystemID *string) (*string, error) {
+	out, err := efsSvc.DescribeFileSystemPolicy(&efs.DescribeFileSystemPolicyInput{FileSystemId: fileSystemID})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == efs.ErrCodePolicyNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "EFS.DescribeFileSystemPolicy: %s", aws.StringValue(fileSystemID))
+	}
+	return out.Policy, nil
+}
+
+// getBackupPolicy returns the automatic backup policy of a file system, or nil if it has none
+func getBackupPolicy(efsSvc efsiface.EFSAPI, fileSystemID *string) (*efs.BackupPolicy, error) {
+	out, err := efsSvc.DescribeBackupPolicy(&efs.DescribeBackupPolicyInput{FileSystemId: fileSystemID})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == efs.ErrCodePolicyNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "EFS.DescribeBackupPolicy: %s", aws.StringValue(fileSystemID))
+	}
+	return out.BackupPolicy, nil
+}
+
+// getLifecyclePolicies returns the lifecycle management policies of a file system
+func getLifecyclePolicies(efsSvc efsiface.EFSAPI, fileSystemID *string) ([]*efs.LifecyclePolicy, error) {
+	out, err := efsSvc.DescribeLifecycleConfiguration(&efs.DescribeLifecycleConfigurationInput{FileSystemId: fileSystemID})
+	if err != nil {
+		return nil, errors.Wrapf(err, "EFS.DescribeLifecycleConfiguration: %s", aws.StringValue(fileSystemID))
+	}
+	return out.LifecyclePolicies, nil
+}
+
+// describeMountTargets returns all the mount targets of a file system
+//
+// The EFS SDK does not provide a paginator for this call, so we follow the markers ourselves.
+func describeMountTargets(efsSvc efsiface.EFSAPI, fileSystemID *string) (mountTargets []*efs.MountTargetDescription, err error) {
+	input := &efs.DescribeMountTargetsInput{FileSystemId: fileSystemID}
+	for {
+		out, err := efsSvc.DescribeMountTargets(input)
+		if err != nil {
+			return nil, errors.Wrapf(err, "EFS.DescribeMountTargets: %s", aws.StringValue(fileSystemID))
+		}
+		mountTargets = append(mountTargets, out.MountTargets...)
+		if out.NextMarker == nil {
+			return mountTargets, nil
+		}
+		input.Marker = out.NextMarker
+	}
+}
+
+// buildEfsFileSystemSnapshot makes all the calls to build up a snapshot of a given EFS file system
+func buildEfsFileSystemSnapshot(efsSvc efsiface.EFSAPI, fileSystem *efs.FileSystemDescription) (*awsmodels.EfsFileSystem, error) {
+	if fileSystem == nil {
+		return nil, nil
+	}
+
+	snapshot := &awsmodels.EfsFileSystem{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   fileSystem.FileSystemArn,
+			ResourceType: aws.String(awsmodels.EfsFileSystemSchema),
+			TimeCreated:  fileSystem.CreationTime,
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  fileSystem.FileSystemArn,
+			ID:   fileSystem.FileSystemId,
+			Name: fileSystem.Name,
+			Tags: utils.ParseTagSlice(fileSystem.Tags),
+		},
+		CreationToken:                fileSystem.CreationToken,
+		Encrypted:                    fileSystem.Encrypted,
+		KmsKeyId:                     fileSystem.KmsKeyId,
+		LifeCycleState:               fileSystem.LifeCycleState,
+		NumberOfMountTargets:         fileSystem.NumberOfMountTargets,
+		OwnerId:                      fileSystem.OwnerId,
+		PerformanceMode:              fileSystem.PerformanceMode,
+		ProvisionedThroughputInMibps: fileSystem.ProvisionedThroughputInMibps,
+		SizeInBytes:                  fileSystem.SizeInBytes,
+		ThroughputMode:               fileSystem.ThroughputMode,
+	}
+
+	var err error
+	if snapshot.Policy, err = getFileSystemPolicy(efsSvc, fileSystem.FileSystemId); err != nil {
+		return nil, err
+	}
+	if snapshot.BackupPolicy, err = getBackupPolicy(efsSvc, fileSystem.FileSystemId); err != nil {
+		return nil, err
+	}
+	if snapshot.LifecyclePolicies, err = getLifecyclePolicies(efsSvc, fileSystem.FileSystemId); err != nil {
+		return nil, err
+	}
+	if snapshot.MountTargets, err = describeMountTargets(efsSvc, fileSystem.FileSystemId); err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// PollEfsFileSystems gathers information on each EFS file system for an AWS account.
+func PollEfsFileSystems(pollerInput *awsmodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	zap.L().Debug("starting EFS File System resource poller")
+
+	efsSvc, err := getEfsClient(pollerInput, *pollerInput.Region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Start with generating a list of all file systems
+	fileSystems, marker, err := describeFileSystems(efsSvc, pollerInput.NextPageToken)
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "region: %s", *pollerInput.Region)
+	}
+
+	resources := make([]apimodels.AddResourceEntry, 0, len(fileSystems))
+	for _, fileSystem := range fileSystems {
+		snapshot, err := buildEfsFileSystemSnapshot(efsSvc, fileSystem)
+		if err != nil {
+			return nil, nil, err
+		}
+		snapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		snapshot.Region = pollerInput.Region
+
+		resources = append(resources, apimodels.AddResourceEntry{
+			Attributes:      snapshot,
+			ID:              *snapshot.ResourceID,
+			IntegrationID:   *pollerInput.IntegrationID,
+			IntegrationType: integrationType,
+			Type:            awsmodels.EfsFileSystemSchema,
+		})
+	}
+
+	return resources, marker, nil
+}
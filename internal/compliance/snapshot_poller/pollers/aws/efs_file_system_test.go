@@ -0,0 +1,133 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/efs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestEfsFileSystemList(t *testing.T) {
+	mockSvc := awstest.BuildMockEfsSvc([]string{"DescribeFileSystemsPages"})
+
+	out, marker, err := describeFileSystems(mockSvc, nil)
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	assert.Len(t, out, 2)
+}
+
+func TestEfsFileSystemListError(t *testing.T) {
+	mockSvc := awstest.BuildMockEfsSvcError([]string{"DescribeFileSystemsPages"})
+
+	out, marker, err := describeFileSystems(mockSvc, nil)
+	require.Error(t, err)
+	assert.Nil(t, marker)
+	assert.Nil(t, out)
+}
+
+// Test the iterator works on consecutive pages but stops at max page size
+func TestEfsFileSystemListIterator(t *testing.T) {
+	var fileSystems []*efs.FileSystemDescription
+	var marker *string
+
+	cont := efsFileSystemIterator(awstest.ExampleDescribeFileSystemsOutput, &fileSystems, &marker)
+	assert.True(t, cont)
+	assert.Nil(t, marker)
+	assert.Len(t, fileSystems, 2)
+
+	for i := 2; i < 50; i++ {
+		cont = efsFileSystemIterator(awstest.ExampleDescribeFileSystemsOutputContinue, &fileSystems, &marker)
+		assert.True(t, cont)
+		assert.NotNil(t, marker)
+		assert.Len(t, fileSystems, i*2)
+	}
+
+	cont = efsFileSystemIterator(awstest.ExampleDescribeFileSystemsOutputContinue, &fileSystems, &marker)
+	assert.False(t, cont)
+	assert.NotNil(t, marker)
+	assert.Len(t, fileSystems, 100)
+}
+
+func TestBuildEfsFileSystemSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockEfsSvcAll()
+
+	fileSystem, err := buildEfsFileSystemSnapshot(mockSvc, awstest.ExampleFileSystem)
+	require.NoError(t, err)
+	assert.Equal(t, awstest.ExampleFileSystem.FileSystemArn, fileSystem.ARN)
+	assert.Equal(t, awstest.ExampleFileSystemId, fileSystem.ID)
+	assert.Equal(t, "example-file-system", *fileSystem.Name)
+	assert.True(t, *fileSystem.Encrypted)
+	assert.NotEmpty(t, fileSystem.Policy)
+	assert.Equal(t, "ENABLED", *fileSystem.BackupPolicy.Status)
+	assert.Len(t, fileSystem.LifecyclePolicies, 1)
+	assert.Len(t, fileSystem.MountTargets, 1)
+	assert.Equal(t, aws.String("example-file-system"), fileSystem.Tags["Name"])
+}
+
+func TestBuildEfsFileSystemSnapshotError(t *testing.T) {
+	mockSvc := awstest.BuildMockEfsSvcAllError()
+
+	fileSystem, err := buildEfsFileSystemSnapshot(mockSvc, awstest.ExampleFileSystem)
+	require.Error(t, err)
+	assert.Nil(t, fileSystem)
+}
+
+func TestEfsFileSystemPoller(t *testing.T) {
+	awstest.MockEfsForSetup = awstest.BuildMockEfsSvcAll()
+
+	EfsClientFunc = awstest.SetupMockEfs
+
+	resources, marker, err := PollEfsFileSystems(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	assert.Len(t, resources, 2)
+}
+
+func TestEfsFileSystemPollerError(t *testing.T) {
+	resetCache()
+	awstest.MockEfsForSetup = awstest.BuildMockEfsSvcAllError()
+
+	EfsClientFunc = awstest.SetupMockEfs
+
+	resources, marker, err := PollEfsFileSystems(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, marker)
+	assert.Nil(t, resources)
+}
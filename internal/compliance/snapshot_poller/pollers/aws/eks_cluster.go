@@ -30,9 +30,16 @@ import (
 	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
 	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
-	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
 )
 
+func init() {
+	resourcetypes.RegisterPollers(awsmodels.EksClusterSchema, &resourcetypes.ResourcePollers{
+		Service: PollEksClusters,
+		ID:      PollEKSCluster,
+	})
+}
+
 // Set as variables to be overridden in testing
 var EksClientFunc = setupEksClient
 
@@ -52,7 +59,7 @@ func getEksClient(pollerResourceInput *awsmodels.ResourcePollerInput, region str
 // PollEKSCluster polls a single EKS cluster resource
 func PollEKSCluster(
 	pollerInput *awsmodels.ResourcePollerInput,
-	parsedResourceID *utils.ParsedResourceID,
+	parsedResourceID *awsmodels.ParsedResourceID,
 	scanRequest *pollermodels.ScanEntry,
 ) (interface{}, error) {
 
@@ -0,0 +1,245 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/elasticsearchservice"
+	"github.com/aws/aws-sdk-go/service/elasticsearchservice/elasticsearchserviceiface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+func init() {
+	resourcetypes.RegisterPollers(awsmodels.ElasticsearchDomainSchema, &resourcetypes.ResourcePollers{
+		Service: PollElasticsearchDomains,
+		ARN:     PollElasticsearchDomain,
+	})
+}
+
+// The maximum number of domains accepted by DescribeElasticsearchDomains
+const elasticsearchDescribeBatchSize = 5
+
+// Set as variables to be overridden in testing
+var (
+	ElasticsearchClientFunc = setupElasticsearchClient
+)
+
+func setupElasticsearchClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return elasticsearchservice.New(sess, cfg)
+}
+
+func getElasticsearchClient(
+	pollerResourceInput *awsmodels.ResourcePollerInput, region string) (elasticsearchserviceiface.ElasticsearchServiceAPI, error) {
+
+	client, err := getClient(pollerResourceInput, ElasticsearchClientFunc, "es", region)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.(elasticsearchserviceiface.ElasticsearchServiceAPI), nil
+}
+
+// PollElasticsearchDomain polls a single Elasticsearch domain resource
+func PollElasticsearchDomain(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	_ *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getElasticsearchClient(pollerResourceInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	domainName := strings.TrimPrefix(resourceARN.Resource, "domain/")
+	domains, err := describeElasticsearchDomains(client, []*string{aws.String(domainName)})
+	if err != nil {
+		return nil, err
+	}
+	if len(domains) == 0 {
+		zap.L().Warn("tried to scan non-existent resource",
+			zap.String("resource", domainName),
+			zap.String("resourceType", awsmodels.ElasticsearchDomainSchema))
+		return nil, nil
+	}
+
+	snapshot, err := buildElasticsearchDomainSnapshot(client, domains[0])
+	if err != nil || snapshot == nil {
+		return nil, err
+	}
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// listDomainNames returns the names of all the Elasticsearch domains in a region
+//
+// The Elasticsearch API does not paginate this call.
+func listDomainNames(esSvc elasticsearchserviceiface.ElasticsearchServiceAPI) ([]*string, error) {
+	out, err := esSvc.ListDomainNames(&elasticsearchservice.ListDomainNamesInput{})
+	if err != nil {
+		return nil, errors.Wrap(err, "Elasticsearch.ListDomainNames")
+	}
+	domainNames := make([]*string, 0, len(out.DomainNames))
+	for _, domain := range out.DomainNames {
+		domainNames = append(domainNames, domain.DomainName)
+	}
+	return domainNames, nil
+}
+
+// describeElasticsearchDomains returns the status of the given Elasticsearch domains
+//
+// Domains that do not exist are omitted from the result.
+func describeElasticsearchDomains(
+	esSvc elasticsearchserviceiface.ElasticsearchServiceAPI, domainNames []*string) ([]*elasticsearchservice.ElasticsearchDomainStatus, error) {
+
+	domains := make([]*elasticsearchservice.ElasticsearchDomainStatus, 0, len(domainNames))
+	for len(domainNames) > 0 {
+		batch := domainNames
+		if len(batch) > elasticsearchDescribeBatchSize {
+			batch = batch[:elasticsearchDescribeBatchSize]
+		}
+		domainNames = domainNames[len(batch):]
+
+		out, err := esSvc.DescribeElasticsearchDomains(&elasticsearchservice.DescribeElasticsearchDomainsInput{
+			DomainNames: batch,
+		})
+		if err != nil {
+			var awsErr awserr.Error
+			if errors.As(err, &awsErr) && awsErr.Code() == elasticsearchservice.ErrCodeResourceNotFoundException {
+				continue
+			}
+			return nil, errors.Wrapf(err, "Elasticsearch.DescribeElasticsearchDomains: %s", aws.StringValueSlice(batch))
+		}
+		domains = append(domains, out.DomainStatusList...)
+	}
+	return domains, nil
+}
+
+// listTagsElasticsearch returns the tags of an Elasticsearch domain
+func listTagsElasticsearch(esSvc elasticsearchserviceiface.ElasticsearchServiceAPI, domainARN *string) ([]*elasticsearchservice.Tag, error) {
+	out, err := esSvc.ListTags(&elasticsearchservice.ListTagsInput{ARN: domainARN})
+	if err != nil {
+		return nil, errors.Wrapf(err, "Elasticsearch.ListTags: %s", aws.StringValue(domainARN))
+	}
+	return out.TagList, nil
+}
+
+// buildElasticsearchDomainSnapshot makes all the calls to build up a snapshot of a given Elasticsearch domain
+func buildElasticsearchDomainSnapshot(
+	esSvc elasticsearchserviceiface.ElasticsearchServiceAPI,
+	domain *elasticsearchservice.ElasticsearchDomainStatus,
+) (*awsmodels.ElasticsearchDomain, error) {
+
+	if domain == nil {
+		return nil, nil
+	}
+
+	snapshot := &awsmodels.ElasticsearchDomain{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   domain.ARN,
+			ResourceType: aws.String(awsmodels.ElasticsearchDomainSchema),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  domain.ARN,
+			ID:   domain.DomainId,
+			Name: domain.DomainName,
+		},
+		AccessPolicies:              domain.AccessPolicies,
+		AdvancedOptions:             domain.AdvancedOptions,
+		AdvancedSecurityOptions:     domain.AdvancedSecurityOptions,
+		CognitoOptions:              domain.CognitoOptions,
+		Created:                     domain.Created,
+		Deleted:                     domain.Deleted,
+		DomainEndpointOptions:       domain.DomainEndpointOptions,
+		EBSOptions:                  domain.EBSOptions,
+		ElasticsearchClusterConfig:  domain.ElasticsearchClusterConfig,
+		ElasticsearchVersion:        domain.ElasticsearchVersion,
+		EncryptionAtRestOptions:     domain.EncryptionAtRestOptions,
+		Endpoint:                    domain.Endpoint,
+		Endpoints:                   domain.Endpoints,
+		LogPublishingOptions:        domain.LogPublishingOptions,
+		NodeToNodeEncryptionOptions: domain.NodeToNodeEncryptionOptions,
+		Processing:                  domain.Processing,
+		ServiceSoftwareOptions:      domain.ServiceSoftwareOptions,
+		SnapshotOptions:             domain.SnapshotOptions,
+		UpgradeProcessing:           domain.UpgradeProcessing,
+		VPCOptions:                  domain.VPCOptions,
+	}
+
+	tags, err := listTagsElasticsearch(esSvc, domain.ARN)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.Tags = utils.ParseTagSlice(tags)
+
+	return snapshot, nil
+}
+
+// PollElasticsearchDomains gathers information on each Elasticsearch domain for an AWS account.
+func PollElasticsearchDomains(pollerInput *awsmodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	zap.L().Debug("starting Elasticsearch Domain resource poller")
+
+	esSvc, err := getElasticsearchClient(pollerInput, *pollerInput.Region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Start with generating a list of all domains, there is no paging for this resource type
+	domainNames, err := listDomainNames(esSvc)
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "region: %s", *pollerInput.Region)
+	}
+	domains, err := describeElasticsearchDomains(esSvc, domainNames)
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "region: %s", *pollerInput.Region)
+	}
+
+	resources := make([]apimodels.AddResourceEntry, 0, len(domains))
+	for _, domain := range domains {
+		snapshot, err := buildElasticsearchDomainSnapshot(esSvc, domain)
+		if err != nil {
+			return nil, nil, err
+		}
+		snapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		snapshot.Region = pollerInput.Region
+
+		resources = append(resources, apimodels.AddResourceEntry{
+			Attributes:      snapshot,
+			ID:              *snapshot.ResourceID,
+			IntegrationID:   *pollerInput.IntegrationID,
+			IntegrationType: integrationType,
+			Type:            awsmodels.ElasticsearchDomainSchema,
+		})
+	}
+
+	return resources, nil, nil
+}
@@ -0,0 +1,121 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestElasticsearchDomainList(t *testing.T) {
+	mockSvc := awstest.BuildMockElasticsearchSvc([]string{"ListDomainNames"})
+
+	out, err := listDomainNames(mockSvc)
+	require.NoError(t, err)
+	require.Len(t, out, 2)
+	assert.Equal(t, awstest.ExampleDomainName, out[0])
+}
+
+func TestElasticsearchDomainListError(t *testing.T) {
+	mockSvc := awstest.BuildMockElasticsearchSvcError([]string{"ListDomainNames"})
+
+	out, err := listDomainNames(mockSvc)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+// Test the domains are described in batches
+func TestElasticsearchDomainDescribeBatches(t *testing.T) {
+	mockSvc := awstest.BuildMockElasticsearchSvc([]string{"DescribeElasticsearchDomains"})
+
+	domainNames := make([]*string, 2*elasticsearchDescribeBatchSize+1)
+	for i := range domainNames {
+		domainNames[i] = awstest.ExampleDomainName
+	}
+	out, err := describeElasticsearchDomains(mockSvc, domainNames)
+	require.NoError(t, err)
+	// The mock returns two domains for each of the three batches
+	assert.Len(t, out, 6)
+	mockSvc.AssertNumberOfCalls(t, "DescribeElasticsearchDomains", 3)
+}
+
+func TestBuildElasticsearchDomainSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockElasticsearchSvcAll()
+
+	domain, err := buildElasticsearchDomainSnapshot(mockSvc, awstest.ExampleDescribeElasticsearchDomainsOutput.DomainStatusList[0])
+	require.NoError(t, err)
+	assert.Equal(t, "arn:aws:es:us-west-2:123456789012:domain/example-domain", *domain.ARN)
+	assert.Equal(t, awstest.ExampleDomainName, domain.Name)
+	assert.Equal(t, "7.9", *domain.ElasticsearchVersion)
+	assert.True(t, *domain.EncryptionAtRestOptions.Enabled)
+	assert.True(t, *domain.DomainEndpointOptions.EnforceHTTPS)
+	assert.NotEmpty(t, domain.AccessPolicies)
+	assert.Equal(t, aws.String("Value1"), domain.Tags["Key1"])
+}
+
+func TestBuildElasticsearchDomainSnapshotError(t *testing.T) {
+	mockSvc := awstest.BuildMockElasticsearchSvcAllError()
+
+	domain, err := buildElasticsearchDomainSnapshot(mockSvc, awstest.ExampleDescribeElasticsearchDomainsOutput.DomainStatusList[0])
+	require.Error(t, err)
+	assert.Nil(t, domain)
+}
+
+func TestElasticsearchDomainPoller(t *testing.T) {
+	awstest.MockElasticsearchForSetup = awstest.BuildMockElasticsearchSvcAll()
+
+	ElasticsearchClientFunc = awstest.SetupMockElasticsearch
+
+	resources, marker, err := PollElasticsearchDomains(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	assert.Len(t, resources, 2)
+}
+
+func TestElasticsearchDomainPollerError(t *testing.T) {
+	resetCache()
+	awstest.MockElasticsearchForSetup = awstest.BuildMockElasticsearchSvcAllError()
+
+	ElasticsearchClientFunc = awstest.SetupMockElasticsearch
+
+	resources, marker, err := PollElasticsearchDomains(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, marker)
+	assert.Nil(t, resources)
+}
@@ -33,9 +33,17 @@ import (
 	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
 	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
 	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
 )
 
+func init() {
+	resourcetypes.RegisterPollers(awsmodels.Elbv2LoadBalancerSchema, &resourcetypes.ResourcePollers{
+		Service: PollElbv2ApplicationLoadBalancers,
+		ARN:     PollELBV2LoadBalancer,
+	})
+}
+
 // Set as variables to be overridden in testing
 var (
 	Elbv2ClientFunc = setupElbv2Client
@@ -29,9 +29,17 @@ import (
 	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
 	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
 	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
 )
 
+func init() {
+	resourcetypes.RegisterPollers(awsmodels.GuardDutySchema, &resourcetypes.ResourcePollers{
+		Service: PollGuardDutyDetectors,
+		ID:      PollGuardDutyDetector,
+	})
+}
+
 // Set as variables to be overridden in testing
 var (
 	GuardDutyClientFunc = setupGuardDutyClient
@@ -53,7 +61,7 @@ func getGuardDutyClient(pollerResourceInput *awsmodels.ResourcePollerInput, regi
 // PollGuardDutyDetector polls a single AWS Config resource
 func PollGuardDutyDetector(
 	pollerResourceInput *awsmodels.ResourcePollerInput,
-	parsedResourceID *utils.ParsedResourceID,
+	parsedResourceID *awsmodels.ParsedResourceID,
 	scanRequest *pollermodels.ScanEntry,
 ) (interface{}, error) {
 
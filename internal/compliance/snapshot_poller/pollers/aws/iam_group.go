@@ -33,8 +33,16 @@ import (
 	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
 	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
 )
 
+func init() {
+	resourcetypes.RegisterPollers(awsmodels.IAMGroupSchema, &resourcetypes.ResourcePollers{
+		Service: PollIamGroups,
+		ARN:     PollIAMGroup,
+	})
+}
+
 // PollIAMGroup polls a single IAM Group resource
 func PollIAMGroup(
 	pollerResourceInput *awsmodels.ResourcePollerInput,
@@ -32,8 +32,16 @@ import (
 	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
 	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
 )
 
+func init() {
+	resourcetypes.RegisterPollers(awsmodels.IAMPolicySchema, &resourcetypes.ResourcePollers{
+		Service: PollIamPolicies,
+		ARN:     PollIAMPolicy,
+	})
+}
+
 const (
 	localPolicyScope = "Local"
 )
@@ -33,10 +33,18 @@ import (
 	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
 	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
 	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
 	"github.com/panther-labs/panther/pkg/awsutils"
 )
 
+func init() {
+	resourcetypes.RegisterPollers(awsmodels.IAMRoleSchema, &resourcetypes.ResourcePollers{
+		Service: PollIAMRoles,
+		ARN:     PollIAMRole,
+	})
+}
+
 // PollIAMRole polls a single IAM Role resource
 func PollIAMRole(
 	pollerResourceInput *awsmodels.ResourcePollerInput,
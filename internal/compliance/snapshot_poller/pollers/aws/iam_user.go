@@ -38,9 +38,20 @@ import (
 	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
 	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
 	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
 )
 
+func init() {
+	resourcetypes.RegisterPollers(awsmodels.IAMRootUserSchema, &resourcetypes.ResourcePollers{
+		ARN: PollIAMRootUser,
+	})
+	resourcetypes.RegisterPollers(awsmodels.IAMUserSchema, &resourcetypes.ResourcePollers{
+		Service: PollIAMUsers,
+		ARN:     PollIAMUser,
+	})
+}
+
 const (
 	// Time to delay the requeue of a scan of IAM Users when the credential report times out
 	credentialReportRequeueDelaySeconds = 90
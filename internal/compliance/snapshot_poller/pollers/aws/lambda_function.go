@@ -31,8 +31,16 @@ import (
 	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
 	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
 )
 
+func init() {
+	resourcetypes.RegisterPollers(awsmodels.LambdaFunctionSchema, &resourcetypes.ResourcePollers{
+		Service: PollLambdaFunctions,
+		ARN:     PollLambdaFunction,
+	})
+}
+
 // Set as variables to be overridden in testing
 var (
 	LambdaClientFunc = setupLambdaClient
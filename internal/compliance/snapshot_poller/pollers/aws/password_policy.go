@@ -30,9 +30,17 @@ import (
 	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
 	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
 	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
 )
 
+func init() {
+	resourcetypes.RegisterPollers(awsmodels.PasswordPolicySchema, &resourcetypes.ResourcePollers{
+		Service: PollPasswordPolicy,
+		ID:      PollPasswordPolicyResource,
+	})
+}
+
 // Set as variables to be overridden in testing
 var (
 	IAMClientFunc = setupIAMClient
@@ -54,7 +62,7 @@ func getIAMClient(pollerResourceInput *awsmodels.ResourcePollerInput, region str
 // PollPasswordPolicyResource polls a password policy and returns it as a resource
 func PollPasswordPolicyResource(
 	pollerResourceInput *awsmodels.ResourcePollerInput,
-	_ *utils.ParsedResourceID,
+	_ *awsmodels.ParsedResourceID,
 	_ *pollermodels.ScanEntry,
 ) (interface{}, error) {
 
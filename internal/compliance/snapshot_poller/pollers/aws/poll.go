@@ -32,16 +32,11 @@ import (
 	resourcesapimodels "github.com/panther-labs/panther/api/lambda/resources/models"
 	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
 	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
 	"github.com/panther-labs/panther/pkg/awsutils"
 )
 
-// resourcePoller is a simple struct to be used only for invoking the ResourcePollers in order.
-type resourcePoller struct {
-	description    string
-	resourcePoller awsmodels.ResourcePoller
-}
-
 const (
 	integrationType = "aws"
 	// How long to wait before re-scanning a resource that was rate limited during scanning
@@ -60,91 +55,6 @@ var (
 	// many resources, then do one additional page worth of resources
 	defaultBatchSize   = 100
 	pageRequeueDelayer = rand.New(rand.NewSource(time.Now().UnixNano())) // nolint:gosec
-
-	// IndividualARNResourcePollers maps resource types to their corresponding individual polling
-	// functions for resources whose ID is their ARN.
-	//
-	// NOTE! - This hardcoded data set is found in several places in our code base.
-	// Until this data is sourced from a single location you need to check if any additions
-	// or modifications to this data need to coincide with updates in the other places where this data
-	// is hardcoded.
-	//
-	// Locations may not be in this list! right now this data is hardcoded in
-	// • internal/compliance/snapshot_poller/models/aws/ResourceTypes.go
-	// • internal/compliance/snapshot_poller/pollers/aws/clients.go
-	// • web/src/constants.ts
-	//
-	IndividualARNResourcePollers = map[string]func(
-		input *awsmodels.ResourcePollerInput, arn arn.ARN, entry *pollermodels.ScanEntry) (interface{}, error){
-		awsmodels.AcmCertificateSchema:      PollACMCertificate,
-		awsmodels.CloudFormationStackSchema: PollCloudFormationStack,
-		awsmodels.CloudTrailSchema:          PollCloudTrailTrail,
-		awsmodels.CloudWatchLogGroupSchema:  PollCloudWatchLogsLogGroup,
-		awsmodels.DynamoDBTableSchema:       PollDynamoDBTable,
-		awsmodels.Ec2AmiSchema:              PollEC2Image,
-		awsmodels.Ec2InstanceSchema:         PollEC2Instance,
-		awsmodels.Ec2NetworkAclSchema:       PollEC2NetworkACL,
-		awsmodels.Ec2SecurityGroupSchema:    PollEC2SecurityGroup,
-		awsmodels.Ec2VolumeSchema:           PollEC2Volume,
-		awsmodels.Ec2VpcSchema:              PollEC2VPC,
-		awsmodels.EcsClusterSchema:          PollECSCluster,
-		awsmodels.Elbv2LoadBalancerSchema:   PollELBV2LoadBalancer,
-		awsmodels.IAMGroupSchema:            PollIAMGroup,
-		awsmodels.IAMPolicySchema:           PollIAMPolicy,
-		awsmodels.IAMRoleSchema:             PollIAMRole,
-		awsmodels.IAMUserSchema:             PollIAMUser,
-		awsmodels.IAMRootUserSchema:         PollIAMRootUser,
-		awsmodels.KmsKeySchema:              PollKMSKey,
-		awsmodels.LambdaFunctionSchema:      PollLambdaFunction,
-		awsmodels.RDSInstanceSchema:         PollRDSInstance,
-		awsmodels.RedshiftClusterSchema:     PollRedshiftCluster,
-		awsmodels.S3BucketSchema:            PollS3Bucket,
-		awsmodels.WafWebAclSchema:           PollWAFWebACL,
-		awsmodels.WafRegionalWebAclSchema:   PollWAFRegionalWebACL,
-	}
-
-	// IndividualResourcePollers maps resource types to their corresponding individual polling
-	// functions for resources whose ID is not their ARN.
-	IndividualResourcePollers = map[string]func(
-		input *awsmodels.ResourcePollerInput, id *utils.ParsedResourceID, entry *pollermodels.ScanEntry) (interface{}, error){
-		awsmodels.ConfigServiceSchema:  PollConfigService,
-		awsmodels.EksClusterSchema:     PollEKSCluster, // This function is never entered - lacking event-processor
-		awsmodels.GuardDutySchema:      PollGuardDutyDetector,
-		awsmodels.PasswordPolicySchema: PollPasswordPolicyResource,
-	}
-
-	// ServicePollers maps a resource type to its Poll function
-	ServicePollers = map[string]resourcePoller{
-		awsmodels.AcmCertificateSchema:      {"ACMCertificate", PollAcmCertificates},
-		awsmodels.CloudFormationStackSchema: {"CloudFormationStack", PollCloudFormationStacks},
-		awsmodels.CloudTrailSchema:          {"CloudTrail", PollCloudTrails},
-		awsmodels.CloudWatchLogGroupSchema:  {"CloudWatchLogGroup", PollCloudWatchLogsLogGroups},
-		awsmodels.ConfigServiceSchema:       {"ConfigService", PollConfigServices},
-		awsmodels.DynamoDBTableSchema:       {"DynamoDBTable", PollDynamoDBTables},
-		awsmodels.Ec2AmiSchema:              {"EC2AMI", PollEc2Amis},
-		awsmodels.Ec2InstanceSchema:         {"EC2Instance", PollEc2Instances},
-		awsmodels.Ec2NetworkAclSchema:       {"EC2NetworkACL", PollEc2NetworkAcls},
-		awsmodels.Ec2SecurityGroupSchema:    {"EC2SecurityGroup", PollEc2SecurityGroups},
-		awsmodels.Ec2VolumeSchema:           {"EC2Volume", PollEc2Volumes},
-		awsmodels.Ec2VpcSchema:              {"EC2VPC", PollEc2Vpcs},
-		awsmodels.EcsClusterSchema:          {"ECSCluster", PollEcsClusters},
-		awsmodels.EksClusterSchema:          {"EKSCluster", PollEksClusters},
-		awsmodels.Elbv2LoadBalancerSchema:   {"ELBV2LoadBalancer", PollElbv2ApplicationLoadBalancers},
-		awsmodels.GuardDutySchema:           {"GuardDutyDetector", PollGuardDutyDetectors},
-		awsmodels.IAMGroupSchema:            {"IAMGroups", PollIamGroups},
-		awsmodels.IAMPolicySchema:           {"IAMPolicies", PollIamPolicies},
-		awsmodels.IAMRoleSchema:             {"IAMRoles", PollIAMRoles},
-		awsmodels.IAMUserSchema:             {"IAMUser", PollIAMUsers},
-		// Service scan for the resource type IAMRootUserSchema is not defined! Do not do it!
-		awsmodels.KmsKeySchema:            {"KMSKey", PollKmsKeys},
-		awsmodels.LambdaFunctionSchema:    {"LambdaFunctions", PollLambdaFunctions},
-		awsmodels.PasswordPolicySchema:    {"PasswordPolicy", PollPasswordPolicy},
-		awsmodels.RDSInstanceSchema:       {"RDSInstance", PollRDSInstances},
-		awsmodels.RedshiftClusterSchema:   {"RedshiftCluster", PollRedshiftClusters},
-		awsmodels.S3BucketSchema:          {"S3Bucket", PollS3Buckets},
-		awsmodels.WafWebAclSchema:         {"WAFWebAcl", PollWafWebAcls},
-		awsmodels.WafRegionalWebAclSchema: {"WAFRegionalWebAcl", PollWafRegionalWebAcls},
-	}
 )
 
 // Poll coordinates AWS generatedEvents gathering across all relevant resources for compliance monitoring.
@@ -239,9 +149,9 @@ func Poll(scanRequest *pollermodels.ScanEntry) (
 				return nil, nil
 			}
 		}
-		if poller, ok := ServicePollers[*scanRequest.ResourceType]; ok {
+		if pollers := lookupPollers(*scanRequest.ResourceType); pollers != nil && pollers.Service != nil {
 			return serviceScan(
-				poller,
+				pollers.Service,
 				pollerResourceInput,
 				scanRequest,
 			)
@@ -296,18 +206,27 @@ func multiRegionScan(
 	return nil, nil
 }
 
+// lookupPollers returns the poll functions of a resource type, nil if it cannot be scanned
+func lookupPollers(resourceType string) *resourcetypes.ResourcePollers {
+	info, ok := resourcetypes.Lookup(resourceType)
+	if !ok {
+		return nil
+	}
+	return info.Pollers
+}
+
 func serviceScan(
-	poller resourcePoller,
+	poller awsmodels.ResourcePoller,
 	pollerInput *awsmodels.ResourcePollerInput,
 	scanRequest *pollermodels.ScanEntry,
 ) (generatedEvents []resourcesapimodels.AddResourceEntry, err error) {
 
 	var marker *string
-	generatedEvents, marker, err = poller.resourcePoller(pollerInput)
+	generatedEvents, marker, err = poller(pollerInput)
 	if err != nil {
 		zap.L().Info(
 			"an error occurred while polling",
-			zap.String("resourcePoller", poller.description),
+			zap.String("resourceType", *scanRequest.ResourceType),
 			zap.String("errorMessage", err.Error()),
 		)
 		return
@@ -316,7 +235,7 @@ func serviceScan(
 	zap.L().Info(
 		"resources generated",
 		zap.Int("numResources", len(generatedEvents)),
-		zap.String("resourcePoller", poller.description),
+		zap.String("resourceType", *scanRequest.ResourceType),
 	)
 
 	// If we exited early because we hit the max batch size, re-queue a scan starting from where we
@@ -359,11 +278,12 @@ func singleResourceScan(
 
 	// I don't know why this comment is here and I'm too scared to remove it
 	// TODO: does this accept short names?
-	if pollFunction, ok := IndividualResourcePollers[*scanRequest.ResourceType]; ok {
+	pollers := lookupPollers(*scanRequest.ResourceType)
+	if pollers != nil && pollers.ID != nil {
 		// Handle cases where the ResourceID is not an ARN
-		parsedResourceID := utils.ParseResourceID(*scanRequest.ResourceID)
-		resource, err = pollFunction(pollerInput, parsedResourceID, scanRequest)
-	} else if pollFunction, ok := IndividualARNResourcePollers[*scanRequest.ResourceType]; ok {
+		parsedResourceID := awsmodels.ParseResourceID(*scanRequest.ResourceID)
+		resource, err = pollers.ID(pollerInput, parsedResourceID, scanRequest)
+	} else if pollers != nil && pollers.ARN != nil {
 		// Handle cases where the ResourceID is an ARN
 		var resourceARN arn.ARN
 		resourceARN, err = arn.Parse(*scanRequest.ResourceID)
@@ -376,7 +296,7 @@ func singleResourceScan(
 		if pollerInput.ShouldIgnoreResource(*scanRequest.ResourceID) {
 			return nil, nil
 		}
-		resource, err = pollFunction(pollerInput, resourceARN, scanRequest)
+		resource, err = pollers.ARN(pollerInput, resourceARN, scanRequest)
 	} else {
 		zap.L().Error("unable to perform scan of specified resource type", zap.String("resourceType", *scanRequest.ResourceType))
 		// This error is not retryable
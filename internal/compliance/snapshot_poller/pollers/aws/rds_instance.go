@@ -32,9 +32,17 @@ import (
 	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
 	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
 	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
 )
 
+func init() {
+	resourcetypes.RegisterPollers(awsmodels.RDSInstanceSchema, &resourcetypes.ResourcePollers{
+		Service: PollRDSInstances,
+		ARN:     PollRDSInstance,
+	})
+}
+
 // RDS scanning regularly gets rate limited on the following API calls (in order of most to least
 // likely to be rate limited):
 //
@@ -0,0 +1,59 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
+)
+
+// Tests that the pollers are attached to every registered resource type, so that adding a resource
+// type without its pollers fails loudly.
+func TestRegistryPollers(t *testing.T) {
+	for _, info := range resourcetypes.RegisteredFor(resourcetypes.IntegrationTypeAWS) {
+		if info.Parent == "" {
+			if assert.NotNil(t, info.Pollers, "missing pollers for resource type %s", info.Name) {
+				assert.NotNil(t, info.Pollers.Service, "missing service poller for resource type %s", info.Name)
+			}
+			assert.NotEmpty(t, info.ServiceID, "missing service ID for resource type %s", info.Name)
+		}
+		if len(info.EventSources) == 0 {
+			// Resources that are not updated in real time are only scanned by service scans
+			continue
+		}
+		if assert.NotNil(t, info.Pollers, "missing pollers for resource type %s", info.Name) {
+			assert.True(t, info.Pollers.ARN != nil || info.Pollers.ID != nil, "missing individual poller for resource type %s", info.Name)
+		}
+	}
+}
+
+func TestRegistryRegionsToScan(t *testing.T) {
+	for _, info := range resourcetypes.RegisteredFor(resourcetypes.IntegrationTypeAWS) {
+		if !info.Global {
+			continue
+		}
+		regions, err := GetRegionsToScan(&awsmodels.ResourcePollerInput{}, info.Name)
+		assert.NoError(t, err)
+		assert.Equal(t, []*string{&defaultRegion}, regions, "resource type %s", info.Name)
+	}
+}
@@ -0,0 +1,279 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+func init() {
+	resourcetypes.RegisterPollers(awsmodels.Route53HostedZoneSchema, &resourcetypes.ResourcePollers{
+		Service: PollRoute53HostedZones,
+		ARN:     PollRoute53HostedZone,
+	})
+}
+
+const route53HostedZoneTagType = "hostedzone"
+
+// Set as variables to be overridden in testing
+var (
+	Route53ClientFunc = setupRoute53Client
+)
+
+func setupRoute53Client(sess *session.Session, cfg *aws.Config) interface{} {
+	return route53.New(sess, cfg)
+}
+
+func getRoute53Client(pollerResourceInput *awsmodels.ResourcePollerInput, region string) (route53iface.Route53API, error) {
+	client, err := getClient(pollerResourceInput, Route53ClientFunc, "route53", region)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.(route53iface.Route53API), nil
+}
+
+// PollRoute53HostedZone polls a single Route 53 hosted zone resource
+func PollRoute53HostedZone(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	_ *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	// Route 53 is a global service, hosted zones do not have a region
+	client, err := getRoute53Client(pollerResourceInput, defaultRegion)
+	if err != nil {
+		return nil, err
+	}
+
+	hostedZoneID := strings.TrimPrefix(resourceARN.Resource, route53HostedZoneTagType+"/")
+	snapshot, err := buildRoute53HostedZoneSnapshot(client, resourceARN.Partition, aws.String(hostedZoneID))
+	if err != nil || snapshot == nil {
+		return nil, err
+	}
+	// Hosted zone ARNs do not contain the account ID
+	snapshot.AccountID = aws.String(pollerResourceInput.AuthSourceParsedARN.AccountID)
+	snapshot.Region = aws.String(awsmodels.GlobalRegion)
+	return snapshot, nil
+}
+
+// route53HostedZoneARN builds the ARN of a hosted zone from its ID
+//
+// The Route 53 API returns hosted zone IDs in the form /hostedzone/Z1D633PJN98FT9
+func route53HostedZoneARN(partition string, hostedZoneID string) string {
+	return arn.ARN{
+		Partition: partition,
+		Service:   "route53",
+		Resource:  route53HostedZoneTagType + "/" + trimHostedZoneID(hostedZoneID),
+	}.String()
+}
+
+func trimHostedZoneID(hostedZoneID string) string {
+	return strings.TrimPrefix(hostedZoneID, "/"+route53HostedZoneTagType+"/")
+}
+
+// listHostedZones returns the IDs of all the hosted zones in the account
+func listHostedZones(route53Svc route53iface.Route53API, nextMarker *string) (hostedZoneIDs []*string, marker *string, err error) {
+	err = route53Svc.ListHostedZonesPages(&route53.ListHostedZonesInput{
+		Marker:   nextMarker,
+		MaxItems: aws.String(strconv.Itoa(defaultBatchSize)),
+	},
+		func(page *route53.ListHostedZonesOutput, lastPage bool) bool {
+			return route53HostedZoneIterator(page, &hostedZoneIDs, &marker)
+		})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "Route53.ListHostedZonesPages")
+	}
+	return
+}
+
+func route53HostedZoneIterator(page *route53.ListHostedZonesOutput, hostedZoneIDs *[]*string, marker **string) bool {
+	for _, hostedZone := range page.HostedZones {
+		*hostedZoneIDs = append(*hostedZoneIDs, aws.String(trimHostedZoneID(aws.StringValue(hostedZone.Id))))
+	}
+	*marker = page.NextMarker
+	return len(*hostedZoneIDs) < defaultBatchSize
+}
+
+// getHostedZone returns a hosted zone, or nil if the hosted zone no longer exists
+func getHostedZone(route53Svc route53iface.Route53API, hostedZoneID *string) (*route53.GetHostedZoneOutput, error) {
+	out, err := route53Svc.GetHostedZone(&route53.GetHostedZoneInput{Id: hostedZoneID})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == route53.ErrCodeNoSuchHostedZone {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", *hostedZoneID),
+				zap.String("resourceType", awsmodels.Route53HostedZoneSchema))
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "Route53.GetHostedZone: %s", aws.StringValue(hostedZoneID))
+	}
+	return out, nil
+}
+
+// getDNSSECStatus returns the DNSSEC signing status of a public hosted zone
+func getDNSSECStatus(route53Svc route53iface.Route53API, hostedZoneID *string) (*route53.DNSSECStatus, error) {
+	out, err := route53Svc.GetDNSSEC(&route53.GetDNSSECInput{HostedZoneId: hostedZoneID})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == "AccessDenied" {
+			zap.L().Warn("Error with IAM Permissions - Use the AWS Console or CloudFormation to update the Panther " +
+				"Audit Role policy to include the route53:GetDNSSEC permission")
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "Route53.GetDNSSEC: %s", aws.StringValue(hostedZoneID))
+	}
+	return out.Status, nil
+}
+
+// listQueryLoggingConfigs returns the query logging configurations of a hosted zone
+func listQueryLoggingConfigs(
+	route53Svc route53iface.Route53API, hostedZoneID *string) (configs []*route53.QueryLoggingConfig, err error) {
+
+	err = route53Svc.ListQueryLoggingConfigsPages(&route53.ListQueryLoggingConfigsInput{HostedZoneId: hostedZoneID},
+		func(page *route53.ListQueryLoggingConfigsOutput, lastPage bool) bool {
+			configs = append(configs, page.QueryLoggingConfigs...)
+			return true
+		})
+	if err != nil {
+		return nil, errors.Wrapf(err, "Route53.ListQueryLoggingConfigsPages: %s", aws.StringValue(hostedZoneID))
+	}
+	return configs, nil
+}
+
+// listTagsRoute53 returns the tags of a hosted zone
+func listTagsRoute53(route53Svc route53iface.Route53API, hostedZoneID *string) ([]*route53.Tag, error) {
+	out, err := route53Svc.ListTagsForResource(&route53.ListTagsForResourceInput{
+		ResourceId:   hostedZoneID,
+		ResourceType: aws.String(route53HostedZoneTagType),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "Route53.ListTagsForResource: %s", aws.StringValue(hostedZoneID))
+	}
+	if out.ResourceTagSet == nil {
+		return nil, nil
+	}
+	return out.ResourceTagSet.Tags, nil
+}
+
+// buildRoute53HostedZoneSnapshot makes all the calls to build up a snapshot of a given hosted zone
+func buildRoute53HostedZoneSnapshot(
+	route53Svc route53iface.Route53API, partition string, hostedZoneID *string) (*awsmodels.Route53HostedZone, error) {
+
+	if hostedZoneID == nil {
+		return nil, nil
+	}
+	out, err := getHostedZone(route53Svc, hostedZoneID)
+	if err != nil || out == nil || out.HostedZone == nil {
+		return nil, err
+	}
+
+	hostedZoneARN := aws.String(route53HostedZoneARN(partition, aws.StringValue(hostedZoneID)))
+	snapshot := &awsmodels.Route53HostedZone{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   hostedZoneARN,
+			ResourceType: aws.String(awsmodels.Route53HostedZoneSchema),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  hostedZoneARN,
+			ID:   hostedZoneID,
+			Name: out.HostedZone.Name,
+		},
+		CallerReference:        out.HostedZone.CallerReference,
+		Config:                 out.HostedZone.Config,
+		LinkedService:          out.HostedZone.LinkedService,
+		ResourceRecordSetCount: out.HostedZone.ResourceRecordSetCount,
+		DelegationSet:          out.DelegationSet,
+		VPCs:                   out.VPCs,
+	}
+
+	tags, err := listTagsRoute53(route53Svc, hostedZoneID)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.Tags = utils.ParseTagSlice(tags)
+
+	// DNSSEC signing and query logging are only supported for public hosted zones
+	if snapshot.Config != nil && aws.BoolValue(snapshot.Config.PrivateZone) {
+		return snapshot, nil
+	}
+	if snapshot.DNSSECStatus, err = getDNSSECStatus(route53Svc, hostedZoneID); err != nil {
+		return nil, err
+	}
+	if snapshot.QueryLoggingConfigs, err = listQueryLoggingConfigs(route53Svc, hostedZoneID); err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// PollRoute53HostedZones gathers information on each Route 53 hosted zone for an AWS account.
+func PollRoute53HostedZones(pollerInput *awsmodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	zap.L().Debug("starting Route53 Hosted Zone resource poller")
+
+	route53Svc, err := getRoute53Client(pollerInput, defaultRegion)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Start with generating a list of all hosted zones
+	hostedZoneIDs, marker, err := listHostedZones(route53Svc, pollerInput.NextPageToken)
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "region: global")
+	}
+
+	resources := make([]apimodels.AddResourceEntry, 0, len(hostedZoneIDs))
+	for _, hostedZoneID := range hostedZoneIDs {
+		snapshot, err := buildRoute53HostedZoneSnapshot(route53Svc, pollerInput.AuthSourceParsedARN.Partition, hostedZoneID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if snapshot == nil {
+			continue
+		}
+		snapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		snapshot.Region = aws.String(awsmodels.GlobalRegion)
+
+		resources = append(resources, apimodels.AddResourceEntry{
+			Attributes:      snapshot,
+			ID:              *snapshot.ResourceID,
+			IntegrationID:   *pollerInput.IntegrationID,
+			IntegrationType: integrationType,
+			Type:            awsmodels.Route53HostedZoneSchema,
+		})
+	}
+
+	return resources, marker, nil
+}
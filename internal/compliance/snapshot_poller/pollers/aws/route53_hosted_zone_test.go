@@ -0,0 +1,133 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestRoute53HostedZoneList(t *testing.T) {
+	mockSvc := awstest.BuildMockRoute53Svc([]string{"ListHostedZonesPages"})
+
+	out, marker, err := listHostedZones(mockSvc, nil)
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	require.Len(t, out, 2)
+	// The "/hostedzone/" prefix is removed from the IDs
+	assert.Equal(t, awstest.ExampleHostedZoneId, out[0])
+}
+
+func TestRoute53HostedZoneListError(t *testing.T) {
+	mockSvc := awstest.BuildMockRoute53SvcError([]string{"ListHostedZonesPages"})
+
+	out, marker, err := listHostedZones(mockSvc, nil)
+	require.Error(t, err)
+	assert.Nil(t, marker)
+	assert.Nil(t, out)
+}
+
+// Test the iterator works on consecutive pages but stops at max page size
+func TestRoute53HostedZoneListIterator(t *testing.T) {
+	var hostedZoneIDs []*string
+	var marker *string
+
+	cont := route53HostedZoneIterator(awstest.ExampleListHostedZonesOutput, &hostedZoneIDs, &marker)
+	assert.True(t, cont)
+	assert.Nil(t, marker)
+	assert.Len(t, hostedZoneIDs, 2)
+
+	for i := 2; i < 50; i++ {
+		cont = route53HostedZoneIterator(awstest.ExampleListHostedZonesOutputContinue, &hostedZoneIDs, &marker)
+		assert.True(t, cont)
+		assert.NotNil(t, marker)
+		assert.Len(t, hostedZoneIDs, i*2)
+	}
+
+	cont = route53HostedZoneIterator(awstest.ExampleListHostedZonesOutputContinue, &hostedZoneIDs, &marker)
+	assert.False(t, cont)
+	assert.NotNil(t, marker)
+	assert.Len(t, hostedZoneIDs, 100)
+}
+
+func TestBuildRoute53HostedZoneSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockRoute53SvcAll()
+
+	hostedZone, err := buildRoute53HostedZoneSnapshot(mockSvc, "aws", awstest.ExampleHostedZoneId)
+	require.NoError(t, err)
+	assert.Equal(t, "arn:aws:route53:::hostedzone/Z1D633PJN98FT9", *hostedZone.ARN)
+	assert.Equal(t, awstest.ExampleHostedZoneId, hostedZone.ID)
+	assert.Equal(t, "example.com.", *hostedZone.Name)
+	assert.Len(t, hostedZone.DelegationSet.NameServers, 2)
+	assert.Equal(t, "NOT_SIGNING", *hostedZone.DNSSECStatus.ServeSignature)
+	assert.Len(t, hostedZone.QueryLoggingConfigs, 1)
+	assert.Equal(t, aws.String("Value1"), hostedZone.Tags["Key1"])
+}
+
+func TestBuildRoute53HostedZoneSnapshotError(t *testing.T) {
+	mockSvc := awstest.BuildMockRoute53SvcAllError()
+
+	hostedZone, err := buildRoute53HostedZoneSnapshot(mockSvc, "aws", awstest.ExampleHostedZoneId)
+	require.Error(t, err)
+	assert.Nil(t, hostedZone)
+}
+
+func TestRoute53HostedZonePoller(t *testing.T) {
+	awstest.MockRoute53ForSetup = awstest.BuildMockRoute53SvcAll()
+
+	Route53ClientFunc = awstest.SetupMockRoute53
+
+	resources, marker, err := PollRoute53HostedZones(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	require.Len(t, resources, 2)
+	assert.Equal(t, awsmodels.GlobalRegion, *resources[0].Attributes.(*awsmodels.Route53HostedZone).Region)
+}
+
+func TestRoute53HostedZonePollerError(t *testing.T) {
+	resetCache()
+	awstest.MockRoute53ForSetup = awstest.BuildMockRoute53SvcAllError()
+
+	Route53ClientFunc = awstest.SetupMockRoute53
+
+	resources, marker, err := PollRoute53HostedZones(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, marker)
+	assert.Nil(t, resources)
+}
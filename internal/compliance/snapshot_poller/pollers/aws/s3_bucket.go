@@ -33,9 +33,17 @@ import (
 	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
 	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
 	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
 )
 
+func init() {
+	resourcetypes.RegisterPollers(awsmodels.S3BucketSchema, &resourcetypes.ResourcePollers{
+		Service: PollS3Buckets,
+		ARN:     PollS3Bucket,
+	})
+}
+
 var (
 	// S3ClientFunc is the function to initialize the S3 Client.
 	S3ClientFunc = setupS3Client
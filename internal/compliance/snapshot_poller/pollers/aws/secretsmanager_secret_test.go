@@ -0,0 +1,130 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestSecretsManagerSecretList(t *testing.T) {
+	mockSvc := awstest.BuildMockSecretsManagerSvc([]string{"ListSecretsPages"})
+
+	out, marker, err := listSecrets(mockSvc, nil)
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	assert.Len(t, out, 2)
+}
+
+func TestSecretsManagerSecretListError(t *testing.T) {
+	mockSvc := awstest.BuildMockSecretsManagerSvcError([]string{"ListSecretsPages"})
+
+	out, marker, err := listSecrets(mockSvc, nil)
+	require.Error(t, err)
+	assert.Nil(t, marker)
+	assert.Nil(t, out)
+}
+
+// Test the iterator works on consecutive pages but stops at max page size
+func TestSecretsManagerSecretListIterator(t *testing.T) {
+	var secretARNs []*string
+	var marker *string
+
+	cont := secretIterator(awstest.ExampleListSecretsOutput, &secretARNs, &marker)
+	assert.True(t, cont)
+	assert.Nil(t, marker)
+	assert.Len(t, secretARNs, 2)
+
+	for i := 2; i < 50; i++ {
+		cont = secretIterator(awstest.ExampleListSecretsOutputContinue, &secretARNs, &marker)
+		assert.True(t, cont)
+		assert.NotNil(t, marker)
+		assert.Len(t, secretARNs, i*2)
+	}
+
+	cont = secretIterator(awstest.ExampleListSecretsOutputContinue, &secretARNs, &marker)
+	assert.False(t, cont)
+	assert.NotNil(t, marker)
+	assert.Len(t, secretARNs, 100)
+}
+
+func TestBuildSecretsManagerSecretSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockSecretsManagerSvcAll()
+
+	secret, err := buildSecretsManagerSecretSnapshot(mockSvc, awstest.ExampleSecretArn)
+	require.NoError(t, err)
+	assert.Equal(t, awstest.ExampleSecretArn, secret.ARN)
+	assert.Equal(t, "example-secret", *secret.Name)
+	assert.True(t, *secret.RotationEnabled)
+	assert.Equal(t, int64(30), *secret.RotationRules.AutomaticallyAfterDays)
+	assert.NotEmpty(t, secret.ResourcePolicy)
+	assert.Equal(t, aws.String("Value1"), secret.Tags["Key1"])
+	mockSvc.AssertNotCalled(t, "GetSecretValue")
+}
+
+func TestBuildSecretsManagerSecretSnapshotError(t *testing.T) {
+	mockSvc := awstest.BuildMockSecretsManagerSvcAllError()
+
+	secret, err := buildSecretsManagerSecretSnapshot(mockSvc, awstest.ExampleSecretArn)
+	require.Error(t, err)
+	assert.Nil(t, secret)
+}
+
+func TestSecretsManagerSecretPoller(t *testing.T) {
+	awstest.MockSecretsManagerForSetup = awstest.BuildMockSecretsManagerSvcAll()
+
+	SecretsManagerClientFunc = awstest.SetupMockSecretsManager
+
+	resources, marker, err := PollSecretsManagerSecrets(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	assert.Len(t, resources, 2)
+}
+
+func TestSecretsManagerSecretPollerError(t *testing.T) {
+	resetCache()
+	awstest.MockSecretsManagerForSetup = awstest.BuildMockSecretsManagerSvcAllError()
+
+	SecretsManagerClientFunc = awstest.SetupMockSecretsManager
+
+	resources, marker, err := PollSecretsManagerSecrets(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, marker)
+	assert.Nil(t, resources)
+}
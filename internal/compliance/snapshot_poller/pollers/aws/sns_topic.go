@@ -0,0 +1,235 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sns/snsiface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+func init() {
+	resourcetypes.RegisterPollers(awsmodels.SnsTopicSchema, &resourcetypes.ResourcePollers{
+		Service: PollSnsTopics,
+		ARN:     PollSnsTopic,
+	})
+}
+
+// Set as variables to be overridden in testing
+var (
+	SnsClientFunc = setupSnsClient
+)
+
+func setupSnsClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return sns.New(sess, cfg)
+}
+
+func getSnsClient(pollerResourceInput *awsmodels.ResourcePollerInput, region string) (snsiface.SNSAPI, error) {
+	client, err := getClient(pollerResourceInput, SnsClientFunc, "sns", region)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.(snsiface.SNSAPI), nil
+}
+
+// PollSnsTopic polls a single SNS topic resource
+func PollSnsTopic(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	scanRequest *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getSnsClient(pollerResourceInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := buildSnsTopicSnapshot(client, scanRequest.ResourceID)
+	if err != nil || snapshot == nil {
+		return nil, err
+	}
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// listTopics returns the ARNs of all topics in the region
+//
+// The SNS API returns up to 100 topics per page and does not accept a page size.
+func listTopics(snsSvc snsiface.SNSAPI, nextMarker *string) (topicARNs []*string, marker *string, err error) {
+	err = snsSvc.ListTopicsPages(&sns.ListTopicsInput{
+		NextToken: nextMarker,
+	},
+		func(page *sns.ListTopicsOutput, lastPage bool) bool {
+			return snsTopicIterator(page, &topicARNs, &marker)
+		})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "SNS.ListTopicsPages")
+	}
+	return
+}
+
+func snsTopicIterator(page *sns.ListTopicsOutput, topicARNs *[]*string, marker **string) bool {
+	for _, topic := range page.Topics {
+		*topicARNs = append(*topicARNs, topic.TopicArn)
+	}
+	*marker = page.NextToken
+	return len(*topicARNs) < defaultBatchSize
+}
+
+// getTopicAttributes returns all attributes of a topic, or nil if the topic no longer exists
+func getTopicAttributes(snsSvc snsiface.SNSAPI, topicARN *string) (map[string]*string, error) {
+	out, err := snsSvc.GetTopicAttributes(&sns.GetTopicAttributesInput{TopicArn: topicARN})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == sns.ErrCodeNotFoundException {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", *topicARN),
+				zap.String("resourceType", awsmodels.SnsTopicSchema))
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "SNS.GetTopicAttributes: %s", aws.StringValue(topicARN))
+	}
+	return out.Attributes, nil
+}
+
+// listTagsSns returns the tags of an SNS topic
+func listTagsSns(snsSvc snsiface.SNSAPI, topicARN *string) ([]*sns.Tag, error) {
+	out, err := snsSvc.ListTagsForResource(&sns.ListTagsForResourceInput{ResourceArn: topicARN})
+	if err != nil {
+		return nil, errors.Wrapf(err, "SNS.ListTagsForResource: %s", aws.StringValue(topicARN))
+	}
+	return out.Tags, nil
+}
+
+// listTopicSubscriptions returns all the subscriptions to a topic
+func listTopicSubscriptions(snsSvc snsiface.SNSAPI, topicARN *string) (subscriptions []*awsmodels.SnsSubscription, err error) {
+	err = snsSvc.ListSubscriptionsByTopicPages(&sns.ListSubscriptionsByTopicInput{TopicArn: topicARN},
+		func(page *sns.ListSubscriptionsByTopicOutput, lastPage bool) bool {
+			for _, subscription := range page.Subscriptions {
+				subscriptions = append(subscriptions, &awsmodels.SnsSubscription{
+					Endpoint:        subscription.Endpoint,
+					Owner:           subscription.Owner,
+					Protocol:        subscription.Protocol,
+					SubscriptionArn: subscription.SubscriptionArn,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, errors.Wrapf(err, "SNS.ListSubscriptionsByTopicPages: %s", aws.StringValue(topicARN))
+	}
+	return subscriptions, nil
+}
+
+// buildSnsTopicSnapshot makes all the calls to build up a snapshot of a given SNS topic
+func buildSnsTopicSnapshot(snsSvc snsiface.SNSAPI, topicARN *string) (*awsmodels.SnsTopic, error) {
+	if topicARN == nil {
+		return nil, nil
+	}
+	attributes, err := getTopicAttributes(snsSvc, topicARN)
+	if err != nil || attributes == nil {
+		return nil, err
+	}
+
+	topic := &awsmodels.SnsTopic{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   topicARN,
+			ResourceType: aws.String(awsmodels.SnsTopicSchema),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN: topicARN,
+		},
+		ContentBasedDeduplication: parseBoolAttribute(attributes["ContentBasedDeduplication"]),
+		DeliveryPolicy:            attributes["DeliveryPolicy"],
+		DisplayName:               attributes["DisplayName"],
+		EffectiveDeliveryPolicy:   attributes["EffectiveDeliveryPolicy"],
+		FifoTopic:                 parseBoolAttribute(attributes["FifoTopic"]),
+		KmsMasterKeyId:            attributes["KmsMasterKeyId"],
+		Owner:                     attributes["Owner"],
+		Policy:                    attributes["Policy"],
+	}
+	if parsedARN, err := arn.Parse(*topicARN); err == nil {
+		topic.Name = aws.String(parsedARN.Resource)
+	}
+
+	tags, err := listTagsSns(snsSvc, topicARN)
+	if err != nil {
+		return nil, err
+	}
+	topic.Tags = utils.ParseTagSlice(tags)
+
+	if topic.Subscriptions, err = listTopicSubscriptions(snsSvc, topicARN); err != nil {
+		return nil, err
+	}
+
+	return topic, nil
+}
+
+// PollSnsTopics gathers information on each SNS topic for an AWS account.
+func PollSnsTopics(pollerInput *awsmodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	zap.L().Debug("starting SNS Topic resource poller")
+
+	snsSvc, err := getSnsClient(pollerInput, *pollerInput.Region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Start with generating a list of all topics
+	topicARNs, marker, err := listTopics(snsSvc, pollerInput.NextPageToken)
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "region: %s", *pollerInput.Region)
+	}
+
+	resources := make([]apimodels.AddResourceEntry, 0, len(topicARNs))
+	for _, topicARN := range topicARNs {
+		topicSnapshot, err := buildSnsTopicSnapshot(snsSvc, topicARN)
+		if err != nil {
+			return nil, nil, err
+		}
+		if topicSnapshot == nil {
+			continue
+		}
+
+		topicSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		topicSnapshot.Region = pollerInput.Region
+
+		resources = append(resources, apimodels.AddResourceEntry{
+			Attributes:      topicSnapshot,
+			ID:              *topicSnapshot.ResourceID,
+			IntegrationID:   *pollerInput.IntegrationID,
+			IntegrationType: integrationType,
+			Type:            awsmodels.SnsTopicSchema,
+		})
+	}
+
+	return resources, marker, nil
+}
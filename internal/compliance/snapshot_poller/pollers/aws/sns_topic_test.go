@@ -0,0 +1,132 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestSnsTopicList(t *testing.T) {
+	mockSvc := awstest.BuildMockSnsSvc([]string{"ListTopicsPages"})
+
+	out, marker, err := listTopics(mockSvc, nil)
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	assert.Len(t, out, 2)
+}
+
+func TestSnsTopicListError(t *testing.T) {
+	mockSvc := awstest.BuildMockSnsSvcError([]string{"ListTopicsPages"})
+
+	out, marker, err := listTopics(mockSvc, nil)
+	require.Error(t, err)
+	assert.Nil(t, marker)
+	assert.Nil(t, out)
+}
+
+// Test the iterator works on consecutive pages but stops at max page size
+func TestSnsTopicListIterator(t *testing.T) {
+	var topicARNs []*string
+	var marker *string
+
+	cont := snsTopicIterator(awstest.ExampleListTopicsOutput, &topicARNs, &marker)
+	assert.True(t, cont)
+	assert.Nil(t, marker)
+	assert.Len(t, topicARNs, 2)
+
+	for i := 2; i < 50; i++ {
+		cont = snsTopicIterator(awstest.ExampleListTopicsOutputContinue, &topicARNs, &marker)
+		assert.True(t, cont)
+		assert.NotNil(t, marker)
+		assert.Len(t, topicARNs, i*2)
+	}
+
+	cont = snsTopicIterator(awstest.ExampleListTopicsOutputContinue, &topicARNs, &marker)
+	assert.False(t, cont)
+	assert.NotNil(t, marker)
+	assert.Len(t, topicARNs, 100)
+}
+
+func TestBuildSnsTopicSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockSnsSvcAll()
+
+	topic, err := buildSnsTopicSnapshot(mockSvc, awstest.ExampleTopicArn)
+	require.NoError(t, err)
+	assert.Equal(t, awstest.ExampleTopicArn, topic.ARN)
+	assert.Equal(t, "example-topic", *topic.Name)
+	assert.Equal(t, "Example Topic", *topic.DisplayName)
+	assert.Equal(t, "alias/aws/sns", *topic.KmsMasterKeyId)
+	assert.NotEmpty(t, topic.Policy)
+	assert.Nil(t, topic.FifoTopic)
+	require.Len(t, topic.Subscriptions, 1)
+	assert.Equal(t, "sqs", *topic.Subscriptions[0].Protocol)
+	assert.Equal(t, aws.String("Value1"), topic.Tags["Key1"])
+}
+
+func TestBuildSnsTopicSnapshotError(t *testing.T) {
+	mockSvc := awstest.BuildMockSnsSvcAllError()
+
+	topic, err := buildSnsTopicSnapshot(mockSvc, awstest.ExampleTopicArn)
+	require.Error(t, err)
+	assert.Nil(t, topic)
+}
+
+func TestSnsTopicPoller(t *testing.T) {
+	awstest.MockSnsForSetup = awstest.BuildMockSnsSvcAll()
+
+	SnsClientFunc = awstest.SetupMockSns
+
+	resources, marker, err := PollSnsTopics(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	assert.Len(t, resources, 2)
+}
+
+func TestSnsTopicPollerError(t *testing.T) {
+	resetCache()
+	awstest.MockSnsForSetup = awstest.BuildMockSnsSvcAllError()
+
+	SnsClientFunc = awstest.SetupMockSns
+
+	resources, marker, err := PollSnsTopics(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, marker)
+	assert.Nil(t, resources)
+}
@@ -0,0 +1,298 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+func init() {
+	resourcetypes.RegisterPollers(awsmodels.SqsQueueSchema, &resourcetypes.ResourcePollers{
+		Service: PollSqsQueues,
+		ARN:     PollSqsQueue,
+	})
+}
+
+// Set as variables to be overridden in testing
+var (
+	SqsClientFunc = setupSqsClient
+)
+
+func setupSqsClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return sqs.New(sess, cfg)
+}
+
+func getSqsClient(pollerResourceInput *awsmodels.ResourcePollerInput, region string) (sqsiface.SQSAPI, error) {
+	client, err := getClient(pollerResourceInput, SqsClientFunc, "sqs", region)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.(sqsiface.SQSAPI), nil
+}
+
+// PollSqsQueue polls a single SQS queue resource
+func PollSqsQueue(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	scanRequest *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getSqsClient(pollerResourceInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	queueURL, err := getQueueURL(client, resourceARN.Resource, resourceARN.AccountID)
+	if err != nil || queueURL == nil {
+		return nil, err
+	}
+
+	snapshot, err := buildSqsQueueSnapshot(client, queueURL)
+	if err != nil || snapshot == nil {
+		return nil, err
+	}
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// getQueueURL returns the URL of a queue given its name
+func getQueueURL(sqsSvc sqsiface.SQSAPI, name, accountID string) (*string, error) {
+	out, err := sqsSvc.GetQueueUrl(&sqs.GetQueueUrlInput{
+		QueueName:              aws.String(name),
+		QueueOwnerAWSAccountId: aws.String(accountID),
+	})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == sqs.ErrCodeQueueDoesNotExist {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", name),
+				zap.String("resourceType", awsmodels.SqsQueueSchema))
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "SQS.GetQueueUrl: %s", name)
+	}
+	return out.QueueUrl, nil
+}
+
+// listQueues returns the URLs of all queues in the region
+func listQueues(sqsSvc sqsiface.SQSAPI, nextMarker *string) (queueURLs []*string, marker *string, err error) {
+	err = sqsSvc.ListQueuesPages(&sqs.ListQueuesInput{
+		NextToken:  nextMarker,
+		MaxResults: aws.Int64(int64(defaultBatchSize)),
+	},
+		func(page *sqs.ListQueuesOutput, lastPage bool) bool {
+			return sqsQueueIterator(page, &queueURLs, &marker)
+		})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "SQS.ListQueuesPages")
+	}
+	return
+}
+
+func sqsQueueIterator(page *sqs.ListQueuesOutput, queueURLs *[]*string, marker **string) bool {
+	*queueURLs = append(*queueURLs, page.QueueUrls...)
+	*marker = page.NextToken
+	return len(*queueURLs) < defaultBatchSize
+}
+
+// getQueueAttributes returns all attributes of a queue, or nil if the queue no longer exists
+func getQueueAttributes(sqsSvc sqsiface.SQSAPI, queueURL *string) (map[string]*string, error) {
+	out, err := sqsSvc.GetQueueAttributes(&sqs.GetQueueAttributesInput{
+		QueueUrl:       queueURL,
+		AttributeNames: []*string{aws.String(sqs.QueueAttributeNameAll)},
+	})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == sqs.ErrCodeQueueDoesNotExist {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", *queueURL),
+				zap.String("resourceType", awsmodels.SqsQueueSchema))
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "SQS.GetQueueAttributes: %s", aws.StringValue(queueURL))
+	}
+	return out.Attributes, nil
+}
+
+// listQueueTags returns the tags of a queue
+func listQueueTags(sqsSvc sqsiface.SQSAPI, queueURL *string) (map[string]*string, error) {
+	out, err := sqsSvc.ListQueueTags(&sqs.ListQueueTagsInput{QueueUrl: queueURL})
+	if err != nil {
+		return nil, errors.Wrapf(err, "SQS.ListQueueTags: %s", aws.StringValue(queueURL))
+	}
+	return out.Tags, nil
+}
+
+// listDeadLetterSourceQueues returns the URLs of the queues that use a queue as their dead-letter queue
+func listDeadLetterSourceQueues(sqsSvc sqsiface.SQSAPI, queueURL *string) (queueURLs []*string, err error) {
+	err = sqsSvc.ListDeadLetterSourceQueuesPages(&sqs.ListDeadLetterSourceQueuesInput{QueueUrl: queueURL},
+		func(page *sqs.ListDeadLetterSourceQueuesOutput, lastPage bool) bool {
+			queueURLs = append(queueURLs, page.QueueUrls...)
+			return true
+		})
+	if err != nil {
+		return nil, errors.Wrapf(err, "SQS.ListDeadLetterSourceQueuesPages: %s", aws.StringValue(queueURL))
+	}
+	return queueURLs, nil
+}
+
+// buildSqsQueueSnapshot makes all the calls to build up a snapshot of a given SQS queue
+func buildSqsQueueSnapshot(sqsSvc sqsiface.SQSAPI, queueURL *string) (*awsmodels.SqsQueue, error) {
+	if queueURL == nil {
+		return nil, nil
+	}
+	attributes, err := getQueueAttributes(sqsSvc, queueURL)
+	if err != nil || attributes == nil {
+		return nil, err
+	}
+
+	queueARN := attributes[sqs.QueueAttributeNameQueueArn]
+	var queueName *string
+	if queueARN != nil {
+		// arn:aws:sqs:region:account-id:queue-name
+		queueName = aws.String((*queueARN)[strings.LastIndex(*queueARN, ":")+1:])
+	}
+	queue := &awsmodels.SqsQueue{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   queueARN,
+			ResourceType: aws.String(awsmodels.SqsQueueSchema),
+			TimeCreated:  parseUnixTimeAttribute(attributes[sqs.QueueAttributeNameCreatedTimestamp]),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  queueARN,
+			Name: queueName,
+		},
+		ContentBasedDeduplication:     parseBoolAttribute(attributes[sqs.QueueAttributeNameContentBasedDeduplication]),
+		DeduplicationScope:            attributes["DeduplicationScope"],
+		DelaySeconds:                  parseIntAttribute(attributes[sqs.QueueAttributeNameDelaySeconds]),
+		FifoQueue:                     parseBoolAttribute(attributes[sqs.QueueAttributeNameFifoQueue]),
+		FifoThroughputLimit:           attributes["FifoThroughputLimit"],
+		KmsDataKeyReusePeriodSeconds:  parseIntAttribute(attributes[sqs.QueueAttributeNameKmsDataKeyReusePeriodSeconds]),
+		KmsMasterKeyId:                attributes[sqs.QueueAttributeNameKmsMasterKeyId],
+		LastModifiedTimestamp:         parseUnixTimeAttribute(attributes[sqs.QueueAttributeNameLastModifiedTimestamp]),
+		MaximumMessageSize:            parseIntAttribute(attributes[sqs.QueueAttributeNameMaximumMessageSize]),
+		MessageRetentionPeriod:        parseIntAttribute(attributes[sqs.QueueAttributeNameMessageRetentionPeriod]),
+		Policy:                        attributes[sqs.QueueAttributeNamePolicy],
+		ReceiveMessageWaitTimeSeconds: parseIntAttribute(attributes[sqs.QueueAttributeNameReceiveMessageWaitTimeSeconds]),
+		RedrivePolicy:                 attributes[sqs.QueueAttributeNameRedrivePolicy],
+		VisibilityTimeout:             parseIntAttribute(attributes[sqs.QueueAttributeNameVisibilityTimeout]),
+		QueueUrl:                      queueURL,
+	}
+
+	if queue.Tags, err = listQueueTags(sqsSvc, queueURL); err != nil {
+		return nil, err
+	}
+	if queue.DeadLetterSourceQueues, err = listDeadLetterSourceQueues(sqsSvc, queueURL); err != nil {
+		return nil, err
+	}
+
+	return queue, nil
+}
+
+// parseIntAttribute parses an integer attribute value, returning nil if it is missing or malformed
+func parseIntAttribute(value *string) *int64 {
+	if value == nil {
+		return nil
+	}
+	n, err := strconv.ParseInt(*value, 10, 64)
+	if err != nil {
+		return nil
+	}
+	return &n
+}
+
+// parseBoolAttribute parses a boolean attribute value, returning nil if it is missing or malformed
+func parseBoolAttribute(value *string) *bool {
+	if value == nil {
+		return nil
+	}
+	b, err := strconv.ParseBool(*value)
+	if err != nil {
+		return nil
+	}
+	return &b
+}
+
+// parseUnixTimeAttribute parses an attribute holding seconds since the epoch
+func parseUnixTimeAttribute(value *string) *time.Time {
+	n := parseIntAttribute(value)
+	if n == nil {
+		return nil
+	}
+	return utils.UnixTimeToDateTime(*n)
+}
+
+// PollSqsQueues gathers information on each SQS queue for an AWS account.
+func PollSqsQueues(pollerInput *awsmodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	zap.L().Debug("starting SQS Queue resource poller")
+
+	sqsSvc, err := getSqsClient(pollerInput, *pollerInput.Region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Start with generating a list of all queues
+	queueURLs, marker, err := listQueues(sqsSvc, pollerInput.NextPageToken)
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "region: %s", *pollerInput.Region)
+	}
+
+	resources := make([]apimodels.AddResourceEntry, 0, len(queueURLs))
+	for _, queueURL := range queueURLs {
+		queueSnapshot, err := buildSqsQueueSnapshot(sqsSvc, queueURL)
+		if err != nil {
+			return nil, nil, err
+		}
+		if queueSnapshot == nil || queueSnapshot.ResourceID == nil {
+			continue
+		}
+
+		queueSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		queueSnapshot.Region = pollerInput.Region
+
+		resources = append(resources, apimodels.AddResourceEntry{
+			Attributes:      queueSnapshot,
+			ID:              *queueSnapshot.ResourceID,
+			IntegrationID:   *pollerInput.IntegrationID,
+			IntegrationType: integrationType,
+			Type:            awsmodels.SqsQueueSchema,
+		})
+	}
+
+	return resources, marker, nil
+}
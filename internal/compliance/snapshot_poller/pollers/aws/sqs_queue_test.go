@@ -0,0 +1,166 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestSqsQueueList(t *testing.T) {
+	mockSvc := awstest.BuildMockSqsSvc([]string{"ListQueuesPages"})
+
+	out, marker, err := listQueues(mockSvc, nil)
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	assert.Len(t, out, 2)
+}
+
+func TestSqsQueueListError(t *testing.T) {
+	mockSvc := awstest.BuildMockSqsSvcError([]string{"ListQueuesPages"})
+
+	out, marker, err := listQueues(mockSvc, nil)
+	require.Error(t, err)
+	assert.Nil(t, marker)
+	assert.Nil(t, out)
+}
+
+// Test the iterator works on consecutive pages but stops at max page size
+func TestSqsQueueListIterator(t *testing.T) {
+	var queueURLs []*string
+	var marker *string
+
+	cont := sqsQueueIterator(awstest.ExampleListQueuesOutput, &queueURLs, &marker)
+	assert.True(t, cont)
+	assert.Nil(t, marker)
+	assert.Len(t, queueURLs, 2)
+
+	for i := 2; i < 50; i++ {
+		cont = sqsQueueIterator(awstest.ExampleListQueuesOutputContinue, &queueURLs, &marker)
+		assert.True(t, cont)
+		assert.NotNil(t, marker)
+		assert.Len(t, queueURLs, i*2)
+	}
+
+	cont = sqsQueueIterator(awstest.ExampleListQueuesOutputContinue, &queueURLs, &marker)
+	assert.False(t, cont)
+	assert.NotNil(t, marker)
+	assert.Len(t, queueURLs, 100)
+}
+
+func TestBuildSqsQueueSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockSqsSvcAll()
+
+	queue, err := buildSqsQueueSnapshot(mockSvc, awstest.ExampleQueueUrl)
+	require.NoError(t, err)
+	assert.Equal(t, "arn:aws:sqs:us-west-2:123456789012:example-queue", *queue.ARN)
+	assert.Equal(t, "example-queue", *queue.Name)
+	assert.Equal(t, int64(1580000000), queue.TimeCreated.Unix())
+	assert.Equal(t, int64(1590000000), queue.LastModifiedTimestamp.Unix())
+	assert.Equal(t, int64(262144), *queue.MaximumMessageSize)
+	assert.Equal(t, int64(20), *queue.ReceiveMessageWaitTimeSeconds)
+	assert.Equal(t, "alias/aws/sqs", *queue.KmsMasterKeyId)
+	assert.Nil(t, queue.FifoQueue)
+	assert.NotEmpty(t, queue.Policy)
+	assert.NotEmpty(t, queue.RedrivePolicy)
+	assert.Len(t, queue.DeadLetterSourceQueues, 1)
+	assert.Equal(t, aws.String("Value1"), queue.Tags["Key1"])
+	assert.Equal(t, awstest.ExampleQueueUrl, queue.QueueUrl)
+}
+
+func TestBuildSqsQueueSnapshotError(t *testing.T) {
+	mockSvc := awstest.BuildMockSqsSvcAllError()
+
+	queue, err := buildSqsQueueSnapshot(mockSvc, awstest.ExampleQueueUrl)
+	require.Error(t, err)
+	assert.Nil(t, queue)
+}
+
+func TestPollSqsQueue(t *testing.T) {
+	resetCache()
+	awstest.MockSqsForSetup = awstest.BuildMockSqsSvcAll()
+
+	SqsClientFunc = awstest.SetupMockSqs
+
+	resourceARN, err := arn.Parse("arn:aws:sqs:us-west-2:123456789012:example-queue")
+	require.NoError(t, err)
+	queue, err := PollSqsQueue(
+		&awsmodels.ResourcePollerInput{
+			AuthSource:          &awstest.ExampleAuthSource,
+			AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+			IntegrationID:       awstest.ExampleIntegrationID,
+			Timestamp:           &awstest.ExampleTime,
+		},
+		resourceARN,
+		&pollermodels.ScanEntry{ResourceID: aws.String(resourceARN.String())},
+	)
+	require.NoError(t, err)
+	require.NotNil(t, queue)
+	awstest.MockSqsForSetup.AssertCalled(t, "GetQueueUrl", &sqs.GetQueueUrlInput{
+		QueueName:              aws.String("example-queue"),
+		QueueOwnerAWSAccountId: aws.String("123456789012"),
+	})
+	assert.Equal(t, "us-west-2", *queue.(*awsmodels.SqsQueue).Region)
+}
+
+func TestSqsQueuePoller(t *testing.T) {
+	awstest.MockSqsForSetup = awstest.BuildMockSqsSvcAll()
+
+	SqsClientFunc = awstest.SetupMockSqs
+
+	resources, marker, err := PollSqsQueues(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	assert.Len(t, resources, 2)
+}
+
+func TestSqsQueuePollerError(t *testing.T) {
+	resetCache()
+	awstest.MockSqsForSetup = awstest.BuildMockSqsSvcAllError()
+
+	SqsClientFunc = awstest.SetupMockSqs
+
+	resources, marker, err := PollSqsQueues(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, marker)
+	assert.Nil(t, resources)
+}
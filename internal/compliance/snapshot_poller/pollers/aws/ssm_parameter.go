@@ -0,0 +1,262 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+func init() {
+	resourcetypes.RegisterPollers(awsmodels.SsmParameterSchema, &resourcetypes.ResourcePollers{
+		Service: PollSsmParameters,
+		ARN:     PollSsmParameter,
+	})
+}
+
+// Set as variables to be overridden in testing
+var (
+	SsmClientFunc = setupSsmClient
+)
+
+// The SSM DescribeParameters API returns at most 50 parameters per page
+const ssmParametersBatchSize = 50
+
+func setupSsmClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return ssm.New(sess, cfg)
+}
+
+func getSsmClient(pollerResourceInput *awsmodels.ResourcePollerInput, region string) (ssmiface.SSMAPI, error) {
+	client, err := getClient(pollerResourceInput, SsmClientFunc, "ssm", region)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.(ssmiface.SSMAPI), nil
+}
+
+// PollSsmParameter polls a single SSM parameter resource
+func PollSsmParameter(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	scanRequest *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getSsmClient(pollerResourceInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	parameter, err := getParameterMetadata(client, ssmParameterNames(resourceARN)...)
+	if err != nil || parameter == nil {
+		return nil, err
+	}
+
+	snapshot, err := buildSsmParameterSnapshot(client, parameter, scanRequest.ResourceID)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// ssmParameterARN builds the ARN of an SSM parameter, the API does not return it
+func ssmParameterARN(partition, region, accountID, name string) string {
+	if !strings.HasPrefix(name, "/") {
+		name = "/" + name
+	}
+	return arn.ARN{
+		Partition: partition,
+		Service:   "ssm",
+		Region:    region,
+		AccountID: accountID,
+		Resource:  "parameter" + name,
+	}.String()
+}
+
+// ssmParameterNames returns the possible names of the parameter identified by an ARN
+//
+// The ARN of the hierarchical parameter /a/b is arn:aws:ssm:region:account-id:parameter/a/b, but
+// both parameters c and /c have the ARN arn:aws:ssm:region:account-id:parameter/c
+func ssmParameterNames(parameterARN arn.ARN) []string {
+	name := strings.TrimPrefix(parameterARN.Resource, "parameter")
+	if strings.Count(name, "/") == 1 {
+		return []string{strings.TrimPrefix(name, "/"), name}
+	}
+	return []string{name}
+}
+
+// getParameterMetadata returns the metadata of the first parameter matching one of the names
+//
+// The parameter value is never requested.
+func getParameterMetadata(ssmSvc ssmiface.SSMAPI, names ...string) (*ssm.ParameterMetadata, error) {
+	out, err := ssmSvc.DescribeParameters(&ssm.DescribeParametersInput{
+		ParameterFilters: []*ssm.ParameterStringFilter{
+			{
+				Key:    aws.String("Name"),
+				Option: aws.String("Equals"),
+				Values: aws.StringSlice(names),
+			},
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "SSM.DescribeParameters: %s", strings.Join(names, ", "))
+	}
+	for _, name := range names {
+		for _, parameter := range out.Parameters {
+			if aws.StringValue(parameter.Name) == name {
+				return parameter, nil
+			}
+		}
+	}
+
+	zap.L().Warn("tried to scan non-existent resource",
+		zap.String("resource", names[0]),
+		zap.String("resourceType", awsmodels.SsmParameterSchema))
+	return nil, nil
+}
+
+// describeParameters returns the metadata of all parameters in the region
+func describeParameters(ssmSvc ssmiface.SSMAPI, nextMarker *string) (
+	parameters []*ssm.ParameterMetadata, marker *string, err error) {
+
+	err = ssmSvc.DescribeParametersPages(&ssm.DescribeParametersInput{
+		NextToken:  nextMarker,
+		MaxResults: aws.Int64(ssmParametersBatchSize),
+	},
+		func(page *ssm.DescribeParametersOutput, lastPage bool) bool {
+			return ssmParameterIterator(page, &parameters, &marker)
+		})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "SSM.DescribeParametersPages")
+	}
+	return
+}
+
+func ssmParameterIterator(page *ssm.DescribeParametersOutput, parameters *[]*ssm.ParameterMetadata, marker **string) bool {
+	*parameters = append(*parameters, page.Parameters...)
+	*marker = page.NextToken
+	return len(*parameters) < defaultBatchSize
+}
+
+// listTagsSsmParameter returns the tags of a parameter
+func listTagsSsmParameter(ssmSvc ssmiface.SSMAPI, name *string) ([]*ssm.Tag, error) {
+	out, err := ssmSvc.ListTagsForResource(&ssm.ListTagsForResourceInput{
+		ResourceId:   name,
+		ResourceType: aws.String(ssm.ResourceTypeForTaggingParameter),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "SSM.ListTagsForResource: %s", aws.StringValue(name))
+	}
+	return out.TagList, nil
+}
+
+// buildSsmParameterSnapshot makes all the calls to build up a snapshot of a given SSM parameter
+func buildSsmParameterSnapshot(
+	ssmSvc ssmiface.SSMAPI,
+	parameter *ssm.ParameterMetadata,
+	parameterARN *string,
+) (*awsmodels.SsmParameter, error) {
+
+	snapshot := &awsmodels.SsmParameter{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   parameterARN,
+			ResourceType: aws.String(awsmodels.SsmParameterSchema),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  parameterARN,
+			Name: parameter.Name,
+		},
+		AllowedPattern:   parameter.AllowedPattern,
+		DataType:         parameter.DataType,
+		Description:      parameter.Description,
+		KeyId:            parameter.KeyId,
+		LastModifiedDate: parameter.LastModifiedDate,
+		LastModifiedUser: parameter.LastModifiedUser,
+		Policies:         parameter.Policies,
+		Tier:             parameter.Tier,
+		Type:             parameter.Type,
+		Version:          parameter.Version,
+	}
+
+	tags, err := listTagsSsmParameter(ssmSvc, parameter.Name)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.Tags = utils.ParseTagSlice(tags)
+
+	return snapshot, nil
+}
+
+// PollSsmParameters gathers information on each SSM parameter for an AWS account.
+func PollSsmParameters(pollerInput *awsmodels.ResourcePollerInput) ([]apimodels.AddResourceEntry, *string, error) {
+	zap.L().Debug("starting SSM Parameter resource poller")
+
+	ssmSvc, err := getSsmClient(pollerInput, *pollerInput.Region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Start with generating a list of all parameters
+	parameters, marker, err := describeParameters(ssmSvc, pollerInput.NextPageToken)
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "region: %s", *pollerInput.Region)
+	}
+
+	resources := make([]apimodels.AddResourceEntry, 0, len(parameters))
+	for _, parameter := range parameters {
+		parameterARN := ssmParameterARN(
+			pollerInput.AuthSourceParsedARN.Partition,
+			*pollerInput.Region,
+			pollerInput.AuthSourceParsedARN.AccountID,
+			aws.StringValue(parameter.Name),
+		)
+		parameterSnapshot, err := buildSsmParameterSnapshot(ssmSvc, parameter, aws.String(parameterARN))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		parameterSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		parameterSnapshot.Region = pollerInput.Region
+
+		resources = append(resources, apimodels.AddResourceEntry{
+			Attributes:      parameterSnapshot,
+			ID:              parameterARN,
+			IntegrationID:   *pollerInput.IntegrationID,
+			IntegrationType: integrationType,
+			Type:            awsmodels.SsmParameterSchema,
+		})
+	}
+
+	return resources, marker, nil
+}
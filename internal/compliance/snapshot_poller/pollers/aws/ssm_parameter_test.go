@@ -0,0 +1,160 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestSsmParameterDescribe(t *testing.T) {
+	mockSvc := awstest.BuildMockSsmSvc([]string{"DescribeParametersPages"})
+
+	out, marker, err := describeParameters(mockSvc, nil)
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	assert.Len(t, out, 2)
+}
+
+func TestSsmParameterDescribeError(t *testing.T) {
+	mockSvc := awstest.BuildMockSsmSvcError([]string{"DescribeParametersPages"})
+
+	out, marker, err := describeParameters(mockSvc, nil)
+	require.Error(t, err)
+	assert.Nil(t, marker)
+	assert.Nil(t, out)
+}
+
+// Test the iterator works on consecutive pages but stops at max page size
+func TestSsmParameterListIterator(t *testing.T) {
+	var parameters []*ssm.ParameterMetadata
+	var marker *string
+
+	cont := ssmParameterIterator(awstest.ExampleDescribeParametersOutput, &parameters, &marker)
+	assert.True(t, cont)
+	assert.Nil(t, marker)
+	assert.Len(t, parameters, 2)
+
+	for i := 2; i < 50; i++ {
+		cont = ssmParameterIterator(awstest.ExampleDescribeParametersOutputContinue, &parameters, &marker)
+		assert.True(t, cont)
+		assert.NotNil(t, marker)
+		assert.Len(t, parameters, i*2)
+	}
+
+	cont = ssmParameterIterator(awstest.ExampleDescribeParametersOutputContinue, &parameters, &marker)
+	assert.False(t, cont)
+	assert.NotNil(t, marker)
+	assert.Len(t, parameters, 100)
+}
+
+func TestSsmParameterARN(t *testing.T) {
+	assert.Equal(t, "arn:aws:ssm:us-west-2:123456789012:parameter/example/parameter",
+		ssmParameterARN("aws", "us-west-2", "123456789012", "/example/parameter"))
+	assert.Equal(t, "arn:aws:ssm:us-west-2:123456789012:parameter/example-parameter",
+		ssmParameterARN("aws", "us-west-2", "123456789012", "example-parameter"))
+}
+
+func TestSsmParameterNames(t *testing.T) {
+	parameterARN, err := arn.Parse("arn:aws:ssm:us-west-2:123456789012:parameter/example/parameter")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/example/parameter"}, ssmParameterNames(parameterARN))
+
+	parameterARN, err = arn.Parse("arn:aws:ssm:us-west-2:123456789012:parameter/example-parameter")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"example-parameter", "/example-parameter"}, ssmParameterNames(parameterARN))
+}
+
+func TestSsmParameterGetMetadata(t *testing.T) {
+	mockSvc := awstest.BuildMockSsmSvc([]string{"DescribeParameters"})
+
+	out, err := getParameterMetadata(mockSvc, "example-parameter", "/example-parameter")
+	require.NoError(t, err)
+	assert.Equal(t, "example-parameter", *out.Name)
+
+	out, err = getParameterMetadata(mockSvc, "missing-parameter")
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestBuildSsmParameterSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockSsmSvcAll()
+
+	parameterARN := aws.String("arn:aws:ssm:us-west-2:123456789012:parameter/example/parameter")
+	parameter, err := buildSsmParameterSnapshot(mockSvc, awstest.ExampleDescribeParametersOutput.Parameters[0], parameterARN)
+	require.NoError(t, err)
+	assert.Equal(t, parameterARN, parameter.ARN)
+	assert.Equal(t, "/example/parameter", *parameter.Name)
+	assert.Equal(t, "SecureString", *parameter.Type)
+	assert.Equal(t, aws.String("Value1"), parameter.Tags["Key1"])
+}
+
+func TestBuildSsmParameterSnapshotError(t *testing.T) {
+	mockSvc := awstest.BuildMockSsmSvcAllError()
+
+	parameterARN := aws.String("arn:aws:ssm:us-west-2:123456789012:parameter/example/parameter")
+	parameter, err := buildSsmParameterSnapshot(mockSvc, awstest.ExampleDescribeParametersOutput.Parameters[0], parameterARN)
+	require.Error(t, err)
+	assert.Nil(t, parameter)
+}
+
+func TestSsmParameterPoller(t *testing.T) {
+	awstest.MockSsmForSetup = awstest.BuildMockSsmSvcAll()
+
+	SsmClientFunc = awstest.SetupMockSsm
+
+	resources, marker, err := PollSsmParameters(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	assert.Len(t, resources, 2)
+}
+
+func TestSsmParameterPollerError(t *testing.T) {
+	resetCache()
+	awstest.MockSsmForSetup = awstest.BuildMockSsmSvcAllError()
+
+	SsmClientFunc = awstest.SetupMockSsm
+
+	resources, marker, err := PollSsmParameters(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Region:              awstest.ExampleRegion,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, marker)
+	assert.Nil(t, resources)
+}
@@ -35,9 +35,21 @@ import (
 	apimodels "github.com/panther-labs/panther/api/lambda/resources/models"
 	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
 	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
 	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
 )
 
+func init() {
+	resourcetypes.RegisterPollers(awsmodels.WafRegionalWebAclSchema, &resourcetypes.ResourcePollers{
+		Service: PollWafRegionalWebAcls,
+		ARN:     PollWAFRegionalWebACL,
+	})
+	resourcetypes.RegisterPollers(awsmodels.WafWebAclSchema, &resourcetypes.ResourcePollers{
+		Service: PollWafWebAcls,
+		ARN:     PollWAFWebACL,
+	})
+}
+
 // Set as variables to be overridden in testing
 var (
 	// Functions to initialize the WAF and WAF Regional client functions
@@ -0,0 +1,197 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+)
+
+const (
+	// The maximum page size of the Cloud Asset ListAssets API
+	maxPageSize    = 1000
+	requestTimeout = 30 * time.Second
+)
+
+var (
+	// Replaced by unit tests with the address of a test server
+	cloudAssetEndpoint = "https://cloudasset.googleapis.com"
+)
+
+// Client reads the Cloud Asset inventory of a Google Cloud project
+type Client struct {
+	ProjectID  string
+	httpClient *http.Client
+	tokens     oauth2.TokenSource
+}
+
+// NewClient builds a Cloud Asset client authenticated with a service account JSON key
+func NewClient(projectID, serviceAccountKey string) (*Client, error) {
+	httpClient := &http.Client{Timeout: requestTimeout}
+	tokens, err := newTokenSource(httpClient, serviceAccountKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		ProjectID:  projectID,
+		httpClient: httpClient,
+		tokens:     tokens,
+	}, nil
+}
+
+// Asset is a Cloud Asset inventory entry
+type Asset struct {
+	Name       string               `json:"name"`
+	AssetType  string               `json:"assetType"`
+	Resource   *AssetResource       `json:"resource"`
+	IAMPolicy  *gcpmodels.IAMPolicy `json:"iamPolicy"`
+	UpdateTime string               `json:"updateTime"`
+}
+
+// AssetResource is the resource metadata of an asset, Data is the resource as returned by its service API
+type AssetResource struct {
+	Version  string              `json:"version"`
+	Parent   string              `json:"parent"`
+	Location string              `json:"location"`
+	Data     jsoniter.RawMessage `json:"data"`
+}
+
+// ListAssetsOutput is a page of assets
+type ListAssetsOutput struct {
+	ReadTime      string   `json:"readTime"`
+	Assets        []*Asset `json:"assets"`
+	NextPageToken string   `json:"nextPageToken"`
+}
+
+// APIError is the error returned by Google Cloud APIs
+type APIError struct {
+	StatusCode int    `json:"-"`
+	Code       int    `json:"code"`
+	Message    string `json:"message"`
+	Status     string `json:"status"`
+}
+
+func (e *APIError) Error() string {
+	if e.Status != "" {
+		return fmt.Sprintf("%d %s: %s", e.StatusCode, e.Status, e.Message)
+	}
+	return fmt.Sprintf("%d: %s", e.StatusCode, e.Message)
+}
+
+// ListAssets returns a page of the assets of one type in the project
+func (c *Client) ListAssets(ctx context.Context, assetType, contentType, pageToken string, pageSize int) (*ListAssetsOutput, error) {
+	if pageSize <= 0 || pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	query := url.Values{
+		"assetTypes":  {assetType},
+		"contentType": {contentType},
+		"pageSize":    {strconv.Itoa(pageSize)},
+	}
+	if pageToken != "" {
+		query.Set("pageToken", pageToken)
+	}
+	endpoint := fmt.Sprintf("%s/v1/projects/%s/assets?%s",
+		cloudAssetEndpoint, url.PathEscape(c.ProjectID), query.Encode())
+
+	token, err := c.accessToken()
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build ListAssets request")
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	output := &ListAssetsOutput{}
+	if err := doJSON(c.httpClient, req, output); err != nil {
+		return nil, errors.Wrapf(err, "ListAssets failed for %s", assetType)
+	}
+	return output, nil
+}
+
+// ListAllAssets returns all the assets of one type in the project
+func (c *Client) ListAllAssets(ctx context.Context, assetType, contentType string) ([]*Asset, error) {
+	var assets []*Asset
+	pageToken := ""
+	for {
+		page, err := c.ListAssets(ctx, assetType, contentType, pageToken, maxPageSize)
+		if err != nil {
+			return nil, err
+		}
+		assets = append(assets, page.Assets...)
+		if page.NextPageToken == "" {
+			return assets, nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
+
+// doJSON sends a request and decodes the JSON response body into output
+func doJSON(httpClient *http.Client, req *http.Request, output interface{}) error {
+	req.Header.Set("Accept", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read response body")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return parseAPIError(resp.StatusCode, body)
+	}
+	if err := jsoniter.Unmarshal(body, output); err != nil {
+		return errors.Wrap(err, "failed to decode response body")
+	}
+	return nil
+}
+
+func parseAPIError(statusCode int, body []byte) *APIError {
+	// Google APIs wrap errors in an error object, the OAuth endpoint uses error and error_description
+	var response struct {
+		Error            jsoniter.RawMessage `json:"error"`
+		ErrorDescription string              `json:"error_description"`
+	}
+	apiErr := &APIError{StatusCode: statusCode}
+	if err := jsoniter.Unmarshal(body, &response); err != nil || len(response.Error) == 0 {
+		apiErr.Message = http.StatusText(statusCode)
+		return apiErr
+	}
+	if err := jsoniter.Unmarshal(response.Error, apiErr); err != nil {
+		// The error is a string code
+		_ = jsoniter.Unmarshal(response.Error, &apiErr.Status)
+		apiErr.Message = response.ErrorDescription
+	}
+	return apiErr
+}
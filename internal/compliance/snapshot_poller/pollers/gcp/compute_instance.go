@@ -0,0 +1,69 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"go.uber.org/zap"
+
+	resourcesapimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+)
+
+// PollComputeInstances gathers information on each Compute Engine VM instance of a project.
+func PollComputeInstances(ctx context.Context, client *Client, input *gcpmodels.ResourcePollerInput) (
+	[]resourcesapimodels.AddResourceEntry, *string, error) {
+
+	assets, marker, err := listAssetsPage(ctx, client, input, gcpmodels.ComputeInstanceSchema)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resources := make([]resourcesapimodels.AddResourceEntry, 0, len(assets))
+	for _, asset := range assets {
+		if input.ShouldIgnoreResource(asset.Name) {
+			continue
+		}
+		instance := &gcpmodels.ComputeInstance{}
+		err := decodeAsset(input, asset, gcpmodels.ComputeInstanceSchema, instance, &instance.GenericGCPResource, &instance.GenericResource)
+		if err != nil {
+			zap.L().Error("skipping invalid Compute instance asset", zap.Error(err))
+			continue
+		}
+		instance.TimeCreated = parseTimestamp(instance.CreationTimestamp)
+		resources = append(resources, newResourceEntry(input, asset.Name, gcpmodels.ComputeInstanceSchema, instance))
+	}
+	return resources, marker, nil
+}
+
+// parseTimestamp parses the RFC3339 timestamps of the Compute API
+func parseTimestamp(timestamp *string) *time.Time {
+	if timestamp == nil {
+		return nil
+	}
+	parsed, err := time.Parse(time.RFC3339, *timestamp)
+	if err != nil {
+		zap.L().Debug("invalid timestamp", zap.String("timestamp", *timestamp))
+		return nil
+	}
+	return aws.Time(parsed.UTC())
+}
@@ -0,0 +1,54 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+)
+
+func TestPollComputeInstances(t *testing.T) {
+	api := newFakeAPI(t)
+
+	resources, marker, err := PollComputeInstances(context.Background(), api.newClient(t), testPollerInput())
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	require.Len(t, resources, 1)
+
+	instance := resources[0].Attributes.(*gcpmodels.ComputeInstance)
+	assert.Equal(t, "//compute.googleapis.com/projects/panther-test/zones/us-central1-a/instances/web-1", resources[0].ID)
+	assert.Equal(t, aws.String("us-central1-a"), instance.Location)
+	assert.Equal(t, aws.String("4815162342108801234"), instance.ID)
+	assert.Equal(t, aws.String("web-1"), instance.Name)
+	assert.Equal(t, map[string]*string{"env": aws.String("prod")}, instance.Labels)
+	assert.Equal(t, time.Date(2021, 2, 1, 18, 15, 42, 127000000, time.UTC), *instance.TimeCreated)
+	assert.False(t, *instance.CanIPForward)
+	assert.Equal(t, aws.String("203.0.113.10"), instance.NetworkInterfaces[0].AccessConfigs[0].NatIP)
+	assert.Equal(t, aws.String("10.128.0.2"), instance.NetworkInterfaces[0].NetworkIP)
+	assert.Equal(t, aws.String("enable-oslogin"), instance.Metadata.Items[0].Key)
+	assert.False(t, *instance.ShieldedInstanceConfig.EnableSecureBoot)
+	assert.Equal(t, []*string{aws.String("https://www.googleapis.com/auth/cloud-platform")}, instance.ServiceAccounts[0].Scopes)
+}
@@ -0,0 +1,71 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	// The OAuth scope required to read the Cloud Asset inventory
+	cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+)
+
+var (
+	// Replaced by unit tests with the address of a test server.
+	// The token_uri of service account keys is ignored so that a key can't send its signed assertion to another host.
+	tokenURL = google.JWTTokenURL
+)
+
+// newTokenSource builds the source of OAuth access tokens of a service account JSON key.
+// Tokens are cached and only requested again when they are about to expire.
+func newTokenSource(httpClient *http.Client, serviceAccountKey string) (oauth2.TokenSource, error) {
+	config, err := google.JWTConfigFromJSON([]byte(serviceAccountKey), cloudPlatformScope)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid service account key")
+	}
+	if config.Email == "" {
+		return nil, errors.New("service account key has no client email")
+	}
+	if len(config.PrivateKey) == 0 {
+		return nil, errors.New("service account key has no private key")
+	}
+	config.TokenURL = tokenURL
+	// The context is only used to pass the HTTP client of the token requests
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, httpClient)
+	return config.TokenSource(ctx), nil
+}
+
+// accessToken returns a valid access token for the service account of the client
+func (c *Client) accessToken() (string, error) {
+	token, err := c.tokens.Token()
+	if err != nil {
+		var retrieveErr *oauth2.RetrieveError
+		if errors.As(err, &retrieveErr) && retrieveErr.Response != nil {
+			err = parseAPIError(retrieveErr.Response.StatusCode, retrieveErr.Body)
+		}
+		return "", errors.Wrap(err, "failed to get service account access token")
+	}
+	return token.AccessToken, nil
+}
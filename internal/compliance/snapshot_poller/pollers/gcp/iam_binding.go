@@ -0,0 +1,77 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+
+	resourcesapimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+)
+
+const projectAssetType = "cloudresourcemanager.googleapis.com/Project"
+
+// PollIAMBindings gathers information on each role binding of the project IAM policy.
+func PollIAMBindings(ctx context.Context, client *Client, input *gcpmodels.ResourcePollerInput) (
+	[]resourcesapimodels.AddResourceEntry, *string, error) {
+
+	assets, marker, err := listAssetsPage(ctx, client, input, gcpmodels.IAMBindingSchema)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var resources []resourcesapimodels.AddResourceEntry
+	for _, asset := range assets {
+		if asset.IAMPolicy == nil {
+			continue
+		}
+		for _, binding := range asset.IAMPolicy.Bindings {
+			resourceID := iamBindingID(asset.Name, binding)
+			if input.ShouldIgnoreResource(resourceID) {
+				continue
+			}
+			snapshot := &gcpmodels.IAMBinding{
+				Role:          binding.Role,
+				Members:       binding.Members,
+				Condition:     binding.Condition,
+				PolicyEtag:    asset.IAMPolicy.Etag,
+				PolicyVersion: asset.IAMPolicy.Version,
+			}
+			fillGenericResource(input, asset, gcpmodels.IAMBindingSchema, &snapshot.GenericGCPResource, &snapshot.GenericResource)
+			snapshot.ResourceID = aws.String(resourceID)
+			snapshot.Name = binding.Role
+			resources = append(resources, newResourceEntry(input, resourceID, gcpmodels.IAMBindingSchema, snapshot))
+		}
+	}
+	return resources, marker, nil
+}
+
+// iamBindingID builds the ID of a binding from the asset name of the policy, the role and the condition.
+//
+// A policy has at most one binding per role and condition.
+// Example: //cloudresourcemanager.googleapis.com/projects/123/bindings/roles/owner
+func iamBindingID(assetName string, binding *gcpmodels.IAMPolicyBinding) string {
+	id := assetName + "/bindings/" + aws.StringValue(binding.Role)
+	if binding.Condition != nil && binding.Condition.Title != nil {
+		id += "/conditions/" + *binding.Condition.Title
+	}
+	return id
+}
@@ -0,0 +1,68 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+)
+
+func TestPollIAMBindings(t *testing.T) {
+	api := newFakeAPI(t)
+
+	resources, marker, err := PollIAMBindings(context.Background(), api.newClient(t), testPollerInput())
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	require.Len(t, resources, 3)
+
+	assert.Equal(t, "//cloudresourcemanager.googleapis.com/projects/123456789012/bindings/roles/owner", resources[0].ID)
+	owner := resources[0].Attributes.(*gcpmodels.IAMBinding)
+	assert.Equal(t, aws.String(resources[0].ID), owner.ResourceID)
+	assert.Equal(t, aws.String(gcpmodels.IAMBindingSchema), owner.ResourceType)
+	assert.Equal(t, aws.String("roles/owner"), owner.Name)
+	assert.Equal(t, aws.String(gcpmodels.GlobalLocation), owner.Location)
+	assert.Equal(t, []*string{aws.String("user:admin@example.com")}, owner.Members)
+	assert.Equal(t, aws.String("BwW6dJ1gRjE="), owner.PolicyEtag)
+	assert.Equal(t, aws.Int64(3), owner.PolicyVersion)
+
+	assert.Equal(t,
+		"//cloudresourcemanager.googleapis.com/projects/123456789012/bindings/roles/editor/conditions/expires-2021",
+		resources[2].ID)
+	editor := resources[2].Attributes.(*gcpmodels.IAMBinding)
+	assert.Equal(t, aws.String("Temporary access"), editor.Condition.Description)
+	assert.Len(t, editor.Members, 2)
+}
+
+func TestPollIAMBindingsIgnored(t *testing.T) {
+	api := newFakeAPI(t)
+	input := testPollerInput()
+	input.ResourceRegexIgnoreList = []string{"*/bindings/roles/owner"}
+	require.NoError(t, input.CompileRegex())
+
+	resources, _, err := PollIAMBindings(context.Background(), api.newClient(t), input)
+	require.NoError(t, err)
+	require.Len(t, resources, 2)
+	assert.Equal(t, aws.String("roles/cloudasset.viewer"), resources[0].Attributes.(*gcpmodels.IAMBinding).Role)
+}
@@ -0,0 +1,122 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+const (
+	sourceAPIFunctionName = "panther-source-api"
+	refreshInterval       = 2 * time.Minute
+)
+
+var (
+	// Replaced by unit tests with mocks
+	lambdaClient  lambdaiface.LambdaAPI                 = lambda.New(session.Must(session.NewSession()))
+	secretsClient secretsmanageriface.SecretsManagerAPI = secretsmanager.New(session.Must(session.NewSession()))
+
+	// GCP integrations keyed on integrationID. The service account keys are kept in Secrets Manager,
+	// so they are read along with the integrations instead of being sent in the scan requests.
+	integrations            = make(map[string]*models.SourceIntegration)
+	integrationsLastUpdated time.Time
+
+	// Clients keyed on integrationID, reused to avoid requesting an access token for every scan
+	clients = make(map[string]*cachedClient)
+)
+
+type cachedClient struct {
+	serviceAccountKey string
+	client            *Client
+}
+
+func resetIntegrationCache() {
+	integrations = make(map[string]*models.SourceIntegration)
+	integrationsLastUpdated = time.Time{}
+}
+
+// getIntegration returns the gcp-scan integration with the given ID, or nil if it does not exist
+func getIntegration(integrationID string) (*models.SourceIntegration, error) {
+	if integrationsLastUpdated.Add(refreshInterval).After(time.Now()) {
+		zap.L().Debug("using cached integrations")
+		return integrations[integrationID], nil
+	}
+
+	zap.L().Debug("populating integration cache")
+	input := &models.LambdaInput{
+		ListIntegrations: &models.ListIntegrationsInput{
+			IntegrationType: aws.String(models.IntegrationTypeGCPScan),
+		},
+	}
+	var output []*models.SourceIntegration
+	if err := genericapi.Invoke(lambdaClient, sourceAPIFunctionName, input, &output); err != nil {
+		return nil, err
+	}
+
+	resetIntegrationCache()
+	for _, integration := range output {
+		if integration.GCPConfig == nil {
+			continue
+		}
+		// The source-api never returns the service account key
+		secret, err := secretsClient.GetSecretValue(&secretsmanager.GetSecretValueInput{
+			SecretId: &integration.CredentialsSecretARN,
+		})
+		if err != nil {
+			// Scans of this integration fail until the key can be read
+			zap.L().Error("failed to read service account key",
+				zap.String("integrationId", integration.IntegrationID), zap.Error(err))
+			continue
+		}
+		integration.GCPConfig.ServiceAccountKey = aws.StringValue(secret.SecretString)
+		integrations[integration.IntegrationID] = integration
+	}
+	integrationsLastUpdated = time.Now()
+	return integrations[integrationID], nil
+}
+
+// getClient returns a Cloud Asset client for the integration
+func getClient(integration *models.SourceIntegration) (*Client, error) {
+	config := integration.GCPConfig
+	if cached, ok := clients[integration.IntegrationID]; ok && cached.serviceAccountKey == config.ServiceAccountKey &&
+		cached.client.ProjectID == config.ProjectID {
+
+		return cached.client, nil
+	}
+	client, err := NewClient(config.ProjectID, config.ServiceAccountKey)
+	if err != nil {
+		return nil, err
+	}
+	clients[integration.IntegrationID] = &cachedClient{
+		serviceAccountKey: config.ServiceAccountKey,
+		client:            client,
+	}
+	return client, nil
+}
@@ -0,0 +1,265 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	resourcesapimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// ResourcePoller lists one page of the resources of a GCP resource type
+type ResourcePoller func(ctx context.Context, client *Client, input *gcpmodels.ResourcePollerInput) (
+	resources []resourcesapimodels.AddResourceEntry, marker *string, err error)
+
+// resourcePoller is a simple struct to be used only for invoking the ResourcePollers in order.
+type resourcePoller struct {
+	description    string
+	resourcePoller ResourcePoller
+}
+
+const (
+	integrationType = "gcp"
+)
+
+var (
+	// The number of assets to request per page, a new scan is queued for the next page
+	defaultPageSize    = 100
+	pageRequeueDelayer = rand.New(rand.NewSource(time.Now().UnixNano())) // nolint:gosec
+
+	// ServicePollers maps a resource type to its Poll function.
+	//
+	// Resource types are defined in the registry in models/resourcetypes/gcp.go, the tests make sure
+	// every registered type has a poller here.
+	ServicePollers = map[string]resourcePoller{
+		gcpmodels.ComputeFirewallSchema: {"ComputeFirewall", PollComputeFirewalls},
+		gcpmodels.ComputeInstanceSchema: {"ComputeInstance", PollComputeInstances},
+		gcpmodels.IAMBindingSchema:      {"IAMBinding", PollIAMBindings},
+		gcpmodels.SQLInstanceSchema:     {"SQLInstance", PollSQLInstances},
+		gcpmodels.StorageBucketSchema:   {"StorageBucket", PollStorageBuckets},
+	}
+)
+
+// Poll coordinates the scan of a GCP resource type for compliance monitoring.
+//
+// Only service scans are supported, there are no change events that trigger single resource scans in GCP projects.
+func Poll(ctx context.Context, scanRequest *pollermodels.ScanEntry) (
+	generatedEvents []resourcesapimodels.AddResourceEntry, err error) {
+
+	// These errors cannot be retried so we don't return them
+	if scanRequest.IntegrationID == nil || scanRequest.ProjectID == nil || scanRequest.ResourceType == nil {
+		zap.L().Error("Invalid scan request input - integrationID, projectID and resourceType must be specified",
+			zap.Any("input", scanRequest))
+		return nil, nil
+	}
+	if scanRequest.ResourceID != nil {
+		zap.L().Error("single resource scans are not supported for GCP integrations",
+			zap.String("resourceID", *scanRequest.ResourceID))
+		return nil, nil
+	}
+
+	// Check if integration is disabled
+	if scanRequest.Enabled != nil && !*scanRequest.Enabled {
+		zap.L().Info("source integration disabled",
+			zap.String("integration id", *scanRequest.IntegrationID))
+		return nil, nil
+	}
+
+	// Check if resource type is filtered
+	for _, resourceType := range scanRequest.ResourceTypeIgnoreList {
+		if resourceType == *scanRequest.ResourceType {
+			zap.L().Info("resource type filtered", zap.String("resource type", resourceType))
+			return nil, nil
+		}
+	}
+
+	poller, ok := ServicePollers[*scanRequest.ResourceType]
+	if !ok {
+		return nil, errors.Errorf("invalid GCP resource type '%s' scan requested", *scanRequest.ResourceType)
+	}
+
+	integration, err := getIntegration(*scanRequest.IntegrationID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to lookup GCP integration")
+	}
+	if integration == nil || integration.GCPConfig == nil {
+		zap.L().Warn("GCP integration not found, skipping scan",
+			zap.String("integration id", *scanRequest.IntegrationID))
+		return nil, nil
+	}
+	if integration.GCPConfig.ProjectID != *scanRequest.ProjectID {
+		zap.L().Warn("GCP integration project changed, skipping scan",
+			zap.String("integration id", *scanRequest.IntegrationID),
+			zap.String("project id", *scanRequest.ProjectID))
+		return nil, nil
+	}
+	client, err := getClient(integration)
+	if err != nil {
+		// The stored key is invalid, retrying won't help
+		zap.L().Error("invalid GCP credentials", zap.Error(err),
+			zap.String("integration id", *scanRequest.IntegrationID))
+		return nil, nil
+	}
+
+	pollerInput := &gcpmodels.ResourcePollerInput{
+		IntegrationID:           scanRequest.IntegrationID,
+		ProjectID:               scanRequest.ProjectID,
+		Timestamp:               aws.Time(utils.TimeNowFunc()),
+		NextPageToken:           scanRequest.NextPageToken,
+		ResourceTypeIgnoreList:  scanRequest.ResourceTypeIgnoreList,
+		ResourceRegexIgnoreList: scanRequest.ResourceRegexIgnoreList,
+	}
+	if err = pollerInput.CompileRegex(); err != nil {
+		zap.L().Error("unable to compile passed regex",
+			zap.Any("resource regex ignore list", scanRequest.ResourceRegexIgnoreList))
+		return nil, err
+	}
+
+	return serviceScan(ctx, poller, client, pollerInput, scanRequest)
+}
+
+func serviceScan(
+	ctx context.Context,
+	poller resourcePoller,
+	client *Client,
+	pollerInput *gcpmodels.ResourcePollerInput,
+	scanRequest *pollermodels.ScanEntry,
+) (generatedEvents []resourcesapimodels.AddResourceEntry, err error) {
+
+	var marker *string
+	generatedEvents, marker, err = poller.resourcePoller(ctx, client, pollerInput)
+	if err != nil {
+		zap.L().Info(
+			"an error occurred while polling",
+			zap.String("resourcePoller", poller.description),
+			zap.String("errorMessage", err.Error()),
+		)
+		return nil, err
+	}
+
+	zap.L().Info(
+		"resources generated",
+		zap.Int("numResources", len(generatedEvents)),
+		zap.String("resourcePoller", poller.description),
+	)
+
+	// Re-queue a scan starting from the next page
+	if marker != nil {
+		scanRequest.NextPageToken = marker
+		err = utils.Requeue(pollermodels.ScanMsg{
+			Entries: []*pollermodels.ScanEntry{
+				scanRequest,
+			},
+		}, int64(pageRequeueDelayer.Intn(30)+1)) // Delay between 1 & 30 seconds to spread out page scans
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return generatedEvents, nil
+}
+
+// CheckCredentials verifies that a service account key can read the Cloud Asset inventory of a project
+func CheckCredentials(ctx context.Context, projectID, serviceAccountKey string) error {
+	client, err := NewClient(projectID, serviceAccountKey)
+	if err != nil {
+		return err
+	}
+	_, err = client.ListAssets(ctx, projectAssetType, resourcetypes.ContentTypeResource, "", 1)
+	return err
+}
+
+// listAssetsPage lists the page of assets of a resource type requested by the poller input
+func listAssetsPage(ctx context.Context, client *Client, input *gcpmodels.ResourcePollerInput, resourceType string) (
+	assets []*Asset, marker *string, err error) {
+
+	info, ok := resourcetypes.Lookup(resourceType)
+	if !ok {
+		return nil, nil, errors.Errorf("unknown resource type %s", resourceType)
+	}
+	page, err := client.ListAssets(ctx, info.AssetType, info.ContentType, aws.StringValue(input.NextPageToken), defaultPageSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	if page.NextPageToken != "" {
+		marker = aws.String(page.NextPageToken)
+	}
+	return page.Assets, marker, nil
+}
+
+// decodeAsset decodes the resource data of an asset into a snapshot and fills in the generic resource fields
+func decodeAsset(
+	input *gcpmodels.ResourcePollerInput,
+	asset *Asset,
+	resourceType string,
+	snapshot interface{},
+	gcpResource *gcpmodels.GenericGCPResource,
+	resource *gcpmodels.GenericResource,
+) error {
+
+	if asset.Resource == nil {
+		return errors.Errorf("asset %s has no resource data", asset.Name)
+	}
+	if err := jsoniter.Unmarshal(asset.Resource.Data, snapshot); err != nil {
+		return errors.Wrapf(err, "failed to decode asset %s", asset.Name)
+	}
+	fillGenericResource(input, asset, resourceType, gcpResource, resource)
+	return nil
+}
+
+// fillGenericResource fills in the fields common to all GCP resources
+func fillGenericResource(
+	input *gcpmodels.ResourcePollerInput,
+	asset *Asset,
+	resourceType string,
+	gcpResource *gcpmodels.GenericGCPResource,
+	resource *gcpmodels.GenericResource,
+) {
+
+	resource.ResourceID = aws.String(asset.Name)
+	resource.ResourceType = aws.String(resourceType)
+	gcpResource.ProjectID = input.ProjectID
+	gcpResource.AssetName = aws.String(asset.Name)
+	gcpResource.Location = aws.String(gcpmodels.GlobalLocation)
+	if asset.Resource != nil && asset.Resource.Location != "" {
+		gcpResource.Location = aws.String(asset.Resource.Location)
+	}
+}
+
+// newResourceEntry builds the resources-api entry of a snapshot
+func newResourceEntry(input *gcpmodels.ResourcePollerInput, resourceID, resourceType string, snapshot interface{}) resourcesapimodels.AddResourceEntry {
+	return resourcesapimodels.AddResourceEntry{
+		Attributes:      snapshot,
+		ID:              resourceID,
+		IntegrationID:   *input.IntegrationID,
+		IntegrationType: integrationType,
+		Type:            resourceType,
+	}
+}
@@ -0,0 +1,190 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+const (
+	testIntegrationID = "3e4e4a30-a3e5-47d1-9ac4-9c3d5a5a6c4b"
+	testSecretARN     = "arn:aws:secretsmanager:us-east-1:123456789012:secret:panther-source-credentials-test"
+)
+
+// mockSourceAPI makes the source-api return a gcp-scan integration for the fake API
+func mockSourceAPI(t *testing.T) *testutils.LambdaMock {
+	integrations := []*models.SourceIntegration{
+		{
+			SourceIntegrationMetadata: models.SourceIntegrationMetadata{
+				IntegrationID:   testIntegrationID,
+				IntegrationType: models.IntegrationTypeGCPScan,
+				GCPConfig: &models.GCPConfig{
+					ProjectID: testProjectID,
+				},
+				CredentialsSecretARN: testSecretARN,
+			},
+		},
+	}
+	payload, err := jsoniter.Marshal(integrations)
+	require.NoError(t, err)
+
+	mockLambda := &testutils.LambdaMock{}
+	mockLambda.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{Payload: payload}, nil)
+
+	mockSecrets := &testutils.SecretsManagerMock{}
+	mockSecrets.On("GetSecretValue", &secretsmanager.GetSecretValueInput{SecretId: aws.String(testSecretARN)}).
+		Return(&secretsmanager.GetSecretValueOutput{SecretString: aws.String(testServiceAccountKey(t))}, nil)
+
+	client, secrets := lambdaClient, secretsClient
+	lambdaClient, secretsClient = mockLambda, mockSecrets
+	resetIntegrationCache()
+	clients = make(map[string]*cachedClient)
+	t.Cleanup(func() {
+		lambdaClient, secretsClient = client, secrets
+		resetIntegrationCache()
+	})
+	return mockLambda
+}
+
+func testScanEntry(resourceType string) *pollermodels.ScanEntry {
+	return &pollermodels.ScanEntry{
+		IntegrationType: aws.String(integrationType),
+		IntegrationID:   aws.String(testIntegrationID),
+		ProjectID:       aws.String(testProjectID),
+		ResourceType:    aws.String(resourceType),
+	}
+}
+
+func TestRegistryServicePollers(t *testing.T) {
+	for _, info := range resourcetypes.RegisteredFor(resourcetypes.IntegrationTypeGCP) {
+		assert.Contains(t, ServicePollers, info.Name)
+	}
+	for resourceType := range ServicePollers {
+		info, ok := resourcetypes.Lookup(resourceType)
+		if assert.True(t, ok, "%s is not registered", resourceType) {
+			assert.Equal(t, resourcetypes.IntegrationTypeGCP, info.IntegrationType)
+		}
+	}
+}
+
+func TestPoll(t *testing.T) {
+	api := newFakeAPI(t)
+	mockLambda := mockSourceAPI(t)
+
+	resources, err := Poll(context.Background(), testScanEntry(gcpmodels.ComputeFirewallSchema))
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, "//compute.googleapis.com/projects/panther-test/global/firewalls/default-allow-ssh", resources[0].ID)
+	assert.Equal(t, "gcp", resources[0].IntegrationType)
+	assert.Equal(t, testIntegrationID, resources[0].IntegrationID)
+	assert.Equal(t, gcpmodels.ComputeFirewallSchema, resources[0].Type)
+
+	// The integration and the access token are cached
+	_, err = Poll(context.Background(), testScanEntry(gcpmodels.SQLInstanceSchema))
+	require.NoError(t, err)
+	mockLambda.AssertNumberOfCalls(t, "Invoke", 1)
+	assert.Equal(t, 1, api.tokenRequests)
+}
+
+func TestPollIgnored(t *testing.T) {
+	api := newFakeAPI(t)
+	mockSourceAPI(t)
+
+	entry := testScanEntry(gcpmodels.ComputeFirewallSchema)
+	entry.Enabled = aws.Bool(false)
+	resources, err := Poll(context.Background(), entry)
+	require.NoError(t, err)
+	assert.Empty(t, resources)
+
+	entry = testScanEntry(gcpmodels.ComputeFirewallSchema)
+	entry.ResourceTypeIgnoreList = []string{gcpmodels.ComputeFirewallSchema}
+	resources, err = Poll(context.Background(), entry)
+	require.NoError(t, err)
+	assert.Empty(t, resources)
+	// Disabled integrations and ignored resource types are not scanned
+	assert.Empty(t, api.assetRequests)
+
+	entry = testScanEntry(gcpmodels.ComputeFirewallSchema)
+	entry.ResourceRegexIgnoreList = []string{"//compute.googleapis.com/projects/panther-test/global/firewalls/default-*"}
+	resources, err = Poll(context.Background(), entry)
+	require.NoError(t, err)
+	assert.Empty(t, resources)
+
+	assert.Len(t, api.assetRequests, 1)
+}
+
+func TestPollUnknownIntegration(t *testing.T) {
+	api := newFakeAPI(t)
+	mockSourceAPI(t)
+
+	entry := testScanEntry(gcpmodels.ComputeFirewallSchema)
+	entry.IntegrationID = aws.String("ce6b7e5c-3d9f-4ad1-9d6b-0d3f5f1d6a20")
+	resources, err := Poll(context.Background(), entry)
+	require.NoError(t, err)
+	assert.Empty(t, resources)
+	assert.Empty(t, api.assetRequests)
+}
+
+func TestPollMissingServiceAccountKey(t *testing.T) {
+	api := newFakeAPI(t)
+	mockSourceAPI(t)
+	mockSecrets := &testutils.SecretsManagerMock{}
+	mockSecrets.On("GetSecretValue", mock.Anything).Return(&secretsmanager.GetSecretValueOutput{}, errors.New("access denied"))
+	secretsClient = mockSecrets
+
+	resources, err := Poll(context.Background(), testScanEntry(gcpmodels.ComputeFirewallSchema))
+	require.NoError(t, err)
+	assert.Empty(t, resources)
+	assert.Empty(t, api.assetRequests)
+	mockSecrets.AssertExpectations(t)
+}
+
+func TestPollInvalidRequest(t *testing.T) {
+	resources, err := Poll(context.Background(), &pollermodels.ScanEntry{
+		IntegrationID: aws.String(testIntegrationID),
+		ProjectID:     aws.String(testProjectID),
+	})
+	require.NoError(t, err)
+	assert.Empty(t, resources)
+
+	entry := testScanEntry(gcpmodels.ComputeFirewallSchema)
+	entry.ResourceID = aws.String("//compute.googleapis.com/projects/panther-test/global/firewalls/default-allow-ssh")
+	resources, err = Poll(context.Background(), entry)
+	require.NoError(t, err)
+	assert.Empty(t, resources)
+
+	_, err = Poll(context.Background(), testScanEntry("GCP.Unknown"))
+	require.Error(t, err)
+}
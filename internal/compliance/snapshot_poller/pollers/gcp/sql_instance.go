@@ -0,0 +1,57 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	resourcesapimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+)
+
+// PollSQLInstances gathers information on each Cloud SQL instance of a project.
+func PollSQLInstances(ctx context.Context, client *Client, input *gcpmodels.ResourcePollerInput) (
+	[]resourcesapimodels.AddResourceEntry, *string, error) {
+
+	assets, marker, err := listAssetsPage(ctx, client, input, gcpmodels.SQLInstanceSchema)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resources := make([]resourcesapimodels.AddResourceEntry, 0, len(assets))
+	for _, asset := range assets {
+		if input.ShouldIgnoreResource(asset.Name) {
+			continue
+		}
+		instance := &gcpmodels.SQLInstance{}
+		err := decodeAsset(input, asset, gcpmodels.SQLInstanceSchema, instance, &instance.GenericGCPResource, &instance.GenericResource)
+		if err != nil {
+			zap.L().Error("skipping invalid Cloud SQL instance asset", zap.Error(err))
+			continue
+		}
+		// Cloud SQL has user labels in the instance settings
+		if instance.Settings != nil {
+			instance.Labels = instance.Settings.UserLabels
+		}
+		resources = append(resources, newResourceEntry(input, asset.Name, gcpmodels.SQLInstanceSchema, instance))
+	}
+	return resources, marker, nil
+}
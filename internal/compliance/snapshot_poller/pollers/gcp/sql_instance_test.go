@@ -0,0 +1,52 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+)
+
+func TestPollSQLInstances(t *testing.T) {
+	api := newFakeAPI(t)
+
+	resources, marker, err := PollSQLInstances(context.Background(), api.newClient(t), testPollerInput())
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	require.Len(t, resources, 1)
+
+	instance := resources[0].Attributes.(*gcpmodels.SQLInstance)
+	assert.Equal(t, "//cloudsql.googleapis.com/projects/panther-test/instances/orders-db", resources[0].ID)
+	assert.Equal(t, aws.String("orders-db"), instance.Name)
+	assert.Equal(t, aws.String("us-central1"), instance.Location)
+	assert.Equal(t, aws.String("POSTGRES_13"), instance.DatabaseVersion)
+	assert.Equal(t, map[string]*string{"team": aws.String("payments")}, instance.Labels)
+	assert.Equal(t, aws.String("198.51.100.7"), instance.IPAddresses[0].IPAddress)
+	assert.True(t, *instance.Settings.IPConfiguration.IPv4Enabled)
+	assert.False(t, *instance.Settings.IPConfiguration.RequireSsl)
+	assert.Equal(t, aws.String("0.0.0.0/0"), instance.Settings.IPConfiguration.AuthorizedNetworks[0].Value)
+	assert.True(t, *instance.Settings.BackupConfiguration.Enabled)
+	assert.Equal(t, aws.String("10"), instance.Settings.DataDiskSizeGb)
+}
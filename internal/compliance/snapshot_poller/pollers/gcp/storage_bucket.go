@@ -0,0 +1,70 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	resourcesapimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
+)
+
+// PollStorageBuckets gathers information on each GCS bucket of a project, including its IAM policy.
+func PollStorageBuckets(ctx context.Context, client *Client, input *gcpmodels.ResourcePollerInput) (
+	[]resourcesapimodels.AddResourceEntry, *string, error) {
+
+	assets, marker, err := listAssetsPage(ctx, client, input, gcpmodels.StorageBucketSchema)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(assets) == 0 {
+		return nil, marker, nil
+	}
+
+	// Bucket IAM policies are separate assets, they are read for all buckets with each page
+	info, _ := resourcetypes.Lookup(gcpmodels.StorageBucketSchema)
+	policyAssets, err := client.ListAllAssets(ctx, info.AssetType, resourcetypes.ContentTypeIAMPolicy)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to list bucket IAM policies")
+	}
+	policies := make(map[string]*gcpmodels.IAMPolicy, len(policyAssets))
+	for _, asset := range policyAssets {
+		policies[asset.Name] = asset.IAMPolicy
+	}
+
+	resources := make([]resourcesapimodels.AddResourceEntry, 0, len(assets))
+	for _, asset := range assets {
+		if input.ShouldIgnoreResource(asset.Name) {
+			continue
+		}
+		bucket := &gcpmodels.StorageBucket{}
+		err := decodeAsset(input, asset, gcpmodels.StorageBucketSchema, bucket, &bucket.GenericGCPResource, &bucket.GenericResource)
+		if err != nil {
+			zap.L().Error("skipping invalid GCS bucket asset", zap.Error(err))
+			continue
+		}
+		bucket.IamPolicy = policies[asset.Name]
+		resources = append(resources, newResourceEntry(input, asset.Name, gcpmodels.StorageBucketSchema, bucket))
+	}
+	return resources, marker, nil
+}
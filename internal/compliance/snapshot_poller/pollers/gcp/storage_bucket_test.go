@@ -0,0 +1,79 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+)
+
+func TestPollStorageBuckets(t *testing.T) {
+	api := newFakeAPI(t)
+
+	resources, marker, err := PollStorageBuckets(context.Background(), api.newClient(t), testPollerInput())
+	require.NoError(t, err)
+	assert.Equal(t, aws.String("CiRzdG9yYWdlLmdvb2dsZWFwaXMuY29tL0J1Y2tldA"), marker)
+	require.Len(t, resources, 2)
+
+	bucket := resources[0].Attributes.(*gcpmodels.StorageBucket)
+	assert.Equal(t, "//storage.googleapis.com/panther-test-logs", resources[0].ID)
+	assert.Equal(t, gcpmodels.StorageBucketSchema, resources[0].Type)
+	assert.Equal(t, aws.String("//storage.googleapis.com/panther-test-logs"), bucket.ResourceID)
+	assert.Equal(t, aws.String(gcpmodels.StorageBucketSchema), bucket.ResourceType)
+	assert.Equal(t, aws.String(testProjectID), bucket.ProjectID)
+	assert.Equal(t, aws.String("us"), bucket.Location)
+	assert.Equal(t, aws.String("panther-test-logs"), bucket.Name)
+	assert.Equal(t, map[string]*string{"team": aws.String("security")}, bucket.Labels)
+	assert.Equal(t, time.Date(2021, 2, 10, 17, 20, 4, 102000000, time.UTC), bucket.TimeCreated.UTC())
+	assert.True(t, *bucket.IamConfiguration.UniformBucketLevelAccess.Enabled)
+	assert.True(t, *bucket.Versioning.Enabled)
+	assert.Equal(t, aws.String("panther-test-access-logs"), bucket.Logging.LogBucket)
+	assert.Equal(t, aws.String("86400"), bucket.RetentionPolicy.RetentionPeriod)
+	assert.Nil(t, bucket.IamPolicy)
+
+	public := resources[1].Attributes.(*gcpmodels.StorageBucket)
+	require.NotNil(t, public.IamPolicy)
+	require.Len(t, public.IamPolicy.Bindings, 2)
+	assert.Equal(t, aws.String("roles/storage.objectViewer"), public.IamPolicy.Bindings[1].Role)
+	assert.Equal(t, []*string{aws.String("allUsers")}, public.IamPolicy.Bindings[1].Members)
+
+	assert.Equal(t, []string{
+		"storage.googleapis.com/Bucket RESOURCE",
+		"storage.googleapis.com/Bucket IAM_POLICY",
+	}, api.assetRequests)
+}
+
+func TestPollStorageBucketsEmpty(t *testing.T) {
+	api := newFakeAPI(t)
+	api.fixtures = map[string]string{}
+
+	resources, marker, err := PollStorageBuckets(context.Background(), api.newClient(t), testPollerInput())
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	assert.Empty(t, resources)
+	// IAM policies are not read without buckets
+	assert.Len(t, api.assetRequests, 1)
+}
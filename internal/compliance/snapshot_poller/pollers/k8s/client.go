@@ -0,0 +1,207 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+)
+
+const (
+	// The maximum number of objects requested per list request
+	maxPageSize    = 500
+	requestTimeout = 30 * time.Second
+)
+
+// restConfig is the connection info of a cluster API server
+type restConfig struct {
+	Server         string
+	CAData         []byte
+	Insecure       bool
+	ClientCertData []byte
+	ClientKeyData  []byte
+	Tokens         tokenSource
+}
+
+// Client reads the objects of a Kubernetes cluster
+type Client struct {
+	ClusterName string
+	server      string
+	httpClient  *http.Client
+	tokens      tokenSource
+}
+
+// NewClient builds a client for the cluster of a Kubernetes integration.
+//
+// The AWS credentials are only used for EKS clusters, they need to be IAM credentials in the cluster account.
+func NewClient(ctx context.Context, config *models.K8sConfig, creds *credentials.Credentials) (*Client, error) {
+	var rest *restConfig
+	var err error
+	if config.Kubeconfig != "" {
+		rest, err = parseKubeconfig(config.Kubeconfig)
+	} else {
+		rest, err = eksRestConfig(ctx, config.EKSClusterARN, creds)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newClient(config.ClusterName, rest)
+}
+
+func newClient(clusterName string, config *restConfig) (*Client, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: config.Insecure, // nolint:gosec
+	}
+	if len(config.CAData) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(config.CAData) {
+			return nil, errors.New("invalid cluster certificate authority")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if len(config.ClientCertData) > 0 {
+		cert, err := tls.X509KeyPair(config.ClientCertData, config.ClientKeyData)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid client certificate")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	return &Client{
+		ClusterName: clusterName,
+		server:      strings.TrimSuffix(config.Server, "/"),
+		httpClient:  &http.Client{Timeout: requestTimeout, Transport: transport},
+		tokens:      config.Tokens,
+	}, nil
+}
+
+// ObjectList is a page of the objects of a resource type, the items are decoded by the pollers
+type ObjectList struct {
+	Metadata ListMeta              `json:"metadata"`
+	Items    []jsoniter.RawMessage `json:"items"`
+}
+
+type ListMeta struct {
+	Continue        string `json:"continue"`
+	ResourceVersion string `json:"resourceVersion"`
+}
+
+// APIError is the Status object returned by the API server for failed requests
+type APIError struct {
+	StatusCode int    `json:"-"`
+	Reason     string `json:"reason"`
+	Message    string `json:"message"`
+}
+
+func (e *APIError) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("%d %s: %s", e.StatusCode, e.Reason, e.Message)
+	}
+	return fmt.Sprintf("%d: %s", e.StatusCode, e.Message)
+}
+
+// List returns a page of the objects of a list endpoint, e.g. /api/v1/pods
+func (c *Client) List(ctx context.Context, apiPath, continueToken string, limit int) (*ObjectList, error) {
+	if limit <= 0 || limit > maxPageSize {
+		limit = maxPageSize
+	}
+	query := url.Values{
+		"limit": {strconv.Itoa(limit)},
+	}
+	if continueToken != "" {
+		query.Set("continue", continueToken)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.server+apiPath+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build list request")
+	}
+	if c.tokens != nil {
+		token, err := c.tokens.Token(ctx)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	output := &ObjectList{}
+	if err := doJSON(c.httpClient, req, output); err != nil {
+		return nil, errors.Wrapf(err, "list %s failed", apiPath)
+	}
+	return output, nil
+}
+
+// ListAll returns all the objects of a list endpoint
+func (c *Client) ListAll(ctx context.Context, apiPath string) ([]jsoniter.RawMessage, error) {
+	var items []jsoniter.RawMessage
+	continueToken := ""
+	for {
+		page, err := c.List(ctx, apiPath, continueToken, maxPageSize)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page.Items...)
+		if page.Metadata.Continue == "" {
+			return items, nil
+		}
+		continueToken = page.Metadata.Continue
+	}
+}
+
+// doJSON sends a request and decodes the JSON response body into output
+func doJSON(httpClient *http.Client, req *http.Request, output interface{}) error {
+	req.Header.Set("Accept", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read response body")
+	}
+	if resp.StatusCode != http.StatusOK {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		if err := jsoniter.Unmarshal(body, apiErr); err != nil || apiErr.Message == "" {
+			apiErr.Message = http.StatusText(resp.StatusCode)
+		}
+		return apiErr
+	}
+	if err := jsoniter.Unmarshal(body, output); err != nil {
+		return errors.Wrap(err, "failed to decode response body")
+	}
+	return nil
+}
@@ -0,0 +1,262 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	k8smodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/k8s"
+)
+
+const (
+	testClusterName = "prod"
+	testToken       = "eyJhbGciOiJSUzI1NiJ9.test-service-account-token"
+	// The fake API returns 410 Gone for this continue token
+	expiredContinueToken = "expired"
+)
+
+// fakeAPI serves the list endpoints of the Kubernetes API from the fixtures in testdata.
+//
+// The fixture of an endpoint is named after the last element of its path, e.g. pods.json for /api/v1/pods
+type fakeAPI struct {
+	server       *httptest.Server
+	listRequests []string
+	// Replaced by tests to check the bearer token of other authentication methods
+	checkToken func(t *testing.T, token string) bool
+}
+
+func newFakeAPI(t *testing.T) *fakeAPI {
+	api := &fakeAPI{
+		checkToken: func(t *testing.T, token string) bool { return token == testToken },
+	}
+	api.server = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		const bearerPrefix = "Bearer "
+		auth := r.Header.Get("Authorization")
+		if len(auth) <= len(bearerPrefix) || !api.checkToken(t, auth[len(bearerPrefix):]) {
+			writeStatus(w, http.StatusUnauthorized, "Unauthorized", "Unauthorized")
+			return
+		}
+		api.listRequests = append(api.listRequests, r.URL.Path)
+		if r.URL.Query().Get("continue") == expiredContinueToken {
+			writeStatus(w, http.StatusGone, "Expired", "The provided continue parameter is too old")
+			return
+		}
+		body, err := ioutil.ReadFile(filepath.Join("testdata", path.Base(r.URL.Path)+".json"))
+		if err != nil {
+			writeStatus(w, http.StatusForbidden, "Forbidden", fmt.Sprintf(
+				`%s is forbidden: User "system:serviceaccount:panther:audit" cannot list resource`, r.URL.Path))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}))
+	// Clients that don't trust the server certificate are tested, the handshake errors are expected
+	api.server.Config.ErrorLog = log.New(ioutil.Discard, "", 0)
+	api.server.StartTLS()
+	t.Cleanup(api.server.Close)
+	return api
+}
+
+// kubeconfig returns a kubeconfig for the fake API using the test token
+func (api *fakeAPI) kubeconfig() string {
+	caData := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: api.server.Certificate().Raw})
+	return fmt.Sprintf(`apiVersion: v1
+kind: Config
+current-context: panther
+clusters:
+- name: prod
+  cluster:
+    server: %s
+    certificate-authority-data: %s
+contexts:
+- name: other
+  context:
+    cluster: other
+    user: other
+- name: panther
+  context:
+    cluster: prod
+    user: panther-audit
+users:
+- name: panther-audit
+  user:
+    token: %s
+`, api.server.URL, base64.StdEncoding.EncodeToString(caData), testToken)
+}
+
+func (api *fakeAPI) newClient(t *testing.T) *Client {
+	client, err := NewClient(context.Background(), &models.K8sConfig{
+		ClusterName: testClusterName,
+		Kubeconfig:  api.kubeconfig(),
+	}, nil)
+	require.NoError(t, err)
+	return client
+}
+
+func writeStatus(w http.ResponseWriter, status int, reason, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = fmt.Fprintf(w, `{"kind":"Status","apiVersion":"v1","status":"Failure","message":%q,"reason":%q,"code":%d}`,
+		message, reason, status)
+}
+
+func testPollerInput() *k8smodels.ResourcePollerInput {
+	integrationID := testIntegrationID
+	clusterName := testClusterName
+	return &k8smodels.ResourcePollerInput{
+		IntegrationID: &integrationID,
+		ClusterName:   &clusterName,
+	}
+}
+
+func TestParseKubeconfig(t *testing.T) {
+	api := newFakeAPI(t)
+	config, err := parseKubeconfig(api.kubeconfig())
+	require.NoError(t, err)
+	assert.Equal(t, api.server.URL, config.Server)
+	assert.Equal(t, staticToken(testToken), config.Tokens)
+	assert.NotEmpty(t, config.CAData)
+	assert.False(t, config.Insecure)
+
+	// The only context is used if the current context is not set
+	config, err = parseKubeconfig(`
+clusters:
+- name: dev
+  cluster:
+    server: https://10.0.0.1:6443
+    insecure-skip-tls-verify: true
+contexts:
+- name: dev
+  context:
+    cluster: dev
+    user: dev
+users:
+- name: dev
+  user:
+    client-certificate-data: Y2VydA==
+    client-key-data: a2V5
+`)
+	require.NoError(t, err)
+	assert.Equal(t, "https://10.0.0.1:6443", config.Server)
+	assert.True(t, config.Insecure)
+	assert.Nil(t, config.Tokens)
+	assert.Equal(t, []byte("cert"), config.ClientCertData)
+	assert.Equal(t, []byte("key"), config.ClientKeyData)
+}
+
+func TestParseKubeconfigInvalid(t *testing.T) {
+	const clusters = `
+clusters:
+- name: dev
+  cluster:
+    server: https://10.0.0.1:6443
+contexts:
+- name: dev
+  context:
+    cluster: dev
+    user: dev
+`
+	for _, kubeconfig := range []string{
+		`not: [valid`,
+		// Unknown context
+		"current-context: other\n" + clusters + "users:\n- name: dev\n  user:\n    token: abc\n",
+		// No credentials
+		clusters + "users:\n- name: dev\n  user: {}\n",
+		// Credentials that need files or commands
+		clusters + "users:\n- name: dev\n  user:\n    tokenFile: /var/run/token\n",
+		clusters + "users:\n- name: dev\n  user:\n    exec:\n      command: aws\n",
+		// Invalid certificate data
+		clusters + "users:\n- name: dev\n  user:\n    client-certificate-data: '!!'\n",
+	} {
+		_, err := parseKubeconfig(kubeconfig)
+		assert.Error(t, err, kubeconfig)
+	}
+}
+
+func TestList(t *testing.T) {
+	api := newFakeAPI(t)
+	client := api.newClient(t)
+
+	page, err := client.List(context.Background(), "/api/v1/pods", "", 10)
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+	assert.NotEmpty(t, page.Metadata.Continue)
+
+	items, err := client.ListAll(context.Background(), "/api/v1/namespaces")
+	require.NoError(t, err)
+	assert.Len(t, items, 2)
+	assert.Equal(t, []string{"/api/v1/pods", "/api/v1/namespaces"}, api.listRequests)
+}
+
+func TestListAPIError(t *testing.T) {
+	api := newFakeAPI(t)
+	client := api.newClient(t)
+
+	_, err := client.List(context.Background(), "/api/v1/secrets", "", 10)
+	require.Error(t, err)
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+	assert.Equal(t, "Forbidden", apiErr.Reason)
+
+	// The server certificate is verified with the certificate authority of the kubeconfig
+	client, err = NewClient(context.Background(), &models.K8sConfig{
+		ClusterName: testClusterName,
+		Kubeconfig: "clusters:\n- name: prod\n  cluster:\n    server: " + api.server.URL + "\n" +
+			"contexts:\n- name: prod\n  context:\n    cluster: prod\n    user: prod\n" +
+			"users:\n- name: prod\n  user:\n    token: " + testToken + "\n",
+	}, nil)
+	require.NoError(t, err)
+	_, err = client.List(context.Background(), "/api/v1/pods", "", 10)
+	require.Error(t, err)
+	assert.False(t, errors.As(err, &apiErr))
+	assert.Len(t, api.listRequests, 1)
+}
+
+func TestCheckCredentials(t *testing.T) {
+	api := newFakeAPI(t)
+	config := &models.K8sConfig{
+		ClusterName: testClusterName,
+		Kubeconfig:  api.kubeconfig(),
+	}
+	assert.NoError(t, CheckCredentials(context.Background(), config, nil))
+	assert.Equal(t, []string{"/api/v1/namespaces"}, api.listRequests)
+
+	api.checkToken = func(*testing.T, string) bool { return false }
+	err := CheckCredentials(context.Background(), config, nil)
+	require.Error(t, err)
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+}
@@ -0,0 +1,53 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	k8smodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/k8s"
+)
+
+func TestPollDeployments(t *testing.T) {
+	api := newFakeAPI(t)
+
+	resources, marker, err := PollDeployments(context.Background(), api.newClient(t), testPollerInput())
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	require.Len(t, resources, 1)
+	assert.Equal(t, "k8s://prod/namespaces/web/deployments/frontend", resources[0].ID)
+
+	deployment := resources[0].Attributes.(*k8smodels.Deployment)
+	assert.Equal(t, aws.String("3"), deployment.Annotations["deployment.kubernetes.io/revision"])
+	assert.Equal(t, aws.Int64(3), deployment.Spec.Replicas)
+	assert.Equal(t, aws.String("frontend"), deployment.Spec.Selector.MatchLabels["app"])
+	assert.Equal(t, aws.String("RollingUpdate"), deployment.Spec.Strategy.Type)
+	assert.Equal(t, aws.String("frontend"), deployment.Spec.Template.Metadata.Labels["app"])
+
+	podSpec := deployment.Spec.Template.Spec
+	assert.Equal(t, aws.Bool(false), podSpec.AutomountServiceAccountToken)
+	assert.Equal(t, aws.Bool(true), podSpec.Containers[0].SecurityContext.RunAsNonRoot)
+	assert.Equal(t, aws.Int64(1000), podSpec.Containers[0].SecurityContext.RunAsUser)
+	assert.Equal(t, aws.Int64(3), deployment.Status.AvailableReplicas)
+}
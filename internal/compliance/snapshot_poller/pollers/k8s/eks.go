@@ -0,0 +1,140 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/aws/aws-sdk-go/service/eks/eksiface"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Same format and lifetime as the tokens of the aws-iam-authenticator, which EKS uses to
+	// authenticate IAM principals. The server accepts the tokens for 15 minutes.
+	eksTokenPrefix       = "k8s-aws-v1."
+	eksClusterIDHeader   = "x-k8s-aws-id"
+	eksTokenPresignDelay = time.Minute
+	eksTokenLifetime     = 14 * time.Minute
+)
+
+var (
+	awsSession = session.Must(session.NewSession())
+
+	// Replaced by unit tests with a mock
+	eksClientFunc = func(sess *session.Session, cfg *aws.Config) eksiface.EKSAPI {
+		return eks.New(sess, cfg)
+	}
+)
+
+// ParseEKSClusterARN returns the account, region and name of an EKS cluster
+func ParseEKSClusterARN(clusterARN string) (accountID, region, name string, err error) {
+	parsed, err := arn.Parse(clusterARN)
+	if err != nil || parsed.Service != "eks" || !strings.HasPrefix(parsed.Resource, "cluster/") {
+		return "", "", "", errors.Errorf("invalid EKS cluster ARN %s", clusterARN)
+	}
+	return parsed.AccountID, parsed.Region, strings.TrimPrefix(parsed.Resource, "cluster/"), nil
+}
+
+// eksRestConfig returns the connection info of an EKS cluster, authenticated with IAM credentials of the cluster account
+func eksRestConfig(ctx context.Context, clusterARN string, creds *credentials.Credentials) (*restConfig, error) {
+	_, region, name, err := ParseEKSClusterARN(clusterARN)
+	if err != nil {
+		return nil, err
+	}
+	config := aws.NewConfig().WithCredentials(creds).WithRegion(region)
+	output, err := eksClientFunc(awsSession, config).DescribeClusterWithContext(ctx, &eks.DescribeClusterInput{
+		Name: aws.String(name),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe EKS cluster %s", clusterARN)
+	}
+	cluster := output.Cluster
+	if cluster == nil || cluster.Endpoint == nil || cluster.CertificateAuthority == nil {
+		return nil, errors.Errorf("EKS cluster %s is not active", clusterARN)
+	}
+	caData, err := base64.StdEncoding.DecodeString(aws.StringValue(cluster.CertificateAuthority.Data))
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid certificate authority of EKS cluster %s", clusterARN)
+	}
+
+	return &restConfig{
+		Server: *cluster.Endpoint,
+		CAData: caData,
+		Tokens: &eksTokenSource{
+			clusterName: name,
+			stsClient:   sts.New(awsSession, config.Copy().WithSTSRegionalEndpoint(endpoints.RegionalSTSEndpoint)),
+		},
+	}, nil
+}
+
+// tokenSource returns the bearer token of the API server requests
+type tokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// staticToken is a token read from a kubeconfig
+type staticToken string
+
+func (t staticToken) Token(context.Context) (string, error) {
+	return string(t), nil
+}
+
+// eksTokenSource builds EKS tokens from presigned STS GetCallerIdentity requests
+type eksTokenSource struct {
+	clusterName string
+	stsClient   *sts.STS
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// Token returns a valid EKS token, presigning a new one if the cached token expired
+func (s *eksTokenSource) Token(context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if s.token != "" && now.Before(s.expiry) {
+		return s.token, nil
+	}
+
+	req, _ := s.stsClient.GetCallerIdentityRequest(&sts.GetCallerIdentityInput{})
+	// The cluster name is signed, so the token can't be used with other clusters
+	req.HTTPRequest.Header.Add(eksClusterIDHeader, s.clusterName)
+	presignedURL, err := req.Presign(eksTokenPresignDelay)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to presign EKS token")
+	}
+	s.token = eksTokenPrefix + base64.RawURLEncoding.EncodeToString([]byte(presignedURL))
+	s.expiry = now.Add(eksTokenLifetime)
+	return s.token, nil
+}
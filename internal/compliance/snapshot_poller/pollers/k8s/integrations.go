@@ -0,0 +1,158 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+const (
+	sourceAPIFunctionName = "panther-source-api"
+	refreshInterval       = 2 * time.Minute
+)
+
+var (
+	// The IAM role assumed in the account of EKS clusters, same as the AWS scans
+	auditRoleName = os.Getenv("AUDIT_ROLE_NAME")
+
+	// Replaced by unit tests with mocks
+	lambdaClient         lambdaiface.LambdaAPI                 = lambda.New(awsSession)
+	secretsClient        secretsmanageriface.SecretsManagerAPI = secretsmanager.New(awsSession)
+	auditRoleCredentials                                       = assumeAuditRole
+
+	// Kubernetes integrations keyed on integrationID. The kubeconfigs are kept in Secrets Manager,
+	// so they are read along with the integrations instead of being sent in the scan requests.
+	integrations            = make(map[string]*models.SourceIntegration)
+	integrationsLastUpdated time.Time
+
+	// Clients keyed on integrationID, reused to avoid describing EKS clusters for every scan
+	clients = make(map[string]*cachedClient)
+)
+
+type cachedClient struct {
+	config models.K8sConfig
+	client *Client
+}
+
+func resetIntegrationCache() {
+	integrations = make(map[string]*models.SourceIntegration)
+	integrationsLastUpdated = time.Time{}
+}
+
+// getIntegration returns the k8s-scan integration with the given ID, or nil if it does not exist
+func getIntegration(integrationID string) (*models.SourceIntegration, error) {
+	if integrationsLastUpdated.Add(refreshInterval).After(time.Now()) {
+		zap.L().Debug("using cached integrations")
+		return integrations[integrationID], nil
+	}
+
+	zap.L().Debug("populating integration cache")
+	input := &models.LambdaInput{
+		ListIntegrations: &models.ListIntegrationsInput{
+			IntegrationType: aws.String(models.IntegrationTypeK8sScan),
+		},
+	}
+	var output []*models.SourceIntegration
+	if err := genericapi.Invoke(lambdaClient, sourceAPIFunctionName, input, &output); err != nil {
+		return nil, err
+	}
+
+	resetIntegrationCache()
+	for _, integration := range output {
+		if integration.K8sConfig == nil {
+			continue
+		}
+		// EKS clusters are read with the audit role, other clusters with a kubeconfig that
+		// the source-api never returns.
+		if integration.CredentialsSecretARN != "" {
+			secret, err := secretsClient.GetSecretValue(&secretsmanager.GetSecretValueInput{
+				SecretId: &integration.CredentialsSecretARN,
+			})
+			if err != nil {
+				// Scans of this integration fail until the kubeconfig can be read
+				zap.L().Error("failed to read kubeconfig",
+					zap.String("integrationId", integration.IntegrationID), zap.Error(err))
+				continue
+			}
+			integration.K8sConfig.Kubeconfig = aws.StringValue(secret.SecretString)
+		}
+		integrations[integration.IntegrationID] = integration
+	}
+	integrationsLastUpdated = time.Now()
+	return integrations[integrationID], nil
+}
+
+// getClient returns a client for the cluster of the integration
+func getClient(ctx context.Context, integration *models.SourceIntegration) (*Client, error) {
+	config := integration.K8sConfig
+	if cached, ok := clients[integration.IntegrationID]; ok && cached.config == *config {
+		return cached.client, nil
+	}
+
+	var creds *credentials.Credentials
+	if config.Kubeconfig == "" {
+		var err error
+		if creds, err = auditRoleCredentials(config.EKSClusterARN); err != nil {
+			return nil, err
+		}
+	}
+	client, err := NewClient(ctx, config, creds)
+	if err != nil {
+		return nil, err
+	}
+	clients[integration.IntegrationID] = &cachedClient{
+		config: *config,
+		client: client,
+	}
+	return client, nil
+}
+
+// assumeAuditRole returns the credentials of the audit role in the account of an EKS cluster
+func assumeAuditRole(clusterARN string) (*credentials.Credentials, error) {
+	if auditRoleName == "" {
+		return nil, errors.New("no audit role configured")
+	}
+	accountID, _, _, err := ParseEKSClusterARN(clusterARN)
+	if err != nil {
+		return nil, err
+	}
+	roleARN := fmt.Sprintf("arn:aws:iam::%s:role/%s", accountID, auditRoleName)
+	zap.L().Debug("assuming role", zap.String("roleArn", roleARN))
+	return stscreds.NewCredentials(
+		awsSession.Copy(aws.NewConfig().WithSTSRegionalEndpoint(endpoints.RegionalSTSEndpoint)),
+		roleARN,
+	), nil
+}
@@ -0,0 +1,141 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"encoding/base64"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// kubeconfig is the subset of the kubeconfig file format needed to connect to a cluster.
+//
+// Only embedded credentials are supported, the poller can't read files or run exec plugins.
+type kubeconfig struct {
+	CurrentContext string `yaml:"current-context"`
+	Clusters       []struct {
+		Name    string `yaml:"name"`
+		Cluster struct {
+			Server                   string `yaml:"server"`
+			CertificateAuthority     string `yaml:"certificate-authority"`
+			CertificateAuthorityData string `yaml:"certificate-authority-data"`
+			InsecureSkipTLSVerify    bool   `yaml:"insecure-skip-tls-verify"`
+		} `yaml:"cluster"`
+	} `yaml:"clusters"`
+	Contexts []struct {
+		Name    string `yaml:"name"`
+		Context struct {
+			Cluster string `yaml:"cluster"`
+			User    string `yaml:"user"`
+		} `yaml:"context"`
+	} `yaml:"contexts"`
+	Users []struct {
+		Name string `yaml:"name"`
+		User struct {
+			Token                 string      `yaml:"token"`
+			TokenFile             string      `yaml:"tokenFile"`
+			ClientCertificate     string      `yaml:"client-certificate"`
+			ClientCertificateData string      `yaml:"client-certificate-data"`
+			ClientKey             string      `yaml:"client-key"`
+			ClientKeyData         string      `yaml:"client-key-data"`
+			Exec                  interface{} `yaml:"exec"`
+			AuthProvider          interface{} `yaml:"auth-provider"`
+		} `yaml:"user"`
+	} `yaml:"users"`
+}
+
+// parseKubeconfig returns the connection info of the current context of a kubeconfig
+func parseKubeconfig(data string) (*restConfig, error) {
+	var config kubeconfig
+	if err := yaml.Unmarshal([]byte(data), &config); err != nil {
+		return nil, errors.Wrap(err, "invalid kubeconfig")
+	}
+
+	contextName := config.CurrentContext
+	if contextName == "" && len(config.Contexts) == 1 {
+		contextName = config.Contexts[0].Name
+	}
+	var clusterName, userName string
+	found := false
+	for _, entry := range config.Contexts {
+		if entry.Name == contextName {
+			clusterName, userName, found = entry.Context.Cluster, entry.Context.User, true
+			break
+		}
+	}
+	if !found {
+		return nil, errors.Errorf("kubeconfig context %q not found", contextName)
+	}
+
+	result := &restConfig{}
+	found = false
+	for _, entry := range config.Clusters {
+		if entry.Name != clusterName {
+			continue
+		}
+		cluster := entry.Cluster
+		if cluster.CertificateAuthority != "" {
+			return nil, errors.New("kubeconfig certificate-authority files are not supported, use certificate-authority-data")
+		}
+		caData, err := base64.StdEncoding.DecodeString(cluster.CertificateAuthorityData)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid kubeconfig certificate-authority-data")
+		}
+		result.Server = cluster.Server
+		result.CAData = caData
+		result.Insecure = cluster.InsecureSkipTLSVerify
+		found = true
+		break
+	}
+	if !found || result.Server == "" {
+		return nil, errors.Errorf("kubeconfig cluster %q not found", clusterName)
+	}
+
+	for _, entry := range config.Users {
+		if entry.Name != userName {
+			continue
+		}
+		user := entry.User
+		switch {
+		case user.Exec != nil || user.AuthProvider != nil:
+			return nil, errors.New("kubeconfig exec and auth-provider credentials are not supported, use a token")
+		case user.TokenFile != "" || user.ClientCertificate != "" || user.ClientKey != "":
+			return nil, errors.New("kubeconfig credential files are not supported, use embedded credentials")
+		}
+		if user.Token != "" {
+			result.Tokens = staticToken(user.Token)
+		}
+		certData, err := base64.StdEncoding.DecodeString(user.ClientCertificateData)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid kubeconfig client-certificate-data")
+		}
+		keyData, err := base64.StdEncoding.DecodeString(user.ClientKeyData)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid kubeconfig client-key-data")
+		}
+		result.ClientCertData = certData
+		result.ClientKeyData = keyData
+		break
+	}
+	if result.Tokens == nil && len(result.ClientCertData) == 0 {
+		return nil, errors.Errorf("kubeconfig user %q has no token or client certificate", userName)
+	}
+	return result, nil
+}
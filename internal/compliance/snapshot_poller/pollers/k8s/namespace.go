@@ -0,0 +1,81 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	resourcesapimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	k8smodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/k8s"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
+)
+
+// PollNamespaces gathers information on each namespace of a cluster, including the names of its network policies.
+func PollNamespaces(ctx context.Context, client *Client, input *k8smodels.ResourcePollerInput) (
+	[]resourcesapimodels.AddResourceEntry, *string, error) {
+
+	items, marker, err := listObjectsPage(ctx, client, input, k8smodels.NamespaceSchema)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(items) == 0 {
+		return nil, marker, nil
+	}
+
+	// Network policies are listed across all namespaces with each page, so policies can check for
+	// namespaces without any
+	info, _ := resourcetypes.Lookup(k8smodels.NetworkPolicySchema)
+	policyItems, err := client.ListAll(ctx, info.APIPath)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to list network policies")
+	}
+	networkPolicies := make(map[string][]*string)
+	for _, item := range policyItems {
+		policy := &k8smodels.NetworkPolicy{}
+		meta, err := decodeObject(input, item, k8smodels.NetworkPolicySchema, policy, &policy.GenericK8sResource, &policy.GenericResource)
+		if err != nil {
+			zap.L().Error("skipping invalid network policy", zap.Error(err))
+			continue
+		}
+		networkPolicies[meta.Namespace] = append(networkPolicies[meta.Namespace], aws.String(meta.Name))
+	}
+
+	resources := make([]resourcesapimodels.AddResourceEntry, 0, len(items))
+	for _, item := range items {
+		namespace := &k8smodels.Namespace{}
+		meta, err := decodeObject(input, item, k8smodels.NamespaceSchema, namespace, &namespace.GenericK8sResource, &namespace.GenericResource)
+		if err != nil {
+			zap.L().Error("skipping invalid namespace", zap.Error(err))
+			continue
+		}
+		if input.ShouldIgnoreResource(*namespace.ResourceID) {
+			continue
+		}
+		namespace.NetworkPolicies = networkPolicies[meta.Name]
+		if namespace.NetworkPolicies == nil {
+			namespace.NetworkPolicies = []*string{}
+		}
+		resources = append(resources, newResourceEntry(input, *namespace.ResourceID, k8smodels.NamespaceSchema, namespace))
+	}
+	return resources, marker, nil
+}
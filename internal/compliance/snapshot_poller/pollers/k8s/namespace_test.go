@@ -0,0 +1,67 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	k8smodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/k8s"
+)
+
+func TestPollNamespaces(t *testing.T) {
+	api := newFakeAPI(t)
+
+	resources, marker, err := PollNamespaces(context.Background(), api.newClient(t), testPollerInput())
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	require.Len(t, resources, 2)
+	assert.Equal(t, []string{"/api/v1/namespaces", "/apis/networking.k8s.io/v1/networkpolicies"}, api.listRequests)
+
+	defaultNamespace := resources[0].Attributes.(*k8smodels.Namespace)
+	assert.Equal(t, "k8s://prod/namespaces/default", resources[0].ID)
+	assert.Equal(t, aws.String("default"), defaultNamespace.Name)
+	assert.Nil(t, defaultNamespace.Namespace)
+	assert.Equal(t, aws.String(testClusterName), defaultNamespace.ClusterName)
+	assert.Equal(t, aws.String("5b1f8a77-2f6c-4e43-9d1b-0e6d4c2bd7b1"), defaultNamespace.ID)
+	assert.Equal(t, aws.String("Active"), defaultNamespace.Status.Phase)
+	assert.NotNil(t, defaultNamespace.TimeCreated)
+	// Namespaces without network policies have an empty list
+	assert.Equal(t, []*string{}, defaultNamespace.NetworkPolicies)
+
+	web := resources[1].Attributes.(*k8smodels.Namespace)
+	assert.Equal(t, aws.String("frontend"), web.Labels["team"])
+	assert.Equal(t, []*string{aws.String("default-deny-ingress"), aws.String("allow-frontend")}, web.NetworkPolicies)
+}
+
+func TestPollNamespacesIgnored(t *testing.T) {
+	api := newFakeAPI(t)
+	input := testPollerInput()
+	input.ResourceRegexIgnoreList = []string{"k8s://prod/namespaces/default"}
+	require.NoError(t, input.CompileRegex())
+
+	resources, _, err := PollNamespaces(context.Background(), api.newClient(t), input)
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, "k8s://prod/namespaces/web", resources[0].ID)
+}
@@ -0,0 +1,53 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	resourcesapimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	k8smodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/k8s"
+)
+
+// PollNetworkPolicies gathers information on each network policy of a cluster.
+func PollNetworkPolicies(ctx context.Context, client *Client, input *k8smodels.ResourcePollerInput) (
+	[]resourcesapimodels.AddResourceEntry, *string, error) {
+
+	items, marker, err := listObjectsPage(ctx, client, input, k8smodels.NetworkPolicySchema)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resources := make([]resourcesapimodels.AddResourceEntry, 0, len(items))
+	for _, item := range items {
+		policy := &k8smodels.NetworkPolicy{}
+		_, err := decodeObject(input, item, k8smodels.NetworkPolicySchema, policy, &policy.GenericK8sResource, &policy.GenericResource)
+		if err != nil {
+			zap.L().Error("skipping invalid network policy", zap.Error(err))
+			continue
+		}
+		if input.ShouldIgnoreResource(*policy.ResourceID) {
+			continue
+		}
+		resources = append(resources, newResourceEntry(input, *policy.ResourceID, k8smodels.NetworkPolicySchema, policy))
+	}
+	return resources, marker, nil
+}
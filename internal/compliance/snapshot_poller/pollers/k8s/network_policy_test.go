@@ -0,0 +1,53 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	k8smodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/k8s"
+)
+
+func TestPollNetworkPolicies(t *testing.T) {
+	api := newFakeAPI(t)
+
+	resources, marker, err := PollNetworkPolicies(context.Background(), api.newClient(t), testPollerInput())
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	require.Len(t, resources, 2)
+	assert.Equal(t, "k8s://prod/namespaces/web/networkpolicies/default-deny-ingress", resources[0].ID)
+
+	denyAll := resources[0].Attributes.(*k8smodels.NetworkPolicy)
+	assert.Empty(t, denyAll.Spec.PodSelector.MatchLabels)
+	assert.Equal(t, []*string{aws.String("Ingress")}, denyAll.Spec.PolicyTypes)
+	assert.Empty(t, denyAll.Spec.Ingress)
+
+	policy := resources[1].Attributes.(*k8smodels.NetworkPolicy)
+	rule := policy.Spec.Ingress[0]
+	assert.Equal(t, aws.String("10.0.0.0/16"), rule.From[0].IPBlock.CIDR)
+	assert.Equal(t, aws.String("frontend"), rule.From[1].NamespaceSelector.MatchLabels["team"])
+	// Ports are either numbers or names
+	assert.EqualValues(t, 8080, rule.Ports[0].Port)
+	assert.Equal(t, "https", rule.Ports[1].Port)
+}
@@ -0,0 +1,54 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	resourcesapimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	k8smodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/k8s"
+)
+
+// PollPods gathers information on each pod of a cluster.
+func PollPods(ctx context.Context, client *Client, input *k8smodels.ResourcePollerInput) (
+	[]resourcesapimodels.AddResourceEntry, *string, error) {
+
+	items, marker, err := listObjectsPage(ctx, client, input, k8smodels.PodSchema)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resources := make([]resourcesapimodels.AddResourceEntry, 0, len(items))
+	for _, item := range items {
+		pod := &k8smodels.Pod{}
+		meta, err := decodeObject(input, item, k8smodels.PodSchema, pod, &pod.GenericK8sResource, &pod.GenericResource)
+		if err != nil {
+			zap.L().Error("skipping invalid pod", zap.Error(err))
+			continue
+		}
+		if input.ShouldIgnoreResource(*pod.ResourceID) {
+			continue
+		}
+		pod.OwnerReferences = meta.OwnerReferences
+		resources = append(resources, newResourceEntry(input, *pod.ResourceID, k8smodels.PodSchema, pod))
+	}
+	return resources, marker, nil
+}
@@ -0,0 +1,58 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	k8smodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/k8s"
+)
+
+func TestPollPods(t *testing.T) {
+	api := newFakeAPI(t)
+
+	resources, marker, err := PollPods(context.Background(), api.newClient(t), testPollerInput())
+	require.NoError(t, err)
+	// The scan continues with the next page
+	require.NotNil(t, marker)
+	assert.Equal(t, "eyJ2IjoibWV0YS5rOHMuaW8vdjEiLCJydiI6MTg0NDkyMSwic3RhcnQiOiJ3ZWIvZnJvbnRlbmQtN2Q0Yjk1Y2Y4Zi14NGs5cFx1MDAwMCJ9", *marker)
+	require.Len(t, resources, 1)
+	assert.Equal(t, "k8s://prod/namespaces/web/pods/frontend-7d4b95cf8f-x4k9p", resources[0].ID)
+
+	pod := resources[0].Attributes.(*k8smodels.Pod)
+	assert.Equal(t, aws.String("web"), pod.Namespace)
+	assert.Equal(t, aws.String("ReplicaSet"), pod.OwnerReferences[0].Kind)
+	assert.Equal(t, aws.Bool(true), pod.Spec.HostNetwork)
+	assert.Equal(t, aws.String("frontend"), pod.Spec.ServiceAccountName)
+	assert.Equal(t, aws.String("/var/run/docker.sock"), pod.Spec.Volumes[0].HostPath.Path)
+	assert.Equal(t, aws.String("frontend-token-8kq2v"), pod.Spec.Volumes[1].Secret.SecretName)
+
+	container := pod.Spec.Containers[0]
+	assert.Equal(t, aws.String("nginx:1.19"), container.Image)
+	assert.Equal(t, aws.Bool(true), container.SecurityContext.Privileged)
+	assert.Equal(t, []*string{aws.String("NET_ADMIN")}, container.SecurityContext.Capabilities.Add)
+	assert.Equal(t, aws.String("128Mi"), container.Resources.Limits["memory"])
+	assert.Equal(t, aws.Int64(80), container.Ports[0].ContainerPort)
+	assert.Equal(t, aws.String("Running"), pod.Status.Phase)
+}
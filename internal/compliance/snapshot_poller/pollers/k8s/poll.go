@@ -0,0 +1,306 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	resourcesapimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	k8smodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/k8s"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// ResourcePoller lists one page of the objects of a Kubernetes resource type
+type ResourcePoller func(ctx context.Context, client *Client, input *k8smodels.ResourcePollerInput) (
+	resources []resourcesapimodels.AddResourceEntry, marker *string, err error)
+
+// resourcePoller is a simple struct to be used only for invoking the ResourcePollers in order.
+type resourcePoller struct {
+	description    string
+	resourcePoller ResourcePoller
+}
+
+const (
+	integrationType = "k8s"
+	// Prefix of the resource IDs, followed by the cluster name and the object path
+	resourceIDPrefix = "k8s://"
+)
+
+var (
+	// The number of objects to request per page, a new scan is queued for the next page
+	defaultPageSize    = 100
+	pageRequeueDelayer = rand.New(rand.NewSource(time.Now().UnixNano())) // nolint:gosec
+
+	// ServicePollers maps a resource type to its Poll function.
+	//
+	// Resource types are defined in the registry in models/resourcetypes/k8s.go, the tests make sure
+	// every registered type has a poller here.
+	ServicePollers = map[string]resourcePoller{
+		k8smodels.ClusterRoleSchema:        {"ClusterRole", PollClusterRoles},
+		k8smodels.ClusterRoleBindingSchema: {"ClusterRoleBinding", PollClusterRoleBindings},
+		k8smodels.DeploymentSchema:         {"Deployment", PollDeployments},
+		k8smodels.NamespaceSchema:          {"Namespace", PollNamespaces},
+		k8smodels.NetworkPolicySchema:      {"NetworkPolicy", PollNetworkPolicies},
+		k8smodels.PodSchema:                {"Pod", PollPods},
+		k8smodels.RoleSchema:               {"Role", PollRoles},
+		k8smodels.RoleBindingSchema:        {"RoleBinding", PollRoleBindings},
+		k8smodels.ServiceAccountSchema:     {"ServiceAccount", PollServiceAccounts},
+	}
+)
+
+// Poll coordinates the scan of a Kubernetes resource type for compliance monitoring.
+//
+// Only service scans are supported, there are no change events that trigger single resource scans in clusters.
+func Poll(ctx context.Context, scanRequest *pollermodels.ScanEntry) (
+	generatedEvents []resourcesapimodels.AddResourceEntry, err error) {
+
+	// These errors cannot be retried so we don't return them
+	if scanRequest.IntegrationID == nil || scanRequest.ClusterName == nil || scanRequest.ResourceType == nil {
+		zap.L().Error("Invalid scan request input - integrationID, clusterName and resourceType must be specified",
+			zap.Any("input", scanRequest))
+		return nil, nil
+	}
+	if scanRequest.ResourceID != nil {
+		zap.L().Error("single resource scans are not supported for Kubernetes integrations",
+			zap.String("resourceID", *scanRequest.ResourceID))
+		return nil, nil
+	}
+
+	// Check if integration is disabled
+	if scanRequest.Enabled != nil && !*scanRequest.Enabled {
+		zap.L().Info("source integration disabled",
+			zap.String("integration id", *scanRequest.IntegrationID))
+		return nil, nil
+	}
+
+	// Check if resource type is filtered
+	for _, resourceType := range scanRequest.ResourceTypeIgnoreList {
+		if resourceType == *scanRequest.ResourceType {
+			zap.L().Info("resource type filtered", zap.String("resource type", resourceType))
+			return nil, nil
+		}
+	}
+
+	poller, ok := ServicePollers[*scanRequest.ResourceType]
+	if !ok {
+		return nil, errors.Errorf("invalid Kubernetes resource type '%s' scan requested", *scanRequest.ResourceType)
+	}
+
+	integration, err := getIntegration(*scanRequest.IntegrationID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to lookup Kubernetes integration")
+	}
+	if integration == nil || integration.K8sConfig == nil {
+		zap.L().Warn("Kubernetes integration not found, skipping scan",
+			zap.String("integration id", *scanRequest.IntegrationID))
+		return nil, nil
+	}
+	if integration.K8sConfig.ClusterName != *scanRequest.ClusterName {
+		zap.L().Warn("Kubernetes integration cluster changed, skipping scan",
+			zap.String("integration id", *scanRequest.IntegrationID),
+			zap.String("cluster name", *scanRequest.ClusterName))
+		return nil, nil
+	}
+	client, err := getClient(ctx, integration)
+	if err != nil {
+		// The cluster may be temporarily unreachable, the scan is retried
+		return nil, errors.Wrapf(err, "failed to connect to cluster %s", *scanRequest.ClusterName)
+	}
+
+	pollerInput := &k8smodels.ResourcePollerInput{
+		IntegrationID:           scanRequest.IntegrationID,
+		ClusterName:             scanRequest.ClusterName,
+		Timestamp:               aws.Time(utils.TimeNowFunc()),
+		NextPageToken:           scanRequest.NextPageToken,
+		ResourceTypeIgnoreList:  scanRequest.ResourceTypeIgnoreList,
+		ResourceRegexIgnoreList: scanRequest.ResourceRegexIgnoreList,
+	}
+	if err = pollerInput.CompileRegex(); err != nil {
+		zap.L().Error("unable to compile passed regex",
+			zap.Any("resource regex ignore list", scanRequest.ResourceRegexIgnoreList))
+		return nil, err
+	}
+
+	return serviceScan(ctx, poller, client, pollerInput, scanRequest)
+}
+
+func serviceScan(
+	ctx context.Context,
+	poller resourcePoller,
+	client *Client,
+	pollerInput *k8smodels.ResourcePollerInput,
+	scanRequest *pollermodels.ScanEntry,
+) (generatedEvents []resourcesapimodels.AddResourceEntry, err error) {
+
+	var marker *string
+	generatedEvents, marker, err = poller.resourcePoller(ctx, client, pollerInput)
+	if err != nil {
+		zap.L().Info(
+			"an error occurred while polling",
+			zap.String("resourcePoller", poller.description),
+			zap.String("errorMessage", err.Error()),
+		)
+		return nil, err
+	}
+
+	zap.L().Info(
+		"resources generated",
+		zap.Int("numResources", len(generatedEvents)),
+		zap.String("resourcePoller", poller.description),
+	)
+
+	// Re-queue a scan starting from the next page
+	if marker != nil {
+		scanRequest.NextPageToken = marker
+		err = utils.Requeue(pollermodels.ScanMsg{
+			Entries: []*pollermodels.ScanEntry{
+				scanRequest,
+			},
+		}, int64(pageRequeueDelayer.Intn(30)+1)) // Delay between 1 & 30 seconds to spread out page scans
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return generatedEvents, nil
+}
+
+// CheckCredentials verifies that the cluster of a Kubernetes integration can be read.
+//
+// The AWS credentials are only used for EKS clusters, they need to be IAM credentials in the cluster account.
+func CheckCredentials(ctx context.Context, config *models.K8sConfig, creds *credentials.Credentials) error {
+	client, err := NewClient(ctx, config, creds)
+	if err != nil {
+		return err
+	}
+	info, _ := resourcetypes.Lookup(k8smodels.NamespaceSchema)
+	_, err = client.List(ctx, info.APIPath, "", 1)
+	return err
+}
+
+// listObjectsPage lists the page of objects of a resource type requested by the poller input
+func listObjectsPage(ctx context.Context, client *Client, input *k8smodels.ResourcePollerInput, resourceType string) (
+	items []jsoniter.RawMessage, marker *string, err error) {
+
+	info, ok := resourcetypes.Lookup(resourceType)
+	if !ok {
+		return nil, nil, errors.Errorf("unknown resource type %s", resourceType)
+	}
+	continueToken := aws.StringValue(input.NextPageToken)
+	page, err := client.List(ctx, info.APIPath, continueToken, defaultPageSize)
+	if err != nil {
+		var apiErr *APIError
+		if continueToken != "" && errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusGone {
+			// Continue tokens expire after a few minutes, the remaining objects are read by the next scan
+			zap.L().Warn("continue token expired, skipping remaining pages",
+				zap.String("resourceType", resourceType))
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+	if page.Metadata.Continue != "" {
+		marker = aws.String(page.Metadata.Continue)
+	}
+	return page.Items, marker, nil
+}
+
+// objectMeta is the metadata common to all Kubernetes objects
+type objectMeta struct {
+	Name              string                      `json:"name"`
+	Namespace         string                      `json:"namespace"`
+	UID               string                      `json:"uid"`
+	CreationTimestamp string                      `json:"creationTimestamp"`
+	Labels            map[string]*string          `json:"labels"`
+	Annotations       map[string]*string          `json:"annotations"`
+	OwnerReferences   []*k8smodels.OwnerReference `json:"ownerReferences"`
+}
+
+// decodeObject decodes a Kubernetes object into a snapshot and fills in the generic resource fields
+func decodeObject(
+	input *k8smodels.ResourcePollerInput,
+	item jsoniter.RawMessage,
+	resourceType string,
+	snapshot interface{},
+	k8sResource *k8smodels.GenericK8sResource,
+	resource *k8smodels.GenericResource,
+) (*objectMeta, error) {
+
+	var object struct {
+		Metadata objectMeta `json:"metadata"`
+	}
+	if err := jsoniter.Unmarshal(item, &object); err != nil {
+		return nil, errors.Wrap(err, "failed to decode object metadata")
+	}
+	meta := &object.Metadata
+	if meta.Name == "" {
+		return nil, errors.New("object has no name")
+	}
+	if err := jsoniter.Unmarshal(item, snapshot); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode object %s", meta.Name)
+	}
+
+	resource.ResourceID = aws.String(resourceID(*input.ClusterName, resourceType, meta.Namespace, meta.Name))
+	resource.ResourceType = aws.String(resourceType)
+	if created, err := time.Parse(time.RFC3339, meta.CreationTimestamp); err == nil {
+		resource.TimeCreated = &created
+	}
+	k8sResource.ClusterName = input.ClusterName
+	k8sResource.ID = aws.String(meta.UID)
+	k8sResource.Name = aws.String(meta.Name)
+	if meta.Namespace != "" {
+		k8sResource.Namespace = aws.String(meta.Namespace)
+	}
+	k8sResource.Labels = meta.Labels
+	k8sResource.Annotations = meta.Annotations
+	return meta, nil
+}
+
+// resourceID builds the ID of an object from its path in the API, e.g. k8s://prod/namespaces/default/pods/web
+func resourceID(clusterName, resourceType, namespace, name string) string {
+	info, _ := resourcetypes.Lookup(resourceType)
+	resource := path.Base(info.APIPath)
+	if info.Namespaced {
+		return resourceIDPrefix + path.Join(clusterName, "namespaces", namespace, resource, name)
+	}
+	return resourceIDPrefix + path.Join(clusterName, resource, name)
+}
+
+// newResourceEntry builds the resources-api entry of a snapshot
+func newResourceEntry(input *k8smodels.ResourcePollerInput, resourceID, resourceType string, snapshot interface{}) resourcesapimodels.AddResourceEntry {
+	return resourcesapimodels.AddResourceEntry{
+		Attributes:      snapshot,
+		ID:              resourceID,
+		IntegrationID:   *input.IntegrationID,
+		IntegrationType: integrationType,
+		Type:            resourceType,
+	}
+}
@@ -0,0 +1,106 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	resourcesapimodels "github.com/panther-labs/panther/api/lambda/resources/models"
+	k8smodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/k8s"
+)
+
+// PollRoles gathers information on each namespaced RBAC role of a cluster.
+func PollRoles(ctx context.Context, client *Client, input *k8smodels.ResourcePollerInput) (
+	[]resourcesapimodels.AddResourceEntry, *string, error) {
+
+	return pollRoles(ctx, client, input, k8smodels.RoleSchema)
+}
+
+// PollClusterRoles gathers information on each RBAC cluster role of a cluster.
+func PollClusterRoles(ctx context.Context, client *Client, input *k8smodels.ResourcePollerInput) (
+	[]resourcesapimodels.AddResourceEntry, *string, error) {
+
+	return pollRoles(ctx, client, input, k8smodels.ClusterRoleSchema)
+}
+
+// PollRoleBindings gathers information on each namespaced RBAC role binding of a cluster.
+func PollRoleBindings(ctx context.Context, client *Client, input *k8smodels.ResourcePollerInput) (
+	[]resourcesapimodels.AddResourceEntry, *string, error) {
+
+	return pollRoleBindings(ctx, client, input, k8smodels.RoleBindingSchema)
+}
+
+// PollClusterRoleBindings gathers information on each RBAC cluster role binding of a cluster.
+func PollClusterRoleBindings(ctx context.Context, client *Client, input *k8smodels.ResourcePollerInput) (
+	[]resourcesapimodels.AddResourceEntry, *string, error) {
+
+	return pollRoleBindings(ctx, client, input, k8smodels.ClusterRoleBindingSchema)
+}
+
+// Roles and cluster roles have the same fields
+func pollRoles(ctx context.Context, client *Client, input *k8smodels.ResourcePollerInput, resourceType string) (
+	[]resourcesapimodels.AddResourceEntry, *string, error) {
+
+	items, marker, err := listObjectsPage(ctx, client, input, resourceType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resources := make([]resourcesapimodels.AddResourceEntry, 0, len(items))
+	for _, item := range items {
+		role := &k8smodels.Role{}
+		_, err := decodeObject(input, item, resourceType, role, &role.GenericK8sResource, &role.GenericResource)
+		if err != nil {
+			zap.L().Error("skipping invalid role", zap.Error(err), zap.String("resourceType", resourceType))
+			continue
+		}
+		if input.ShouldIgnoreResource(*role.ResourceID) {
+			continue
+		}
+		resources = append(resources, newResourceEntry(input, *role.ResourceID, resourceType, role))
+	}
+	return resources, marker, nil
+}
+
+// Role bindings and cluster role bindings have the same fields
+func pollRoleBindings(ctx context.Context, client *Client, input *k8smodels.ResourcePollerInput, resourceType string) (
+	[]resourcesapimodels.AddResourceEntry, *string, error) {
+
+	items, marker, err := listObjectsPage(ctx, client, input, resourceType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resources := make([]resourcesapimodels.AddResourceEntry, 0, len(items))
+	for _, item := range items {
+		binding := &k8smodels.RoleBinding{}
+		_, err := decodeObject(input, item, resourceType, binding, &binding.GenericK8sResource, &binding.GenericResource)
+		if err != nil {
+			zap.L().Error("skipping invalid role binding", zap.Error(err), zap.String("resourceType", resourceType))
+			continue
+		}
+		if input.ShouldIgnoreResource(*binding.ResourceID) {
+			continue
+		}
+		resources = append(resources, newResourceEntry(input, *binding.ResourceID, resourceType, binding))
+	}
+	return resources, marker, nil
+}
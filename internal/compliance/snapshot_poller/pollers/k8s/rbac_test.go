@@ -0,0 +1,95 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	k8smodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/k8s"
+)
+
+func TestPollRoles(t *testing.T) {
+	api := newFakeAPI(t)
+
+	resources, marker, err := PollRoles(context.Background(), api.newClient(t), testPollerInput())
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	require.Len(t, resources, 1)
+	assert.Equal(t, "k8s://prod/namespaces/web/roles/secret-reader", resources[0].ID)
+	assert.Equal(t, k8smodels.RoleSchema, resources[0].Type)
+
+	role := resources[0].Attributes.(*k8smodels.Role)
+	assert.Equal(t, aws.String("web"), role.Namespace)
+	assert.Equal(t, []*string{aws.String("secrets")}, role.Rules[0].Resources)
+	assert.Equal(t, []*string{aws.String("get"), aws.String("list"), aws.String("watch")}, role.Rules[0].Verbs)
+}
+
+func TestPollClusterRoles(t *testing.T) {
+	api := newFakeAPI(t)
+
+	resources, marker, err := PollClusterRoles(context.Background(), api.newClient(t), testPollerInput())
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	require.Len(t, resources, 2)
+	assert.Equal(t, "k8s://prod/clusterroles/cluster-admin", resources[0].ID)
+	assert.Equal(t, k8smodels.ClusterRoleSchema, resources[0].Type)
+
+	admin := resources[0].Attributes.(*k8smodels.Role)
+	assert.Nil(t, admin.Namespace)
+	assert.Equal(t, []*string{aws.String("*")}, admin.Rules[0].Verbs)
+	assert.Equal(t, []*string{aws.String("*")}, admin.Rules[1].NonResourceURLs)
+	assert.Nil(t, admin.AggregationRule)
+
+	aggregated := resources[1].Attributes.(*k8smodels.Role)
+	assert.Equal(t, aws.String("true"),
+		aggregated.AggregationRule.ClusterRoleSelectors[0].MatchLabels["rbac.example.com/aggregate-to-monitoring"])
+}
+
+func TestPollRoleBindings(t *testing.T) {
+	api := newFakeAPI(t)
+
+	resources, _, err := PollRoleBindings(context.Background(), api.newClient(t), testPollerInput())
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, "k8s://prod/namespaces/web/rolebindings/read-secrets", resources[0].ID)
+
+	binding := resources[0].Attributes.(*k8smodels.RoleBinding)
+	assert.Equal(t, aws.String("Role"), binding.RoleRef.Kind)
+	assert.Equal(t, aws.String("secret-reader"), binding.RoleRef.Name)
+	assert.Equal(t, aws.String("ServiceAccount"), binding.Subjects[0].Kind)
+	assert.Equal(t, aws.String("web"), binding.Subjects[0].Namespace)
+}
+
+func TestPollClusterRoleBindings(t *testing.T) {
+	api := newFakeAPI(t)
+
+	resources, _, err := PollClusterRoleBindings(context.Background(), api.newClient(t), testPollerInput())
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, k8smodels.ClusterRoleBindingSchema, resources[0].Type)
+
+	binding := resources[0].Attributes.(*k8smodels.RoleBinding)
+	assert.Equal(t, aws.String("cluster-admin"), binding.RoleRef.Name)
+	assert.Equal(t, aws.String("system:authenticated"), binding.Subjects[0].Name)
+}
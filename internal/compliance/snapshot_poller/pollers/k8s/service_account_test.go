@@ -0,0 +1,46 @@
+package k8s
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	k8smodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/k8s"
+)
+
+func TestPollServiceAccounts(t *testing.T) {
+	api := newFakeAPI(t)
+
+	resources, marker, err := PollServiceAccounts(context.Background(), api.newClient(t), testPollerInput())
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+	require.Len(t, resources, 1)
+	assert.Equal(t, "k8s://prod/namespaces/web/serviceaccounts/frontend", resources[0].ID)
+
+	account := resources[0].Attributes.(*k8smodels.ServiceAccount)
+	assert.Equal(t, aws.String("arn:aws:iam::123456789012:role/frontend"), account.Annotations["eks.amazonaws.com/role-arn"])
+	assert.Nil(t, account.AutomountServiceAccountToken)
+	assert.Equal(t, aws.String("frontend-token-8kq2v"), account.Secrets[0].Name)
+	assert.Equal(t, aws.String("registry-credentials"), account.ImagePullSecrets[0].Name)
+}
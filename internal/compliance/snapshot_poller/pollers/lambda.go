@@ -23,6 +23,7 @@ import (
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambdacontext"
+	"github.com/aws/aws-sdk-go/aws"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -30,6 +31,8 @@ import (
 	api "github.com/panther-labs/panther/api/lambda/resources/models"
 	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
 	pollers "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws"
+	gcppollers "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/gcp"
+	k8spollers "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/k8s"
 	"github.com/panther-labs/panther/pkg/lambdalogger"
 	"github.com/panther-labs/panther/pkg/oplog"
 )
@@ -86,12 +89,26 @@ func Handle(ctx context.Context, event events.SQSEvent) (err error) {
 		}
 
 		for _, entry := range scanRequest.Entries {
+			integrationType := aws.StringValue(entry.IntegrationType)
+			if integrationType == "" {
+				// Scan requests queued before GCP and Kubernetes support don't set the integration type
+				integrationType = "aws"
+			}
 			zap.L().Debug("starting poller",
 				zap.Any("sqsEntry", entry),
 				zap.Int("messageNumber", indx),
-				zap.String("integrationType", "aws"))
-
-			resources, pollErr := pollers.Poll(entry)
+				zap.String("integrationType", integrationType))
+
+			var resources []api.AddResourceEntry
+			var pollErr error
+			switch integrationType {
+			case "gcp":
+				resources, pollErr = gcppollers.Poll(ctx, entry)
+			case "k8s":
+				resources, pollErr = k8spollers.Poll(ctx, entry)
+			default:
+				resources, pollErr = pollers.Poll(entry)
+			}
 			if pollErr != nil {
 				operation.LogError(errors.Wrap(pollErr, "poll failed"), zap.Any("sqsEntry", entry))
 				return pollErr
@@ -102,7 +119,7 @@ func Handle(ctx context.Context, event events.SQSEvent) (err error) {
 				zap.L().Debug("total resources generated",
 					zap.Int("messageNumber", indx),
 					zap.Int("numResources", len(resources)),
-					zap.String("integrationType", "aws"),
+					zap.String("integrationType", integrationType),
 				)
 
 				for _, batch := range batchResources(resources) {
@@ -39,6 +39,8 @@ import (
 
 	resourcesapi "github.com/panther-labs/panther/api/lambda/resources/models"
 	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+	k8smodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/k8s"
 	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
 	pollers "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws"
 	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
@@ -193,6 +195,64 @@ func TestPollSourceNotEnabled(t *testing.T) {
 	assert.Equal(t, expected, logs[:1])
 }
 
+func TestPollGCPSourceNotEnabled(t *testing.T) {
+	// GCP scan requests have no AWS account, they would fail in the AWS poller
+	testPollSourceNotEnabled(t, &pollermodels.ScanEntry{
+		IntegrationType: aws.String("gcp"),
+		IntegrationID:   &testIntegrationID,
+		ProjectID:       aws.String("panther-test"),
+		ResourceType:    aws.String(gcpmodels.StorageBucketSchema),
+		Enabled:         aws.Bool(false),
+	})
+}
+
+func TestPollK8sSourceNotEnabled(t *testing.T) {
+	testPollSourceNotEnabled(t, &pollermodels.ScanEntry{
+		IntegrationType: aws.String("k8s"),
+		IntegrationID:   &testIntegrationID,
+		ClusterName:     aws.String("prod"),
+		ResourceType:    aws.String(k8smodels.PodSchema),
+		Enabled:         aws.Bool(false),
+	})
+}
+
+func testPollSourceNotEnabled(t *testing.T, entry *pollermodels.ScanEntry) {
+	loggerSetupFunc = setupTestLogger
+	logger := mockLogger(zapcore.InfoLevel)
+	mockResourceClient := &gatewayapi.MockClient{}
+	apiClient = mockResourceClient
+
+	testIntegrations := &pollermodels.ScanMsg{
+		Entries: []*pollermodels.ScanEntry{entry},
+	}
+	testIntegrationStr, err := jsoniter.MarshalToString(testIntegrations)
+	require.NoError(t, err)
+
+	sampleEvent := events.SQSEvent{
+		Records: []events.SQSMessage{
+			{
+				AWSRegion: "us-west-2",
+				MessageId: "702a0aba-ab1f-11e8-b09c-f218981400a1",
+				Body:      testIntegrationStr,
+			},
+		},
+	}
+	require.NoError(t, Handle(testContext(), sampleEvent))
+
+	mockResourceClient.AssertExpectations(t)
+	expected := []observer.LoggedEntry{
+		{
+			Entry: zapcore.Entry{Level: zapcore.InfoLevel, Message: "source integration disabled"},
+			Context: []zapcore.Field{
+				zap.String("integration id", "0aab70c6-da66-4bb9-a83c-bbe8f5717fde"),
+			},
+		},
+	}
+	logs := logger.AllUntimed()
+	require.Len(t, logs, 2)
+	assert.Equal(t, expected, logs[:1])
+}
+
 func TestPollRegionIgnored(t *testing.T) {
 	loggerSetupFunc = setupTestLogger
 	logger := mockLogger(zapcore.InfoLevel)
@@ -433,7 +493,7 @@ func TestHandler(t *testing.T) {
 			Entry: zapcore.Entry{Level: zapcore.InfoLevel, Message: "resources generated"},
 			Context: []zapcore.Field{
 				zap.Int64("numResources", 2),
-				zap.String("resourcePoller", "KMSKey"),
+				zap.String("resourceType", "AWS.KMS.Key"),
 			},
 		},
 	}
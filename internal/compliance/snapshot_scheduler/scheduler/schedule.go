@@ -33,6 +33,13 @@ import (
 
 const sourceAPIFunctionName = "panther-source-api"
 
+// The types of the integrations with scheduled scans
+var scanIntegrationTypes = []string{
+	models.IntegrationTypeAWSScan,
+	models.IntegrationTypeGCPScan,
+	models.IntegrationTypeK8sScan,
+}
+
 var (
 	sess                               = session.Must(session.NewSession())
 	lambdaClient lambdaiface.LambdaAPI = lambda.New(sess)
@@ -73,16 +80,23 @@ func PollAndIssueNewScans() error {
 
 // GetEnabledIntegrations lists enabled integrations from the snapshot-api.
 func GetEnabledIntegrations() (integrations []*models.SourceIntegration, err error) {
-	err = genericapi.Invoke(
-		lambdaClient,
-		sourceAPIFunctionName,
-		&models.LambdaInput{ListIntegrations: &models.ListIntegrationsInput{
-			IntegrationType: aws.String("aws-scan"),
-		}},
-		&integrations,
-	)
+	for _, integrationType := range scanIntegrationTypes {
+		var output []*models.SourceIntegration
+		err = genericapi.Invoke(
+			lambdaClient,
+			sourceAPIFunctionName,
+			&models.LambdaInput{ListIntegrations: &models.ListIntegrationsInput{
+				IntegrationType: aws.String(integrationType),
+			}},
+			&output,
+		)
+		if err != nil {
+			return nil, err
+		}
+		integrations = append(integrations, output...)
+	}
 
-	return
+	return integrations, nil
 }
 
 // scanIsStuck checks if an integration's is stuck in the "scanning" state.
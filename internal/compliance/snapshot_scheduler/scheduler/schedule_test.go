@@ -56,10 +56,10 @@ func (client *mockLambdaClient) Invoke(input *lambda.InvokeInput) (*lambda.Invok
 //
 
 // getTestInvokeInput returns an example Lambda.Invoke input for the SnapshotAPI.
-func getTestInvokeInput() *lambda.InvokeInput {
+func getTestInvokeInput(integrationType string) *lambda.InvokeInput {
 	input := &models.LambdaInput{
 		ListIntegrations: &models.ListIntegrationsInput{
-			IntegrationType: aws.String("aws-scan"),
+			IntegrationType: aws.String(integrationType),
 		},
 	}
 	payload, err := jsoniter.Marshal(input)
@@ -174,7 +174,7 @@ func TestPollAndIssueNewScansNoneToRun(t *testing.T) {
 		// Pass in the first integration, which won't need a new scan.
 		Return(getTestInvokeOutput(exampleIntegrations[:1], 200), nil)
 	mockLambda.
-		On("Invoke", getTestInvokeInput()).
+		On("Invoke", getTestInvokeInput("aws-scan")).
 		// Pass in the first integration, which won't need a new scan.
 		Return(getTestInvokeOutput(exampleIntegrations[:1], 200), nil)
 	lambdaClient = mockLambda
@@ -190,8 +190,13 @@ func TestPollAndIssueNewScansZeroIntegrations(t *testing.T) {
 	var emptyOutput []*models.SourceIntegration
 
 	mockLambda.
-		On("Invoke", getTestInvokeInput()).
-		// Pass in the first integration, which won't need a new scan.
+		On("Invoke", getTestInvokeInput("aws-scan")).
+		Return(getTestInvokeOutput(emptyOutput, 200), nil)
+	mockLambda.
+		On("Invoke", getTestInvokeInput("gcp-scan")).
+		Return(getTestInvokeOutput(emptyOutput, 200), nil)
+	mockLambda.
+		On("Invoke", getTestInvokeInput("k8s-scan")).
 		Return(getTestInvokeOutput(emptyOutput, 200), nil)
 	lambdaClient = mockLambda
 
@@ -243,15 +248,42 @@ func TestGetEnabledIntegrations(t *testing.T) {
 	mockLambda := new(mockLambdaClient)
 	lambdaClient = mockLambda
 
+	gcpIntegrations := []*models.SourceIntegration{
+		{
+			SourceIntegrationMetadata: models.SourceIntegrationMetadata{
+				IntegrationID:    "0b3c6c9b-5a8e-4c6f-9f4e-6f1b8f1d9a7e",
+				IntegrationLabel: "ProdGCP",
+				IntegrationType:  models.IntegrationTypeGCPScan,
+				ScanIntervalMins: 60,
+			},
+		},
+	}
+	k8sIntegrations := []*models.SourceIntegration{
+		{
+			SourceIntegrationMetadata: models.SourceIntegrationMetadata{
+				IntegrationID:    "5e8b1f2a-3c4d-4e6f-8a9b-0c1d2e3f4a5b",
+				IntegrationLabel: "ProdCluster",
+				IntegrationType:  models.IntegrationTypeK8sScan,
+				ScanIntervalMins: 60,
+			},
+		},
+	}
 	mockLambda.
-		On("Invoke", getTestInvokeInput()).
+		On("Invoke", getTestInvokeInput("aws-scan")).
 		Return(getTestInvokeOutput(exampleIntegrations, 200), nil)
+	mockLambda.
+		On("Invoke", getTestInvokeInput("gcp-scan")).
+		Return(getTestInvokeOutput(gcpIntegrations, 200), nil)
+	mockLambda.
+		On("Invoke", getTestInvokeInput("k8s-scan")).
+		Return(getTestInvokeOutput(k8sIntegrations, 200), nil)
 
 	integrations, err := GetEnabledIntegrations()
 
 	mockLambda.AssertExpectations(t)
 	require.NoError(t, err)
-	assert.Len(t, integrations, len(exampleIntegrations))
+	assert.Len(t, integrations, len(exampleIntegrations)+len(gcpIntegrations)+len(k8sIntegrations))
+	assert.Equal(t, models.IntegrationTypeK8sScan, integrations[len(integrations)-1].IntegrationType)
 }
 
 func TestGetEnabledIntegrationsError(t *testing.T) {
@@ -259,7 +291,7 @@ func TestGetEnabledIntegrationsError(t *testing.T) {
 	lambdaClient = mockLambda
 
 	mockLambda.
-		On("Invoke", getTestInvokeInput()).
+		On("Invoke", getTestInvokeInput("aws-scan")).
 		Return(&lambda.InvokeOutput{}, errors.New("fake error"))
 
 	_, err := GetEnabledIntegrations()
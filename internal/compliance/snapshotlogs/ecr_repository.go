@@ -0,0 +1,97 @@
+package snapshotlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+const TypeEcrRepository = "Resource.AWS.ECR.Repository"
+
+var logTypeEcrRepository = logtypes.MustBuild(logtypes.ConfigJSON{
+	Name:         TypeEcrRepository,
+	Description:  `Contains Cloud Security snapshots of ECR repositories`,
+	ReferenceURL: `https://docs.runpanther.io/cloud-security/resources`,
+	NewEvent: func() interface{} {
+		return &EcrRepository{}
+	},
+	ExtraIndicators: pantherlog.FieldSet{ // these are added by extractors but not used in struct directly
+		pantherlog.FieldAWSTag,
+	},
+	Validate: pantherlog.ValidateStruct,
+})
+
+// nolint:lll
+type EcrRepository struct {
+	ChangeType       pantherlog.String      `json:"changeType" validate:"required" description:"The type of change that initiated this snapshot creation."`
+	Changes          *pantherlog.RawMessage `json:"changes" description:"The changes, if any, from the prior snapshot to this one."`
+	IntegrationID    pantherlog.String      `json:"integrationId" validate:"required" description:"The unique source ID of the account this resource lives in."`
+	IntegrationLabel pantherlog.String      `json:"integrationLabel" validate:"required" description:"The friendly source name of the account this resource lives in."`
+	LastUpdated      pantherlog.Time        `json:"lastUpdated" tcodec:"rfc3339" event_time:"true" validate:"required" description:"The time this snapshot occurred."`
+	Resource         *EcrRepositoryResource `json:"resource" validate:"required" description:"This object represents the state of the repository."`
+	ID               pantherlog.String      `json:"id" description:"The AWS resource identifier of the resource."`
+	ResourceID       pantherlog.String      `json:"resourceId" description:"A panther wide unique identifier of the resource."`
+	ResourceType     pantherlog.String      `json:"resourceType" validate:"required,eq=AWS.ECR.Repository" description:"The resource type, always AWS.ECR.Repository."`
+	TimeCreated      pantherlog.Time        `json:"timeCreated" tcodec:"rfc3339" description:"When this resource was created."`
+	AccountID        pantherlog.String      `json:"accountId" panther:"aws_account_id" description:"The ID of the AWS Account the resource resides in."`
+	Region           pantherlog.String      `json:"region" description:"The region the resource exists in."`
+	ARN              pantherlog.String      `json:"arn" panther:"aws_arn" description:"The Amazon Resource Name (ARN) of the resource."`
+	Name             pantherlog.String      `json:"name" description:"The AWS resource name of the resource."`
+	Tags             map[string]string      `json:"tags" description:"A standardized format for key/value resource tags."`
+}
+
+// nolint:lll
+type EcrRepositoryResource struct {
+	AccountID    pantherlog.String `json:"AccountId" panther:"aws_account_id" description:"The ID of the AWS Account the repository resides in."`
+	Region       pantherlog.String `json:"Region" description:"The region the repository exists in."`
+	ARN          pantherlog.String `json:"Arn" panther:"aws_arn" description:"The ARN of the repository."`
+	Name         pantherlog.String `json:"Name" description:"The name of the repository."`
+	Tags         map[string]string `json:"Tags" description:"The tags of the repository."`
+	ResourceID   pantherlog.String `json:"ResourceId" description:"A panther wide unique identifier of the repository."`
+	ResourceType pantherlog.String `json:"ResourceType" description:"The panther resource type of the repository."`
+	TimeCreated  pantherlog.Time   `json:"TimeCreated" tcodec:"rfc3339" description:"When the repository was created."`
+
+	EncryptionConfiguration    *EcrEncryptionConfiguration    `json:"EncryptionConfiguration" description:"The encryption configuration of the repository."`
+	ImageScanningConfiguration *EcrImageScanningConfiguration `json:"ImageScanningConfiguration" description:"The image scanning configuration of the repository."`
+	ImageTagMutability         pantherlog.String              `json:"ImageTagMutability" description:"Whether image tags can be overwritten."`
+	RegistryID                 pantherlog.String              `json:"RegistryId" panther:"aws_account_id" description:"The AWS account ID of the registry that contains the repository."`
+	RepositoryURI              pantherlog.String              `json:"RepositoryUri" description:"The URI of the repository."`
+	LifecyclePolicy            pantherlog.String              `json:"LifecyclePolicy" description:"The lifecycle policy document of the repository."`
+	Policy                     pantherlog.String              `json:"Policy" description:"The policy document of the repository."`
+}
+
+// nolint:lll
+type EcrEncryptionConfiguration struct {
+	EncryptionType pantherlog.String `json:"EncryptionType" description:"The encryption type of the repository."`
+	KmsKey         pantherlog.String `json:"KmsKey" panther:"aws_arn" description:"The ARN of the KMS key used for encryption."`
+}
+
+// nolint:lll
+type EcrImageScanningConfiguration struct {
+	ScanOnPush pantherlog.Bool `json:"ScanOnPush" description:"Whether images are scanned after being pushed to the repository."`
+}
+
+// WriteValuesTo implements pantherlog.ValueWriterTo interface
+func (r *EcrRepository) WriteValuesTo(w pantherlog.ValueWriter) {
+	writeTagValues(w, r.Tags)
+	if r.Resource != nil {
+		extractPolicyIndicators(w, r.Resource.Policy)
+	}
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws/arn"
 	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
 
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
@@ -42,9 +43,28 @@ var logTypeResourceHistory = logtypes.MustBuild(logtypes.ConfigJSON{
 		pantherlog.FieldIPAddress,
 		pantherlog.FieldAWSTag,
 	},
-	Validate: pantherlog.ValidateStruct,
+	Validate: validateResource,
 })
 
+// typedResourceTypes are the resource types with a dedicated log type.
+// Snapshots of these resources are rejected by Resource.History so that each snapshot matches a single log type.
+var typedResourceTypes = map[string]struct{}{
+	"AWS.ECR.Repository":        {},
+	"AWS.SecretsManager.Secret": {},
+	"AWS.SNS.Topic":             {},
+	"AWS.SQS.Queue":             {},
+	"AWS.SSM.Parameter":         {},
+}
+
+func validateResource(x interface{}) error {
+	if r, ok := x.(*Resource); ok {
+		if _, typed := typedResourceTypes[r.ResourceType.Value]; typed {
+			return errors.Errorf("resource type %q has a dedicated log type", r.ResourceType.Value)
+		}
+	}
+	return pantherlog.ValidateStruct(x)
+}
+
 // nolint:lll
 type Resource struct {
 	ChangeType       pantherlog.String      `json:"changeType" validate:"required" description:"The type of change that initiated this snapshot creation."`
@@ -67,11 +87,25 @@ type Resource struct {
 // WriteValuesTo implements pantherlog.ValueWriterTo interface
 func (r *Resource) WriteValuesTo(w pantherlog.ValueWriter) {
 	pantherlog.ExtractRawMessageIndicators(w, extractIndicators, r.Resource)
-	for key, value := range r.Tags {
+	writeTagValues(w, r.Tags)
+}
+
+func writeTagValues(w pantherlog.ValueWriter, tags map[string]string) {
+	for key, value := range tags {
 		w.WriteValues(pantherlog.FieldAWSTag, key+":"+value)
 	}
 }
 
+// extractPolicyIndicators scans JSON policy documents stored as strings in typed resource snapshots
+func extractPolicyIndicators(w pantherlog.ValueWriter, policies ...pantherlog.String) {
+	for _, policy := range policies {
+		if policy.Value == "" {
+			continue
+		}
+		pantherlog.ExtractRawMessageIndicators(w, extractIndicators, pantherlog.RawMessage(policy.Value))
+	}
+}
+
 func extractIndicators(w pantherlog.ValueWriter, iter *jsoniter.Iterator, key string) {
 	switch iter.WhatIsNext() {
 	case jsoniter.ObjectValue:
@@ -0,0 +1,95 @@
+package snapshotlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+const TypeSecretsManagerSecret = "Resource.AWS.SecretsManager.Secret"
+
+var logTypeSecretsManagerSecret = logtypes.MustBuild(logtypes.ConfigJSON{
+	Name:         TypeSecretsManagerSecret,
+	Description:  `Contains Cloud Security snapshots of Secrets Manager secrets`,
+	ReferenceURL: `https://docs.runpanther.io/cloud-security/resources`,
+	NewEvent: func() interface{} {
+		return &SecretsManagerSecret{}
+	},
+	ExtraIndicators: pantherlog.FieldSet{ // these are added by extractors but not used in struct directly
+		pantherlog.FieldAWSTag,
+	},
+	Validate: pantherlog.ValidateStruct,
+})
+
+// nolint:lll
+type SecretsManagerSecret struct {
+	ChangeType       pantherlog.String             `json:"changeType" validate:"required" description:"The type of change that initiated this snapshot creation."`
+	Changes          *pantherlog.RawMessage        `json:"changes" description:"The changes, if any, from the prior snapshot to this one."`
+	IntegrationID    pantherlog.String             `json:"integrationId" validate:"required" description:"The unique source ID of the account this resource lives in."`
+	IntegrationLabel pantherlog.String             `json:"integrationLabel" validate:"required" description:"The friendly source name of the account this resource lives in."`
+	LastUpdated      pantherlog.Time               `json:"lastUpdated" tcodec:"rfc3339" event_time:"true" validate:"required" description:"The time this snapshot occurred."`
+	Resource         *SecretsManagerSecretResource `json:"resource" validate:"required" description:"This object represents the state of the secret."`
+	ID               pantherlog.String             `json:"id" description:"The AWS resource identifier of the resource."`
+	ResourceID       pantherlog.String             `json:"resourceId" description:"A panther wide unique identifier of the resource."`
+	ResourceType     pantherlog.String             `json:"resourceType" validate:"required,eq=AWS.SecretsManager.Secret" description:"The resource type, always AWS.SecretsManager.Secret."`
+	TimeCreated      pantherlog.Time               `json:"timeCreated" tcodec:"rfc3339" description:"When this resource was created."`
+	AccountID        pantherlog.String             `json:"accountId" panther:"aws_account_id" description:"The ID of the AWS Account the resource resides in."`
+	Region           pantherlog.String             `json:"region" description:"The region the resource exists in."`
+	ARN              pantherlog.String             `json:"arn" panther:"aws_arn" description:"The Amazon Resource Name (ARN) of the resource."`
+	Name             pantherlog.String             `json:"name" description:"The AWS resource name of the resource."`
+	Tags             map[string]string             `json:"tags" description:"A standardized format for key/value resource tags."`
+}
+
+// nolint:lll
+type SecretsManagerSecretResource struct {
+	AccountID    pantherlog.String `json:"AccountId" panther:"aws_account_id" description:"The ID of the AWS Account the secret resides in."`
+	Region       pantherlog.String `json:"Region" description:"The region the secret exists in."`
+	ARN          pantherlog.String `json:"Arn" panther:"aws_arn" description:"The ARN of the secret."`
+	Name         pantherlog.String `json:"Name" description:"The name of the secret."`
+	Tags         map[string]string `json:"Tags" description:"The tags of the secret."`
+	ResourceID   pantherlog.String `json:"ResourceId" description:"A panther wide unique identifier of the secret."`
+	ResourceType pantherlog.String `json:"ResourceType" description:"The panther resource type of the secret."`
+	TimeCreated  pantherlog.Time   `json:"TimeCreated" tcodec:"rfc3339" description:"When the secret was created."`
+
+	DeletedDate        pantherlog.Time      `json:"DeletedDate" tcodec:"rfc3339" description:"When the secret is scheduled to be deleted."`
+	Description        pantherlog.String    `json:"Description" description:"The description of the secret."`
+	KmsKeyID           pantherlog.String    `json:"KmsKeyId" description:"The ID of the KMS key used to encrypt the secret."`
+	LastChangedDate    pantherlog.Time      `json:"LastChangedDate" tcodec:"rfc3339" description:"When the secret was last changed."`
+	LastRotatedDate    pantherlog.Time      `json:"LastRotatedDate" tcodec:"rfc3339" description:"When the secret was last rotated."`
+	OwningService      pantherlog.String    `json:"OwningService" description:"The ID of the service that created the secret."`
+	RotationEnabled    pantherlog.Bool      `json:"RotationEnabled" description:"Whether automatic rotation is enabled for the secret."`
+	RotationLambdaARN  pantherlog.String    `json:"RotationLambdaARN" panther:"aws_arn" description:"The ARN of the Lambda function that rotates the secret."`
+	RotationRules      *SecretRotationRules `json:"RotationRules" description:"The rotation schedule of the secret."`
+	VersionIdsToStages map[string][]string  `json:"VersionIdsToStages" description:"The staging labels attached to each version of the secret."`
+	ResourcePolicy     pantherlog.String    `json:"ResourcePolicy" description:"The resource policy document of the secret."`
+}
+
+// nolint:lll
+type SecretRotationRules struct {
+	AutomaticallyAfterDays pantherlog.Int64 `json:"AutomaticallyAfterDays" description:"The number of days between automatic rotations of the secret."`
+}
+
+// WriteValuesTo implements pantherlog.ValueWriterTo interface
+func (r *SecretsManagerSecret) WriteValuesTo(w pantherlog.ValueWriter) {
+	writeTagValues(w, r.Tags)
+	if r.Resource != nil {
+		extractPolicyIndicators(w, r.Resource.ResourcePolicy)
+	}
+}
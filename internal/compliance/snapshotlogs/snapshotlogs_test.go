@@ -21,9 +21,39 @@ package snapshotlogs
 import (
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes/logtesting"
 )
 
 func TestSnapshotsLogs(t *testing.T) {
 	logtesting.RunTestsFromYAML(t, LogTypes(), "./testdata/snapshot_tests.yml")
 }
+
+func TestTypedResourceSnapshots(t *testing.T) {
+	assert := require.New(t)
+	const input = `{
+		"changeType": "CREATED",
+		"integrationId": "d3be8d06-3e30-4908-9c07-6640b4b5b3dc",
+		"integrationLabel": "panther-account",
+		"lastUpdated": "2020-10-15T06:29:00.498108265Z",
+		"resourceType": "AWS.SNS.Topic",
+		"resource": {"ResourceType": "AWS.SNS.Topic"}
+	}`
+	history, err := logTypeResourceHistory.NewParser(nil)
+	assert.NoError(err)
+	_, err = history.ParseLog(input)
+	assert.Error(err)
+
+	topic, err := logTypeSnsTopic.NewParser(nil)
+	assert.NoError(err)
+	results, err := topic.ParseLog(input)
+	assert.NoError(err)
+	assert.Len(results, 1)
+
+	// Typed log types only accept their own resource type
+	queue, err := logTypeSqsQueue.NewParser(nil)
+	assert.NoError(err)
+	_, err = queue.ParseLog(input)
+	assert.Error(err)
+}
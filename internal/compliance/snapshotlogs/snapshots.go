@@ -29,7 +29,15 @@ func LogTypes() logtypes.Group {
 
 const CloudSecurityGroup = "cloudsecurity"
 
-var logTypes = logtypes.Must(CloudSecurityGroup, logTypeComplianceHistory, logTypeResourceHistory)
+var logTypes = logtypes.Must(CloudSecurityGroup,
+	logTypeComplianceHistory,
+	logTypeResourceHistory,
+	logTypeEcrRepository,
+	logTypeSecretsManagerSecret,
+	logTypeSnsTopic,
+	logTypeSqsQueue,
+	logTypeSsmParameter,
+)
 
 func Resolver() logtypes.Resolver {
 	return logtypes.LocalResolver(logTypes)
@@ -0,0 +1,96 @@
+package snapshotlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+const TypeSnsTopic = "Resource.AWS.SNS.Topic"
+
+var logTypeSnsTopic = logtypes.MustBuild(logtypes.ConfigJSON{
+	Name:         TypeSnsTopic,
+	Description:  `Contains Cloud Security snapshots of SNS topics`,
+	ReferenceURL: `https://docs.runpanther.io/cloud-security/resources`,
+	NewEvent: func() interface{} {
+		return &SnsTopic{}
+	},
+	ExtraIndicators: pantherlog.FieldSet{ // these are added by extractors but not used in struct directly
+		pantherlog.FieldAWSTag,
+	},
+	Validate: pantherlog.ValidateStruct,
+})
+
+// nolint:lll
+type SnsTopic struct {
+	ChangeType       pantherlog.String      `json:"changeType" validate:"required" description:"The type of change that initiated this snapshot creation."`
+	Changes          *pantherlog.RawMessage `json:"changes" description:"The changes, if any, from the prior snapshot to this one."`
+	IntegrationID    pantherlog.String      `json:"integrationId" validate:"required" description:"The unique source ID of the account this resource lives in."`
+	IntegrationLabel pantherlog.String      `json:"integrationLabel" validate:"required" description:"The friendly source name of the account this resource lives in."`
+	LastUpdated      pantherlog.Time        `json:"lastUpdated" tcodec:"rfc3339" event_time:"true" validate:"required" description:"The time this snapshot occurred."`
+	Resource         *SnsTopicResource      `json:"resource" validate:"required" description:"This object represents the state of the topic."`
+	ID               pantherlog.String      `json:"id" description:"The AWS resource identifier of the resource."`
+	ResourceID       pantherlog.String      `json:"resourceId" description:"A panther wide unique identifier of the resource."`
+	ResourceType     pantherlog.String      `json:"resourceType" validate:"required,eq=AWS.SNS.Topic" description:"The resource type, always AWS.SNS.Topic."`
+	TimeCreated      pantherlog.Time        `json:"timeCreated" tcodec:"rfc3339" description:"When this resource was created."`
+	AccountID        pantherlog.String      `json:"accountId" panther:"aws_account_id" description:"The ID of the AWS Account the resource resides in."`
+	Region           pantherlog.String      `json:"region" description:"The region the resource exists in."`
+	ARN              pantherlog.String      `json:"arn" panther:"aws_arn" description:"The Amazon Resource Name (ARN) of the resource."`
+	Name             pantherlog.String      `json:"name" description:"The AWS resource name of the resource."`
+	Tags             map[string]string      `json:"tags" description:"A standardized format for key/value resource tags."`
+}
+
+// nolint:lll
+type SnsTopicResource struct {
+	AccountID    pantherlog.String `json:"AccountId" panther:"aws_account_id" description:"The ID of the AWS Account the topic resides in."`
+	Region       pantherlog.String `json:"Region" description:"The region the topic exists in."`
+	ARN          pantherlog.String `json:"Arn" panther:"aws_arn" description:"The ARN of the topic."`
+	Name         pantherlog.String `json:"Name" description:"The name of the topic."`
+	Tags         map[string]string `json:"Tags" description:"The tags of the topic."`
+	ResourceID   pantherlog.String `json:"ResourceId" description:"A panther wide unique identifier of the topic."`
+	ResourceType pantherlog.String `json:"ResourceType" description:"The panther resource type of the topic."`
+	TimeCreated  pantherlog.Time   `json:"TimeCreated" tcodec:"rfc3339" description:"When the topic was created."`
+
+	ContentBasedDeduplication pantherlog.Bool        `json:"ContentBasedDeduplication" description:"Whether content-based deduplication is enabled for a FIFO topic."`
+	DeliveryPolicy            pantherlog.String      `json:"DeliveryPolicy" description:"The delivery policy of the topic."`
+	DisplayName               pantherlog.String      `json:"DisplayName" description:"The display name of the topic."`
+	EffectiveDeliveryPolicy   pantherlog.String      `json:"EffectiveDeliveryPolicy" description:"The effective delivery policy of the topic, taking system defaults into account."`
+	FifoTopic                 pantherlog.Bool        `json:"FifoTopic" description:"Whether the topic is a FIFO topic."`
+	KmsMasterKeyID            pantherlog.String      `json:"KmsMasterKeyId" description:"The ID of the KMS key used for server-side encryption."`
+	Owner                     pantherlog.String      `json:"Owner" panther:"aws_account_id" description:"The AWS account ID of the topic owner."`
+	Policy                    pantherlog.String      `json:"Policy" description:"The policy document of the topic."`
+	Subscriptions             []SnsTopicSubscription `json:"Subscriptions" description:"The subscriptions to the topic."`
+}
+
+// nolint:lll
+type SnsTopicSubscription struct {
+	Endpoint        pantherlog.String `json:"Endpoint" description:"The endpoint of the subscription."`
+	Owner           pantherlog.String `json:"Owner" panther:"aws_account_id" description:"The AWS account ID of the subscription owner."`
+	Protocol        pantherlog.String `json:"Protocol" description:"The protocol of the subscription."`
+	SubscriptionArn pantherlog.String `json:"SubscriptionArn" description:"The ARN of the subscription, or PendingConfirmation if it is not confirmed."`
+}
+
+// WriteValuesTo implements pantherlog.ValueWriterTo interface
+func (r *SnsTopic) WriteValuesTo(w pantherlog.ValueWriter) {
+	writeTagValues(w, r.Tags)
+	if r.Resource != nil {
+		extractPolicyIndicators(w, r.Resource.Policy)
+	}
+}
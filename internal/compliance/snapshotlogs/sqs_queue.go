@@ -0,0 +1,95 @@
+package snapshotlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+const TypeSqsQueue = "Resource.AWS.SQS.Queue"
+
+var logTypeSqsQueue = logtypes.MustBuild(logtypes.ConfigJSON{
+	Name:         TypeSqsQueue,
+	Description:  `Contains Cloud Security snapshots of SQS queues`,
+	ReferenceURL: `https://docs.runpanther.io/cloud-security/resources`,
+	NewEvent: func() interface{} {
+		return &SqsQueue{}
+	},
+	ExtraIndicators: pantherlog.FieldSet{ // these are added by extractors but not used in struct directly
+		pantherlog.FieldAWSTag,
+	},
+	Validate: pantherlog.ValidateStruct,
+})
+
+// nolint:lll
+type SqsQueue struct {
+	ChangeType       pantherlog.String      `json:"changeType" validate:"required" description:"The type of change that initiated this snapshot creation."`
+	Changes          *pantherlog.RawMessage `json:"changes" description:"The changes, if any, from the prior snapshot to this one."`
+	IntegrationID    pantherlog.String      `json:"integrationId" validate:"required" description:"The unique source ID of the account this resource lives in."`
+	IntegrationLabel pantherlog.String      `json:"integrationLabel" validate:"required" description:"The friendly source name of the account this resource lives in."`
+	LastUpdated      pantherlog.Time        `json:"lastUpdated" tcodec:"rfc3339" event_time:"true" validate:"required" description:"The time this snapshot occurred."`
+	Resource         *SqsQueueResource      `json:"resource" validate:"required" description:"This object represents the state of the queue."`
+	ID               pantherlog.String      `json:"id" description:"The AWS resource identifier of the resource."`
+	ResourceID       pantherlog.String      `json:"resourceId" description:"A panther wide unique identifier of the resource."`
+	ResourceType     pantherlog.String      `json:"resourceType" validate:"required,eq=AWS.SQS.Queue" description:"The resource type, always AWS.SQS.Queue."`
+	TimeCreated      pantherlog.Time        `json:"timeCreated" tcodec:"rfc3339" description:"When this resource was created."`
+	AccountID        pantherlog.String      `json:"accountId" panther:"aws_account_id" description:"The ID of the AWS Account the resource resides in."`
+	Region           pantherlog.String      `json:"region" description:"The region the resource exists in."`
+	ARN              pantherlog.String      `json:"arn" panther:"aws_arn" description:"The Amazon Resource Name (ARN) of the resource."`
+	Name             pantherlog.String      `json:"name" description:"The AWS resource name of the resource."`
+	Tags             map[string]string      `json:"tags" description:"A standardized format for key/value resource tags."`
+}
+
+// nolint:lll
+type SqsQueueResource struct {
+	AccountID    pantherlog.String `json:"AccountId" panther:"aws_account_id" description:"The ID of the AWS Account the queue resides in."`
+	Region       pantherlog.String `json:"Region" description:"The region the queue exists in."`
+	ARN          pantherlog.String `json:"Arn" panther:"aws_arn" description:"The ARN of the queue."`
+	Name         pantherlog.String `json:"Name" description:"The name of the queue."`
+	Tags         map[string]string `json:"Tags" description:"The tags of the queue."`
+	ResourceID   pantherlog.String `json:"ResourceId" description:"A panther wide unique identifier of the queue."`
+	ResourceType pantherlog.String `json:"ResourceType" description:"The panther resource type of the queue."`
+	TimeCreated  pantherlog.Time   `json:"TimeCreated" tcodec:"rfc3339" description:"When the queue was created."`
+
+	ContentBasedDeduplication     pantherlog.Bool   `json:"ContentBasedDeduplication" description:"Whether content-based deduplication is enabled for a FIFO queue."`
+	DeduplicationScope            pantherlog.String `json:"DeduplicationScope" description:"Whether message deduplication occurs at the message group or queue level."`
+	DelaySeconds                  pantherlog.Int64  `json:"DelaySeconds" description:"The default delay on the queue in seconds."`
+	FifoQueue                     pantherlog.Bool   `json:"FifoQueue" description:"Whether the queue is a FIFO queue."`
+	FifoThroughputLimit           pantherlog.String `json:"FifoThroughputLimit" description:"Whether the FIFO queue throughput quota applies to the entire queue or per message group."`
+	KmsDataKeyReusePeriodSeconds  pantherlog.Int64  `json:"KmsDataKeyReusePeriodSeconds" description:"The length of time in seconds for which SQS can reuse a data key."`
+	KmsMasterKeyID                pantherlog.String `json:"KmsMasterKeyId" description:"The ID of the KMS key used for server-side encryption."`
+	LastModifiedTimestamp         pantherlog.Time   `json:"LastModifiedTimestamp" tcodec:"rfc3339" description:"When the queue was last changed."`
+	MaximumMessageSize            pantherlog.Int64  `json:"MaximumMessageSize" description:"The limit of how many bytes a message can contain."`
+	MessageRetentionPeriod        pantherlog.Int64  `json:"MessageRetentionPeriod" description:"The length of time in seconds for which SQS retains a message."`
+	Policy                        pantherlog.String `json:"Policy" description:"The policy document of the queue."`
+	ReceiveMessageWaitTimeSeconds pantherlog.Int64  `json:"ReceiveMessageWaitTimeSeconds" description:"The length of time in seconds for which ReceiveMessage waits for a message to arrive."`
+	RedrivePolicy                 pantherlog.String `json:"RedrivePolicy" description:"The dead-letter queue configuration of the queue."`
+	VisibilityTimeout             pantherlog.Int64  `json:"VisibilityTimeout" description:"The visibility timeout of the queue in seconds."`
+	QueueURL                      pantherlog.String `json:"QueueUrl" description:"The URL of the queue."`
+	DeadLetterSourceQueues        []string          `json:"DeadLetterSourceQueues" description:"The URLs of the queues that use this queue as their dead-letter queue."`
+}
+
+// WriteValuesTo implements pantherlog.ValueWriterTo interface
+func (r *SqsQueue) WriteValuesTo(w pantherlog.ValueWriter) {
+	writeTagValues(w, r.Tags)
+	if r.Resource != nil {
+		extractPolicyIndicators(w, r.Resource.Policy, r.Resource.RedrivePolicy)
+	}
+}
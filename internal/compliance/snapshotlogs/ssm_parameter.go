@@ -0,0 +1,96 @@
+package snapshotlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+const TypeSsmParameter = "Resource.AWS.SSM.Parameter"
+
+var logTypeSsmParameter = logtypes.MustBuild(logtypes.ConfigJSON{
+	Name:         TypeSsmParameter,
+	Description:  `Contains Cloud Security snapshots of SSM parameters`,
+	ReferenceURL: `https://docs.runpanther.io/cloud-security/resources`,
+	NewEvent: func() interface{} {
+		return &SsmParameter{}
+	},
+	ExtraIndicators: pantherlog.FieldSet{ // these are added by extractors but not used in struct directly
+		pantherlog.FieldAWSTag,
+	},
+	Validate: pantherlog.ValidateStruct,
+})
+
+// nolint:lll
+type SsmParameter struct {
+	ChangeType       pantherlog.String      `json:"changeType" validate:"required" description:"The type of change that initiated this snapshot creation."`
+	Changes          *pantherlog.RawMessage `json:"changes" description:"The changes, if any, from the prior snapshot to this one."`
+	IntegrationID    pantherlog.String      `json:"integrationId" validate:"required" description:"The unique source ID of the account this resource lives in."`
+	IntegrationLabel pantherlog.String      `json:"integrationLabel" validate:"required" description:"The friendly source name of the account this resource lives in."`
+	LastUpdated      pantherlog.Time        `json:"lastUpdated" tcodec:"rfc3339" event_time:"true" validate:"required" description:"The time this snapshot occurred."`
+	Resource         *SsmParameterResource  `json:"resource" validate:"required" description:"This object represents the state of the parameter."`
+	ID               pantherlog.String      `json:"id" description:"The AWS resource identifier of the resource."`
+	ResourceID       pantherlog.String      `json:"resourceId" description:"A panther wide unique identifier of the resource."`
+	ResourceType     pantherlog.String      `json:"resourceType" validate:"required,eq=AWS.SSM.Parameter" description:"The resource type, always AWS.SSM.Parameter."`
+	TimeCreated      pantherlog.Time        `json:"timeCreated" tcodec:"rfc3339" description:"When this resource was created."`
+	AccountID        pantherlog.String      `json:"accountId" panther:"aws_account_id" description:"The ID of the AWS Account the resource resides in."`
+	Region           pantherlog.String      `json:"region" description:"The region the resource exists in."`
+	ARN              pantherlog.String      `json:"arn" panther:"aws_arn" description:"The Amazon Resource Name (ARN) of the resource."`
+	Name             pantherlog.String      `json:"name" description:"The AWS resource name of the resource."`
+	Tags             map[string]string      `json:"tags" description:"A standardized format for key/value resource tags."`
+}
+
+// nolint:lll
+type SsmParameterResource struct {
+	AccountID    pantherlog.String `json:"AccountId" panther:"aws_account_id" description:"The ID of the AWS Account the parameter resides in."`
+	Region       pantherlog.String `json:"Region" description:"The region the parameter exists in."`
+	ARN          pantherlog.String `json:"Arn" panther:"aws_arn" description:"The ARN of the parameter."`
+	Name         pantherlog.String `json:"Name" description:"The name of the parameter."`
+	Tags         map[string]string `json:"Tags" description:"The tags of the parameter."`
+	ResourceID   pantherlog.String `json:"ResourceId" description:"A panther wide unique identifier of the parameter."`
+	ResourceType pantherlog.String `json:"ResourceType" description:"The panther resource type of the parameter."`
+	TimeCreated  pantherlog.Time   `json:"TimeCreated" tcodec:"rfc3339" description:"When the parameter was created."`
+
+	AllowedPattern   pantherlog.String    `json:"AllowedPattern" description:"The regular expression used to validate the parameter value."`
+	DataType         pantherlog.String    `json:"DataType" description:"The data type of the parameter."`
+	Description      pantherlog.String    `json:"Description" description:"The description of the parameter."`
+	KeyID            pantherlog.String    `json:"KeyId" description:"The ID of the KMS key used to encrypt a SecureString parameter."`
+	LastModifiedDate pantherlog.Time      `json:"LastModifiedDate" tcodec:"rfc3339" description:"When the parameter was last changed."`
+	LastModifiedUser pantherlog.String    `json:"LastModifiedUser" panther:"aws_arn" description:"The ARN of the user who last changed the parameter."`
+	Policies         []SsmParameterPolicy `json:"Policies" description:"The policies attached to the parameter."`
+	Tier             pantherlog.String    `json:"Tier" description:"The tier of the parameter."`
+	Type             pantherlog.String    `json:"Type" description:"The type of the parameter."`
+	Version          pantherlog.Int64     `json:"Version" description:"The version of the parameter."`
+}
+
+// nolint:lll
+type SsmParameterPolicy struct {
+	PolicyStatus pantherlog.String `json:"PolicyStatus" description:"The status of the policy."`
+	PolicyText   pantherlog.String `json:"PolicyText" description:"The policy document."`
+	PolicyType   pantherlog.String `json:"PolicyType" description:"The type of the policy."`
+}
+
+// WriteValuesTo implements pantherlog.ValueWriterTo interface
+func (r *SsmParameter) WriteValuesTo(w pantherlog.ValueWriter) {
+	writeTagValues(w, r.Tags)
+	if r.Resource != nil {
+		extractPolicyIndicators(w)
+	}
+}
@@ -29,7 +29,10 @@ import (
 	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
 	"github.com/kelseyhightower/envconfig"
 
+	"github.com/panther-labs/panther/internal/core/alert_delivery/deadletter"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/digest"
 	"github.com/panther-labs/panther/internal/core/alert_delivery/outputs"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/throttle"
 	alertTable "github.com/panther-labs/panther/internal/log_analysis/alerts_api/table"
 	"github.com/panther-labs/panther/pkg/gatewayapi"
 )
@@ -48,6 +51,9 @@ type envConfig struct {
 	AlertQueueURL          string        `required:"true" split_words:"true"`
 	AlertsAPI              string        `required:"true" split_words:"true"`
 	OutputsAPI             string        `required:"true" split_words:"true"`
+	DeliveryBudgetsTable   string        `required:"true" split_words:"true"`
+	DeliveryDigestsTable   string        `required:"true" split_words:"true"`
+	DeadLettersTable       string        `required:"true" split_words:"true"`
 }
 
 // Globals
@@ -57,6 +63,9 @@ var (
 	alertsTableClient    *alertTable.AlertsTable
 	lambdaClient         lambdaiface.LambdaAPI
 	outputClient         outputs.API
+	outputLimiter        throttle.API
+	digestStore          digest.API
+	deadLetterStore      deadletter.API
 	sqsClient            sqsiface.SQSAPI
 	outputsCache         *alertOutputsCache
 	analysisClient       gatewayapi.API
@@ -69,6 +78,18 @@ func Setup() {
 	awsSession = session.Must(session.NewSession())
 	lambdaClient = lambda.New(awsSession)
 	outputClient = outputs.New(awsSession)
+	outputLimiter = &throttle.Limiter{
+		Client:    dynamodb.New(awsSession),
+		TableName: env.DeliveryBudgetsTable,
+	}
+	digestStore = &digest.Store{
+		Client:    dynamodb.New(awsSession),
+		TableName: env.DeliveryDigestsTable,
+	}
+	deadLetterStore = &deadletter.Store{
+		Client:    dynamodb.New(awsSession),
+		TableName: env.DeadLettersTable,
+	}
 	sqsClient = sqs.New(awsSession)
 	outputsCache = &alertOutputsCache{
 		RefreshInterval: env.OutputsRefreshInterval,
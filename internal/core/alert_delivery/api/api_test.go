@@ -45,6 +45,16 @@ func (m *mockOutputsClient) Slack(
 	return args.Get(0).(*outputs.AlertDeliveryResponse)
 }
 
+func (m *mockOutputsClient) SlackDigest(
+	ctx context.Context,
+	digest *outputs.Digest,
+	config *outputModels.SlackConfig,
+) *outputs.AlertDeliveryResponse {
+
+	args := m.Called(ctx, digest, config)
+	return args.Get(0).(*outputs.AlertDeliveryResponse)
+}
+
 func sampleAlert() *deliverymodel.Alert {
 	return &deliverymodel.Alert{
 		AlertID:      aws.String("alert-id"),
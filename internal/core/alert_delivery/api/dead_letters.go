@@ -0,0 +1,146 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	deliverymodel "github.com/panther-labs/panther/api/lambda/delivery/models"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/deadletter"
+	"github.com/panther-labs/panther/pkg/awsbatch/sqsbatch"
+)
+
+// ListDeadLetters - lists the deliveries which failed after all retries, most recent first
+func (API) ListDeadLetters(_ context.Context, input *deliverymodel.ListDeadLettersInput) (
+	*deliverymodel.ListDeadLettersOutput, error) {
+
+	items, err := deadLetterStore.List(deadLetterFilter(&input.DeadLetterFilter))
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].FailedAt.After(items[j].FailedAt)
+	})
+
+	output := &deliverymodel.ListDeadLettersOutput{
+		DeadLetters: make([]*deliverymodel.DeadLetter, 0, len(items)),
+	}
+	for _, item := range items {
+		letter := &deliverymodel.DeadLetter{
+			OutputID:   item.OutputID,
+			AlertID:    item.AlertID,
+			AnalysisID: item.AnalysisID,
+			FailedAt:   item.FailedAt.UTC(),
+			StatusCode: item.StatusCode,
+			Message:    item.Message,
+			RetryCount: item.RetryCount,
+		}
+		// The failure is still listed if its alert cannot be decoded
+		if letter.Alert, err = item.DecodeAlert(); err != nil {
+			zap.L().Warn("failed to decode dead letter", zap.Error(err))
+		}
+		output.DeadLetters = append(output.DeadLetters, letter)
+	}
+	return output, nil
+}
+
+// RedeliverDeadLetters - puts failed deliveries back on the alert queue and removes them from the dead-letter store.
+//
+// The alerts are delivered again to the output that failed only, with a fresh retry count.
+// If they fail again after all retries, they are recorded again.
+func (API) RedeliverDeadLetters(_ context.Context, input *deliverymodel.RedeliverDeadLettersInput) (
+	*deliverymodel.RedeliverDeadLettersOutput, error) {
+
+	items, err := deadLetterStore.List(deadLetterFilter(&input.DeadLetterFilter))
+	if err != nil {
+		return nil, err
+	}
+
+	alerts := make([]*deliverymodel.Alert, 0, len(items))
+	redelivered := make([]*deadletter.Item, 0, len(items))
+	for _, item := range items {
+		alert, err := item.DecodeAlert()
+		if err != nil {
+			zap.L().Error("cannot redeliver dead letter", zap.Error(err))
+			continue
+		}
+		alert.RetryCount = 0
+		alert.OutputIds = []string{item.OutputID}
+		alerts = append(alerts, alert)
+		redelivered = append(redelivered, item)
+	}
+	if len(alerts) == 0 {
+		return &deliverymodel.RedeliverDeadLettersOutput{}, nil
+	}
+
+	// Spread the alerts over the retry delay so that a destination that just recovered is not flooded
+	queueInput := createInput(alerts, env.AlertQueueURL, env.MinRetryDelaySecs, env.MaxRetryDelaySecs)
+	if _, err := sqsbatch.SendMessageBatch(sqsClient, maxSQSBackoff, queueInput); err != nil {
+		return nil, errors.Wrap(err, "failed to queue dead letters for redelivery")
+	}
+
+	// The alerts are already queued, failing here would only cause duplicate deliveries if the caller retries
+	if err := deadLetterStore.Delete(redelivered); err != nil {
+		zap.L().Error("failed to delete redelivered dead letters", zap.Error(err))
+	}
+	return &deliverymodel.RedeliverDeadLettersOutput{Redelivered: len(alerts)}, nil
+}
+
+func deadLetterFilter(input *deliverymodel.DeadLetterFilter) *deadletter.Filter {
+	filter := &deadletter.Filter{
+		OutputIDs:  input.OutputIds,
+		AnalysisID: input.AnalysisID,
+	}
+	if input.Since != nil {
+		filter.Since = *input.Since
+	}
+	if input.Until != nil {
+		filter.Until = *input.Until
+	}
+	return filter
+}
+
+// recordDeadLetters - stores the failed deliveries that will not be retried, so they can be redelivered later.
+func recordDeadLetters(failedDispatchStatuses []DispatchStatus, maximumRetryCount int, store deadletter.API) {
+	if store == nil {
+		return
+	}
+	for i := range failedDispatchStatuses {
+		failed := &failedDispatchStatuses[i]
+		// Mirrors getAlertsToRetry, the other failures are put back on the queue
+		if failed.Alert.RetryCount < maximumRetryCount && failed.NeedsRetry {
+			continue
+		}
+
+		letter, err := deadletter.NewItem(failed.OutputID, &failed.Alert, failed.DispatchedAt)
+		if err != nil {
+			zap.L().Error("failed to create dead letter", zap.Any("status", failed), zap.Error(err))
+			continue
+		}
+		letter.StatusCode = failed.StatusCode
+		letter.Message = failed.Message
+		if err := store.Put(letter); err != nil {
+			zap.L().Error("failed to record dead letter", zap.Any("status", failed), zap.Error(err))
+		}
+	}
+}
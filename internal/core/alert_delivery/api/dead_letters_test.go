@@ -0,0 +1,204 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/sqs"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	deliverymodel "github.com/panther-labs/panther/api/lambda/delivery/models"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/deadletter"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+type mockDeadLetterStore struct {
+	deadletter.API
+	mock.Mock
+}
+
+func (m *mockDeadLetterStore) Put(letter *deadletter.Item) error {
+	args := m.Called(letter)
+	return args.Error(0)
+}
+
+func (m *mockDeadLetterStore) List(filter *deadletter.Filter) ([]*deadletter.Item, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]*deadletter.Item), args.Error(1)
+}
+
+func (m *mockDeadLetterStore) Delete(letters []*deadletter.Item) error {
+	args := m.Called(letters)
+	return args.Error(0)
+}
+
+func deadLetter(t *testing.T, outputID string, failedAt time.Time) *deadletter.Item {
+	alert := sampleAlert()
+	alert.RetryCount = 10
+	item, err := deadletter.NewItem(outputID, alert, failedAt)
+	require.NoError(t, err)
+	item.StatusCode = 503
+	item.Message = "request failed"
+	return item
+}
+
+func TestRecordDeadLetters(t *testing.T) {
+	store := &mockDeadLetterStore{}
+	dispatchedAt := time.Now().UTC()
+
+	exhausted := DispatchStatus{Alert: *sampleAlert(), OutputID: "output-1", StatusCode: 503,
+		Message: "unavailable", NeedsRetry: true, DispatchedAt: dispatchedAt}
+	exhausted.Alert.RetryCount = 10
+	permanent := DispatchStatus{Alert: *sampleAlert(), OutputID: "output-2", StatusCode: 400,
+		Message: "bad request", NeedsRetry: false, DispatchedAt: dispatchedAt}
+	retried := DispatchStatus{Alert: *sampleAlert(), OutputID: "output-3", StatusCode: 500,
+		Message: "error", NeedsRetry: true, DispatchedAt: dispatchedAt}
+
+	store.On("Put", mock.MatchedBy(func(letter *deadletter.Item) bool {
+		return letter.OutputID == "output-1" && letter.StatusCode == 503 && letter.Message == "unavailable" &&
+			letter.RetryCount == 10 && letter.FailedAt.Equal(dispatchedAt)
+	})).Return(nil).Once()
+	store.On("Put", mock.MatchedBy(func(letter *deadletter.Item) bool {
+		return letter.OutputID == "output-2" && letter.StatusCode == 400 && letter.AlertID == "alert-id"
+	})).Return(errors.New("ddb error")).Once()
+
+	recordDeadLetters([]DispatchStatus{exhausted, permanent, retried}, 10, store)
+	store.AssertExpectations(t)
+
+	// No store configured
+	recordDeadLetters([]DispatchStatus{exhausted}, 10, nil)
+}
+
+func TestListDeadLetters(t *testing.T) {
+	store := &mockDeadLetterStore{}
+	deadLetterStore = store
+	defer func() { deadLetterStore = nil }()
+
+	since := time.Date(2020, 12, 1, 0, 0, 0, 0, time.UTC)
+	older := deadLetter(t, "output-1", since.Add(time.Hour))
+	newer := deadLetter(t, "output-2", since.Add(2*time.Hour))
+	invalid := &deadletter.Item{OutputID: "output-3", AlertID: "invalid", FailedAt: since, Alert: "{"}
+
+	store.On("List", &deadletter.Filter{
+		OutputIDs:  []string{"output-1", "output-2", "output-3"},
+		AnalysisID: "test-rule-id",
+		Since:      since,
+	}).Return([]*deadletter.Item{older, invalid, newer}, nil).Once()
+
+	output, err := (API{}).ListDeadLetters(context.Background(), &deliverymodel.ListDeadLettersInput{
+		DeadLetterFilter: deliverymodel.DeadLetterFilter{
+			OutputIds:  []string{"output-1", "output-2", "output-3"},
+			AnalysisID: "test-rule-id",
+			Since:      &since,
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, output.DeadLetters, 3)
+	assert.Equal(t, "output-2", output.DeadLetters[0].OutputID)
+	assert.Equal(t, "output-1", output.DeadLetters[1].OutputID)
+	assert.Equal(t, 503, output.DeadLetters[1].StatusCode)
+	assert.Equal(t, "request failed", output.DeadLetters[1].Message)
+	assert.Equal(t, "test-rule-id", output.DeadLetters[1].Alert.AnalysisID)
+	assert.Equal(t, "invalid", output.DeadLetters[2].AlertID)
+	assert.Nil(t, output.DeadLetters[2].Alert)
+	store.AssertExpectations(t)
+}
+
+func TestRedeliverDeadLetters(t *testing.T) {
+	store := &mockDeadLetterStore{}
+	deadLetterStore = store
+	mockSQS := &testutils.SqsMock{}
+	sqsClient = mockSQS
+	env.AlertQueueURL = "alert-queue"
+	env.MinRetryDelaySecs = 5
+	env.MaxRetryDelaySecs = 6
+	defer func() { deadLetterStore = nil }()
+
+	letters := []*deadletter.Item{
+		deadLetter(t, "output-1", time.Now()),
+		deadLetter(t, "output-2", time.Now()),
+		{OutputID: "output-3", AlertID: "invalid", Alert: "{"},
+	}
+	store.On("List", &deadletter.Filter{OutputIDs: []string{"output-1"}}).Return(letters, nil).Once()
+	mockSQS.On("SendMessageBatch", mock.MatchedBy(func(input *sqs.SendMessageBatchInput) bool {
+		if *input.QueueUrl != "alert-queue" || len(input.Entries) != 2 {
+			return false
+		}
+		for i, outputID := range []string{"output-1", "output-2"} {
+			var alert deliverymodel.Alert
+			if err := jsoniter.UnmarshalFromString(*input.Entries[i].MessageBody, &alert); err != nil {
+				return false
+			}
+			if alert.RetryCount != 0 || len(alert.OutputIds) != 1 || alert.OutputIds[0] != outputID ||
+				*input.Entries[i].DelaySeconds != 5 {
+
+				return false
+			}
+		}
+		return true
+	})).Return(&sqs.SendMessageBatchOutput{}, nil).Once()
+	store.On("Delete", letters[:2]).Return(nil).Once()
+
+	output, err := (API{}).RedeliverDeadLetters(context.Background(), &deliverymodel.RedeliverDeadLettersInput{
+		DeadLetterFilter: deliverymodel.DeadLetterFilter{OutputIds: []string{"output-1"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &deliverymodel.RedeliverDeadLettersOutput{Redelivered: 2}, output)
+	store.AssertExpectations(t)
+	mockSQS.AssertExpectations(t)
+}
+
+func TestRedeliverDeadLettersQueueError(t *testing.T) {
+	store := &mockDeadLetterStore{}
+	deadLetterStore = store
+	mockSQS := &testutils.SqsMock{}
+	sqsClient = mockSQS
+	env.MinRetryDelaySecs = 5
+	env.MaxRetryDelaySecs = 6
+	defer func() { deadLetterStore = nil }()
+
+	store.On("List", &deadletter.Filter{}).Return([]*deadletter.Item{deadLetter(t, "output-1", time.Now())}, nil).Once()
+	mockSQS.On("SendMessageBatch", mock.Anything).Return(&sqs.SendMessageBatchOutput{}, errors.New("sqs error"))
+
+	output, err := (API{}).RedeliverDeadLetters(context.Background(), &deliverymodel.RedeliverDeadLettersInput{})
+	require.Error(t, err)
+	assert.Nil(t, output)
+	// The dead letters are kept if they could not be queued
+	store.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+func TestRedeliverDeadLettersNone(t *testing.T) {
+	store := &mockDeadLetterStore{}
+	deadLetterStore = store
+	defer func() { deadLetterStore = nil }()
+
+	store.On("List", &deadletter.Filter{}).Return([]*deadletter.Item{}, nil).Once()
+
+	output, err := (API{}).RedeliverDeadLetters(context.Background(), &deliverymodel.RedeliverDeadLettersInput{})
+	require.NoError(t, err)
+	assert.Equal(t, &deliverymodel.RedeliverDeadLettersOutput{}, output)
+	store.AssertExpectations(t)
+}
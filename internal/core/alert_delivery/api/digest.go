@@ -0,0 +1,256 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"go.uber.org/zap"
+
+	deliverymodel "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/deadletter"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/digest"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/outputs"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/throttle"
+)
+
+// digestScheduleTolerance allows digests to be sent slightly early so that a scheduled run
+// which fires a few seconds before the interval has elapsed does not postpone the digest.
+const digestScheduleTolerance = time.Minute
+
+// SendDigests - Sends the alerts waiting for the digest of each output once its interval has elapsed.
+func (API) SendDigests(ctx context.Context, _ *deliverymodel.SendDigestsInput) error {
+	alertOutputs, err := getOutputs()
+	if err != nil {
+		return err
+	}
+
+	dispatchStatuses := []DispatchStatus{}
+	for _, output := range alertOutputs {
+		if !output.Digest.Enabled() {
+			continue
+		}
+		dispatchStatuses = append(dispatchStatuses, sendOutputDigest(ctx, output, digestStore, deadLetterStore, outputLimiter, outputClient)...)
+	}
+
+	// Record the delivery statuses to ddb. Ignore the returned output.
+	updateAlerts(dispatchStatuses)
+	return nil
+}
+
+// sendOutputDigest - sends the digest of an output if it is due and removes the alerts that were delivered.
+//
+// Alerts of a digest that failed permanently are recorded as dead letters before they are removed.
+func sendOutputDigest(
+	ctx context.Context,
+	output *outputModels.AlertOutput,
+	store digest.API,
+	deadLetters deadletter.API,
+	limiter throttle.API,
+	outputClient outputs.API,
+) []DispatchStatus {
+
+	outputID := aws.StringValue(output.OutputID)
+	reservation, err := limiter.ReserveDigest(outputID, output.Digest.Interval()-digestScheduleTolerance)
+	if err != nil {
+		zap.L().Error("failed to check output digest", zap.String("outputID", outputID), zap.Error(err))
+		return nil
+	}
+	if reservation == nil {
+		return nil
+	}
+	// The digest is only recorded as sent once the alerts were delivered
+	sent := false
+	defer func() {
+		var err error
+		if sent {
+			err = limiter.CommitDigest(reservation)
+		} else {
+			err = limiter.ReleaseDigest(reservation)
+		}
+		if err != nil {
+			zap.L().Error("failed to update output digest", zap.String("outputID", outputID), zap.Error(err))
+		}
+	}()
+
+	items, err := store.List(outputID)
+	if err != nil {
+		zap.L().Error("failed to list output digest", zap.String("outputID", outputID), zap.Error(err))
+		return nil
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	alerts, errs := digest.Alerts(items)
+	for _, err := range errs {
+		zap.L().Error("invalid alert in output digest", zap.String("outputID", outputID), zap.Error(err))
+	}
+
+	var dispatchStatuses []DispatchStatus
+	if len(alerts) > 0 {
+		zap.L().Debug("sending output digest", zap.String("outputID", outputID), zap.Int("num_alerts", len(alerts)))
+		dispatchStatuses = deliverDigest(ctx, output, outputs.NewDigest(alerts), outputClient)
+		// Keep the alerts for the next digest if delivery can be retried
+		if len(dispatchStatuses) > 0 && dispatchStatuses[0].NeedsRetry {
+			return dispatchStatuses
+		}
+	}
+
+	// Keep the failed deliveries so they can be redelivered once the output recovers
+	_, failed := filterDispatches(dispatchStatuses)
+	recordDeadLetters(failed, env.AlertRetryCount, deadLetters)
+
+	if err := store.Delete(items); err != nil {
+		zap.L().Error("failed to delete output digest", zap.String("outputID", outputID), zap.Error(err))
+	}
+	sent = len(dispatchStatuses) > 0 && dispatchStatuses[0].Success
+	return dispatchStatuses
+}
+
+// deferDigests - stores the alerts of outputs in digest mode until their next digest.
+//
+// It returns the alert -> output mappings that should be delivered right away. If an alert
+// cannot be stored it is delivered right away instead.
+func deferDigests(alertOutputs AlertOutputMap, store digest.API) AlertOutputMap {
+	if store == nil {
+		return alertOutputs
+	}
+
+	immediate := make(AlertOutputMap, len(alertOutputs))
+	for alert, alertOutputs := range alertOutputs {
+		for _, output := range alertOutputs {
+			if output.Digest.Enabled() {
+				err := store.Add(*output.OutputID, alert)
+				if err == nil {
+					continue
+				}
+				zap.L().Error("failed to add alert to output digest", zap.Stringp("outputID", output.OutputID), zap.Error(err))
+			}
+			immediate[alert] = append(immediate[alert], output)
+		}
+	}
+	return immediate
+}
+
+// deliverDigest - sends the scheduled digest of an output as a single notification.
+//
+// The delivery status of the digest is recorded for each of the alerts in it.
+func deliverDigest(
+	ctx context.Context,
+	output *outputModels.AlertOutput,
+	digest *outputs.Digest,
+	outputClient outputs.API,
+) []DispatchStatus {
+
+	dispatchedAt := time.Now().UTC()
+	response := (*outputs.AlertDeliveryResponse)(nil)
+	switch *output.OutputType {
+	case "slack":
+		response = outputClient.SlackDigest(ctx, digest, output.OutputConfig.Slack)
+	case "msteams":
+		response = outputClient.MsTeamsDigest(ctx, digest, output.OutputConfig.MsTeams)
+	case "jira":
+		response = outputClient.JiraDigest(ctx, digest, output.OutputConfig.Jira)
+	case "customwebhook":
+		response = outputClient.CustomWebhookDigest(ctx, digest, output.OutputConfig.CustomWebhook)
+	default:
+		// Outputs without a digest format receive the digest as a single alert
+		statusChannel := make(chan DispatchStatus, 1)
+		go sendAlert(ctx, rollupDigest(output, digest), output, dispatchedAt, statusChannel, outputClient)
+		return digestStatuses(digest, <-statusChannel)
+	}
+
+	if response == nil {
+		zap.L().Warn("output response is nil", zap.Stringp("outputID", output.OutputID))
+		return digestStatuses(digest, DispatchStatus{
+			OutputID:     *output.OutputID,
+			StatusCode:   500,
+			Success:      false,
+			Message:      "output response is nil",
+			NeedsRetry:   false,
+			DispatchedAt: dispatchedAt,
+		})
+	}
+
+	// Retry only if not successful and we don't have a permanent failure
+	return digestStatuses(digest, DispatchStatus{
+		OutputID:     *output.OutputID,
+		StatusCode:   response.StatusCode,
+		Success:      response.Success && !response.Permanent,
+		Message:      response.Message,
+		NeedsRetry:   !response.Success && !response.Permanent,
+		DispatchedAt: dispatchedAt,
+	})
+}
+
+// digestStatuses - copies the delivery status of a digest to each of its alerts
+func digestStatuses(digest *outputs.Digest, status DispatchStatus) []DispatchStatus {
+	dispatchStatuses := make([]DispatchStatus, 0, len(digest.Alerts))
+	for _, alert := range digest.Alerts {
+		alertStatus := status
+		alertStatus.Alert = *alert
+		if status.Success {
+			alertStatus.Message = fmt.Sprintf("delivered in a digest of %d alerts: %s", len(digest.Alerts), status.Message)
+		} else {
+			alertStatus.Message = fmt.Sprintf("failed to deliver a digest of %d alerts: %s", len(digest.Alerts), status.Message)
+		}
+		dispatchStatuses = append(dispatchStatuses, alertStatus)
+	}
+	return dispatchStatuses
+}
+
+// rollupDigest - creates a single alert summarizing a digest for outputs without a digest format
+func rollupDigest(output *outputModels.AlertOutput, digest *outputs.Digest) *deliverymodel.Alert {
+	first := digest.Alerts[0]
+	rollup := &deliverymodel.Alert{
+		AnalysisID: first.AnalysisID,
+		Type:       first.Type,
+		CreatedAt:  first.CreatedAt,
+		Severity:   digest.Severity(),
+		OutputIds:  []string{*output.OutputID},
+		AlertID:    first.AlertID,
+		Title:      digest.Title,
+	}
+	if rollup.Title == "" {
+		rollup.Title = fmt.Sprintf("%d alerts in the digest of %s", len(digest.Alerts), aws.StringValue(output.DisplayName))
+	}
+
+	var description strings.Builder
+	for _, group := range digest.Groups() {
+		fmt.Fprintf(&description, "%s: %d alerts\n", group.Severity, group.Count)
+		for _, rule := range group.Rules {
+			fmt.Fprintf(&description, "- %s: %d\n", rule.Name, rule.Count)
+		}
+	}
+	rollup.AnalysisDescription = strings.TrimSuffix(description.String(), "\n")
+
+	alertIDs := make([]string, 0, len(digest.Alerts))
+	for _, alert := range digest.Alerts {
+		alertIDs = append(alertIDs, aws.StringValue(alert.AlertID))
+	}
+	rollup.Context = map[string]interface{}{
+		"alertIds": alertIDs,
+	}
+	return rollup
+}
@@ -0,0 +1,246 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	deliverymodel "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/deadletter"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/digest"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/outputs"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/throttle"
+)
+
+type mockDigestStore struct {
+	digest.API
+	mock.Mock
+}
+
+func (m *mockDigestStore) Add(outputID string, alert *deliverymodel.Alert) error {
+	args := m.Called(outputID, alert)
+	return args.Error(0)
+}
+
+func (m *mockDigestStore) List(outputID string) ([]*digest.Item, error) {
+	args := m.Called(outputID)
+	return args.Get(0).([]*digest.Item), args.Error(1)
+}
+
+func (m *mockDigestStore) Delete(items []*digest.Item) error {
+	args := m.Called(items)
+	return args.Error(0)
+}
+
+var testReservation = &throttle.DigestReservation{
+	OutputID:   "output-id",
+	ReservedAt: 1606816800000,
+}
+
+func digestOutput(outputType string) *outputModels.AlertOutput {
+	return &outputModels.AlertOutput{
+		OutputID:    aws.String("output-id"),
+		OutputType:  aws.String(outputType),
+		DisplayName: aws.String("alerts"),
+		OutputConfig: &outputModels.OutputConfig{
+			Slack: &outputModels.SlackConfig{WebhookURL: "https://slack.com"},
+		},
+		Digest: &outputModels.Digest{IntervalMinutes: 15},
+	}
+}
+
+func digestItems(t *testing.T, alerts ...*deliverymodel.Alert) []*digest.Item {
+	items := make([]*digest.Item, 0, len(alerts))
+	for _, alert := range alerts {
+		body, err := jsoniter.MarshalToString(alert)
+		require.NoError(t, err)
+		items = append(items, &digest.Item{
+			OutputID: "output-id",
+			AlertKey: *alert.AlertID,
+			Alert:    body,
+		})
+	}
+	return items
+}
+
+func TestDeferDigests(t *testing.T) {
+	store := &mockDigestStore{}
+	output := digestOutput("slack")
+	failedOutput := digestOutput("slack")
+	failedOutput.OutputID = aws.String("failed-output-id")
+	immediateOutput := &outputModels.AlertOutput{OutputID: aws.String("immediate-output-id")}
+
+	alert := sampleAlert()
+	store.On("Add", "output-id", alert).Return(nil).Once()
+	store.On("Add", "failed-output-id", alert).Return(errors.New("failed")).Once()
+
+	immediate := deferDigests(AlertOutputMap{
+		alert: {output, failedOutput, immediateOutput},
+	}, store)
+	store.AssertExpectations(t)
+
+	// Alerts that could not be added to the digest are delivered right away
+	assert.Equal(t, AlertOutputMap{
+		alert: {failedOutput, immediateOutput},
+	}, immediate)
+}
+
+func TestSendOutputDigest(t *testing.T) {
+	store := &mockDigestStore{}
+	limiter := &mockLimiter{}
+	outputClient := &mockOutputsClient{}
+	output := digestOutput("slack")
+
+	alert := sampleAlert()
+	alert.CreatedAt = alert.CreatedAt.Truncate(time.Second)
+	items := digestItems(t, alert)
+
+	limiter.On("ReserveDigest", "output-id", 14*time.Minute).Return(testReservation, nil).Once()
+	store.On("List", "output-id").Return(items, nil).Once()
+	outputClient.On("SlackDigest", mock.Anything, mock.MatchedBy(func(digest *outputs.Digest) bool {
+		return len(digest.Alerts) == 1 && *digest.Alerts[0].AlertID == "alert-id"
+	}), output.OutputConfig.Slack).Return(&outputs.AlertDeliveryResponse{
+		StatusCode: 200,
+		Success:    true,
+		Message:    "ok",
+	}).Once()
+	store.On("Delete", items).Return(nil).Once()
+	limiter.On("CommitDigest", testReservation).Return(nil).Once()
+
+	statuses := sendOutputDigest(context.Background(), output, store, nil, limiter, outputClient)
+	limiter.AssertExpectations(t)
+	store.AssertExpectations(t)
+	outputClient.AssertExpectations(t)
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "alert-id", *statuses[0].Alert.AlertID)
+	assert.True(t, statuses[0].Success)
+	assert.Equal(t, "delivered in a digest of 1 alerts: ok", statuses[0].Message)
+}
+
+func TestSendOutputDigestNotDue(t *testing.T) {
+	store := &mockDigestStore{}
+	limiter := &mockLimiter{}
+
+	limiter.On("ReserveDigest", "output-id", 14*time.Minute).Return((*throttle.DigestReservation)(nil), nil).Once()
+	statuses := sendOutputDigest(context.Background(), digestOutput("slack"), store, nil, limiter, &mockOutputsClient{})
+	assert.Empty(t, statuses)
+	limiter.AssertExpectations(t)
+	store.AssertExpectations(t)
+}
+
+func TestSendOutputDigestRetry(t *testing.T) {
+	store := &mockDigestStore{}
+	limiter := &mockLimiter{}
+	outputClient := &mockOutputsClient{}
+	output := digestOutput("slack")
+
+	limiter.On("ReserveDigest", "output-id", 14*time.Minute).Return(testReservation, nil).Once()
+	store.On("List", "output-id").Return(digestItems(t, sampleAlert()), nil).Once()
+	outputClient.On("SlackDigest", mock.Anything, mock.Anything, output.OutputConfig.Slack).Return(&outputs.AlertDeliveryResponse{
+		StatusCode: 503,
+		Success:    false,
+		Message:    "unavailable",
+	}).Once()
+	// The digest is retried on the next run
+	limiter.On("ReleaseDigest", testReservation).Return(nil).Once()
+
+	// The alerts are kept for the next digest
+	statuses := sendOutputDigest(context.Background(), output, store, nil, limiter, outputClient)
+	require.Len(t, statuses, 1)
+	assert.True(t, statuses[0].NeedsRetry)
+	assert.False(t, statuses[0].Success)
+	assert.Equal(t, "failed to deliver a digest of 1 alerts: unavailable", statuses[0].Message)
+	limiter.AssertExpectations(t)
+	store.AssertExpectations(t)
+	outputClient.AssertExpectations(t)
+}
+
+func TestSendOutputDigestPermanentFailure(t *testing.T) {
+	store := &mockDigestStore{}
+	deadLetters := &mockDeadLetterStore{}
+	limiter := &mockLimiter{}
+	outputClient := &mockOutputsClient{}
+	output := digestOutput("slack")
+
+	items := digestItems(t, sampleAlert())
+	limiter.On("ReserveDigest", "output-id", 14*time.Minute).Return(testReservation, nil).Once()
+	store.On("List", "output-id").Return(items, nil).Once()
+	outputClient.On("SlackDigest", mock.Anything, mock.Anything, output.OutputConfig.Slack).Return(&outputs.AlertDeliveryResponse{
+		StatusCode: 404,
+		Success:    false,
+		Message:    "not found",
+		Permanent:  true,
+	}).Once()
+	// The alerts are recorded as dead letters before they are removed from the digest
+	deadLetters.On("Put", mock.MatchedBy(func(letter *deadletter.Item) bool {
+		return letter.OutputID == "output-id" && letter.AlertID == "alert-id" && letter.StatusCode == 404
+	})).Return(nil).Once()
+	store.On("Delete", items).Return(nil).Once()
+	limiter.On("ReleaseDigest", testReservation).Return(nil).Once()
+
+	statuses := sendOutputDigest(context.Background(), output, store, deadLetters, limiter, outputClient)
+	require.Len(t, statuses, 1)
+	assert.False(t, statuses[0].NeedsRetry)
+	assert.False(t, statuses[0].Success)
+	limiter.AssertExpectations(t)
+	store.AssertExpectations(t)
+	deadLetters.AssertExpectations(t)
+	outputClient.AssertExpectations(t)
+}
+
+func TestSendOutputDigestEmpty(t *testing.T) {
+	store := &mockDigestStore{}
+	limiter := &mockLimiter{}
+
+	limiter.On("ReserveDigest", "output-id", 14*time.Minute).Return(testReservation, nil).Once()
+	store.On("List", "output-id").Return([]*digest.Item{}, nil).Once()
+	// Nothing was sent so the next alert does not wait for another interval
+	limiter.On("ReleaseDigest", testReservation).Return(nil).Once()
+
+	statuses := sendOutputDigest(context.Background(), digestOutput("slack"), store, nil, limiter, &mockOutputsClient{})
+	assert.Empty(t, statuses)
+	limiter.AssertExpectations(t)
+	store.AssertExpectations(t)
+}
+
+func TestRollupDigest(t *testing.T) {
+	low, high := sampleAlert(), sampleAlert()
+	low.Severity = "LOW"
+	high.AlertID = aws.String("other-alert-id")
+	high.Severity = "HIGH"
+	high.AnalysisID = "other-rule-id"
+	high.AnalysisName = nil
+
+	rollup := rollupDigest(digestOutput("pagerduty"), outputs.NewDigest([]*deliverymodel.Alert{low, high}))
+	assert.Equal(t, "HIGH", rollup.Severity)
+	assert.Equal(t, "2 alerts in the digest of alerts", rollup.Title)
+	assert.Equal(t, "HIGH: 1 alerts\n- other-rule-id: 1\nLOW: 1 alerts\n- test_rule_name: 1", rollup.AnalysisDescription)
+	assert.Equal(t, []string{"output-id"}, rollup.OutputIds)
+	assert.Equal(t, map[string]interface{}{"alertIds": []string{"alert-id", "other-alert-id"}}, rollup.Context)
+}
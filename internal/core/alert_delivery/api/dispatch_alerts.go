@@ -44,9 +44,18 @@ func (API) DispatchAlerts(ctx context.Context, input []*deliverymodel.DispatchAl
 		return nil, err
 	}
 
+	// Hold back alerts for outputs that receive them in a digest
+	alertOutputMap = deferDigests(alertOutputMap, digestStore)
+
+	// Hold back alerts that exceed the rate limit of their outputs
+	alertOutputMap, throttled := throttleAlerts(alertOutputMap, outputLimiter)
+
 	// Send alerts to the specified destination(s) and obtain each response status
 	dispatchStatuses := sendAlerts(ctx, alertOutputMap, outputClient)
 
+	// Queue the throttled alerts or roll them up into a digest
+	dispatchStatuses = append(dispatchStatuses, handleThrottled(ctx, throttled, outputLimiter, outputClient)...)
+
 	// Record the delivery statuses to ddb. Ignore the returned output.
 	updateAlerts(dispatchStatuses)
 	zap.L().Debug("Finished updating alert delivery statuses")
@@ -61,6 +70,9 @@ func (API) DispatchAlerts(ctx context.Context, input []*deliverymodel.DispatchAl
 	// Put any alerts that need to be retried back into the queue
 	retry(alertsToRetry, env.AlertQueueURL, env.MinRetryDelaySecs, env.MaxRetryDelaySecs)
 
+	// Keep the deliveries that will not be retried so they can be redelivered once the output recovers
+	recordDeadLetters(failed, env.AlertRetryCount, deadLetterStore)
+
 	return nil, err
 }
 
@@ -160,6 +160,16 @@ func sendAlert(
 		response = outputClient.Asana(ctx, alert, output.OutputConfig.Asana)
 	case "customwebhook":
 		response = outputClient.CustomWebhook(ctx, alert, output.OutputConfig.CustomWebhook)
+	case "email":
+		response = outputClient.Email(ctx, alert, output.OutputConfig.Email)
+	case "splunk":
+		response = outputClient.Splunk(ctx, alert, output.OutputConfig.Splunk)
+	case "elasticsearch":
+		response = outputClient.Elasticsearch(ctx, alert, output.OutputConfig.Elasticsearch)
+	case "googlechat":
+		response = outputClient.GoogleChat(ctx, alert, output.OutputConfig.GoogleChat)
+	case "servicenow":
+		response = outputClient.ServiceNow(ctx, alert, output.OutputConfig.ServiceNow)
 	default:
 		zap.L().Warn("unsupported output type", commonFields...)
 		statusChannel <- DispatchStatus{
@@ -26,6 +26,8 @@ import (
 	"go.uber.org/zap"
 
 	deliverymodel "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/outputs"
 )
 
 // SendTestAlert sends a dummy alert to the specified destinations.
@@ -42,6 +44,10 @@ func (API) SendTestAlert(ctx context.Context, input *deliverymodel.SendTestAlert
 		return nil, err
 	}
 
+	if input.Preview {
+		return previewTemplates(alert, alertOutputMap[alert]), nil
+	}
+
 	// Send alerts to the specified destination(s) and obtain each response status
 	dispatchStatuses := sendAlerts(ctx, alertOutputMap, outputClient)
 
@@ -60,6 +66,36 @@ func (API) SendTestAlert(ctx context.Context, input *deliverymodel.SendTestAlert
 	return responseStatuses, nil
 }
 
+// previewTemplates - renders the notification template of each output for an alert
+func previewTemplates(alert *deliverymodel.Alert, alertOutputs []*outputModels.AlertOutput) []*deliverymodel.SendTestAlertOutput {
+	previews := make([]*deliverymodel.SendTestAlertOutput, 0, len(alertOutputs))
+	for _, output := range alertOutputs {
+		preview := &deliverymodel.SendTestAlertOutput{
+			OutputID:     *output.OutputID,
+			StatusCode:   200,
+			Success:      true,
+			DispatchedAt: time.Now().UTC(),
+		}
+		if !output.OutputConfig.SupportsTemplate() {
+			preview.StatusCode = 400
+			preview.Success = false
+			preview.Message = "notification templates are not supported by " + aws.StringValue(output.OutputType) + " outputs"
+			previews = append(previews, preview)
+			continue
+		}
+		title, body, err := outputs.RenderTemplate(output.OutputConfig.Template(), alert)
+		if err != nil {
+			preview.StatusCode = 400
+			preview.Success = false
+			preview.Message = err.Error()
+		} else {
+			preview.Preview = &deliverymodel.NotificationPreview{Title: title, Body: body}
+		}
+		previews = append(previews, preview)
+	}
+	return previews
+}
+
 // generateTestAlert - genreates an alert with dummy values
 func generateTestAlert() *deliverymodel.Alert {
 	return &deliverymodel.Alert{
@@ -0,0 +1,250 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	jsoniter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+
+	deliverymodel "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/outputs"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/throttle"
+)
+
+const (
+	// maxSQSDelaySecs is the maximum delay SQS allows for a message
+	maxSQSDelaySecs = 900
+	// digestInterval is the minimum time between two digest notifications of a rate limited output
+	digestInterval = time.Minute
+)
+
+// severityOrder is used to rank alerts in a digest
+var severityOrder = map[string]int{
+	"INFO":     0,
+	"LOW":      1,
+	"MEDIUM":   2,
+	"HIGH":     3,
+	"CRITICAL": 4,
+}
+
+// throttledAlerts holds the alerts that exceeded the rate limit of an output
+type throttledAlerts struct {
+	Output *outputModels.AlertOutput
+	Alerts []*deliverymodel.Alert
+	// Wait is the time until the output has budget again
+	Wait time.Duration
+}
+
+// throttleAlerts - takes alerts from the budget of each rate limited output.
+//
+// It returns the alert -> output mappings that are within budget and the alerts
+// that exceeded the budget of each output. Older alerts are sent first.
+func throttleAlerts(alertOutputs AlertOutputMap, limiter throttle.API) (AlertOutputMap, []*throttledAlerts) {
+	if limiter == nil {
+		return alertOutputs, nil
+	}
+
+	// Group the alerts by rate limited output
+	limitedOutputs := make(map[string]*outputModels.AlertOutput)
+	limitedAlerts := make(map[string][]*deliverymodel.Alert)
+	allowed := make(AlertOutputMap, len(alertOutputs))
+	for alert, alertOutputs := range alertOutputs {
+		for _, output := range alertOutputs {
+			if !output.RateLimit.Enabled() {
+				allowed[alert] = append(allowed[alert], output)
+				continue
+			}
+			limitedOutputs[*output.OutputID] = output
+			limitedAlerts[*output.OutputID] = append(limitedAlerts[*output.OutputID], alert)
+		}
+	}
+
+	throttled := []*throttledAlerts{}
+	for outputID, alerts := range limitedAlerts {
+		output := limitedOutputs[outputID]
+		sort.SliceStable(alerts, func(i, j int) bool {
+			return alerts[i].CreatedAt.Before(alerts[j].CreatedAt)
+		})
+		taken, wait, err := limiter.Take(outputID, output.RateLimit, len(alerts))
+		if err != nil {
+			// Prefer delivering alerts over enforcing the limit
+			zap.L().Error("failed to check output rate limit", zap.String("outputID", outputID), zap.Error(err))
+			taken = len(alerts)
+		}
+		for _, alert := range alerts[:taken] {
+			allowed[alert] = append(allowed[alert], output)
+		}
+		if taken < len(alerts) {
+			zap.L().Warn("output rate limit exceeded",
+				zap.String("outputID", outputID),
+				zap.Int("numThrottled", len(alerts)-taken),
+			)
+			throttled = append(throttled, &throttledAlerts{
+				Output: output,
+				Alerts: alerts[taken:],
+				Wait:   wait,
+			})
+		}
+	}
+	return allowed, throttled
+}
+
+// handleThrottled - queues or rolls up the alerts that exceeded the rate limit of their outputs.
+//
+// It returns the statuses of alerts that were delivered in a digest.
+func handleThrottled(
+	ctx context.Context,
+	throttled []*throttledAlerts,
+	limiter throttle.API,
+	outputClient outputs.API,
+) []DispatchStatus {
+
+	dispatchStatuses := []DispatchStatus{}
+	queued := []*sqs.SendMessageBatchRequestEntry{}
+	for _, t := range throttled {
+		if t.Output.RateLimit.Overflow == outputModels.RateLimitOverflowDigest {
+			sent, wait, err := limiter.TakeDigest(*t.Output.OutputID, digestInterval)
+			if err != nil {
+				zap.L().Error("failed to check output digest", zap.Stringp("outputID", t.Output.OutputID), zap.Error(err))
+			}
+			if sent {
+				dispatchStatuses = append(dispatchStatuses, sendDigest(ctx, t.Output, t.Alerts, outputClient)...)
+				continue
+			}
+			// Wait for the next digest if it comes later than the next token
+			if wait > t.Wait {
+				t.Wait = wait
+			}
+		}
+		queued = append(queued, createThrottledEntries(t, len(queued))...)
+	}
+
+	if len(queued) > 0 {
+		sendToSQS(&sqs.SendMessageBatchInput{
+			Entries:  queued,
+			QueueUrl: aws.String(env.AlertQueueURL),
+		})
+	}
+	return dispatchStatuses
+}
+
+// createThrottledEntries - creates the queue entries to deliver throttled alerts once the output has budget again.
+//
+// Each alert is delayed by the time it takes for the output to refill one more token
+// so that queued alerts do not exceed the budget again when they are received.
+func createThrottledEntries(t *throttledAlerts, offset int) []*sqs.SendMessageBatchRequestEntry {
+	interval := time.Minute / time.Duration(t.Output.RateLimit.AlertsPerMinute)
+	entries := make([]*sqs.SendMessageBatchRequestEntry, 0, len(t.Alerts))
+	for i, alert := range t.Alerts {
+		// Create a shallow copy to mutate. The retry count is not incremented since delivery was not attempted.
+		queuedAlert := *alert
+		queuedAlert.OutputIds = []string{*t.Output.OutputID}
+		body, err := jsoniter.MarshalToString(queuedAlert)
+		if err != nil {
+			zap.L().Panic("error encoding alert as JSON", zap.Error(err))
+		}
+		delay := t.Wait + time.Duration(i)*interval
+		delaySecs := int64(math.Min(math.Ceil(delay.Seconds()), maxSQSDelaySecs))
+		entries = append(entries, createEntry(body, offset+i, delaySecs))
+	}
+	return entries
+}
+
+// sendDigest - sends a single notification summarizing the alerts to the output.
+//
+// The delivery status of the digest is recorded for each of the alerts in it.
+func sendDigest(
+	ctx context.Context,
+	output *outputModels.AlertOutput,
+	alerts []*deliverymodel.Alert,
+	outputClient outputs.API,
+) []DispatchStatus {
+
+	digest := rollupAlerts(output, alerts)
+	statusChannel := make(chan DispatchStatus, 1)
+	go sendAlert(ctx, digest, output, time.Now().UTC(), statusChannel, outputClient)
+	status := <-statusChannel
+
+	dispatchStatuses := make([]DispatchStatus, 0, len(alerts))
+	for _, alert := range alerts {
+		alertStatus := status
+		alertStatus.Alert = *alert
+		alertStatus.Message = fmt.Sprintf("delivered in a digest of %d alerts: %s", len(alerts), status.Message)
+		dispatchStatuses = append(dispatchStatuses, alertStatus)
+	}
+	return dispatchStatuses
+}
+
+// rollupAlerts - creates an alert summarizing alerts that exceeded the rate limit of an output
+func rollupAlerts(output *outputModels.AlertOutput, alerts []*deliverymodel.Alert) *deliverymodel.Alert {
+	first := alerts[0]
+	digest := &deliverymodel.Alert{
+		AnalysisID: first.AnalysisID,
+		Type:       first.Type,
+		CreatedAt:  first.CreatedAt,
+		Severity:   first.Severity,
+		OutputIds:  []string{*output.OutputID},
+		AlertID:    first.AlertID,
+		Title:      fmt.Sprintf("%d alerts were rolled up by the rate limit of %s", len(alerts), aws.StringValue(output.DisplayName)),
+	}
+
+	alertIDs := make([]string, 0, len(alerts))
+	bySeverity := make(map[string]int)
+	byRule := make(map[string]int)
+	for _, alert := range alerts {
+		alertIDs = append(alertIDs, aws.StringValue(alert.AlertID))
+		bySeverity[alert.Severity]++
+		byRule[alert.AnalysisID]++
+		if severityOrder[alert.Severity] > severityOrder[digest.Severity] {
+			digest.Severity = alert.Severity
+		}
+	}
+
+	var description strings.Builder
+	description.WriteString("Alerts by severity:")
+	for _, severity := range []string{"CRITICAL", "HIGH", "MEDIUM", "LOW", "INFO"} {
+		if n := bySeverity[severity]; n > 0 {
+			fmt.Fprintf(&description, " %s: %d", severity, n)
+		}
+	}
+	ruleIDs := make([]string, 0, len(byRule))
+	for ruleID := range byRule {
+		ruleIDs = append(ruleIDs, ruleID)
+	}
+	sort.Strings(ruleIDs)
+	description.WriteString("\nAlerts by detection:")
+	for _, ruleID := range ruleIDs {
+		fmt.Fprintf(&description, "\n%s: %d", ruleID, byRule[ruleID])
+	}
+	digest.AnalysisDescription = description.String()
+	digest.Context = map[string]interface{}{
+		"alertIds": alertIDs,
+	}
+	return digest
+}
@@ -0,0 +1,228 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	deliverymodel "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/outputs"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/throttle"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+type mockLimiter struct {
+	throttle.API
+	mock.Mock
+}
+
+func (m *mockLimiter) Take(outputID string, limit *outputModels.RateLimit, n int) (int, time.Duration, error) {
+	args := m.Called(outputID, limit, n)
+	return args.Int(0), args.Get(1).(time.Duration), args.Error(2)
+}
+
+func (m *mockLimiter) TakeDigest(outputID string, interval time.Duration) (bool, time.Duration, error) {
+	args := m.Called(outputID, interval)
+	return args.Bool(0), args.Get(1).(time.Duration), args.Error(2)
+}
+
+func (m *mockLimiter) ReserveDigest(outputID string, interval time.Duration) (*throttle.DigestReservation, error) {
+	args := m.Called(outputID, interval)
+	return args.Get(0).(*throttle.DigestReservation), args.Error(1)
+}
+
+func (m *mockLimiter) CommitDigest(r *throttle.DigestReservation) error {
+	return m.Called(r).Error(0)
+}
+
+func (m *mockLimiter) ReleaseDigest(r *throttle.DigestReservation) error {
+	return m.Called(r).Error(0)
+}
+
+func rateLimitedOutput(overflow string) *outputModels.AlertOutput {
+	return &outputModels.AlertOutput{
+		OutputID:    aws.String("output-id"),
+		OutputType:  aws.String("slack"),
+		DisplayName: aws.String("slack:alerts"),
+		OutputConfig: &outputModels.OutputConfig{
+			Slack: &outputModels.SlackConfig{WebhookURL: "https://slack.com"},
+		},
+		RateLimit: &outputModels.RateLimit{
+			AlertsPerMinute: 6,
+			Burst:           1,
+			Overflow:        overflow,
+		},
+	}
+}
+
+func TestThrottleAlerts(t *testing.T) {
+	limiter := &mockLimiter{}
+	limited := rateLimitedOutput(outputModels.RateLimitOverflowQueue)
+	unlimited := &outputModels.AlertOutput{OutputID: aws.String("unlimited-output-id")}
+
+	first, second := sampleAlert(), sampleAlert()
+	first.CreatedAt = second.CreatedAt.Add(-time.Minute)
+	alertOutputs := AlertOutputMap{
+		second: {limited, unlimited},
+		first:  {limited},
+	}
+
+	limiter.On("Take", "output-id", limited.RateLimit, 2).Return(1, 10*time.Second, nil).Once()
+	allowed, throttled := throttleAlerts(alertOutputs, limiter)
+	limiter.AssertExpectations(t)
+
+	// The oldest alert is sent first
+	assert.Equal(t, AlertOutputMap{
+		first:  {limited},
+		second: {unlimited},
+	}, allowed)
+	assert.Equal(t, []*throttledAlerts{
+		{
+			Output: limited,
+			Alerts: []*deliverymodel.Alert{second},
+			Wait:   10 * time.Second,
+		},
+	}, throttled)
+}
+
+func TestThrottleAlertsNoLimiter(t *testing.T) {
+	alertOutputs := AlertOutputMap{
+		sampleAlert(): {rateLimitedOutput(outputModels.RateLimitOverflowQueue)},
+	}
+	allowed, throttled := throttleAlerts(alertOutputs, nil)
+	assert.Equal(t, alertOutputs, allowed)
+	assert.Empty(t, throttled)
+}
+
+func TestHandleThrottledQueue(t *testing.T) {
+	mockSQS := &testutils.SqsMock{}
+	sqsClient = mockSQS
+	env.AlertQueueURL = "sqs-url"
+
+	alert := sampleAlert()
+	alert.OutputIds = nil
+	throttled := []*throttledAlerts{
+		{
+			Output: rateLimitedOutput(outputModels.RateLimitOverflowQueue),
+			Alerts: []*deliverymodel.Alert{alert, alert},
+			Wait:   5 * time.Second,
+		},
+	}
+
+	queuedAlert := *alert
+	queuedAlert.OutputIds = []string{"output-id"}
+	body, err := jsoniter.MarshalToString(queuedAlert)
+	require.NoError(t, err)
+	input := &sqs.SendMessageBatchInput{
+		Entries: []*sqs.SendMessageBatchRequestEntry{
+			{
+				DelaySeconds: aws.Int64(5),
+				Id:           aws.String("0"),
+				MessageBody:  aws.String(body),
+			},
+			{
+				DelaySeconds: aws.Int64(15),
+				Id:           aws.String("1"),
+				MessageBody:  aws.String(body),
+			},
+		},
+		QueueUrl: aws.String("sqs-url"),
+	}
+	mockSQS.On("SendMessageBatch", input).Return(&sqs.SendMessageBatchOutput{}, nil).Once()
+
+	statuses := handleThrottled(context.Background(), throttled, &mockLimiter{}, &mockOutputsClient{})
+	assert.Empty(t, statuses)
+	mockSQS.AssertExpectations(t)
+}
+
+func TestHandleThrottledDigest(t *testing.T) {
+	limiter := &mockLimiter{}
+	outputClient := &mockOutputsClient{}
+	output := rateLimitedOutput(outputModels.RateLimitOverflowDigest)
+
+	low, high := sampleAlert(), sampleAlert()
+	low.Severity = "LOW"
+	high.AlertID = aws.String("other-alert-id")
+	high.Severity = "HIGH"
+	throttled := []*throttledAlerts{
+		{
+			Output: output,
+			Alerts: []*deliverymodel.Alert{low, high},
+			Wait:   5 * time.Second,
+		},
+	}
+
+	limiter.On("TakeDigest", "output-id", digestInterval).Return(true, time.Duration(0), nil).Once()
+	outputClient.On("Slack", mock.Anything, mock.MatchedBy(func(alert *deliverymodel.Alert) bool {
+		return alert.Severity == "HIGH" &&
+			alert.Title == "2 alerts were rolled up by the rate limit of slack:alerts" &&
+			alert.AnalysisDescription == "Alerts by severity: HIGH: 1 LOW: 1\nAlerts by detection:\ntest-rule-id: 2"
+	}), output.OutputConfig.Slack).Return(&outputs.AlertDeliveryResponse{
+		StatusCode: 200,
+		Success:    true,
+		Message:    "ok",
+	}).Once()
+
+	statuses := handleThrottled(context.Background(), throttled, limiter, outputClient)
+	limiter.AssertExpectations(t)
+	outputClient.AssertExpectations(t)
+	require.Len(t, statuses, 2)
+	for i, alert := range []*deliverymodel.Alert{low, high} {
+		assert.Equal(t, *alert, statuses[i].Alert)
+		assert.Equal(t, "output-id", statuses[i].OutputID)
+		assert.True(t, statuses[i].Success)
+		assert.Equal(t, "delivered in a digest of 2 alerts: ok", statuses[i].Message)
+	}
+}
+
+func TestHandleThrottledDigestWait(t *testing.T) {
+	mockSQS := &testutils.SqsMock{}
+	sqsClient = mockSQS
+	env.AlertQueueURL = "sqs-url"
+	limiter := &mockLimiter{}
+
+	throttled := []*throttledAlerts{
+		{
+			Output: rateLimitedOutput(outputModels.RateLimitOverflowDigest),
+			Alerts: []*deliverymodel.Alert{sampleAlert()},
+			Wait:   5 * time.Second,
+		},
+	}
+
+	// The alert waits for the next digest
+	limiter.On("TakeDigest", "output-id", digestInterval).Return(false, 30*time.Second, nil).Once()
+	mockSQS.On("SendMessageBatch", mock.MatchedBy(func(input *sqs.SendMessageBatchInput) bool {
+		return len(input.Entries) == 1 && *input.Entries[0].DelaySeconds == 30
+	})).Return(&sqs.SendMessageBatchOutput{}, nil).Once()
+
+	statuses := handleThrottled(context.Background(), throttled, limiter, &mockOutputsClient{})
+	assert.Empty(t, statuses)
+	limiter.AssertExpectations(t)
+	mockSQS.AssertExpectations(t)
+}
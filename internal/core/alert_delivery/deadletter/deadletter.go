@@ -0,0 +1,260 @@
+package deadletter
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+
+	deliverymodel "github.com/panther-labs/panther/api/lambda/delivery/models"
+	"github.com/panther-labs/panther/pkg/awsbatch/dynamodbbatch"
+)
+
+const (
+	// maxBackoff is the maximum time spent deleting redelivered alerts
+	maxBackoff = 30 * time.Second
+	// retention is how long failed deliveries are kept if they are never redelivered
+	retention = 30 * 24 * time.Hour
+)
+
+// API is the interface for the dead-letter store that can be used for mocks in tests.
+type API interface {
+	// Put records a delivery of an alert to an output which permanently failed
+	Put(letter *Item) error
+	// List returns the failed deliveries matching a filter
+	List(filter *Filter) ([]*Item, error)
+	// Delete removes failed deliveries, e.g. once they have been redelivered
+	Delete(letters []*Item) error
+}
+
+// Store keeps the failed deliveries of each output in DynamoDB.
+type Store struct {
+	Client    dynamodbiface.DynamoDBAPI
+	TableName string
+}
+
+// Store must satisfy the API interface.
+var _ API = (*Store)(nil)
+
+// Item is a delivery of an alert to an output which failed after all retries.
+//
+// There is at most one item for each (output, alert) pair, the last failure overwrites previous ones.
+type Item struct {
+	OutputID   string    `json:"outputId"`
+	AlertID    string    `json:"alertId"`
+	AnalysisID string    `json:"analysisId"`
+	FailedAt   time.Time `json:"failedAt" dynamodbav:"failedAt,unixtime"`
+	StatusCode int       `json:"statusCode"`
+	Message    string    `json:"message"`
+	RetryCount int       `json:"retryCount"`
+	// Alert is the alert encoded as JSON
+	Alert string `json:"alert"`
+	// ExpiresAt is the unix time the item expires
+	ExpiresAt int64 `json:"expiresAt"`
+}
+
+// Filter selects failed deliveries. Empty fields match all items.
+type Filter struct {
+	OutputIDs  []string
+	AnalysisID string
+	Since      time.Time
+	Until      time.Time
+}
+
+// NewItem creates the dead letter of an alert.
+func NewItem(outputID string, alert *deliverymodel.Alert, failedAt time.Time) (*Item, error) {
+	body, err := jsoniter.MarshalToString(alert)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode alert")
+	}
+	return &Item{
+		OutputID:   outputID,
+		AlertID:    aws.StringValue(alert.AlertID),
+		AnalysisID: alert.AnalysisID,
+		FailedAt:   failedAt,
+		RetryCount: alert.RetryCount,
+		Alert:      body,
+		ExpiresAt:  failedAt.Add(retention).Unix(),
+	}, nil
+}
+
+// DecodeAlert decodes the alert of a dead letter.
+func (item *Item) DecodeAlert() (*deliverymodel.Alert, error) {
+	alert := &deliverymodel.Alert{}
+	if err := jsoniter.UnmarshalFromString(item.Alert, alert); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode alert %s of output %s", item.AlertID, item.OutputID)
+	}
+	return alert, nil
+}
+
+// Put implements the API interface
+func (s *Store) Put(letter *Item) error {
+	item, err := dynamodbattribute.MarshalMap(letter)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal dead letter")
+	}
+	_, err = s.Client.PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String(s.TableName),
+		Item:      item,
+	})
+	return errors.Wrapf(err, "failed to store dead letter of alert %s for output %s", letter.AlertID, letter.OutputID)
+}
+
+// List implements the API interface
+//
+// Failed deliveries of specific outputs are queried, otherwise the whole table is scanned.
+func (s *Store) List(filter *Filter) ([]*Item, error) {
+	if len(filter.OutputIDs) == 0 {
+		return s.scan(filter)
+	}
+	var items []*Item
+	queried := make(map[string]bool, len(filter.OutputIDs))
+	for _, outputID := range filter.OutputIDs {
+		if queried[outputID] {
+			continue
+		}
+		queried[outputID] = true
+		page, err := s.query(outputID, filter)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page...)
+	}
+	return items, nil
+}
+
+func (s *Store) query(outputID string, filter *Filter) ([]*Item, error) {
+	builder := expression.NewBuilder().
+		WithKeyCondition(expression.Key("outputId").Equal(expression.Value(outputID)))
+	if condition, ok := filterCondition(filter); ok {
+		builder = builder.WithFilter(condition)
+	}
+	expr, err := builder.Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build dead letter query")
+	}
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(s.TableName),
+		KeyConditionExpression:    expr.KeyCondition(),
+		FilterExpression:          expr.Filter(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	}
+
+	var items []*Item
+	for {
+		output, err := s.Client.Query(input)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list dead letters of output %s", outputID)
+		}
+		var page []*Item
+		if err := dynamodbattribute.UnmarshalListOfMaps(output.Items, &page); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal dead letters of output %s", outputID)
+		}
+		items = append(items, page...)
+		if len(output.LastEvaluatedKey) == 0 {
+			return items, nil
+		}
+		input.ExclusiveStartKey = output.LastEvaluatedKey
+	}
+}
+
+func (s *Store) scan(filter *Filter) ([]*Item, error) {
+	input := &dynamodb.ScanInput{
+		TableName: aws.String(s.TableName),
+	}
+	if condition, ok := filterCondition(filter); ok {
+		expr, err := expression.NewBuilder().WithFilter(condition).Build()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to build dead letter scan")
+		}
+		input.FilterExpression = expr.Filter()
+		input.ExpressionAttributeNames = expr.Names()
+		input.ExpressionAttributeValues = expr.Values()
+	}
+
+	var items []*Item
+	for {
+		output, err := s.Client.Scan(input)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list dead letters")
+		}
+		var page []*Item
+		if err := dynamodbattribute.UnmarshalListOfMaps(output.Items, &page); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal dead letters")
+		}
+		items = append(items, page...)
+		if len(output.LastEvaluatedKey) == 0 {
+			return items, nil
+		}
+		input.ExclusiveStartKey = output.LastEvaluatedKey
+	}
+}
+
+// filterCondition builds the condition for the rule and time range of a filter, if any
+func filterCondition(filter *Filter) (expression.ConditionBuilder, bool) {
+	var conditions []expression.ConditionBuilder
+	if filter.AnalysisID != "" {
+		conditions = append(conditions, expression.Name("analysisId").Equal(expression.Value(filter.AnalysisID)))
+	}
+	if !filter.Since.IsZero() {
+		conditions = append(conditions, expression.Name("failedAt").GreaterThanEqual(expression.Value(filter.Since.Unix())))
+	}
+	if !filter.Until.IsZero() {
+		conditions = append(conditions, expression.Name("failedAt").LessThanEqual(expression.Value(filter.Until.Unix())))
+	}
+
+	switch len(conditions) {
+	case 0:
+		return expression.ConditionBuilder{}, false
+	case 1:
+		return conditions[0], true
+	default:
+		return expression.And(conditions[0], conditions[1], conditions[2:]...), true
+	}
+}
+
+// Delete implements the API interface
+func (s *Store) Delete(letters []*Item) error {
+	if len(letters) == 0 {
+		return nil
+	}
+	requests := make([]*dynamodb.WriteRequest, 0, len(letters))
+	for _, letter := range letters {
+		requests = append(requests, &dynamodb.WriteRequest{
+			DeleteRequest: &dynamodb.DeleteRequest{
+				Key: map[string]*dynamodb.AttributeValue{
+					"outputId": {S: aws.String(letter.OutputID)},
+					"alertId":  {S: aws.String(letter.AlertID)},
+				},
+			},
+		})
+	}
+	input := &dynamodb.BatchWriteItemInput{
+		RequestItems: map[string][]*dynamodb.WriteRequest{s.TableName: requests},
+	}
+	return errors.Wrap(dynamodbbatch.BatchWriteItem(s.Client, maxBackoff, input), "failed to delete dead letters")
+}
@@ -0,0 +1,171 @@
+package deadletter
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	deliverymodel "github.com/panther-labs/panther/api/lambda/delivery/models"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+var failedAt = time.Date(2020, 12, 1, 10, 0, 0, 0, time.UTC)
+
+func testAlert() *deliverymodel.Alert {
+	return &deliverymodel.Alert{
+		AlertID:    aws.String("alert-id"),
+		AnalysisID: "rule-id",
+		Type:       deliverymodel.RuleType,
+		Severity:   "INFO",
+		RetryCount: 10,
+		CreatedAt:  time.Date(2020, 12, 1, 9, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestNewItem(t *testing.T) {
+	item, err := NewItem("output-id", testAlert(), failedAt)
+	require.NoError(t, err)
+	assert.Equal(t, "output-id", item.OutputID)
+	assert.Equal(t, "alert-id", item.AlertID)
+	assert.Equal(t, "rule-id", item.AnalysisID)
+	assert.Equal(t, 10, item.RetryCount)
+	assert.Equal(t, failedAt.Add(30*24*time.Hour).Unix(), item.ExpiresAt)
+
+	alert, err := item.DecodeAlert()
+	require.NoError(t, err)
+	assert.Equal(t, testAlert(), alert)
+}
+
+func TestPut(t *testing.T) {
+	mockClient := &testutils.DynamoDBMock{}
+	store := &Store{Client: mockClient, TableName: "dead-letters"}
+
+	mockClient.On("PutItem", mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+		return *input.TableName == "dead-letters" &&
+			*input.Item["outputId"].S == "output-id" &&
+			*input.Item["alertId"].S == "alert-id" &&
+			*input.Item["failedAt"].N == "1606816800" &&
+			*input.Item["message"].S == "request failed: 503 Service Unavailable"
+	})).Return(&dynamodb.PutItemOutput{}, nil).Once()
+
+	item, err := NewItem("output-id", testAlert(), failedAt)
+	require.NoError(t, err)
+	item.StatusCode = 503
+	item.Message = "request failed: 503 Service Unavailable"
+	require.NoError(t, store.Put(item))
+	mockClient.AssertExpectations(t)
+}
+
+func TestListByOutput(t *testing.T) {
+	mockClient := &testutils.DynamoDBMock{}
+	store := &Store{Client: mockClient, TableName: "dead-letters"}
+
+	item := func(outputID, alertID string) map[string]*dynamodb.AttributeValue {
+		return map[string]*dynamodb.AttributeValue{
+			"outputId": {S: aws.String(outputID)},
+			"alertId":  {S: aws.String(alertID)},
+			"failedAt": {N: aws.String("1606816800")},
+		}
+	}
+	lastKey := map[string]*dynamodb.AttributeValue{"alertId": {S: aws.String("a")}}
+	mockClient.On("Query", mock.MatchedBy(func(input *dynamodb.QueryInput) bool {
+		return *input.ExpressionAttributeValues[":0"].S == "output-1" && input.ExclusiveStartKey == nil
+	})).Return(&dynamodb.QueryOutput{
+		Items:            []map[string]*dynamodb.AttributeValue{item("output-1", "a")},
+		LastEvaluatedKey: lastKey,
+	}, nil).Once()
+	mockClient.On("Query", mock.MatchedBy(func(input *dynamodb.QueryInput) bool {
+		return *input.ExpressionAttributeValues[":0"].S == "output-1" && input.ExclusiveStartKey != nil
+	})).Return(&dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{item("output-1", "b")},
+	}, nil).Once()
+	mockClient.On("Query", mock.MatchedBy(func(input *dynamodb.QueryInput) bool {
+		return *input.ExpressionAttributeValues[":0"].S == "output-2"
+	})).Return(&dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{item("output-2", "c")},
+	}, nil).Once()
+
+	items, err := store.List(&Filter{OutputIDs: []string{"output-1", "output-2", "output-1"}})
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+	assert.Equal(t, "a", items[0].AlertID)
+	assert.Equal(t, "b", items[1].AlertID)
+	assert.Equal(t, "output-2", items[2].OutputID)
+	assert.Equal(t, failedAt, items[0].FailedAt.UTC())
+	mockClient.AssertExpectations(t)
+}
+
+func TestListScanWithFilter(t *testing.T) {
+	mockClient := &testutils.DynamoDBMock{}
+	store := &Store{Client: mockClient, TableName: "dead-letters"}
+
+	mockClient.On("Scan", mock.MatchedBy(func(input *dynamodb.ScanInput) bool {
+		return *input.TableName == "dead-letters" &&
+			*input.FilterExpression == "(#0 = :0) AND (#1 >= :1) AND (#1 <= :2)" &&
+			*input.ExpressionAttributeNames["#0"] == "analysisId" &&
+			*input.ExpressionAttributeNames["#1"] == "failedAt" &&
+			*input.ExpressionAttributeValues[":1"].N == "1606816800" &&
+			*input.ExpressionAttributeValues[":2"].N == "1606820400"
+	})).Return(&dynamodb.ScanOutput{}, nil).Once()
+
+	items, err := store.List(&Filter{
+		AnalysisID: "rule-id",
+		Since:      failedAt,
+		Until:      failedAt.Add(time.Hour),
+	})
+	require.NoError(t, err)
+	assert.Empty(t, items)
+	mockClient.AssertExpectations(t)
+}
+
+func TestListScanAll(t *testing.T) {
+	mockClient := &testutils.DynamoDBMock{}
+	store := &Store{Client: mockClient, TableName: "dead-letters"}
+
+	mockClient.On("Scan", mock.MatchedBy(func(input *dynamodb.ScanInput) bool {
+		return input.FilterExpression == nil
+	})).Return(&dynamodb.ScanOutput{}, nil).Once()
+
+	_, err := store.List(&Filter{})
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestDelete(t *testing.T) {
+	mockClient := &testutils.DynamoDBMock{}
+	store := &Store{Client: mockClient, TableName: "dead-letters"}
+
+	mockClient.On("BatchWriteItem", mock.MatchedBy(func(input *dynamodb.BatchWriteItemInput) bool {
+		requests := input.RequestItems["dead-letters"]
+		return len(requests) == 1 &&
+			*requests[0].DeleteRequest.Key["outputId"].S == "output-id" &&
+			*requests[0].DeleteRequest.Key["alertId"].S == "alert-id"
+	})).Return(&dynamodb.BatchWriteItemOutput{}, nil).Once()
+
+	require.NoError(t, store.Delete([]*Item{{OutputID: "output-id", AlertID: "alert-id"}}))
+	require.NoError(t, store.Delete(nil))
+	mockClient.AssertExpectations(t)
+}
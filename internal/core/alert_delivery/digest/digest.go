@@ -0,0 +1,165 @@
+// Package digest stores the alerts waiting to be delivered in the next digest of an output.
+package digest
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+
+	deliverymodel "github.com/panther-labs/panther/api/lambda/delivery/models"
+	"github.com/panther-labs/panther/pkg/awsbatch/dynamodbbatch"
+)
+
+const (
+	// maxBackoff is the maximum time spent deleting delivered alerts
+	maxBackoff = 30 * time.Second
+	// retention is how long alerts are kept if their digest is never sent (e.g. the digest was disabled)
+	retention = 7 * 24 * time.Hour
+)
+
+// API is the interface for the digest store that can be used for mocks in tests.
+type API interface {
+	// Add stores an alert until the next digest of an output
+	Add(outputID string, alert *deliverymodel.Alert) error
+	// List returns the alerts waiting for the next digest of an output, oldest first
+	List(outputID string) ([]*Item, error)
+	// Delete removes alerts that were delivered in a digest
+	Delete(items []*Item) error
+}
+
+// Store keeps the alerts of each output in DynamoDB until its digest is sent.
+type Store struct {
+	Client    dynamodbiface.DynamoDBAPI
+	TableName string
+}
+
+// Store must satisfy the API interface.
+var _ API = (*Store)(nil)
+
+// Item is an alert waiting for the digest of an output.
+type Item struct {
+	OutputID string `json:"outputId"`
+	// AlertKey sorts alerts by creation time and makes them unique
+	AlertKey string `json:"alertKey"`
+	// Alert is the alert encoded as JSON
+	Alert string `json:"alert"`
+	// ExpiresAt is the unix time the item expires
+	ExpiresAt int64 `json:"expiresAt"`
+}
+
+// Add implements the API interface
+func (s *Store) Add(outputID string, alert *deliverymodel.Alert) error {
+	body, err := jsoniter.MarshalToString(alert)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode alert")
+	}
+	item, err := dynamodbattribute.MarshalMap(&Item{
+		OutputID:  outputID,
+		AlertKey:  alert.CreatedAt.UTC().Format(time.RFC3339Nano) + "#" + aws.StringValue(alert.AlertID),
+		Alert:     body,
+		ExpiresAt: time.Now().Add(retention).Unix(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal digest item")
+	}
+	_, err = s.Client.PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String(s.TableName),
+		Item:      item,
+	})
+	return errors.Wrapf(err, "failed to add alert to the digest of output %s", outputID)
+}
+
+// List implements the API interface
+func (s *Store) List(outputID string) ([]*Item, error) {
+	keyCondition := expression.Key("outputId").Equal(expression.Value(outputID))
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCondition).Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build digest query")
+	}
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(s.TableName),
+		ConsistentRead:            aws.Bool(true),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	}
+
+	var items []*Item
+	for {
+		output, err := s.Client.Query(input)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list the digest of output %s", outputID)
+		}
+		var page []*Item
+		if err := dynamodbattribute.UnmarshalListOfMaps(output.Items, &page); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal the digest of output %s", outputID)
+		}
+		items = append(items, page...)
+		if len(output.LastEvaluatedKey) == 0 {
+			break
+		}
+		input.ExclusiveStartKey = output.LastEvaluatedKey
+	}
+	return items, nil
+}
+
+// Delete implements the API interface
+func (s *Store) Delete(items []*Item) error {
+	if len(items) == 0 {
+		return nil
+	}
+	requests := make([]*dynamodb.WriteRequest, 0, len(items))
+	for _, item := range items {
+		requests = append(requests, &dynamodb.WriteRequest{
+			DeleteRequest: &dynamodb.DeleteRequest{
+				Key: map[string]*dynamodb.AttributeValue{
+					"outputId": {S: aws.String(item.OutputID)},
+					"alertKey": {S: aws.String(item.AlertKey)},
+				},
+			},
+		})
+	}
+	input := &dynamodb.BatchWriteItemInput{
+		RequestItems: map[string][]*dynamodb.WriteRequest{s.TableName: requests},
+	}
+	return errors.Wrap(dynamodbbatch.BatchWriteItem(s.Client, maxBackoff, input), "failed to delete digest items")
+}
+
+// Alerts decodes the alerts of digest items, skipping any that are invalid.
+func Alerts(items []*Item) ([]*deliverymodel.Alert, []error) {
+	alerts := make([]*deliverymodel.Alert, 0, len(items))
+	var errs []error
+	for _, item := range items {
+		alert := &deliverymodel.Alert{}
+		if err := jsoniter.UnmarshalFromString(item.Alert, alert); err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to decode digest alert %s", item.AlertKey))
+			continue
+		}
+		alerts = append(alerts, alert)
+	}
+	return alerts, errs
+}
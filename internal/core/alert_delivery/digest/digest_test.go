@@ -0,0 +1,118 @@
+package digest
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	deliverymodel "github.com/panther-labs/panther/api/lambda/delivery/models"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+func testAlert() *deliverymodel.Alert {
+	return &deliverymodel.Alert{
+		AlertID:    aws.String("alert-id"),
+		AnalysisID: "rule-id",
+		Type:       deliverymodel.RuleType,
+		Severity:   "INFO",
+		CreatedAt:  time.Date(2020, 12, 1, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestAdd(t *testing.T) {
+	mockClient := &testutils.DynamoDBMock{}
+	store := &Store{Client: mockClient, TableName: "digests"}
+
+	mockClient.On("PutItem", mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+		return *input.TableName == "digests" &&
+			*input.Item["outputId"].S == "output-id" &&
+			*input.Item["alertKey"].S == "2020-12-01T10:00:00Z#alert-id"
+	})).Return(&dynamodb.PutItemOutput{}, nil).Once()
+
+	require.NoError(t, store.Add("output-id", testAlert()))
+	mockClient.AssertExpectations(t)
+}
+
+func TestListPages(t *testing.T) {
+	mockClient := &testutils.DynamoDBMock{}
+	store := &Store{Client: mockClient, TableName: "digests"}
+
+	item := func(key string) map[string]*dynamodb.AttributeValue {
+		return map[string]*dynamodb.AttributeValue{
+			"outputId": {S: aws.String("output-id")},
+			"alertKey": {S: aws.String(key)},
+			"alert":    {S: aws.String(`{"alertId":"` + key + `"}`)},
+		}
+	}
+	lastKey := map[string]*dynamodb.AttributeValue{"alertKey": {S: aws.String("a")}}
+	mockClient.On("Query", mock.MatchedBy(func(input *dynamodb.QueryInput) bool {
+		return input.ExclusiveStartKey == nil
+	})).Return(&dynamodb.QueryOutput{
+		Items:            []map[string]*dynamodb.AttributeValue{item("a")},
+		LastEvaluatedKey: lastKey,
+	}, nil).Once()
+	mockClient.On("Query", mock.MatchedBy(func(input *dynamodb.QueryInput) bool {
+		return input.ExclusiveStartKey != nil
+	})).Return(&dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{item("b")},
+	}, nil).Once()
+
+	items, err := store.List("output-id")
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+	require.Len(t, items, 2)
+	assert.Equal(t, "a", items[0].AlertKey)
+	assert.Equal(t, "b", items[1].AlertKey)
+
+	alerts, errs := Alerts(append(items, &Item{AlertKey: "invalid", Alert: "{"}))
+	assert.Len(t, errs, 1)
+	require.Len(t, alerts, 2)
+	assert.Equal(t, "b", *alerts[1].AlertID)
+}
+
+func TestDelete(t *testing.T) {
+	mockClient := &testutils.DynamoDBMock{}
+	store := &Store{Client: mockClient, TableName: "digests"}
+
+	mockClient.On("BatchWriteItem", &dynamodb.BatchWriteItemInput{
+		RequestItems: map[string][]*dynamodb.WriteRequest{
+			"digests": {
+				{
+					DeleteRequest: &dynamodb.DeleteRequest{
+						Key: map[string]*dynamodb.AttributeValue{
+							"outputId": {S: aws.String("output-id")},
+							"alertKey": {S: aws.String("a")},
+						},
+					},
+				},
+			},
+		},
+	}).Return(&dynamodb.BatchWriteItemOutput{}, nil).Once()
+
+	require.NoError(t, store.Delete([]*Item{{OutputID: "output-id", AlertKey: "a"}}))
+	require.NoError(t, store.Delete(nil))
+	mockClient.AssertExpectations(t)
+}
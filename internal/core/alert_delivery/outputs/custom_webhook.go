@@ -29,9 +29,34 @@ import (
 func (client *OutputClient) CustomWebhook(
 	ctx context.Context, alert *deliverymodel.Alert, config *outputModels.CustomWebhookConfig) *AlertDeliveryResponse {
 
+	title, body := renderAlertTemplate(config.Template, alert)
+	// A templated body is sent as the request body
+	if body != "" {
+		postInput := &PostInput{
+			url:  config.WebhookURL,
+			body: []byte(body),
+		}
+		return client.httpWrapper.post(ctx, postInput)
+	}
+
+	notification := generateNotificationFromAlert(alert)
+	if title != "" {
+		notification.Title = title
+	}
+	postInput := &PostInput{
+		url:  config.WebhookURL,
+		body: notification,
+	}
+	return client.httpWrapper.post(ctx, postInput)
+}
+
+// CustomWebhookDigest sends a digest of alerts.
+func (client *OutputClient) CustomWebhookDigest(
+	ctx context.Context, digest *Digest, config *outputModels.CustomWebhookConfig) *AlertDeliveryResponse {
+
 	postInput := &PostInput{
 		url:  config.WebhookURL,
-		body: generateNotificationFromAlert(alert),
+		body: generateNotificationFromDigest(digest),
 	}
 	return client.httpWrapper.post(ctx, postInput)
 }
@@ -84,3 +84,64 @@ func TestCustomWebhookAlert(t *testing.T) {
 	require.Nil(t, client.CustomWebhook(ctx, alert, customWebhookConfig))
 	httpWrapper.AssertExpectations(t)
 }
+
+func TestCustomWebhookDigest(t *testing.T) {
+	httpWrapper := &mockHTTPWrapper{}
+	client := &OutputClient{httpWrapper: httpWrapper}
+
+	digest := sampleDigest()
+	expectedNotification := DigestNotification{
+		Title:      "Alert Digest: 4 alerts from 01 Dec 20 10:00 UTC to 01 Dec 20 10:15 UTC",
+		Start:      digestStart,
+		End:        digestStart.Add(15 * time.Minute),
+		AlertCount: 4,
+		Severity:   "HIGH",
+		Link:       "https://panther.io",
+		Groups:     digest.Groups(),
+		Text: "HIGH: 1 alerts\n" +
+			"- rule.b (https://panther.io/alerts/alert-2): 1\n" +
+			"LOW: 3 alerts\n" +
+			"- Rule A (https://panther.io/alerts/alert-3): 2\n" +
+			"- rule.c (https://panther.io/alerts/alert-4): 1",
+	}
+
+	expectedPostInput := &PostInput{
+		url:  "custom-webhook-url",
+		body: expectedNotification,
+	}
+	ctx := context.Background()
+	httpWrapper.On("post", ctx, expectedPostInput).Return((*AlertDeliveryResponse)(nil))
+
+	require.Nil(t, client.CustomWebhookDigest(ctx, digest, customWebhookConfig))
+	httpWrapper.AssertExpectations(t)
+}
+
+func TestCustomWebhookTemplate(t *testing.T) {
+	httpWrapper := &mockHTTPWrapper{}
+	client := &OutputClient{httpWrapper: httpWrapper}
+
+	alert := &deliverymodel.Alert{
+		AlertID:    aws.String("alertId"),
+		AnalysisID: "ruleId",
+		Type:       deliverymodel.RuleType,
+		Severity:   "HIGH",
+		Title:      "Unusual login",
+		LogTypes:   []string{"AWS.CloudTrail"},
+	}
+	config := &outputModels.CustomWebhookConfig{
+		WebhookURL: "custom-webhook-url",
+		Template: &outputModels.NotificationTemplate{
+			Body: `{"summary": {{ json .Title }}, "logTypes": {{ json .LogTypes }}}`,
+		},
+	}
+
+	expectedPostInput := &PostInput{
+		url:  "custom-webhook-url",
+		body: []byte(`{"summary": "New Alert: Unusual login", "logTypes": ["AWS.CloudTrail"]}`),
+	}
+	ctx := context.Background()
+	httpWrapper.On("post", ctx, expectedPostInput).Return((*AlertDeliveryResponse)(nil))
+
+	require.Nil(t, client.CustomWebhook(ctx, alert, config))
+	httpWrapper.AssertExpectations(t)
+}
@@ -0,0 +1,201 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+
+	deliverymodel "github.com/panther-labs/panther/api/lambda/delivery/models"
+)
+
+// Severities in the order they are listed in a digest
+var digestSeverities = []string{"CRITICAL", "HIGH", "MEDIUM", "LOW", "INFO"}
+
+// Digest is a summary of the alerts delivered to an output during a period of time
+type Digest struct {
+	// Title overrides the default title of the digest
+	Title string
+	// Start and End are the creation times of the oldest and newest alert in the digest
+	Start time.Time
+	End   time.Time
+	// Alerts are the alerts summarized by the digest
+	Alerts []*deliverymodel.Alert
+}
+
+// DigestGroup holds the alerts of a digest with the same severity
+type DigestGroup struct {
+	Severity string        `json:"severity"`
+	Count    int           `json:"count"`
+	Rules    []*DigestRule `json:"rules"`
+}
+
+// DigestRule holds the alerts of a digest triggered by the same rule or policy
+type DigestRule struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Count    int      `json:"count"`
+	Link     string   `json:"link"`
+	AlertIDs []string `json:"alertIds"`
+
+	// latest is the creation time of the alert the link points to
+	latest time.Time
+}
+
+// DigestNotification is the payload delivered by webhooks for a digest
+type DigestNotification struct {
+	// The title for this digest
+	Title string `json:"title"`
+
+	// The creation time of the oldest alert in the digest
+	Start time.Time `json:"start"`
+
+	// The creation time of the newest alert in the digest
+	End time.Time `json:"end"`
+
+	// The number of alerts in the digest
+	AlertCount int `json:"alertCount"`
+
+	// The highest severity of the alerts in the digest
+	Severity string `json:"severity"`
+
+	// Link to the Panther UI
+	Link string `json:"link"`
+
+	// The alerts grouped by severity and rule
+	Groups []*DigestGroup `json:"groups"`
+
+	// The digest as plain text, e.g. for the body of an email
+	Text string `json:"text"`
+}
+
+// NewDigest creates a digest of alerts.
+func NewDigest(alerts []*deliverymodel.Alert) *Digest {
+	digest := &Digest{Alerts: alerts}
+	for _, alert := range alerts {
+		if digest.Start.IsZero() || alert.CreatedAt.Before(digest.Start) {
+			digest.Start = alert.CreatedAt
+		}
+		if alert.CreatedAt.After(digest.End) {
+			digest.End = alert.CreatedAt
+		}
+	}
+	return digest
+}
+
+// Groups groups the alerts of the digest by severity, from most to least severe.
+//
+// Within each severity, rules are ordered by the number of alerts they triggered.
+func (d *Digest) Groups() []*DigestGroup {
+	groups := make(map[string]*DigestGroup)
+	rules := make(map[string]map[string]*DigestRule)
+	for _, alert := range d.Alerts {
+		group, ok := groups[alert.Severity]
+		if !ok {
+			group = &DigestGroup{Severity: alert.Severity}
+			groups[alert.Severity] = group
+			rules[alert.Severity] = make(map[string]*DigestRule)
+		}
+		rule, ok := rules[alert.Severity][alert.AnalysisID]
+		if !ok {
+			rule = &DigestRule{
+				ID:   alert.AnalysisID,
+				Name: getDisplayName(alert),
+			}
+			rules[alert.Severity][alert.AnalysisID] = rule
+			group.Rules = append(group.Rules, rule)
+		}
+		group.Count++
+		rule.Count++
+		rule.AlertIDs = append(rule.AlertIDs, aws.StringValue(alert.AlertID))
+		// Link to the most recent alert of the rule
+		if rule.Link == "" || alert.CreatedAt.After(rule.latest) {
+			rule.Link = generateURL(alert)
+			rule.latest = alert.CreatedAt
+		}
+	}
+
+	result := make([]*DigestGroup, 0, len(groups))
+	for _, severity := range digestSeverities {
+		group, ok := groups[severity]
+		if !ok {
+			continue
+		}
+		sort.SliceStable(group.Rules, func(i, j int) bool {
+			if group.Rules[i].Count != group.Rules[j].Count {
+				return group.Rules[i].Count > group.Rules[j].Count
+			}
+			return group.Rules[i].ID < group.Rules[j].ID
+		})
+		result = append(result, group)
+	}
+	return result
+}
+
+// Severity returns the highest severity of the alerts in the digest
+func (d *Digest) Severity() string {
+	if groups := d.Groups(); len(groups) > 0 {
+		return groups[0].Severity
+	}
+	return "INFO"
+}
+
+func generateNotificationFromDigest(digest *Digest) DigestNotification {
+	return DigestNotification{
+		Title:      generateDigestTitle(digest),
+		Start:      digest.Start,
+		End:        digest.End,
+		AlertCount: len(digest.Alerts),
+		Severity:   digest.Severity(),
+		Link:       appDomainURL,
+		Groups:     digest.Groups(),
+		Text: generateDigestText(digest, func(text, url string) string {
+			return text + " (" + url + ")"
+		}),
+	}
+}
+
+func generateDigestTitle(digest *Digest) string {
+	if digest.Title != "" {
+		return digest.Title
+	}
+	return fmt.Sprintf("Alert Digest: %d alerts from %s to %s",
+		len(digest.Alerts),
+		digest.Start.UTC().Format(time.RFC822),
+		digest.End.UTC().Format(time.RFC822),
+	)
+}
+
+// generateDigestText renders a digest as plain text, one line per severity followed by its rules.
+//
+// formatLink renders a link to the most recent alert of each rule in the markup of the output.
+func generateDigestText(digest *Digest, formatLink func(text, url string) string) string {
+	var text strings.Builder
+	for _, group := range digest.Groups() {
+		fmt.Fprintf(&text, "%s: %d alerts\n", group.Severity, group.Count)
+		for _, rule := range group.Rules {
+			fmt.Fprintf(&text, "- %s: %d\n", formatLink(rule.Name, rule.Link), rule.Count)
+		}
+	}
+	return strings.TrimSuffix(text.String(), "\n")
+}
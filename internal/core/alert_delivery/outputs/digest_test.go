@@ -0,0 +1,130 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+
+	deliverymodel "github.com/panther-labs/panther/api/lambda/delivery/models"
+)
+
+var digestStart = time.Date(2020, 12, 1, 10, 0, 0, 0, time.UTC)
+
+func sampleDigest() *Digest {
+	return NewDigest([]*deliverymodel.Alert{
+		{
+			AlertID:      aws.String("alert-1"),
+			AnalysisID:   "rule.a",
+			AnalysisName: aws.String("Rule A"),
+			Type:         deliverymodel.RuleType,
+			Severity:     "LOW",
+			CreatedAt:    digestStart.Add(time.Minute),
+		},
+		{
+			AlertID:    aws.String("alert-2"),
+			AnalysisID: "rule.b",
+			Type:       deliverymodel.RuleType,
+			Severity:   "HIGH",
+			CreatedAt:  digestStart,
+		},
+		{
+			AlertID:      aws.String("alert-3"),
+			AnalysisID:   "rule.a",
+			AnalysisName: aws.String("Rule A"),
+			Type:         deliverymodel.RuleType,
+			Severity:     "LOW",
+			CreatedAt:    digestStart.Add(15 * time.Minute),
+		},
+		{
+			AlertID:    aws.String("alert-4"),
+			AnalysisID: "rule.c",
+			Type:       deliverymodel.RuleType,
+			Severity:   "LOW",
+			CreatedAt:  digestStart.Add(10 * time.Minute),
+		},
+	})
+}
+
+func TestNewDigest(t *testing.T) {
+	digest := sampleDigest()
+	assert.Equal(t, digestStart, digest.Start)
+	assert.Equal(t, digestStart.Add(15*time.Minute), digest.End)
+	assert.Equal(t, "HIGH", digest.Severity())
+	assert.Equal(t, "Alert Digest: 4 alerts from 01 Dec 20 10:00 UTC to 01 Dec 20 10:15 UTC", generateDigestTitle(digest))
+
+	digest.Title = "custom title"
+	assert.Equal(t, "custom title", generateDigestTitle(digest))
+}
+
+func TestDigestGroups(t *testing.T) {
+	groups := sampleDigest().Groups()
+	assert.Equal(t, []*DigestGroup{
+		{
+			Severity: "HIGH",
+			Count:    1,
+			Rules: []*DigestRule{
+				{
+					ID:       "rule.b",
+					Name:     "rule.b",
+					Count:    1,
+					Link:     "https://panther.io/alerts/alert-2",
+					AlertIDs: []string{"alert-2"},
+					latest:   digestStart,
+				},
+			},
+		},
+		{
+			Severity: "LOW",
+			Count:    3,
+			Rules: []*DigestRule{
+				{
+					ID:       "rule.a",
+					Name:     "Rule A",
+					Count:    2,
+					Link:     "https://panther.io/alerts/alert-3",
+					AlertIDs: []string{"alert-1", "alert-3"},
+					latest:   digestStart.Add(15 * time.Minute),
+				},
+				{
+					ID:       "rule.c",
+					Name:     "rule.c",
+					Count:    1,
+					Link:     "https://panther.io/alerts/alert-4",
+					AlertIDs: []string{"alert-4"},
+					latest:   digestStart.Add(10 * time.Minute),
+				},
+			},
+		},
+	}, groups)
+}
+
+func TestGenerateDigestText(t *testing.T) {
+	text := generateDigestText(sampleDigest(), func(text, url string) string {
+		return text + " <" + url + ">"
+	})
+	assert.Equal(t, "HIGH: 1 alerts\n"+
+		"- rule.b <https://panther.io/alerts/alert-2>: 1\n"+
+		"LOW: 3 alerts\n"+
+		"- Rule A <https://panther.io/alerts/alert-3>: 2\n"+
+		"- rule.c <https://panther.io/alerts/alert-4>: 1", text)
+}
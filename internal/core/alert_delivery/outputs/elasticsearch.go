@@ -0,0 +1,139 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+
+	deliverymodel "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+)
+
+const elasticsearchBulkEndpoint = "/_bulk"
+
+// elasticsearchDocument is the indexed alert, with a timestamp for index patterns and data streams
+type elasticsearchDocument struct {
+	Timestamp time.Time `json:"@timestamp"`
+	Notification
+}
+
+type elasticsearchBulkAction struct {
+	Index elasticsearchBulkIndex `json:"index"`
+}
+
+type elasticsearchBulkIndex struct {
+	Index string `json:"_index"`
+	// Using the alert ID as the document ID makes retries idempotent
+	ID string `json:"_id,omitempty"`
+}
+
+// elasticsearchBulkResponse is the part of the bulk API response needed to detect failed documents
+type elasticsearchBulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []struct {
+		Index struct {
+			Status int `json:"status"`
+			Error  struct {
+				Type   string `json:"type"`
+				Reason string `json:"reason"`
+			} `json:"error"`
+		} `json:"index"`
+	} `json:"items"`
+}
+
+// Elasticsearch indexes an alert as a document using the bulk API.
+func (client *OutputClient) Elasticsearch(
+	ctx context.Context, alert *deliverymodel.Alert, config *outputModels.ElasticsearchConfig) *AlertDeliveryResponse {
+
+	notification := generateNotificationFromAlert(alert)
+	action := &elasticsearchBulkAction{
+		Index: elasticsearchBulkIndex{Index: config.Index},
+	}
+	if notification.AlertID != nil {
+		action.Index.ID = *notification.AlertID
+	}
+
+	// The bulk API expects newline delimited JSON, terminated by a newline
+	var body bytes.Buffer
+	stream := jsoniter.NewEncoder(&body)
+	if err := stream.Encode(action); err != nil {
+		return elasticsearchMarshalError(err)
+	}
+	if err := stream.Encode(&elasticsearchDocument{Timestamp: alert.CreatedAt, Notification: notification}); err != nil {
+		return elasticsearchMarshalError(err)
+	}
+
+	headers := map[string]string{
+		"Content-Type": "application/x-ndjson",
+	}
+	switch {
+	case config.APIKey != "":
+		headers[AuthorizationHTTPHeader] = "ApiKey " + config.APIKey
+	case config.UserName != "":
+		auth := config.UserName + ":" + config.Password
+		headers[AuthorizationHTTPHeader] = "Basic " + base64.StdEncoding.EncodeToString([]byte(auth))
+	}
+
+	postInput := &PostInput{
+		url:     strings.TrimSuffix(config.URL, "/") + elasticsearchBulkEndpoint,
+		body:    body.Bytes(),
+		headers: headers,
+	}
+	response := client.httpWrapper.post(ctx, postInput)
+	if response == nil || !response.Success {
+		return response
+	}
+	return getAlertResponseFromBulkResponse(response)
+}
+
+// getAlertResponseFromBulkResponse - the bulk API responds with 200 OK even if the document was not indexed
+func getAlertResponseFromBulkResponse(response *AlertDeliveryResponse) *AlertDeliveryResponse {
+	var bulkResponse elasticsearchBulkResponse
+	if err := jsoniter.UnmarshalFromString(response.Message, &bulkResponse); err != nil || !bulkResponse.Errors {
+		return response
+	}
+	for _, item := range bulkResponse.Items {
+		if item.Index.Status >= 200 && item.Index.Status <= 299 {
+			continue
+		}
+		return &AlertDeliveryResponse{
+			StatusCode: item.Index.Status,
+			Success:    false,
+			Message:    "failed to index alert: " + item.Index.Error.Type + ": " + item.Index.Error.Reason,
+			// Mapping errors will fail again, while throttling (429) and unavailable shards can be retried
+			Permanent: item.Index.Status >= 400 && item.Index.Status < 500 && item.Index.Status != 429,
+		}
+	}
+	return response
+}
+
+func elasticsearchMarshalError(err error) *AlertDeliveryResponse {
+	return &AlertDeliveryResponse{
+		StatusCode: 500,
+		Success:    false,
+		Message:    "json marshal error: " + err.Error(),
+		Permanent:  true,
+	}
+}
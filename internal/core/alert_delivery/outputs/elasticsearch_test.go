@@ -0,0 +1,106 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	deliverymodel "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+)
+
+var elasticsearchAlert = &deliverymodel.Alert{
+	AlertID:    aws.String("alertId"),
+	AnalysisID: "ruleId",
+	Type:       deliverymodel.RuleType,
+	CreatedAt:  time.Date(2020, 12, 1, 10, 0, 0, 0, time.UTC),
+	Severity:   "MEDIUM",
+}
+
+func TestElasticsearchAlert(t *testing.T) {
+	client, url, request := newTestServer(t, 200,
+		`{"took":3,"errors":false,"items":[{"index":{"_index":"alerts","_id":"alertId","status":201}}]}`)
+	config := &outputModels.ElasticsearchConfig{
+		URL:      url,
+		Index:    "alerts",
+		UserName: "user",
+		Password: "password",
+	}
+
+	response := client.Elasticsearch(context.Background(), elasticsearchAlert, config)
+	require.NotNil(t, response)
+	assert.True(t, response.Success)
+
+	assert.Equal(t, "/_bulk", request.path)
+	assert.Equal(t, "application/x-ndjson", request.header.Get("Content-Type"))
+	assert.Equal(t, "Basic dXNlcjpwYXNzd29yZA==", request.header.Get(AuthorizationHTTPHeader))
+
+	lines := strings.Split(request.body, "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, `{"index":{"_index":"alerts","_id":"alertId"}}`, lines[0])
+	var document map[string]interface{}
+	require.NoError(t, jsoniter.UnmarshalFromString(lines[1], &document))
+	assert.Equal(t, "2020-12-01T10:00:00Z", document["@timestamp"])
+	assert.Equal(t, "ruleId", document["id"])
+	assert.Equal(t, "MEDIUM", document["severity"])
+	assert.Empty(t, lines[2])
+}
+
+func TestElasticsearchAPIKey(t *testing.T) {
+	client, url, request := newTestServer(t, 200, `{"errors":false,"items":[]}`)
+	config := &outputModels.ElasticsearchConfig{URL: url, Index: "alerts", APIKey: "key"}
+
+	response := client.Elasticsearch(context.Background(), elasticsearchAlert, config)
+	require.NotNil(t, response)
+	assert.True(t, response.Success)
+	assert.Equal(t, "ApiKey key", request.header.Get(AuthorizationHTTPHeader))
+}
+
+func TestElasticsearchDocumentError(t *testing.T) {
+	client, url, _ := newTestServer(t, 200, `{"errors":true,"items":[{"index":{"status":400,`+
+		`"error":{"type":"mapper_parsing_exception","reason":"failed to parse field [severity]"}}}]}`)
+	config := &outputModels.ElasticsearchConfig{URL: url, Index: "alerts"}
+
+	response := client.Elasticsearch(context.Background(), elasticsearchAlert, config)
+	assert.Equal(t, &AlertDeliveryResponse{
+		StatusCode: 400,
+		Success:    false,
+		Message:    "failed to index alert: mapper_parsing_exception: failed to parse field [severity]",
+		Permanent:  true,
+	}, response)
+}
+
+func TestElasticsearchThrottled(t *testing.T) {
+	client, url, _ := newTestServer(t, 200, `{"errors":true,"items":[{"index":{"status":429,`+
+		`"error":{"type":"es_rejected_execution_exception","reason":"rejected execution"}}}]}`)
+	config := &outputModels.ElasticsearchConfig{URL: url, Index: "alerts"}
+
+	response := client.Elasticsearch(context.Background(), elasticsearchAlert, config)
+	require.NotNil(t, response)
+	assert.False(t, response.Success)
+	assert.False(t, response.Permanent)
+}
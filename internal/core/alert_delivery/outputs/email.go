@@ -0,0 +1,166 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	deliverymodel "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+)
+
+const (
+	defaultSMTPPort = 587
+	smtpTimeout     = 30 * time.Second
+)
+
+// Email sends an alert to a list of recipients through an SMTP server.
+func (client *OutputClient) Email(
+	ctx context.Context, alert *deliverymodel.Alert, config *outputModels.EmailConfig) *AlertDeliveryResponse {
+
+	message, err := generateEmailMessage(config, generateAlertTitle(alert), generateDetailedAlertMessage(alert))
+	if err != nil {
+		return &AlertDeliveryResponse{
+			StatusCode: 500,
+			Success:    false,
+			Message:    "email message error: " + err.Error(),
+			Permanent:  true,
+		}
+	}
+
+	if err := sendEmail(ctx, config, message); err != nil {
+		return getAlertResponseFromSMTPError(err)
+	}
+	return &AlertDeliveryResponse{
+		StatusCode: 200,
+		Success:    true,
+		Message:    "email sent to " + strings.Join(config.To, ", "),
+		Permanent:  false,
+	}
+}
+
+func generateEmailMessage(config *outputModels.EmailConfig, subject, body string) ([]byte, error) {
+	var message bytes.Buffer
+	headers := []string{
+		"From: " + config.From,
+		"To: " + strings.Join(config.To, ", "),
+		"Subject: " + mime.QEncoding.Encode("utf-8", removeNewLines(subject)),
+		"Date: " + time.Now().UTC().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		`Content-Type: text/plain; charset="utf-8"`,
+		"Content-Transfer-Encoding: quoted-printable",
+	}
+	message.WriteString(strings.Join(headers, "\r\n") + "\r\n\r\n")
+
+	writer := quotedprintable.NewWriter(&message)
+	if _, err := writer.Write([]byte(body)); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return message.Bytes(), nil
+}
+
+// sendEmail delivers a message, upgrading the connection with STARTTLS when the server supports it
+func sendEmail(ctx context.Context, config *outputModels.EmailConfig, message []byte) error {
+	port := config.Port
+	if port == 0 {
+		port = defaultSMTPPort
+	}
+	dialer := &net.Dialer{Timeout: smtpTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(config.Host, strconv.Itoa(port)))
+	if err != nil {
+		return err
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(smtpTimeout)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+
+	smtpClient, err := smtp.NewClient(conn, config.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer smtpClient.Close()
+
+	if ok, _ := smtpClient.Extension("STARTTLS"); ok {
+		if err := smtpClient.StartTLS(&tls.Config{ServerName: config.Host}); err != nil {
+			return err
+		}
+	}
+	// PlainAuth refuses to send the credentials over an unencrypted connection
+	if config.UserName != "" {
+		if err := smtpClient.Auth(smtp.PlainAuth("", config.UserName, config.Password, config.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := smtpClient.Mail(config.From); err != nil {
+		return err
+	}
+	for _, recipient := range config.To {
+		if err := smtpClient.Rcpt(recipient); err != nil {
+			return errors.WithMessagef(err, "recipient %s", recipient)
+		}
+	}
+	writer, err := smtpClient.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(message); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	return smtpClient.Quit()
+}
+
+// getAlertResponseFromSMTPError - permanent SMTP failures (5xx) are not retried
+func getAlertResponseFromSMTPError(err error) *AlertDeliveryResponse {
+	var smtpErr *textproto.Error
+	if errors.As(err, &smtpErr) {
+		return &AlertDeliveryResponse{
+			StatusCode: smtpErr.Code,
+			Success:    false,
+			Message:    fmt.Sprintf("smtp error: %s", err),
+			Permanent:  smtpErr.Code >= 500,
+		}
+	}
+	return getResponse(500, "smtp error: "+err.Error())
+}
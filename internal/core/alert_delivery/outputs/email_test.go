@@ -0,0 +1,190 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	deliverymodel "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+)
+
+// smtpSession is what a local SMTP stand-in received from the client
+type smtpSession struct {
+	auth       string
+	from       string
+	recipients []string
+	data       string
+}
+
+// newSMTPServer starts a local SMTP stand-in which accepts a single session.
+//
+// Recipients in the rejected list are refused with a permanent error.
+func newSMTPServer(t *testing.T, rejected ...string) (string, int, <-chan *smtpSession) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	sessions := make(chan *smtpSession, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		session := &smtpSession{}
+		defer func() { sessions <- session }()
+
+		text := textproto.NewConn(conn)
+		_ = text.PrintfLine("220 localhost ESMTP")
+		for {
+			line, err := text.ReadLine()
+			if err != nil {
+				return
+			}
+			command := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+			switch command {
+			case "EHLO":
+				_ = text.PrintfLine("250-localhost")
+				_ = text.PrintfLine("250 AUTH PLAIN")
+			case "AUTH":
+				session.auth = strings.TrimPrefix(line, "AUTH PLAIN ")
+				_ = text.PrintfLine("235 Authentication successful")
+			case "MAIL":
+				session.from = line
+				_ = text.PrintfLine("250 OK")
+			case "RCPT":
+				recipient := strings.TrimSuffix(strings.TrimPrefix(line, "RCPT TO:<"), ">")
+				if contains(rejected, recipient) {
+					_ = text.PrintfLine("550 No such user")
+					continue
+				}
+				session.recipients = append(session.recipients, recipient)
+				_ = text.PrintfLine("250 OK")
+			case "DATA":
+				_ = text.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+				data, err := text.ReadDotBytes()
+				if err != nil {
+					return
+				}
+				session.data = string(data)
+				_ = text.PrintfLine("250 OK")
+			case "QUIT":
+				_ = text.PrintfLine("221 Bye")
+				return
+			default:
+				_ = text.PrintfLine("502 Command not implemented")
+			}
+		}
+	}()
+
+	addr := listener.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port, sessions
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+var emailAlert = &deliverymodel.Alert{
+	AlertID:             aws.String("alertId"),
+	AnalysisID:          "ruleId",
+	Type:                deliverymodel.RuleType,
+	Severity:            "HIGH",
+	Title:               "Unusual login from ünknown country",
+	AnalysisDescription: "description",
+}
+
+func TestEmailAlert(t *testing.T) {
+	host, port, sessions := newSMTPServer(t)
+	config := &outputModels.EmailConfig{
+		Host:     host,
+		Port:     port,
+		UserName: "user",
+		Password: "password",
+		From:     "panther@example.com",
+		To:       []string{"security@example.com", "oncall@example.com"},
+	}
+
+	response := (&OutputClient{}).Email(context.Background(), emailAlert, config)
+	require.NotNil(t, response)
+	assert.True(t, response.Success, response.Message)
+
+	session := <-sessions
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("\x00user\x00password")), session.auth)
+	assert.Equal(t, "MAIL FROM:<panther@example.com>", session.from)
+	assert.Equal(t, []string{"security@example.com", "oncall@example.com"}, session.recipients)
+
+	message, err := textproto.NewReader(bufio.NewReader(strings.NewReader(session.data))).ReadMIMEHeader()
+	require.NoError(t, err)
+	assert.Equal(t, "panther@example.com", message.Get("From"))
+	assert.Equal(t, "security@example.com, oncall@example.com", message.Get("To"))
+	assert.Equal(t, "=?utf-8?q?New_Alert:_Unusual_login_from_=C3=BCnknown_country?=", message.Get("Subject"))
+	assert.Contains(t, session.data, "For more details please visit: https://panther.io/alerts/alertId")
+}
+
+func TestEmailRejectedRecipient(t *testing.T) {
+	host, port, _ := newSMTPServer(t, "nobody@example.com")
+	config := &outputModels.EmailConfig{
+		Host: host,
+		Port: port,
+		From: "panther@example.com",
+		To:   []string{"nobody@example.com"},
+	}
+
+	response := (&OutputClient{}).Email(context.Background(), emailAlert, config)
+	require.NotNil(t, response)
+	assert.False(t, response.Success)
+	assert.True(t, response.Permanent)
+	assert.Equal(t, 550, response.StatusCode)
+	assert.Contains(t, response.Message, "recipient nobody@example.com")
+}
+
+func TestEmailConnectionRefused(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	config := &outputModels.EmailConfig{
+		Host: "127.0.0.1",
+		Port: port,
+		From: "panther@example.com",
+		To:   []string{"security@example.com"},
+	}
+	response := (&OutputClient{}).Email(context.Background(), emailAlert, config)
+	require.NotNil(t, response)
+	assert.False(t, response.Success)
+	assert.False(t, response.Permanent)
+}
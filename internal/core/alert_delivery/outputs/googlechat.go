@@ -0,0 +1,56 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+
+	deliverymodel "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+)
+
+// GoogleChat sends an alert to a Google Chat space through an incoming webhook.
+func (client *OutputClient) GoogleChat(
+	ctx context.Context, alert *deliverymodel.Alert, config *outputModels.GoogleChatConfig) *AlertDeliveryResponse {
+
+	// Best effort attempt to marshal Alert Context
+	marshaledContext, _ := jsoniter.MarshalToString(alert.Context)
+
+	// https://developers.google.com/chat/reference/message-formats/basic
+	lines := []string{
+		"*" + generateAlertTitle(alert) + "*",
+		"<" + generateURL(alert) + "|Click here to view in the Panther UI>",
+		"*Severity:* " + alert.Severity,
+		"*Description:* " + alert.AnalysisDescription,
+		"*Runbook:* " + alert.Runbook,
+		"*Tags:* " + strings.Join(alert.Tags, ", "),
+		"*AlertContext:* ```" + marshaledContext + "```",
+	}
+
+	postInput := &PostInput{
+		url: config.WebhookURL,
+		body: map[string]string{
+			"text": strings.Join(lines, "\n"),
+		},
+	}
+	return client.httpWrapper.post(ctx, postInput)
+}
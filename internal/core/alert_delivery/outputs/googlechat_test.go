@@ -0,0 +1,62 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	deliverymodel "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+)
+
+func TestGoogleChatAlert(t *testing.T) {
+	client, url, request := newTestServer(t, 200, `{"name":"spaces/AAAA/messages/BBBB"}`)
+	alert := &deliverymodel.Alert{
+		AlertID:             aws.String("alertId"),
+		AnalysisID:          "ruleId",
+		Type:                deliverymodel.RuleType,
+		Severity:            "LOW",
+		AnalysisDescription: "description",
+		Runbook:             "runbook",
+		Tags:                []string{"a", "b"},
+		Context:             map[string]interface{}{"key": "value"},
+	}
+
+	response := client.GoogleChat(context.Background(), alert, &outputModels.GoogleChatConfig{
+		WebhookURL: url + "/v1/spaces/AAAA/messages?key=key&token=token",
+	})
+	require.NotNil(t, response)
+	assert.True(t, response.Success)
+	assert.Equal(t, "/v1/spaces/AAAA/messages", request.path)
+	var message map[string]string
+	require.NoError(t, jsoniter.UnmarshalFromString(request.body, &message))
+	assert.Equal(t, "*New Alert: ruleId*\n"+
+		"<https://panther.io/alerts/alertId|Click here to view in the Panther UI>\n"+
+		"*Severity:* LOW\n"+
+		"*Description:* description\n"+
+		"*Runbook:* runbook\n"+
+		"*Tags:* a, b\n"+
+		"*AlertContext:* ```{\"key\":\"value\"}```", message["text"])
+}
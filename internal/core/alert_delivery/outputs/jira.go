@@ -47,11 +47,38 @@ func (client *OutputClient) Jira(
 	marshaledContext, _ := jsoniter.MarshalToString(alert.Context)
 	alertContext := "\n *AlertContext:* " + marshaledContext
 
-	summary := removeNewLines(generateAlertTitle(alert))
+	title, body := renderAlertTemplate(config.Template, alert)
+	if title == "" {
+		title = generateAlertTitle(alert)
+	}
+	summary := removeNewLines(title)
+
+	// A templated body replaces the default description
+	if body != "" {
+		return client.postJiraIssue(ctx, summary, body+link, config)
+	}
+	return client.postJiraIssue(ctx, summary, description+link+runBook+severity+tags+alertContext, config)
+}
+
+// JiraDigest creates a single issue for a digest of alerts.
+func (client *OutputClient) JiraDigest(
+	ctx context.Context, digest *Digest, config *outputModels.JiraConfig) *AlertDeliveryResponse {
+
+	description := generateDigestText(digest, func(text, url string) string {
+		return "[" + text + "|" + url + "]"
+	})
+	link := "\n [Click here to view in the Panther UI|" + appDomainURL + "]"
+
+	summary := removeNewLines(generateDigestTitle(digest))
+	return client.postJiraIssue(ctx, summary, description+link, config)
+}
+
+func (client *OutputClient) postJiraIssue(
+	ctx context.Context, summary, description string, config *outputModels.JiraConfig) *AlertDeliveryResponse {
 
 	fields := map[string]interface{}{
 		"summary":     summary,
-		"description": description + link + runBook + severity + tags + alertContext,
+		"description": description,
 		"project": map[string]*string{
 			"key": aws.String(config.ProjectKey),
 		},
@@ -91,3 +91,43 @@ func TestJiraAlert(t *testing.T) {
 	assert.Nil(t, client.Jira(ctx, alert, jiraConfig))
 	httpWrapper.AssertExpectations(t)
 }
+
+func TestJiraDigest(t *testing.T) {
+	httpWrapper := &mockHTTPWrapper{}
+	client := &OutputClient{httpWrapper: httpWrapper}
+
+	jiraPayload := map[string]interface{}{
+		"fields": map[string]interface{}{
+			"summary": "Alert Digest: 4 alerts from 01 Dec 20 10:00 UTC to 01 Dec 20 10:15 UTC",
+			"description": "HIGH: 1 alerts\n" +
+				"- [rule.b|https://panther.io/alerts/alert-2]: 1\n" +
+				"LOW: 3 alerts\n" +
+				"- [Rule A|https://panther.io/alerts/alert-3]: 2\n" +
+				"- [rule.c|https://panther.io/alerts/alert-4]: 1\n" +
+				" [Click here to view in the Panther UI|https://panther.io]",
+			"project": map[string]*string{
+				"key": aws.String(jiraConfig.ProjectKey),
+			},
+			"issuetype": map[string]*string{
+				"name": aws.String(jiraConfig.Type),
+			},
+			"assignee": map[string]*string{
+				"id": aws.String(jiraConfig.AssigneeID),
+			},
+			"labels": aws.StringSlice(jiraConfig.Labels),
+		},
+	}
+	auth := jiraConfig.UserName + ":" + jiraConfig.APIKey
+	expectedPostInput := &PostInput{
+		url:  "https://panther-labs.atlassian.net/rest/api/latest/issue/",
+		body: jiraPayload,
+		headers: map[string]string{
+			AuthorizationHTTPHeader: "Basic " + base64.StdEncoding.EncodeToString([]byte(auth)),
+		},
+	}
+	ctx := context.Background()
+	httpWrapper.On("post", ctx, expectedPostInput).Return((*AlertDeliveryResponse)(nil))
+
+	assert.Nil(t, client.JiraDigest(ctx, sampleDigest(), jiraConfig))
+	httpWrapper.AssertExpectations(t)
+}
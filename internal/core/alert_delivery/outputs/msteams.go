@@ -20,6 +20,7 @@ package outputs
 
 import (
 	"context"
+	"strconv"
 	"strings"
 
 	jsoniter "github.com/json-iterator/go"
@@ -34,25 +35,81 @@ func (client *OutputClient) MsTeams(
 
 	link := "[Click here to view in the Panther UI](" + generateURL(alert) + ").\n"
 
+	title, body := renderAlertTemplate(config.Template, alert)
+	if title == "" {
+		title = generateAlertTitle(alert)
+	}
+
 	// Best effort attempt to marshal Alert Context
 	marshaledContext, _ := jsoniter.MarshalToString(alert.Context)
 
+	section := map[string]interface{}{
+		"facts": []interface{}{
+			map[string]string{"name": "Description", "value": alert.AnalysisDescription},
+			map[string]string{"name": "Runbook", "value": alert.Runbook},
+			map[string]string{"name": "Severity", "value": alert.Severity},
+			map[string]string{"name": "Tags", "value": strings.Join(alert.Tags, ", ")},
+			map[string]string{"name": "AlertContext", "value": marshaledContext},
+		},
+		"text": link,
+	}
+	// A templated body replaces the default facts
+	if body != "" {
+		section = map[string]interface{}{
+			"text": body + "\n\n" + link,
+		}
+	}
+
 	msTeamsRequestBody := map[string]interface{}{
 		"@context": "http://schema.org/extensions",
 		"@type":    "MessageCard",
-		"text":     generateAlertTitle(alert),
-		"sections": []interface{}{
+		"text":     title,
+		"sections": []interface{}{section},
+		"potentialAction": []interface{}{
 			map[string]interface{}{
-				"facts": []interface{}{
-					map[string]string{"name": "Description", "value": alert.AnalysisDescription},
-					map[string]string{"name": "Runbook", "value": alert.Runbook},
-					map[string]string{"name": "Severity", "value": alert.Severity},
-					map[string]string{"name": "Tags", "value": strings.Join(alert.Tags, ", ")},
-					map[string]string{"name": "AlertContext", "value": marshaledContext},
+				"@type": "OpenUri",
+				"name":  "Click here to view in the Panther UI",
+				"targets": []interface{}{
+					map[string]string{
+						"os":  "default",
+						"uri": generateURL(alert),
+					},
 				},
-				"text": link,
 			},
 		},
+	}
+
+	postInput := &PostInput{
+		url:  config.WebhookURL,
+		body: msTeamsRequestBody,
+	}
+	return client.httpWrapper.post(ctx, postInput)
+}
+
+// MsTeamsDigest sends a digest of alerts, with one section per severity.
+func (client *OutputClient) MsTeamsDigest(
+	ctx context.Context, digest *Digest, config *outputModels.MsTeamsConfig) *AlertDeliveryResponse {
+
+	sections := []interface{}{}
+	for _, group := range digest.Groups() {
+		facts := make([]interface{}, 0, len(group.Rules))
+		for _, rule := range group.Rules {
+			facts = append(facts, map[string]string{
+				"name":  "[" + rule.Name + "](" + rule.Link + ")",
+				"value": strconv.Itoa(rule.Count),
+			})
+		}
+		sections = append(sections, map[string]interface{}{
+			"activityTitle": group.Severity + ": " + strconv.Itoa(group.Count) + " alerts",
+			"facts":         facts,
+		})
+	}
+
+	msTeamsRequestBody := map[string]interface{}{
+		"@context": "http://schema.org/extensions",
+		"@type":    "MessageCard",
+		"text":     generateDigestTitle(digest),
+		"sections": sections,
 		"potentialAction": []interface{}{
 			map[string]interface{}{
 				"@type": "OpenUri",
@@ -60,7 +117,7 @@ func (client *OutputClient) MsTeams(
 				"targets": []interface{}{
 					map[string]string{
 						"os":  "default",
-						"uri": generateURL(alert),
+						"uri": appDomainURL,
 					},
 				},
 			},
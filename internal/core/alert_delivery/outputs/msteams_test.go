@@ -92,3 +92,51 @@ func TestMsTeamsAlert(t *testing.T) {
 	assert.Nil(t, client.MsTeams(ctx, alert, msTeamConfig))
 	httpWrapper.AssertExpectations(t)
 }
+
+func TestMsTeamsDigest(t *testing.T) {
+	httpWrapper := &mockHTTPWrapper{}
+	client := &OutputClient{httpWrapper: httpWrapper}
+
+	msTeamsPayload := map[string]interface{}{
+		"@context": "http://schema.org/extensions",
+		"@type":    "MessageCard",
+		"text":     "Alert Digest: 4 alerts from 01 Dec 20 10:00 UTC to 01 Dec 20 10:15 UTC",
+		"sections": []interface{}{
+			map[string]interface{}{
+				"activityTitle": "HIGH: 1 alerts",
+				"facts": []interface{}{
+					map[string]string{"name": "[rule.b](https://panther.io/alerts/alert-2)", "value": "1"},
+				},
+			},
+			map[string]interface{}{
+				"activityTitle": "LOW: 3 alerts",
+				"facts": []interface{}{
+					map[string]string{"name": "[Rule A](https://panther.io/alerts/alert-3)", "value": "2"},
+					map[string]string{"name": "[rule.c](https://panther.io/alerts/alert-4)", "value": "1"},
+				},
+			},
+		},
+		"potentialAction": []interface{}{
+			map[string]interface{}{
+				"@type": "OpenUri",
+				"name":  "Click here to view in the Panther UI",
+				"targets": []interface{}{
+					map[string]string{
+						"os":  "default",
+						"uri": "https://panther.io",
+					},
+				},
+			},
+		},
+	}
+
+	expectedPostInput := &PostInput{
+		url:  "msteam-url",
+		body: msTeamsPayload,
+	}
+	ctx := context.Background()
+	httpWrapper.On("post", ctx, expectedPostInput).Return((*AlertDeliveryResponse)(nil))
+
+	assert.Nil(t, client.MsTeamsDigest(ctx, sampleDigest(), msTeamConfig))
+	httpWrapper.AssertExpectations(t)
+}
@@ -73,6 +73,15 @@ type API interface {
 	Sns(context.Context, *deliverymodel.Alert, *outputModels.SnsConfig) *AlertDeliveryResponse
 	Asana(context.Context, *deliverymodel.Alert, *outputModels.AsanaConfig) *AlertDeliveryResponse
 	CustomWebhook(context.Context, *deliverymodel.Alert, *outputModels.CustomWebhookConfig) *AlertDeliveryResponse
+	Email(context.Context, *deliverymodel.Alert, *outputModels.EmailConfig) *AlertDeliveryResponse
+	Splunk(context.Context, *deliverymodel.Alert, *outputModels.SplunkConfig) *AlertDeliveryResponse
+	Elasticsearch(context.Context, *deliverymodel.Alert, *outputModels.ElasticsearchConfig) *AlertDeliveryResponse
+	GoogleChat(context.Context, *deliverymodel.Alert, *outputModels.GoogleChatConfig) *AlertDeliveryResponse
+	ServiceNow(context.Context, *deliverymodel.Alert, *outputModels.ServiceNowConfig) *AlertDeliveryResponse
+	SlackDigest(context.Context, *Digest, *outputModels.SlackConfig) *AlertDeliveryResponse
+	MsTeamsDigest(context.Context, *Digest, *outputModels.MsTeamsConfig) *AlertDeliveryResponse
+	JiraDigest(context.Context, *Digest, *outputModels.JiraConfig) *AlertDeliveryResponse
+	CustomWebhookDigest(context.Context, *Digest, *outputModels.CustomWebhookConfig) *AlertDeliveryResponse
 }
 
 // OutputClient encapsulates the clients that allow sending alerts to multiple outputs
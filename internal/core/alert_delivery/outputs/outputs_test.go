@@ -20,6 +20,9 @@ package outputs
 
 import (
 	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -30,6 +33,7 @@ import (
 )
 
 func init() {
+	appDomainURL = "https://panther.io"
 	alertURLPrefix = "https://panther.io/alerts/"
 }
 
@@ -43,6 +47,34 @@ func (m *mockHTTPWrapper) post(cxt context.Context, postInput *PostInput) *Alert
 	return args.Get(0).(*AlertDeliveryResponse)
 }
 
+// recordedRequest is the last request received by a test server
+type recordedRequest struct {
+	path   string
+	header http.Header
+	body   string
+}
+
+// newTestServer starts a local HTTP stand-in for an output which replies with a fixed response.
+//
+// It returns a client sending requests to the server and the URL of the server.
+func newTestServer(t *testing.T, statusCode int, response string) (*OutputClient, string, *recordedRequest) {
+	request := &recordedRequest{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		request.path = r.URL.Path
+		request.header = r.Header
+		request.body = string(body)
+		w.WriteHeader(statusCode)
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+	client := &OutputClient{httpWrapper: &HTTPWrapper{httpClient: server.Client()}}
+	return client, server.URL, request
+}
+
 func TestGenerateAlertTitleReturnGivenTitle(t *testing.T) {
 	alert := &alertModel.Alert{
 		Title: "my title",
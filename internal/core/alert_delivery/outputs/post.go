@@ -31,9 +31,9 @@ const (
 	AuthorizationHTTPHeader = "Authorization"
 )
 
-// post sends a JSON body to an endpoint.
+// post sends a JSON body to an endpoint. A body of bytes is sent as is.
 func (client *HTTPWrapper) post(ctx context.Context, input *PostInput) *AlertDeliveryResponse {
-	payload, err := jsoniter.Marshal(input.body)
+	payload, err := marshalBody(input.body)
 
 	// If there was an error marshaling the input
 	if err != nil {
@@ -98,3 +98,10 @@ func (client *HTTPWrapper) post(ctx context.Context, input *PostInput) *AlertDel
 		Permanent:  false,
 	}
 }
+
+func marshalBody(body interface{}) ([]byte, error) {
+	if payload, ok := body.([]byte); ok {
+		return payload, nil
+	}
+	return jsoniter.Marshal(body)
+}
@@ -0,0 +1,85 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+
+	deliverymodel "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+)
+
+const (
+	serviceNowIncidentEndpoint = "/api/now/table/incident"
+	// The short description of an incident is limited to 160 characters
+	maxServiceNowSummarySize = 160
+)
+
+// ServiceNow urgency and impact: 1 (High), 2 (Medium), 3 (Low)
+var pantherToServiceNowUrgency = map[string]string{
+	"CRITICAL": "1",
+	"HIGH":     "1",
+	"MEDIUM":   "2",
+	"LOW":      "3",
+	"INFO":     "3",
+}
+
+var pantherToServiceNowImpact = map[string]string{
+	"CRITICAL": "1",
+	"HIGH":     "2",
+	"MEDIUM":   "2",
+	"LOW":      "3",
+	"INFO":     "3",
+}
+
+// ServiceNow creates an incident for an alert.
+func (client *OutputClient) ServiceNow(
+	ctx context.Context, alert *deliverymodel.Alert, config *outputModels.ServiceNowConfig) *AlertDeliveryResponse {
+
+	summary := removeNewLines(generateAlertTitle(alert))
+	if runes := []rune(summary); len(runes) > maxServiceNowSummarySize {
+		summary = string(runes[:maxServiceNowSummarySize])
+	}
+
+	incident := map[string]string{
+		"short_description":   summary,
+		"description":         generateDetailedAlertMessage(alert) + "\nTags: " + strings.Join(alert.Tags, ", "),
+		"urgency":             pantherToServiceNowUrgency[alert.Severity],
+		"impact":              pantherToServiceNowImpact[alert.Severity],
+		"correlation_id":      aws.StringValue(alert.AlertID),
+		"correlation_display": "Panther",
+	}
+	if config.AssignmentGroup != "" {
+		incident["assignment_group"] = config.AssignmentGroup
+	}
+
+	auth := config.UserName + ":" + config.Password
+	postInput := &PostInput{
+		url:  strings.TrimSuffix(config.InstanceURL, "/") + serviceNowIncidentEndpoint,
+		body: incident,
+		headers: map[string]string{
+			AuthorizationHTTPHeader: "Basic " + base64.StdEncoding.EncodeToString([]byte(auth)),
+		},
+	}
+	return client.httpWrapper.post(ctx, postInput)
+}
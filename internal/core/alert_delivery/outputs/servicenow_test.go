@@ -0,0 +1,73 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	deliverymodel "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+)
+
+func TestServiceNowAlert(t *testing.T) {
+	client, url, request := newTestServer(t, 201, `{"result":{"number":"INC0010001"}}`)
+	config := &outputModels.ServiceNowConfig{
+		InstanceURL:     url + "/",
+		UserName:        "user",
+		Password:        "password",
+		AssignmentGroup: "Security",
+	}
+	alert := &deliverymodel.Alert{
+		AlertID:    aws.String("alertId"),
+		AnalysisID: "ruleId",
+		Type:       deliverymodel.RuleType,
+		Severity:   "CRITICAL",
+		Title:      strings.Repeat("a", 200),
+	}
+
+	response := client.ServiceNow(context.Background(), alert, config)
+	require.NotNil(t, response)
+	assert.True(t, response.Success)
+	assert.Equal(t, 201, response.StatusCode)
+
+	assert.Equal(t, "/api/now/table/incident", request.path)
+	assert.Equal(t, "Basic dXNlcjpwYXNzd29yZA==", request.header.Get(AuthorizationHTTPHeader))
+	var incident map[string]string
+	require.NoError(t, jsoniter.UnmarshalFromString(request.body, &incident))
+	assert.Len(t, incident["short_description"], 160)
+	assert.Equal(t, "1", incident["urgency"])
+	assert.Equal(t, "1", incident["impact"])
+	assert.Equal(t, "alertId", incident["correlation_id"])
+	assert.Equal(t, "Security", incident["assignment_group"])
+	assert.Contains(t, incident["description"], "For more details please visit: https://panther.io/alerts/alertId")
+}
+
+func TestServiceNowSeverityMapping(t *testing.T) {
+	for _, severity := range []string{"INFO", "LOW", "MEDIUM", "HIGH", "CRITICAL"} {
+		assert.Contains(t, pantherToServiceNowUrgency, severity)
+		assert.Contains(t, pantherToServiceNowImpact, severity)
+	}
+}
@@ -21,6 +21,7 @@ package outputs
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	deliverymodel "github.com/panther-labs/panther/api/lambda/delivery/models"
 	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
@@ -42,6 +43,11 @@ func (client *OutputClient) Slack(
 	config *outputModels.SlackConfig,
 ) *AlertDeliveryResponse {
 
+	title, body := renderAlertTemplate(config.Template, alert)
+	if title == "" {
+		title = generateAlertTitle(alert)
+	}
+
 	messageField := fmt.Sprintf("<%s|%s>",
 		generateURL(alert),
 		"Click here to view in the Panther UI")
@@ -61,13 +67,26 @@ func (client *OutputClient) Slack(
 			"short": true,
 		},
 	}
+	// A templated body replaces the default fields
+	if body != "" {
+		fields = []map[string]interface{}{
+			{
+				"value": messageField,
+				"short": false,
+			},
+			{
+				"value": body,
+				"short": false,
+			},
+		}
+	}
 
 	payload := map[string]interface{}{
 		"attachments": []map[string]interface{}{
 			{
-				"fallback": generateAlertTitle(alert),
+				"fallback": title,
 				"color":    severityColors[alert.Severity],
-				"title":    generateAlertTitle(alert),
+				"title":    title,
 				"fields":   fields,
 			},
 		},
@@ -79,3 +98,36 @@ func (client *OutputClient) Slack(
 
 	return client.httpWrapper.post(ctx, postInput)
 }
+
+// SlackDigest sends a digest of alerts to a slack channel, with one attachment per severity.
+func (client *OutputClient) SlackDigest(
+	ctx context.Context,
+	digest *Digest,
+	config *outputModels.SlackConfig,
+) *AlertDeliveryResponse {
+
+	attachments := []map[string]interface{}{}
+	for _, group := range digest.Groups() {
+		lines := make([]string, 0, len(group.Rules))
+		for _, rule := range group.Rules {
+			lines = append(lines, fmt.Sprintf("<%s|%s>: %d", rule.Link, rule.Name, rule.Count))
+		}
+		attachments = append(attachments, map[string]interface{}{
+			"fallback": fmt.Sprintf("%s: %d alerts", group.Severity, group.Count),
+			"color":    severityColors[group.Severity],
+			"title":    fmt.Sprintf("%s: %d alerts", group.Severity, group.Count),
+			"text":     strings.Join(lines, "\n"),
+		})
+	}
+
+	payload := map[string]interface{}{
+		"text":        fmt.Sprintf("<%s|%s>", appDomainURL, generateDigestTitle(digest)),
+		"attachments": attachments,
+	}
+	postInput := &PostInput{
+		url:  config.WebhookURL,
+		body: payload,
+	}
+
+	return client.httpWrapper.post(ctx, postInput)
+}
@@ -83,3 +83,36 @@ func TestSlackAlert(t *testing.T) {
 	require.Nil(t, client.Slack(ctx, alert, slackConfig))
 	httpWrapper.AssertExpectations(t)
 }
+
+func TestSlackDigest(t *testing.T) {
+	httpWrapper := &mockHTTPWrapper{}
+	client := &OutputClient{httpWrapper: httpWrapper}
+
+	expectedPostPayload := map[string]interface{}{
+		"text": "<https://panther.io|Alert Digest: 4 alerts from 01 Dec 20 10:00 UTC to 01 Dec 20 10:15 UTC>",
+		"attachments": []map[string]interface{}{
+			{
+				"color":    "#cb2e2e",
+				"fallback": "HIGH: 1 alerts",
+				"title":    "HIGH: 1 alerts",
+				"text":     "<https://panther.io/alerts/alert-2|rule.b>: 1",
+			},
+			{
+				"color":    "#f7d154",
+				"fallback": "LOW: 3 alerts",
+				"title":    "LOW: 3 alerts",
+				"text":     "<https://panther.io/alerts/alert-3|Rule A>: 2\n<https://panther.io/alerts/alert-4|rule.c>: 1",
+			},
+		},
+	}
+	expectedPostInput := &PostInput{
+		url:  slackConfig.WebhookURL,
+		body: expectedPostPayload,
+	}
+
+	ctx := context.Background()
+	httpWrapper.On("post", ctx, expectedPostInput).Return((*AlertDeliveryResponse)(nil))
+
+	require.Nil(t, client.SlackDigest(ctx, sampleDigest(), slackConfig))
+	httpWrapper.AssertExpectations(t)
+}
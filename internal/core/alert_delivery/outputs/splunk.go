@@ -0,0 +1,58 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+
+	deliverymodel "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+)
+
+// splunkEvent is the payload of the HTTP Event Collector (HEC) event endpoint
+type splunkEvent struct {
+	Time       float64      `json:"time"`
+	Index      string       `json:"index,omitempty"`
+	Source     string       `json:"source,omitempty"`
+	SourceType string       `json:"sourcetype,omitempty"`
+	Event      Notification `json:"event"`
+}
+
+// Splunk sends an alert as an event to a Splunk HTTP Event Collector.
+func (client *OutputClient) Splunk(
+	ctx context.Context, alert *deliverymodel.Alert, config *outputModels.SplunkConfig) *AlertDeliveryResponse {
+
+	event := &splunkEvent{
+		// HEC expects epoch seconds, with an optional fraction for milliseconds
+		Time:       float64(alert.CreatedAt.UnixNano()/1e6) / 1e3,
+		Index:      config.Index,
+		Source:     config.Source,
+		SourceType: config.SourceType,
+		Event:      generateNotificationFromAlert(alert),
+	}
+
+	postInput := &PostInput{
+		url:  config.URL,
+		body: event,
+		headers: map[string]string{
+			AuthorizationHTTPHeader: "Splunk " + config.Token,
+		},
+	}
+	return client.httpWrapper.post(ctx, postInput)
+}
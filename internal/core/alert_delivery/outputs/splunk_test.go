@@ -0,0 +1,75 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	deliverymodel "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+)
+
+func TestSplunkAlert(t *testing.T) {
+	client, url, request := newTestServer(t, 200, `{"text":"Success","code":0}`)
+	config := &outputModels.SplunkConfig{
+		URL:        url + "/services/collector/event",
+		Token:      "token",
+		Index:      "security",
+		SourceType: "panther:alert",
+	}
+	alert := &deliverymodel.Alert{
+		AlertID:    aws.String("alertId"),
+		AnalysisID: "ruleId",
+		Type:       deliverymodel.RuleType,
+		CreatedAt:  time.Date(2020, 12, 1, 10, 0, 0, 250e6, time.UTC),
+		Severity:   "HIGH",
+	}
+
+	response := client.Splunk(context.Background(), alert, config)
+	require.NotNil(t, response)
+	assert.True(t, response.Success)
+
+	assert.Equal(t, "/services/collector/event", request.path)
+	assert.Equal(t, "Splunk token", request.header.Get(AuthorizationHTTPHeader))
+	var event map[string]interface{}
+	require.NoError(t, jsoniter.UnmarshalFromString(request.body, &event))
+	assert.Equal(t, 1606816800.25, event["time"])
+	assert.Equal(t, "security", event["index"])
+	assert.Equal(t, "panther:alert", event["sourcetype"])
+	assert.NotContains(t, event, "source")
+	assert.Equal(t, "ruleId", event["event"].(map[string]interface{})["id"])
+	assert.Equal(t, "https://panther.io/alerts/alertId", event["event"].(map[string]interface{})["link"])
+}
+
+func TestSplunkInvalidToken(t *testing.T) {
+	client, url, _ := newTestServer(t, 403, `{"text":"Invalid token","code":4}`)
+	alert := &deliverymodel.Alert{AlertID: aws.String("alertId"), AnalysisID: "ruleId", Type: deliverymodel.RuleType}
+
+	response := client.Splunk(context.Background(), alert, &outputModels.SplunkConfig{URL: url, Token: "wrong"})
+	require.NotNil(t, response)
+	assert.False(t, response.Success)
+	assert.Equal(t, 403, response.StatusCode)
+}
@@ -0,0 +1,157 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	deliverymodel "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+)
+
+// TemplateData is the data available to notification templates.
+//
+// It extends the default notification payload with the triage fields of the alert.
+type TemplateData struct {
+	Notification
+
+	// The user-provided triage information of the rule or policy
+	Reference string `json:"reference"`
+
+	// The set of logs that could trigger the alert
+	LogTypes []string `json:"logTypes"`
+
+	// The set of resources that could trigger the alert
+	ResourceTypes []string `json:"resourceTypes"`
+
+	// The ID of the failing resource of a policy
+	ResourceID string `json:"resourceId"`
+}
+
+var templateFuncs = template.FuncMap{
+	"json":  templateJSON,
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// templateJSON encodes a value as JSON, e.g. to embed alert fields in a JSON webhook body
+func templateJSON(value interface{}) (string, error) {
+	return jsoniter.MarshalToString(value)
+}
+
+func generateTemplateData(alert *deliverymodel.Alert) *TemplateData {
+	return &TemplateData{
+		Notification:  generateNotificationFromAlert(alert),
+		Reference:     alert.Reference,
+		LogTypes:      alert.LogTypes,
+		ResourceTypes: alert.ResourceTypes,
+		ResourceID:    alert.ResourceID,
+	}
+}
+
+// RenderTemplate renders a notification template for an alert.
+//
+// It returns an empty title or body if the template does not override them.
+func RenderTemplate(tmpl *outputModels.NotificationTemplate, alert *deliverymodel.Alert) (string, string, error) {
+	if tmpl == nil {
+		return "", "", nil
+	}
+	data := generateTemplateData(alert)
+	title, err := executeTemplate("title", tmpl.Title, data)
+	if err != nil {
+		return "", "", err
+	}
+	body, err := executeTemplate("body", tmpl.Body, data)
+	if err != nil {
+		return "", "", err
+	}
+	return title, body, nil
+}
+
+// ValidateTemplate checks that a template can be rendered for an alert.
+func ValidateTemplate(text string) error {
+	_, err := executeTemplate("template", text, generateTemplateData(sampleTemplateAlert()))
+	return err
+}
+
+// ValidateJSONTemplate checks that a template renders valid JSON for an alert.
+func ValidateJSONTemplate(text string) error {
+	output, err := executeTemplate("template", text, generateTemplateData(sampleTemplateAlert()))
+	if err != nil {
+		return err
+	}
+	if output != "" && !jsoniter.Valid([]byte(output)) {
+		return errors.New("template does not render valid JSON")
+	}
+	return nil
+}
+
+func executeTemplate(name, text string, data *TemplateData) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+	tmpl, err := template.New(name).Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid template")
+	}
+	var output strings.Builder
+	if err := tmpl.Execute(&output, data); err != nil {
+		return "", errors.Wrap(err, "failed to render template")
+	}
+	return output.String(), nil
+}
+
+// renderAlertTemplate renders the template of an output, falling back to the default layout if it fails
+func renderAlertTemplate(tmpl *outputModels.NotificationTemplate, alert *deliverymodel.Alert) (string, string) {
+	title, body, err := RenderTemplate(tmpl, alert)
+	if err != nil {
+		zap.L().Warn("failed to render notification template, using the default layout",
+			zap.Stringp("alertID", alert.AlertID), zap.Error(err))
+		return "", ""
+	}
+	return title, body
+}
+
+// sampleTemplateAlert is used to check templates when they are saved
+func sampleTemplateAlert() *deliverymodel.Alert {
+	return &deliverymodel.Alert{
+		AnalysisID:          "Sample.Rule",
+		Type:                deliverymodel.RuleType,
+		CreatedAt:           time.Now().UTC(),
+		Severity:            "INFO",
+		AnalysisDescription: "A sample alert",
+		AnalysisName:        aws.String("Sample Rule"),
+		Version:             aws.String("abcdefg"),
+		Runbook:             "A sample runbook",
+		Reference:           "A sample reference",
+		LogTypes:            []string{"AWS.CloudTrail"},
+		Tags:                []string{"sample"},
+		AlertID:             aws.String("Sample.Alert"),
+		Title:               "A sample alert",
+		Context:             map[string]interface{}{"key": "value"},
+	}
+}
@@ -0,0 +1,90 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	deliverymodel "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+)
+
+var templateAlert = &deliverymodel.Alert{
+	AlertID:      aws.String("alertId"),
+	AnalysisID:   "ruleId",
+	AnalysisName: aws.String("Unusual Login"),
+	Type:         deliverymodel.RuleType,
+	Severity:     "HIGH",
+	Reference:    "https://wiki/unusual-login",
+	LogTypes:     []string{"AWS.CloudTrail", "Okta.SystemLog"},
+	Tags:         []string{"IAM"},
+	Context:      map[string]interface{}{"user": "alice"},
+}
+
+func TestRenderTemplate(t *testing.T) {
+	tmpl := &outputModels.NotificationTemplate{
+		Title: "[{{ .Severity }}] {{ .Name }}",
+		Body: "{{ .Link }}\nLogs: {{ join .LogTypes \", \" }}\nUser: {{ index .AlertContext \"user\" }}\n" +
+			"Reference: {{ .Reference }}",
+	}
+	title, body, err := RenderTemplate(tmpl, templateAlert)
+	require.NoError(t, err)
+	assert.Equal(t, "[HIGH] Unusual Login", title)
+	assert.Equal(t, "https://panther.io/alerts/alertId\nLogs: AWS.CloudTrail, Okta.SystemLog\nUser: alice\n"+
+		"Reference: https://wiki/unusual-login", body)
+}
+
+func TestRenderTemplateEmpty(t *testing.T) {
+	title, body, err := RenderTemplate(nil, templateAlert)
+	require.NoError(t, err)
+	assert.Empty(t, title)
+	assert.Empty(t, body)
+
+	title, body, err = RenderTemplate(&outputModels.NotificationTemplate{Title: "{{ upper .Severity }}"}, templateAlert)
+	require.NoError(t, err)
+	assert.Equal(t, "HIGH", title)
+	assert.Empty(t, body)
+}
+
+func TestRenderTemplateError(t *testing.T) {
+	_, _, err := RenderTemplate(&outputModels.NotificationTemplate{Body: "{{ .Missing }}"}, templateAlert)
+	require.Error(t, err)
+
+	// The default layout is used if the template fails
+	title, body := renderAlertTemplate(&outputModels.NotificationTemplate{Title: "{{ .Name", Body: "body"}, templateAlert)
+	assert.Empty(t, title)
+	assert.Empty(t, body)
+}
+
+func TestValidateTemplate(t *testing.T) {
+	assert.NoError(t, ValidateTemplate("{{ .Title }} ({{ lower .Severity }})"))
+	assert.Error(t, ValidateTemplate("{{ .Title "))
+	assert.Error(t, ValidateTemplate("{{ .NotAField }}"))
+	assert.Error(t, ValidateTemplate("{{ unknownFunc .Title }}"))
+}
+
+func TestValidateJSONTemplate(t *testing.T) {
+	assert.NoError(t, ValidateJSONTemplate(`{"title": {{ json .Title }}, "tags": {{ json .Tags }}}`))
+	assert.NoError(t, ValidateJSONTemplate(""))
+	assert.Error(t, ValidateJSONTemplate(`{"title": {{ .Title }}}`))
+}
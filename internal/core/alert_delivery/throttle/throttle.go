@@ -0,0 +1,324 @@
+// Package throttle implements per-output rate limits for alert delivery.
+package throttle
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"math"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/pkg/errors"
+
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+)
+
+const (
+	// maxAttempts is the number of times a budget update is attempted when there are concurrent updates
+	maxAttempts = 5
+	// digestLease is how long a scheduled digest stays reserved if the reservation is neither committed nor released
+	digestLease = 5 * time.Minute
+)
+
+// API is the interface for output rate limits that can be used for mocks in tests.
+type API interface {
+	// Take takes up to n tokens from the budget of an output.
+	// It returns the number of tokens taken and how long until the next token is available.
+	Take(outputID string, limit *outputModels.RateLimit, n int) (int, time.Duration, error)
+	// TakeDigest reserves the next digest notification of an output if none was sent during the last interval.
+	// If the digest is not reserved it returns how long until the next digest can be sent.
+	TakeDigest(outputID string, interval time.Duration) (bool, time.Duration, error)
+	// ReserveDigest reserves the scheduled digest of an output if none was sent during the last interval.
+	// It returns nil if the digest is not due or is reserved by another invocation.
+	// The reservation must be committed once the digest is sent or released so that it is retried.
+	ReserveDigest(outputID string, interval time.Duration) (*DigestReservation, error)
+	// CommitDigest records that the scheduled digest of a reservation was sent
+	CommitDigest(r *DigestReservation) error
+	// ReleaseDigest cancels a reservation without recording a sent digest
+	ReleaseDigest(r *DigestReservation) error
+}
+
+// DigestReservation is a reservation to send the scheduled digest of an output.
+//
+// Scheduled digests are tracked separately from the digests of rate limited outputs (see TakeDigest).
+type DigestReservation struct {
+	OutputID string
+	// ReservedAt is the time of the reservation in unix milliseconds
+	ReservedAt int64
+}
+
+// Limiter stores the token bucket of each output in DynamoDB so that the budget is shared by all concurrent
+// alert delivery invocations.
+type Limiter struct {
+	Client    dynamodbiface.DynamoDBAPI
+	TableName string
+	// Now overrides the current time in tests
+	Now func() time.Time
+}
+
+// Limiter must satisfy the API interface.
+var _ API = (*Limiter)(nil)
+
+// Bucket is the token bucket of an output stored in DynamoDB.
+type Bucket struct {
+	OutputID string `json:"outputId"`
+	// Tokens is the number of tokens in the bucket at UpdatedAt
+	Tokens float64 `json:"tokens"`
+	// UpdatedAt is the time the tokens were last updated in unix milliseconds
+	UpdatedAt int64 `json:"updatedAt"`
+	// DigestAt is the time the last digest was sent in unix milliseconds
+	DigestAt int64 `json:"digestAt,omitempty"`
+	// Version is incremented on each update to detect concurrent updates
+	Version int64 `json:"version"`
+}
+
+// Take implements the API interface
+func (l *Limiter) Take(outputID string, limit *outputModels.RateLimit, n int) (int, time.Duration, error) {
+	if !limit.Enabled() || n <= 0 {
+		return n, 0, nil
+	}
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		bucket, err := l.getBucket(outputID)
+		if err != nil {
+			return 0, 0, err
+		}
+		version := bucket.Version
+		taken, wait := bucket.Take(limit, n, l.now())
+		if taken == 0 && version != 0 {
+			// Nothing changed, no need to store the bucket
+			return 0, wait, nil
+		}
+		err = l.updateBucket(bucket, version)
+		if err == nil {
+			return taken, wait, nil
+		}
+		if !isConditionalCheckFailed(err) {
+			return 0, 0, err
+		}
+	}
+	return 0, 0, errors.Errorf("too many concurrent rate limit updates for output %s", outputID)
+}
+
+// Take refills the bucket and takes up to n tokens from it.
+// It returns the number of tokens taken and how long until the next token is available.
+func (b *Bucket) Take(limit *outputModels.RateLimit, n int, now time.Time) (int, time.Duration) {
+	rate := float64(limit.AlertsPerMinute) / float64(time.Minute)
+	capacity := float64(limit.Burst)
+	if capacity < 1 {
+		capacity = 1
+	}
+	nowMillis := now.UnixNano() / int64(time.Millisecond)
+	if b.Version == 0 {
+		// A new bucket starts full
+		b.Tokens = capacity
+	} else if elapsed := nowMillis - b.UpdatedAt; elapsed > 0 {
+		b.Tokens = math.Min(capacity, b.Tokens+rate*float64(elapsed*int64(time.Millisecond)))
+	}
+	b.UpdatedAt = nowMillis
+	taken := int(math.Min(math.Floor(b.Tokens), float64(n)))
+	if taken < 0 {
+		taken = 0
+	}
+	b.Tokens -= float64(taken)
+	// Time until the next whole token
+	wait := time.Duration(math.Ceil((1 - b.Tokens) / rate))
+	if wait < 0 {
+		wait = 0
+	}
+	return taken, wait
+}
+
+// TakeDigest implements the API interface
+func (l *Limiter) TakeDigest(outputID string, interval time.Duration) (bool, time.Duration, error) {
+	now := l.now().UnixNano() / int64(time.Millisecond)
+	since := now - interval.Milliseconds()
+	cond := expression.Or(
+		expression.AttributeNotExists(expression.Name("digestAt")),
+		expression.Name("digestAt").LessThanEqual(expression.Value(since)),
+	)
+	update := expression.Set(expression.Name("digestAt"), expression.Value(now))
+	expr, err := expression.NewBuilder().WithCondition(cond).WithUpdate(update).Build()
+	if err != nil {
+		return false, 0, errors.Wrap(err, "failed to build digest update expression")
+	}
+	_, err = l.Client.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(l.TableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"outputId": {S: aws.String(outputID)},
+		},
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if err == nil {
+		return true, 0, nil
+	}
+	if !isConditionalCheckFailed(err) {
+		return false, 0, errors.Wrapf(err, "failed to update digest time of output %s", outputID)
+	}
+	bucket, err := l.getBucket(outputID)
+	if err != nil {
+		return false, 0, err
+	}
+	wait := time.Duration(bucket.DigestAt-since) * time.Millisecond
+	if wait < 0 {
+		wait = 0
+	}
+	return false, wait, nil
+}
+
+// ReserveDigest implements the API interface
+func (l *Limiter) ReserveDigest(outputID string, interval time.Duration) (*DigestReservation, error) {
+	now := l.now().UnixNano() / int64(time.Millisecond)
+	since := now - interval.Milliseconds()
+	cond := expression.And(
+		expression.Or(
+			expression.AttributeNotExists(expression.Name("scheduledDigestAt")),
+			expression.Name("scheduledDigestAt").LessThanEqual(expression.Value(since)),
+		),
+		expression.Or(
+			expression.AttributeNotExists(expression.Name("scheduledDigestLease")),
+			expression.Name("scheduledDigestLease").LessThanEqual(expression.Value(now-digestLease.Milliseconds())),
+		),
+	)
+	update := expression.Set(expression.Name("scheduledDigestLease"), expression.Value(now))
+	err := l.updateDigest(outputID, cond, update)
+	if err == nil {
+		return &DigestReservation{
+			OutputID:   outputID,
+			ReservedAt: now,
+		}, nil
+	}
+	if isConditionalCheckFailed(err) {
+		return nil, nil
+	}
+	return nil, errors.Wrapf(err, "failed to reserve digest of output %s", outputID)
+}
+
+// CommitDigest implements the API interface
+func (l *Limiter) CommitDigest(r *DigestReservation) error {
+	cond := expression.Name("scheduledDigestLease").Equal(expression.Value(r.ReservedAt))
+	update := expression.
+		Set(expression.Name("scheduledDigestAt"), expression.Value(r.ReservedAt)).
+		Remove(expression.Name("scheduledDigestLease"))
+	if err := l.updateDigest(r.OutputID, cond, update); err != nil {
+		if isConditionalCheckFailed(err) {
+			return errors.Errorf("digest reservation of output %s expired", r.OutputID)
+		}
+		return errors.Wrapf(err, "failed to commit digest of output %s", r.OutputID)
+	}
+	return nil
+}
+
+// ReleaseDigest implements the API interface
+func (l *Limiter) ReleaseDigest(r *DigestReservation) error {
+	cond := expression.Name("scheduledDigestLease").Equal(expression.Value(r.ReservedAt))
+	update := expression.Remove(expression.Name("scheduledDigestLease"))
+	if err := l.updateDigest(r.OutputID, cond, update); err != nil && !isConditionalCheckFailed(err) {
+		return errors.Wrapf(err, "failed to release digest of output %s", r.OutputID)
+	}
+	return nil
+}
+
+// updateDigest updates the scheduled digest attributes of an output.
+// The error is returned as is so that failed conditions can be checked.
+func (l *Limiter) updateDigest(outputID string, cond expression.ConditionBuilder, update expression.UpdateBuilder) error {
+	expr, err := expression.NewBuilder().WithCondition(cond).WithUpdate(update).Build()
+	if err != nil {
+		return errors.Wrap(err, "failed to build digest update expression")
+	}
+	_, err = l.Client.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(l.TableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"outputId": {S: aws.String(outputID)},
+		},
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	return err
+}
+
+func (l *Limiter) getBucket(outputID string) (*Bucket, error) {
+	output, err := l.Client.GetItem(&dynamodb.GetItemInput{
+		TableName:      aws.String(l.TableName),
+		ConsistentRead: aws.Bool(true),
+		Key: map[string]*dynamodb.AttributeValue{
+			"outputId": {S: aws.String(outputID)},
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get rate limit budget of output %s", outputID)
+	}
+	bucket := Bucket{}
+	if err := dynamodbattribute.UnmarshalMap(output.Item, &bucket); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal rate limit budget of output %s", outputID)
+	}
+	bucket.OutputID = outputID
+	return &bucket, nil
+}
+
+// updateBucket stores the tokens of a bucket if it was not modified since it was read at version
+func (l *Limiter) updateBucket(bucket *Bucket, version int64) error {
+	cond := expression.AttributeNotExists(expression.Name("version"))
+	if version != 0 {
+		cond = expression.Name("version").Equal(expression.Value(version))
+	}
+	update := expression.
+		Set(expression.Name("tokens"), expression.Value(bucket.Tokens)).
+		Set(expression.Name("updatedAt"), expression.Value(bucket.UpdatedAt)).
+		Set(expression.Name("version"), expression.Value(version+1))
+	expr, err := expression.NewBuilder().WithCondition(cond).WithUpdate(update).Build()
+	if err != nil {
+		return errors.Wrap(err, "failed to build rate limit update expression")
+	}
+	_, err = l.Client.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(l.TableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"outputId": {S: aws.String(bucket.OutputID)},
+		},
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if err != nil && !isConditionalCheckFailed(err) {
+		return errors.Wrapf(err, "failed to update rate limit budget of output %s", bucket.OutputID)
+	}
+	return err
+}
+
+func (l *Limiter) now() time.Time {
+	if l.Now != nil {
+		return l.Now()
+	}
+	return time.Now()
+}
+
+func isConditionalCheckFailed(err error) bool {
+	var awsErr awserr.Error
+	return errors.As(err, &awsErr) && awsErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException
+}
@@ -0,0 +1,209 @@
+package throttle
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+var (
+	testNow   = time.Date(2020, 12, 1, 10, 0, 0, 0, time.UTC)
+	testLimit = &outputModels.RateLimit{
+		AlertsPerMinute: 6,
+		Burst:           3,
+	}
+)
+
+func TestBucketTakeNew(t *testing.T) {
+	bucket := Bucket{}
+	taken, wait := bucket.Take(testLimit, 5, testNow)
+	assert.Equal(t, 3, taken)
+	assert.Equal(t, 10*time.Second, wait)
+	assert.Equal(t, float64(0), bucket.Tokens)
+	assert.Equal(t, testNow.UnixNano()/int64(time.Millisecond), bucket.UpdatedAt)
+}
+
+func TestBucketTakeRefill(t *testing.T) {
+	bucket := Bucket{
+		Version:   1,
+		Tokens:    0,
+		UpdatedAt: testNow.Add(-25*time.Second).UnixNano() / int64(time.Millisecond),
+	}
+	// 2.5 tokens were refilled
+	taken, wait := bucket.Take(testLimit, 5, testNow)
+	assert.Equal(t, 2, taken)
+	assert.Equal(t, 5*time.Second, wait)
+	assert.InDelta(t, 0.5, bucket.Tokens, 0.001)
+}
+
+func TestBucketTakeCapacity(t *testing.T) {
+	bucket := Bucket{
+		Version:   1,
+		Tokens:    1,
+		UpdatedAt: testNow.Add(-time.Hour).UnixNano() / int64(time.Millisecond),
+	}
+	// The bucket never holds more than the burst
+	taken, wait := bucket.Take(testLimit, 1, testNow)
+	assert.Equal(t, 1, taken)
+	assert.Equal(t, time.Duration(0), wait)
+	assert.Equal(t, float64(2), bucket.Tokens)
+}
+
+func TestBucketTakeNoBurst(t *testing.T) {
+	bucket := Bucket{}
+	limit := &outputModels.RateLimit{AlertsPerMinute: 1}
+	taken, wait := bucket.Take(limit, 2, testNow)
+	assert.Equal(t, 1, taken)
+	assert.Equal(t, time.Minute, wait)
+}
+
+func TestLimiterTakeDisabled(t *testing.T) {
+	mockClient := &testutils.DynamoDBMock{}
+	limiter := &Limiter{Client: mockClient, TableName: "budgets"}
+	taken, wait, err := limiter.Take("output-id", nil, 5)
+	require.NoError(t, err)
+	assert.Equal(t, 5, taken)
+	assert.Equal(t, time.Duration(0), wait)
+	mockClient.AssertExpectations(t)
+}
+
+func TestLimiterTakeConcurrentUpdate(t *testing.T) {
+	mockClient := &testutils.DynamoDBMock{}
+	limiter := &Limiter{
+		Client:    mockClient,
+		TableName: "budgets",
+		Now:       func() time.Time { return testNow },
+	}
+	conditionFailed := awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "version changed", nil)
+
+	mockClient.On("GetItem", mock.Anything).Return(&dynamodb.GetItemOutput{}, nil).Once()
+	mockClient.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, conditionFailed).Once()
+	mockClient.On("GetItem", mock.Anything).Return(&dynamodb.GetItemOutput{
+		Item: map[string]*dynamodb.AttributeValue{
+			"outputId":  {S: aws.String("output-id")},
+			"tokens":    {N: aws.String("1")},
+			"updatedAt": {N: aws.String("1606816800000")},
+			"version":   {N: aws.String("4")},
+		},
+	}, nil).Once()
+	mockClient.On("UpdateItem", mock.MatchedBy(func(input *dynamodb.UpdateItemInput) bool {
+		// The update is conditioned on the version that was read
+		versions := []string{}
+		for _, value := range input.ExpressionAttributeValues {
+			if value.N != nil && (*value.N == "4" || *value.N == "5") {
+				versions = append(versions, *value.N)
+			}
+		}
+		return len(versions) == 2
+	})).Return(&dynamodb.UpdateItemOutput{}, nil).Once()
+
+	taken, wait, err := limiter.Take("output-id", testLimit, 5)
+	require.NoError(t, err)
+	assert.Equal(t, 1, taken)
+	assert.Equal(t, 10*time.Second, wait)
+	mockClient.AssertExpectations(t)
+}
+
+func TestLimiterTakeDigest(t *testing.T) {
+	mockClient := &testutils.DynamoDBMock{}
+	limiter := &Limiter{
+		Client:    mockClient,
+		TableName: "budgets",
+		Now:       func() time.Time { return testNow },
+	}
+	conditionFailed := awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "digest sent", nil)
+
+	mockClient.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, nil).Once()
+	sent, wait, err := limiter.TakeDigest("output-id", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, sent)
+	assert.Equal(t, time.Duration(0), wait)
+
+	mockClient.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, conditionFailed).Once()
+	mockClient.On("GetItem", mock.Anything).Return(&dynamodb.GetItemOutput{
+		Item: map[string]*dynamodb.AttributeValue{
+			"outputId": {S: aws.String("output-id")},
+			"digestAt": {N: aws.String("1606816780000")},
+		},
+	}, nil).Once()
+	sent, wait, err = limiter.TakeDigest("output-id", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, sent)
+	assert.Equal(t, 40*time.Second, wait)
+	mockClient.AssertExpectations(t)
+}
+
+func TestLimiterReserveDigest(t *testing.T) {
+	mockClient := &testutils.DynamoDBMock{}
+	limiter := &Limiter{
+		Client:    mockClient,
+		TableName: "budgets",
+		Now:       func() time.Time { return testNow },
+	}
+	conditionFailed := awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "digest reserved", nil)
+	nowMillis := testNow.UnixNano() / int64(time.Millisecond)
+
+	// Scheduled digests do not share the digest time of rate limited outputs
+	mockClient.On("UpdateItem", mock.MatchedBy(func(input *dynamodb.UpdateItemInput) bool {
+		names := make(map[string]bool)
+		for _, name := range input.ExpressionAttributeNames {
+			names[aws.StringValue(name)] = true
+		}
+		return names["scheduledDigestAt"] && names["scheduledDigestLease"] && !names["digestAt"]
+	})).Return(&dynamodb.UpdateItemOutput{}, nil).Once()
+	r, err := limiter.ReserveDigest("output-id", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, &DigestReservation{OutputID: "output-id", ReservedAt: nowMillis}, r)
+
+	mockClient.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, nil).Once()
+	require.NoError(t, limiter.CommitDigest(r))
+
+	mockClient.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, conditionFailed).Once()
+	r, err = limiter.ReserveDigest("output-id", time.Minute)
+	require.NoError(t, err)
+	assert.Nil(t, r)
+	mockClient.AssertExpectations(t)
+}
+
+func TestLimiterCommitExpiredDigest(t *testing.T) {
+	mockClient := &testutils.DynamoDBMock{}
+	limiter := &Limiter{
+		Client:    mockClient,
+		TableName: "budgets",
+	}
+	conditionFailed := awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "lease expired", nil)
+	r := &DigestReservation{OutputID: "output-id", ReservedAt: 1}
+
+	mockClient.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, conditionFailed).Twice()
+	assert.Error(t, limiter.CommitDigest(r))
+	// Releasing an expired reservation is a no-op
+	assert.NoError(t, limiter.ReleaseDigest(r))
+	mockClient.AssertExpectations(t)
+}
@@ -23,17 +23,16 @@ import (
 
 	"github.com/pkg/errors"
 
-	resourceTypesProvider "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
 )
 
 // Traverse a passed set of resource and return an error if any of them are not found in the current
 // list of valid resource types
 //
-// CAVEAT: This method uses a hardcoded list of existing resource types. If this method is returning
-// unexpected errors the hardcoded list may be missing new or modified resource types.
+// The valid resource types are the AWS, GCP and Kubernetes resource types registered for the snapshot pollers.
 func validResourceTypeSet(checkResourceTypeSet []string) error {
 	for _, writeResourceTypeEntry := range checkResourceTypeSet {
-		if _, exists := resourceTypesProvider.ResourceTypes[writeResourceTypeEntry]; !exists {
+		if _, exists := resourcetypes.Lookup(writeResourceTypeEntry); !exists {
 			// Found a resource type that doesnt exist
 			return errors.Errorf("%s", writeResourceTypeEntry)
 		}
@@ -22,6 +22,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/pkg/errors"
 
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logschema"
@@ -43,6 +44,11 @@ type LogTypesAPI struct {
 	UpdateDataCatalog func(ctx context.Context, logType string, from, to []logschema.FieldSchema) error
 	LogTypesInUse     func(ctx context.Context) ([]string, error)
 	ManagedSchemas    managedschemas.ReleaseFeeder
+	LookupTables      LookupTableDatabase
+	// LookupTablesBucket is the only S3 bucket the log processor can read lookup table data from
+	LookupTablesBucket string
+	// SampleDataClient returns an S3 client to read sample data for schema inference, assuming roleARN if it is set
+	SampleDataClient func(ctx context.Context, roleARN, bucket string) (s3iface.S3API, error)
 }
 
 // SchemaDatabase handles the external actions required for LogTypesAPI to be implemented
@@ -53,20 +59,26 @@ type SchemaDatabase interface {
 	PutSchema(ctx context.Context, id string, record *SchemaRecord) (*SchemaRecord, error)
 	// ScanSchemas iterates through all schema records as long as scan returns true
 	ScanSchemas(ctx context.Context, scan ScanSchemaFunc) error
+	// GetSchemaRevision gets a single revision of a schema record
+	GetSchemaRevision(ctx context.Context, id string, revision int64) (*SchemaRecord, error)
+	// ScanSchemaRevisions iterates through all revisions of a schema record in order as long as scan returns true
+	ScanSchemaRevisions(ctx context.Context, id string, scan ScanSchemaFunc) error
 }
 
 type ScanSchemaFunc func(r *SchemaRecord) bool
 
 const (
 	// ErrRevisionConflict is the error code to use when there is a revision conflict
-	ErrRevisionConflict = "RevisionConflict"
-	ErrAlreadyExists    = "AlreadyExists"
-	ErrNotFound         = "NotFound"
-	ErrInUse            = "InUse"
-	ErrInvalidUpdate    = "InvalidUpdate"
-	ErrInvalidSyntax    = "InvalidSyntax"
-	ErrInvalidLogSchema = "InvalidLogSchema"
-	ErrServerError      = "ServerError"
+	ErrRevisionConflict   = "RevisionConflict"
+	ErrAlreadyExists      = "AlreadyExists"
+	ErrNotFound           = "NotFound"
+	ErrInUse              = "InUse"
+	ErrInvalidUpdate      = "InvalidUpdate"
+	ErrInvalidSyntax      = "InvalidSyntax"
+	ErrInvalidLogSchema   = "InvalidLogSchema"
+	ErrInvalidLookupTable = "InvalidLookupTable"
+	ErrInvalidSampleData  = "InvalidSampleData"
+	ErrServerError        = "ServerError"
 )
 
 // APIError is an error that has a code and a message and is returned as part of the API response
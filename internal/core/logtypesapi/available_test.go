@@ -54,6 +54,16 @@ func (l ListAvailableAPI) PutSchema(_ context.Context, _ string, _ *logtypesapi.
 	panic("implement me")
 }
 
+// nolint:lll
+func (l ListAvailableAPI) GetSchemaRevision(_ context.Context, _ string, _ int64) (*logtypesapi.SchemaRecord, error) {
+	panic("implement me")
+}
+
+// nolint:lll
+func (l ListAvailableAPI) ScanSchemaRevisions(_ context.Context, _ string, _ logtypesapi.ScanSchemaFunc) error {
+	panic("implement me")
+}
+
 // nolint:lll
 func (l ListAvailableAPI) ScanSchemas(_ context.Context, scan logtypesapi.ScanSchemaFunc) error {
 	for _, name := range l {
@@ -29,6 +29,7 @@ import (
 
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/customlogs"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logschema"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/transform"
 	"github.com/panther-labs/panther/pkg/stringset"
 )
 
@@ -155,8 +156,11 @@ func checkSchema(name string, schema *logschema.Schema) error {
 	if err := logschema.ValidateSchema(schema); err != nil {
 		return NewAPIError(ErrInvalidLogSchema, err.Error())
 	}
-	// Schemas requiring native parsers don't need further checks
+	// Schemas requiring native parsers only need their transforms checked
 	if p := schema.Parser; p != nil && p.Native != nil {
+		if _, err := transform.Build(schema); err != nil {
+			return NewAPIError(ErrInvalidLogSchema, err.Error())
+		}
 		return nil
 	}
 	// Build non-native parser entries
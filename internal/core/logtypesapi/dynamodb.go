@@ -42,12 +42,18 @@ const (
 	// We will use this kind of record to store custom log types
 	// For backwards compatibility the value is 'custom'
 	recordKindSchema = "custom"
+	// We will use this kind of record to store lookup tables
+	recordKindLookupTable = "lookup"
 
 	attrRecordKind = "RecordKind"
+	attrRecordID   = "RecordID"
 	attrRevision   = "revision"
 )
 
-var _ SchemaDatabase = (*DynamoDBSchemas)(nil)
+var (
+	_ SchemaDatabase      = (*DynamoDBSchemas)(nil)
+	_ LookupTableDatabase = (*DynamoDBSchemas)(nil)
+)
 
 // DynamoDBSchemas provides logtypes api actions for DDB
 type DynamoDBSchemas struct {
@@ -116,36 +122,42 @@ func (d *DynamoDBSchemas) GetSchema(ctx context.Context, id string) (*SchemaReco
 	return &record.SchemaRecord, nil
 }
 
-// nolint:lll
+// PutSchema updates a schema record and stores the new revision as a separate immutable record in the same transaction.
 func (d *DynamoDBSchemas) PutSchema(ctx context.Context, id string, record *SchemaRecord) (*SchemaRecord, error) {
-	upd, err := buildPutSchemaExpression(record)
-	if err != nil {
-		return nil, errors.WithMessage(err, "failed to build update schema expression")
+	result := *record
+	result.Revision = record.Revision + 1
+	tx := transact.Transaction{
+		d.buildPutSchemaUpdate(id, record),
+		&transact.Put{
+			TableName: d.TableName,
+			Item: &ddbSchemaRecord{
+				recordKey:    schemaRevisionRecordKey(id, result.Revision),
+				SchemaRecord: result,
+			},
+			// Revisions are immutable
+			Condition: expression.Name(attrRecordKind).AttributeNotExists(),
+			Cancel: func(r *dynamodb.CancellationReason) error {
+				if transact.IsConditionalCheckFailed(r) {
+					return NewAPIError(ErrRevisionConflict, fmt.Sprintf("schema record %q revision %d already exists", id, result.Revision))
+				}
+				return nil
+			},
+		},
 	}
-	reply, err := d.DB.UpdateItemWithContext(ctx, &dynamodb.UpdateItemInput{
-		TableName:                 aws.String(d.TableName),
-		Key:                       mustMarshalMap(schemaRecordKey(id)),
-		ConditionExpression:       upd.Condition(),
-		UpdateExpression:          upd.Update(),
-		ExpressionAttributeNames:  upd.Names(),
-		ExpressionAttributeValues: upd.Values(),
-		ReturnValues:              aws.String(dynamodb.ReturnValueAllNew),
-	})
+	input, err := tx.Build()
 	if err != nil {
-		if errors.As(err, &dynamodb.ConditionalCheckFailedException{}) {
-			return nil, NewAPIError(ErrRevisionConflict, fmt.Sprintf("schema record %q is not at revision %d", id, record.Revision))
-		}
-		return nil, err
+		return nil, errors.WithMessage(err, "failed to build put schema transaction")
 	}
-	result := SchemaRecord{}
-	if err := dynamodbattribute.UnmarshalMap(reply.Attributes, &result); err != nil {
-		return nil, err
+	if _, err := d.DB.TransactWriteItemsWithContext(ctx, input); err != nil {
+		return nil, tx.ExplainTransactionError(err)
 	}
 	return &result, nil
 }
 
-func buildPutSchemaExpression(record *SchemaRecord) (expression.Expression, error) {
-	return transact.BuildExpression(&transact.Update{
+func (d *DynamoDBSchemas) buildPutSchemaUpdate(id string, record *SchemaRecord) *transact.Update {
+	return &transact.Update{
+		TableName: d.TableName,
+		Key:       schemaRecordKey(id),
 		Set: map[string]interface{}{
 			// Set if the record is being put for the first time
 			transact.SetIfNotExists: struct {
@@ -184,12 +196,71 @@ func buildPutSchemaExpression(record *SchemaRecord) (expression.Expression, erro
 			// Check that the record has not incremented its revision
 			expression.Name(attrRevision).Equal(expression.Value(record.Revision)),
 		),
+		Cancel: func(r *dynamodb.CancellationReason) error {
+			if transact.IsConditionalCheckFailed(r) {
+				return NewAPIError(ErrRevisionConflict, fmt.Sprintf("schema record %q is not at revision %d", id, record.Revision))
+			}
+			return nil
+		},
+	}
+}
+
+// GetSchemaRevision gets a revision record of a schema.
+// Revisions are only recorded for updates that happened after revision history was introduced.
+func (d *DynamoDBSchemas) GetSchemaRevision(ctx context.Context, id string, revision int64) (*SchemaRecord, error) {
+	output, err := d.DB.GetItemWithContext(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(d.TableName),
+		Key:       mustMarshalMap(schemaRevisionRecordKey(id, revision)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	record := ddbSchemaRecord{}
+	if err := dynamodbattribute.UnmarshalMap(output.Item, &record); err != nil {
+		return nil, err
+	}
+	if record.Name == "" {
+		return nil, nil
+	}
+	return &record.SchemaRecord, nil
+}
+
+// ScanSchemaRevisions iterates through the revision records of a schema in ascending order
+func (d *DynamoDBSchemas) ScanSchemaRevisions(ctx context.Context, id string, scan ScanSchemaFunc) error {
+	query, err := expression.NewBuilder().WithKeyCondition(expression.KeyAnd(
+		expression.Key(attrRecordKind).Equal(expression.Value(recordKindSchema)),
+		expression.Key(attrRecordID).BeginsWith(schemaRevisionRecordPrefix(id)),
+	)).Build()
+	if err != nil {
+		return err
+	}
+	var itemErr error
+	queryErr := d.DB.QueryPagesWithContext(ctx, &dynamodb.QueryInput{
+		TableName:                 aws.String(d.TableName),
+		KeyConditionExpression:    query.KeyCondition(),
+		ExpressionAttributeNames:  query.Names(),
+		ExpressionAttributeValues: query.Values(),
+	}, func(page *dynamodb.QueryOutput, isLast bool) bool {
+		for _, item := range page.Items {
+			record := ddbSchemaRecord{}
+			if itemErr = dynamodbattribute.UnmarshalMap(item, &record); itemErr != nil {
+				return false
+			}
+			if !scan(&record.SchemaRecord) {
+				return false
+			}
+		}
+		return true
 	})
+	if queryErr != nil {
+		return queryErr
+	}
+	return itemErr
 }
 
 type recordKey struct {
 	RecordID   string `json:"RecordID" validate:"required"`
-	RecordKind string `json:"RecordKind" validate:"required,oneof=native status custom"`
+	RecordKind string `json:"RecordKind" validate:"required,oneof=native status custom lookup"`
 }
 
 func mustMarshalMap(val interface{}) map[string]*dynamodb.AttributeValue {
@@ -210,7 +281,151 @@ func schemaRecordID(id string) string {
 	return strings.ToUpper(id)
 }
 
+func schemaRevisionRecordKey(id string, revision int64) recordKey {
+	return recordKey{
+		// Revisions are zero-padded so that they sort in order
+		RecordID:   fmt.Sprintf("%s%010d", schemaRevisionRecordPrefix(id), revision),
+		RecordKind: recordKindSchema,
+	}
+}
+
+func schemaRevisionRecordPrefix(id string) string {
+	return schemaRecordID(id) + "@"
+}
+
 type ddbSchemaRecord struct {
 	recordKey
 	SchemaRecord
 }
+
+func (d *DynamoDBSchemas) ScanLookupTables(ctx context.Context, scan ScanLookupTableFunc) error {
+	filter, err := expression.NewBuilder().WithFilter(
+		expression.Name(attrRecordKind).Equal(expression.Value(recordKindLookupTable)),
+	).Build()
+	if err != nil {
+		return err
+	}
+	var itemErr error
+	scanErr := d.DB.ScanPagesWithContext(ctx, &dynamodb.ScanInput{
+		FilterExpression:          filter.Filter(),
+		ExpressionAttributeNames:  filter.Names(),
+		ExpressionAttributeValues: filter.Values(),
+		TableName:                 aws.String(d.TableName),
+	}, func(page *dynamodb.ScanOutput, isLast bool) bool {
+		for _, item := range page.Items {
+			record := ddbLookupTableRecord{}
+			if itemErr = dynamodbattribute.UnmarshalMap(item, &record); itemErr != nil {
+				return false
+			}
+			if !scan(&record.LookupTableRecord) {
+				return false
+			}
+		}
+		return true
+	})
+	if scanErr != nil {
+		return scanErr
+	}
+	return itemErr
+}
+
+func (d *DynamoDBSchemas) GetLookupTable(ctx context.Context, name string) (*LookupTableRecord, error) {
+	output, err := d.DB.GetItemWithContext(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(d.TableName),
+		Key:       mustMarshalMap(lookupTableRecordKey(name)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	record := ddbLookupTableRecord{}
+	if err := dynamodbattribute.UnmarshalMap(output.Item, &record); err != nil {
+		return nil, err
+	}
+	if record.Name == "" {
+		return nil, nil
+	}
+	return &record.LookupTableRecord, nil
+}
+
+// nolint:lll
+func (d *DynamoDBSchemas) PutLookupTable(ctx context.Context, name string, record *LookupTableRecord) (*LookupTableRecord, error) {
+	upd, err := buildPutLookupTableExpression(record)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to build update lookup table expression")
+	}
+	reply, err := d.DB.UpdateItemWithContext(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(d.TableName),
+		Key:                       mustMarshalMap(lookupTableRecordKey(name)),
+		ConditionExpression:       upd.Condition(),
+		UpdateExpression:          upd.Update(),
+		ExpressionAttributeNames:  upd.Names(),
+		ExpressionAttributeValues: upd.Values(),
+		ReturnValues:              aws.String(dynamodb.ReturnValueAllNew),
+	})
+	if err != nil {
+		if errors.As(err, &dynamodb.ConditionalCheckFailedException{}) {
+			return nil, NewAPIError(ErrRevisionConflict, fmt.Sprintf("lookup table %q is not at revision %d", name, record.Revision))
+		}
+		return nil, err
+	}
+	result := LookupTableRecord{}
+	if err := dynamodbattribute.UnmarshalMap(reply.Attributes, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func buildPutLookupTableExpression(record *LookupTableRecord) (expression.Expression, error) {
+	return transact.BuildExpression(&transact.Update{
+		Set: map[string]interface{}{
+			// Set if the record is being put for the first time
+			transact.SetIfNotExists: struct {
+				Name string `dynamodbav:"name"`
+			}{
+				Name: record.Name,
+			},
+			// Update fields of the lookup table record.
+			// The creation time is also set since a deleted lookup table can be replaced by a new one.
+			transact.SetAll: struct {
+				CreatedAt   time.Time `dynamodbav:"createdAt"`
+				UpdatedAt   time.Time `dynamodbav:"updatedAt"`
+				Revision    int64     `dynamodbav:"revision"`
+				Description string    `dynamodbav:"description"`
+				S3Bucket    string    `dynamodbav:"s3Bucket"`
+				S3Key       string    `dynamodbav:"s3Key"`
+				Format      string    `dynamodbav:"format"`
+				KeyField    string    `dynamodbav:"keyField"`
+				Disabled    bool      `dynamodbav:"IsDeleted"`
+			}{
+				CreatedAt:   record.CreatedAt,
+				UpdatedAt:   record.UpdatedAt,
+				Revision:    record.Revision + 1,
+				Description: record.Description,
+				S3Bucket:    record.S3Bucket,
+				S3Key:       record.S3Key,
+				Format:      record.Format,
+				KeyField:    record.KeyField,
+				Disabled:    record.Disabled,
+			},
+		},
+		Condition: expression.Or(
+			// Check that the record does not exist
+			expression.Name(attrRecordKind).AttributeNotExists(),
+			// OR
+			// Check that the record has not incremented its revision
+			expression.Name(attrRevision).Equal(expression.Value(record.Revision)),
+		),
+	})
+}
+
+func lookupTableRecordKey(name string) recordKey {
+	return recordKey{
+		RecordID:   name,
+		RecordKind: recordKindLookupTable,
+	}
+}
+
+type ddbLookupTableRecord struct {
+	recordKey
+	LookupTableRecord
+}
@@ -0,0 +1,298 @@
+package logtypesapi
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/customlogs"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logschema"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/s3pipe"
+)
+
+const (
+	defaultInferMaxObjects = 10
+	defaultInferMaxLines   = 1000
+	// Keep the reply size reasonable
+	maxInferFailures = 100
+	// Sample data is small, we only need a single part for most objects
+	inferDownloadPartSize = 1024 * 1024
+	// Lines longer than this are truncated
+	maxSampleLineSize = 1024 * 1024
+	// The schema name used to validate the draft schema
+	draftSchemaName = customlogs.LogTypePrefix + ".Draft"
+)
+
+// Field names that are preferred as the event time of a draft schema (case insensitive)
+var eventTimeFieldNames = []string{
+	"timestamp",
+	"@timestamp",
+	"time",
+	"eventtime",
+	"event_time",
+	"ts",
+	"datetime",
+	"date",
+}
+
+var inferJSON = jsoniter.Config{
+	UseNumber: true,
+}.Froze()
+
+// nolint:lll
+type InferSchemaInput struct {
+	S3Bucket   string `json:"s3Bucket" validate:"required" description:"The S3 bucket of the sample data"`
+	S3Prefix   string `json:"s3Prefix" description:"The S3 key prefix of the sample data objects"`
+	RoleARN    string `json:"roleARN,omitempty" description:"The role to assume to read the sample data (ie the log processing role of a source)"`
+	MaxObjects int    `json:"maxObjects,omitempty" validate:"omitempty,min=1,max=100" description:"Maximum number of objects to read (defaults to 10)"`
+	MaxLines   int    `json:"maxLines,omitempty" validate:"omitempty,min=1,max=10000" description:"Maximum number of lines to read across all objects (defaults to 1000)"`
+}
+
+// nolint:lll
+type InferSchemaOutput struct {
+	Spec       string          `json:"logSpec,omitempty" description:"The draft schema spec in YAML format (field omitted if an error occurred)"`
+	NumObjects int             `json:"numObjects" description:"The number of sample objects read"`
+	NumLines   int             `json:"numLines" description:"The number of sample lines read"`
+	Failures   []SampleFailure `json:"failures,omitempty" description:"Sample lines that fail to parse using the draft schema"`
+	Error      *APIError       `json:"error,omitempty" description:"An error that occurred during the operation"`
+}
+
+// SampleFailure describes a sample line that failed to parse using a draft schema
+// nolint:lll
+type SampleFailure struct {
+	S3Key   string `json:"s3Key" description:"The S3 key of the sample object"`
+	Line    int    `json:"line" description:"The line number in the sample object"`
+	Message string `json:"message" description:"The reason the line failed to parse"`
+}
+
+type sampleLine struct {
+	key  string
+	num  int
+	text string
+}
+
+// InferSchema infers a draft schema from sample JSON logs in S3.
+//
+// Timestamps are detected and the most likely top-level timestamp is marked as the event time.
+// All sample lines are parsed using the draft schema and lines that fail are reported.
+func (api *LogTypesAPI) InferSchema(ctx context.Context, input *InferSchemaInput) (*InferSchemaOutput, error) {
+	if api.SampleDataClient == nil {
+		return nil, errors.New("schema inference is not available")
+	}
+	client, err := api.SampleDataClient(ctx, input.RoleARN, input.S3Bucket)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get S3 client for bucket %q", input.S3Bucket)
+	}
+	maxObjects, maxLines := input.MaxObjects, input.MaxLines
+	if maxObjects == 0 {
+		maxObjects = defaultInferMaxObjects
+	}
+	if maxLines == 0 {
+		maxLines = defaultInferMaxLines
+	}
+	keys, err := listSampleKeys(ctx, client, input.S3Bucket, input.S3Prefix, maxObjects)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list sample objects at s3://%s/%s", input.S3Bucket, input.S3Prefix)
+	}
+	if len(keys) == 0 {
+		return nil, NewAPIError(ErrNotFound, fmt.Sprintf("no sample objects found at s3://%s/%s", input.S3Bucket, input.S3Prefix))
+	}
+
+	var samples []sampleLine
+	numObjects := 0
+	for _, key := range keys {
+		if len(samples) >= maxLines {
+			break
+		}
+		samples, err = readSampleLines(ctx, client, input.S3Bucket, key, samples, maxLines)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read sample object s3://%s/%s", input.S3Bucket, key)
+		}
+		numObjects++
+	}
+
+	schema, err := inferDraftSchema(samples)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := yaml.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+	failures, err := testDraftSchema(schema, samples)
+	if err != nil {
+		return nil, err
+	}
+	return &InferSchemaOutput{
+		Spec:       string(spec),
+		NumObjects: numObjects,
+		NumLines:   len(samples),
+		Failures:   failures,
+	}, nil
+}
+
+func listSampleKeys(ctx context.Context, client s3iface.S3API, bucket, prefix string, max int) ([]string, error) {
+	var keys []string
+	input := s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(prefix),
+	}
+	err := client.ListObjectsV2PagesWithContext(ctx, &input, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, obj := range page.Contents {
+			key := aws.StringValue(obj.Key)
+			// Skip 'folders' and empty objects
+			if strings.HasSuffix(key, "/") || aws.Int64Value(obj.Size) == 0 {
+				continue
+			}
+			keys = append(keys, key)
+			if len(keys) >= max {
+				return false
+			}
+		}
+		return true
+	})
+	return keys, err
+}
+
+func readSampleLines(ctx context.Context, client s3iface.S3API, bucket, key string, lines []sampleLine, max int) ([]sampleLine, error) {
+	dl := s3pipe.Downloader{
+		S3:       client,
+		PartSize: inferDownloadPartSize,
+	}
+	// Compressed objects are transparently uncompressed
+	r := dl.Download(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	defer r.Close()
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, s3pipe.DefaultReadBufferSize), maxSampleLineSize)
+	num := 0
+	for len(lines) < max && scanner.Scan() {
+		num++
+		text := string(bytes.TrimSpace(scanner.Bytes()))
+		if text == "" {
+			continue
+		}
+		lines = append(lines, sampleLine{
+			key:  key,
+			num:  num,
+			text: text,
+		})
+	}
+	if err := scanner.Err(); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// inferDraftSchema merges the schema of all sample lines that are JSON objects
+func inferDraftSchema(samples []sampleLine) (*logschema.Schema, error) {
+	var root *logschema.ValueSchema
+	for i := range samples {
+		var data map[string]interface{}
+		if err := inferJSON.UnmarshalFromString(samples[i].text, &data); err != nil {
+			// Lines that are not JSON objects will be reported as failures when testing the draft
+			continue
+		}
+		root = logschema.Merge(root, logschema.InferJSONValueSchema(data))
+	}
+	// Remove empty objects
+	root = root.NonEmpty()
+	if root == nil {
+		return nil, NewAPIError(ErrInvalidSampleData, "no JSON objects with values found in sample data")
+	}
+	markEventTime(root.Fields)
+	return &logschema.Schema{
+		Version: 0,
+		Fields:  root.Fields,
+	}, nil
+}
+
+// markEventTime marks the most likely required top-level timestamp field as the event time
+func markEventTime(fields []logschema.FieldSchema) {
+	candidate := -1
+	for i := range fields {
+		field := &fields[i]
+		if field.Type != logschema.TypeTimestamp || !field.Required {
+			continue
+		}
+		if candidate == -1 {
+			candidate = i
+		}
+		if isEventTimeFieldName(field.Name) {
+			candidate = i
+			break
+		}
+	}
+	if candidate != -1 {
+		fields[candidate].IsEventTime = true
+	}
+}
+
+func isEventTimeFieldName(name string) bool {
+	for _, n := range eventTimeFieldNames {
+		if strings.EqualFold(name, n) {
+			return true
+		}
+	}
+	return false
+}
+
+// testDraftSchema parses all sample lines using the draft schema and reports lines that fail
+func testDraftSchema(schema *logschema.Schema, samples []sampleLine) ([]SampleFailure, error) {
+	draft := *schema
+	draft.Schema = draftSchemaName
+	entry, err := customlogs.Build(draftSchemaName, &draft)
+	if err != nil {
+		return nil, NewAPIError(ErrInvalidLogSchema, fmt.Sprintf("inferred schema is not valid: %s", err))
+	}
+	parser, err := entry.NewParser(nil)
+	if err != nil {
+		return nil, err
+	}
+	var failures []SampleFailure
+	for i := range samples {
+		sample := &samples[i]
+		if _, err := parser.ParseLog(sample.text); err != nil {
+			failures = append(failures, SampleFailure{
+				S3Key:   sample.key,
+				Line:    sample.num,
+				Message: err.Error(),
+			})
+			if len(failures) >= maxInferFailures {
+				break
+			}
+		}
+	}
+	return failures, nil
+}
@@ -0,0 +1,118 @@
+package logtypesapi_test
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+
+	"github.com/panther-labs/panther/internal/core/logtypesapi"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logschema"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+func TestAPI_InferSchema(t *testing.T) {
+	assert := require.New(t)
+	plain := `{"time":"2020-10-01T13:30:00Z","created":"2020-10-01T13:00:00Z","ip":"10.0.0.1","count":1}
+{"time":"2020-10-01T13:31:00Z","created":"2020-10-01T13:00:00Z","ip":"10.0.0.2","count":2,"tags":["a"]}
+`
+	compressed := bytes.Buffer{}
+	gz := gzip.NewWriter(&compressed)
+	_, _ = gz.Write([]byte(`{"time":"2020-10-01T13:32:00Z","created":"2020-10-01T13:00:00Z","ip":"10.0.0.3","count":3}
+not a JSON object
+["not", "an", "object"]
+`))
+	assert.NoError(gz.Close())
+
+	s3Mock := &testutils.S3Mock{}
+	s3Mock.On("MaxRetries").Return(3)
+	s3Mock.On("ListObjectsV2PagesWithContext", mock.Anything, &s3.ListObjectsV2Input{
+		Bucket: aws.String("samples"),
+		Prefix: aws.String("app/"),
+	}, mock.Anything, mock.Anything).Return(&s3.ListObjectsV2Output{
+		Contents: []*s3.Object{
+			{Key: aws.String("app/"), Size: aws.Int64(0)},
+			{Key: aws.String("app/1.json"), Size: aws.Int64(int64(len(plain)))},
+			{Key: aws.String("app/2.json.gz"), Size: aws.Int64(int64(compressed.Len()))},
+		},
+	}, nil).Once()
+	mockGetObject(s3Mock, "app/1.json", []byte(plain))
+	mockGetObject(s3Mock, "app/2.json.gz", compressed.Bytes())
+
+	api := logtypesapi.LogTypesAPI{
+		SampleDataClient: func(_ context.Context, roleARN, bucket string) (s3iface.S3API, error) {
+			assert.Equal("arn:aws:iam::123456789012:role/PantherLogProcessingRole-test", roleARN)
+			assert.Equal("samples", bucket)
+			return s3Mock, nil
+		},
+	}
+	reply, err := api.InferSchema(context.Background(), &logtypesapi.InferSchemaInput{
+		S3Bucket: "samples",
+		S3Prefix: "app/",
+		RoleARN:  "arn:aws:iam::123456789012:role/PantherLogProcessingRole-test",
+	})
+	assert.NoError(err)
+	s3Mock.AssertExpectations(t)
+	assert.Equal(2, reply.NumObjects)
+	assert.Equal(5, reply.NumLines)
+
+	schema := logschema.Schema{}
+	assert.NoError(yaml.Unmarshal([]byte(reply.Spec), &schema))
+	fields := map[string]logschema.FieldSchema{}
+	for _, f := range schema.Fields {
+		fields[f.Name] = f
+	}
+	assert.Len(fields, 5)
+	assert.Equal(logschema.TypeTimestamp, fields["time"].Type)
+	assert.True(fields["time"].IsEventTime)
+	assert.Equal(logschema.TypeTimestamp, fields["created"].Type)
+	assert.False(fields["created"].IsEventTime)
+	assert.Equal([]string{"ip"}, fields["ip"].Indicators)
+	assert.True(fields["ip"].Required)
+	assert.False(fields["tags"].Required)
+
+	assert.Len(reply.Failures, 2)
+	assert.Equal("app/2.json.gz", reply.Failures[0].S3Key)
+	assert.Equal(2, reply.Failures[0].Line)
+	assert.Equal("app/2.json.gz", reply.Failures[1].S3Key)
+	assert.Equal(3, reply.Failures[1].Line)
+}
+
+func mockGetObject(s3Mock *testutils.S3Mock, key string, body []byte) {
+	s3Mock.On("GetObjectWithContext", mock.Anything, mock.MatchedBy(func(input *s3.GetObjectInput) bool {
+		return aws.StringValue(input.Key) == key
+	}), mock.Anything).Return(&s3.GetObjectOutput{
+		ContentRange:  aws.String(fmt.Sprintf("bytes 0-%d/%d", len(body)-1, len(body))),
+		ContentLength: aws.Int64(int64(len(body))),
+		// Hide io.WriterTo so that the downloader reads the body into its own buffers
+		Body: ioutil.NopCloser(struct{ io.Reader }{bytes.NewReader(body)}),
+	}, nil).Once()
+}
@@ -24,14 +24,19 @@ import (
 	"sync"
 )
 
-// InMemDB is an in-memory implementation of the SchemaDatabase.
+// InMemDB is an in-memory implementation of the SchemaDatabase and LookupTableDatabase.
 // It is useful for tests and for caching results of another implementation.
 type InMemDB struct {
-	mu      sync.RWMutex
-	records map[string]*SchemaRecord
+	mu        sync.RWMutex
+	records   map[string]*SchemaRecord
+	revisions map[string][]SchemaRecord
+	tables    map[string]*LookupTableRecord
 }
 
-var _ SchemaDatabase = (*InMemDB)(nil)
+var (
+	_ SchemaDatabase      = (*InMemDB)(nil)
+	_ LookupTableDatabase = (*InMemDB)(nil)
+)
 
 func NewInMemory() *InMemDB {
 	return &InMemDB{
@@ -61,6 +66,7 @@ func (db *InMemDB) PutSchema(_ context.Context, name string, r *SchemaRecord) (*
 	if !ok {
 		r.Revision = 1
 		db.records[id] = r
+		db.putRevision(id, r)
 		return r, nil
 	}
 	if current.Revision != revision {
@@ -69,9 +75,40 @@ func (db *InMemDB) PutSchema(_ context.Context, name string, r *SchemaRecord) (*
 	rec := *r
 	rec.Revision++
 	db.records[id] = &rec
+	db.putRevision(id, &rec)
 	return &rec, nil
 }
 
+func (db *InMemDB) putRevision(id string, r *SchemaRecord) {
+	if db.revisions == nil {
+		db.revisions = map[string][]SchemaRecord{}
+	}
+	db.revisions[id] = append(db.revisions[id], *r)
+}
+
+func (db *InMemDB) GetSchemaRevision(_ context.Context, id string, revision int64) (*SchemaRecord, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	for _, r := range db.revisions[strings.ToUpper(id)] {
+		if r.Revision == revision {
+			return &r, nil
+		}
+	}
+	return nil, nil
+}
+
+func (db *InMemDB) ScanSchemaRevisions(_ context.Context, id string, scan ScanSchemaFunc) error {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	for _, r := range db.revisions[strings.ToUpper(id)] {
+		rec := r
+		if !scan(&rec) {
+			return nil
+		}
+	}
+	return nil
+}
+
 func (db *InMemDB) ScanSchemas(_ context.Context, scan ScanSchemaFunc) error {
 	db.mu.RLock()
 	defer db.mu.RUnlock()
@@ -82,3 +119,42 @@ func (db *InMemDB) ScanSchemas(_ context.Context, scan ScanSchemaFunc) error {
 	}
 	return nil
 }
+
+func (db *InMemDB) GetLookupTable(_ context.Context, name string) (*LookupTableRecord, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	result, ok := db.tables[name]
+	if !ok {
+		return nil, nil
+	}
+	rec := *result
+	return &rec, nil
+}
+
+func (db *InMemDB) PutLookupTable(_ context.Context, name string, r *LookupTableRecord) (*LookupTableRecord, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if db.tables == nil {
+		db.tables = map[string]*LookupTableRecord{}
+	}
+	rec := *r
+	if current, ok := db.tables[name]; ok && current.Revision != r.Revision {
+		return nil, NewAPIError(ErrRevisionConflict, "record revision mismatch")
+	}
+	rec.Revision++
+	db.tables[name] = &rec
+	result := rec
+	return &result, nil
+}
+
+func (db *InMemDB) ScanLookupTables(_ context.Context, scan ScanLookupTableFunc) error {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	for _, r := range db.tables {
+		rec := *r
+		if !scan(&rec) {
+			return nil
+		}
+	}
+	return nil
+}
@@ -45,8 +45,16 @@ type LogTypesAPIPayload struct {
 	PutCustomLog             *PutCustomLogInput             `json:"PutCustomLog,omitempty"`
 	DelCustomLog             *DelCustomLogInput             `json:"DelCustomLog,omitempty"`
 	ListCustomLogs           *struct{}                      `json:"ListCustomLogs,omitempty"`
+	InferSchema              *InferSchemaInput              `json:"InferSchema,omitempty"`
+	GetLookupTable           *GetLookupTableInput           `json:"GetLookupTable,omitempty"`
+	PutLookupTable           *PutLookupTableInput           `json:"PutLookupTable,omitempty"`
+	DelLookupTable           *DelLookupTableInput           `json:"DelLookupTable,omitempty"`
+	ListLookupTables         *struct{}                      `json:"ListLookupTables,omitempty"`
 	ListManagedSchemaUpdates *ListManagedSchemaUpdatesInput `json:"ListManagedSchemaUpdates,omitempty"`
 	UpdateManagedSchemas     *UpdateManagedSchemasInput     `json:"UpdateManagedSchemas,omitempty"`
+	ListSchemaRevisions      *ListSchemaRevisionsInput      `json:"ListSchemaRevisions,omitempty"`
+	DiffSchemaRevisions      *DiffSchemaRevisionsInput      `json:"DiffSchemaRevisions,omitempty"`
+	RollbackCustomLog        *RollbackCustomLogInput        `json:"RollbackCustomLog,omitempty"`
 	GetSchema                *GetSchemaInput                `json:"GetSchema,omitempty"`
 }
 
@@ -125,6 +133,73 @@ func (c *LogTypesAPILambdaClient) ListCustomLogs(ctx context.Context) (*ListCust
 	return &reply, nil
 }
 
+func (c *LogTypesAPILambdaClient) InferSchema(ctx context.Context, input *InferSchemaInput) (*InferSchemaOutput, error) {
+	if input == nil {
+		input = &InferSchemaInput{}
+	}
+	payload := LogTypesAPIPayload{
+		InferSchema: input,
+	}
+	reply := InferSchemaOutput{}
+	if err := c.invoke(ctx, &payload, &reply); err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
+func (c *LogTypesAPILambdaClient) GetLookupTable(ctx context.Context, input *GetLookupTableInput) (*GetLookupTableOutput, error) {
+	if input == nil {
+		input = &GetLookupTableInput{}
+	}
+	payload := LogTypesAPIPayload{
+		GetLookupTable: input,
+	}
+	reply := GetLookupTableOutput{}
+	if err := c.invoke(ctx, &payload, &reply); err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
+func (c *LogTypesAPILambdaClient) PutLookupTable(ctx context.Context, input *PutLookupTableInput) (*PutLookupTableOutput, error) {
+	if input == nil {
+		input = &PutLookupTableInput{}
+	}
+	payload := LogTypesAPIPayload{
+		PutLookupTable: input,
+	}
+	reply := PutLookupTableOutput{}
+	if err := c.invoke(ctx, &payload, &reply); err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
+func (c *LogTypesAPILambdaClient) DelLookupTable(ctx context.Context, input *DelLookupTableInput) (*DelLookupTableOutput, error) {
+	if input == nil {
+		input = &DelLookupTableInput{}
+	}
+	payload := LogTypesAPIPayload{
+		DelLookupTable: input,
+	}
+	reply := DelLookupTableOutput{}
+	if err := c.invoke(ctx, &payload, &reply); err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
+func (c *LogTypesAPILambdaClient) ListLookupTables(ctx context.Context) (*ListLookupTablesOutput, error) {
+	payload := LogTypesAPIPayload{
+		ListLookupTables: &struct{}{},
+	}
+	reply := ListLookupTablesOutput{}
+	if err := c.invoke(ctx, &payload, &reply); err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
 func (c *LogTypesAPILambdaClient) ListManagedSchemaUpdates(ctx context.Context, input *ListManagedSchemaUpdatesInput) (*ListManagedSchemaUpdatesOutput, error) {
 	if input == nil {
 		input = &ListManagedSchemaUpdatesInput{}
@@ -153,6 +228,48 @@ func (c *LogTypesAPILambdaClient) UpdateManagedSchemas(ctx context.Context, inpu
 	return &reply, nil
 }
 
+func (c *LogTypesAPILambdaClient) ListSchemaRevisions(ctx context.Context, input *ListSchemaRevisionsInput) (*ListSchemaRevisionsOutput, error) {
+	if input == nil {
+		input = &ListSchemaRevisionsInput{}
+	}
+	payload := LogTypesAPIPayload{
+		ListSchemaRevisions: input,
+	}
+	reply := ListSchemaRevisionsOutput{}
+	if err := c.invoke(ctx, &payload, &reply); err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
+func (c *LogTypesAPILambdaClient) DiffSchemaRevisions(ctx context.Context, input *DiffSchemaRevisionsInput) (*DiffSchemaRevisionsOutput, error) {
+	if input == nil {
+		input = &DiffSchemaRevisionsInput{}
+	}
+	payload := LogTypesAPIPayload{
+		DiffSchemaRevisions: input,
+	}
+	reply := DiffSchemaRevisionsOutput{}
+	if err := c.invoke(ctx, &payload, &reply); err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
+func (c *LogTypesAPILambdaClient) RollbackCustomLog(ctx context.Context, input *RollbackCustomLogInput) (*RollbackCustomLogOutput, error) {
+	if input == nil {
+		input = &RollbackCustomLogInput{}
+	}
+	payload := LogTypesAPIPayload{
+		RollbackCustomLog: input,
+	}
+	reply := RollbackCustomLogOutput{}
+	if err := c.invoke(ctx, &payload, &reply); err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
 func (c *LogTypesAPILambdaClient) GetSchema(ctx context.Context, input *GetSchemaInput) (*GetSchemaOutput, error) {
 	if input == nil {
 		input = &GetSchemaInput{}
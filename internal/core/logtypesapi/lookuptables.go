@@ -0,0 +1,221 @@
+package logtypesapi
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// LookupTableDatabase handles the storage of lookup table records
+type LookupTableDatabase interface {
+	// GetLookupTable gets a single lookup table record
+	GetLookupTable(ctx context.Context, name string) (*LookupTableRecord, error)
+	// PutLookupTable puts a single lookup table record
+	PutLookupTable(ctx context.Context, name string, record *LookupTableRecord) (*LookupTableRecord, error)
+	// ScanLookupTables iterates through all lookup table records as long as scan returns true
+	ScanLookupTables(ctx context.Context, scan ScanLookupTableFunc) error
+}
+
+type ScanLookupTableFunc func(r *LookupTableRecord) bool
+
+const (
+	// LookupTablesPrefix is the S3 key prefix under which lookup table data must be uploaded.
+	// The log processor is only allowed to read objects under this prefix of the lookup tables bucket.
+	LookupTablesPrefix = "lookup_tables/"
+
+	LookupTableFormatCSV  = "csv"
+	LookupTableFormatJSON = "json"
+)
+
+var lookupTableNameRegExp = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// LookupTableRecord describes a lookup table whose rows are joined onto log events at ingest.
+//
+// The table data is stored in S3 either as CSV with a header row or as JSON objects.
+// Each row is keyed by the value of KeyField.
+// nolint:lll
+type LookupTableRecord struct {
+	Name        string    `json:"name" dynamodbav:"name" validate:"required" description:"The lookup table name"`
+	Revision    int64     `json:"revision" validate:"required,min=1" description:"Lookup table record revision"`
+	UpdatedAt   time.Time `json:"updatedAt" description:"Last update timestamp of the record"`
+	CreatedAt   time.Time `json:"createdAt" description:"Creation timestamp of the record"`
+	Description string    `json:"description" description:"Lookup table description"`
+	S3Bucket    string    `json:"s3Bucket" validate:"required" description:"The S3 bucket of the table data"`
+	S3Key       string    `json:"s3Key" validate:"required" description:"The S3 object key of the table data"`
+	Format      string    `json:"format" validate:"required,oneof=csv json" description:"The format of the table data (csv or json)"`
+	KeyField    string    `json:"keyField" validate:"required" description:"The field of each row that is matched against event values"`
+	// For compatibility with schema records we use 'IsDeleted' as the DDB field name
+	Disabled bool `json:"disabled,omitempty" dynamodbav:"IsDeleted" description:"Lookup table is deleted"`
+}
+
+// GetLookupTableInput specifies the lookup table to retrieve
+type GetLookupTableInput struct {
+	Name string `json:"name" validate:"required" description:"The lookup table name"`
+}
+
+//nolint:lll
+type GetLookupTableOutput struct {
+	Record *LookupTableRecord `json:"record,omitempty" description:"The lookup table record (field omitted if an error occurred)"`
+	Error  *APIError          `json:"error,omitempty" description:"An error that occurred while fetching the record"`
+}
+
+// GetLookupTable gets a lookup table record
+func (api *LogTypesAPI) GetLookupTable(ctx context.Context, input *GetLookupTableInput) (*GetLookupTableOutput, error) {
+	record, err := api.LookupTables.GetLookupTable(ctx, input.Name)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil || record.Disabled {
+		return nil, NewAPIError(ErrNotFound, fmt.Sprintf("lookup table %q not found", input.Name))
+	}
+	return &GetLookupTableOutput{
+		Record: record,
+	}, nil
+}
+
+// nolint:lll
+type PutLookupTableInput struct {
+	Name string `json:"name" validate:"required" description:"The lookup table name"`
+	// Revision is required when updating a lookup table record.
+	// If it is omitted a new lookup table record will be created.
+	Revision    int64  `json:"revision,omitempty" validate:"omitempty,min=1" description:"Lookup table record revision to update (if omitted a new record will be created)"`
+	Description string `json:"description" description:"Lookup table description"`
+	S3Bucket    string `json:"s3Bucket" validate:"required" description:"The S3 bucket of the table data (must be the Panther input data bucket)"`
+	S3Key       string `json:"s3Key" validate:"required" description:"The S3 object key of the table data (must be under 'lookup_tables/')"`
+	Format      string `json:"format" validate:"required,oneof=csv json" description:"The format of the table data (csv or json)"`
+	KeyField    string `json:"keyField" validate:"required" description:"The field of each row that is matched against event values"`
+}
+
+//nolint:lll
+type PutLookupTableOutput struct {
+	Record *LookupTableRecord `json:"record,omitempty" description:"The modified record (field is omitted if an error occurred)"`
+	Error  *APIError          `json:"error,omitempty" description:"An error that occurred during the operation"`
+}
+
+// PutLookupTable creates or updates a lookup table record
+func (api *LogTypesAPI) PutLookupTable(ctx context.Context, input *PutLookupTableInput) (*PutLookupTableOutput, error) {
+	if !lookupTableNameRegExp.MatchString(input.Name) {
+		return nil, NewAPIError(ErrInvalidLookupTable, fmt.Sprintf("invalid lookup table name %q", input.Name))
+	}
+	if bucket := api.LookupTablesBucket; bucket != "" && input.S3Bucket != bucket {
+		return nil, NewAPIError(ErrInvalidLookupTable, fmt.Sprintf("lookup table data must be stored in bucket %q", bucket))
+	}
+	if !strings.HasPrefix(input.S3Key, LookupTablesPrefix) {
+		return nil, NewAPIError(ErrInvalidLookupTable, fmt.Sprintf("lookup table data must be stored under %q", LookupTablesPrefix))
+	}
+	now := time.Now()
+	record := LookupTableRecord{
+		Name:        input.Name,
+		UpdatedAt:   now,
+		CreatedAt:   now,
+		Description: input.Description,
+		S3Bucket:    input.S3Bucket,
+		S3Key:       input.S3Key,
+		Format:      input.Format,
+		KeyField:    input.KeyField,
+	}
+	current, err := api.LookupTables.GetLookupTable(ctx, input.Name)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case input.Revision != 0:
+		if current == nil || current.Disabled {
+			return nil, NewAPIError(ErrNotFound, fmt.Sprintf("lookup table %q was not found", input.Name))
+		}
+		if current.Revision != input.Revision {
+			return nil, NewAPIError(ErrRevisionConflict, fmt.Sprintf("lookup table %q is not on revision %d", input.Name, input.Revision))
+		}
+		record.CreatedAt = current.CreatedAt
+		record.Revision = current.Revision
+	case current == nil:
+		// A new lookup table is created
+	case current.Disabled:
+		// The name of a deleted lookup table can be reused by a new table
+		record.Revision = current.Revision
+	default:
+		return nil, NewAPIError(ErrAlreadyExists, fmt.Sprintf("lookup table %q already exists", input.Name))
+	}
+	result, err := api.LookupTables.PutLookupTable(ctx, input.Name, &record)
+	if err != nil {
+		return nil, err
+	}
+	return &PutLookupTableOutput{
+		Record: result,
+	}, nil
+}
+
+type DelLookupTableInput struct {
+	Name     string `json:"name" validate:"required" description:"The lookup table name"`
+	Revision int64  `json:"revision" validate:"min=1" description:"Lookup table record revision"`
+}
+
+type DelLookupTableOutput struct {
+	Error *APIError `json:"error,omitempty" description:"An error that occurred during the operation"`
+}
+
+// DelLookupTable marks a lookup table record as deleted
+func (api *LogTypesAPI) DelLookupTable(ctx context.Context, input *DelLookupTableInput) (*DelLookupTableOutput, error) {
+	record, err := api.LookupTables.GetLookupTable(ctx, input.Name)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		return nil, NewAPIError(ErrNotFound, fmt.Sprintf("lookup table %q not found", input.Name))
+	}
+	if record.Disabled {
+		return &DelLookupTableOutput{}, nil
+	}
+	if record.Revision != input.Revision {
+		return nil, NewAPIError(ErrRevisionConflict, fmt.Sprintf("lookup table %q is not on revision %d", input.Name, input.Revision))
+	}
+	record.Disabled = true
+	record.UpdatedAt = time.Now()
+	if _, err := api.LookupTables.PutLookupTable(ctx, input.Name, record); err != nil {
+		return nil, err
+	}
+	return &DelLookupTableOutput{}, nil
+}
+
+// ListLookupTables lists all active lookup table records
+func (api *LogTypesAPI) ListLookupTables(ctx context.Context) (*ListLookupTablesOutput, error) {
+	records := make([]*LookupTableRecord, 0, 8)
+	scan := func(r *LookupTableRecord) bool {
+		if !r.Disabled {
+			records = append(records, r)
+		}
+		return true
+	}
+	if err := api.LookupTables.ScanLookupTables(ctx, scan); err != nil {
+		return nil, err
+	}
+	return &ListLookupTablesOutput{
+		Records: records,
+	}, nil
+}
+
+//nolint:lll
+type ListLookupTablesOutput struct {
+	Records []*LookupTableRecord `json:"lookupTables" description:"Lookup table records stored"`
+	Error   *APIError            `json:"error,omitempty" description:"An error that occurred during the operation"`
+}
@@ -0,0 +1,157 @@
+package logtypesapi_test
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/core/logtypesapi"
+)
+
+func TestAPI_LookupTables(t *testing.T) {
+	api := logtypesapi.LogTypesAPI{
+		LookupTables:       logtypesapi.NewInMemory(),
+		LookupTablesBucket: "bucket",
+	}
+	ctx := context.Background()
+	assert := require.New(t)
+
+	_, err := api.PutLookupTable(ctx, &logtypesapi.PutLookupTableInput{
+		Name:     "Users",
+		S3Bucket: "bucket",
+		S3Key:    "lookup_tables/users.csv",
+		Format:   "csv",
+		KeyField: "user",
+	})
+	assert.Error(err)
+	assert.Equal(logtypesapi.ErrInvalidLookupTable, logtypesapi.AsAPIError(err).Code)
+
+	_, err = api.PutLookupTable(ctx, &logtypesapi.PutLookupTableInput{
+		Name:     "users",
+		S3Bucket: "other-bucket",
+		S3Key:    "lookup_tables/users.csv",
+		Format:   "csv",
+		KeyField: "user",
+	})
+	assert.Error(err)
+	assert.Equal(logtypesapi.ErrInvalidLookupTable, logtypesapi.AsAPIError(err).Code)
+
+	_, err = api.PutLookupTable(ctx, &logtypesapi.PutLookupTableInput{
+		Name:     "users",
+		S3Bucket: "bucket",
+		S3Key:    "users.csv",
+		Format:   "csv",
+		KeyField: "user",
+	})
+	assert.Error(err)
+	assert.Equal(logtypesapi.ErrInvalidLookupTable, logtypesapi.AsAPIError(err).Code)
+
+	reply, err := api.PutLookupTable(ctx, &logtypesapi.PutLookupTableInput{
+		Name:        "users",
+		Description: "User teams",
+		S3Bucket:    "bucket",
+		S3Key:       "lookup_tables/users.csv",
+		Format:      "csv",
+		KeyField:    "user",
+	})
+	assert.NoError(err)
+	assert.Equal(int64(1), reply.Record.Revision)
+	assert.Equal("User teams", reply.Record.Description)
+
+	_, err = api.PutLookupTable(ctx, &logtypesapi.PutLookupTableInput{
+		Name:     "users",
+		S3Bucket: "bucket",
+		S3Key:    "lookup_tables/users.csv",
+		Format:   "csv",
+		KeyField: "user",
+	})
+	assert.Error(err)
+	assert.Equal(logtypesapi.ErrAlreadyExists, logtypesapi.AsAPIError(err).Code)
+
+	// Updates require the current revision
+	_, err = api.PutLookupTable(ctx, &logtypesapi.PutLookupTableInput{
+		Name:     "users",
+		Revision: 2,
+		S3Bucket: "bucket",
+		S3Key:    "lookup_tables/users.json",
+		Format:   "json",
+		KeyField: "user",
+	})
+	assert.Error(err)
+	assert.Equal(logtypesapi.ErrRevisionConflict, logtypesapi.AsAPIError(err).Code)
+	reply, err = api.PutLookupTable(ctx, &logtypesapi.PutLookupTableInput{
+		Name:     "users",
+		Revision: 1,
+		S3Bucket: "bucket",
+		S3Key:    "lookup_tables/users.json",
+		Format:   "json",
+		KeyField: "user",
+	})
+	assert.NoError(err)
+	assert.Equal(int64(2), reply.Record.Revision)
+	assert.Equal("json", reply.Record.Format)
+
+	list, err := api.ListLookupTables(ctx)
+	assert.NoError(err)
+	assert.Len(list.Records, 1)
+
+	_, err = api.DelLookupTable(ctx, &logtypesapi.DelLookupTableInput{
+		Name:     "users",
+		Revision: 2,
+	})
+	assert.NoError(err)
+	list, err = api.ListLookupTables(ctx)
+	assert.NoError(err)
+	assert.Empty(list.Records)
+	_, err = api.GetLookupTable(ctx, &logtypesapi.GetLookupTableInput{
+		Name: "users",
+	})
+	assert.Error(err)
+	assert.Equal(logtypesapi.ErrNotFound, logtypesapi.AsAPIError(err).Code)
+
+	_, err = api.PutLookupTable(ctx, &logtypesapi.PutLookupTableInput{
+		Name:     "users",
+		Revision: 3,
+		S3Bucket: "bucket",
+		S3Key:    "lookup_tables/users.csv",
+		Format:   "csv",
+		KeyField: "user",
+	})
+	assert.Error(err)
+	assert.Equal(logtypesapi.ErrNotFound, logtypesapi.AsAPIError(err).Code)
+
+	// The name of a deleted lookup table can be reused
+	reply, err = api.PutLookupTable(ctx, &logtypesapi.PutLookupTableInput{
+		Name:     "users",
+		S3Bucket: "bucket",
+		S3Key:    "lookup_tables/users.csv",
+		Format:   "csv",
+		KeyField: "user",
+	})
+	assert.NoError(err)
+	assert.Equal(int64(4), reply.Record.Revision)
+	assert.False(reply.Record.Disabled)
+	assert.Equal("csv", reply.Record.Format)
+	list, err = api.ListLookupTables(ctx)
+	assert.NoError(err)
+	assert.Len(list.Records, 1)
+}
@@ -23,9 +23,14 @@ import (
 	"net/http"
 
 	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	lambdaclient "github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/google/go-github/github"
 	jsoniter "github.com/json-iterator/go"
@@ -48,6 +53,7 @@ var config = struct {
 	Debug               bool
 	LogTypesTableName   string `required:"true" split_words:"true"`
 	DataCatalogQueueURL string `required:"true" split_words:"true"`
+	LookupTablesBucket  string `required:"true" split_words:"true"`
 }{}
 
 func main() {
@@ -64,11 +70,14 @@ func main() {
 
 	session := session.Must(session.NewSession())
 	lambdaClient := lambdaclient.New(session)
+	db := &logtypesapi.DynamoDBSchemas{
+		DB:        dynamodb.New(session),
+		TableName: config.LogTypesTableName,
+	}
 	api := &logtypesapi.LogTypesAPI{
-		Database: &logtypesapi.DynamoDBSchemas{
-			DB:        dynamodb.New(session),
-			TableName: config.LogTypesTableName,
-		},
+		Database:           db,
+		LookupTables:       db,
+		LookupTablesBucket: config.LookupTablesBucket,
 		UpdateDataCatalog: func(ctx context.Context, logType string, from, to []logschema.FieldSchema) error {
 			if from == nil || to == nil {
 				return nil
@@ -94,6 +103,18 @@ func main() {
 			}
 			return logTypes, nil
 		},
+		SampleDataClient: func(ctx context.Context, roleARN, bucket string) (s3iface.S3API, error) {
+			config := aws.Config{}
+			if roleARN != "" {
+				config.Credentials = stscreds.NewCredentials(session, roleARN)
+			}
+			region, err := s3manager.GetBucketRegion(ctx, session.Copy(&config), bucket, aws.StringValue(session.Config.Region))
+			if err != nil {
+				return nil, err
+			}
+			config.Region = aws.String(region)
+			return s3.New(session, &config), nil
+		},
 		ManagedSchemas: &managedschemas.GitHubRepository{
 			Repo:   "panther-analysis",
 			Owner:  "panther-labs",
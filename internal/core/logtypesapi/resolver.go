@@ -28,6 +28,7 @@ import (
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/customlogs"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logschema"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/transform"
 )
 
 // Resolver resolves a log type entry using the API
@@ -70,7 +71,11 @@ func (r *Resolver) Resolve(ctx context.Context, name string) (logtypes.Entry, er
 		if entry == nil {
 			return nil, errors.Errorf("failed to resolve native log type %q", name)
 		}
-		return entry, nil
+		plan, err := transform.Build(&schema)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid transforms for native log type %q", name)
+		}
+		return transform.WrapEntry(entry, plan), nil
 	}
 	schema.Description = record.Description
 	schema.ReferenceURL = record.ReferenceURL
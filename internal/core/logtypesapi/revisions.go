@@ -0,0 +1,192 @@
+package logtypesapi
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/customlogs"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logschema"
+)
+
+// ListSchemaRevisionsInput specifies the schema to list revisions for
+type ListSchemaRevisionsInput struct {
+	Name string `json:"name" validate:"required" description:"The schema id"`
+}
+
+//nolint:lll
+type ListSchemaRevisionsOutput struct {
+	Records []*SchemaRecord `json:"records" description:"The revisions of the schema record in ascending order"`
+	Error   *APIError       `json:"error,omitempty" description:"An error that occurred during the operation"`
+}
+
+// ListSchemaRevisions lists all stored revisions of a schema record
+func (api *LogTypesAPI) ListSchemaRevisions(ctx context.Context, input *ListSchemaRevisionsInput) (*ListSchemaRevisionsOutput, error) {
+	current, err := api.Database.GetSchema(ctx, input.Name)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, NewAPIError(ErrNotFound, fmt.Sprintf("schema record %s not found", input.Name))
+	}
+	records := make([]*SchemaRecord, 0, current.Revision)
+	scan := func(r *SchemaRecord) bool {
+		records = append(records, r)
+		return true
+	}
+	if err := api.Database.ScanSchemaRevisions(ctx, input.Name, scan); err != nil {
+		return nil, err
+	}
+	// Records last updated before revision history was kept have no revision records
+	if n := len(records); n == 0 || records[n-1].Revision != current.Revision {
+		records = append(records, current)
+	}
+	return &ListSchemaRevisionsOutput{
+		Records: records,
+	}, nil
+}
+
+// nolint:lll
+type DiffSchemaRevisionsInput struct {
+	Name string `json:"name" validate:"required" description:"The schema id"`
+	From int64  `json:"from" validate:"required,min=1" description:"The revision to compare from"`
+	To   int64  `json:"to" validate:"required,min=1" description:"The revision to compare to"`
+}
+
+//nolint:lll
+type DiffSchemaRevisionsOutput struct {
+	Changes []SchemaChange `json:"changes" description:"The changes required to go from one revision to the other"`
+	Error   *APIError      `json:"error,omitempty" description:"An error that occurred during the operation"`
+}
+
+// SchemaChange describes a single change between two schema revisions
+// nolint:lll
+type SchemaChange struct {
+	Type     string      `json:"type" description:"The type of change"`
+	Path     []string    `json:"path" description:"The path to the changed value in the schema"`
+	From     interface{} `json:"from,omitempty" description:"The value before the change"`
+	To       interface{} `json:"to,omitempty" description:"The value after the change"`
+	Breaking string      `json:"breaking,omitempty" description:"The reason the change is not compatible with existing Glue columns (field omitted if the change is compatible)"`
+}
+
+// DiffSchemaRevisions computes the changes between two revisions of a schema record
+func (api *LogTypesAPI) DiffSchemaRevisions(ctx context.Context, input *DiffSchemaRevisionsInput) (*DiffSchemaRevisionsOutput, error) {
+	from, err := api.getSchemaRevision(ctx, input.Name, input.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := api.getSchemaRevision(ctx, input.Name, input.To)
+	if err != nil {
+		return nil, err
+	}
+	schemaFrom, err := buildSchema(from.Spec)
+	if err != nil {
+		return nil, err
+	}
+	schemaTo, err := buildSchema(to.Spec)
+	if err != nil {
+		return nil, err
+	}
+	diff, err := logschema.Diff(schemaFrom, schemaTo)
+	if err != nil {
+		return nil, NewAPIError(ErrInvalidLogSchema, err.Error())
+	}
+	changes := make([]SchemaChange, len(diff))
+	for i := range diff {
+		d := &diff[i]
+		changes[i] = SchemaChange{
+			Type: d.Type,
+			Path: d.Path,
+			From: d.From,
+			To:   d.To,
+		}
+		if err := customlogs.CheckSchemaChange(d); err != nil {
+			changes[i].Breaking = err.Error()
+		}
+	}
+	return &DiffSchemaRevisionsOutput{
+		Changes: changes,
+	}, nil
+}
+
+// getSchemaRevision gets a revision record falling back to the current record if it is on the requested revision
+func (api *LogTypesAPI) getSchemaRevision(ctx context.Context, name string, revision int64) (*SchemaRecord, error) {
+	record, err := api.Database.GetSchemaRevision(ctx, name, revision)
+	if err != nil {
+		return nil, err
+	}
+	if record != nil {
+		return record, nil
+	}
+	current, err := api.Database.GetSchema(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil && current.Revision == revision {
+		return current, nil
+	}
+	return nil, NewAPIError(ErrNotFound, fmt.Sprintf("schema record %s revision %d not found", name, revision))
+}
+
+// nolint:lll
+type RollbackCustomLogInput struct {
+	LogType    string `json:"logType" validate:"required,startswith=Custom." description:"The log type id"`
+	Revision   int64  `json:"revision" validate:"required,min=1" description:"Custom log record revision to update"`
+	ToRevision int64  `json:"toRevision" validate:"required,min=1" description:"The revision to restore"`
+}
+
+//nolint:lll
+type RollbackCustomLogOutput struct {
+	Result *SchemaRecord `json:"record,omitempty" description:"The modified record (field is omitted if an error occurred)"`
+	Error  *APIError     `json:"error,omitempty" description:"An error that occurred during the operation"`
+}
+
+// RollbackCustomLog restores the spec of a previous revision as a new revision of a custom log record.
+// The rollback is rejected if it is not backwards compatible with the current revision.
+func (api *LogTypesAPI) RollbackCustomLog(ctx context.Context, input *RollbackCustomLogInput) (*RollbackCustomLogOutput, error) {
+	id := customlogs.LogType(input.LogType)
+	current, err := api.Database.GetSchema(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil || !current.IsCustom() || current.Disabled {
+		return nil, NewAPIError(ErrNotFound, fmt.Sprintf("custom log record %q not found", input.LogType))
+	}
+	if current.Revision != input.Revision {
+		return nil, NewAPIError(ErrRevisionConflict, fmt.Sprintf("record %q is not on revision %d", id, input.Revision))
+	}
+	target, err := api.getSchemaRevision(ctx, id, input.ToRevision)
+	if err != nil {
+		return nil, err
+	}
+	reply, err := api.PutCustomLog(ctx, &PutCustomLogInput{
+		LogType:      input.LogType,
+		Revision:     input.Revision,
+		Description:  target.Description,
+		ReferenceURL: target.ReferenceURL,
+		Spec:         target.Spec,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &RollbackCustomLogOutput{
+		Result: reply.Result,
+	}, nil
+}
@@ -0,0 +1,123 @@
+package logtypesapi_test
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/core/logtypesapi"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logschema"
+)
+
+func TestAPI_SchemaRevisions(t *testing.T) {
+	api := logtypesapi.LogTypesAPI{
+		Database: logtypesapi.NewInMemory(),
+		UpdateDataCatalog: func(ctx context.Context, logType string, from, to []logschema.FieldSchema) error {
+			return nil
+		},
+	}
+	ctx := context.Background()
+	assert := require.New(t)
+	specV1 := `{"version": 0, "fields": [{"name": "foo", "type": "string"}]}`
+	specV2 := `{"version": 0, "fields": [{"name": "foo", "type": "string"}, {"name": "bar", "type": "string"}]}`
+	for i, spec := range []string{specV1, specV2} {
+		_, err := api.PutCustomLog(ctx, &logtypesapi.PutCustomLogInput{
+			LogType:  "Custom.Event",
+			Revision: int64(i),
+			Spec:     spec,
+		})
+		assert.NoError(err)
+	}
+
+	list, err := api.ListSchemaRevisions(ctx, &logtypesapi.ListSchemaRevisionsInput{
+		Name: "Custom.Event",
+	})
+	assert.NoError(err)
+	assert.Len(list.Records, 2)
+	assert.Equal(int64(1), list.Records[0].Revision)
+	assert.Equal(specV1, list.Records[0].Spec)
+	assert.Equal(int64(2), list.Records[1].Revision)
+	assert.Equal(specV2, list.Records[1].Spec)
+
+	diff, err := api.DiffSchemaRevisions(ctx, &logtypesapi.DiffSchemaRevisionsInput{
+		Name: "Custom.Event",
+		From: 1,
+		To:   2,
+	})
+	assert.NoError(err)
+	assert.Len(diff.Changes, 1)
+	assert.Equal(logschema.AddField, diff.Changes[0].Type)
+	assert.Equal([]string{"Fields"}, diff.Changes[0].Path)
+	assert.Empty(diff.Changes[0].Breaking)
+
+	diff, err = api.DiffSchemaRevisions(ctx, &logtypesapi.DiffSchemaRevisionsInput{
+		Name: "Custom.Event",
+		From: 2,
+		To:   1,
+	})
+	assert.NoError(err)
+	assert.Len(diff.Changes, 1)
+	assert.Equal(logschema.DeleteField, diff.Changes[0].Type)
+	assert.NotEmpty(diff.Changes[0].Breaking)
+
+	_, err = api.DiffSchemaRevisions(ctx, &logtypesapi.DiffSchemaRevisionsInput{
+		Name: "Custom.Event",
+		From: 1,
+		To:   3,
+	})
+	assert.Error(err)
+	assert.Equal(logtypesapi.ErrNotFound, logtypesapi.AsAPIError(err).Code)
+
+	// Rolling back to revision 1 would drop the 'bar' column
+	_, err = api.RollbackCustomLog(ctx, &logtypesapi.RollbackCustomLogInput{
+		LogType:    "Custom.Event",
+		Revision:   2,
+		ToRevision: 1,
+	})
+	assert.Error(err)
+	assert.Equal(logtypesapi.ErrInvalidUpdate, logtypesapi.AsAPIError(err).Code)
+
+	_, err = api.PutCustomLog(ctx, &logtypesapi.PutCustomLogInput{
+		LogType:     "Custom.Event",
+		Revision:    2,
+		Description: "A bad edit",
+		Spec:        specV2,
+	})
+	assert.NoError(err)
+	_, err = api.RollbackCustomLog(ctx, &logtypesapi.RollbackCustomLogInput{
+		LogType:    "Custom.Event",
+		Revision:   2,
+		ToRevision: 2,
+	})
+	assert.Error(err)
+	assert.Equal(logtypesapi.ErrRevisionConflict, logtypesapi.AsAPIError(err).Code)
+
+	rollback, err := api.RollbackCustomLog(ctx, &logtypesapi.RollbackCustomLogInput{
+		LogType:    "Custom.Event",
+		Revision:   3,
+		ToRevision: 2,
+	})
+	assert.NoError(err)
+	assert.Equal(int64(4), rollback.Result.Revision)
+	assert.Equal(specV2, rollback.Result.Spec)
+	assert.Empty(rollback.Result.Description)
+}
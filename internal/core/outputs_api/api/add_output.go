@@ -62,6 +62,8 @@ func (API) AddOutput(input *models.AddOutputInput) (*models.AddOutputOutput, err
 		OutputConfig:       input.OutputConfig,
 		DefaultForSeverity: input.DefaultForSeverity,
 		AlertTypes:         input.AlertTypes,
+		RateLimit:          input.RateLimit,
+		Digest:             input.Digest,
 	}
 
 	alertOutputItem, err := AlertOutputToItem(alertOutput)
@@ -289,3 +289,77 @@ func TestAddOutputAsana(t *testing.T) {
 	_, err = uuid.Parse(*result.OutputID)
 	assert.NoError(t, err)
 }
+
+func TestAddOutputEmail(t *testing.T) {
+	mockEncryptionKey := &mockEncryptionKey{}
+	encryptionKey = mockEncryptionKey
+	mockOutputTable := &mockOutputTable{}
+	outputsTable = mockOutputTable
+
+	mockOutputTable.On("GetOutputByName", aws.String("my-email-destination")).Return(nil, nil)
+	mockEncryptionKey.On("EncryptConfig", mock.Anything).Return(make([]byte, 1), nil)
+	mockOutputTable.On("PutOutput", mock.Anything).Return(nil)
+
+	input := &models.AddOutputInput{
+		UserID:      aws.String("userId"),
+		DisplayName: aws.String("my-email-destination"),
+		AlertTypes:  []string{deliverymodel.RuleType},
+		OutputConfig: &models.OutputConfig{
+			Email: &models.EmailConfig{
+				Host:     "smtp.example.com",
+				UserName: "panther",
+				Password: "password",
+				From:     "panther@example.com",
+				To:       []string{"security@example.com"},
+			},
+		},
+	}
+
+	result, err := (API{}).AddOutput(input)
+	require.NoError(t, err)
+
+	expected := &models.AddOutputOutput{
+		DisplayName:    aws.String("my-email-destination"),
+		OutputType:     aws.String("email"),
+		LastModifiedBy: aws.String("userId"),
+		CreatedBy:      aws.String("userId"),
+		AlertTypes:     []string{deliverymodel.RuleType},
+		OutputConfig: &models.OutputConfig{
+			Email: &models.EmailConfig{
+				Host:     "smtp.example.com",
+				UserName: "panther",
+				Password: "",
+				From:     "panther@example.com",
+				To:       []string{"security@example.com"},
+			},
+		},
+		OutputID:         result.OutputID,
+		CreationTime:     result.CreationTime,
+		LastModifiedTime: result.LastModifiedTime,
+	}
+	assert.Equal(t, expected, result)
+}
+
+func TestAddOutputElasticsearchMissingIndex(t *testing.T) {
+	mockOutputTable := &mockOutputTable{}
+	outputsTable = mockOutputTable
+
+	mockOutputTable.On("GetOutputByName", aws.String("my-elasticsearch-destination")).Return(nil, nil)
+
+	input := &models.AddOutputInput{
+		UserID:      aws.String("userId"),
+		DisplayName: aws.String("my-elasticsearch-destination"),
+		AlertTypes:  []string{deliverymodel.RuleType},
+		OutputConfig: &models.OutputConfig{
+			Elasticsearch: &models.ElasticsearchConfig{
+				URL:    "https://elasticsearch.example.com:9200",
+				APIKey: "key",
+			},
+		},
+	}
+
+	result, err := (API{}).AddOutput(input)
+	require.Error(t, err)
+	assert.Nil(t, result)
+	mockOutputTable.AssertExpectations(t)
+}
@@ -72,6 +72,8 @@ func (API) UpdateOutput(input *models.UpdateOutputInput) (*models.UpdateOutputOu
 		OutputConfig:       newConfig,
 		DefaultForSeverity: input.DefaultForSeverity,
 		AlertTypes:         input.AlertTypes,
+		RateLimit:          input.RateLimit,
+		Digest:             input.Digest,
 	}
 
 	alertOutputItem, err := AlertOutputToItem(alertOutput)
@@ -130,3 +130,32 @@ func TestUpdateSameOutputOutput(t *testing.T) {
 
 	mockOutputsTable.AssertExpectations(t)
 }
+
+func TestMergeConfigsTemplate(t *testing.T) {
+	oldConfig := &models.OutputConfig{
+		Slack: &models.SlackConfig{
+			WebhookURL: "https://hooks.slack.com/old",
+			Template:   &models.NotificationTemplate{Title: "{{.Title}}", Body: "{{.Description}}"},
+		},
+	}
+
+	// An omitted template keeps the existing one
+	merged, err := mergeConfigs(oldConfig, &models.OutputConfig{Slack: &models.SlackConfig{}})
+	require.NoError(t, err)
+	assert.Equal(t, oldConfig.Slack, merged.Slack)
+
+	// A template replaces the existing one as a whole
+	merged, err = mergeConfigs(oldConfig, &models.OutputConfig{Slack: &models.SlackConfig{
+		Template: &models.NotificationTemplate{Title: "[{{.Severity}}] {{.Title}}"},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, &models.NotificationTemplate{Title: "[{{.Severity}}] {{.Title}}"}, merged.Slack.Template)
+
+	// An empty template clears the existing one
+	merged, err = mergeConfigs(oldConfig, &models.OutputConfig{Slack: &models.SlackConfig{
+		Template: &models.NotificationTemplate{Title: "", Body: ""},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, "https://hooks.slack.com/old", merged.Slack.WebhookURL)
+	assert.Nil(t, merged.Slack.Template)
+}
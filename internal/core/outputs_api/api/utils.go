@@ -45,6 +45,8 @@ func AlertOutputToItem(input *models.AlertOutput) (*table.AlertOutputItem, error
 		OutputType:         input.OutputType,
 		DefaultForSeverity: input.DefaultForSeverity,
 		AlertTypes:         input.AlertTypes,
+		RateLimit:          input.RateLimit,
+		Digest:             input.Digest,
 	}
 
 	if input.OutputConfig != nil {
@@ -70,6 +72,8 @@ func ItemToAlertOutput(input *table.AlertOutputItem) (alertOutput *models.AlertO
 		OutputType:         input.OutputType,
 		DefaultForSeverity: input.DefaultForSeverity,
 		AlertTypes:         input.AlertTypes,
+		RateLimit:          input.RateLimit,
+		Digest:             input.Digest,
 	}
 
 	// Decrypt the output before returning to the caller
@@ -107,6 +111,22 @@ func redactOutput(outputConfig *models.OutputConfig) {
 	if outputConfig.CustomWebhook != nil {
 		outputConfig.CustomWebhook.WebhookURL = redacted
 	}
+	if outputConfig.Email != nil {
+		outputConfig.Email.Password = redacted
+	}
+	if outputConfig.Splunk != nil {
+		outputConfig.Splunk.Token = redacted
+	}
+	if outputConfig.Elasticsearch != nil {
+		outputConfig.Elasticsearch.Password = redacted
+		outputConfig.Elasticsearch.APIKey = redacted
+	}
+	if outputConfig.GoogleChat != nil {
+		outputConfig.GoogleChat.WebhookURL = redacted
+	}
+	if outputConfig.ServiceNow != nil {
+		outputConfig.ServiceNow.Password = redacted
+	}
 }
 
 // TODO: remove this function when proper migrations are in place
@@ -156,13 +176,32 @@ func getOutputType(outputConfig *models.OutputConfig) (*string, error) {
 	if outputConfig.CustomWebhook != nil {
 		return aws.String("customwebhook"), nil
 	}
+	if outputConfig.Email != nil {
+		return aws.String("email"), nil
+	}
+	if outputConfig.Splunk != nil {
+		return aws.String("splunk"), nil
+	}
+	if outputConfig.Elasticsearch != nil {
+		return aws.String("elasticsearch"), nil
+	}
+	if outputConfig.GoogleChat != nil {
+		return aws.String("googlechat"), nil
+	}
+	if outputConfig.ServiceNow != nil {
+		return aws.String("servicenow"), nil
+	}
 
 	return nil, errors.New("no valid output configuration specified for alert output")
 }
 
+// templateConfigKey is the JSON key of the notification template in output configs
+const templateConfigKey = "template"
+
 // mergeConfigs combines an old config with a new config based on the following rules:
 // 1. For every value in the new config, use it
 // 2. For every value in the old config, keep it if it is not overwritten by the new config
+// 3. An empty notification template in the new config clears the template of the old config
 func mergeConfigs(oldConfig, newConfig *models.OutputConfig) (*models.OutputConfig, error) {
 	// Convert the old config into bytes so we can merge it with the new config
 	oldBytes, err := jsoniter.Marshal(oldConfig)
@@ -201,6 +240,11 @@ func mergeConfigs(oldConfig, newConfig *models.OutputConfig) (*models.OutputConf
 			if configValue == "" {
 				continue
 			}
+			// Templates are replaced as a whole, a template with an empty title and body clears the existing one
+			if template, ok := configValue.(map[string]interface{}); ok && configKey == templateConfigKey && len(template) == 0 {
+				delete(oldMap[configType], configKey)
+				continue
+			}
 			oldMap[configType][configKey] = configValue
 		}
 	}
@@ -268,6 +312,27 @@ func validateConfigByType(config *models.OutputConfig, outputType *string) error
 		if config.CustomWebhook.WebhookURL != "" {
 			return nil
 		}
+	case "email":
+		if config.Email.Host != "" && config.Email.From != "" && len(config.Email.To) != 0 {
+			return nil
+		}
+	case "splunk":
+		if config.Splunk.URL != "" && config.Splunk.Token != "" {
+			return nil
+		}
+	case "elasticsearch":
+		// Authentication is optional, e.g. for clusters behind a VPC endpoint
+		if config.Elasticsearch.URL != "" && config.Elasticsearch.Index != "" {
+			return nil
+		}
+	case "googlechat":
+		if config.GoogleChat.WebhookURL != "" {
+			return nil
+		}
+	case "servicenow":
+		if config.ServiceNow.InstanceURL != "" && config.ServiceNow.UserName != "" && config.ServiceNow.Password != "" {
+			return nil
+		}
 	}
 
 	return errors.New("invalid output configuration specified for alert output, missing required fields")
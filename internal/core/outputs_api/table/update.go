@@ -47,6 +47,12 @@ func (table *OutputsTable) UpdateOutput(alertOutput *AlertOutputItem) (*AlertOut
 	if alertOutput.AlertTypes != nil {
 		updateExpression.Set(expression.Name("alertTypes"), expression.Value(alertOutput.AlertTypes))
 	}
+	if alertOutput.RateLimit != nil {
+		updateExpression.Set(expression.Name("rateLimit"), expression.Value(alertOutput.RateLimit))
+	}
+	if alertOutput.Digest != nil {
+		updateExpression.Set(expression.Name("digest"), expression.Value(alertOutput.Digest))
+	}
 
 	conditionExpression := expression.Name("outputId").Equal(expression.Value(alertOutput.OutputID))
 	combinedExpression, err := expression.NewBuilder().
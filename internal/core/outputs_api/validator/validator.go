@@ -19,8 +19,13 @@ package validator
  */
 
 import (
+	"reflect"
+
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"gopkg.in/go-playground/validator.v9"
+
+	"github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/outputs"
 )
 
 // Validator builds a custom struct validator.
@@ -29,6 +34,11 @@ func Validator() (*validator.Validate, error) {
 	if err := result.RegisterValidation("snsArn", validateAwsArn); err != nil {
 		return nil, err
 	}
+	if err := result.RegisterValidation("notificationTemplate", validateNotificationTemplate); err != nil {
+		return nil, err
+	}
+	result.RegisterStructValidation(validateCustomWebhookConfig, models.CustomWebhookConfig{})
+	result.RegisterStructValidation(validateOutputConfig, models.OutputConfig{})
 	return result, nil
 }
 
@@ -36,3 +46,38 @@ func validateAwsArn(fl validator.FieldLevel) bool {
 	fieldArn, err := arn.Parse(fl.Field().String())
 	return err == nil && fieldArn.Service == "sns"
 }
+
+func validateNotificationTemplate(fl validator.FieldLevel) bool {
+	return outputs.ValidateTemplate(fl.Field().String()) == nil
+}
+
+// The body of a custom webhook template is the request body and must be valid JSON
+func validateCustomWebhookConfig(sl validator.StructLevel) {
+	config := sl.Current().Interface().(models.CustomWebhookConfig)
+	if config.Template == nil {
+		return
+	}
+	if err := outputs.ValidateJSONTemplate(config.Template.Body); err != nil {
+		sl.ReportError(config.Template.Body, "Body", "Body", "jsonTemplate", "")
+	}
+}
+
+// Notification templates are only rendered by Slack, MS Teams, Jira and custom webhook outputs.
+// A template is rejected if the config also sets another output which would be used instead.
+func validateOutputConfig(sl validator.StructLevel) {
+	config := sl.Current().Interface().(models.OutputConfig)
+	template := config.Template()
+	if template == nil {
+		return
+	}
+	v := reflect.ValueOf(config)
+	numOutputs := 0
+	for i := 0; i < v.NumField(); i++ {
+		if f := v.Field(i); f.Kind() == reflect.Ptr && !f.IsNil() {
+			numOutputs++
+		}
+	}
+	if numOutputs > 1 {
+		sl.ReportError(template, "Template", "Template", "templateOutput", "")
+	}
+}
@@ -92,3 +92,121 @@ func TestAddNonSnsArn(t *testing.T) {
 	require.Error(t, err)
 	assert.Equal(t, expectedMsg("AddOutputInput.OutputConfig.Sns", "TopicArn", "snsArn"), err.Error())
 }
+
+func TestAddOutputTemplate(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	assert.NoError(t, validator.Struct(&models.AddOutputInput{
+		UserID:      aws.String("3601990c-b566-404b-b367-3c6eacd6fe60"),
+		DisplayName: aws.String("mychannel"),
+		AlertTypes:  []string{deliverymodel.RuleType},
+		OutputConfig: &models.OutputConfig{
+			Slack: &models.SlackConfig{
+				WebhookURL: "https://hooks.slack.com",
+				Template: &models.NotificationTemplate{
+					Title: "[{{.Severity}}] {{.Title}}",
+					Body:  "{{.Description}} {{.AlertContext.key}} {{join .Tags \", \"}}",
+				},
+			},
+		},
+	}))
+}
+
+func TestAddOutputInvalidTemplate(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	for _, body := range []string{"{{.Title", "{{.UnknownField}}"} {
+		err = validator.Struct(&models.AddOutputInput{
+			UserID:      aws.String("3601990c-b566-404b-b367-3c6eacd6fe60"),
+			DisplayName: aws.String("mychannel"),
+			AlertTypes:  []string{deliverymodel.RuleType},
+			OutputConfig: &models.OutputConfig{
+				MsTeams: &models.MsTeamsConfig{
+					WebhookURL: "https://outlook.office.com",
+					Template:   &models.NotificationTemplate{Body: body},
+				},
+			},
+		})
+		require.Error(t, err)
+		assert.Equal(t, expectedMsg("AddOutputInput.OutputConfig.MsTeams.Template", "Body", "notificationTemplate"), err.Error())
+	}
+}
+
+func TestAddOutputCustomWebhookTemplate(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	input := &models.AddOutputInput{
+		UserID:      aws.String("3601990c-b566-404b-b367-3c6eacd6fe60"),
+		DisplayName: aws.String("mywebhook"),
+		AlertTypes:  []string{deliverymodel.RuleType},
+		OutputConfig: &models.OutputConfig{
+			CustomWebhook: &models.CustomWebhookConfig{
+				WebhookURL: "https://example.com",
+				Template:   &models.NotificationTemplate{Body: `{"text": {{json .Title}}}`},
+			},
+		},
+	}
+	assert.NoError(t, validator.Struct(input))
+
+	input.OutputConfig.CustomWebhook.Template.Body = `{"text": {{.Title}}}`
+	err = validator.Struct(input)
+	require.Error(t, err)
+	assert.Equal(t, expectedMsg("AddOutputInput.OutputConfig.CustomWebhook", "Body", "jsonTemplate"), err.Error())
+}
+
+func TestAddOutputEmail(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	input := &models.AddOutputInput{
+		UserID:      aws.String("3601990c-b566-404b-b367-3c6eacd6fe60"),
+		DisplayName: aws.String("security-team"),
+		AlertTypes:  []string{deliverymodel.RuleType},
+		OutputConfig: &models.OutputConfig{
+			Email: &models.EmailConfig{
+				Host: "smtp.example.com",
+				Port: 587,
+				From: "panther@example.com",
+				To:   []string{"security@example.com"},
+			},
+		},
+	}
+	assert.NoError(t, validator.Struct(input))
+
+	input.OutputConfig.Email.To = []string{"security@example.com", "not an address"}
+	err = validator.Struct(input)
+	require.Error(t, err)
+	assert.Equal(t, expectedMsg("AddOutputInput.OutputConfig.Email", "To[1]", "email"), err.Error())
+}
+
+func TestAddOutputInvalidURL(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	err = validator.Struct(&models.AddOutputInput{
+		UserID:      aws.String("3601990c-b566-404b-b367-3c6eacd6fe60"),
+		DisplayName: aws.String("alerts-index"),
+		AlertTypes:  []string{deliverymodel.RuleType},
+		OutputConfig: &models.OutputConfig{
+			Elasticsearch: &models.ElasticsearchConfig{URL: "elasticsearch.example.com", Index: "alerts"},
+		},
+	})
+	require.Error(t, err)
+	assert.Equal(t, expectedMsg("AddOutputInput.OutputConfig.Elasticsearch", "URL", "url"), err.Error())
+}
+
+func TestAddOutputTemplateUnsupportedOutput(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	err = validator.Struct(&models.AddOutputInput{
+		UserID:      aws.String("3601990c-b566-404b-b367-3c6eacd6fe60"),
+		DisplayName: aws.String("mytopic"),
+		AlertTypes:  []string{deliverymodel.RuleType},
+		OutputConfig: &models.OutputConfig{
+			Sns: &models.SnsConfig{TopicArn: "arn:aws:sns:us-west-2:123456789012:MyTopic"},
+			Slack: &models.SlackConfig{
+				Template: &models.NotificationTemplate{Title: "{{.Title}}"},
+			},
+		},
+	})
+	require.Error(t, err)
+	assert.Equal(t, expectedMsg("AddOutputInput.OutputConfig", "Template", "templateOutput"), err.Error())
+}
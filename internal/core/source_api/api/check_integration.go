@@ -38,6 +38,8 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/panther-labs/panther/api/lambda/source/models"
+	gcppollers "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/gcp"
+	k8spollers "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/k8s"
 	"github.com/panther-labs/panther/pkg/genericapi"
 	"github.com/panther-labs/panther/pkg/stringset"
 )
@@ -51,6 +53,11 @@ const (
 
 var (
 	checkIntegrationInternalError = &genericapi.InternalError{Message: "Failed to validate source. Please try again later"}
+
+	// Replaced by unit tests, reads the Cloud Asset inventory of a GCP project with a service account key
+	checkGCPCredentials = gcppollers.CheckCredentials
+	// Replaced by unit tests, lists the namespaces of a Kubernetes cluster
+	checkK8sCredentials = k8spollers.CheckCredentials
 )
 
 // CheckIntegration adds a set of new integrations in a batch.
@@ -63,6 +70,12 @@ func (api *API) CheckIntegration(input *models.CheckIntegrationInput) (*models.S
 		return api.checkAwsS3Integration(input), nil
 	case models.IntegrationTypeSqs:
 		return api.checkSqsQueueHealth(input), nil
+	case models.IntegrationTypeHTTP:
+		return checkHTTPSourceHealth(input), nil
+	case models.IntegrationTypeGCPScan:
+		return checkGCPScanIntegration(input), nil
+	case models.IntegrationTypeK8sScan:
+		return api.checkK8sScanIntegration(input), nil
 	default:
 		return nil, checkIntegrationInternalError
 	}
@@ -394,6 +407,12 @@ func (api *API) evaluateIntegration(integration *models.CheckIntegrationInput) (
 			return status.SqsStatus.Message, false, nil
 		}
 		return status.SqsStatus.Message, true, nil
+	case models.IntegrationTypeHTTP:
+		return status.HTTPStatus.Message, status.HTTPStatus.Healthy, nil
+	case models.IntegrationTypeGCPScan:
+		return status.GCPCredentialsStatus.Message, status.GCPCredentialsStatus.Healthy, nil
+	case models.IntegrationTypeK8sScan:
+		return status.K8sCredentialsStatus.Message, status.K8sCredentialsStatus.Healthy, nil
 
 	default:
 		return "", false, errors.New("invalid integration type")
@@ -431,3 +450,90 @@ func (api *API) checkSqsQueueHealth(input *models.CheckIntegrationInput) *models
 	health.SqsStatus.Message = "We were able to call sqs:GetQueueAttributes on the specified SQS queue."
 	return health
 }
+
+// Check the configuration of the HTTP source.
+// There are no external resources to check, the ingestion endpoint is shared by all HTTP sources.
+func checkHTTPSourceHealth(input *models.CheckIntegrationInput) *models.SourceIntegrationHealth {
+	health := &models.SourceIntegrationHealth{
+		IntegrationType: input.IntegrationType,
+	}
+	config := input.HTTPConfig
+	switch {
+	case config == nil:
+		health.HTTPStatus.Message = "The HTTP source configuration is missing."
+	case config.AuthMethod != models.HTTPAuthSharedSecret && config.AuthMethod != models.HTTPAuthHMAC:
+		health.HTTPStatus.Message = fmt.Sprintf("Unsupported authentication method %q.", config.AuthMethod)
+	case len(config.AuthSecret) == 0:
+		health.HTTPStatus.Message = "The HTTP source requires a secret to authenticate requests."
+	default:
+		health.HTTPStatus.Healthy = true
+		health.HTTPStatus.Message = "The HTTP source is configured to accept requests."
+	}
+	return health
+}
+
+// Check that the service account key of a GCP source can read the project inventory
+func checkGCPScanIntegration(input *models.CheckIntegrationInput) *models.SourceIntegrationHealth {
+	health := &models.SourceIntegrationHealth{
+		IntegrationType: input.IntegrationType,
+	}
+	config := input.GCPConfig
+	if config == nil || config.ProjectID == "" || config.ServiceAccountKey == "" {
+		health.GCPCredentialsStatus.Message = "The GCP project and service account key are required."
+		return health
+	}
+	if err := checkGCPCredentials(context.TODO(), config.ProjectID, config.ServiceAccountKey); err != nil {
+		health.GCPCredentialsStatus = models.SourceIntegrationItemStatus{
+			Healthy:      false,
+			Message:      fmt.Sprintf("We were unable to read the Cloud Asset inventory of project %s", config.ProjectID),
+			ErrorMessage: err.Error(),
+		}
+		return health
+	}
+	health.GCPCredentialsStatus = models.SourceIntegrationItemStatus{
+		Healthy: true,
+		Message: fmt.Sprintf("We were able to read the Cloud Asset inventory of project %s", config.ProjectID),
+	}
+	return health
+}
+
+// Check that the objects of a Kubernetes cluster can be read with a kubeconfig, or with the audit role for EKS clusters
+func (api *API) checkK8sScanIntegration(input *models.CheckIntegrationInput) *models.SourceIntegrationHealth {
+	health := &models.SourceIntegrationHealth{
+		IntegrationType: input.IntegrationType,
+	}
+	config := input.K8sConfig
+	if config == nil || config.ClusterName == "" || (config.Kubeconfig == "" && config.EKSClusterARN == "") {
+		health.K8sCredentialsStatus.Message = "The cluster name and either a kubeconfig or an EKS cluster ARN are required."
+		return health
+	}
+
+	var roleCredentials *credentials.Credentials
+	if config.Kubeconfig == "" {
+		accountID, _, _, err := k8spollers.ParseEKSClusterARN(config.EKSClusterARN)
+		if err != nil {
+			health.K8sCredentialsStatus.Message = err.Error()
+			return health
+		}
+		var roleStatus models.SourceIntegrationItemStatus
+		roleCredentials, roleStatus = api.getCredentialsWithStatus(fmt.Sprintf(auditRoleFormat, accountID, api.Config.Region))
+		if !roleStatus.Healthy {
+			health.K8sCredentialsStatus = roleStatus
+			return health
+		}
+	}
+
+	if err := checkK8sCredentials(context.TODO(), config, roleCredentials); err != nil {
+		health.K8sCredentialsStatus = models.SourceIntegrationItemStatus{
+			Healthy:      false,
+			Message:      fmt.Sprintf("We were unable to list the namespaces of cluster %s", config.ClusterName),
+			ErrorMessage: err.Error(),
+		}
+		return health
+	}
+	health.K8sCredentialsStatus = models.SourceIntegrationItemStatus{
+		Healthy: true,
+		Message: fmt.Sprintf("We were able to list the namespaces of cluster %s", config.ClusterName),
+	}
+	return health
+}
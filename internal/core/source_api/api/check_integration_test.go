@@ -19,10 +19,12 @@ package api
  */
 
 import (
+	"context"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
@@ -151,3 +153,105 @@ func Test_CheckGetObject(t *testing.T) {
 		s3Client.AssertExpectations(t)
 	})
 }
+
+func Test_CheckHTTPSourceHealth(t *testing.T) {
+	input := &models.CheckIntegrationInput{
+		IntegrationType: models.IntegrationTypeHTTP,
+		HTTPConfig: &models.HTTPConfig{
+			LogTypes:   []string{"Some.LogType"},
+			AuthMethod: models.HTTPAuthHMAC,
+			AuthSecret: "0123456789abcdef",
+		},
+	}
+	health := checkHTTPSourceHealth(input)
+	require.True(t, health.HTTPStatus.Healthy)
+
+	input.HTTPConfig.AuthMethod = "basic"
+	health = checkHTTPSourceHealth(input)
+	require.False(t, health.HTTPStatus.Healthy)
+
+	input.HTTPConfig = nil
+	health = checkHTTPSourceHealth(input)
+	require.False(t, health.HTTPStatus.Healthy)
+}
+
+func Test_CheckGCPScanIntegration(t *testing.T) {
+	checkCredentials := checkGCPCredentials
+	defer func() { checkGCPCredentials = checkCredentials }()
+
+	var checkedProject string
+	checkGCPCredentials = func(_ context.Context, projectID, _ string) error {
+		checkedProject = projectID
+		if projectID == "panther-denied" {
+			return errors.New("403 PERMISSION_DENIED: The caller does not have permission")
+		}
+		return nil
+	}
+
+	input := &models.CheckIntegrationInput{
+		IntegrationType: models.IntegrationTypeGCPScan,
+		GCPConfig: &models.GCPConfig{
+			ProjectID:         "panther-test",
+			ServiceAccountKey: `{"type":"service_account"}`,
+		},
+	}
+	health := checkGCPScanIntegration(input)
+	require.True(t, health.GCPCredentialsStatus.Healthy)
+	require.Equal(t, "panther-test", checkedProject)
+
+	input.GCPConfig.ProjectID = "panther-denied"
+	health = checkGCPScanIntegration(input)
+	require.False(t, health.GCPCredentialsStatus.Healthy)
+	require.Contains(t, health.GCPCredentialsStatus.ErrorMessage, "PERMISSION_DENIED")
+
+	input.GCPConfig = nil
+	health = checkGCPScanIntegration(input)
+	require.False(t, health.GCPCredentialsStatus.Healthy)
+}
+
+func Test_CheckK8sScanIntegration(t *testing.T) {
+	checkCredentials := checkK8sCredentials
+	defer func() { checkK8sCredentials = checkCredentials }()
+
+	var checkedCluster string
+	checkK8sCredentials = func(_ context.Context, config *models.K8sConfig, _ *credentials.Credentials) error {
+		checkedCluster = config.ClusterName
+		if config.ClusterName == "denied" {
+			return errors.New(`403 Forbidden: namespaces is forbidden: User "panther" cannot list resource`)
+		}
+		return nil
+	}
+
+	apiTest := NewAPITest()
+	input := &models.CheckIntegrationInput{
+		IntegrationType: models.IntegrationTypeK8sScan,
+		K8sConfig: &models.K8sConfig{
+			ClusterName: "prod",
+			Kubeconfig:  "apiVersion: v1",
+		},
+	}
+	health := apiTest.checkK8sScanIntegration(input)
+	require.True(t, health.K8sCredentialsStatus.Healthy)
+	require.Equal(t, "prod", checkedCluster)
+
+	input.K8sConfig.ClusterName = "denied"
+	health = apiTest.checkK8sScanIntegration(input)
+	require.False(t, health.K8sCredentialsStatus.Healthy)
+	require.Contains(t, health.K8sCredentialsStatus.ErrorMessage, "Forbidden")
+
+	// The audit role is only assumed for valid EKS cluster ARNs
+	input.K8sConfig = &models.K8sConfig{ClusterName: "prod", EKSClusterARN: "arn:aws:iam::123456789012:role/prod"}
+	health = apiTest.checkK8sScanIntegration(input)
+	require.False(t, health.K8sCredentialsStatus.Healthy)
+	require.Contains(t, health.K8sCredentialsStatus.Message, "invalid EKS cluster ARN")
+
+	// Either a kubeconfig or an EKS cluster is required
+	input.K8sConfig = &models.K8sConfig{ClusterName: "prod"}
+	health = apiTest.checkK8sScanIntegration(input)
+	require.False(t, health.K8sCredentialsStatus.Healthy)
+	require.Contains(t, health.K8sCredentialsStatus.Message, "are required")
+
+	input.K8sConfig = nil
+	health = apiTest.checkK8sScanIntegration(input)
+	require.False(t, health.K8sCredentialsStatus.Healthy)
+}
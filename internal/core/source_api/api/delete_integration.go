@@ -108,6 +108,13 @@ func (api *API) DeleteIntegration(input *models.DeleteIntegrationInput) error {
 		}
 	}
 
+	if err := api.deleteCredentials(integrationItem.CredentialsSecretARN); err != nil {
+		zap.L().Error("failed to delete source credentials",
+			zap.String("integrationId", input.IntegrationID),
+			zap.Error(err))
+		return deleteIntegrationInternalError
+	}
+
 	err = api.DdbClient.DeleteItem(input.IntegrationID)
 	if err != nil {
 		zap.L().Error("failed to delete item", zap.Error(err))
@@ -26,6 +26,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/stretchr/testify/assert"
@@ -52,6 +53,27 @@ func TestDeleteIntegrationItem(t *testing.T) {
 	apiTest.AssertExpectations(t)
 }
 
+func TestDeleteIntegrationCredentials(t *testing.T) {
+	t.Parallel()
+	apiTest := NewAPITest()
+
+	item := generateDDBAttributes(models.IntegrationTypeHTTP)
+	item["credentialsSecretArn"] = &dynamodb.AttributeValue{S: aws.String(testSecretARN)}
+	apiTest.mockDdb.On("GetItem", mock.Anything).Return(&dynamodb.GetItemOutput{Item: item}, nil)
+	apiTest.mockDdb.On("DeleteItem", mock.Anything).Return(&dynamodb.DeleteItemOutput{}, nil)
+	apiTest.mockSecrets.On("DeleteSecret", &secretsmanager.DeleteSecretInput{
+		SecretId:                   aws.String(testSecretARN),
+		ForceDeleteWithoutRecovery: aws.Bool(true),
+	}).Return(&secretsmanager.DeleteSecretOutput{}, nil)
+
+	result := apiTest.DeleteIntegration(&models.DeleteIntegrationInput{
+		IntegrationID: testIntegrationID,
+	})
+
+	assert.NoError(t, result)
+	apiTest.AssertExpectations(t)
+}
+
 func TestDeleteLogIntegration(t *testing.T) {
 	t.Parallel()
 	apiTest := NewAPITest()
@@ -33,11 +33,10 @@ import (
 
 	"github.com/panther-labs/panther/api/lambda/source/models"
 	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
-	awspoller "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
 	"github.com/panther-labs/panther/internal/log_analysis/datacatalog_updater/datacatalog"
 	"github.com/panther-labs/panther/pkg/awsbatch/sqsbatch"
 	"github.com/panther-labs/panther/pkg/genericapi"
-	"github.com/panther-labs/panther/pkg/stringset"
 )
 
 var (
@@ -77,6 +76,11 @@ func (api *API) PutIntegration(input *models.PutIntegrationInput) (newIntegratio
 		api.handleManagedBucketNotifications(newIntegration)
 	}
 
+	if err = api.storeCredentials(newIntegration); err != nil {
+		zap.L().Error("failed to store source credentials", zap.Error(err))
+		return nil, putIntegrationInternalError
+	}
+
 	// Write to DynamoDB
 	item := integrationToItem(newIntegration)
 	if err = api.DdbClient.PutItem(item); err != nil {
@@ -84,7 +88,7 @@ func (api *API) PutIntegration(input *models.PutIntegrationInput) (newIntegratio
 		return nil, putIntegrationInternalError
 	}
 
-	if input.IntegrationType == models.IntegrationTypeAWSScan {
+	if isScanIntegration(input.IntegrationType) {
 		err = api.FullScan(&models.FullScanInput{Integrations: []*models.SourceIntegrationMetadata{&newIntegration.SourceIntegrationMetadata}})
 		if err != nil {
 			zap.L().Error("failed to trigger scanning of resources", zap.Error(err))
@@ -126,18 +130,21 @@ func (api *API) setupExternalResources(integration *models.SourceIntegration) er
 		if err := api.AddSourceAsLambdaTrigger(integration.IntegrationID); err != nil {
 			return errors.Wrap(err, "failed to configure queue as lambda source")
 		}
+	case models.IntegrationTypeHTTP:
+		if err := api.AllowInputDataBucketSubscription(); err != nil {
+			return errors.Wrap(err, "failed to enable subscription for input bucket")
+		}
 	}
 	return nil
 }
 
 func (api *API) validateIntegration(input *models.PutIntegrationInput) error {
-	// Prefixes in the same S3 source should be unique (although we allow overlapping for now)
+	if err := validateEventFilters(input.EventFilters); err != nil {
+		return err
+	}
 	if input.IntegrationType == models.IntegrationTypeAWS3 {
-		prefixes := input.S3PrefixLogTypes.S3Prefixes()
-		if len(prefixes) != len(stringset.Dedup(prefixes)) {
-			return &genericapi.InvalidInputError{
-				Message: "Cannot have duplicate prefixes in an s3 source.",
-			}
+		if err := validateS3PrefixLogTypes(input.S3PrefixLogTypes); err != nil {
+			return err
 		}
 	}
 
@@ -157,6 +164,9 @@ func (api *API) validateIntegration(input *models.PutIntegrationInput) error {
 		S3PrefixLogTypes:  input.S3PrefixLogTypes,
 		KmsKey:            input.KmsKey,
 		SqsConfig:         input.SqsConfig,
+		HTTPConfig:        input.HTTPConfig,
+		GCPConfig:         input.GCPConfig,
+		K8sConfig:         input.K8sConfig,
 	})
 	if err != nil {
 		return putIntegrationInternalError
@@ -216,9 +226,25 @@ func (api API) integrationAlreadyExists(input *models.PutIntegrationInput) error
 						}
 					}
 				}
-			case models.IntegrationTypeSqs:
+			case models.IntegrationTypeGCPScan:
+				if existingIntegration.GCPConfig != nil && input.GCPConfig != nil &&
+					existingIntegration.GCPConfig.ProjectID == input.GCPConfig.ProjectID {
+					// We can only have one cloudsec integration for each project
+					return &genericapi.InvalidInputError{
+						Message: fmt.Sprintf("Source project %s already onboarded", input.GCPConfig.ProjectID),
+					}
+				}
+			case models.IntegrationTypeK8sScan:
+				if existingIntegration.K8sConfig != nil && input.K8sConfig != nil &&
+					existingIntegration.K8sConfig.ClusterName == input.K8sConfig.ClusterName {
+					// The cluster name is part of the resource IDs, so it has to be unique
+					return &genericapi.InvalidInputError{
+						Message: fmt.Sprintf("Source cluster %s already onboarded", input.K8sConfig.ClusterName),
+					}
+				}
+			case models.IntegrationTypeSqs, models.IntegrationTypeHTTP:
 				if existingIntegration.IntegrationLabel == input.IntegrationLabel {
-					// Sqs sources need to have different labels
+					// Sqs and HTTP sources need to have different labels
 					return &genericapi.InvalidInputError{
 						Message: fmt.Sprintf("Integration with label %s already exists", input.IntegrationLabel),
 					}
@@ -238,19 +264,10 @@ func (api *API) FullScan(input *models.FullScanInput) error {
 
 	// For each integration, add a ScanMsg to the queue per service
 	for _, integration := range input.Integrations {
-		for resourceType := range awspoller.ServicePollers {
+		for _, entry := range scanEntries(integration) {
+			resourceType := *entry.ResourceType
 			scanMsg := &pollermodels.ScanMsg{
-				Entries: []*pollermodels.ScanEntry{
-					{
-						AWSAccountID:            &integration.AWSAccountID,
-						IntegrationID:           &integration.IntegrationID,
-						ResourceType:            aws.String(resourceType),
-						Enabled:                 integration.Enabled,
-						RegionIgnoreList:        integration.RegionIgnoreList,
-						ResourceTypeIgnoreList:  integration.ResourceTypeIgnoreList,
-						ResourceRegexIgnoreList: integration.ResourceRegexIgnoreList,
-					},
-				},
+				Entries: []*pollermodels.ScanEntry{entry},
 			}
 
 			messageBodyBytes, err := jsoniter.MarshalToString(scanMsg)
@@ -282,6 +299,60 @@ func (api *API) FullScan(input *models.FullScanInput) error {
 	return err
 }
 
+// scanEntries builds the scan requests of all the resource types scanned for an integration
+func scanEntries(integration *models.SourceIntegrationMetadata) []*pollermodels.ScanEntry {
+	if integration.IntegrationType == models.IntegrationTypeGCPScan {
+		if integration.GCPConfig == nil {
+			return nil
+		}
+		var entries []*pollermodels.ScanEntry
+		for _, resourceType := range resourcetypes.Scannable(resourcetypes.IntegrationTypeGCP) {
+			entries = append(entries, &pollermodels.ScanEntry{
+				IntegrationType:         aws.String(resourcetypes.IntegrationTypeGCP),
+				IntegrationID:           &integration.IntegrationID,
+				ProjectID:               &integration.GCPConfig.ProjectID,
+				ResourceType:            aws.String(resourceType),
+				Enabled:                 integration.Enabled,
+				ResourceTypeIgnoreList:  integration.ResourceTypeIgnoreList,
+				ResourceRegexIgnoreList: integration.ResourceRegexIgnoreList,
+			})
+		}
+		return entries
+	}
+	if integration.IntegrationType == models.IntegrationTypeK8sScan {
+		if integration.K8sConfig == nil {
+			return nil
+		}
+		var entries []*pollermodels.ScanEntry
+		for _, resourceType := range resourcetypes.Scannable(resourcetypes.IntegrationTypeK8s) {
+			entries = append(entries, &pollermodels.ScanEntry{
+				IntegrationType:         aws.String(resourcetypes.IntegrationTypeK8s),
+				IntegrationID:           &integration.IntegrationID,
+				ClusterName:             &integration.K8sConfig.ClusterName,
+				ResourceType:            aws.String(resourceType),
+				Enabled:                 integration.Enabled,
+				ResourceTypeIgnoreList:  integration.ResourceTypeIgnoreList,
+				ResourceRegexIgnoreList: integration.ResourceRegexIgnoreList,
+			})
+		}
+		return entries
+	}
+
+	var entries []*pollermodels.ScanEntry
+	for _, resourceType := range resourcetypes.Scannable(resourcetypes.IntegrationTypeAWS) {
+		entries = append(entries, &pollermodels.ScanEntry{
+			AWSAccountID:            &integration.AWSAccountID,
+			IntegrationID:           &integration.IntegrationID,
+			ResourceType:            aws.String(resourceType),
+			Enabled:                 integration.Enabled,
+			RegionIgnoreList:        integration.RegionIgnoreList,
+			ResourceTypeIgnoreList:  integration.ResourceTypeIgnoreList,
+			ResourceRegexIgnoreList: integration.ResourceRegexIgnoreList,
+		})
+	}
+	return entries
+}
+
 func (api *API) generateNewIntegration(input *models.PutIntegrationInput) *models.SourceIntegration {
 	metadata := models.SourceIntegrationMetadata{
 		CreatedAtTime:    time.Now(),
@@ -291,6 +362,9 @@ func (api *API) generateNewIntegration(input *models.PutIntegrationInput) *model
 		IntegrationType:  input.IntegrationType,
 		PantherVersion:   api.Config.Version,
 	}
+	if !isScanIntegration(input.IntegrationType) {
+		metadata.EventFilters = input.EventFilters
+	}
 
 	switch input.IntegrationType {
 	case models.IntegrationTypeAWSScan:
@@ -323,6 +397,34 @@ func (api *API) generateNewIntegration(input *models.PutIntegrationInput) *model
 			LogTypes:             input.SqsConfig.LogTypes,
 			QueueURL:             api.SourceSqsQueueURL(metadata.IntegrationID),
 		}
+	case models.IntegrationTypeHTTP:
+		metadata.HTTPConfig = &models.HTTPConfig{
+			S3Bucket:          api.Config.InputDataBucketName,
+			LogProcessingRole: api.Config.InputDataRoleArn,
+			LogTypes:          input.HTTPConfig.LogTypes,
+			AuthMethod:        input.HTTPConfig.AuthMethod,
+			AuthHeader:        input.HTTPConfig.AuthHeader,
+			AuthSecret:        input.HTTPConfig.AuthSecret,
+		}
+	case models.IntegrationTypeGCPScan:
+		metadata.GCPConfig = &models.GCPConfig{
+			ProjectID:         input.GCPConfig.ProjectID,
+			ServiceAccountKey: input.GCPConfig.ServiceAccountKey,
+		}
+		metadata.ScanIntervalMins = input.ScanIntervalMins
+		metadata.Enabled = input.Enabled
+		metadata.ResourceTypeIgnoreList = input.ResourceTypeIgnoreList
+		metadata.ResourceRegexIgnoreList = input.ResourceRegexIgnoreList
+	case models.IntegrationTypeK8sScan:
+		metadata.K8sConfig = &models.K8sConfig{
+			ClusterName:   input.K8sConfig.ClusterName,
+			Kubeconfig:    input.K8sConfig.Kubeconfig,
+			EKSClusterARN: input.K8sConfig.EKSClusterARN,
+		}
+		metadata.ScanIntervalMins = input.ScanIntervalMins
+		metadata.Enabled = input.Enabled
+		metadata.ResourceTypeIgnoreList = input.ResourceTypeIgnoreList
+		metadata.ResourceRegexIgnoreList = input.ResourceRegexIgnoreList
 	}
 	return &models.SourceIntegration{
 		SourceIntegrationMetadata: metadata,
@@ -335,6 +437,9 @@ func (api *API) createTables(integration *models.SourceIntegration) error {
 		QueueURL: api.Config.DataCatalogUpdaterQueueURL,
 	}
 	logTypes := integration.RequiredLogTypes()
+	if len(logTypes) == 0 {
+		return nil
+	}
 	err := client.SendCreateTablesForLogTypes(context.TODO(), logTypes...)
 	if err != nil {
 		return errors.Wrap(err, "failed to create Glue tables")
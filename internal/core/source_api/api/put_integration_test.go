@@ -24,8 +24,10 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/stretchr/testify/assert"
@@ -33,8 +35,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/panther-labs/panther/api/lambda/source/models"
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
 	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
-	awspoller "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
 	"github.com/panther-labs/panther/internal/core/source_api/ddb"
 	"github.com/panther-labs/panther/internal/core/source_api/ddb/modelstest"
 )
@@ -59,7 +62,7 @@ func generateMockSQSBatchInputOutput(integration models.SourceIntegrationMetadat
 	}
 
 	// Generate all messages for scans
-	for resourceType := range awspoller.ServicePollers {
+	for _, resourceType := range resourcetypes.Scannable(resourcetypes.IntegrationTypeAWS) {
 		scanMsg := &pollermodels.ScanMsg{
 			Entries: []*pollermodels.ScanEntry{
 				{
@@ -112,7 +115,7 @@ func TestAddToSnapshotQueue(t *testing.T) {
 
 	require.NoError(t, err)
 	// Check that there is one message per service
-	assert.Len(t, sqsIn.Entries, len(awspoller.ServicePollers))
+	assert.Len(t, sqsIn.Entries, len(resourcetypes.Scannable(resourcetypes.IntegrationTypeAWS)))
 	apiTest.AssertExpectations(t)
 }
 
@@ -176,6 +179,53 @@ func TestPutLogIntegrationExists(t *testing.T) {
 	apiTest.AssertExpectations(t)
 }
 
+func TestPutLogIntegrationInvalidJSONArrayPath(t *testing.T) {
+	t.Parallel()
+	apiTest := NewAPITest()
+
+	out, err := apiTest.PutIntegration(&models.PutIntegrationInput{
+		PutIntegrationSettings: models.PutIntegrationSettings{
+			AWSAccountID:     testAccountID,
+			IntegrationLabel: testIntegrationLabel,
+			IntegrationType:  models.IntegrationTypeAWS3,
+			UserID:           testUserID,
+			S3Bucket:         "bucket",
+			S3PrefixLogTypes: models.S3PrefixLogtypes{
+				{S3Prefix: "okta/", LogTypes: []string{"Okta.SystemLog"}, JSONArrayPath: "data..events"},
+			},
+		},
+	})
+	require.Error(t, err)
+	require.Empty(t, out)
+	assert.Contains(t, err.Error(), `Invalid JSON array path for prefix "okta/"`)
+	apiTest.AssertExpectations(t)
+}
+
+func TestPutLogIntegrationInvalidEventFilter(t *testing.T) {
+	t.Parallel()
+	apiTest := NewAPITest()
+
+	out, err := apiTest.PutIntegration(&models.PutIntegrationInput{
+		PutIntegrationSettings: models.PutIntegrationSettings{
+			AWSAccountID:     testAccountID,
+			IntegrationLabel: testIntegrationLabel,
+			IntegrationType:  models.IntegrationTypeAWS3,
+			UserID:           testUserID,
+			S3Bucket:         "bucket",
+			S3PrefixLogTypes: models.S3PrefixLogtypes{
+				{S3Prefix: "", LogTypes: []string{"AWS.VPCFlow"}},
+			},
+			EventFilters: []models.EventFilter{
+				{LogType: "AWS.VPCFlow", Field: "srcAddr", CIDR: []string{"10.0.0.0"}, SampleRatio: aws.Float64(0)},
+			},
+		},
+	})
+	require.Error(t, err)
+	require.Empty(t, out)
+	assert.Contains(t, err.Error(), "Invalid event filter #1")
+	apiTest.AssertExpectations(t)
+}
+
 func TestPutCloudSecIntegrationExists(t *testing.T) {
 	t.Parallel()
 	apiTest := NewAPITest()
@@ -209,6 +259,220 @@ func TestPutCloudSecIntegrationExists(t *testing.T) {
 	assert.Equal(t, "Source account 123456789012 already onboarded", err.Error())
 }
 
+func TestPutGCPScanIntegration(t *testing.T) {
+	t.Parallel()
+	apiTest := NewAPITest()
+	apiTest.DdbClient = &ddb.DDB{Client: &modelstest.MockDDBClient{TestErr: false}, TableName: "test"}
+	apiTest.EvaluateIntegrationFunc = func(_ *models.CheckIntegrationInput) (string, bool, error) { return "", true, nil }
+
+	// GCP sources have no log types, only the full scan is started
+	apiTest.mockSqs.On("SendMessageBatch", mock.Anything).Return(&sqs.SendMessageBatchOutput{}, nil)
+	apiTest.mockSecrets.On("PutSecretValue", mock.Anything).
+		Return(&secretsmanager.PutSecretValueOutput{ARN: aws.String(testSecretARN)}, nil).Once()
+
+	out, err := apiTest.PutIntegration(&models.PutIntegrationInput{
+		PutIntegrationSettings: models.PutIntegrationSettings{
+			IntegrationLabel:       testIntegrationLabel,
+			IntegrationType:        models.IntegrationTypeGCPScan,
+			ScanIntervalMins:       60,
+			UserID:                 testUserID,
+			ResourceTypeIgnoreList: []string{gcpmodels.SQLInstanceSchema},
+			GCPConfig: &models.GCPConfig{
+				ProjectID:         "panther-test",
+				ServiceAccountKey: `{"type":"service_account"}`,
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "panther-test", out.GCPConfig.ProjectID)
+	assert.Equal(t, []string{gcpmodels.SQLInstanceSchema}, out.ResourceTypeIgnoreList)
+	// The key is only kept in Secrets Manager
+	assert.Equal(t, testSecretARN, out.CredentialsSecretARN)
+	assert.Empty(t, out.GCPConfig.ServiceAccountKey)
+	apiTest.AssertExpectations(t)
+
+	putSecret := apiTest.mockSecrets.Calls[0].Arguments.Get(0).(*secretsmanager.PutSecretValueInput)
+	assert.Equal(t, `{"type":"service_account"}`, *putSecret.SecretString)
+
+	batch := apiTest.mockSqs.Calls[0].Arguments.Get(0).(*sqs.SendMessageBatchInput)
+	require.Len(t, batch.Entries, len(resourcetypes.Scannable(resourcetypes.IntegrationTypeGCP)))
+	var scanMsg pollermodels.ScanMsg
+	require.NoError(t, jsoniter.UnmarshalFromString(*batch.Entries[0].MessageBody, &scanMsg))
+	require.Len(t, scanMsg.Entries, 1)
+	assert.Equal(t, aws.String(resourcetypes.IntegrationTypeGCP), scanMsg.Entries[0].IntegrationType)
+	assert.Equal(t, aws.String("panther-test"), scanMsg.Entries[0].ProjectID)
+	assert.Nil(t, scanMsg.Entries[0].AWSAccountID)
+}
+
+func TestPutGCPScanIntegrationExists(t *testing.T) {
+	t.Parallel()
+	apiTest := NewAPITest()
+	apiTest.EvaluateIntegrationFunc = func(_ *models.CheckIntegrationInput) (string, bool, error) { return "", true, nil }
+
+	apiTest.DdbClient = &ddb.DDB{
+		Client: &modelstest.MockDDBClient{
+			MockScanAttributes: []map[string]*dynamodb.AttributeValue{
+				{
+					"integrationType":  {S: aws.String(models.IntegrationTypeGCPScan)},
+					"integrationLabel": {S: aws.String("other-label")},
+					"gcpConfig": {M: map[string]*dynamodb.AttributeValue{
+						"projectId": {S: aws.String("panther-test")},
+					}},
+				},
+			},
+			TestErr: false,
+		},
+		TableName: "test",
+	}
+
+	out, err := apiTest.PutIntegration(&models.PutIntegrationInput{
+		PutIntegrationSettings: models.PutIntegrationSettings{
+			IntegrationLabel: testIntegrationLabel,
+			IntegrationType:  models.IntegrationTypeGCPScan,
+			ScanIntervalMins: 60,
+			UserID:           testUserID,
+			GCPConfig: &models.GCPConfig{
+				ProjectID:         "panther-test",
+				ServiceAccountKey: `{"type":"service_account"}`,
+			},
+		},
+	})
+	require.Error(t, err)
+	require.Empty(t, out)
+	assert.Equal(t, "Source project panther-test already onboarded", err.Error())
+}
+
+func TestPutK8sScanIntegration(t *testing.T) {
+	t.Parallel()
+	apiTest := NewAPITest()
+	apiTest.DdbClient = &ddb.DDB{Client: &modelstest.MockDDBClient{TestErr: false}, TableName: "test"}
+	apiTest.EvaluateIntegrationFunc = func(_ *models.CheckIntegrationInput) (string, bool, error) { return "", true, nil }
+
+	// Kubernetes sources have no log types, only the full scan is started
+	apiTest.mockSqs.On("SendMessageBatch", mock.Anything).Return(&sqs.SendMessageBatchOutput{}, nil)
+
+	out, err := apiTest.PutIntegration(&models.PutIntegrationInput{
+		PutIntegrationSettings: models.PutIntegrationSettings{
+			IntegrationLabel: testIntegrationLabel,
+			IntegrationType:  models.IntegrationTypeK8sScan,
+			ScanIntervalMins: 60,
+			UserID:           testUserID,
+			K8sConfig: &models.K8sConfig{
+				ClusterName:   "prod",
+				EKSClusterARN: "arn:aws:eks:us-west-2:123456789012:cluster/prod",
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "prod", out.K8sConfig.ClusterName)
+	assert.Equal(t, "arn:aws:eks:us-west-2:123456789012:cluster/prod", out.K8sConfig.EKSClusterARN)
+	// EKS clusters have no credentials to store
+	assert.Empty(t, out.CredentialsSecretARN)
+	apiTest.AssertExpectations(t)
+
+	batch := apiTest.mockSqs.Calls[0].Arguments.Get(0).(*sqs.SendMessageBatchInput)
+	require.Len(t, batch.Entries, len(resourcetypes.Scannable(resourcetypes.IntegrationTypeK8s)))
+	var scanMsg pollermodels.ScanMsg
+	require.NoError(t, jsoniter.UnmarshalFromString(*batch.Entries[0].MessageBody, &scanMsg))
+	require.Len(t, scanMsg.Entries, 1)
+	assert.Equal(t, aws.String(resourcetypes.IntegrationTypeK8s), scanMsg.Entries[0].IntegrationType)
+	assert.Equal(t, aws.String("prod"), scanMsg.Entries[0].ClusterName)
+	assert.Nil(t, scanMsg.Entries[0].AWSAccountID)
+}
+
+func TestPutK8sScanIntegrationKubeconfig(t *testing.T) {
+	t.Parallel()
+	apiTest := NewAPITest()
+	apiTest.DdbClient = &ddb.DDB{Client: &modelstest.MockDDBClient{TestErr: false}, TableName: "test"}
+	apiTest.EvaluateIntegrationFunc = func(_ *models.CheckIntegrationInput) (string, bool, error) { return "", true, nil }
+	apiTest.mockSqs.On("SendMessageBatch", mock.Anything).Return(&sqs.SendMessageBatchOutput{}, nil)
+	apiTest.mockSecrets.On("PutSecretValue", mock.Anything).
+		Return(&secretsmanager.PutSecretValueOutput{ARN: aws.String(testSecretARN)}, nil).Once()
+
+	out, err := apiTest.PutIntegration(&models.PutIntegrationInput{
+		PutIntegrationSettings: models.PutIntegrationSettings{
+			IntegrationLabel: testIntegrationLabel,
+			IntegrationType:  models.IntegrationTypeK8sScan,
+			ScanIntervalMins: 60,
+			UserID:           testUserID,
+			K8sConfig: &models.K8sConfig{
+				ClusterName: "prod",
+				Kubeconfig:  "apiVersion: v1",
+			},
+		},
+	})
+	require.NoError(t, err)
+	// The kubeconfig is only kept in Secrets Manager
+	assert.Equal(t, testSecretARN, out.CredentialsSecretARN)
+	assert.Empty(t, out.K8sConfig.Kubeconfig)
+	apiTest.AssertExpectations(t)
+
+	putSecret := apiTest.mockSecrets.Calls[0].Arguments.Get(0).(*secretsmanager.PutSecretValueInput)
+	assert.Equal(t, "panther-source-credentials-"+out.IntegrationID, *putSecret.SecretId)
+	assert.Equal(t, "apiVersion: v1", *putSecret.SecretString)
+}
+
+func TestPutK8sScanIntegrationExists(t *testing.T) {
+	t.Parallel()
+	apiTest := NewAPITest()
+	apiTest.EvaluateIntegrationFunc = func(_ *models.CheckIntegrationInput) (string, bool, error) { return "", true, nil }
+
+	apiTest.DdbClient = &ddb.DDB{
+		Client: &modelstest.MockDDBClient{
+			MockScanAttributes: []map[string]*dynamodb.AttributeValue{
+				{
+					"integrationType":  {S: aws.String(models.IntegrationTypeK8sScan)},
+					"integrationLabel": {S: aws.String("other-label")},
+					"k8sConfig": {M: map[string]*dynamodb.AttributeValue{
+						"clusterName": {S: aws.String("prod")},
+					}},
+				},
+			},
+			TestErr: false,
+		},
+		TableName: "test",
+	}
+
+	out, err := apiTest.PutIntegration(&models.PutIntegrationInput{
+		PutIntegrationSettings: models.PutIntegrationSettings{
+			IntegrationLabel: testIntegrationLabel,
+			IntegrationType:  models.IntegrationTypeK8sScan,
+			ScanIntervalMins: 60,
+			UserID:           testUserID,
+			K8sConfig: &models.K8sConfig{
+				ClusterName:   "prod",
+				EKSClusterARN: "arn:aws:eks:us-west-2:123456789012:cluster/prod",
+			},
+		},
+	})
+	require.Error(t, err)
+	require.Empty(t, out)
+	assert.Equal(t, "Source cluster prod already onboarded", err.Error())
+}
+
+func TestPutK8sScanIntegrationInvalidInput(t *testing.T) {
+	t.Parallel()
+	validator, err := models.Validator()
+	require.NoError(t, err)
+	input := &models.PutIntegrationInput{
+		PutIntegrationSettings: models.PutIntegrationSettings{
+			IntegrationLabel: testIntegrationLabel,
+			IntegrationType:  models.IntegrationTypeK8sScan,
+			ScanIntervalMins: 60,
+			UserID:           testUserID,
+			K8sConfig: &models.K8sConfig{
+				ClusterName: "prod",
+				Kubeconfig:  "apiVersion: v1",
+			},
+		},
+	}
+	assert.NoError(t, validator.Struct(input))
+
+	// The cluster name is part of the resource IDs
+	input.K8sConfig.ClusterName = "prod/web"
+	assert.Error(t, validator.Struct(input))
+}
+
 func TestPutIntegrationValidInput(t *testing.T) {
 	t.Parallel()
 	validator, err := models.Validator()
@@ -391,3 +655,42 @@ func TestPutSqsIntegration(t *testing.T) {
 	assert.JSONEq(t, expectedSqsQueuePolicy, *createQueueRequest.Attributes["Policy"])
 	apiTest.AssertExpectations(t)
 }
+
+func TestPutHTTPIntegration(t *testing.T) {
+	t.Parallel()
+	apiTest := NewAPITest()
+	apiTest.DdbClient = &ddb.DDB{Client: &modelstest.MockDDBClient{TestErr: false}, TableName: "test"}
+	apiTest.Config.InputDataBucketName = "input-data"
+	apiTest.Config.InputDataRoleArn = "role-arn"
+	apiTest.EvaluateIntegrationFunc = func(_ *models.CheckIntegrationInput) (string, bool, error) { return "", true, nil }
+
+	apiTest.mockSqs.On("SendMessageWithContext", mock.Anything, mock.Anything).Return(&sqs.SendMessageOutput{}, nil)
+	apiTest.mockSqs.On("GetQueueAttributes", mock.Anything).
+		Return(&sqs.GetQueueAttributesOutput{Attributes: generateQueueAttributeOutput(t, []string{})}, nil).Once()
+	apiTest.mockSqs.On("SetQueueAttributes", mock.Anything).Return(&sqs.SetQueueAttributesOutput{}, nil).Once()
+	// The secret is created on the first write
+	notFound := awserr.New(secretsmanager.ErrCodeResourceNotFoundException, "not found", nil)
+	apiTest.mockSecrets.On("PutSecretValue", mock.Anything).Return(&secretsmanager.PutSecretValueOutput{}, notFound).Once()
+	apiTest.mockSecrets.On("CreateSecret", mock.Anything).
+		Return(&secretsmanager.CreateSecretOutput{ARN: aws.String(testSecretARN)}, nil).Once()
+
+	out, err := apiTest.PutIntegration(&models.PutIntegrationInput{
+		PutIntegrationSettings: models.PutIntegrationSettings{
+			IntegrationLabel: testIntegrationLabel,
+			IntegrationType:  models.IntegrationTypeHTTP,
+			HTTPConfig: &models.HTTPConfig{
+				LogTypes:   []string{"AWS.CloudTrail"},
+				AuthMethod: models.HTTPAuthSharedSecret,
+				AuthSecret: "0123456789abcdef",
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, testSecretARN, out.CredentialsSecretARN)
+	assert.Empty(t, out.HTTPConfig.AuthSecret)
+	apiTest.AssertExpectations(t)
+
+	createSecret := apiTest.mockSecrets.Calls[1].Arguments.Get(0).(*secretsmanager.CreateSecretInput)
+	assert.Equal(t, "panther-source-credentials-"+out.IntegrationID, *createSecret.Name)
+	assert.Equal(t, "0123456789abcdef", *createSecret.SecretString)
+}
@@ -0,0 +1,181 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/internal/core/source_api/ddb"
+)
+
+// The credentials of a source (e.g. the shared secret of an HTTP source, the service account key of a GCP source or
+// the kubeconfig of a Kubernetes source) are kept in Secrets Manager.
+// The integrations table only stores the ARN of the secret, so credentials are never returned when reading sources.
+const credentialsSecretPrefix = "panther-source-credentials-"
+
+// putCredentials stores the credentials of a source and returns the ARN of the secret holding them.
+func (api *API) putCredentials(integrationID, credentials string) (string, error) {
+	secretName := credentialsSecretPrefix + integrationID
+	putOutput, err := api.SecretsClient.PutSecretValue(&secretsmanager.PutSecretValueInput{
+		SecretId:     &secretName,
+		SecretString: &credentials,
+	})
+	if err == nil {
+		return aws.StringValue(putOutput.ARN), nil
+	}
+	if awsErr, ok := err.(awserr.Error); !ok || awsErr.Code() != secretsmanager.ErrCodeResourceNotFoundException {
+		return "", errors.Wrap(err, "failed to update source credentials")
+	}
+	createOutput, err := api.SecretsClient.CreateSecret(&secretsmanager.CreateSecretInput{
+		Name:         &secretName,
+		Description:  aws.String("Credentials of Panther source " + integrationID),
+		SecretString: &credentials,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create source credentials")
+	}
+	return aws.StringValue(createOutput.ARN), nil
+}
+
+// getCredentials reads the credentials of a source
+func (api *API) getCredentials(secretARN string) (string, error) {
+	output, err := api.SecretsClient.GetSecretValue(&secretsmanager.GetSecretValueInput{
+		SecretId: &secretARN,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get source credentials")
+	}
+	return aws.StringValue(output.SecretString), nil
+}
+
+// deleteCredentials deletes the credentials of a source, if it has any
+func (api *API) deleteCredentials(secretARN string) error {
+	if secretARN == "" {
+		return nil
+	}
+	_, err := api.SecretsClient.DeleteSecret(&secretsmanager.DeleteSecretInput{
+		SecretId:                   &secretARN,
+		ForceDeleteWithoutRecovery: aws.Bool(true),
+	})
+	if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == secretsmanager.ErrCodeResourceNotFoundException {
+		return nil
+	}
+	return errors.Wrap(err, "failed to delete source credentials")
+}
+
+// storeCredentials moves the credentials of a new or updated source to Secrets Manager.
+// Sources without new credentials keep their existing secret.
+func (api *API) storeCredentials(integration *models.SourceIntegration) error {
+	credentials := sourceCredentials(integration)
+	if credentials == "" {
+		return nil
+	}
+	secretARN, err := api.putCredentials(integration.IntegrationID, credentials)
+	if err != nil {
+		return err
+	}
+	integration.CredentialsSecretARN = secretARN
+	clearCredentials(integration)
+	return nil
+}
+
+// sourceCredentials returns the credentials set in the configuration of a source
+func sourceCredentials(integration *models.SourceIntegration) string {
+	switch integration.IntegrationType {
+	case models.IntegrationTypeHTTP:
+		if integration.HTTPConfig != nil {
+			return integration.HTTPConfig.AuthSecret
+		}
+	case models.IntegrationTypeGCPScan:
+		if integration.GCPConfig != nil {
+			return integration.GCPConfig.ServiceAccountKey
+		}
+	case models.IntegrationTypeK8sScan:
+		if integration.K8sConfig != nil {
+			return integration.K8sConfig.Kubeconfig
+		}
+	}
+	return ""
+}
+
+// clearCredentials removes the credentials from the configuration of a source
+func clearCredentials(integration *models.SourceIntegration) {
+	switch integration.IntegrationType {
+	case models.IntegrationTypeHTTP:
+		if integration.HTTPConfig != nil {
+			integration.HTTPConfig.AuthSecret = ""
+		}
+	case models.IntegrationTypeGCPScan:
+		if integration.GCPConfig != nil {
+			integration.GCPConfig.ServiceAccountKey = ""
+		}
+	case models.IntegrationTypeK8sScan:
+		if integration.K8sConfig != nil {
+			integration.K8sConfig.Kubeconfig = ""
+		}
+	}
+}
+
+// fillExistingCredentials sets the stored credentials of a source in the check of an update that keeps them,
+// so that updated sources are checked with the credentials they will use.
+func (api *API) fillExistingCredentials(item *ddb.Integration, input *models.CheckIntegrationInput) error {
+	if item.CredentialsSecretARN == "" {
+		return nil
+	}
+	switch item.IntegrationType {
+	case models.IntegrationTypeHTTP:
+		if input.HTTPConfig == nil || input.HTTPConfig.AuthSecret != "" {
+			return nil
+		}
+		credentials, err := api.getCredentials(item.CredentialsSecretARN)
+		if err != nil {
+			return err
+		}
+		config := *input.HTTPConfig
+		config.AuthSecret = credentials
+		input.HTTPConfig = &config
+	case models.IntegrationTypeGCPScan:
+		if input.GCPConfig == nil || input.GCPConfig.ServiceAccountKey != "" {
+			return nil
+		}
+		credentials, err := api.getCredentials(item.CredentialsSecretARN)
+		if err != nil {
+			return err
+		}
+		config := *input.GCPConfig
+		config.ServiceAccountKey = credentials
+		input.GCPConfig = &config
+	case models.IntegrationTypeK8sScan:
+		if input.K8sConfig == nil || input.K8sConfig.Kubeconfig != "" {
+			return nil
+		}
+		credentials, err := api.getCredentials(item.CredentialsSecretARN)
+		if err != nil {
+			return err
+		}
+		config := *input.K8sConfig
+		config.Kubeconfig = credentials
+		input.K8sConfig = &config
+	}
+	return nil
+}
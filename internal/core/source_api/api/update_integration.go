@@ -30,7 +30,6 @@ import (
 	"github.com/panther-labs/panther/internal/core/source_api/ddb"
 	"github.com/panther-labs/panther/internal/log_analysis/datacatalog_updater/datacatalog"
 	"github.com/panther-labs/panther/pkg/genericapi"
-	"github.com/panther-labs/panther/pkg/stringset"
 )
 
 var (
@@ -78,6 +77,11 @@ func (api *API) UpdateIntegrationSettings(input *models.UpdateIntegrationSetting
 	}
 
 	existingIntegration := ddb.ItemToIntegration(existingItem)
+	setUpdatedCredentials(existingIntegration, input)
+	if err := api.storeCredentials(existingIntegration); err != nil {
+		zap.L().Error("failed to store source credentials", zap.Error(err))
+		return nil, updateIntegrationInternalError
+	}
 
 	if existingIntegration.IntegrationType == models.IntegrationTypeAWS3 &&
 		existingIntegration.ManagedBucketNotifications {
@@ -95,7 +99,7 @@ func (api *API) UpdateIntegrationSettings(input *models.UpdateIntegrationSetting
 }
 
 func (api *API) checkSource(existingItem *ddb.Integration, input *models.UpdateIntegrationSettingsInput) error {
-	reason, passing, err := api.EvaluateIntegrationFunc(&models.CheckIntegrationInput{
+	checkInput := &models.CheckIntegrationInput{
 		// Same as the existing integration item
 		AWSAccountID:    existingItem.AWSAccountID,
 		IntegrationType: existingItem.IntegrationType,
@@ -108,7 +112,15 @@ func (api *API) checkSource(existingItem *ddb.Integration, input *models.UpdateI
 		S3PrefixLogTypes:  input.S3PrefixLogTypes,
 		KmsKey:            input.KmsKey,
 		SqsConfig:         input.SqsConfig,
-	})
+		HTTPConfig:        input.HTTPConfig,
+		GCPConfig:         updatedGCPConfig(existingItem, input),
+		K8sConfig:         updatedK8sConfig(existingItem, input),
+	}
+	if err := api.fillExistingCredentials(existingItem, checkInput); err != nil {
+		zap.L().Error("failed to read source credentials", zap.Error(err))
+		return updateIntegrationInternalError
+	}
+	reason, passing, err := api.EvaluateIntegrationFunc(checkInput)
 	if err != nil {
 		return err
 	}
@@ -126,13 +138,12 @@ func (api *API) checkSource(existingItem *ddb.Integration, input *models.UpdateI
 }
 
 func (api *API) validateUniqueConstraints(existingIntegrationItem *ddb.Integration, input *models.UpdateIntegrationSettingsInput) error {
-	// Prefixes in the same S3 source should should be unique (although we allow overlapping for now)
+	if err := validateEventFilters(input.EventFilters); err != nil {
+		return err
+	}
 	if existingIntegrationItem.IntegrationType == models.IntegrationTypeAWS3 {
-		prefixes := input.S3PrefixLogTypes.S3Prefixes()
-		if len(prefixes) != len(stringset.Dedup(prefixes)) {
-			return &genericapi.InvalidInputError{
-				Message: "Cannot have duplicate prefixes in an s3 source.",
-			}
+		if err := validateS3PrefixLogTypes(input.S3PrefixLogTypes); err != nil {
+			return err
 		}
 	}
 
@@ -168,9 +179,9 @@ func (api *API) validateUniqueConstraints(existingIntegrationItem *ddb.Integrati
 						}
 					}
 				}
-			case models.IntegrationTypeSqs:
+			case models.IntegrationTypeSqs, models.IntegrationTypeHTTP:
 				if existingIntegration.IntegrationLabel == input.IntegrationLabel {
-					// Sqs sources need to have different labels
+					// Sqs and HTTP sources need to have different labels
 					return &genericapi.InvalidInputError{
 						Message: fmt.Sprintf("Integration with label %s already exists", input.IntegrationLabel),
 					}
@@ -209,7 +220,82 @@ func updateIntegrationDBItem(item *ddb.Integration, input *models.UpdateIntegrat
 		item.SqsConfig.LogTypes = input.SqsConfig.LogTypes
 		item.SqsConfig.AllowedSourceArns = input.SqsConfig.AllowedSourceArns
 		item.SqsConfig.AllowedPrincipalArns = input.SqsConfig.AllowedPrincipalArns
+	case models.IntegrationTypeHTTP:
+		item.IntegrationLabel = input.IntegrationLabel
+		item.HTTPConfig.LogTypes = input.HTTPConfig.LogTypes
+		item.HTTPConfig.AuthMethod = input.HTTPConfig.AuthMethod
+		item.HTTPConfig.AuthHeader = input.HTTPConfig.AuthHeader
+	case models.IntegrationTypeGCPScan:
+		item.IntegrationLabel = input.IntegrationLabel
+		item.ScanIntervalMins = input.ScanIntervalMins
+		item.Enabled = input.Enabled
+		item.ResourceTypeIgnoreList = input.ResourceTypeIgnoreList
+		item.ResourceRegexIgnoreList = input.ResourceRegexIgnoreList
+	case models.IntegrationTypeK8sScan:
+		item.IntegrationLabel = input.IntegrationLabel
+		item.ScanIntervalMins = input.ScanIntervalMins
+		item.Enabled = input.Enabled
+		item.ResourceTypeIgnoreList = input.ResourceTypeIgnoreList
+		item.ResourceRegexIgnoreList = input.ResourceRegexIgnoreList
+	}
+	if !isScanIntegration(item.IntegrationType) {
+		item.EventFilters = input.EventFilters
+	}
+}
+
+// setUpdatedCredentials sets the new credentials of an update on the configuration of a source.
+// Updates without new credentials keep the existing ones.
+func setUpdatedCredentials(integration *models.SourceIntegration, input *models.UpdateIntegrationSettingsInput) {
+	switch integration.IntegrationType {
+	case models.IntegrationTypeHTTP:
+		if integration.HTTPConfig != nil && input.HTTPConfig != nil {
+			integration.HTTPConfig.AuthSecret = input.HTTPConfig.AuthSecret
+		}
+	case models.IntegrationTypeGCPScan:
+		if integration.GCPConfig != nil && input.GCPConfig != nil {
+			integration.GCPConfig.ServiceAccountKey = input.GCPConfig.ServiceAccountKey
+		}
+	case models.IntegrationTypeK8sScan:
+		// EKS clusters are always read with the audit role
+		if integration.K8sConfig != nil && integration.K8sConfig.EKSClusterARN == "" && input.K8sConfig != nil {
+			integration.K8sConfig.Kubeconfig = input.K8sConfig.Kubeconfig
+		}
+	}
+}
+
+// updatedGCPConfig returns the GCP settings of an integration after an update.
+//
+// The project of a GCP integration can't change. The service account key is only set if a new one is,
+// the stored key is read by fillExistingCredentials.
+func updatedGCPConfig(item *ddb.Integration, input *models.UpdateIntegrationSettingsInput) *models.GCPConfig {
+	if item.GCPConfig == nil {
+		return nil
+	}
+	config := &models.GCPConfig{
+		ProjectID: item.GCPConfig.ProjectID,
+	}
+	if input.GCPConfig != nil {
+		config.ServiceAccountKey = input.GCPConfig.ServiceAccountKey
+	}
+	return config
+}
+
+// updatedK8sConfig returns the Kubernetes settings of an integration after an update.
+//
+// The cluster of a Kubernetes integration can't change. Clusters read with a kubeconfig keep it unless a new one
+// is set, the stored kubeconfig is read by fillExistingCredentials. EKS clusters are always read with the audit role.
+func updatedK8sConfig(item *ddb.Integration, input *models.UpdateIntegrationSettingsInput) *models.K8sConfig {
+	if item.K8sConfig == nil {
+		return nil
+	}
+	config := &models.K8sConfig{
+		ClusterName:   item.K8sConfig.ClusterName,
+		EKSClusterARN: item.K8sConfig.EKSClusterARN,
+	}
+	if input.K8sConfig != nil && config.EKSClusterARN == "" {
+		config.Kubeconfig = input.K8sConfig.Kubeconfig
 	}
+	return config
 }
 
 // UpdateIntegrationLastScanStart updates an integration when a new scan is started.
@@ -267,6 +353,9 @@ func (api *API) updateTables(item *ddb.Integration, input *models.UpdateIntegrat
 	case models.IntegrationTypeSqs:
 		existingLogTypes = item.SqsConfig.LogTypes
 		newLogTypes = input.SqsConfig.LogTypes
+	case models.IntegrationTypeHTTP:
+		existingLogTypes = item.HTTPConfig.LogTypes
+		newLogTypes = input.HTTPConfig.LogTypes
 	}
 
 	// If the user hasn't added new log types to the integration
@@ -25,6 +25,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -156,6 +157,176 @@ func TestUpdateIntegrationSameLogTypes(t *testing.T) {
 	apiTest.AssertExpectations(t)
 }
 
+func TestUpdateHTTPIntegrationKeepsSecret(t *testing.T) {
+	t.Parallel()
+	apiTest := NewAPITest()
+
+	// The health check is done with the stored secret
+	var checkInput *models.CheckIntegrationInput
+	apiTest.EvaluateIntegrationFunc = func(input *models.CheckIntegrationInput) (string, bool, error) {
+		checkInput = input
+		return "", true, nil
+	}
+
+	getResponse := &dynamodb.GetItemOutput{Item: map[string]*dynamodb.AttributeValue{
+		"integrationId":        {S: aws.String(testIntegrationID)},
+		"integrationType":      {S: aws.String(models.IntegrationTypeHTTP)},
+		"credentialsSecretArn": {S: aws.String(testSecretARN)},
+		"httpConfig": {M: map[string]*dynamodb.AttributeValue{
+			"logTypes":   {SS: aws.StringSlice([]string{"Log.TypeA"})},
+			"authMethod": {S: aws.String(models.HTTPAuthSharedSecret)},
+		}},
+	}}
+	apiTest.mockDdb.On("GetItem", mock.Anything).Return(getResponse, nil).Once()
+	apiTest.mockDdb.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil).Once()
+	apiTest.mockDdb.On("Scan", mock.Anything).Return(&dynamodb.ScanOutput{}, nil).Once()
+	apiTest.mockSecrets.On("GetSecretValue", &secretsmanager.GetSecretValueInput{SecretId: aws.String(testSecretARN)}).
+		Return(&secretsmanager.GetSecretValueOutput{SecretString: aws.String("0123456789abcdef")}, nil).Once()
+
+	result, err := apiTest.UpdateIntegrationSettings(&models.UpdateIntegrationSettingsInput{
+		IntegrationID:    testIntegrationID,
+		IntegrationLabel: "new-label",
+		HTTPConfig: &models.HTTPConfig{
+			LogTypes:   []string{"Log.TypeA"},
+			AuthMethod: models.HTTPAuthHMAC,
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789abcdef", checkInput.HTTPConfig.AuthSecret)
+	assert.Equal(t, testSecretARN, result.CredentialsSecretARN)
+	assert.Equal(t, models.HTTPAuthHMAC, result.HTTPConfig.AuthMethod)
+	assert.Empty(t, result.HTTPConfig.AuthSecret)
+	apiTest.AssertExpectations(t)
+
+	item := apiTest.mockDdb.Calls[2].Arguments.Get(0).(*dynamodb.PutItemInput).Item
+	assert.Equal(t, testSecretARN, *item["credentialsSecretArn"].S)
+	assert.NotContains(t, item["httpConfig"].M, "authSecret")
+}
+
+func TestUpdateHTTPIntegrationReplacesSecret(t *testing.T) {
+	t.Parallel()
+	apiTest := NewAPITest()
+	apiTest.EvaluateIntegrationFunc = func(_ *models.CheckIntegrationInput) (string, bool, error) {
+		return "", true, nil
+	}
+
+	getResponse := &dynamodb.GetItemOutput{Item: map[string]*dynamodb.AttributeValue{
+		"integrationId":        {S: aws.String(testIntegrationID)},
+		"integrationType":      {S: aws.String(models.IntegrationTypeHTTP)},
+		"credentialsSecretArn": {S: aws.String(testSecretARN)},
+		"httpConfig": {M: map[string]*dynamodb.AttributeValue{
+			"logTypes":   {SS: aws.StringSlice([]string{"Log.TypeA"})},
+			"authMethod": {S: aws.String(models.HTTPAuthSharedSecret)},
+		}},
+	}}
+	apiTest.mockDdb.On("GetItem", mock.Anything).Return(getResponse, nil).Once()
+	apiTest.mockDdb.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil).Once()
+	apiTest.mockDdb.On("Scan", mock.Anything).Return(&dynamodb.ScanOutput{}, nil).Once()
+	apiTest.mockSecrets.On("PutSecretValue", &secretsmanager.PutSecretValueInput{
+		SecretId:     aws.String("panther-source-credentials-" + testIntegrationID),
+		SecretString: aws.String("fedcba9876543210"),
+	}).Return(&secretsmanager.PutSecretValueOutput{ARN: aws.String(testSecretARN)}, nil).Once()
+
+	result, err := apiTest.UpdateIntegrationSettings(&models.UpdateIntegrationSettingsInput{
+		IntegrationID:    testIntegrationID,
+		IntegrationLabel: "new-label",
+		HTTPConfig: &models.HTTPConfig{
+			LogTypes:   []string{"Log.TypeA"},
+			AuthMethod: models.HTTPAuthSharedSecret,
+			AuthSecret: "fedcba9876543210",
+		},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, result.HTTPConfig.AuthSecret)
+	apiTest.AssertExpectations(t)
+}
+
+func TestUpdateGCPIntegrationKeepsKey(t *testing.T) {
+	t.Parallel()
+	apiTest := NewAPITest()
+
+	// The health check is done with the stored key
+	var checkInput *models.CheckIntegrationInput
+	apiTest.EvaluateIntegrationFunc = func(input *models.CheckIntegrationInput) (string, bool, error) {
+		checkInput = input
+		return "", true, nil
+	}
+
+	getResponse := &dynamodb.GetItemOutput{Item: map[string]*dynamodb.AttributeValue{
+		"integrationId":        {S: aws.String(testIntegrationID)},
+		"integrationType":      {S: aws.String(models.IntegrationTypeGCPScan)},
+		"credentialsSecretArn": {S: aws.String(testSecretARN)},
+		"gcpConfig": {M: map[string]*dynamodb.AttributeValue{
+			"projectId": {S: aws.String("panther-test")},
+		}},
+	}}
+	apiTest.mockDdb.On("GetItem", mock.Anything).Return(getResponse, nil).Once()
+	apiTest.mockDdb.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil).Once()
+	apiTest.mockDdb.On("Scan", mock.Anything).Return(&dynamodb.ScanOutput{}, nil).Once()
+	apiTest.mockSecrets.On("GetSecretValue", &secretsmanager.GetSecretValueInput{SecretId: aws.String(testSecretARN)}).
+		Return(&secretsmanager.GetSecretValueOutput{SecretString: aws.String(`{"type":"service_account"}`)}, nil).Once()
+
+	result, err := apiTest.UpdateIntegrationSettings(&models.UpdateIntegrationSettingsInput{
+		IntegrationID:    testIntegrationID,
+		IntegrationLabel: "new-label",
+		ScanIntervalMins: 60,
+		GCPConfig:        &models.GCPConfig{},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "panther-test", checkInput.GCPConfig.ProjectID)
+	assert.Equal(t, `{"type":"service_account"}`, checkInput.GCPConfig.ServiceAccountKey)
+	assert.Equal(t, testSecretARN, result.CredentialsSecretARN)
+	assert.Empty(t, result.GCPConfig.ServiceAccountKey)
+	apiTest.AssertExpectations(t)
+
+	item := apiTest.mockDdb.Calls[2].Arguments.Get(0).(*dynamodb.PutItemInput).Item
+	assert.Equal(t, testSecretARN, *item["credentialsSecretArn"].S)
+	assert.Equal(t, map[string]*dynamodb.AttributeValue{"projectId": {S: aws.String("panther-test")}}, item["gcpConfig"].M)
+}
+
+func TestUpdateK8sIntegrationReplacesKubeconfig(t *testing.T) {
+	t.Parallel()
+	apiTest := NewAPITest()
+	var checkInput *models.CheckIntegrationInput
+	apiTest.EvaluateIntegrationFunc = func(input *models.CheckIntegrationInput) (string, bool, error) {
+		checkInput = input
+		return "", true, nil
+	}
+
+	getResponse := &dynamodb.GetItemOutput{Item: map[string]*dynamodb.AttributeValue{
+		"integrationId":        {S: aws.String(testIntegrationID)},
+		"integrationType":      {S: aws.String(models.IntegrationTypeK8sScan)},
+		"credentialsSecretArn": {S: aws.String(testSecretARN)},
+		"k8sConfig": {M: map[string]*dynamodb.AttributeValue{
+			"clusterName": {S: aws.String("prod")},
+		}},
+	}}
+	apiTest.mockDdb.On("GetItem", mock.Anything).Return(getResponse, nil).Once()
+	apiTest.mockDdb.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil).Once()
+	apiTest.mockDdb.On("Scan", mock.Anything).Return(&dynamodb.ScanOutput{}, nil).Once()
+	apiTest.mockSecrets.On("PutSecretValue", &secretsmanager.PutSecretValueInput{
+		SecretId:     aws.String("panther-source-credentials-" + testIntegrationID),
+		SecretString: aws.String("apiVersion: v2"),
+	}).Return(&secretsmanager.PutSecretValueOutput{ARN: aws.String(testSecretARN)}, nil).Once()
+
+	result, err := apiTest.UpdateIntegrationSettings(&models.UpdateIntegrationSettingsInput{
+		IntegrationID:    testIntegrationID,
+		IntegrationLabel: "new-label",
+		ScanIntervalMins: 60,
+		K8sConfig:        &models.K8sConfig{Kubeconfig: "apiVersion: v2"},
+	})
+	require.NoError(t, err)
+	// The cluster is checked with the new kubeconfig
+	assert.Equal(t, "prod", checkInput.K8sConfig.ClusterName)
+	assert.Equal(t, "apiVersion: v2", checkInput.K8sConfig.Kubeconfig)
+	assert.Equal(t, testSecretARN, result.CredentialsSecretARN)
+	assert.Empty(t, result.K8sConfig.Kubeconfig)
+	apiTest.AssertExpectations(t)
+
+	item := apiTest.mockDdb.Calls[2].Arguments.Get(0).(*dynamodb.PutItemInput).Item
+	assert.NotContains(t, item["k8sConfig"].M, "kubeconfig")
+}
+
 func TestUpdateIntegrationValidTime(t *testing.T) {
 	t.Parallel()
 	now := time.Now()
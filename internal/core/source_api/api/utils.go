@@ -19,10 +19,13 @@ package api
  */
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/panther-labs/panther/api/lambda/source/models"
 	"github.com/panther-labs/panther/internal/core/source_api/ddb"
+	"github.com/panther-labs/panther/pkg/genericapi"
+	"github.com/panther-labs/panther/pkg/stringset"
 )
 
 func integrationToItem(input *models.SourceIntegration) *ddb.Integration {
@@ -36,6 +39,8 @@ func integrationToItem(input *models.SourceIntegration) *ddb.Integration {
 		PantherVersion:   input.PantherVersion,
 	}
 	item.LastEventReceived = input.LastEventReceived
+	item.EventFilters = input.EventFilters
+	item.CredentialsSecretARN = input.CredentialsSecretARN
 
 	switch input.IntegrationType {
 	case models.IntegrationTypeAWS3:
@@ -72,6 +77,41 @@ func integrationToItem(input *models.SourceIntegration) *ddb.Integration {
 			AllowedPrincipalArns: input.SqsConfig.AllowedPrincipalArns,
 			AllowedSourceArns:    input.SqsConfig.AllowedSourceArns,
 		}
+	case models.IntegrationTypeHTTP:
+		item.HTTPConfig = &ddb.HTTPConfig{
+			S3Bucket:          input.HTTPConfig.S3Bucket,
+			LogProcessingRole: input.HTTPConfig.LogProcessingRole,
+			LogTypes:          input.HTTPConfig.LogTypes,
+			AuthMethod:        input.HTTPConfig.AuthMethod,
+			AuthHeader:        input.HTTPConfig.AuthHeader,
+		}
+	case models.IntegrationTypeGCPScan:
+		item.GCPConfig = &ddb.GCPConfig{
+			ProjectID: input.GCPConfig.ProjectID,
+		}
+		item.EventStatus = input.EventStatus
+		item.LastScanErrorMessage = input.LastScanErrorMessage
+		item.LastScanEndTime = input.LastScanEndTime
+		item.LastScanStartTime = input.LastScanStartTime
+		item.ScanIntervalMins = input.ScanIntervalMins
+		item.ScanStatus = input.ScanStatus
+		item.Enabled = input.Enabled
+		item.ResourceTypeIgnoreList = input.ResourceTypeIgnoreList
+		item.ResourceRegexIgnoreList = input.ResourceRegexIgnoreList
+	case models.IntegrationTypeK8sScan:
+		item.K8sConfig = &ddb.K8sConfig{
+			ClusterName:   input.K8sConfig.ClusterName,
+			EKSClusterARN: input.K8sConfig.EKSClusterARN,
+		}
+		item.EventStatus = input.EventStatus
+		item.LastScanErrorMessage = input.LastScanErrorMessage
+		item.LastScanEndTime = input.LastScanEndTime
+		item.LastScanStartTime = input.LastScanStartTime
+		item.ScanIntervalMins = input.ScanIntervalMins
+		item.ScanStatus = input.ScanStatus
+		item.Enabled = input.Enabled
+		item.ResourceTypeIgnoreList = input.ResourceTypeIgnoreList
+		item.ResourceRegexIgnoreList = input.ResourceRegexIgnoreList
 	}
 	return item
 }
@@ -94,3 +134,44 @@ func reduceNoPrefixStrings(strs []string) (reduced []string) {
 	}
 	return
 }
+
+// validateS3PrefixLogTypes checks that prefixes are unique and that JSON array paths are valid.
+func validateS3PrefixLogTypes(prefixLogTypes models.S3PrefixLogtypes) error {
+	// Prefixes in the same S3 source should be unique (although we allow overlapping for now)
+	prefixes := prefixLogTypes.S3Prefixes()
+	if len(prefixes) != len(stringset.Dedup(prefixes)) {
+		return &genericapi.InvalidInputError{
+			Message: "Cannot have duplicate prefixes in an s3 source.",
+		}
+	}
+	for i := range prefixLogTypes {
+		if _, err := prefixLogTypes[i].JSONArrayPathElements(); err != nil {
+			return &genericapi.InvalidInputError{
+				Message: fmt.Sprintf("Invalid JSON array path for prefix %q: %s", prefixLogTypes[i].S3Prefix, err),
+			}
+		}
+	}
+	return nil
+}
+
+// validateEventFilters checks that the conditions of event filters are well-formed.
+func validateEventFilters(filters []models.EventFilter) error {
+	for i := range filters {
+		if err := filters[i].Validate(); err != nil {
+			return &genericapi.InvalidInputError{
+				Message: fmt.Sprintf("Invalid event filter #%d: %s", i+1, err),
+			}
+		}
+	}
+	return nil
+}
+
+// isScanIntegration returns true for the integration types of Cloud Security snapshot scans
+func isScanIntegration(integrationType string) bool {
+	switch integrationType {
+	case models.IntegrationTypeAWSScan, models.IntegrationTypeGCPScan, models.IntegrationTypeK8sScan:
+		return true
+	default:
+		return false
+	}
+}
@@ -26,6 +26,8 @@ import (
 	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
 	"github.com/kelseyhightower/envconfig"
@@ -70,6 +72,7 @@ func Setup() *API {
 		SqsClient:        sqs.New(awsSession),
 		TemplateS3Client: s3.New(awsSession, aws.NewConfig().WithRegion(templateBucketRegion)),
 		LambdaClient:     lambda.New(awsSession),
+		SecretsClient:    secretsmanager.New(awsSession),
 		Config:           env,
 	}
 	api.EvaluateIntegrationFunc = api.evaluateIntegration
@@ -83,6 +86,7 @@ type API struct {
 	SqsClient               sqsiface.SQSAPI
 	TemplateS3Client        s3iface.S3API
 	LambdaClient            lambdaiface.LambdaAPI
+	SecretsClient           secretsmanageriface.SecretsManagerAPI
 	Config                  Config
 	EvaluateIntegrationFunc func(integration *models.CheckIntegrationInput) (string, bool, error)
 }
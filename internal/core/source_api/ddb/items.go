@@ -62,6 +62,18 @@ type Integration struct {
 
 	SqsConfig *SqsConfig `json:"sqsConfig,omitempty"`
 
+	HTTPConfig *HTTPConfig `json:"httpConfig,omitempty"`
+
+	GCPConfig *GCPConfig `json:"gcpConfig,omitempty"`
+
+	K8sConfig *K8sConfig `json:"k8sConfig,omitempty"`
+
+	// The ARN of the secret holding the credentials of the source, the credentials are never stored in the table
+	CredentialsSecretARN string `json:"credentialsSecretArn,omitempty"`
+
+	// fields for log analysis sources
+	EventFilters []models.EventFilter `json:"eventFilters,omitempty"`
+
 	// The Panther version in which this source was created.
 	PantherVersion string `json:"pantherVersion,omitempty"`
 }
@@ -80,3 +92,20 @@ type SqsConfig struct {
 	AllowedSourceArns    []string `json:"allowedSourceArns" dynamodbav:",stringset"`
 	QueueURL             string   `json:"queueUrl,omitempty"`
 }
+
+type HTTPConfig struct {
+	S3Bucket          string   `json:"s3Bucket,omitempty"`
+	LogProcessingRole string   `json:"logProcessingRole,omitempty"`
+	LogTypes          []string `json:"logTypes" dynamodbav:",stringset"`
+	AuthMethod        string   `json:"authMethod,omitempty"`
+	AuthHeader        string   `json:"authHeader,omitempty"`
+}
+
+type GCPConfig struct {
+	ProjectID string `json:"projectId,omitempty"`
+}
+
+type K8sConfig struct {
+	ClusterName   string `json:"clusterName,omitempty"`
+	EKSClusterARN string `json:"eksClusterArn,omitempty"`
+}
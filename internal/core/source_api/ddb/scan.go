@@ -104,6 +104,8 @@ func ItemToIntegration(item *Integration) *models.SourceIntegration {
 	integration.CreatedBy = item.CreatedBy
 	integration.LastEventReceived = item.LastEventReceived
 	integration.PantherVersion = item.PantherVersion
+	integration.EventFilters = item.EventFilters
+	integration.CredentialsSecretARN = item.CredentialsSecretARN
 	switch item.IntegrationType {
 	case models.IntegrationTypeAWS3:
 		integration.AWSAccountID = item.AWSAccountID
@@ -144,6 +146,41 @@ func ItemToIntegration(item *Integration) *models.SourceIntegration {
 			AllowedPrincipalArns: item.SqsConfig.AllowedPrincipalArns,
 			AllowedSourceArns:    item.SqsConfig.AllowedSourceArns,
 		}
+	case models.IntegrationTypeHTTP:
+		integration.HTTPConfig = &models.HTTPConfig{
+			S3Bucket:          item.HTTPConfig.S3Bucket,
+			LogProcessingRole: item.HTTPConfig.LogProcessingRole,
+			LogTypes:          item.HTTPConfig.LogTypes,
+			AuthMethod:        item.HTTPConfig.AuthMethod,
+			AuthHeader:        item.HTTPConfig.AuthHeader,
+		}
+	case models.IntegrationTypeGCPScan:
+		integration.GCPConfig = &models.GCPConfig{
+			ProjectID: item.GCPConfig.ProjectID,
+		}
+		integration.ScanIntervalMins = item.ScanIntervalMins
+		integration.ScanStatus = item.ScanStatus
+		integration.EventStatus = item.EventStatus
+		integration.LastScanStartTime = item.LastScanStartTime
+		integration.LastScanEndTime = item.LastScanEndTime
+		integration.LastScanErrorMessage = item.LastScanErrorMessage
+		integration.Enabled = item.Enabled
+		integration.ResourceTypeIgnoreList = item.ResourceTypeIgnoreList
+		integration.ResourceRegexIgnoreList = item.ResourceRegexIgnoreList
+	case models.IntegrationTypeK8sScan:
+		integration.K8sConfig = &models.K8sConfig{
+			ClusterName:   item.K8sConfig.ClusterName,
+			EKSClusterARN: item.K8sConfig.EKSClusterARN,
+		}
+		integration.ScanIntervalMins = item.ScanIntervalMins
+		integration.ScanStatus = item.ScanStatus
+		integration.EventStatus = item.EventStatus
+		integration.LastScanStartTime = item.LastScanStartTime
+		integration.LastScanEndTime = item.LastScanEndTime
+		integration.LastScanErrorMessage = item.LastScanErrorMessage
+		integration.Enabled = item.Enabled
+		integration.ResourceTypeIgnoreList = item.ResourceTypeIgnoreList
+		integration.ResourceRegexIgnoreList = item.ResourceRegexIgnoreList
 	}
 	return integration
 }
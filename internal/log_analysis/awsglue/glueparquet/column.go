@@ -0,0 +1,182 @@
+package glueparquet
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/xitongsys/parquet-go/common"
+	"github.com/xitongsys/parquet-go/layout"
+	"github.com/xitongsys/parquet-go/schema"
+
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue/glueschema"
+)
+
+// column buffers the values of a leaf field in the schema until they are encoded to pages
+type column struct {
+	path   []string
+	typ    glueschema.Type
+	maxDef int
+	maxRep int
+	// key of the column in the tables passed to the parquet writer
+	key string
+	// table holds the values along with their repetition and definition levels, nulls are stored as nil values
+	table *layout.Table
+	// size of the values in the table
+	size int
+	// mark holds the number of values and their size at the start of the current row
+	mark [2]int
+	// raw is set if the column stores raw JSON values
+	raw bool
+}
+
+func newColumn(path []string, typ glueschema.Type, maxDef, maxRep int) *column {
+	return &column{
+		path:   path,
+		typ:    typ,
+		maxDef: maxDef,
+		maxRep: maxRep,
+		table:  layout.NewEmptyTable(),
+	}
+}
+
+// initTable sets the schema of the column table from the schema element at index i
+func (c *column) initTable(sh *schema.SchemaHandler, i int) error {
+	c.key = sh.IndexMap[int32(i)]
+	path := common.StrToPath(c.key)
+	maxDef, err := sh.MaxDefinitionLevel(path)
+	if err != nil {
+		return err
+	}
+	maxRep, err := sh.MaxRepetitionLevel(path)
+	if err != nil {
+		return err
+	}
+	if int(maxDef) != c.maxDef || int(maxRep) != c.maxRep {
+		return errors.Errorf("invalid levels for column %q", c.key)
+	}
+	c.table = c.newTable(sh, i)
+	return nil
+}
+
+func (c *column) newTable(sh *schema.SchemaHandler, i int) *layout.Table {
+	el := sh.SchemaElements[i]
+	return &layout.Table{
+		RepetitionType:     el.GetRepetitionType(),
+		Schema:             el,
+		Path:               common.StrToPath(c.key),
+		MaxDefinitionLevel: int32(c.maxDef),
+		MaxRepetitionLevel: int32(c.maxRep),
+		Info:               sh.Infos[i],
+	}
+}
+
+// flushTable returns the buffered values and resets the column
+func (c *column) flushTable() *layout.Table {
+	table := c.table
+	c.table = layout.NewTableFromTable(table)
+	c.table.RepetitionType = table.RepetitionType
+	c.table.MaxDefinitionLevel = table.MaxDefinitionLevel
+	c.table.MaxRepetitionLevel = table.MaxRepetitionLevel
+	c.size = 0
+	return table
+}
+
+func (c *column) setMark() {
+	c.mark = [2]int{len(c.table.Values), c.size}
+}
+
+func (c *column) resetMark() {
+	n := c.mark[0]
+	c.table.Values = c.table.Values[:n]
+	c.table.RepetitionLevels = c.table.RepetitionLevels[:n]
+	c.table.DefinitionLevels = c.table.DefinitionLevels[:n]
+	c.size = c.mark[1]
+}
+
+// writeNull adds a null value defined up to level def
+func (c *column) writeNull(rep, def int) {
+	c.write(rep, def, nil, 0)
+}
+
+func (c *column) write(rep, def int, value interface{}, size int) {
+	c.table.Values = append(c.table.Values, value)
+	c.table.RepetitionLevels = append(c.table.RepetitionLevels, int32(rep))
+	c.table.DefinitionLevels = append(c.table.DefinitionLevels, int32(def))
+	c.size += size + 2
+}
+
+func (c *column) writeBool(rep int, b bool) {
+	c.write(rep, c.maxDef, b, 1)
+}
+
+func (c *column) writeInt32(rep int, x int32) {
+	c.write(rep, c.maxDef, x, 4)
+}
+
+func (c *column) writeInt64(rep int, x int64) {
+	c.write(rep, c.maxDef, x, 8)
+}
+
+func (c *column) writeFloat(rep int, f float32) {
+	c.write(rep, c.maxDef, f, 4)
+}
+
+func (c *column) writeDouble(rep int, f float64) {
+	c.write(rep, c.maxDef, f, 8)
+}
+
+func (c *column) writeString(rep int, s string) {
+	c.write(rep, c.maxDef, s, len(s))
+}
+
+const (
+	// Julian day number of the Unix epoch
+	julianDayUnixEpoch = 2440588
+	int96Size          = 12
+)
+
+// writeTimestamp adds an INT96 timestamp value (nanoseconds in the day followed by the Julian day number)
+func (c *column) writeTimestamp(rep int, tm time.Time) {
+	c.write(rep, c.maxDef, encodeInt96(tm), int96Size)
+}
+
+// encodeInt96 encodes a timestamp the way Hive and Impala do, without truncating the nanoseconds
+func encodeInt96(tm time.Time) string {
+	days := tm.Unix() / 86400
+	if tm.Unix() < 0 && tm.Unix()%86400 != 0 {
+		days--
+	}
+	nanos := (tm.Unix()-days*86400)*int64(time.Second) + int64(tm.Nanosecond())
+	var b [int96Size]byte
+	binary.LittleEndian.PutUint64(b[:], uint64(nanos))
+	binary.LittleEndian.PutUint32(b[8:], uint32(days+julianDayUnixEpoch))
+	return string(b[:])
+}
+
+func decodeInt96(s string) time.Time {
+	if len(s) != int96Size {
+		return time.Time{}
+	}
+	nanos := int64(binary.LittleEndian.Uint64([]byte(s)))
+	days := int64(binary.LittleEndian.Uint32([]byte(s[8:]))) - julianDayUnixEpoch
+	return time.Unix(days*86400, nanos).UTC()
+}
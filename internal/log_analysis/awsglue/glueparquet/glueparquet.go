@@ -0,0 +1,357 @@
+// Package glueparquet reads and writes Parquet files for Glue tables.
+//
+// Rows are provided as JSON objects whose keys are the Glue column names, ie the output of
+// `common.ConfigForDataLakeWriters()`, and are shredded to columns using the Glue column types.
+// Encoding, compression and file metadata are handled by github.com/xitongsys/parquet-go.
+package glueparquet
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"github.com/xitongsys/parquet-go/layout"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/schema"
+	"github.com/xitongsys/parquet-go/writer"
+
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue/glueschema"
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue/gluetimestamp"
+)
+
+// Writer streams rows to a Parquet file.
+//
+// Rows are shredded to column values as they are written. Once the values of all columns fill a page they are
+// encoded and compressed and, when a row group is complete, its pages are written to the output.
+type Writer struct {
+	root    *node
+	pw      *writer.ParquetWriter
+	numRows int
+	// size of the column values that are not encoded yet
+	size int
+}
+
+// NewWriter creates a writer for a table with the provided columns.
+// The Parquet file is written to w as rows are added and is completed by Close.
+func NewWriter(w io.Writer, columns []glueschema.Column) (*Writer, error) {
+	root, err := newSchema(columns)
+	if err != nil {
+		return nil, err
+	}
+	elements := root.appendElements(nil)
+	// The root element has no repetition
+	elements[0].RepetitionType = nil
+	sh := schema.NewSchemaHandlerFromSchemaList(elements)
+	if len(sh.MapIndex) != len(elements) {
+		return nil, errors.New("column names are not unique after conversion to Parquet field names")
+	}
+	if err := root.initTables(sh); err != nil {
+		return nil, err
+	}
+	// Rows are shredded in WriteJSON so marshaling cannot be parallel
+	const numParallel = 1
+	pw, err := writer.NewParquetWriterFromWriter(w, elements, numParallel)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create parquet writer")
+	}
+	pw.CompressionType = parquet.CompressionCodec_SNAPPY
+	pw.MarshalFunc = func(_ []interface{}, _ *schema.SchemaHandler) (*map[string]*layout.Table, error) {
+		tables := root.flushTables()
+		return &tables, nil
+	}
+	return &Writer{
+		root: root,
+		pw:   pw,
+	}, nil
+}
+
+// NumRows returns the number of rows written
+func (w *Writer) NumRows() int {
+	return w.numRows
+}
+
+// Size returns the size of the data that is buffered and not yet written to the output
+func (w *Writer) Size() int {
+	return w.size + int(w.pw.Size)
+}
+
+// WriteJSON adds a row from a JSON object.
+// Fields that are not in the schema are ignored and values that cannot be converted to the column type are stored as nulls.
+// If the JSON is not valid, no values of the row are added.
+func (w *Writer) WriteJSON(data []byte) error {
+	iter := jsoniter.ConfigDefault.BorrowIterator(data)
+	defer jsoniter.ConfigDefault.ReturnIterator(iter)
+
+	columns := w.root.columns
+	for _, c := range columns {
+		c.setMark()
+	}
+	if iter.WhatIsNext() != jsoniter.ObjectValue {
+		iter.ReportError("WriteJSON", "expected a JSON object")
+	} else {
+		w.root.writeFields(iter, 0)
+	}
+	if err := iter.Error; err != nil && err != io.EOF {
+		for _, c := range columns {
+			c.resetMark()
+		}
+		return errors.Wrap(err, "invalid JSON row")
+	}
+	w.numRows++
+	size := 0
+	for _, c := range columns {
+		size += c.size
+	}
+	w.size = size
+	// The rows are already shredded, the parquet writer only needs to count them
+	w.pw.Objs = append(w.pw.Objs, nil)
+	if int64(w.size) < w.pw.PageSize*int64(len(columns)) {
+		return nil
+	}
+	if err := w.pw.Flush(false); err != nil {
+		return errors.Wrap(err, "failed to write parquet pages")
+	}
+	w.size = 0
+	return nil
+}
+
+// RawJSONColumnsKey is the key of the file metadata listing the string columns that store raw JSON values.
+// The value is a JSON array of column paths, with path elements separated by '.'.
+const RawJSONColumnsKey = "panther.raw_json_columns"
+
+// Close writes the remaining rows and the file footer
+func (w *Writer) Close() error {
+	var raw []string
+	for _, c := range w.root.columns {
+		if c.raw {
+			raw = append(raw, strings.Join(c.path, "."))
+		}
+	}
+	if raw != nil {
+		value, err := jsoniter.MarshalToString(raw)
+		if err != nil {
+			return err
+		}
+		w.pw.Footer.KeyValueMetadata = append(w.pw.Footer.KeyValueMetadata, &parquet.KeyValue{
+			Key:   RawJSONColumnsKey,
+			Value: &value,
+		})
+	}
+	if err := w.pw.WriteStop(); err != nil {
+		return errors.Wrap(err, "failed to write parquet file")
+	}
+	w.size = 0
+	return nil
+}
+
+// writeValue shreds the next JSON value for a node
+func (n *node) writeValue(iter *jsoniter.Iterator, rep int) {
+	next := iter.WhatIsNext()
+	if next == jsoniter.NilValue {
+		iter.Skip()
+		n.writeNull(rep, n.def-1)
+		return
+	}
+	switch n.kind {
+	case kindStruct:
+		if next != jsoniter.ObjectValue {
+			iter.Skip()
+			n.writeNull(rep, n.def-1)
+			return
+		}
+		n.writeFields(iter, rep)
+	case kindList:
+		if next != jsoniter.ArrayValue {
+			iter.Skip()
+			n.writeNull(rep, n.def-1)
+			return
+		}
+		size := 0
+		iter.ReadArrayCB(func(iter *jsoniter.Iterator) bool {
+			if size == 0 {
+				n.elem.writeValue(iter, rep)
+			} else {
+				n.elem.writeValue(iter, n.elem.rep)
+			}
+			size++
+			return true
+		})
+		if size == 0 {
+			n.writeNull(rep, n.def)
+		}
+	case kindMap:
+		if next != jsoniter.ObjectValue {
+			iter.Skip()
+			n.writeNull(rep, n.def-1)
+			return
+		}
+		size := 0
+		iter.ReadObjectCB(func(iter *jsoniter.Iterator, key string) bool {
+			r := rep
+			if size > 0 {
+				r = n.key.rep
+			}
+			n.key.column.writeString(r, key)
+			n.value.writeValue(iter, r)
+			size++
+			return true
+		})
+		if size == 0 {
+			n.writeNull(rep, n.def)
+		}
+	default:
+		n.writeScalar(iter, rep, next)
+	}
+}
+
+// writeFields shreds a JSON object to the fields of a struct node
+func (n *node) writeFields(iter *jsoniter.Iterator, rep int) {
+	seen := n.seen
+	for i := range seen {
+		seen[i] = false
+	}
+	iter.ReadObjectCB(func(iter *jsoniter.Iterator, key string) bool {
+		i, ok := n.index[key]
+		if !ok || seen[i] {
+			iter.Skip()
+			return true
+		}
+		seen[i] = true
+		n.fields[i].writeValue(iter, rep)
+		return true
+	})
+	for i, field := range n.fields {
+		if !seen[i] {
+			field.writeNull(rep, n.def)
+		}
+	}
+}
+
+// writeNull adds a null value to all columns of the node that is defined up to level def
+func (n *node) writeNull(rep, def int) {
+	for _, c := range n.columns {
+		c.writeNull(rep, def)
+	}
+}
+
+func (n *node) writeScalar(iter *jsoniter.Iterator, rep int, next jsoniter.ValueType) {
+	c := n.column
+	switch n.typ {
+	case glueschema.TypeString:
+		if next == jsoniter.StringValue {
+			c.writeString(rep, iter.ReadString())
+			return
+		}
+		// Store raw JSON values (ie json.RawMessage fields)
+		c.raw = true
+		c.writeString(rep, string(iter.SkipAndReturnBytes()))
+		return
+	case glueschema.TypeBool:
+		if next == jsoniter.BoolValue {
+			c.writeBool(rep, iter.ReadBool())
+			return
+		}
+	case glueschema.TypeTimestamp:
+		if next == jsoniter.StringValue {
+			if tm, ok := parseTimestamp(iter.ReadString()); ok {
+				c.writeTimestamp(rep, tm)
+				return
+			}
+			n.writeNull(rep, n.def-1)
+			return
+		}
+	default:
+		if next == jsoniter.NumberValue || next == jsoniter.StringValue {
+			if n.writeNumber(rep, readNumber(iter, next)) {
+				return
+			}
+			n.writeNull(rep, n.def-1)
+			return
+		}
+	}
+	iter.Skip()
+	n.writeNull(rep, n.def-1)
+}
+
+func readNumber(iter *jsoniter.Iterator, next jsoniter.ValueType) string {
+	if next == jsoniter.StringValue {
+		return iter.ReadString()
+	}
+	return string(iter.ReadNumber())
+}
+
+func (n *node) writeNumber(rep int, s string) bool {
+	c := n.column
+	switch n.typ {
+	case glueschema.TypeTinyInt:
+		x, err := strconv.ParseInt(s, 10, 8)
+		if err != nil {
+			return false
+		}
+		c.writeInt32(rep, int32(x))
+	case glueschema.TypeSmallInt:
+		x, err := strconv.ParseInt(s, 10, 16)
+		if err != nil {
+			return false
+		}
+		c.writeInt32(rep, int32(x))
+	case glueschema.TypeInt:
+		x, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return false
+		}
+		c.writeInt32(rep, int32(x))
+	case glueschema.TypeBigInt:
+		x, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return false
+		}
+		c.writeInt64(rep, x)
+	case glueschema.TypeFloat:
+		f, err := strconv.ParseFloat(s, 32)
+		if err != nil {
+			return false
+		}
+		c.writeFloat(rep, float32(f))
+	case glueschema.TypeDouble:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return false
+		}
+		c.writeDouble(rep, f)
+	default:
+		return false
+	}
+	return true
+}
+
+func parseTimestamp(s string) (time.Time, bool) {
+	if tm, err := time.Parse(gluetimestamp.Layout, s); err == nil {
+		return tm, true
+	}
+	if tm, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return tm, true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,382 @@
+package glueparquet
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+	"github.com/xitongsys/parquet-go/types"
+
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue/glueschema"
+)
+
+func TestParseType(t *testing.T) {
+	for _, typ := range []string{
+		"string",
+		"array<bigint>",
+		"map<string,array<struct<a:int,b-c:double>>>",
+		"struct<a:struct<b:map<string,timestamp>>,c:boolean>",
+	} {
+		_, err := parseType(typ)
+		require.NoError(t, err, typ)
+	}
+	for _, typ := range []string{
+		"",
+		"decimal(10,2)",
+		"array<string",
+		"struct<a:int,a:int>",
+		"map<array<string>,int>",
+		"struct<a:int>>",
+	} {
+		_, err := parseType(typ)
+		require.Error(t, err, typ)
+	}
+}
+
+func TestWriter(t *testing.T) {
+	columns := []glueschema.Column{
+		{Name: "ts", Type: glueschema.TypeTimestamp},
+		{Name: "name", Type: glueschema.TypeString},
+		{Name: "tags", Type: glueschema.ArrayOf(glueschema.TypeString)},
+		{Name: "attrs", Type: glueschema.MapOf(glueschema.TypeString, glueschema.TypeBigInt)},
+		{Name: "nested", Type: "struct<a:int,b:array<struct<c:boolean>>>"},
+		{Name: "raw", Type: glueschema.TypeString},
+	}
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, columns)
+	require.NoError(t, err)
+	rows := []string{
+		`{"ts":"2020-01-01 00:00:00.000000000","name":"foo","tags":["a","b"],"attrs":{"x":1},"nested":{"a":1,"b":[{"c":true},{"c":null}]},"raw":{"k":"v"}}`,
+		`{"name":null,"tags":[],"nested":{"b":[]},"unknown":{"foo":[1,2]}}`,
+		`{"tags":["c",null],"attrs":{},"nested":{"a":"not a number"}}`,
+	}
+	for _, row := range rows {
+		require.NoError(t, w.WriteJSON([]byte(row)))
+	}
+	size := w.Size()
+	require.Error(t, w.WriteJSON([]byte(`{"name":"bar","tags":["d",`)))
+	require.Error(t, w.WriteJSON([]byte(`[]`)))
+	require.Equal(t, 3, w.NumRows())
+	require.Equal(t, size, w.Size())
+
+	require.NoError(t, w.Close())
+	require.Zero(t, w.Size())
+
+	f, err := buffer.NewBufferFile(buf.Bytes())
+	require.NoError(t, err)
+	r, err := reader.NewParquetColumnReader(f, 1)
+	require.NoError(t, err)
+	defer r.ReadStop()
+	require.Equal(t, int64(3), r.GetNumRows())
+	var names []string
+	for _, info := range r.SchemaHandler.Infos {
+		names = append(names, info.ExName)
+	}
+	require.Equal(t, []string{
+		"schema",
+		"ts",
+		"name",
+		"tags", "list", "element",
+		"attrs", "key_value", "key", "value",
+		"nested", "a", "b", "list", "element", "c",
+		"raw",
+	}, names)
+
+	type expectColumn struct {
+		reps []int32
+		defs []int32
+	}
+	expect := []expectColumn{
+		{reps: []int32{0, 0, 0}, defs: []int32{1, 0, 0}},
+		{reps: []int32{0, 0, 0}, defs: []int32{1, 0, 0}},
+		{reps: []int32{0, 1, 0, 0, 1}, defs: []int32{3, 3, 1, 3, 2}},
+		{reps: []int32{0, 0, 0}, defs: []int32{2, 0, 1}},
+		{reps: []int32{0, 0, 0}, defs: []int32{3, 0, 1}},
+		{reps: []int32{0, 0, 0}, defs: []int32{2, 1, 1}},
+		{reps: []int32{0, 1, 0, 0}, defs: []int32{5, 4, 2, 1}},
+		{reps: []int32{0, 0, 0}, defs: []int32{1, 0, 0}},
+	}
+	require.Len(t, r.SchemaHandler.ValueColumns, len(expect))
+	values := make([][]interface{}, len(expect))
+	for i, path := range r.SchemaHandler.ValueColumns {
+		var reps, defs []int32
+		values[i], reps, defs, err = r.ReadColumnByIndex(int64(i), int64(len(expect[i].defs)))
+		require.NoError(t, err, path)
+		require.Equal(t, expect[i].reps, reps, path)
+		require.Equal(t, expect[i].defs, defs, path)
+	}
+	require.Equal(t, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), decodeInt96(values[0][0].(string)))
+	require.Equal(t, []interface{}{"a", "b", nil, "c", nil}, values[2])
+	require.Equal(t, []interface{}{int64(1), nil, nil}, values[4])
+	require.Equal(t, []interface{}{true, nil, nil, nil}, values[6])
+	require.Equal(t, []interface{}{`{"k":"v"}`, nil, nil}, values[7])
+}
+
+// TestWriterReadBack reads the output of the writer with an independent Parquet implementation
+func TestWriterReadBack(t *testing.T) {
+	columns := []glueschema.Column{
+		{Name: "ts", Type: glueschema.TypeTimestamp},
+		{Name: "str", Type: glueschema.TypeString},
+		{Name: "bool", Type: glueschema.TypeBool},
+		{Name: "tiny", Type: glueschema.TypeTinyInt},
+		{Name: "small", Type: glueschema.TypeSmallInt},
+		{Name: "int", Type: glueschema.TypeInt},
+		{Name: "big", Type: glueschema.TypeBigInt},
+		{Name: "float", Type: glueschema.TypeFloat},
+		{Name: "double", Type: glueschema.TypeDouble},
+		{Name: "tags", Type: glueschema.ArrayOf(glueschema.TypeString)},
+		{Name: "attrs", Type: glueschema.MapOf(glueschema.TypeString, glueschema.TypeBigInt)},
+		{Name: "nested", Type: "struct<a:int,b:array<struct<c:boolean>>>"},
+	}
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, columns)
+	require.NoError(t, err)
+	rows := []string{
+		`{"ts":"2020-01-01 10:20:30.042000000","str":"foo","bool":true,"tiny":-8,"small":1600,"int":"32","big":6400000000,"float":1.5,"double":-2.25,"tags":["a","b"],"attrs":{"x":1,"y":2},"nested":{"a":1,"b":[{"c":true},{"c":false}]}}`,
+		`{"ts":"2020-01-01T10:20:30Z","bool":false,"tiny":1000,"tags":[],"attrs":{},"nested":{"b":[]}}`,
+		`{"str":null,"tags":["c",null],"nested":{"a":"not a number","b":[{}]}}`,
+	}
+	for _, row := range rows {
+		require.NoError(t, w.WriteJSON([]byte(row)))
+	}
+	require.NoError(t, w.Close())
+
+	f, err := buffer.NewBufferFile(buf.Bytes())
+	require.NoError(t, err)
+	r, err := reader.NewParquetColumnReader(f, 1)
+	require.NoError(t, err)
+	defer r.ReadStop()
+	require.Equal(t, int64(len(rows)), r.GetNumRows())
+
+	type expectColumn struct {
+		typ       parquet.Type
+		converted *parquet.ConvertedType
+		values    []interface{}
+		reps      []int32
+		defs      []int32
+	}
+	ts := time.Date(2020, 1, 1, 10, 20, 30, int(42*time.Millisecond), time.UTC)
+	expect := []expectColumn{
+		{
+			typ:    parquet.Type_INT96,
+			values: []interface{}{ts, ts.Truncate(time.Second), nil},
+			reps:   []int32{0, 0, 0},
+			defs:   []int32{1, 1, 0},
+		},
+		{
+			typ:       parquet.Type_BYTE_ARRAY,
+			converted: parquet.ConvertedTypePtr(parquet.ConvertedType_UTF8),
+			values:    []interface{}{"foo", nil, nil},
+			reps:      []int32{0, 0, 0},
+			defs:      []int32{1, 0, 0},
+		},
+		{
+			typ:    parquet.Type_BOOLEAN,
+			values: []interface{}{true, false, nil},
+			reps:   []int32{0, 0, 0},
+			defs:   []int32{1, 1, 0},
+		},
+		{
+			typ:       parquet.Type_INT32,
+			converted: parquet.ConvertedTypePtr(parquet.ConvertedType_INT_8),
+			values:    []interface{}{int32(-8), nil, nil},
+			reps:      []int32{0, 0, 0},
+			defs:      []int32{1, 0, 0},
+		},
+		{
+			typ:       parquet.Type_INT32,
+			converted: parquet.ConvertedTypePtr(parquet.ConvertedType_INT_16),
+			values:    []interface{}{int32(1600), nil, nil},
+			reps:      []int32{0, 0, 0},
+			defs:      []int32{1, 0, 0},
+		},
+		{
+			typ:    parquet.Type_INT32,
+			values: []interface{}{int32(32), nil, nil},
+			reps:   []int32{0, 0, 0},
+			defs:   []int32{1, 0, 0},
+		},
+		{
+			typ:    parquet.Type_INT64,
+			values: []interface{}{int64(6400000000), nil, nil},
+			reps:   []int32{0, 0, 0},
+			defs:   []int32{1, 0, 0},
+		},
+		{
+			typ:    parquet.Type_FLOAT,
+			values: []interface{}{float32(1.5), nil, nil},
+			reps:   []int32{0, 0, 0},
+			defs:   []int32{1, 0, 0},
+		},
+		{
+			typ:    parquet.Type_DOUBLE,
+			values: []interface{}{float64(-2.25), nil, nil},
+			reps:   []int32{0, 0, 0},
+			defs:   []int32{1, 0, 0},
+		},
+		{
+			typ:       parquet.Type_BYTE_ARRAY,
+			converted: parquet.ConvertedTypePtr(parquet.ConvertedType_UTF8),
+			values:    []interface{}{"a", "b", nil, "c", nil},
+			reps:      []int32{0, 1, 0, 0, 1},
+			defs:      []int32{3, 3, 1, 3, 2},
+		},
+		{
+			typ:       parquet.Type_BYTE_ARRAY,
+			converted: parquet.ConvertedTypePtr(parquet.ConvertedType_UTF8),
+			values:    []interface{}{"x", "y", nil, nil},
+			reps:      []int32{0, 1, 0, 0},
+			defs:      []int32{2, 2, 1, 0},
+		},
+		{
+			typ:    parquet.Type_INT64,
+			values: []interface{}{int64(1), int64(2), nil, nil},
+			reps:   []int32{0, 1, 0, 0},
+			defs:   []int32{3, 3, 1, 0},
+		},
+		{
+			typ:    parquet.Type_INT32,
+			values: []interface{}{int32(1), nil, nil},
+			reps:   []int32{0, 0, 0},
+			defs:   []int32{2, 1, 1},
+		},
+		{
+			typ:    parquet.Type_BOOLEAN,
+			values: []interface{}{true, false, nil, nil},
+			reps:   []int32{0, 1, 0, 0},
+			defs:   []int32{5, 5, 2, 4},
+		},
+	}
+	require.Len(t, r.SchemaHandler.ValueColumns, len(expect))
+	for i, path := range r.SchemaHandler.ValueColumns {
+		el := r.SchemaHandler.SchemaElements[r.SchemaHandler.MapIndex[path]]
+		require.Equal(t, expect[i].typ, el.GetType(), path)
+		require.Equal(t, expect[i].converted, el.ConvertedType, path)
+		values, reps, defs, err := r.ReadColumnByIndex(int64(i), int64(len(expect[i].values)))
+		require.NoError(t, err, path)
+		if expect[i].typ == parquet.Type_INT96 {
+			for j, v := range values {
+				if v != nil {
+					values[j] = types.INT96ToTime(v.(string)).UTC()
+				}
+			}
+		}
+		require.Equal(t, expect[i].values, values, path)
+		require.Equal(t, expect[i].reps, reps, path)
+		require.Equal(t, expect[i].defs, defs, path)
+	}
+	// Athena/Hive map keys must be required
+	key := r.SchemaHandler.SchemaElements[r.SchemaHandler.MapIndex[r.SchemaHandler.ValueColumns[10]]]
+	require.Equal(t, parquet.FieldRepetitionType_REQUIRED, key.GetRepetitionType())
+}
+
+func TestWriteTimestamp(t *testing.T) {
+	c := newColumn([]string{"ts"}, glueschema.TypeTimestamp, 1, 0)
+	tm := time.Date(1969, 12, 31, 23, 0, 0, 1, time.UTC)
+	c.writeTimestamp(0, tm)
+	expect := make([]byte, 12)
+	binary.LittleEndian.PutUint64(expect, uint64(23*time.Hour+1))
+	binary.LittleEndian.PutUint32(expect[8:], julianDayUnixEpoch-1)
+	require.Equal(t, []interface{}{string(expect)}, c.table.Values)
+	require.Equal(t, tm, decodeInt96(string(expect)))
+}
+
+func TestReader(t *testing.T) {
+	columns := []glueschema.Column{
+		{Name: "ts", Type: glueschema.TypeTimestamp},
+		{Name: "p_source_id", Type: glueschema.TypeString},
+		{Name: "n", Type: glueschema.TypeBigInt},
+		{Name: "ratio", Type: glueschema.TypeFloat},
+		{Name: "tags", Type: glueschema.ArrayOf(glueschema.TypeString)},
+		{Name: "attrs", Type: glueschema.MapOf(glueschema.TypeString, glueschema.TypeInt)},
+		{Name: "nested", Type: "struct<a:boolean,b:array<struct<c:double>>>"},
+		{Name: "raw", Type: glueschema.TypeString},
+	}
+	rows := []string{
+		`{"ts":"2020-01-01 10:20:30.000000001","p_source_id":"src","n":1,"ratio":0.5,"tags":["a",null],"attrs":{"y":2,"x":1},"nested":{"a":true,"b":[{"c":1.5},{}]},"raw":{"k":["v"]}}`,
+		`{"p_source_id":"[not json","tags":[],"attrs":{},"nested":{"b":[]},"raw":[1,2]}`,
+		`{"p_source_id":"{\"a\":1}","raw":"{\"a\":1}"}`,
+		`{}`,
+	}
+	expect := []string{
+		`{"ts":"2020-01-01 10:20:30.000000001","p_source_id":"src","n":1,"ratio":0.5,"tags":["a",null],"attrs":{"x":1,"y":2},"nested":{"a":true,"b":[{"c":1.5},{}]},"raw":{"k":["v"]}}`,
+		`{"p_source_id":"[not json","nested":{},"raw":[1,2]}`,
+		// Only the columns that stored raw JSON values are decoded
+		`{"p_source_id":"{\"a\":1}","raw":{"a":1}}`,
+		`{}`,
+	}
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, columns)
+	require.NoError(t, err)
+	for _, row := range rows {
+		require.NoError(t, w.WriteJSON([]byte(row)))
+	}
+	require.NoError(t, w.Close())
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	defer r.Close()
+	var actual []string
+	for row := r.Next(); row != nil; row = r.Next() {
+		actual = append(actual, string(row))
+	}
+	require.NoError(t, r.Err())
+	require.Equal(t, expect, actual)
+}
+
+func TestReaderPages(t *testing.T) {
+	columns := []glueschema.Column{
+		{Name: "id", Type: glueschema.TypeBigInt},
+		{Name: "line", Type: glueschema.TypeString},
+	}
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, columns)
+	require.NoError(t, err)
+	// Write enough rows to flush multiple pages and read multiple batches
+	const numRows = 3 * readBatchSize
+	for i := 0; i < numRows; i++ {
+		require.NoError(t, w.WriteJSON([]byte(fmt.Sprintf(`{"id":%d,"line":"line %d"}`, i, i))))
+	}
+	require.NoError(t, w.Close())
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	defer r.Close()
+	n := 0
+	for row := r.Next(); row != nil; row = r.Next() {
+		require.Equal(t, fmt.Sprintf(`{"id":%d,"line":"line %d"}`, n, n), string(row))
+		n++
+	}
+	require.NoError(t, r.Err())
+	require.Equal(t, numRows, n)
+}
+
+func TestReaderInvalid(t *testing.T) {
+	data := []byte("not a parquet file")
+	_, err := NewReader(bytes.NewReader(data), int64(len(data)))
+	require.Error(t, err)
+}
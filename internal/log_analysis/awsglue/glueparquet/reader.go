@@ -0,0 +1,423 @@
+package glueparquet
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"io"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+	"github.com/xitongsys/parquet-go/schema"
+	"github.com/xitongsys/parquet-go/source"
+
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue/glueschema"
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue/gluetimestamp"
+)
+
+// readBatchSize is the number of rows decoded at a time
+const readBatchSize = 1024
+
+// Reader reads the rows of a Parquet file written by Writer as JSON objects.
+//
+// Null fields and empty lists or maps are omitted and timestamps use the gluetimestamp layout.
+// Values of the columns listed in the RawJSONColumnsKey file metadata are written as raw JSON
+// if they are valid JSON objects or arrays.
+// Reader implements the logstream.Stream interface.
+type Reader struct {
+	pr      *reader.ParquetReader
+	root    *node
+	stream  *jsoniter.Stream
+	rows    []interface{}
+	numRows int64
+	err     error
+}
+
+// NewReader reads the schema of a Parquet file of the provided size.
+func NewReader(r io.ReaderAt, size int64) (*Reader, error) {
+	file := &readerAtFile{
+		SectionReader: io.NewSectionReader(r, 0, size),
+	}
+	pr, err := reader.NewParquetReader(file, nil, 1)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read parquet file metadata")
+	}
+	root, err := readSchema(pr.SchemaHandler, rawJSONColumns(pr.Footer))
+	if err != nil {
+		pr.ReadStop()
+		return nil, err
+	}
+	return &Reader{
+		pr:      pr,
+		root:    root,
+		stream:  jsoniter.NewStream(jsoniter.ConfigDefault, nil, 4096),
+		numRows: pr.GetNumRows(),
+	}, nil
+}
+
+// Next returns the next row as a JSON object or nil if there are no more rows.
+// The slice returned is stable until the next call to Next.
+func (r *Reader) Next() []byte {
+	for len(r.rows) == 0 {
+		if r.err != nil || r.numRows <= 0 {
+			return nil
+		}
+		r.readRows()
+	}
+	row := reflect.ValueOf(r.rows[0])
+	r.rows[0] = nil
+	r.rows = r.rows[1:]
+
+	stream := r.stream
+	stream.SetBuffer(stream.Buffer()[:0])
+	r.root.readFields(stream, row)
+	if stream.Error != nil {
+		r.err = errors.Wrap(stream.Error, "failed to encode parquet row")
+		return nil
+	}
+	return stream.Buffer()
+}
+
+func (r *Reader) readRows() {
+	// The parquet-go reader panics on malformed files
+	defer func() {
+		if p := recover(); p != nil {
+			r.err = errors.Errorf("failed to read parquet rows: %v", p)
+		}
+	}()
+	n := int64(readBatchSize)
+	if n > r.numRows {
+		n = r.numRows
+	}
+	rows, err := r.pr.ReadByNumber(int(n))
+	if err != nil {
+		r.err = errors.Wrap(err, "failed to read parquet rows")
+		return
+	}
+	if len(rows) == 0 {
+		r.err = errors.New("parquet file has less rows than its metadata")
+		return
+	}
+	r.rows = rows
+	r.numRows -= int64(len(rows))
+}
+
+// Err returns the first error encountered while reading rows
+func (r *Reader) Err() error {
+	return r.err
+}
+
+// Close releases the column readers
+func (r *Reader) Close() error {
+	r.pr.ReadStop()
+	return nil
+}
+
+// rawJSONColumns returns the paths of the columns that store raw JSON values
+func rawJSONColumns(footer *parquet.FileMetaData) map[string]bool {
+	for _, kv := range footer.GetKeyValueMetadata() {
+		if kv.GetKey() != RawJSONColumnsKey {
+			continue
+		}
+		var paths []string
+		if err := jsoniter.UnmarshalFromString(kv.GetValue(), &paths); err != nil {
+			return nil
+		}
+		raw := make(map[string]bool, len(paths))
+		for _, p := range paths {
+			raw[p] = true
+		}
+		return raw
+	}
+	return nil
+}
+
+// schemaReader reads the schema elements along with the paths of the raw JSON columns
+type schemaReader struct {
+	sh  *schema.SchemaHandler
+	raw map[string]bool
+}
+
+// readSchema builds the schema tree from the metadata of a Parquet file
+func readSchema(sh *schema.SchemaHandler, raw map[string]bool) (*node, error) {
+	if len(sh.SchemaElements) == 0 {
+		return nil, errors.New("empty parquet schema")
+	}
+	r := schemaReader{
+		sh:  sh,
+		raw: raw,
+	}
+	root, _, err := r.readElement(nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	if root.kind != kindStruct {
+		return nil, errors.New("invalid parquet schema root")
+	}
+	return root, nil
+}
+
+// readElement reads the node of the schema element at index i and returns the index of the next sibling element
+func (r *schemaReader) readElement(path []string, i int) (*node, int, error) {
+	sh := r.sh
+	el := sh.SchemaElements[i]
+	n := &node{
+		name:     sh.Infos[i].ExName,
+		required: el.GetRepetitionType() != parquet.FieldRepetitionType_OPTIONAL,
+	}
+	if el.GetRepetitionType() == parquet.FieldRepetitionType_REPEATED && i != 0 {
+		return nil, 0, errors.Errorf("unsupported repeated field %q", n.name)
+	}
+	// The root element is not part of the column paths
+	if i != 0 {
+		path = append(path[:len(path):len(path)], n.name)
+	}
+	next := i + 1
+	if el.GetNumChildren() == 0 {
+		typ, err := readScalarType(el)
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, "invalid field %q", n.name)
+		}
+		n.kind, n.typ = kindScalar, typ
+		n.raw = typ == glueschema.TypeString && r.raw[strings.Join(path, ".")]
+		return n, next, nil
+	}
+	switch el.GetConvertedType() {
+	case parquet.ConvertedType_LIST:
+		elems, end, err := r.readGroup(path, next, "list", "element")
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, "invalid list %q", n.name)
+		}
+		n.kind, n.elem = kindList, elems[0]
+		return n, end, nil
+	case parquet.ConvertedType_MAP:
+		entries, end, err := r.readGroup(path, next, "key_value", "key", "value")
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, "invalid map %q", n.name)
+		}
+		n.kind, n.key, n.value = kindMap, entries[0], entries[1]
+		if n.key.kind != kindScalar || n.key.typ != glueschema.TypeString {
+			return nil, 0, errors.Errorf("unsupported key type for map %q", n.name)
+		}
+		return n, end, nil
+	}
+	n.kind = kindStruct
+	for j := int32(0); j < el.GetNumChildren(); j++ {
+		field, end, err := r.readElement(path, next)
+		if err != nil {
+			return nil, 0, err
+		}
+		n.fields = append(n.fields, field)
+		next = end
+	}
+	return n, next, nil
+}
+
+// readGroup reads the nodes of the repeated group of a list or a map
+func (r *schemaReader) readGroup(path []string, i int, name string, fields ...string) ([]*node, int, error) {
+	sh := r.sh
+	el := sh.SchemaElements[i]
+	if sh.Infos[i].ExName != name || el.GetRepetitionType() != parquet.FieldRepetitionType_REPEATED ||
+		int(el.GetNumChildren()) != len(fields) {
+		return nil, 0, errors.Errorf("expected a repeated group %q", name)
+	}
+	path = append(path[:len(path):len(path)], name)
+	next := i + 1
+	nodes := make([]*node, len(fields))
+	for j := range fields {
+		if sh.Infos[next].ExName != fields[j] {
+			return nil, 0, errors.Errorf("expected field %q", fields[j])
+		}
+		child, end, err := r.readElement(path, next)
+		if err != nil {
+			return nil, 0, err
+		}
+		nodes[j] = child
+		next = end
+	}
+	return nodes, next, nil
+}
+
+// readScalarType maps the Parquet types written by scalarTypes back to Glue types
+func readScalarType(el *parquet.SchemaElement) (glueschema.Type, error) {
+	switch el.GetType() {
+	case parquet.Type_BYTE_ARRAY:
+		return glueschema.TypeString, nil
+	case parquet.Type_BOOLEAN:
+		return glueschema.TypeBool, nil
+	case parquet.Type_INT96:
+		return glueschema.TypeTimestamp, nil
+	case parquet.Type_INT32:
+		switch el.GetConvertedType() {
+		case parquet.ConvertedType_INT_8:
+			return glueschema.TypeTinyInt, nil
+		case parquet.ConvertedType_INT_16:
+			return glueschema.TypeSmallInt, nil
+		}
+		return glueschema.TypeInt, nil
+	case parquet.Type_INT64:
+		return glueschema.TypeBigInt, nil
+	case parquet.Type_FLOAT:
+		return glueschema.TypeFloat, nil
+	case parquet.Type_DOUBLE:
+		return glueschema.TypeDouble, nil
+	default:
+		return "", errors.Errorf("unsupported parquet type %s", el.GetType())
+	}
+}
+
+// isEmpty checks if a value read by parquet-go is omitted from the JSON output
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr:
+		return v.IsNil()
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	default:
+		return false
+	}
+}
+
+// readFields writes the fields of a struct value read by parquet-go as a JSON object.
+// The struct fields are in the same order as the schema fields.
+func (n *node) readFields(stream *jsoniter.Stream, v reflect.Value) {
+	stream.WriteObjectStart()
+	more := false
+	for i, field := range n.fields {
+		fv := v.Field(i)
+		if isEmpty(fv) {
+			continue
+		}
+		if more {
+			stream.WriteMore()
+		}
+		stream.WriteObjectField(field.name)
+		field.readValue(stream, fv)
+		more = true
+	}
+	stream.WriteObjectEnd()
+}
+
+// readValue writes a value read by parquet-go as JSON
+func (n *node) readValue(stream *jsoniter.Stream, v reflect.Value) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			stream.WriteNil()
+			return
+		}
+		v = v.Elem()
+	}
+	switch n.kind {
+	case kindStruct:
+		n.readFields(stream, v)
+	case kindList:
+		stream.WriteArrayStart()
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				stream.WriteMore()
+			}
+			n.elem.readValue(stream, v.Index(i))
+		}
+		stream.WriteArrayEnd()
+	case kindMap:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return keys[i].String() < keys[j].String()
+		})
+		stream.WriteObjectStart()
+		for i, key := range keys {
+			if i > 0 {
+				stream.WriteMore()
+			}
+			stream.WriteObjectField(key.String())
+			n.value.readValue(stream, v.MapIndex(key))
+		}
+		stream.WriteObjectEnd()
+	default:
+		n.readScalar(stream, v)
+	}
+}
+
+func (n *node) readScalar(stream *jsoniter.Stream, v reflect.Value) {
+	switch n.typ {
+	case glueschema.TypeString:
+		s := v.String()
+		if n.raw && isRawJSON(s) {
+			stream.WriteRaw(s)
+			return
+		}
+		stream.WriteString(s)
+	case glueschema.TypeBool:
+		stream.WriteBool(v.Bool())
+	case glueschema.TypeTimestamp:
+		stream.WriteString(decodeInt96(v.String()).Format(gluetimestamp.Layout))
+	case glueschema.TypeFloat, glueschema.TypeDouble:
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			stream.WriteNil()
+			return
+		}
+		if n.typ == glueschema.TypeFloat {
+			stream.WriteFloat32(float32(f))
+			return
+		}
+		stream.WriteFloat64(f)
+	default:
+		stream.WriteInt64(v.Int())
+	}
+}
+
+// isRawJSON checks if a value of a raw JSON column was stored from a JSON object or array
+func isRawJSON(s string) bool {
+	if s == "" || (s[0] != '{' && s[0] != '[') {
+		return false
+	}
+	return jsoniter.ConfigDefault.Valid([]byte(s))
+}
+
+// readerAtFile adapts an io.ReaderAt to the read-only file interface of the parquet-go reader
+type readerAtFile struct {
+	*io.SectionReader
+}
+
+var _ source.ParquetFile = (*readerAtFile)(nil)
+
+// Open returns an independent reader for the file, the parquet-go reader uses one per column
+func (f *readerAtFile) Open(_ string) (source.ParquetFile, error) {
+	return &readerAtFile{
+		SectionReader: io.NewSectionReader(f.SectionReader, 0, f.Size()),
+	}, nil
+}
+
+func (f *readerAtFile) Create(_ string) (source.ParquetFile, error) {
+	return nil, errors.New("parquet file is read-only")
+}
+
+func (f *readerAtFile) Write(_ []byte) (int, error) {
+	return 0, errors.New("parquet file is read-only")
+}
+
+func (f *readerAtFile) Close() error {
+	return nil
+}
@@ -0,0 +1,378 @@
+package glueparquet
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/xitongsys/parquet-go/layout"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/schema"
+
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue/glueschema"
+)
+
+type nodeKind int
+
+const (
+	kindScalar nodeKind = iota
+	kindStruct
+	kindList
+	kindMap
+)
+
+// node is a field in the Parquet schema tree
+type node struct {
+	name     string
+	kind     nodeKind
+	typ      glueschema.Type
+	required bool
+	// def is the definition level when the node value is present
+	def int
+	// rep is the repetition level for the node values
+	rep int
+	// struct fields
+	fields []*node
+	index  map[string]int
+	// seen marks the fields set while shredding an object
+	seen []bool
+	// list element
+	elem *node
+	// map key and value
+	key   *node
+	value *node
+	// column is set for scalar nodes
+	column *column
+	// columns are all the leaf columns under the node
+	columns []*column
+	// raw is set when reading string values that are stored from raw JSON values
+	raw bool
+}
+
+// newSchema builds the Parquet schema tree for Glue table columns
+func newSchema(columns []glueschema.Column) (*node, error) {
+	root := &node{
+		name:     "schema",
+		kind:     kindStruct,
+		required: true,
+		index:    make(map[string]int, len(columns)),
+	}
+	for i := range columns {
+		col := &columns[i]
+		field, err := parseType(string(col.Type))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid type for column %q", col.Name)
+		}
+		if err := root.addField(col.Name, field); err != nil {
+			return nil, err
+		}
+	}
+	for _, field := range root.fields {
+		field.init(nil, root.def, root.rep)
+		root.columns = append(root.columns, field.columns...)
+	}
+	return root, nil
+}
+
+func (n *node) addField(name string, field *node) error {
+	if _, duplicate := n.index[name]; duplicate {
+		return errors.Errorf("duplicate field %q", name)
+	}
+	field.name = name
+	n.index[name] = len(n.fields)
+	n.fields = append(n.fields, field)
+	n.seen = append(n.seen, false)
+	return nil
+}
+
+// init sets the levels of a node and creates the leaf columns
+func (n *node) init(path []string, def, rep int) {
+	if !n.required {
+		def++
+	}
+	n.def, n.rep = def, rep
+	path = append(path[:len(path):len(path)], n.name)
+	switch n.kind {
+	case kindStruct:
+		for _, field := range n.fields {
+			field.init(path, def, rep)
+			n.columns = append(n.columns, field.columns...)
+		}
+	case kindList:
+		// The repeated group adds a definition and a repetition level
+		n.elem.init(append(path, "list"), def+1, rep+1)
+		n.columns = n.elem.columns
+	case kindMap:
+		path = append(path, "key_value")
+		n.key.init(path, def+1, rep+1)
+		n.value.init(path, def+1, rep+1)
+		n.columns = append(n.key.columns, n.value.columns...)
+	default:
+		n.column = newColumn(path, n.typ, def, rep)
+		n.columns = []*column{n.column}
+	}
+}
+
+// appendElements appends the schema elements of the node in depth-first order as required in the file metadata
+func (n *node) appendElements(elements []*parquet.SchemaElement) []*parquet.SchemaElement {
+	repetition := parquet.FieldRepetitionType_OPTIONAL
+	if n.required {
+		repetition = parquet.FieldRepetitionType_REQUIRED
+	}
+	switch n.kind {
+	case kindStruct:
+		elements = append(elements, &parquet.SchemaElement{
+			Name:           n.name,
+			RepetitionType: &repetition,
+			NumChildren:    numChildren(len(n.fields)),
+		})
+		for _, field := range n.fields {
+			elements = field.appendElements(elements)
+		}
+		return elements
+	case kindList:
+		elements = append(elements, &parquet.SchemaElement{
+			Name:           n.name,
+			RepetitionType: &repetition,
+			NumChildren:    numChildren(1),
+			ConvertedType:  parquet.ConvertedTypePtr(parquet.ConvertedType_LIST),
+		}, &parquet.SchemaElement{
+			Name:           "list",
+			RepetitionType: parquet.FieldRepetitionTypePtr(parquet.FieldRepetitionType_REPEATED),
+			NumChildren:    numChildren(1),
+		})
+		return n.elem.appendElements(elements)
+	case kindMap:
+		elements = append(elements, &parquet.SchemaElement{
+			Name:           n.name,
+			RepetitionType: &repetition,
+			NumChildren:    numChildren(1),
+			ConvertedType:  parquet.ConvertedTypePtr(parquet.ConvertedType_MAP),
+		}, &parquet.SchemaElement{
+			Name:           "key_value",
+			RepetitionType: parquet.FieldRepetitionTypePtr(parquet.FieldRepetitionType_REPEATED),
+			NumChildren:    numChildren(2),
+			ConvertedType:  parquet.ConvertedTypePtr(parquet.ConvertedType_MAP_KEY_VALUE),
+		})
+		elements = n.key.appendElements(elements)
+		return n.value.appendElements(elements)
+	default:
+		physical, converted := scalarTypes(n.typ)
+		return append(elements, &parquet.SchemaElement{
+			Name:           n.name,
+			Type:           &physical,
+			RepetitionType: &repetition,
+			ConvertedType:  converted,
+		})
+	}
+}
+
+func numChildren(n int) *int32 {
+	x := int32(n)
+	return &x
+}
+
+// initTables binds the leaf columns to the schema handler of the parquet writer
+func (n *node) initTables(sh *schema.SchemaHandler) error {
+	columns := n.columns
+	for i, el := range sh.SchemaElements {
+		if el.GetNumChildren() > 0 {
+			continue
+		}
+		if len(columns) == 0 {
+			return errors.New("schema has more leaf elements than columns")
+		}
+		if err := columns[0].initTable(sh, i); err != nil {
+			return err
+		}
+		columns = columns[1:]
+	}
+	if len(columns) != 0 {
+		return errors.New("schema has less leaf elements than columns")
+	}
+	return nil
+}
+
+// flushTables returns the values of all leaf columns to be encoded by the parquet writer
+func (n *node) flushTables() map[string]*layout.Table {
+	tables := make(map[string]*layout.Table, len(n.columns))
+	for _, c := range n.columns {
+		tables[c.key] = c.flushTable()
+	}
+	return tables
+}
+
+// scalarTypes maps Glue scalar types to Parquet physical and converted types.
+// Timestamps are stored as INT96 values as this is the representation supported by all Hive/Presto versions.
+func scalarTypes(typ glueschema.Type) (parquet.Type, *parquet.ConvertedType) {
+	switch typ {
+	case glueschema.TypeString:
+		return parquet.Type_BYTE_ARRAY, parquet.ConvertedTypePtr(parquet.ConvertedType_UTF8)
+	case glueschema.TypeBool:
+		return parquet.Type_BOOLEAN, nil
+	case glueschema.TypeTimestamp:
+		return parquet.Type_INT96, nil
+	case glueschema.TypeTinyInt:
+		return parquet.Type_INT32, parquet.ConvertedTypePtr(parquet.ConvertedType_INT_8)
+	case glueschema.TypeSmallInt:
+		return parquet.Type_INT32, parquet.ConvertedTypePtr(parquet.ConvertedType_INT_16)
+	case glueschema.TypeInt:
+		return parquet.Type_INT32, nil
+	case glueschema.TypeBigInt:
+		return parquet.Type_INT64, nil
+	case glueschema.TypeFloat:
+		return parquet.Type_FLOAT, nil
+	case glueschema.TypeDouble:
+		return parquet.Type_DOUBLE, nil
+	default:
+		panic("unsupported scalar type " + typ)
+	}
+}
+
+// parseType parses a Glue type expression to a schema node
+func parseType(typ string) (*node, error) {
+	p := typeParser{input: typ}
+	n, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.input) {
+		return nil, p.errorf("unexpected input")
+	}
+	return n, nil
+}
+
+type typeParser struct {
+	input string
+	pos   int
+}
+
+func (p *typeParser) errorf(format string, args ...interface{}) error {
+	return errors.Errorf("failed to parse type %q at %d: %s", p.input, p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *typeParser) consume(prefix string) bool {
+	if strings.HasPrefix(p.input[p.pos:], prefix) {
+		p.pos += len(prefix)
+		return true
+	}
+	return false
+}
+
+func (p *typeParser) expect(prefix string) error {
+	if !p.consume(prefix) {
+		return p.errorf("expected %q", prefix)
+	}
+	return nil
+}
+
+// name reads input up to the next delimiter
+func (p *typeParser) name() string {
+	start := p.pos
+	for p.pos < len(p.input) && strings.IndexByte(":,<>", p.input[p.pos]) == -1 {
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+func (p *typeParser) parse() (*node, error) {
+	switch {
+	case p.consume("struct<"):
+		n := &node{
+			kind:  kindStruct,
+			index: make(map[string]int),
+		}
+		for !p.consume(">") {
+			if len(n.fields) > 0 {
+				if err := p.expect(","); err != nil {
+					return nil, err
+				}
+			}
+			name := p.name()
+			if name == "" {
+				return nil, p.errorf("empty field name")
+			}
+			if err := p.expect(":"); err != nil {
+				return nil, err
+			}
+			field, err := p.parse()
+			if err != nil {
+				return nil, err
+			}
+			if err := n.addField(name, field); err != nil {
+				return nil, p.errorf("%s", err)
+			}
+		}
+		return n, nil
+	case p.consume("array<"):
+		elem, err := p.parse()
+		if err != nil {
+			return nil, err
+		}
+		elem.name = "element"
+		if err := p.expect(">"); err != nil {
+			return nil, err
+		}
+		return &node{
+			kind: kindList,
+			elem: elem,
+		}, nil
+	case p.consume("map<"):
+		key, err := p.parse()
+		if err != nil {
+			return nil, err
+		}
+		if key.kind != kindScalar {
+			return nil, p.errorf("invalid map key type")
+		}
+		key.name = "key"
+		key.required = true
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+		value, err := p.parse()
+		if err != nil {
+			return nil, err
+		}
+		value.name = "value"
+		if err := p.expect(">"); err != nil {
+			return nil, err
+		}
+		return &node{
+			kind:  kindMap,
+			key:   key,
+			value: value,
+		}, nil
+	default:
+		typ := glueschema.Type(p.name())
+		switch typ {
+		case glueschema.TypeString, glueschema.TypeBool, glueschema.TypeTimestamp,
+			glueschema.TypeTinyInt, glueschema.TypeSmallInt, glueschema.TypeInt, glueschema.TypeBigInt,
+			glueschema.TypeFloat, glueschema.TypeDouble:
+			return &node{
+				kind: kindScalar,
+				typ:  typ,
+			}, nil
+		default:
+			return nil, p.errorf("unsupported type %q", typ)
+		}
+	}
+}
@@ -82,7 +82,7 @@ type PartitionColumnInfo struct {
 
 // Gets the partition from S3bucket and S3 object key info.
 // The s3Object key is expected to be in the the format
-// `{logs,rules}/{table_name}/year=d{4}/month=d{2}/[day=d{2}/][hour=d{2}/]/{S+}.json.gz` otherwise an error is returned.
+// `{logs,rules}/{table_name}/year=d{4}/month=d{2}/[day=d{2}/][hour=d{2}/]/{S+}.{json.gz,parquet}` otherwise an error is returned.
 func PartitionFromS3Object(s3Bucket, s3ObjectKey string) (*GluePartition, error) {
 	partition := &GluePartition{s3Bucket: s3Bucket}
 
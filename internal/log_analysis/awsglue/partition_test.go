@@ -129,7 +129,7 @@ func TestCreatePartitionLog(t *testing.T) {
 	mockClient.On("GetTable", mock.Anything).Return(testGetTableOutput, nil).Once()
 	mockClient.On("CreatePartition", mock.Anything).Return(&glue.CreatePartitionOutput{}, nil).Once()
 
-	created, err := partition.GetGlueTableMetadata().CreateJSONPartition(mockClient, partition.GetTime())
+	created, err := partition.GetGlueTableMetadata().CreatePartition(mockClient, partition.GetTime())
 	assert.NoError(t, err)
 	assert.True(t, created)
 	mockClient.AssertExpectations(t)
@@ -144,7 +144,7 @@ func TestCreateParitionRule(t *testing.T) {
 	mockClient.On("GetTable", mock.Anything).Return(testGetTableOutput, nil).Once()
 	mockClient.On("CreatePartition", mock.Anything).Return(&glue.CreatePartitionOutput{}, nil).Once()
 
-	created, err := partition.GetGlueTableMetadata().CreateJSONPartition(mockClient, partition.GetTime())
+	created, err := partition.GetGlueTableMetadata().CreatePartition(mockClient, partition.GetTime())
 	assert.NoError(t, err)
 	assert.True(t, created)
 	mockClient.AssertExpectations(t)
@@ -160,7 +160,7 @@ func TestCreatePartitionPartitionAlreadExists(t *testing.T) {
 	mockClient.On("CreatePartition", mock.Anything).
 		Return(&glue.CreatePartitionOutput{}, awserr.New(glue.ErrCodeAlreadyExistsException, "error", nil)).Once()
 
-	created, err := partition.GetGlueTableMetadata().CreateJSONPartition(mockClient, partition.GetTime())
+	created, err := partition.GetGlueTableMetadata().CreatePartition(mockClient, partition.GetTime())
 	assert.NoError(t, err)
 	assert.False(t, created)
 	mockClient.AssertExpectations(t)
@@ -176,7 +176,7 @@ func TestCreatePartitionAwsError(t *testing.T) {
 	mockClient.On("CreatePartition", mock.Anything).
 		Return(&glue.CreatePartitionOutput{}, awserr.New(glue.ErrCodeInternalServiceException, "error", nil)).Once()
 
-	created, err := partition.GetGlueTableMetadata().CreateJSONPartition(mockClient, partition.GetTime())
+	created, err := partition.GetGlueTableMetadata().CreatePartition(mockClient, partition.GetTime())
 	assert.Error(t, err)
 	assert.False(t, created)
 	mockClient.AssertExpectations(t)
@@ -191,7 +191,7 @@ func TestCreatePartitionGeneralError(t *testing.T) {
 	mockClient.On("GetTable", mock.Anything).Return(testGetTableOutput, nil).Once()
 	mockClient.On("CreatePartition", mock.Anything).Return(&glue.CreatePartitionOutput{}, errors.New("error")).Once()
 
-	created, err := partition.GetGlueTableMetadata().CreateJSONPartition(mockClient, partition.GetTime())
+	created, err := partition.GetGlueTableMetadata().CreatePartition(mockClient, partition.GetTime())
 	assert.Error(t, err)
 	assert.False(t, created)
 	mockClient.AssertExpectations(t)
@@ -21,6 +21,7 @@ package awsglue
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -38,6 +39,28 @@ import (
 	"github.com/panther-labs/panther/pkg/box"
 )
 
+// DataFormat is the format of the data files of a table
+type DataFormat string
+
+const (
+	// DataFormatJSON stores gzipped JSON lines files
+	DataFormatJSON DataFormat = "json"
+	// DataFormatParquet stores Parquet files
+	DataFormatParquet DataFormat = "parquet"
+)
+
+// ParseDataFormat parses a data format name, an empty name defaults to JSON
+func ParseDataFormat(name string) (DataFormat, error) {
+	switch format := DataFormat(strings.ToLower(name)); format {
+	case "", DataFormatJSON:
+		return DataFormatJSON, nil
+	case DataFormatParquet:
+		return DataFormatParquet, nil
+	default:
+		return "", errors.Errorf("invalid data format %q", name)
+	}
+}
+
 type PartitionKey struct {
 	Name string
 	Type string
@@ -51,6 +74,7 @@ type GlueTableMetadata struct {
 	prefix       string
 	timebin      GlueTableTimebin // at what time resolution is this table partitioned
 	eventStruct  interface{}
+	format       DataFormat
 }
 
 // Creates a new GlueTableMetadata object for Panther log sources
@@ -65,9 +89,17 @@ func NewGlueTableMetadata(
 		timebin:      timebin,
 		prefix:       tablePrefix,
 		eventStruct:  eventStruct,
+		format:       DataFormatJSON,
 	}
 }
 
+// WithFormat returns a copy of the table metadata using a different data format
+func (gm *GlueTableMetadata) WithFormat(format DataFormat) *GlueTableMetadata {
+	tbl := *gm
+	tbl.format = format
+	return &tbl
+}
+
 func (gm *GlueTableMetadata) DatabaseName() string {
 	return gm.databaseName
 }
@@ -93,6 +125,11 @@ func (gm *GlueTableMetadata) EventStruct() interface{} {
 	return gm.eventStruct
 }
 
+// Format is the format of the data files of the table
+func (gm *GlueTableMetadata) Format() DataFormat {
+	return gm.format
+}
+
 func (gm *GlueTableMetadata) HasPartitions(glueClient glueiface.GlueAPI) (bool, error) {
 	return TableHasPartitions(glueClient, gm.databaseName, gm.tableName)
 }
@@ -119,6 +156,7 @@ func (gm *GlueTableMetadata) RuleTable() *GlueTableMetadata {
 		return gm
 	}
 	// the corresponding rule table shares the same structure as the log table + some columns
+	// rule matches are always written as JSON by the rules engine
 	return NewGlueTableMetadata(pantherdb.RuleMatchDatabase, gm.tableName, gm.Description(), GlueTableHourly, gm.EventStruct())
 }
 
@@ -163,6 +201,32 @@ func (gm *GlueTableMetadata) glueTableInput(bucketName string) (*glue.TableInput
 		}
 	}
 
+	return &glue.TableInput{
+		Name:              &gm.tableName,
+		Description:       &gm.description,
+		PartitionKeys:     partitionColumns,
+		StorageDescriptor: gm.storageDescriptor(glueColumns, mappings, bucketName),
+		TableType:         aws.String("EXTERNAL_TABLE"),
+	}, nil
+}
+
+func (gm *GlueTableMetadata) storageDescriptor(columns []*glue.Column, mappings map[string]string, bucketName string) *glue.StorageDescriptor {
+	location := aws.String("s3://" + bucketName + "/" + gm.prefix)
+	if gm.format == DataFormatParquet {
+		return &glue.StorageDescriptor{ // configure as Parquet
+			Columns:      columns,
+			Location:     location,
+			InputFormat:  aws.String("org.apache.hadoop.hive.ql.io.parquet.MapredParquetInputFormat"),
+			OutputFormat: aws.String("org.apache.hadoop.hive.ql.io.parquet.MapredParquetOutputFormat"),
+			SerdeInfo: &glue.SerDeInfo{
+				SerializationLibrary: aws.String("org.apache.hadoop.hive.ql.io.parquet.serde.ParquetHiveSerDe"),
+				Parameters: map[string]*string{
+					"serialization.format": aws.String("1"),
+				},
+			},
+		}
+	}
+
 	// Need to be case sensitive to deal with columns that have same name but different casing
 	// https://github.com/rcongiu/Hive-JSON-Serde#case-sensitivity-in-mappings
 	descriptorParameters := map[string]*string{
@@ -176,22 +240,16 @@ func (gm *GlueTableMetadata) glueTableInput(bucketName string) (*glue.TableInput
 		descriptorParameters[fmt.Sprintf("mapping.%s", from)] = &to
 	}
 
-	return &glue.TableInput{
-		Name:          &gm.tableName,
-		Description:   &gm.description,
-		PartitionKeys: partitionColumns,
-		StorageDescriptor: &glue.StorageDescriptor{ // configure as JSON
-			Columns:      glueColumns,
-			Location:     aws.String("s3://" + bucketName + "/" + gm.prefix),
-			InputFormat:  aws.String("org.apache.hadoop.mapred.TextInputFormat"),
-			OutputFormat: aws.String("org.apache.hadoop.hive.ql.io.HiveIgnoreKeyTextOutputFormat"),
-			SerdeInfo: &glue.SerDeInfo{
-				SerializationLibrary: aws.String("org.openx.data.jsonserde.JsonSerDe"),
-				Parameters:           descriptorParameters,
-			},
+	return &glue.StorageDescriptor{ // configure as JSON
+		Columns:      columns,
+		Location:     location,
+		InputFormat:  aws.String("org.apache.hadoop.mapred.TextInputFormat"),
+		OutputFormat: aws.String("org.apache.hadoop.hive.ql.io.HiveIgnoreKeyTextOutputFormat"),
+		SerdeInfo: &glue.SerDeInfo{
+			SerializationLibrary: aws.String("org.openx.data.jsonserde.JsonSerDe"),
+			Parameters:           descriptorParameters,
 		},
-		TableType: aws.String("EXTERNAL_TABLE"),
-	}, nil
+	}
 }
 
 func (gm *GlueTableMetadata) UpdateTableIfExists(ctx context.Context, glueAPI glueiface.GlueAPI, bucketName string) (bool, error) {
@@ -387,7 +445,8 @@ func (gm *GlueTableMetadata) SyncPartitions(glueClient glueiface.GlueAPI, s3Clie
 				storageDescriptor := *getPartitionOutput.Partition.StorageDescriptor // copy because we will mutate
 				storageDescriptor.Columns = columns
 				// we need to update the SerDeInfo for JSON partitions to get the column mappings
-				if IsJSONPartition(&storageDescriptor) {
+				// partitions created before a table changed format keep their own SerDeInfo
+				if IsJSONPartition(&storageDescriptor) && IsJSONPartition(tableOutput.Table.StorageDescriptor) {
 					storageDescriptor.SerdeInfo = tableOutput.Table.StorageDescriptor.SerdeInfo
 				}
 				_, err = UpdatePartition(glueClient, gm.databaseName, gm.tableName, values,
@@ -427,16 +486,17 @@ func (gm *GlueTableMetadata) SyncPartitions(glueClient glueiface.GlueAPI, s3Clie
 	return nextTimeBin, <-errChan
 }
 
-func (gm *GlueTableMetadata) CreateJSONPartition(client glueiface.GlueAPI, t time.Time) (created bool, err error) {
+// CreatePartition creates the partition for time t in a JSON or Parquet table
+func (gm *GlueTableMetadata) CreatePartition(client glueiface.GlueAPI, t time.Time) (created bool, err error) {
 	// inherit StorageDescriptor from table
 	tableOutput, err := GetTable(client, gm.databaseName, gm.tableName)
 	if err != nil {
 		return false, err
 	}
 
-	// ensure this is a JSON table, use Contains() because there are multiple json serdes
-	if !IsJSONPartition(tableOutput.Table.StorageDescriptor) {
-		return false, errors.Errorf("not a JSON table: %#v", *tableOutput.Table.StorageDescriptor)
+	// ensure this is a table written by Panther, use Contains() because there are multiple json serdes
+	if sd := tableOutput.Table.StorageDescriptor; !IsJSONPartition(sd) && !IsParquetPartition(sd) {
+		return false, errors.Errorf("not a JSON or Parquet table: %#v", *sd)
 	}
 
 	return gm.createPartition(client, t, tableOutput)
@@ -138,57 +138,136 @@ func TestGlueTableMetadataRuleMatches(t *testing.T) {
 	assert.Equal(t, "rules/my_rule/year=2020/month=01/day=03/hour=01/", gm.PartitionPrefix(refTime))
 }
 
-func TestCreateJSONPartition(t *testing.T) {
+func TestCreatePartition(t *testing.T) {
 	gm := NewGlueTableMetadata(pantherdb.LogProcessingDatabase, "test_logs", "Description", GlueTableHourly, partitionTestEvent{})
 
 	// test no errors and partition does not exist (no error)
 	glueClient := &testutils.GlueMock{}
 	glueClient.On("GetTable", mock.Anything).Return(testGetTableOutput, nil).Once()
 	glueClient.On("CreatePartition", mock.Anything).Return(testCreatePartitionOutput, nil).Once()
-	created, err := gm.CreateJSONPartition(glueClient, refTime)
+	created, err := gm.CreatePartition(glueClient, refTime)
 	assert.NoError(t, err)
 	assert.True(t, created)
 	glueClient.AssertExpectations(t)
 }
 
-func TestCreateJSONPartitionPartitionExists(t *testing.T) {
+func TestCreatePartitionPartitionExists(t *testing.T) {
 	gm := NewGlueTableMetadata(pantherdb.LogProcessingDatabase, "test_logs", "Description", GlueTableHourly, partitionTestEvent{})
 
 	// test partition exists at start
 	glueClient := &testutils.GlueMock{}
 	glueClient.On("GetTable", mock.Anything).Return(testGetTableOutput, nil)
 	glueClient.On("CreatePartition", mock.Anything).Return(testCreatePartitionOutput, entityExistsError)
-	created, err := gm.CreateJSONPartition(glueClient, refTime)
+	created, err := gm.CreatePartition(glueClient, refTime)
 	assert.NoError(t, err)
 	assert.False(t, created)
 	glueClient.AssertExpectations(t)
 }
 
-func TestCreateJSONPartitionErrorGettingTable(t *testing.T) {
+func TestCreatePartitionErrorGettingTable(t *testing.T) {
 	gm := NewGlueTableMetadata(pantherdb.LogProcessingDatabase, "test_logs", "Description", GlueTableHourly, partitionTestEvent{})
 	// test error in GetTable
 	glueClient := &testutils.GlueMock{}
 	glueClient.On("GetTable", mock.Anything).Return(testGetTableOutput, nonAWSError).Once()
-	created, err := gm.CreateJSONPartition(glueClient, refTime)
+	created, err := gm.CreatePartition(glueClient, refTime)
 	assert.Error(t, err)
 	assert.False(t, created)
 	assert.Equal(t, nonAWSError, err)
 	glueClient.AssertExpectations(t)
 }
 
-func TestCreateJSONPartitionNonAWSError(t *testing.T) {
+func TestCreatePartitionNonAWSError(t *testing.T) {
 	gm := NewGlueTableMetadata(pantherdb.LogProcessingDatabase, "test_logs", "Description", GlueTableHourly, partitionTestEvent{})
 	// test error in CreatePartition
 	glueClient := &testutils.GlueMock{}
 	glueClient.On("GetTable", mock.Anything).Return(testGetTableOutput, nil).Once()
 	glueClient.On("CreatePartition", mock.Anything).Return(testCreatePartitionOutput, nonAWSError).Once()
-	created, err := gm.CreateJSONPartition(glueClient, refTime)
+	created, err := gm.CreatePartition(glueClient, refTime)
 	assert.Error(t, err)
 	assert.False(t, created)
 	assert.Equal(t, nonAWSError, err)
 	glueClient.AssertExpectations(t)
 }
 
+func TestCreatePartitionParquet(t *testing.T) {
+	gm := NewGlueTableMetadata(pantherdb.LogProcessingDatabase, "test_logs", "Description", GlueTableHourly, partitionTestEvent{})
+
+	storageDescriptor := *testStorageDescriptor
+	storageDescriptor.SerdeInfo = &glue.SerDeInfo{
+		SerializationLibrary: aws.String("org.apache.hadoop.hive.ql.io.parquet.serde.ParquetHiveSerDe"),
+	}
+	glueClient := &testutils.GlueMock{}
+	glueClient.On("GetTable", mock.Anything).Return(&glue.GetTableOutput{
+		Table: &glue.TableData{
+			StorageDescriptor: &storageDescriptor,
+		},
+	}, nil).Once()
+	glueClient.On("CreatePartition", mock.MatchedBy(func(input *glue.CreatePartitionInput) bool {
+		return IsParquetPartition(input.PartitionInput.StorageDescriptor)
+	})).Return(testCreatePartitionOutput, nil).Once()
+	created, err := gm.CreatePartition(glueClient, refTime)
+	assert.NoError(t, err)
+	assert.True(t, created)
+	glueClient.AssertExpectations(t)
+}
+
+func TestCreatePartitionUnsupportedTable(t *testing.T) {
+	gm := NewGlueTableMetadata(pantherdb.LogProcessingDatabase, "test_logs", "Description", GlueTableHourly, partitionTestEvent{})
+
+	storageDescriptor := *testStorageDescriptor
+	storageDescriptor.SerdeInfo = &glue.SerDeInfo{
+		SerializationLibrary: aws.String("org.apache.hadoop.hive.ql.io.orc.OrcSerde"),
+	}
+	glueClient := &testutils.GlueMock{}
+	glueClient.On("GetTable", mock.Anything).Return(&glue.GetTableOutput{
+		Table: &glue.TableData{
+			StorageDescriptor: &storageDescriptor,
+		},
+	}, nil).Once()
+	created, err := gm.CreatePartition(glueClient, refTime)
+	assert.Error(t, err)
+	assert.False(t, created)
+	glueClient.AssertExpectations(t)
+}
+
+func TestGlueTableInputFormat(t *testing.T) {
+	gm := NewGlueTableMetadata(pantherdb.LogProcessingDatabase, "test_logs", "Description", GlueTableHourly, struct {
+		Foo string `json:"foo"`
+	}{})
+	require.Equal(t, DataFormatJSON, gm.Format())
+
+	input, err := gm.glueTableInput(metadataTestBucket)
+	require.NoError(t, err)
+	assert.True(t, IsJSONPartition(input.StorageDescriptor))
+
+	parquetTable := gm.WithFormat(DataFormatParquet)
+	require.Equal(t, DataFormatJSON, gm.Format())
+	require.Equal(t, DataFormatParquet, parquetTable.Format())
+	input, err = parquetTable.glueTableInput(metadataTestBucket)
+	require.NoError(t, err)
+	assert.True(t, IsParquetPartition(input.StorageDescriptor))
+	assert.Equal(t, "org.apache.hadoop.hive.ql.io.parquet.MapredParquetInputFormat", aws.StringValue(input.StorageDescriptor.InputFormat))
+	assert.Equal(t, "s3://"+metadataTestBucket+"/logs/test_logs/", aws.StringValue(input.StorageDescriptor.Location))
+	assert.Equal(t, "foo", aws.StringValue(input.StorageDescriptor.Columns[0].Name))
+
+	// Rule matches are always JSON
+	assert.Equal(t, DataFormatJSON, parquetTable.RuleTable().Format())
+}
+
+func TestParseDataFormat(t *testing.T) {
+	for name, expect := range map[string]DataFormat{
+		"":        DataFormatJSON,
+		"json":    DataFormatJSON,
+		"Parquet": DataFormatParquet,
+	} {
+		format, err := ParseDataFormat(name)
+		require.NoError(t, err)
+		require.Equal(t, expect, format)
+	}
+	_, err := ParseDataFormat("orc")
+	require.Error(t, err)
+}
+
 func TestSyncPartitions(t *testing.T) {
 	var startDate time.Time // default unset
 	gm := NewGlueTableMetadata(pantherdb.LogProcessingDatabase, "test_logs", "Description", GlueTableHourly, partitionTestEvent{})
@@ -0,0 +1,199 @@
+package datacatalog
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/internal/log_analysis/gluetasks"
+	"github.com/panther-labs/panther/internal/log_analysis/pantherdb"
+	"github.com/panther-labs/panther/pkg/lambdalogger"
+)
+
+const (
+	// Set the number of partitions compacted in parallel
+	numCompactWorkersPerTable = 4
+	// Limit the number of partitions compacted in a single lambda invocation, the task continues in a new invocation
+	maxCompactPartitionsPerCall = 24
+)
+
+// CompactDatabasePartitionsEvent is a request to compact small objects in recent partitions of all log tables.
+// It is sent on a schedule to the Lambda.
+type CompactDatabasePartitionsEvent struct {
+	// An identifier to use in order to keep track of all 'child' Lambda invocations for this compaction.
+	TraceID string
+	// Which databases to compact, defaults to the databases written by the log processor.
+	// Rule matches should not be compacted since the same `p_row_id` can match multiple rules.
+	DatabaseNames []string
+	// How many hours back to look for partitions to compact, if not set all partitions are scanned
+	LookBackHours int
+	// If set to true the compaction will only scan for objects and will not modify any data
+	DryRun bool
+}
+
+// HandleCompactDatabasePartitionsEvent starts a compaction task for each table in the background
+func (h *LambdaHandler) HandleCompactDatabasePartitionsEvent(ctx context.Context, event *CompactDatabasePartitionsEvent) error {
+	log := lambdalogger.FromContext(ctx)
+	traceID := traceIDFromContext(ctx, event.TraceID)
+	log = log.With(
+		zap.String("traceId", traceID),
+		zap.Bool("dryRun", event.DryRun),
+	)
+	logTypes, err := h.ListAvailableLogTypes(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to list log types")
+	}
+	dbNames := event.DatabaseNames
+	if len(dbNames) == 0 {
+		dbNames = []string{
+			pantherdb.LogProcessingDatabase,
+			pantherdb.CloudSecurityDatabase,
+		}
+	}
+	var start time.Time
+	if event.LookBackHours > 0 {
+		start = time.Now().Add(-time.Duration(event.LookBackHours) * time.Hour)
+	}
+	var tableEvents []*CompactTableEvent
+	for _, logType := range logTypes {
+		for _, dbName := range dbNames {
+			if !pantherdb.IsInDatabase(logType, dbName) {
+				continue
+			}
+			tableEvents = append(tableEvents, &CompactTableEvent{
+				TraceID: traceID,
+				CompactTablePartitions: gluetasks.CompactTablePartitions{
+					DryRun:       event.DryRun,
+					TableName:    pantherdb.TableName(logType),
+					DatabaseName: dbName,
+					Start:        start,
+				},
+			})
+		}
+	}
+	numTasks := 0
+	for _, event := range tableEvents {
+		sendErr := sendEvent(ctx, h.SQSClient, h.QueueURL, sqsTask{
+			CompactTablePartitions: event,
+		})
+		if sendErr != nil {
+			log.Error("failed to invoke table compaction", zap.String("table", event.TableName), zap.Error(sendErr))
+			continue
+		}
+		numTasks++
+	}
+	log.Info("database compaction started", zap.Int("numTables", len(tableEvents)), zap.Int("numTasks", numTasks))
+	return nil
+}
+
+// CompactTableEvent initializes or continues a gluetasks.CompactTablePartitions task
+type CompactTableEvent struct {
+	// Use a common trace id (the CompactDatabasePartitions request id) for all events triggered by a compaction.
+	TraceID string
+	// NumCalls keeps track of the number of recursive calls for the specific compact table event.
+	// It acts as a guard against infinite recursion.
+	NumCalls int
+	// NumTimeouts keeps track of how many times the same hour was retried because of timeout.
+	NumTimeouts int
+	// Embed the full compact table partitions task state
+	// This allows us to continue the task by recursively calling the lambda.
+	gluetasks.CompactTablePartitions
+}
+
+// HandleCompactTableEvent starts or continues a gluetasks.CompactTablePartitions task.
+func (h *LambdaHandler) HandleCompactTableEvent(ctx context.Context, event *CompactTableEvent) error {
+	// Reserve some time for continuing the task in a new lambda invocation
+	if deadline, ok := ctx.Deadline(); ok {
+		// Partitions being swapped when the deadline is reached need time to complete the swap
+		const gracefulExitTimeout = 3 * time.Minute
+		timeout := time.Until(deadline)
+		if timeout > gracefulExitTimeout {
+			timeout = timeout - gracefulExitTimeout
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+	}
+
+	logger := lambdalogger.FromContext(ctx).With(
+		zap.String("traceId", event.TraceID),
+		zap.Int("numCalls", event.NumCalls),
+		zap.Int("numTimeouts", event.NumTimeouts),
+	)
+	compact := event.CompactTablePartitions
+	compact.NumWorkers = numCompactWorkersPerTable
+	compact.MaxPartitions = maxCompactPartitionsPerCall
+
+	err := compact.Run(ctx, h.GlueClient, h.S3Client, logger)
+	if err == nil {
+		return nil
+	}
+
+	// We only continue our work if failure was due to timeout or the partition limit was reached.
+	// AWS requests wrap the context error so we check the context directly.
+	if errors.Is(err, gluetasks.ErrPartitionLimit) || errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		nextEvent, nextErr := buildNextCompactEvent(event, &compact)
+		if nextErr != nil {
+			return nextErr
+		}
+		// We use context.Background to limit the probability of missing the continuation request
+		err = sendEvent(context.Background(), h.SQSClient, h.QueueURL, *nextEvent)
+		if err == nil {
+			return nil
+		}
+		err = errors.WithMessage(err, "compaction failed to continue")
+	}
+	logger.Error("compaction failed",
+		zap.String("table", event.TableName),
+		zap.String("database", event.DatabaseName),
+		zap.Error(err),
+	)
+	return errors.WithMessagef(err, "compaction %s.%s failed", event.DatabaseName, event.TableName)
+}
+
+func buildNextCompactEvent(event *CompactTableEvent, compact *gluetasks.CompactTablePartitions) (*sqsTask, error) {
+	numTimeouts := 0
+	if compact.LastHour.Equal(event.LastHour) {
+		// Deadline reached without any progress
+		numTimeouts = event.NumTimeouts + 1
+	}
+	if numTimeouts > maxConsecutiveSyncTimeouts {
+		return nil, errors.Errorf("no progress after %d retries", numTimeouts)
+	}
+	// protect against infinite recursion
+	numCalls := event.NumCalls + 1
+	if numCalls > maxNumCalls {
+		return nil, errors.Errorf("compaction did not complete after %d lambda calls", numCalls)
+	}
+	next := *compact
+	// Stats are logged on each invocation
+	next.Stats = gluetasks.CompactStats{}
+	return &sqsTask{
+		CompactTablePartitions: &CompactTableEvent{
+			CompactTablePartitions: next,
+			NumCalls:               numCalls,
+			NumTimeouts:            numTimeouts,
+			TraceID:                event.TraceID, // keep the original trace id
+		},
+	}, nil
+}
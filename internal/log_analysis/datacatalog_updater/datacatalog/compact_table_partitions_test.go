@@ -0,0 +1,104 @@
+package datacatalog
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/lambdacontext"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/internal/log_analysis/gluetasks"
+	"github.com/panther-labs/panther/internal/log_analysis/pantherdb"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+func TestScheduledCompactDatabasePartitions(t *testing.T) {
+	assert := require.New(t)
+	sqsMock := &testutils.SqsMock{}
+	h := LambdaHandler{
+		QueueURL:  "queue-url",
+		SQSClient: sqsMock,
+		Logger:    zap.NewNop(),
+		ListAvailableLogTypes: func(_ context.Context) ([]string, error) {
+			return []string{"AWS.CloudTrail", "Resource.History"}, nil
+		},
+	}
+	var tasks []sqsTask
+	sqsMock.On("SendMessageWithContext", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		input := args.Get(1).(*sqs.SendMessageInput)
+		assert.Equal("queue-url", aws.StringValue(input.QueueUrl))
+		task := sqsTask{}
+		assert.NoError(jsoniter.UnmarshalFromString(aws.StringValue(input.MessageBody), &task))
+		tasks = append(tasks, task)
+	}).Return(&sqs.SendMessageOutput{}, nil).Twice()
+
+	ctx := lambdacontext.NewContext(context.Background(), &lambdacontext.LambdaContext{
+		AwsRequestID: "request-id",
+	})
+	_, err := h.Invoke(ctx, []byte(`{"CompactDatabasePartitions":{"LookBackHours":24}}`))
+	assert.NoError(err)
+	sqsMock.AssertExpectations(t)
+
+	assert.Len(tasks, 2)
+	for _, task := range tasks {
+		assert.NotNil(task.CompactTablePartitions)
+		assert.Equal("request-id", task.CompactTablePartitions.TraceID)
+		assert.WithinDuration(time.Now().Add(-24*time.Hour), task.CompactTablePartitions.Start, time.Minute)
+	}
+	assert.Equal(pantherdb.LogProcessingDatabase, tasks[0].CompactTablePartitions.DatabaseName)
+	assert.Equal("aws_cloudtrail", tasks[0].CompactTablePartitions.TableName)
+	assert.Equal(pantherdb.CloudSecurityDatabase, tasks[1].CompactTablePartitions.DatabaseName)
+	assert.Equal("resource_history", tasks[1].CompactTablePartitions.TableName)
+}
+
+func TestBuildNextCompactEvent(t *testing.T) {
+	assert := require.New(t)
+	lastHour := time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)
+	event := &CompactTableEvent{
+		TraceID: "trace-id",
+		CompactTablePartitions: gluetasks.CompactTablePartitions{
+			DatabaseName: pantherdb.LogProcessingDatabase,
+			TableName:    "aws_cloudtrail",
+			LastHour:     lastHour,
+		},
+	}
+	compact := event.CompactTablePartitions
+	compact.LastHour = lastHour.Add(time.Hour)
+	compact.Stats.NumObjects = 42
+	next, err := buildNextCompactEvent(event, &compact)
+	assert.NoError(err)
+	assert.Equal(1, next.CompactTablePartitions.NumCalls)
+	assert.Equal(0, next.CompactTablePartitions.NumTimeouts)
+	assert.Equal("trace-id", next.CompactTablePartitions.TraceID)
+	assert.Equal(compact.LastHour, next.CompactTablePartitions.LastHour)
+	assert.Zero(next.CompactTablePartitions.Stats.NumObjects)
+
+	// No progress
+	event.NumTimeouts = maxConsecutiveSyncTimeouts
+	_, err = buildNextCompactEvent(event, &event.CompactTablePartitions)
+	assert.Error(err)
+}
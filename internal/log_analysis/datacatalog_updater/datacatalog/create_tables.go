@@ -51,7 +51,7 @@ func (h *LambdaHandler) HandleCreateTablesEvent(ctx context.Context, event *Crea
 
 func (h *LambdaHandler) createTablesForLogTypes(ctx context.Context, logTypes []string) error {
 	// We map the log types to their 'base' log tables.
-	tables, err := resolveTables(ctx, h.Resolver, logTypes...)
+	tables, err := resolveTables(ctx, h.Resolver, h.DataFormat, logTypes...)
 	if err != nil {
 		return err
 	}
@@ -79,7 +79,7 @@ func (h *LambdaHandler) createTablesForLogTypes(ctx context.Context, logTypes []
 
 func (h *LambdaHandler) createOrUpdateTablesForLogTypes(ctx context.Context, logTypes []string) error {
 	// We map the log types to their 'base' log tables, errors are collected and not fatal
-	tables, err := resolveTables(ctx, h.Resolver, logTypes...)
+	tables, err := resolveTables(ctx, h.Resolver, h.DataFormat, logTypes...)
 	if err != nil {
 		return err
 	}
@@ -133,7 +133,7 @@ func (h *LambdaHandler) fetchAllDeployedLogTypes(ctx context.Context) ([]string,
 // Resolves the tables for the provided log types.
 // Note that this will return only the BASE tables (tables in for panther_logs and panther_cloudsecurity databases) but not any
 // downstream tables e.g. panther_rule_matches, panther_rule_errors
-func resolveTables(ctx context.Context, r logtypes.Resolver, names ...string) ([]*awsglue.GlueTableMetadata, error) {
+func resolveTables(ctx context.Context, r logtypes.Resolver, format awsglue.DataFormat, names ...string) ([]*awsglue.GlueTableMetadata, error) {
 	var out []*awsglue.GlueTableMetadata
 	for _, name := range names {
 		entry, err := r.Resolve(ctx, name)
@@ -143,15 +143,16 @@ func resolveTables(ctx context.Context, r logtypes.Resolver, names ...string) ([
 		if entry == nil { // don't fail whole operation if missing data...
 			continue
 		}
-		out = append(out, tableForEntry(entry))
+		out = append(out, tableForEntry(entry, format))
 	}
 	return out, nil
 }
 
-func tableForEntry(entry logtypes.Entry) *awsglue.GlueTableMetadata {
+func tableForEntry(entry logtypes.Entry, format awsglue.DataFormat) *awsglue.GlueTableMetadata {
 	eventSchema := entry.Schema()
 	desc := entry.Describe()
 	tableName := pantherdb.TableName(desc.Name)
 	db := pantherdb.DatabaseName(pantherdb.GetDataType(desc.Name))
-	return awsglue.NewGlueTableMetadata(db, tableName, desc.Description, awsglue.GlueTableHourly, eventSchema)
+	table := awsglue.NewGlueTableMetadata(db, tableName, desc.Description, awsglue.GlueTableHourly, eventSchema)
+	return table.WithFormat(format)
 }
@@ -26,19 +26,23 @@ import (
 	"github.com/aws/aws-lambda-go/lambdacontext"
 	"github.com/aws/aws-sdk-go/service/athena/athenaiface"
 	"github.com/aws/aws-sdk-go/service/glue/glueiface"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/pkg/errors"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
 	"github.com/panther-labs/panther/pkg/lambdalogger"
 	"github.com/panther-labs/panther/pkg/oplog"
 )
 
 type LambdaHandler struct {
-	ProcessedDataBucket   string
+	ProcessedDataBucket string
+	// DataFormat is the format of the log tables written by the log processor
+	DataFormat            awsglue.DataFormat
 	AthenaWorkgroup       string
 	QueueURL              string
 	ListAvailableLogTypes func(ctx context.Context) ([]string, error)
@@ -46,6 +50,7 @@ type LambdaHandler struct {
 	Resolver              logtypes.Resolver
 	AthenaClient          athenaiface.AthenaAPI
 	SQSClient             sqsiface.SQSAPI
+	S3Client              s3iface.S3API
 	Logger                *zap.Logger
 
 	// Glue partitions known to have been created.
@@ -55,12 +60,14 @@ type LambdaHandler struct {
 var _ lambda.Handler = (*LambdaHandler)(nil)
 
 type sqsTask struct {
-	Records                []events.S3EventRecord       `json:",omitempty"`
-	SyncDatabase           *SyncDatabaseEvent           `json:",omitempty"`
-	CreateTables           *CreateTablesEvent           `json:",omitempty"`
-	SyncDatabasePartitions *SyncDatabasePartitionsEvent `json:",omitempty"`
-	SyncTablePartitions    *SyncTableEvent              `json:",omitempty"`
-	UpdateTable            *UpdateTablesEvent           `json:",omitempty"`
+	Records                   []events.S3EventRecord          `json:",omitempty"`
+	SyncDatabase              *SyncDatabaseEvent              `json:",omitempty"`
+	CreateTables              *CreateTablesEvent              `json:",omitempty"`
+	SyncDatabasePartitions    *SyncDatabasePartitionsEvent    `json:",omitempty"`
+	SyncTablePartitions       *SyncTableEvent                 `json:",omitempty"`
+	UpdateTable               *UpdateTablesEvent              `json:",omitempty"`
+	CompactDatabasePartitions *CompactDatabasePartitionsEvent `json:",omitempty"`
+	CompactTablePartitions    *CompactTableEvent              `json:",omitempty"`
 }
 
 // Invoke implements lambda.Handler interface.
@@ -72,6 +79,15 @@ func (h *LambdaHandler) Invoke(ctx context.Context, payload []byte) ([]byte, err
 	if err := jsoniter.Unmarshal(payload, &event); err != nil {
 		return nil, errors.Wrap(err, "failed to unmarshal Lambda payload")
 	}
+	// Scheduled jobs invoke the Lambda directly with a task payload
+	if len(event.Records) == 0 {
+		event.Records = []events.SQSMessage{
+			{
+				MessageId: "scheduled",
+				Body:      string(payload),
+			},
+		}
+	}
 	if err := h.HandleSQSEvent(ctx, &event); err != nil {
 		return nil, err
 	}
@@ -108,6 +124,10 @@ func (h *LambdaHandler) HandleSQSEvent(ctx context.Context, event *events.SQSEve
 			err = h.HandleSyncTableEvent(ctx, task)
 		case *UpdateTablesEvent:
 			err = h.HandleUpdateTablesEvent(ctx, task)
+		case *CompactDatabasePartitionsEvent:
+			err = h.HandleCompactDatabasePartitionsEvent(ctx, task)
+		case *CompactTableEvent:
+			err = h.HandleCompactTableEvent(ctx, task)
 		default:
 			err = errors.New("invalid task")
 		}
@@ -147,6 +167,10 @@ func tasksFromSQSMessages(messages ...events.SQSMessage) (tasks []interface{}, e
 			tasks = append(tasks, task.CreateTables)
 		case task.UpdateTable != nil:
 			tasks = append(tasks, task.UpdateTable)
+		case task.CompactDatabasePartitions != nil:
+			tasks = append(tasks, task.CompactDatabasePartitions)
+		case task.CompactTablePartitions != nil:
+			tasks = append(tasks, task.CompactTablePartitions)
 		default:
 			err = multierr.Append(err, errors.Errorf("invalid SQS message body %q", msg.MessageId))
 		}
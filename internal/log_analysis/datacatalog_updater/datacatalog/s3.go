@@ -59,7 +59,7 @@ func (h *LambdaHandler) HandleS3EventRecord(ctx context.Context, event *events.S
 	}
 	partitionTime := partition.GetTime()
 	tableMeta := partition.GetGlueTableMetadata()
-	if _, err := tableMeta.CreateJSONPartition(h.GlueClient, partitionTime); err != nil {
+	if _, err := tableMeta.CreatePartition(h.GlueClient, partitionTime); err != nil {
 		return errors.Wrapf(err, "cannot create partition for s3://%s/%s", bucketName, objectKey)
 	}
 
@@ -28,14 +28,17 @@ import (
 	"github.com/aws/aws-sdk-go/service/athena"
 	"github.com/aws/aws-sdk-go/service/glue"
 	lambdaclient "github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/kelseyhightower/envconfig"
 	"go.uber.org/zap"
 
 	"github.com/panther-labs/panther/internal/compliance/snapshotlogs"
 	"github.com/panther-labs/panther/internal/core/logtypesapi"
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
 	"github.com/panther-labs/panther/internal/log_analysis/datacatalog_updater/datacatalog"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/quarantine"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
 	"github.com/panther-labs/panther/pkg/awsretry"
 	"github.com/panther-labs/panther/pkg/lambdalogger"
@@ -55,10 +58,16 @@ func main() {
 		SyncWorkersPerTable int    `default:"10" split_words:"true"`
 		QueueURL            string `required:"true" split_words:"true"`
 		ProcessedDataBucket string `split_words:"true"`
+		ProcessedDataFormat string `split_words:"true"`
 		Debug               bool   `split_words:"true"`
 	}{}
 	envconfig.MustProcess("", &config)
 
+	dataFormat, err := awsglue.ParseDataFormat(config.ProcessedDataFormat)
+	if err != nil {
+		panic(err)
+	}
+
 	logger := lambdalogger.Config{
 		Debug:     config.Debug,
 		Namespace: "log_analysis",
@@ -85,11 +94,12 @@ func main() {
 
 	apiResolver := &logtypesapi.Resolver{
 		LogTypesAPI:    logtypesAPI,
-		NativeLogTypes: logtypes.MustMerge("native", registry.NativeLogTypes(), snapshotlogs.LogTypes()),
+		NativeLogTypes: logtypes.MustMerge("native", registry.NativeLogTypes(), snapshotlogs.LogTypes(), quarantine.LogTypes()),
 	}
 
-	// Also include the cloud-security logs since they are not yet exported as managed schemas.
-	chainResolver := logtypes.ChainResolvers(apiResolver, snapshotlogs.Resolver())
+	// Also include the cloud-security logs since they are not yet exported as managed schemas
+	// and the quarantine table for log lines that could not be classified.
+	chainResolver := logtypes.ChainResolvers(apiResolver, snapshotlogs.Resolver(), quarantine.Resolver())
 
 	// Log cases where a log type failed to resolve. Almost certainly something is amiss in the DDB.
 	resolver := logtypes.ResolverFunc(func(ctx context.Context, name string) (logtypes.Entry, error) {
@@ -107,6 +117,7 @@ func main() {
 
 	handler := datacatalog.LambdaHandler{
 		ProcessedDataBucket: config.ProcessedDataBucket,
+		DataFormat:          dataFormat,
 		QueueURL:            config.QueueURL,
 		AthenaWorkgroup:     config.AthenaWorkgroup,
 		ListAvailableLogTypes: func(ctx context.Context) ([]string, error) {
@@ -114,13 +125,15 @@ func main() {
 			if err != nil {
 				return nil, err
 			}
-			// append in snapshot logs which are always onboarded
-			return stringset.Append(reply.LogTypes, logtypes.CollectNames(snapshotlogs.LogTypes())...), nil
+			// append in snapshot logs and the quarantine table which are always onboarded
+			logTypes := stringset.Append(reply.LogTypes, logtypes.CollectNames(snapshotlogs.LogTypes())...)
+			return stringset.Append(logTypes, logtypes.CollectNames(quarantine.LogTypes())...), nil
 		},
 		GlueClient:   glue.New(clientsSession),
 		Resolver:     resolver,
 		AthenaClient: athena.New(clientsSession),
 		SQSClient:    sqs.New(clientsSession),
+		S3Client:     s3.New(clientsSession),
 		Logger:       logger,
 	}
 
@@ -0,0 +1,793 @@
+package gluetasks
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/glue"
+	"github.com/aws/aws-sdk-go/service/glue/glueiface"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+const (
+	// DefaultCompactTargetSize is the size of compressed objects produced by compaction if no size is set.
+	// Athena performs best with files over 128MB.
+	DefaultCompactTargetSize = 128 * 1024 * 1024
+	// DefaultCompactMinAge is the time to wait after an object was last modified before it can be compacted.
+	DefaultCompactMinAge = time.Hour
+
+	// S3 DeleteObjects accepts up to 1000 keys per request.
+	maxDeleteObjects = 1000
+	// We cap the number of objects merged at once so that merged objects are deleted with a single request.
+	maxCompactObjects = maxDeleteObjects
+	// Time allowed to swap the objects of a partition once its compacted objects are staged.
+	compactSwapTimeout = 2 * time.Minute
+	// Same layout as destinations.S3ObjectTimestampLayout so compacted objects are named like the ones they replace.
+	compactTimestampLayout = "20060102T150405Z"
+	compactObjectSuffix    = ".json.gz"
+	// Compacted objects are staged under a prefix in the partition that Athena ignores because it starts with '_'
+	compactStagingPrefix = "_compacted-"
+	// The manifest lists the objects merged into the staged objects, so that an interrupted swap can be resumed
+	compactManifestName = "_manifest.json"
+)
+
+// ErrPartitionLimit is returned when a compaction stops after MaxPartitions partitions.
+// The task can be continued from LastHour.
+var ErrPartitionLimit = errors.New("partition limit reached")
+
+// CompactDatabaseTables merges small objects in completed hourly partitions for all tables in a database
+type CompactDatabaseTables struct {
+	// DatabaseName scans this Glue database for partitions to compact
+	DatabaseName string
+	// MatchPrefix will match tables whose name begins with this prefix
+	MatchPrefix string
+	// Start sets the start of the scan range
+	Start time.Time
+	// End sets the end of the scan range
+	End time.Time
+	// MinAge is the time to wait after an object was last modified before compacting it
+	MinAge time.Duration
+	// TargetSize is the max size in bytes of the compressed objects to merge together
+	TargetSize int64
+	// NumWorkers sets the number of partitions to compact in parallel for each table
+	NumWorkers int
+	// DryRun is a flag to not modify any objects
+	DryRun bool
+	// Stats holds the stats for all tables compacted
+	Stats CompactStats
+}
+
+// Run executes the compaction
+func (c *CompactDatabaseTables) Run(ctx context.Context, glueAPI glueiface.GlueAPI, s3API s3iface.S3API, log *zap.Logger) error {
+	if log == nil {
+		log = zap.NewNop()
+	}
+	log = log.Named("CompactDatabase").With(
+		zap.String("database", c.DatabaseName),
+	)
+	group, ctx := errgroup.WithContext(ctx)
+	tables := make(chan []*glue.TableData)
+	group.Go(func() error {
+		defer close(tables)
+		log.Info("scanning for tables")
+		input := glue.GetTablesInput{
+			DatabaseName: &c.DatabaseName,
+		}
+		if c.MatchPrefix != "" {
+			expr := c.MatchPrefix + "*"
+			input.Expression = &expr
+		}
+		err := glueAPI.GetTablesPagesWithContext(ctx, &input, func(page *glue.GetTablesOutput, _ bool) bool {
+			select {
+			case tables <- page.TableList:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+		if err != nil {
+			log.Error("failed to scan tables", zap.Error(err))
+		}
+		return err
+	})
+	group.Go(func() error {
+		for page := range tables {
+			tasks := make([]*CompactTablePartitions, len(page))
+			childGroup, ctx := errgroup.WithContext(ctx)
+			for i, tbl := range page {
+				i, tbl := i, tbl
+				task := &CompactTablePartitions{
+					DatabaseName: c.DatabaseName,
+					TableName:    aws.StringValue(tbl.Name),
+					Start:        c.Start,
+					End:          c.End,
+					MinAge:       c.MinAge,
+					TargetSize:   c.TargetSize,
+					NumWorkers:   c.NumWorkers,
+					DryRun:       c.DryRun,
+				}
+				tasks[i] = task
+				childGroup.Go(func() error {
+					log := log.With(zap.String("table", task.TableName))
+					return task.compactTable(ctx, glueAPI, s3API, log, tbl)
+				})
+			}
+			err := childGroup.Wait()
+			for _, task := range tasks {
+				c.Stats.merge(task.Stats)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return group.Wait()
+}
+
+// CompactTablePartitions merges small objects in completed hourly partitions of a table.
+//
+// Objects are merged into larger gzip JSON files of up to TargetSize bytes and rows with a duplicate `p_row_id`
+// are dropped. Only objects that were last modified more than MinAge ago are merged, so that objects still being
+// written to a partition (i.e. late events) are left alone.
+//
+// The objects of a partition are swapped atomically for queries:
+//   - The compacted objects are staged under a new prefix, hidden inside the partition prefix.
+//   - The partition is pointed to the staged prefix with glue.UpdatePartition and the merged objects are deleted.
+//   - The compacted objects are copied to the partition prefix and the partition is pointed back to it, so that
+//     objects written to the partition by the log processor meanwhile are not hidden. The staged objects are then deleted.
+//
+// A manifest of the merged objects is staged along with the compacted objects. If the task is interrupted while
+// the partition points to the staged prefix, the swap is resumed the next time the partition is compacted.
+type CompactTablePartitions struct {
+	DatabaseName string
+	TableName    string
+	NumWorkers   int
+	DryRun       bool
+	Start        time.Time
+	End          time.Time
+	MinAge       time.Duration
+	TargetSize   int64
+	// MaxPartitions is the number of partitions to compact before returning ErrPartitionLimit, zero means no limit
+	MaxPartitions int
+	// LastHour is the last partition hour compacted, used to continue an interrupted task
+	LastHour time.Time
+	Stats    CompactStats
+}
+
+func (c *CompactTablePartitions) Run(ctx context.Context, glueAPI glueiface.GlueAPI, s3API s3iface.S3API, log *zap.Logger) error {
+	tbl, err := findTable(ctx, glueAPI, c.DatabaseName, c.TableName)
+	if log == nil {
+		log = zap.NewNop()
+	}
+	log = log.Named("CompactTablePartitions").With(
+		zap.String("database", c.DatabaseName),
+		zap.String("table", c.TableName),
+	)
+	if err != nil {
+		log.Error("table not found", zap.Error(err))
+		return err
+	}
+	return c.compactTable(ctx, glueAPI, s3API, log, tbl)
+}
+
+func (c *CompactTablePartitions) compactTable(ctx context.Context, glueAPI glueiface.GlueAPI, s3API s3iface.S3API,
+	log *zap.Logger, tbl *glue.TableData) (err error) {
+
+	start := c.Start
+	if !c.LastHour.IsZero() {
+		start = hourly.Next(c.LastHour)
+	}
+	now := time.Now()
+	minAge := c.MinAge
+	if minAge <= 0 {
+		minAge = DefaultCompactMinAge
+	}
+	start, end := buildCompactRange(tbl, start, c.End, now)
+	if !start.Before(end) {
+		log.Info("no completed partitions to compact", zap.Stringer("start", start), zap.Stringer("end", end))
+		return nil
+	}
+	log.Info("starting compaction", zap.Stringer("start", start), zap.Stringer("end", end))
+	defer func(since time.Time) {
+		delta := time.Since(since)
+		if errors.Is(err, ErrPartitionLimit) {
+			log.Info("compaction paused", zap.Duration("duration", delta), zap.Any("stats", &c.Stats), zap.Time("lastHour", c.LastHour))
+		} else if err != nil {
+			log.Error("compaction failed", zap.Error(err), zap.Duration("duration", delta), zap.Any("stats", &c.Stats))
+		} else {
+			log.Info("compaction finished", zap.Duration("duration", delta), zap.Any("stats", &c.Stats))
+		}
+	}(time.Now())
+
+	var partitions []compactTask
+	expr := hourly.PartitionsBetween(start, end)
+	input := glue.GetPartitionsInput{
+		CatalogId:    tbl.CatalogId,
+		DatabaseName: tbl.DatabaseName,
+		TableName:    tbl.Name,
+		Expression:   &expr,
+	}
+	log.Info("scanning for partitions")
+	err = glueAPI.GetPartitionsPagesWithContext(ctx, &input, func(page *glue.GetPartitionsOutput, _ bool) bool {
+		for _, p := range page.Partitions {
+			tm, err := awsglue.PartitionTimeFromValues(p.Values)
+			if err != nil {
+				continue
+			}
+			// Only compact partitions within the requested range
+			if tm.Before(start) || !tm.Before(end) {
+				continue
+			}
+			if p.StorageDescriptor == nil || p.StorageDescriptor.Location == nil {
+				continue
+			}
+			partitions = append(partitions, compactTask{
+				table:     tbl,
+				partition: p,
+				hour:      tm,
+			})
+		}
+		return true
+	})
+	if err != nil {
+		log.Error("partition scan failed", zap.Error(err))
+		return err
+	}
+	log.Info("partitions scanned", zap.Int("numPartitions", len(partitions)))
+	// Partitions are processed in ascending order so that LastHour can be used to continue the task
+	sort.Slice(partitions, func(i, j int) bool {
+		return partitions[i].hour.Before(partitions[j].hour)
+	})
+
+	numWorkers := c.NumWorkers
+	if numWorkers < 1 {
+		numWorkers = 1
+	}
+	targetSize := c.TargetSize
+	if targetSize <= 0 {
+		targetSize = DefaultCompactTargetSize
+	}
+	numDone := 0
+	for len(partitions) > 0 {
+		if c.MaxPartitions > 0 && numDone >= c.MaxPartitions {
+			return ErrPartitionLimit
+		}
+		n := numWorkers
+		if n > len(partitions) {
+			n = len(partitions)
+		}
+		if c.MaxPartitions > 0 && n > c.MaxPartitions-numDone {
+			n = c.MaxPartitions - numDone
+		}
+		batch := partitions[:n]
+		partitions = partitions[n:]
+		workers := make([]compactWorker, n)
+		group, ctx := errgroup.WithContext(ctx)
+		for i := range workers {
+			w := &workers[i]
+			*w = compactWorker{
+				s3:             s3API,
+				glue:           glueAPI,
+				dryRun:         c.DryRun,
+				targetSize:     targetSize,
+				modifiedBefore: now.Add(-minAge),
+				log:            log,
+			}
+			task := batch[i]
+			group.Go(func() error {
+				return w.compactPartition(ctx, task)
+			})
+		}
+		err := group.Wait()
+		for i := range workers {
+			c.Stats.merge(workers[i].stats)
+		}
+		if err != nil {
+			return err
+		}
+		// All partitions up to this hour have been processed
+		c.LastHour = batch[n-1].hour
+		numDone += n
+	}
+	return nil
+}
+
+type compactTask struct {
+	table     *glue.TableData
+	partition *glue.Partition
+	hour      time.Time
+}
+
+type compactWorker struct {
+	s3             s3iface.S3API
+	glue           glueiface.GlueAPI
+	dryRun         bool
+	targetSize     int64
+	modifiedBefore time.Time
+	log            *zap.Logger
+	stats          CompactStats
+}
+
+func (w *compactWorker) compactPartition(ctx context.Context, task compactTask) error {
+	log := w.log.With(zap.String("time", task.hour.Format("2006-01-02 15:04")))
+	location := aws.StringValue(task.partition.StorageDescriptor.Location)
+	bucket, prefix, err := awsglue.ParseS3URL(location)
+	if err != nil {
+		return errors.WithMessagef(err, "failed to parse S3 path for partition %q", location)
+	}
+	if !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	w.stats.NumPartitions++
+
+	if partitionPrefix, ok := stagedPartitionPrefix(prefix); ok {
+		// A previous run was interrupted while the partition pointed to the staged objects
+		log.Info("resuming partition swap", zap.String("location", location))
+		if w.dryRun {
+			return nil
+		}
+		swapCtx, cancel := context.WithTimeout(context.Background(), compactSwapTimeout)
+		defer cancel()
+		if err := w.swapPartition(swapCtx, task, bucket, partitionPrefix, prefix); err != nil {
+			return err
+		}
+		w.stats.NumResumed++
+		return nil
+	}
+
+	var objects []*s3.Object
+	var staged []string
+	hasParquet := false
+	input := s3.ListObjectsV2Input{
+		Bucket: &bucket,
+		Prefix: &prefix,
+	}
+	err = w.s3.ListObjectsV2PagesWithContext(ctx, &input, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, obj := range page.Contents {
+			key := aws.StringValue(obj.Key)
+			if strings.HasSuffix(key, ".parquet") {
+				hasParquet = true
+				return false
+			}
+			if strings.HasPrefix(key, prefix+compactStagingPrefix) {
+				staged = append(staged, key)
+				continue
+			}
+			if isCompactCandidate(obj, w.targetSize, w.modifiedBefore) {
+				objects = append(objects, obj)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to list objects in %q", location)
+	}
+	if hasParquet {
+		// The JSON files in Parquet partitions are hidden and not used by Athena
+		log.Debug("skipping partition", zap.String("reason", "parquet"))
+		w.stats.NumSkipped++
+		return nil
+	}
+	if len(staged) > 0 && !w.dryRun {
+		// Leftovers of a run that was interrupted before the partition was swapped, they are not visible to queries
+		if err := w.deleteObjects(ctx, bucket, staged); err != nil {
+			return errors.WithMessage(err, "failed to delete staged objects")
+		}
+	}
+	batches := buildCompactBatches(objects, w.targetSize)
+	if len(batches) == 0 {
+		log.Debug("skipping partition", zap.String("reason", "compacted"), zap.Int("numObjects", len(objects)))
+		return nil
+	}
+	if w.dryRun {
+		for _, batch := range batches {
+			w.stats.NumObjects += len(batch)
+		}
+		log.Info("dryrun, skipping compaction", zap.Int("numObjects", w.stats.NumObjects))
+		return nil
+	}
+	stagingPrefix := prefix + compactStagingPrefix + uuid.New().String() + "/"
+	// Rows are unique across all objects produced for this partition
+	seen := make(map[string]struct{})
+	var merged []string
+	for _, batch := range batches {
+		key := path.Join(stagingPrefix, fmt.Sprintf("%s-%s%s", task.hour.Format(compactTimestampLayout), uuid.New(), compactObjectSuffix))
+		if err := w.compactObjects(ctx, bucket, key, batch, seen); err != nil {
+			return err
+		}
+		for _, obj := range batch {
+			merged = append(merged, aws.StringValue(obj.Key))
+		}
+		log.Info("objects compacted", zap.String("key", key), zap.Int("numObjects", len(batch)))
+	}
+	if err := w.putManifest(ctx, bucket, stagingPrefix, merged); err != nil {
+		return err
+	}
+
+	// Once the partition points to the staged objects the swap needs to complete, even if the task deadline
+	// is reached. If it is interrupted it is resumed the next time the partition is compacted.
+	swapCtx, cancel := context.WithTimeout(context.Background(), compactSwapTimeout)
+	defer cancel()
+	if err := w.updatePartitionLocation(swapCtx, task, bucket, stagingPrefix); err != nil {
+		return err
+	}
+	if err := w.swapPartition(swapCtx, task, bucket, prefix, stagingPrefix); err != nil {
+		return err
+	}
+	w.stats.NumCompacted++
+	return nil
+}
+
+// swapPartition completes the swap of a partition that points to its staged compacted objects.
+//
+// The merged objects listed in the manifest are deleted and the compacted objects are copied to the partition prefix.
+// The partition is then pointed back to its prefix and the staged objects are deleted.
+// All steps can be repeated so that an interrupted swap can be resumed.
+func (w *compactWorker) swapPartition(ctx context.Context, task compactTask, bucket, partitionPrefix, stagingPrefix string) error {
+	merged, err := w.getManifest(ctx, bucket, stagingPrefix)
+	if err != nil {
+		return err
+	}
+	if err := w.deleteObjects(ctx, bucket, merged); err != nil {
+		return errors.WithMessage(err, "failed to delete merged objects")
+	}
+	var staged []string
+	input := s3.ListObjectsV2Input{
+		Bucket: &bucket,
+		Prefix: &stagingPrefix,
+	}
+	err = w.s3.ListObjectsV2PagesWithContext(ctx, &input, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, obj := range page.Contents {
+			staged = append(staged, aws.StringValue(obj.Key))
+		}
+		return true
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to list staged objects in %q", stagingPrefix)
+	}
+	for _, key := range staged {
+		name := path.Base(key)
+		if name == compactManifestName {
+			continue
+		}
+		dst := partitionPrefix + name
+		if _, err := w.s3.CopyObjectWithContext(ctx, &s3.CopyObjectInput{
+			Bucket:     &bucket,
+			Key:        &dst,
+			CopySource: aws.String(copySource(bucket, key)),
+		}); err != nil {
+			return errors.Wrapf(err, "failed to copy compacted object %q", key)
+		}
+	}
+	if err := w.updatePartitionLocation(ctx, task, bucket, partitionPrefix); err != nil {
+		return err
+	}
+	if err := w.deleteObjects(ctx, bucket, staged); err != nil {
+		return errors.WithMessage(err, "failed to delete staged objects")
+	}
+	return nil
+}
+
+// updatePartitionLocation points a partition to a prefix in bucket
+func (w *compactWorker) updatePartitionLocation(ctx context.Context, task compactTask, bucket, prefix string) error {
+	p := task.partition
+	desc := *p.StorageDescriptor
+	desc.Location = aws.String(fmt.Sprintf("s3://%s/%s", bucket, prefix))
+	input := glue.UpdatePartitionInput{
+		CatalogId:    task.table.CatalogId,
+		DatabaseName: task.table.DatabaseName,
+		TableName:    task.table.Name,
+		PartitionInput: &glue.PartitionInput{
+			LastAccessTime:    p.LastAccessTime,
+			LastAnalyzedTime:  p.LastAnalyzedTime,
+			Parameters:        p.Parameters,
+			StorageDescriptor: &desc,
+			Values:            p.Values,
+		},
+		PartitionValueList: p.Values,
+	}
+	if _, err := w.glue.UpdatePartitionWithContext(ctx, &input); err != nil {
+		return errors.Wrapf(err, "failed to update partition location to %q", aws.StringValue(desc.Location))
+	}
+	p.StorageDescriptor = &desc
+	return nil
+}
+
+type compactManifest struct {
+	Objects []string `json:"objects"`
+}
+
+func (w *compactWorker) putManifest(ctx context.Context, bucket, stagingPrefix string, merged []string) error {
+	data, err := jsoniter.Marshal(&compactManifest{
+		Objects: merged,
+	})
+	if err != nil {
+		return err
+	}
+	key := stagingPrefix + compactManifestName
+	if _, err := w.s3.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket: &bucket,
+		Key:    &key,
+		Body:   bytes.NewReader(data),
+	}); err != nil {
+		return errors.Wrapf(err, "failed to upload manifest %q", key)
+	}
+	return nil
+}
+
+func (w *compactWorker) getManifest(ctx context.Context, bucket, stagingPrefix string) ([]string, error) {
+	key := stagingPrefix + compactManifestName
+	reply, err := w.s3.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: &bucket,
+		Key:    &key,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get manifest %q", key)
+	}
+	defer reply.Body.Close()
+	manifest := compactManifest{}
+	if err := jsoniter.NewDecoder(reply.Body).Decode(&manifest); err != nil {
+		return nil, errors.Wrapf(err, "failed to read manifest %q", key)
+	}
+	return manifest.Objects, nil
+}
+
+// deleteObjects deletes keys from bucket in chunks of up to 1000 keys
+func (w *compactWorker) deleteObjects(ctx context.Context, bucket string, keys []string) error {
+	for len(keys) > 0 {
+		n := len(keys)
+		if n > maxDeleteObjects {
+			n = maxDeleteObjects
+		}
+		del := s3.Delete{
+			Quiet: aws.Bool(true),
+		}
+		for _, key := range keys[:n] {
+			del.Objects = append(del.Objects, &s3.ObjectIdentifier{
+				Key: aws.String(key),
+			})
+		}
+		keys = keys[n:]
+		reply, err := w.s3.DeleteObjectsWithContext(ctx, &s3.DeleteObjectsInput{
+			Bucket: &bucket,
+			Delete: &del,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to delete objects")
+		}
+		for _, e := range reply.Errors {
+			err = multierr.Append(err, errors.Errorf("failed to delete object %q: %s",
+				aws.StringValue(e.Key), aws.StringValue(e.Message)))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compactObjects merges a batch of objects into a new object at key.
+func (w *compactWorker) compactObjects(ctx context.Context, bucket, key string, objects []*s3.Object, seen map[string]struct{}) error {
+	r, pw := io.Pipe()
+	var stats CompactStats
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		gz := gzip.NewWriter(pw)
+		err := func() error {
+			for _, obj := range objects {
+				reply, err := w.s3.GetObjectWithContext(ctx, &s3.GetObjectInput{
+					Bucket: &bucket,
+					Key:    obj.Key,
+				})
+				if err != nil {
+					return errors.Wrapf(err, "failed to get object %q", aws.StringValue(obj.Key))
+				}
+				numRows, numDuplicates, err := mergeRows(gz, reply.Body, seen)
+				_ = reply.Body.Close()
+				if err != nil {
+					return errors.Wrapf(err, "failed to read object %q", aws.StringValue(obj.Key))
+				}
+				stats.NumRows += numRows
+				stats.NumDuplicates += numDuplicates
+				stats.NumBytes += aws.Int64Value(obj.Size)
+			}
+			return gz.Close()
+		}()
+		// Aborts the upload if we failed to read any of the objects
+		_ = pw.CloseWithError(err)
+	}()
+	uploader := s3manager.NewUploaderWithClient(w.s3)
+	if _, err := uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket: &bucket,
+		Key:    &key,
+		Body:   r,
+	}); err != nil {
+		// Make sure the writer goroutine exits
+		_ = r.CloseWithError(err)
+		return errors.Wrapf(err, "failed to upload compacted object %q", key)
+	}
+	<-done
+	stats.NumObjects += len(objects)
+	stats.NumObjectsOut++
+	w.stats.merge(stats)
+	return nil
+}
+
+// stagedPartitionPrefix checks if prefix is a staging prefix and returns the partition prefix it belongs to.
+func stagedPartitionPrefix(prefix string) (string, bool) {
+	dir := strings.TrimSuffix(prefix, "/")
+	if !strings.HasPrefix(path.Base(dir), compactStagingPrefix) {
+		return "", false
+	}
+	return path.Dir(dir) + "/", true
+}
+
+// copySource builds the URL encoded source of a CopyObject request
+func copySource(bucket, key string) string {
+	parts := strings.Split(key, "/")
+	for i, part := range parts {
+		parts[i] = url.PathEscape(part)
+	}
+	return bucket + "/" + strings.Join(parts, "/")
+}
+
+// mergeRows copies the rows of a gzip JSON lines stream to w skipping rows whose `p_row_id` was already seen.
+func mergeRows(w io.Writer, r io.Reader, seen map[string]struct{}) (numRows, numDuplicates int, err error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer gz.Close()
+	lines := bufio.NewReader(gz)
+	for {
+		line, err := lines.ReadBytes('\n')
+		if row := bytes.TrimSpace(line); len(row) > 0 {
+			if rowID := jsoniter.Get(row, pantherlog.FieldRowIDJSON).ToString(); rowID != "" {
+				if _, duplicate := seen[rowID]; duplicate {
+					numDuplicates++
+					continue
+				}
+				seen[rowID] = struct{}{}
+			}
+			if _, err := w.Write(row); err != nil {
+				return numRows, numDuplicates, err
+			}
+			if _, err := w.Write([]byte{'\n'}); err != nil {
+				return numRows, numDuplicates, err
+			}
+			numRows++
+		}
+		if err == io.EOF {
+			return numRows, numDuplicates, nil
+		}
+		if err != nil {
+			return numRows, numDuplicates, err
+		}
+	}
+}
+
+// isCompactCandidate checks if an object is a visible JSON file that is small enough to be merged
+// and was last modified before modifiedBefore.
+func isCompactCandidate(obj *s3.Object, targetSize int64, modifiedBefore time.Time) bool {
+	key := aws.StringValue(obj.Key)
+	if !strings.HasSuffix(key, compactObjectSuffix) {
+		return false
+	}
+	// Athena ignores files and directories starting with '_' or '.'
+	for _, name := range strings.Split(key, "/") {
+		if strings.HasPrefix(name, "_") || strings.HasPrefix(name, ".") {
+			return false
+		}
+	}
+	if !aws.TimeValue(obj.LastModified).Before(modifiedBefore) {
+		return false
+	}
+	size := aws.Int64Value(obj.Size)
+	return 0 < size && size < targetSize
+}
+
+// buildCompactBatches groups objects in batches of up to targetSize bytes.
+// Batches with a single object are dropped since there is nothing to merge.
+func buildCompactBatches(objects []*s3.Object, targetSize int64) (batches [][]*s3.Object) {
+	sort.Slice(objects, func(i, j int) bool {
+		return aws.StringValue(objects[i].Key) < aws.StringValue(objects[j].Key)
+	})
+	var batch []*s3.Object
+	var batchSize int64
+	for _, obj := range objects {
+		size := aws.Int64Value(obj.Size)
+		if len(batch) > 0 && (batchSize+size > targetSize || len(batch) == maxCompactObjects) {
+			if len(batch) > 1 {
+				batches = append(batches, batch)
+			}
+			batch, batchSize = nil, 0
+		}
+		batch = append(batch, obj)
+		batchSize += size
+	}
+	if len(batch) > 1 {
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+// buildCompactRange limits the scan range to hours that have ended.
+// Objects in these partitions can still be modified by late events, so each object is also checked for MinAge.
+func buildCompactRange(tbl *glue.TableData, start, end, now time.Time) (time.Time, time.Time) {
+	// The current hour is still being written to
+	maxTime := hourly.Truncate(now.UTC())
+	if start.IsZero() {
+		start = aws.TimeValue(tbl.CreateTime)
+	}
+	if end.IsZero() || end.After(maxTime) {
+		end = maxTime
+	}
+	return hourly.Truncate(start.UTC()), hourly.Truncate(end.UTC())
+}
+
+type CompactStats struct {
+	NumPartitions int
+	NumCompacted  int
+	NumResumed    int
+	NumSkipped    int
+	NumObjects    int
+	NumObjectsOut int
+	NumBytes      int64
+	NumRows       int
+	NumDuplicates int
+}
+
+func (s *CompactStats) merge(others ...CompactStats) {
+	for _, other := range others {
+		s.NumPartitions += other.NumPartitions
+		s.NumCompacted += other.NumCompacted
+		s.NumResumed += other.NumResumed
+		s.NumSkipped += other.NumSkipped
+		s.NumObjects += other.NumObjects
+		s.NumObjectsOut += other.NumObjectsOut
+		s.NumBytes += other.NumBytes
+		s.NumRows += other.NumRows
+		s.NumDuplicates += other.NumDuplicates
+	}
+}
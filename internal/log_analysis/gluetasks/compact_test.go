@@ -0,0 +1,265 @@
+package gluetasks
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/glue"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+func gzipLines(t *testing.T, lines ...string) *bytes.Buffer {
+	buf := &bytes.Buffer{}
+	w := gzip.NewWriter(buf)
+	for _, line := range lines {
+		_, err := w.Write([]byte(line + "\n"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf
+}
+
+func TestMergeRows(t *testing.T) {
+	assert := require.New(t)
+	seen := make(map[string]struct{})
+	out := bytes.Buffer{}
+	numRows, numDuplicates, err := mergeRows(&out, gzipLines(t,
+		`{"p_row_id":"a","foo":1}`,
+		``,
+		`{"p_row_id":"b","foo":2}`,
+	), seen)
+	assert.NoError(err)
+	assert.Equal(2, numRows)
+	assert.Equal(0, numDuplicates)
+
+	// Last line without a newline, duplicate row and a row without p_row_id
+	last := &bytes.Buffer{}
+	w := gzip.NewWriter(last)
+	_, err = w.Write([]byte("{\"p_row_id\":\"a\",\"foo\":1}\n{\"foo\":3}\n{\"p_row_id\":\"c\",\"foo\":4}"))
+	assert.NoError(err)
+	assert.NoError(w.Close())
+	numRows, numDuplicates, err = mergeRows(&out, last, seen)
+	assert.NoError(err)
+	assert.Equal(2, numRows)
+	assert.Equal(1, numDuplicates)
+
+	expect := `{"p_row_id":"a","foo":1}
+{"p_row_id":"b","foo":2}
+{"foo":3}
+{"p_row_id":"c","foo":4}
+`
+	assert.Equal(expect, out.String())
+
+	_, _, err = mergeRows(&out, bytes.NewReader([]byte("not gzip")), seen)
+	assert.Error(err)
+}
+
+func TestBuildCompactBatches(t *testing.T) {
+	assert := require.New(t)
+	obj := func(key string, size int64) *s3.Object {
+		return &s3.Object{
+			Key:  aws.String(key),
+			Size: aws.Int64(size),
+		}
+	}
+	const targetSize = 100
+	objects := []*s3.Object{
+		obj("logs/hour=01/20200101T010000Z-3.json.gz", 50),
+		obj("logs/hour=01/20200101T010000Z-1.json.gz", 40),
+		obj("logs/hour=01/20200101T010000Z-2.json.gz", 40),
+		obj("logs/hour=01/20200101T010000Z-4.json.gz", 30),
+	}
+	var keys [][]string
+	for _, batch := range buildCompactBatches(objects, targetSize) {
+		var batchKeys []string
+		for _, obj := range batch {
+			batchKeys = append(batchKeys, aws.StringValue(obj.Key))
+		}
+		keys = append(keys, batchKeys)
+	}
+	assert.Equal([][]string{
+		{"logs/hour=01/20200101T010000Z-1.json.gz", "logs/hour=01/20200101T010000Z-2.json.gz"},
+		{"logs/hour=01/20200101T010000Z-3.json.gz", "logs/hour=01/20200101T010000Z-4.json.gz"},
+	}, keys)
+
+	// Nothing to merge
+	assert.Empty(buildCompactBatches(objects[:1], targetSize))
+	assert.Empty(buildCompactBatches([]*s3.Object{obj("a.json.gz", 90), obj("b.json.gz", 90)}, targetSize))
+}
+
+func TestIsCompactCandidate(t *testing.T) {
+	assert := require.New(t)
+	modifiedBefore := time.Date(2020, 1, 1, 2, 0, 0, 0, time.UTC)
+	obj := func(key string, size int64) *s3.Object {
+		return &s3.Object{
+			Key:          aws.String(key),
+			Size:         aws.Int64(size),
+			LastModified: aws.Time(modifiedBefore.Add(-time.Minute)),
+		}
+	}
+	assert.True(isCompactCandidate(obj("logs/hour=01/20200101T010000Z-1.json.gz", 10), 100, modifiedBefore))
+	assert.False(isCompactCandidate(obj("logs/hour=01/20200101T010000Z-1.json.gz", 0), 100, modifiedBefore))
+	assert.False(isCompactCandidate(obj("logs/hour=01/20200101T010000Z-1.json.gz", 100), 100, modifiedBefore))
+	assert.False(isCompactCandidate(obj("logs/hour=01/_20200101T010000Z-1.json.gz", 10), 100, modifiedBefore))
+	assert.False(isCompactCandidate(obj("logs/hour=01/20200101T010000Z-1.parquet", 10), 100, modifiedBefore))
+	assert.False(isCompactCandidate(obj("logs/hour=01/_compacted-1/20200101T010000Z-1.json.gz", 10), 100, modifiedBefore))
+
+	// Late events written to an older partition
+	recent := obj("logs/hour=01/20200101T010000Z-1.json.gz", 10)
+	recent.LastModified = aws.Time(modifiedBefore)
+	assert.False(isCompactCandidate(recent, 100, modifiedBefore))
+}
+
+func TestBuildCompactRange(t *testing.T) {
+	assert := require.New(t)
+	now := time.Date(2020, 1, 2, 10, 30, 0, 0, time.UTC)
+	tbl := &glue.TableData{
+		CreateTime: aws.Time(time.Date(2020, 1, 1, 5, 20, 0, 0, time.UTC)),
+	}
+	start, end := buildCompactRange(tbl, time.Time{}, time.Time{}, now)
+	assert.Equal(time.Date(2020, 1, 1, 5, 0, 0, 0, time.UTC), start)
+	// 10:00-11:00 has not ended yet
+	assert.Equal(time.Date(2020, 1, 2, 10, 0, 0, 0, time.UTC), end)
+
+	start, end = buildCompactRange(tbl, now.Add(-24*time.Hour), now.Add(-2*time.Hour), now)
+	assert.Equal(time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC), start)
+	assert.Equal(time.Date(2020, 1, 2, 8, 0, 0, 0, time.UTC), end)
+}
+
+func TestStagedPartitionPrefix(t *testing.T) {
+	assert := require.New(t)
+	prefix, ok := stagedPartitionPrefix("logs/year=2020/month=01/day=01/hour=01/_compacted-1/")
+	assert.True(ok)
+	assert.Equal("logs/year=2020/month=01/day=01/hour=01/", prefix)
+	_, ok = stagedPartitionPrefix("logs/year=2020/month=01/day=01/hour=01/")
+	assert.False(ok)
+}
+
+func TestCompactPartitionResumeSwap(t *testing.T) {
+	assert := require.New(t)
+	s3Mock := &testutils.S3Mock{}
+	glueMock := &testutils.GlueMock{}
+	const partitionPrefix = "logs/hour=01/"
+	const stagingPrefix = partitionPrefix + "_compacted-1/"
+	task := compactTask{
+		table: &glue.TableData{
+			DatabaseName: aws.String("db"),
+			Name:         aws.String("table"),
+		},
+		partition: &glue.Partition{
+			Values: aws.StringSlice([]string{"2020", "01", "01", "01"}),
+			StorageDescriptor: &glue.StorageDescriptor{
+				Location: aws.String("s3://bucket/" + stagingPrefix),
+			},
+		},
+		hour: time.Date(2020, 1, 1, 1, 0, 0, 0, time.UTC),
+	}
+	s3Mock.On("GetObjectWithContext", mock.Anything, mock.MatchedBy(func(input *s3.GetObjectInput) bool {
+		return aws.StringValue(input.Key) == stagingPrefix+compactManifestName
+	}), mock.Anything).Return(&s3.GetObjectOutput{
+		Body: ioutil.NopCloser(bytes.NewReader([]byte(`{"objects":["logs/hour=01/a.json.gz"]}`))),
+	}, nil).Once()
+	s3Mock.On("DeleteObjectsWithContext", mock.Anything, mock.MatchedBy(func(input *s3.DeleteObjectsInput) bool {
+		return len(input.Delete.Objects) == 1 && aws.StringValue(input.Delete.Objects[0].Key) == "logs/hour=01/a.json.gz"
+	}), mock.Anything).Return(&s3.DeleteObjectsOutput{}, nil).Once()
+	s3Mock.On("ListObjectsV2PagesWithContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&s3.ListObjectsV2Output{
+		Contents: []*s3.Object{
+			{Key: aws.String(stagingPrefix + "b.json.gz")},
+			{Key: aws.String(stagingPrefix + compactManifestName)},
+		},
+	}, nil).Once()
+	s3Mock.On("CopyObjectWithContext", mock.Anything, &s3.CopyObjectInput{
+		Bucket:     aws.String("bucket"),
+		Key:        aws.String(partitionPrefix + "b.json.gz"),
+		CopySource: aws.String("bucket/logs/hour=01/_compacted-1/b.json.gz"),
+	}, mock.Anything).Return(&s3.CopyObjectOutput{}, nil).Once()
+	glueMock.On("UpdatePartitionWithContext", mock.Anything, mock.MatchedBy(func(input *glue.UpdatePartitionInput) bool {
+		return aws.StringValue(input.PartitionInput.StorageDescriptor.Location) == "s3://bucket/"+partitionPrefix
+	})).Return(&glue.UpdatePartitionOutput{}, nil).Once()
+	s3Mock.On("DeleteObjectsWithContext", mock.Anything, mock.MatchedBy(func(input *s3.DeleteObjectsInput) bool {
+		return len(input.Delete.Objects) == 2
+	}), mock.Anything).Return(&s3.DeleteObjectsOutput{}, nil).Once()
+
+	w := compactWorker{
+		s3:   s3Mock,
+		glue: glueMock,
+		log:  zap.NewNop(),
+	}
+	assert.NoError(w.compactPartition(context.Background(), task))
+	s3Mock.AssertExpectations(t)
+	glueMock.AssertExpectations(t)
+	assert.Equal(1, w.stats.NumResumed)
+	assert.Equal("s3://bucket/"+partitionPrefix, aws.StringValue(task.partition.StorageDescriptor.Location))
+}
+
+func TestCompactTablePartitionsLimit(t *testing.T) {
+	assert := require.New(t)
+	s3Mock := &testutils.S3Mock{}
+	glueMock := &testutils.GlueMock{}
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	glueMock.On("GetTableWithContext", mock.Anything, mock.Anything).Return(&glue.GetTableOutput{
+		Table: &glue.TableData{
+			DatabaseName: aws.String("db"),
+			Name:         aws.String("table"),
+			CreateTime:   aws.Time(start),
+		},
+	}, nil).Once()
+	var partitions []*glue.Partition
+	for _, hour := range []string{"02", "00", "01"} {
+		partitions = append(partitions, &glue.Partition{
+			Values: aws.StringSlice([]string{"2020", "01", "01", hour}),
+			StorageDescriptor: &glue.StorageDescriptor{
+				Location: aws.String("s3://bucket/logs/hour=" + hour + "/"),
+			},
+		})
+	}
+	glueMock.On("GetPartitionsPagesWithContext", mock.Anything, mock.Anything, mock.Anything).Return(&glue.GetPartitionsOutput{
+		Partitions: partitions,
+	}, nil).Once()
+	// Empty partitions are skipped
+	s3Mock.On("ListObjectsV2PagesWithContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(&s3.ListObjectsV2Output{}, nil).Twice()
+
+	task := CompactTablePartitions{
+		DatabaseName:  "db",
+		TableName:     "table",
+		NumWorkers:    4,
+		Start:         start,
+		End:           start.Add(3 * time.Hour),
+		MaxPartitions: 2,
+	}
+	err := task.Run(context.Background(), glueMock, s3Mock, zap.NewNop())
+	assert.Equal(ErrPartitionLimit, err)
+	assert.Equal(start.Add(time.Hour), task.LastHour)
+	assert.Equal(2, task.Stats.NumPartitions)
+	s3Mock.AssertExpectations(t)
+	glueMock.AssertExpectations(t)
+}
@@ -0,0 +1,331 @@
+package ingest
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/firehose"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	sourcemodels "github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/internal/log_analysis/http_ingest/config"
+	"github.com/panther-labs/panther/internal/log_analysis/message_forwarder/cache"
+	"github.com/panther-labs/panther/internal/log_analysis/message_forwarder/forwarder"
+	"github.com/panther-labs/panther/pkg/awsbatch/firehosebatch"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+const (
+	// Requests are routed as `POST /sources/{sourceId}`
+	SourceIDPathParameter = "sourceId"
+
+	// Firehose rejects records larger than 1000KiB, leave some room for the message envelope.
+	MaxLineSize = 1000*1024 - 1024
+
+	// API Gateway rejects payloads larger than 10MiB, decompressed bodies are limited to the same size.
+	MaxBodySize = 10 * 1024 * 1024
+
+	// Sources are reloaded periodically so that rotated or revoked secrets take effect.
+	sourcesMaxAge = 5 * time.Minute
+
+	hmacSignaturePrefix = "sha256="
+)
+
+var (
+	sourcesCache = cache.NewWithMaxAge(getSourceInfo, sourcesMaxAge)
+
+	errLineTooLong            = errors.New("log line exceeds the maximum size")
+	errBodyTooLarge           = errors.New("request body exceeds the maximum size")
+	errUnsupportedContentType = errors.New("unsupported content type")
+)
+
+// Response is the JSON body returned to HTTP clients
+type Response struct {
+	Accepted int    `json:"accepted"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Handle authenticates a batch of logs pushed to an HTTP source and forwards them to the log processor.
+// Logs are wrapped in the same messages the message forwarder produces for SQS sources
+// so that the log processor can classify them using the source configuration.
+func Handle(ctx context.Context, event *events.APIGatewayV2HTTPRequest) (*events.APIGatewayV2HTTPResponse, error) {
+	sourceID := event.PathParameters[SourceIDPathParameter]
+	cacheValue, ok := sourcesCache.Get(sourceID)
+	if !ok {
+		zap.L().Warn("request for unknown source", zap.String("sourceId", sourceID))
+		return respond(http.StatusNotFound, 0, "source not found"), nil
+	}
+	src := cacheValue.(*sourcemodels.SourceIntegration)
+
+	body, err := requestBody(event)
+	switch {
+	case errors.Is(err, errBodyTooLarge):
+		return respond(http.StatusRequestEntityTooLarge, 0, err.Error()), nil
+	case err != nil:
+		return respond(http.StatusBadRequest, 0, "failed to read request body"), nil
+	}
+	if !Authenticate(src.HTTPConfig, event.Headers, body) {
+		zap.L().Warn("failed to authenticate request", zap.String("sourceId", sourceID))
+		return respond(http.StatusUnauthorized, 0, "unauthorized"), nil
+	}
+
+	lines, err := SplitBatch(header(event.Headers, "Content-Type"), body)
+	switch {
+	case errors.Is(err, errLineTooLong):
+		return respond(http.StatusRequestEntityTooLarge, 0, err.Error()), nil
+	case errors.Is(err, errUnsupportedContentType):
+		return respond(http.StatusUnsupportedMediaType, 0, err.Error()), nil
+	case err != nil:
+		return respond(http.StatusBadRequest, 0, err.Error()), nil
+	}
+
+	firehoseRecords := make([]*firehose.Record, 0, len(lines))
+	for _, line := range lines {
+		message := forwarder.Message{
+			Payload:             line,
+			SourceIntegrationID: src.IntegrationID,
+		}
+		data, err := jsoniter.Marshal(message)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal event")
+		}
+		data = append(data, forwarder.RecordDelimiter)
+		firehoseRecords = append(firehoseRecords, &firehose.Record{Data: data})
+	}
+
+	zap.L().Debug("Sending data", zap.String("sourceId", sourceID), zap.Int("size", len(firehoseRecords)))
+	if len(firehoseRecords) == 0 {
+		return respond(http.StatusOK, 0, ""), nil
+	}
+	request := firehose.PutRecordBatchInput{
+		Records:            firehoseRecords,
+		DeliveryStreamName: &config.Env.StreamName,
+	}
+	// Lines are limited to MaxLineSize so there can be no records that are too big for a batch
+	if _, err := firehosebatch.BatchSend(ctx, config.FirehoseClient, request, config.MaxRetries); err != nil {
+		return nil, err
+	}
+	return respond(http.StatusAccepted, len(firehoseRecords), ""), nil
+}
+
+// Authenticate checks the request credentials against the HTTP source configuration.
+func Authenticate(cfg *sourcemodels.HTTPConfig, headers map[string]string, body []byte) bool {
+	if cfg == nil || cfg.AuthSecret == "" {
+		return false
+	}
+	value := header(headers, cfg.Header())
+	if value == "" {
+		return false
+	}
+	switch cfg.AuthMethod {
+	case sourcemodels.HTTPAuthSharedSecret:
+		return subtle.ConstantTimeCompare([]byte(value), []byte(cfg.AuthSecret)) == 1
+	case sourcemodels.HTTPAuthHMAC:
+		signature, err := hex.DecodeString(strings.TrimPrefix(value, hmacSignaturePrefix))
+		if err != nil {
+			return false
+		}
+		return hmac.Equal(signature, Sign(cfg.AuthSecret, body))
+	default:
+		return false
+	}
+}
+
+// Sign computes the HMAC-SHA256 of a request body.
+func Sign(secret string, body []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(body)
+	return mac.Sum(nil)
+}
+
+// SplitBatch splits a request body into log lines according to its content type.
+//
+// Plain text and NDJSON bodies are split on newlines.
+// JSON bodies can hold either a single event or an array of events.
+func SplitBatch(contentType string, body []byte) ([]string, error) {
+	mediaType := "text/plain"
+	if contentType != "" {
+		var err error
+		if mediaType, _, err = mime.ParseMediaType(contentType); err != nil {
+			return nil, errors.WithMessagef(errUnsupportedContentType, "invalid content type %q (%s)", contentType, err)
+		}
+	}
+	switch mediaType {
+	case "text/plain", "application/x-ndjson", "application/jsonl", "application/x-jsonlines":
+		return splitLines(body)
+	case "application/json":
+		return splitJSON(body)
+	default:
+		return nil, errors.WithMessagef(errUnsupportedContentType, "content type %q", mediaType)
+	}
+}
+
+func splitLines(body []byte) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	scanner.Buffer(make([]byte, 0, 64*1024), MaxLineSize)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return nil, errLineTooLong
+		}
+		return nil, errors.Wrap(err, "invalid body")
+	}
+	return lines, nil
+}
+
+func splitJSON(body []byte) ([]string, error) {
+	iter := jsoniter.ConfigDefault.BorrowIterator(body)
+	defer jsoniter.ConfigDefault.ReturnIterator(iter)
+
+	var lines []string
+	addLine := func(raw jsoniter.RawMessage) error {
+		var buf bytes.Buffer
+		// Compact the value so that it fits in a single line
+		if err := json.Compact(&buf, raw); err != nil {
+			return errors.Wrap(err, "invalid JSON body")
+		}
+		if buf.Len() > MaxLineSize {
+			return errLineTooLong
+		}
+		lines = append(lines, buf.String())
+		return nil
+	}
+	if iter.WhatIsNext() == jsoniter.ArrayValue {
+		for iter.ReadArray() {
+			if err := addLine(iter.SkipAndReturnBytes()); err != nil {
+				return nil, err
+			}
+		}
+	} else if err := addLine(iter.SkipAndReturnBytes()); err != nil {
+		return nil, err
+	}
+	if iter.Error != nil {
+		return nil, errors.Wrap(iter.Error, "invalid JSON body")
+	}
+	return lines, nil
+}
+
+func requestBody(event *events.APIGatewayV2HTTPRequest) ([]byte, error) {
+	body := []byte(event.Body)
+	if event.IsBase64Encoded {
+		decoded, err := base64.StdEncoding.DecodeString(event.Body)
+		if err != nil {
+			return nil, err
+		}
+		body = decoded
+	}
+	if strings.EqualFold(header(event.Headers, "Content-Encoding"), "gzip") {
+		r, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		// Guard against decompression bombs
+		decompressed, err := ioutil.ReadAll(io.LimitReader(r, MaxBodySize+1))
+		if err != nil {
+			return nil, err
+		}
+		body = decompressed
+	}
+	if len(body) > MaxBodySize {
+		return nil, errBodyTooLarge
+	}
+	return body, nil
+}
+
+// API Gateway HTTP APIs lower-case header names but we do not rely on it.
+func header(headers map[string]string, name string) string {
+	if value, ok := headers[strings.ToLower(name)]; ok {
+		return value
+	}
+	for key, value := range headers {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	return ""
+}
+
+func respond(statusCode, accepted int, errMessage string) *events.APIGatewayV2HTTPResponse {
+	body, _ := jsoniter.MarshalToString(Response{
+		Accepted: accepted,
+		Error:    errMessage,
+	})
+	return &events.APIGatewayV2HTTPResponse{
+		StatusCode: statusCode,
+		Headers:    map[string]string{"Content-Type": "application/json"},
+		Body:       body,
+	}
+}
+
+func getSourceInfo() (map[string]interface{}, error) {
+	input := &sourcemodels.LambdaInput{ListIntegrations: &sourcemodels.ListIntegrationsInput{
+		IntegrationType: aws.String(sourcemodels.IntegrationTypeHTTP),
+	}}
+	var output []*sourcemodels.SourceIntegration
+	err := genericapi.Invoke(config.LambdaClient, config.SourceAPIFunctionName, input, &output)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch available integrations")
+	}
+	result := make(map[string]interface{}, len(output))
+	for _, source := range output {
+		if source.HTTPConfig == nil {
+			continue
+		}
+		// The source API never returns the secret, it is read from Secrets Manager
+		secret, err := config.SecretsClient.GetSecretValue(&secretsmanager.GetSecretValueInput{
+			SecretId: &source.CredentialsSecretARN,
+		})
+		if err != nil {
+			// Requests for this source are rejected until the secret can be read
+			zap.L().Error("failed to read source secret", zap.String("sourceId", source.IntegrationID), zap.Error(err))
+			continue
+		}
+		source.HTTPConfig.AuthSecret = aws.StringValue(secret.SecretString)
+		result[source.IntegrationID] = source
+	}
+	return result, nil
+}
@@ -0,0 +1,279 @@
+package ingest
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/firehose"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/internal/log_analysis/http_ingest/config"
+	"github.com/panther-labs/panther/internal/log_analysis/message_forwarder/cache"
+	"github.com/panther-labs/panther/internal/log_analysis/message_forwarder/forwarder"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+const (
+	secretSourceID = "45c378a7-2e36-4b12-8e16-2d3c49ff1371"
+	hmacSourceID   = "45c378a7-2e36-4b12-8e16-2d3c49ff1372"
+	testSecret     = "0123456789abcdef"
+	testSecretARN  = "arn:aws:secretsmanager:us-east-1:123456789012:secret:panther-source-credentials-test"
+)
+
+var availableHTTPSources = []*models.SourceIntegration{
+	{
+		SourceIntegrationMetadata: models.SourceIntegrationMetadata{
+			IntegrationID:   secretSourceID,
+			IntegrationType: models.IntegrationTypeHTTP,
+			HTTPConfig: &models.HTTPConfig{
+				LogTypes:   []string{"Custom.Test"},
+				AuthMethod: models.HTTPAuthSharedSecret,
+			},
+			CredentialsSecretARN: testSecretARN,
+		},
+	},
+	{
+		SourceIntegrationMetadata: models.SourceIntegrationMetadata{
+			IntegrationID:   hmacSourceID,
+			IntegrationType: models.IntegrationTypeHTTP,
+			HTTPConfig: &models.HTTPConfig{
+				LogTypes:   []string{"Custom.Test"},
+				AuthMethod: models.HTTPAuthHMAC,
+				AuthHeader: "X-Hub-Signature-256",
+			},
+			CredentialsSecretARN: testSecretARN,
+		},
+	},
+}
+
+func TestMain(m *testing.M) {
+	// The failure tests will trigger backoff, make sure it doesn't take too long
+	oldRetries := config.MaxRetries
+	config.MaxRetries = 1
+	exitVal := m.Run()
+	config.MaxRetries = oldRetries
+
+	os.Exit(exitVal)
+}
+
+func TestHandleSharedSecret(t *testing.T) {
+	mockLambda, mockFirehose := setupMocks(t)
+
+	expectedFirehoseInput := &firehose.PutRecordBatchInput{
+		Records:            expectedRecords(t, secretSourceID, `{"a":1}`, `plain text`),
+		DeliveryStreamName: aws.String("testStreamName"),
+	}
+	mockFirehose.On("PutRecordBatchWithContext", mock.Anything, expectedFirehoseInput, mock.Anything).
+		Return(&firehose.PutRecordBatchOutput{}, nil)
+
+	response, err := Handle(context.TODO(), &events.APIGatewayV2HTTPRequest{
+		PathParameters: map[string]string{SourceIDPathParameter: secretSourceID},
+		Headers: map[string]string{
+			"x-panther-secret": testSecret,
+			"content-type":     "application/x-ndjson",
+		},
+		Body: "{\"a\":1}\n\n  plain text  \n",
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusAccepted, response.StatusCode)
+	require.JSONEq(t, `{"accepted":2}`, response.Body)
+
+	mockLambda.AssertExpectations(t)
+	mockFirehose.AssertExpectations(t)
+}
+
+func TestHandleHMAC(t *testing.T) {
+	mockLambda, mockFirehose := setupMocks(t)
+
+	body := `[{"a": 1}, {"b": [1, 2]}]`
+	expectedFirehoseInput := &firehose.PutRecordBatchInput{
+		Records:            expectedRecords(t, hmacSourceID, `{"a":1}`, `{"b":[1,2]}`),
+		DeliveryStreamName: aws.String("testStreamName"),
+	}
+	mockFirehose.On("PutRecordBatchWithContext", mock.Anything, expectedFirehoseInput, mock.Anything).
+		Return(&firehose.PutRecordBatchOutput{}, nil)
+
+	response, err := Handle(context.TODO(), &events.APIGatewayV2HTTPRequest{
+		PathParameters: map[string]string{SourceIDPathParameter: hmacSourceID},
+		Headers: map[string]string{
+			"x-hub-signature-256": "sha256=" + hex.EncodeToString(Sign(testSecret, []byte(body))),
+			"content-type":        "application/json; charset=utf-8",
+		},
+		Body: body,
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusAccepted, response.StatusCode)
+
+	mockLambda.AssertExpectations(t)
+	mockFirehose.AssertExpectations(t)
+}
+
+func TestHandleUnauthorized(t *testing.T) {
+	mockLambda, mockFirehose := setupMocks(t)
+
+	response, err := Handle(context.TODO(), &events.APIGatewayV2HTTPRequest{
+		PathParameters: map[string]string{SourceIDPathParameter: hmacSourceID},
+		Headers: map[string]string{
+			// A shared secret is not a valid signature
+			"x-hub-signature-256": testSecret,
+		},
+		Body: "payload",
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+
+	mockLambda.AssertExpectations(t)
+	mockFirehose.AssertNotCalled(t, "PutRecordBatchWithContext")
+}
+
+func TestHandleUnknownSource(t *testing.T) {
+	mockLambda, mockFirehose := setupMocks(t)
+
+	response, err := Handle(context.TODO(), &events.APIGatewayV2HTTPRequest{
+		PathParameters: map[string]string{SourceIDPathParameter: "unknown"},
+		Headers:        map[string]string{"x-panther-secret": testSecret},
+		Body:           "payload",
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, response.StatusCode)
+
+	mockLambda.AssertExpectations(t)
+	mockFirehose.AssertNotCalled(t, "PutRecordBatchWithContext")
+}
+
+func TestHandleInvalidBody(t *testing.T) {
+	for _, tc := range []struct {
+		contentType string
+		body        string
+		statusCode  int
+	}{
+		{"application/json", `[{"a":`, http.StatusBadRequest},
+		{"application/xml", `<a/>`, http.StatusUnsupportedMediaType},
+		{"application/", `<a/>`, http.StatusUnsupportedMediaType},
+	} {
+		mockLambda, mockFirehose := setupMocks(t)
+
+		response, err := Handle(context.TODO(), &events.APIGatewayV2HTTPRequest{
+			PathParameters: map[string]string{SourceIDPathParameter: secretSourceID},
+			Headers: map[string]string{
+				"x-panther-secret": testSecret,
+				"content-type":     tc.contentType,
+			},
+			Body: tc.body,
+		})
+		require.NoError(t, err)
+		require.Equal(t, tc.statusCode, response.StatusCode, tc.contentType)
+
+		mockLambda.AssertExpectations(t)
+		mockFirehose.AssertNotCalled(t, "PutRecordBatchWithContext")
+	}
+}
+
+func TestHandleGzipTooLarge(t *testing.T) {
+	mockLambda, mockFirehose := setupMocks(t)
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write(make([]byte, MaxBodySize+1))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	response, err := Handle(context.TODO(), &events.APIGatewayV2HTTPRequest{
+		PathParameters: map[string]string{SourceIDPathParameter: secretSourceID},
+		Headers: map[string]string{
+			"x-panther-secret": testSecret,
+			"content-encoding": "gzip",
+		},
+		Body:            base64.StdEncoding.EncodeToString(buf.Bytes()),
+		IsBase64Encoded: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusRequestEntityTooLarge, response.StatusCode)
+
+	mockLambda.AssertExpectations(t)
+	mockFirehose.AssertNotCalled(t, "PutRecordBatchWithContext")
+}
+
+func TestSplitBatch(t *testing.T) {
+	lines, err := SplitBatch("", []byte("a\r\nb\n"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, lines)
+
+	lines, err = SplitBatch("application/json", []byte(`{"a": {"b": 1}}`))
+	require.NoError(t, err)
+	require.Equal(t, []string{`{"a":{"b":1}}`}, lines)
+
+	_, err = SplitBatch("application/json", []byte(`[{"a":`))
+	require.Error(t, err)
+
+	_, err = SplitBatch("application/xml", []byte(`<a/>`))
+	require.True(t, errors.Is(err, errUnsupportedContentType))
+}
+
+func setupMocks(t *testing.T) (*testutils.LambdaMock, *testutils.FirehoseMock) {
+	mockLambda := &testutils.LambdaMock{}
+	config.LambdaClient = mockLambda
+	mockFirehose := &testutils.FirehoseMock{}
+	config.FirehoseClient = mockFirehose
+	config.Env.StreamName = "testStreamName"
+	mockSecrets := &testutils.SecretsManagerMock{}
+	config.SecretsClient = mockSecrets
+	sourcesCache = cache.NewWithMaxAge(getSourceInfo, sourcesMaxAge)
+
+	marshaledSources, err := jsoniter.Marshal(availableHTTPSources)
+	require.NoError(t, err)
+	mockLambda.On("Invoke", mock.Anything).Return(
+		&lambda.InvokeOutput{
+			Payload:    marshaledSources,
+			StatusCode: aws.Int64(http.StatusOK),
+		}, nil)
+	mockSecrets.On("GetSecretValue", &secretsmanager.GetSecretValueInput{SecretId: aws.String(testSecretARN)}).
+		Return(&secretsmanager.GetSecretValueOutput{SecretString: aws.String(testSecret)}, nil)
+	return mockLambda, mockFirehose
+}
+
+func expectedRecords(t *testing.T, sourceID string, payloads ...string) (records []*firehose.Record) {
+	for _, payload := range payloads {
+		serializedMsg, err := jsoniter.MarshalToString(forwarder.Message{
+			Payload:             payload,
+			SourceIntegrationID: sourceID,
+		})
+		require.NoError(t, err)
+		records = append(records, &firehose.Record{
+			Data: []byte(serializedMsg + "\n"),
+		})
+	}
+	return records
+}
@@ -51,6 +51,27 @@ type ClassifierResult struct {
 	NumMiss int
 }
 
+// ParserError is the error of a parser that failed to parse a log line
+type ParserError struct {
+	LogType string
+	Err     error
+}
+
+// ClassificationError is returned by ClassifierAPI#Classify when no parser could parse a log line
+type ClassificationError struct {
+	// Log is the log line that could not be classified
+	Log string
+	// SourceID is set if the log line belongs to a different source than the one of the data stream
+	// This is the case for sources that forward messages (i.e. SQS sources)
+	SourceID string
+	// Errors contains the error of each parser that failed to parse the log line
+	Errors []ParserError
+}
+
+func (e *ClassificationError) Error() string {
+	return "failed to classify log line"
+}
+
 // NewClassifier returns a new instance of a ClassifierAPI implementation
 func NewClassifier(parsers map[string]parsers.Interface) ClassifierAPI {
 	return &Classifier{
@@ -96,6 +117,8 @@ func (c *Classifier) Classify(log string) (*ClassifierResult, error) {
 	startClassify := time.Now().UTC()
 	// Slice containing the popped queue items
 	var popped []interface{}
+	// Errors of the parsers that failed
+	var parserErrors []ParserError
 	result := &ClassifierResult{}
 
 	if len(log) == 0 { // likely empty file, nothing to do
@@ -132,6 +155,10 @@ func (c *Classifier) Classify(log string) (*ClassifierResult, error) {
 		// Parser failed to parse event
 		if err != nil {
 			zap.L().Debug("failed to parse event", zap.String("expectedLogType", logType), zap.Error(err))
+			parserErrors = append(parserErrors, ParserError{
+				LogType: logType,
+				Err:     err,
+			})
 			// Removing parser from queue
 			popped = append(popped, heap.Pop(c.parsers))
 			// Increasing penalty of the parser
@@ -171,7 +198,10 @@ func (c *Classifier) Classify(log string) (*ClassifierResult, error) {
 		heap.Push(c.parsers, item)
 	}
 	if !result.Matched {
-		return result, errors.New("failed to classify log line")
+		return result, &ClassificationError{
+			Log:    log,
+			Errors: parserErrors,
+		}
 	}
 	return result, nil
 }
@@ -129,6 +129,12 @@ func TestClassifyNoMatch(t *testing.T) {
 
 	result, err := classifier.Classify(logLine)
 	require.Error(t, err)
+	classErr, ok := err.(*ClassificationError)
+	require.True(t, ok)
+	require.Equal(t, logLine, classErr.Log)
+	require.Len(t, classErr.Errors, 1)
+	require.Equal(t, "failure", classErr.Errors[0].LogType)
+	require.EqualError(t, classErr.Errors[0].Err, "fail")
 
 	// skipping specifically validating the times
 	expectedStats.ClassifyTimeMicroseconds = classifier.Stats().ClassifyTimeMicroseconds
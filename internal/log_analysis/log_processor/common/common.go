@@ -19,7 +19,9 @@ package common
  */
 
 import (
+	"context"
 	"io"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/request"
@@ -35,7 +37,10 @@ import (
 	"github.com/kelseyhightower/envconfig"
 
 	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/geoip"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/metrics"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/processor/logstream"
 	"github.com/panther-labs/panther/pkg/awsretry"
 )
@@ -55,14 +60,24 @@ var (
 	SnsClient    snsiface.SNSAPI
 
 	Config EnvConfig
+
+	// Enricher adds indicators derived from the values collected from log events (nil if not configured)
+	Enricher pantherlog.ValueEnricher
 )
 
 type EnvConfig struct {
-	AwsLambdaFunctionMemorySize int    `required:"true" split_words:"true"`
-	ProcessedDataBucket         string `required:"true" split_words:"true"`
-	SqsQueueURL                 string `required:"true" split_words:"true"`
-	SqsBatchSize                int64  `required:"true" split_words:"true"`
-	SnsTopicARN                 string `required:"true" split_words:"true"`
+	AwsLambdaFunctionMemorySize int                `required:"true" split_words:"true"`
+	ProcessedDataBucket         string             `required:"true" split_words:"true"`
+	SqsQueueURL                 string             `required:"true" split_words:"true"`
+	SqsBatchSize                int64              `required:"true" split_words:"true"`
+	SnsTopicARN                 string             `required:"true" split_words:"true"`
+	ProcessedDataFormat         awsglue.DataFormat `default:"json" split_words:"true"`
+	// Paths of MaxMind DB files used to add country and ASN indicators for IP addresses
+	GeoIPDatabases []string `envconfig:"GEOIP_DATABASES"`
+	// Memory budget for the rows of lookup tables joined onto events
+	LookupTablesMaxMemoryMB int `default:"64" split_words:"true"`
+	// How often lookup tables are checked for updates
+	LookupTablesRefreshInterval time.Duration `default:"5m" split_words:"true"`
 }
 
 func Setup() {
@@ -81,6 +96,17 @@ func Setup() {
 	if err != nil {
 		panic(err)
 	}
+	Config.ProcessedDataFormat, err = awsglue.ParseDataFormat(string(Config.ProcessedDataFormat))
+	if err != nil {
+		panic(err)
+	}
+	enricher, err := geoip.OpenEnricher(Config.GeoIPDatabases...)
+	if err != nil {
+		panic(err)
+	}
+	if enricher != nil {
+		Enricher = enricher
+	}
 	metrics.Setup()
 }
 
@@ -91,4 +117,16 @@ type DataStream struct {
 	Source      *models.SourceIntegration
 	S3ObjectKey string
 	S3Bucket    string
+	// ArchiveFile is the name of the file in an archive S3 object that the stream reads.
+	ArchiveFile string
+	// Archive is set if the S3 object is an archive of multiple files.
+	// In this case Stream is nil and each file in the archive is read as a separate DataStream.
+	Archive Archive
+}
+
+// Archive reads the files contained in an archive S3 object
+type Archive interface {
+	// NextDataStream returns the DataStream of the next file in the archive or io.EOF if there are no more files.
+	// Files in an archive can only be read in sequence, so it blocks until the previous DataStream is closed.
+	NextDataStream(ctx context.Context) (*DataStream, error)
 }
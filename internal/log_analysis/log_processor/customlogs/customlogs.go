@@ -28,8 +28,11 @@ import (
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/customlogs/customparser"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logschema"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/lookuptables"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/preprocessors"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/processor/logstream"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/transform"
 )
 
 const LogTypePrefix = "Custom"
@@ -66,6 +69,9 @@ func Build(name string, schema *logschema.Schema) (logtypes.Entry, error) {
 		return nil, err
 	}
 	eventType := typ.Elem()
+	if err := lookuptables.CheckEventType(eventType); err != nil {
+		return nil, err
+	}
 	eventSchema, err := pantherlog.BuildEventTypeSchema(eventType)
 	if err != nil {
 		return nil, err
@@ -74,6 +80,13 @@ func Build(name string, schema *logschema.Schema) (logtypes.Entry, error) {
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to build preprocessor")
 	}
+	var multiLine *logstream.MultiLineConfig
+	if schema.Parser != nil && schema.Parser.MultiLine != nil {
+		multiLine = schema.Parser.MultiLine
+		if err := multiLine.Validate(); err != nil {
+			return nil, err
+		}
+	}
 	entry, err := logtypes.Config{
 		Name:         name,
 		Description:  desc.Description,
@@ -91,9 +104,49 @@ func Build(name string, schema *logschema.Schema) (logtypes.Entry, error) {
 	if err != nil {
 		return nil, errors.WithMessage(err, "log type entry generation failed")
 	}
+	plan, err := transform.Build(schema)
+	if err != nil {
+		return nil, err
+	}
+	entry = transform.WrapEntry(entry, plan)
+	if multiLine != nil {
+		return &multiLineEntry{
+			Entry:  entry,
+			config: *multiLine,
+		}, nil
+	}
 	return entry, nil
 }
 
+// multiLineEntry is a log type entry for events that span multiple lines.
+// It implements logstream.MultiLineLogType so that the log processor can reassemble events before parsing.
+type multiLineEntry struct {
+	logtypes.Entry
+	config logstream.MultiLineConfig
+}
+
+var _ logstream.MultiLineLogType = (*multiLineEntry)(nil)
+
+func (e *multiLineEntry) MultiLineConfig() *logstream.MultiLineConfig {
+	config := e.config
+	return &config
+}
+
+func (e *multiLineEntry) BuildEntry() (logtypes.Entry, error) {
+	return e, nil
+}
+
+func (e *multiLineEntry) Find(logType string) logtypes.Entry {
+	if e.String() == logType {
+		return e
+	}
+	return nil
+}
+
+func (e *multiLineEntry) Entries() []logtypes.Entry {
+	return []logtypes.Entry{e}
+}
+
 func buildPreprocessor(parser *logschema.Parser) (preprocessors.Interface, error) {
 	switch {
 	case parser == nil:
@@ -104,6 +157,14 @@ func buildPreprocessor(parser *logschema.Parser) (preprocessors.Interface, error
 		return parser.CSV.BuildPreprocessor()
 	case parser.Regex != nil:
 		return parser.Regex.BuildPreprocessor()
+	case parser.KeyValue != nil:
+		return parser.KeyValue.BuildPreprocessor()
+	case parser.CEF != nil:
+		return parser.CEF.BuildPreprocessor()
+	case parser.LEEF != nil:
+		return parser.LEEF.BuildPreprocessor()
+	case parser.XML != nil:
+		return parser.XML.BuildPreprocessor()
 	default:
 		return preprocessors.Nop(), nil
 	}
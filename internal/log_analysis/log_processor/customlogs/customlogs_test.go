@@ -44,6 +44,7 @@ import (
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logschema"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes/logtesting"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/processor/logstream"
 )
 
 func ExampleBuild() {
@@ -304,6 +305,96 @@ func TestVPCFlowLog_CSV(t *testing.T) {
 	logtesting.TestRegisteredParser(t, entry, entry.String(), vpcFlowSampleLog, expectJSON)
 }
 
+//nolint: lll
+func TestTextParsers(t *testing.T) {
+	for _, tc := range []struct {
+		SchemaFile string
+		Log        string
+		Expect     string
+	}{
+		{
+			SchemaFile: "../logschema/testdata/logfmt_schema.yml",
+			Log:        `ts=2020-10-01T13:30:00Z level=info msg="user logged in" remote_ip=10.0.0.1 user=- duration_ms=12.5`,
+			Expect: `{
+  "ts": "2020-10-01T13:30:00Z",
+  "level": "info",
+  "message": "user logged in",
+  "remote_ip": "10.0.0.1",
+  "duration_ms": 12.5,
+  "p_log_type": "%s",
+  "p_any_ip_addresses": ["10.0.0.1"],
+  "p_event_time": "2020-10-01T13:30:00Z"
+}`,
+		},
+		{
+			SchemaFile: "../logschema/testdata/cef_schema.yml",
+			Log:        `Oct 01 13:30:00 host CEF:0|Security|threatmanager|1.0|100|worm successfully stopped|10|src=10.0.0.1 dst=2.1.2.2 rt=1601559000000 msg=Detected a threat\=worm. No action needed`,
+			Expect: `{
+  "version": 0,
+  "deviceVendor": "Security",
+  "deviceProduct": "threatmanager",
+  "deviceVersion": "1.0",
+  "deviceEventClassId": "100",
+  "name": "worm successfully stopped",
+  "severity": 10,
+  "sourceAddress": "10.0.0.1",
+  "destinationAddress": "2.1.2.2",
+  "receiptTime": 1601559000000,
+  "msg": "Detected a threat=worm. No action needed",
+  "p_log_type": "%s",
+  "p_any_ip_addresses": ["10.0.0.1", "2.1.2.2"],
+  "p_event_time": "2020-10-01T13:30:00Z"
+}`,
+		},
+		{
+			SchemaFile: "../logschema/testdata/leef_schema.yml",
+			Log:        "LEEF:2.0|Lancope|StealthWatch|1.0|41|^|src=10.0.1.8^dst=10.0.0.5^sev=5^devTime=Oct 01 2020 13:30:00",
+			Expect: `{
+  "version": "2.0",
+  "vendor": "Lancope",
+  "product": "StealthWatch",
+  "productVersion": "1.0",
+  "eventId": "41",
+  "sourceAddress": "10.0.1.8",
+  "destinationAddress": "10.0.0.5",
+  "sev": 5,
+  "devTime": "Oct 01 2020 13:30:00",
+  "p_log_type": "%s",
+  "p_any_ip_addresses": ["10.0.0.5", "10.0.1.8"],
+  "p_event_time": "2020-10-01T13:30:00Z"
+}`,
+		},
+		{
+			SchemaFile: "../logschema/testdata/xml_schema.yml",
+			Log:        `<audit><record time="2020-10-01T13:30:00Z"><user ip="10.0.0.1">alice</user><action>login</action><tag>web</tag></record></audit>`,
+			Expect: `{
+  "@time": "2020-10-01T13:30:00Z",
+  "user": {"@ip": "10.0.0.1", "value": "alice"},
+  "action": "login",
+  "tag": ["web"],
+  "p_log_type": "%s",
+  "p_any_ip_addresses": ["10.0.0.1"],
+  "p_any_usernames": ["alice"],
+  "p_event_time": "2020-10-01T13:30:00Z"
+}`,
+		},
+	} {
+		tc := tc
+		t.Run(tc.SchemaFile, func(t *testing.T) {
+			assert := require.New(t)
+			data, err := ioutil.ReadFile(tc.SchemaFile)
+			assert.NoError(err)
+			logSchema := logschema.Schema{}
+			assert.NoError(yaml.Unmarshal(data, &logSchema))
+			assert.NoError(logschema.ValidateSchema(&logSchema))
+			entry, err := customlogs.Build(logSchema.Schema, &logSchema)
+			assert.NoError(err)
+			assert.NotNil(entry)
+			logtesting.TestRegisteredParser(t, entry, entry.String(), tc.Log, fmt.Sprintf(tc.Expect, entry.String()))
+		})
+	}
+}
+
 func TestNameCollisions(t *testing.T) {
 	schema := logschema.Schema{
 		Fields: []logschema.FieldSchema{
@@ -328,3 +419,100 @@ func TestNameCollisions(t *testing.T) {
 	assert.Error(err)
 	assert.Nil(entry)
 }
+
+func TestBuild_MultiLine(t *testing.T) {
+	assert := require.New(t)
+	data, err := ioutil.ReadFile("../logschema/testdata/multiline_schema.yml")
+	assert.NoError(err)
+	logSchema := logschema.Schema{}
+	assert.NoError(yaml.Unmarshal(data, &logSchema))
+	assert.NoError(logschema.ValidateSchema(&logSchema))
+	entry, err := customlogs.Build(logSchema.Schema, &logSchema)
+	assert.NoError(err)
+	multiLine, ok := entry.(logstream.MultiLineLogType)
+	assert.True(ok)
+	assert.Equal(&logstream.MultiLineConfig{
+		StartPattern: `^\d{4}-\d\d-\d\dT`,
+		MaxLines:     200,
+	}, multiLine.MultiLineConfig())
+	assert.Equal(entry, entry.Find(entry.String()))
+
+	logSchema.Parser.MultiLine.StartPattern = "["
+	_, err = customlogs.Build(logSchema.Schema, &logSchema)
+	assert.Error(err)
+}
+
+func TestBuild_Transform(t *testing.T) {
+	assert := require.New(t)
+	const spec = `
+schema: Transform
+version: 0
+fields:
+- name: card
+  type: string
+  transform:
+  - redact:
+      pattern: '\d{12}(\d{4})'
+      replace: '************$1'
+- name: user
+  type: string
+  transform:
+  - copy:
+      target: user_hash
+- name: user_hash
+  type: string
+  indicators: [username]
+  transform:
+  - hash:
+      salt: pepper
+- name: vendor_ip
+  type: string
+  transform:
+  - rename:
+      target: ip
+- name: ip
+  type: string
+  indicators: [ip]
+- name: noise
+  type: json
+  transform:
+  - drop: true
+- name: tags
+  type: array
+  element:
+    type: string
+  transform:
+  - truncate:
+      length: 3
+`
+	logSchema := logschema.Schema{}
+	assert.NoError(yaml.Unmarshal([]byte(spec), &logSchema))
+	assert.NoError(logschema.ValidateSchema(&logSchema))
+	entry, err := customlogs.Build("Custom.Transform", &logSchema)
+	assert.NoError(err)
+	parser, err := entry.NewParser(nil)
+	assert.NoError(err)
+	input := `{"card":"4111111111111111","user":"alice","vendor_ip":"10.0.0.1","noise":{"a":1},"tags":["abcdef","xy"]}`
+	results, err := parser.ParseLog(input)
+	assert.NoError(err)
+	assert.Len(results, 1)
+	data, err := pantherlog.ConfigJSON().Marshal(results[0])
+	assert.NoError(err)
+	// sha256("pepperalice")
+	const userHash = "b1b68da447843a6519d8dd7a9c13c90aa1148805cbe55810f86712e6c294ff36"
+	actual := gjson.ParseBytes(data)
+	assert.Equal("************1111", actual.Get("card").String())
+	assert.Equal("alice", actual.Get("user").String())
+	assert.Equal(userHash, actual.Get("user_hash").String())
+	// Indicators are scanned after the transforms are applied
+	assert.Equal(`["`+userHash+`"]`, actual.Get("p_any_usernames").Raw)
+	assert.False(actual.Get("vendor_ip").Exists())
+	assert.Equal("10.0.0.1", actual.Get("ip").String())
+	assert.Equal(`["10.0.0.1"]`, actual.Get("p_any_ip_addresses").Raw)
+	assert.False(actual.Get("noise").Exists())
+	assert.Equal(`["abc","xy"]`, actual.Get("tags").Raw)
+
+	logSchema.Fields[1].Transform[0].Copy.Target = "missing"
+	_, err = customlogs.Build("Custom.Transform", &logSchema)
+	assert.Error(err)
+}
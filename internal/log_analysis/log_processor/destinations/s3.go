@@ -21,7 +21,9 @@ package destinations
 import (
 	"bytes"
 	"compress/gzip"
+	"context"
 	"fmt"
+	"io"
 	"path"
 	"runtime"
 	"sync"
@@ -37,8 +39,12 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue/glueparquet"
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue/glueschema"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
 	logmetrics "github.com/panther-labs/panther/internal/log_analysis/log_processor/metrics"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/sources"
 	"github.com/panther-labs/panther/internal/log_analysis/notify"
@@ -75,7 +81,7 @@ func init() {
 	memUsedAtStartupMB = (int)(memStats.Sys/(1024*1024)) + 1
 }
 
-func CreateS3Destination(jsonAPI jsoniter.API) Destination {
+func CreateS3Destination(jsonAPI jsoniter.API, resolver logtypes.Resolver) Destination {
 	if jsonAPI == nil {
 		jsonAPI = jsoniter.ConfigDefault
 	}
@@ -92,6 +98,9 @@ func CreateS3Destination(jsonAPI jsoniter.API) Destination {
 		maxDuration:         maxDuration,
 		maxBuffers:          maxBuffers,
 		jsonAPI:             jsonAPI,
+		dataFormat:          common.Config.ProcessedDataFormat,
+		resolver:            resolver,
+		enricher:            common.Enricher,
 	}
 }
 
@@ -129,6 +138,12 @@ type S3Destination struct {
 	latencyCounter      metrics.Counter
 	outputFiles         metrics.Counter
 	outputBytes         metrics.Counter
+	// dataFormat is the format of the files in the data lake, either gzipped JSON lines or Parquet
+	dataFormat awsglue.DataFormat
+	// resolver is used to get the columns of Parquet files
+	resolver logtypes.Resolver
+	// enricher adds derived indicator values to events (optional)
+	enricher pantherlog.ValueEnricher
 }
 
 // SendEvents stores events in S3.
@@ -228,23 +243,26 @@ func (d *S3Destination) sendData(buffer *s3EventBuffer, errChan chan error) {
 		return
 	}
 
-	var (
-		err error
-		key string
-	)
+	key := getS3ObjectKey(buffer)
 
-	key = getS3ObjectKey(buffer)
+	payload, err := buffer.read()
 	if err != nil {
 		errChan <- err
 		return
 	}
 
-	payload, err := buffer.read()
-	if err != nil {
+	if err := d.upload(key, payload); err != nil {
 		errChan <- err
 		return
 	}
 
+	err = d.sendSNSNotification(key, buffer) // if send fails we fail whole operation
+	if err != nil {
+		errChan <- err
+	}
+}
+
+func (d *S3Destination) upload(key string, payload []byte) error {
 	if _, err := d.s3Uploader.Upload(&s3manager.UploadInput{
 		Bucket: &d.s3Bucket,
 		Key:    &key,
@@ -253,16 +271,11 @@ func (d *S3Destination) sendData(buffer *s3EventBuffer, errChan chan error) {
 		u.Concurrency = (len(payload) / uploaderPartSize) + 1 // if it evenly divides an extra won't matter
 		u.PartSize = uploaderPartSize
 	}); err != nil {
-		errChan <- errors.Wrap(err, "S3Upload")
-		return
-	}
-
-	err = d.sendSNSNotification(key, buffer) // if send fails we fail whole operation
-	if err != nil {
-		errChan <- err
+		return errors.Wrap(err, "S3Upload")
 	}
 	d.outputBytes.Add(float64(len(payload)))
 	d.outputFiles.Add(1)
+	return nil
 }
 
 func (d *S3Destination) sendSNSNotification(key string, buffer *s3EventBuffer) error {
@@ -287,17 +300,20 @@ func (d *S3Destination) sendSNSNotification(key string, buffer *s3EventBuffer) e
 	return err
 }
 
-// getS3ObjectKey builds the S3 object key for storing a partition file of processed logs.
+// getS3ObjectKey builds the S3 object key for storing a partition file of processed logs
 func getS3ObjectKey(buf *s3EventBuffer) string {
 	typ := pantherdb.GetDataType(buf.logType)
 	db := pantherdb.DatabaseName(typ)
 	table := pantherdb.TableName(buf.logType)
 	partitionPrefix := awsglue.PartitionPrefix(db, table, awsglue.GlueTableHourly, buf.hour)
-	filename := fmt.Sprintf("%s-%s.json.gz",
+	filename := fmt.Sprintf("%s-%s",
 		buf.hour.Format(S3ObjectTimestampLayout),
 		uuid.New(),
 	)
-	return path.Join(partitionPrefix, filename)
+	if buf.parquet != nil {
+		return path.Join(partitionPrefix, filename+".parquet")
+	}
+	return path.Join(partitionPrefix, filename+".json.gz")
 }
 
 // s3BufferSet is a group of buffers associated with hour time bins, pointing to maps logtype->s3EventBuffer
@@ -312,12 +328,19 @@ type s3EventBufferSet struct {
 	maxBufferSize           int
 	maxTotalSize            uint64
 	latencyCounter          metrics.Counter
+	dataFormat              awsglue.DataFormat
+	resolver                logtypes.Resolver
+	parquetColumns          map[string][]glueschema.Column
 }
 
 func (d *S3Destination) newS3EventBufferSet() *s3EventBufferSet {
 	const initialBufferSize = 8192
 	// Stream will be a buffered stream
 	stream := jsoniter.NewStream(d.jsonAPI, nil, initialBufferSize)
+	// The result encoder picks up the enricher from the stream attachment
+	if d.enricher != nil {
+		stream.Attachment = d.enricher
+	}
 	return &s3EventBufferSet{
 		stream:         stream,
 		set:            make(map[time.Time]map[string]*s3EventBuffer),
@@ -325,6 +348,9 @@ func (d *S3Destination) newS3EventBufferSet() *s3EventBufferSet {
 		maxBufferSize:  d.maxBufferSize,
 		maxTotalSize:   d.maxBufferedMemBytes,
 		latencyCounter: d.latencyCounter,
+		dataFormat:     d.dataFormat,
+		resolver:       d.resolver,
+		parquetColumns: make(map[string][]glueschema.Column),
 	}
 }
 
@@ -346,6 +372,13 @@ func (bs *s3EventBufferSet) writeEvent(event *parsers.Result) (sendBuffers []*s3
 	if buf == nil {
 		return nil, errors.New(`could not resolve a buffer for the event`)
 	}
+	if bs.dataFormat == awsglue.DataFormatParquet && buf.parquet == nil {
+		if buf.parquet, err = bs.newParquetWriter(buf.logType, buf.buffer); err != nil {
+			return nil, err
+		}
+		// Events are written only to the Parquet file
+		buf.writer = nil
+	}
 	// We need to increment the counter here since the event.PantherParseTime and event.PantherEventTime are populated only
 	// after the event has been serialized
 	buf.latencyCounter.Add(event.PantherParseTime.Sub(event.PantherEventTime).Seconds())
@@ -426,6 +459,25 @@ func (bs *s3EventBufferSet) getBuffer(event *parsers.Result) *s3EventBuffer {
 	return buffer
 }
 
+func (bs *s3EventBufferSet) newParquetWriter(logType string, w io.Writer) (*glueparquet.Writer, error) {
+	columns, ok := bs.parquetColumns[logType]
+	if !ok {
+		entry, err := bs.resolver.Resolve(context.Background(), logType)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to resolve log type %q", logType)
+		}
+		if entry == nil {
+			return nil, errors.Errorf("unresolved log type %q", logType)
+		}
+		columns, err = glueschema.InferColumns(entry.Schema())
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to infer columns for log type %q", logType)
+		}
+		bs.parquetColumns[logType] = columns
+	}
+	return glueparquet.NewWriter(w, columns)
+}
+
 func (bs *s3EventBufferSet) removeBuffer(buffer *s3EventBuffer) {
 	logTypeToBuffer, ok := bs.set[buffer.hour]
 	if !ok {
@@ -486,7 +538,8 @@ func (bs *s3EventBufferSet) apply(f func(buffer *s3EventBuffer) (bool, error)) e
 type s3EventBuffer struct {
 	logType        string
 	buffer         *bytes.Buffer
-	writer         *gzip.Writer
+	writer         *gzip.Writer        // set if the events are written as gzipped JSON lines
+	parquet        *glueparquet.Writer // set if the events are written to a Parquet file
 	bytes          int
 	events         int
 	hour           time.Time // the event time bin
@@ -510,15 +563,23 @@ func newS3EventBuffer(latencyCounter metrics.Counter, logType string, hour time.
 
 // addEvent adds new data to the s3EventBuffer, return bytes added and error
 func (b *s3EventBuffer) addEvent(data []byte) (int, error) {
+	startBufferSize := b.bytes
+	if b.parquet != nil {
+		if err := b.parquet.WriteJSON(data); err != nil {
+			return 0, errors.Wrap(err, "failed to add data to parquet buffer")
+		}
+		// size of the encoded pages and of the column values that are not encoded yet
+		b.bytes = b.buffer.Len() + b.parquet.Size()
+		b.events++
+		return b.bytes - startBufferSize, nil
+	}
 	// FIXME: To have proper JSONL data in the buffers we need to write "\n" *before* writing the JSON if startBufferSize is zero
-	startBufferSize := b.buffer.Len()
 	if _, err := b.writer.Write(data); err != nil {
 		return 0, err
 	}
 	if _, err := b.writer.Write(newLineDelimiter); err != nil {
 		return 0, errors.Wrap(err, "failed to add data to buffer %s")
 	}
-
 	b.bytes = b.buffer.Len() // size of compressed data minus gzip buffer (that's ok we just use this for memory pressure)
 	b.events++
 	return b.bytes - startBufferSize, nil
@@ -526,17 +587,22 @@ func (b *s3EventBuffer) addEvent(data []byte) (int, error) {
 
 func (b *s3EventBuffer) read() ([]byte, error) {
 	// get last buffered data into buffer
-	if err := b.writer.Close(); err != nil {
+	if b.parquet != nil {
+		if err := b.parquet.Close(); err != nil {
+			return nil, errors.Wrap(err, "close failed in buffer read()")
+		}
+	} else if err := b.writer.Close(); err != nil {
 		return nil, errors.Wrap(err, "close failed in buffer read()")
 	}
 
 	data := b.buffer.Bytes()
-	b.bytes = len(data) // true final size after flushing gzip buffer
+	b.bytes = len(data) // true final size after flushing gzip buffer or writing the parquet footer
 
 	// clear to make GC more effective
 	b.buffer.Reset()
 	b.buffer = nil
 	b.writer = nil
+	b.parquet = nil
 
 	return data, nil
 }
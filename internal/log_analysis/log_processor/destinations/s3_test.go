@@ -38,7 +38,10 @@ import (
 	"go.uber.org/multierr"
 
 	"github.com/panther-labs/panther/internal/compliance/snapshotlogs"
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue/glueparquet"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
@@ -621,3 +624,56 @@ func runDestination(destination Destination, events chan *parsers.Result) error
 	}
 	return foundErr
 }
+
+func TestSendDataParquet(t *testing.T) {
+	t.Parallel()
+
+	destination := mockDestination()
+	destination.dataFormat = awsglue.DataFormatParquet
+	destination.resolver = logtypes.LocalResolver(logtypes.Must("test", logtypes.Config{
+		Name:         testLogType,
+		Description:  "Test log type",
+		ReferenceURL: "-",
+		Schema:       &fooEvent{},
+		NewParser: pantherlog.FactoryFunc(func(_ interface{}) (pantherlog.LogParser, error) {
+			return nil, errors.New("not implemented")
+		}),
+	}))
+
+	destination.mockLatencyCounter.On("With", mock.Anything).Return(destination.mockLatencyCounter).Once()
+	destination.mockLatencyCounter.On("Add", mock.Anything).Once()
+	destination.mockOutputBytesCounter.On("Add", mock.Anything).Once()
+	destination.mockOutputFilesCounter.On("Add", mock.Anything).Once()
+
+	eventChannel := make(chan *parsers.Result, 1)
+	eventChannel <- newTestResult(nil)
+	close(eventChannel)
+
+	destination.mockS3Uploader.On("Upload", mock.Anything, mock.Anything).Return(&s3manager.UploadOutput{}, nil).Once()
+	destination.mockSns.On("Publish", mock.Anything).Return(&sns.PublishOutput{}, nil).Once()
+
+	assert.NoError(t, runDestination(destination, eventChannel))
+
+	destination.AssertExpectations(t)
+
+	// Only the parquet object is uploaded
+	uploadInput := destination.mockS3Uploader.Calls[0].Arguments.Get(0).(*s3manager.UploadInput)
+	key := *uploadInput.Key
+	assert.True(t, strings.HasPrefix(key, expectedS3Prefix))
+	assert.True(t, strings.HasSuffix(key, ".parquet"))
+	parquetBytes, _ := ioutil.ReadAll(uploadInput.Body)
+	r, err := glueparquet.NewReader(bytes.NewReader(parquetBytes), int64(len(parquetBytes)))
+	require.NoError(t, err)
+	defer r.Close()
+	row := r.Next()
+	require.NotNil(t, row)
+	assert.Equal(t, "bar", jsoniter.Get(row, "foo").ToString())
+	assert.Equal(t, "2020-01-01 00:01:01.000000000", jsoniter.Get(row, "ts").ToString())
+	assert.Nil(t, r.Next())
+	require.NoError(t, r.Err())
+
+	publishInput := destination.mockSns.Calls[0].Arguments.Get(0).(*sns.PublishInput)
+	expectedS3Notification := notify.NewS3ObjectPutNotification(destination.s3Bucket, key, len(parquetBytes))
+	marshaledExpectedS3Notification, _ := jsoniter.MarshalToString(expectedS3Notification)
+	assert.Equal(t, marshaledExpectedS3Notification, aws.StringValue(publishInput.Message))
+}
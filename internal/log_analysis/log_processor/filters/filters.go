@@ -0,0 +1,239 @@
+package filters
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+	"math/rand"
+	"net"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+// Filter decides which classified events of a source are kept
+type Filter struct {
+	rules []rule
+	// Random number generator for sampling, returns a number in [0.0,1.0)
+	rand func() float64
+}
+
+type rule struct {
+	logType     string
+	path        []string
+	equals      []string
+	networks    []*net.IPNet
+	pattern     *regexp.Regexp
+	sampleRatio float64
+}
+
+// Build compiles the event filters of a source.
+// It returns nil if there are no filters.
+func Build(filters []models.EventFilter) (*Filter, error) {
+	if len(filters) == 0 {
+		return nil, nil
+	}
+	rules := make([]rule, 0, len(filters))
+	for i := range filters {
+		f := &filters[i]
+		if err := f.Validate(); err != nil {
+			return nil, errors.WithMessagef(err, "invalid event filter #%d", i+1)
+		}
+		r := rule{
+			logType:     f.LogType,
+			equals:      f.Equals,
+			sampleRatio: *f.SampleRatio,
+		}
+		if f.Field != "" {
+			r.path = strings.Split(f.Field, ".")
+		}
+		for _, cidr := range f.CIDR {
+			_, network, _ := net.ParseCIDR(cidr)
+			r.networks = append(r.networks, network)
+		}
+		if f.Regex != "" {
+			r.pattern = regexp.MustCompile(f.Regex)
+		}
+		rules = append(rules, r)
+	}
+	return &Filter{
+		rules: rules,
+		rand:  rand.Float64,
+	}, nil
+}
+
+// Keep checks if an event should be kept.
+// The first filter that matches the event decides, events that do not match any filter are kept.
+func (f *Filter) Keep(result *pantherlog.Result) bool {
+	if f == nil || result == nil {
+		return true
+	}
+	for i := range f.rules {
+		r := &f.rules[i]
+		if !r.match(result) {
+			continue
+		}
+		switch r.sampleRatio {
+		case 0:
+			return false
+		case 1:
+			return true
+		default:
+			return f.rand() < r.sampleRatio
+		}
+	}
+	return true
+}
+
+func (r *rule) match(result *pantherlog.Result) bool {
+	if r.logType != "" && r.logType != result.PantherLogType {
+		return false
+	}
+	if r.path == nil {
+		return true
+	}
+	value, ok := lookupValue(reflect.ValueOf(result.Event), r.path)
+	if !ok {
+		return false
+	}
+	switch {
+	case r.equals != nil:
+		for _, v := range r.equals {
+			if v == value {
+				return true
+			}
+		}
+		return false
+	case r.networks != nil:
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return false
+		}
+		for _, network := range r.networks {
+			if network.Contains(ip) {
+				return true
+			}
+		}
+		return false
+	case r.pattern != nil:
+		return r.pattern.MatchString(value)
+	default:
+		// A filter with a field and no condition matches events where the field is set
+		return true
+	}
+}
+
+// lookupValue finds the value at a path of JSON field names and formats it as a string.
+func lookupValue(v reflect.Value, path []string) (string, bool) {
+	for _, name := range path {
+		v = indirect(v)
+		switch v.Kind() {
+		case reflect.Struct:
+			v = fieldByJSONName(v, name)
+		case reflect.Map:
+			if v.Type().Key().Kind() != reflect.String {
+				return "", false
+			}
+			v = v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+		default:
+			return "", false
+		}
+		if !v.IsValid() {
+			return "", false
+		}
+	}
+	return formatValue(indirect(v))
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func fieldByJSONName(v reflect.Value, name string) reflect.Value {
+	typ := v.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		if idx := strings.IndexByte(tag, ','); idx != -1 {
+			tag = tag[:idx]
+		}
+		if field.PkgPath == "" && (tag == name || (tag == "" && !field.Anonymous && field.Name == name)) {
+			return v.Field(i)
+		}
+		if field.Anonymous && tag == "" {
+			// Fields of embedded structs are promoted
+			if embedded := indirect(v.Field(i)); embedded.Kind() == reflect.Struct {
+				if f := fieldByJSONName(embedded, name); f.IsValid() {
+					return f
+				}
+			}
+		}
+	}
+	return reflect.Value{}
+}
+
+func formatValue(v reflect.Value) (string, bool) {
+	if !v.IsValid() {
+		return "", false
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), true
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return fmt.Sprint(v.Interface()), true
+	case reflect.Struct:
+		// Nullable values (i.e. null.String) store their value in a `Value` field
+		if exists := v.FieldByName("Exists"); exists.IsValid() && exists.Kind() == reflect.Bool {
+			if value := v.FieldByName("Value"); value.IsValid() {
+				if !exists.Bool() {
+					return "", false
+				}
+				return formatValue(value)
+			}
+		}
+		if s, ok := v.Interface().(fmt.Stringer); ok {
+			return s.String(), true
+		}
+		if v.CanAddr() {
+			if s, ok := v.Addr().Interface().(fmt.Stringer); ok {
+				return s.String(), true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,159 @@
+package filters
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+type testRequest struct {
+	Method null.String `json:"method"`
+	Status int         `json:"status"`
+}
+
+type testEmbedded struct {
+	Host string `json:"host"`
+}
+
+type testEvent struct {
+	testEmbedded
+	RemoteIP null.String  `json:"remote_ip"`
+	Path     string       `json:"path"`
+	Request  *testRequest `json:"request,omitempty"`
+}
+
+func testResult(logType string, event interface{}) *pantherlog.Result {
+	result := pantherlog.Result{Event: event}
+	result.PantherLogType = logType
+	return &result
+}
+
+func TestFilter(t *testing.T) {
+	assert := require.New(t)
+	filter, err := Build([]models.EventFilter{
+		{
+			LogType: "Foo.Bar",
+			Field:   "request.method",
+			Equals:  []string{"OPTIONS", "HEAD"},
+			// Drop everything
+			SampleRatio: aws.Float64(0),
+		},
+		{
+			Field:       "remote_ip",
+			CIDR:        []string{"10.0.0.0/8"},
+			SampleRatio: aws.Float64(0),
+		},
+		{
+			Field:       "path",
+			Regex:       `^/health`,
+			SampleRatio: aws.Float64(0),
+		},
+		{
+			Field:  "request.status",
+			Equals: []string{"200"},
+			// Keep everything, stops evaluating the filters below
+			SampleRatio: aws.Float64(1),
+		},
+		{
+			Field:       "host",
+			Equals:      []string{"noisy.example.com"},
+			SampleRatio: aws.Float64(0),
+		},
+	})
+	assert.NoError(err)
+	assert.NotNil(filter)
+
+	event := func(method, ip, path string, status int) *testEvent {
+		return &testEvent{
+			testEmbedded: testEmbedded{Host: "noisy.example.com"},
+			RemoteIP:     null.FromString(ip),
+			Path:         path,
+			Request: &testRequest{
+				Method: null.FromString(method),
+				Status: status,
+			},
+		}
+	}
+	assert.False(filter.Keep(testResult("Foo.Bar", event("HEAD", "", "/", 0))))
+	assert.True(filter.Keep(testResult("Foo.Baz", event("HEAD", "", "/", 200))))
+	assert.False(filter.Keep(testResult("Foo.Baz", event("GET", "10.1.2.3", "/", 200))))
+	assert.False(filter.Keep(testResult("Foo.Baz", event("GET", "", "/healthz", 200))))
+	assert.True(filter.Keep(testResult("Foo.Baz", event("GET", "192.168.1.1", "/", 200))))
+	// Matches the embedded host field
+	assert.False(filter.Keep(testResult("Foo.Baz", event("GET", "192.168.1.1", "/", 404))))
+	// Missing values do not match
+	assert.False(filter.Keep(testResult("Foo.Baz", &testEvent{testEmbedded: testEmbedded{Host: "noisy.example.com"}})))
+	assert.True(filter.Keep(testResult("Foo.Baz", &testEvent{Path: "/"})))
+	// Events that are not structs
+	assert.True(filter.Keep(testResult("Foo.Baz", map[string]interface{}{"path": "/"})))
+	assert.False(filter.Keep(testResult("Foo.Baz", map[string]interface{}{"path": "/health"})))
+}
+
+func TestFilterSampling(t *testing.T) {
+	assert := require.New(t)
+	filter, err := Build([]models.EventFilter{
+		{
+			LogType:     "Foo.Bar",
+			SampleRatio: aws.Float64(0.25),
+		},
+	})
+	assert.NoError(err)
+	n := 0.0
+	filter.rand = func() float64 {
+		n += 0.1
+		return n
+	}
+	var kept int
+	for i := 0; i < 8; i++ {
+		if filter.Keep(testResult("Foo.Bar", &testEvent{})) {
+			kept++
+		}
+	}
+	assert.Equal(2, kept)
+	assert.True(filter.Keep(testResult("Foo.Baz", &testEvent{})))
+}
+
+func TestBuild(t *testing.T) {
+	assert := require.New(t)
+	filter, err := Build(nil)
+	assert.NoError(err)
+	assert.Nil(filter)
+	assert.True(filter.Keep(testResult("Foo.Bar", &testEvent{})))
+
+	_, err = Build([]models.EventFilter{{Field: "remote_ip", CIDR: []string{"10.0.0.0"}}})
+	assert.Error(err)
+	_, err = Build([]models.EventFilter{{Field: "path", Regex: "("}})
+	assert.Error(err)
+	_, err = Build([]models.EventFilter{{Equals: []string{"foo"}}})
+	assert.Error(err)
+	_, err = Build([]models.EventFilter{{Field: "path", Equals: []string{"foo"}, Regex: "foo"}})
+	assert.Error(err)
+	_, err = Build([]models.EventFilter{{SampleRatio: aws.Float64(1.5)}})
+	assert.Error(err)
+	// The sample ratio is required so that omitting it does not drop all events
+	_, err = Build([]models.EventFilter{{LogType: "Foo.Bar"}})
+	assert.Error(err)
+}
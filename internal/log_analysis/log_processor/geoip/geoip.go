@@ -0,0 +1,159 @@
+package geoip
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net"
+	"strconv"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/oschwald/maxminddb-golang"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+// The number of IP addresses whose indicators are kept in memory
+const cacheSize = 8192
+
+// Enricher adds country and autonomous system indicators for the IP addresses collected from a log event.
+//
+// It looks up each value of `p_any_ip_addresses` in the loaded MaxMind DB files and writes:
+//   - the ISO country code (GeoIP2/GeoLite2 Country and City databases) to `p_any_countries`
+//   - the AS number (GeoIP2/GeoLite2 ASN databases) to `p_any_asns`
+//   - the AS organization (GeoIP2/GeoLite2 ASN databases) to `p_any_as_organizations`
+type Enricher struct {
+	readers []*maxminddb.Reader
+	// IP address to *indicators, lookups of the same address are frequent within a log file
+	cache *lru.Cache
+}
+
+var _ pantherlog.ValueEnricher = (*Enricher)(nil)
+
+// NewEnricher creates an enricher looking up IP addresses in all readers.
+func NewEnricher(readers ...*maxminddb.Reader) *Enricher {
+	cache, err := lru.New(cacheSize)
+	if err != nil {
+		panic(err)
+	}
+	return &Enricher{
+		readers: readers,
+		cache:   cache,
+	}
+}
+
+// OpenEnricher loads MaxMind DB files from disk and creates an enricher for them.
+// It returns nil if no paths are provided.
+func OpenEnricher(paths ...string) (*Enricher, error) {
+	var readers []*maxminddb.Reader
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		r, err := maxminddb.Open(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to open MaxMind DB %q", path)
+		}
+		readers = append(readers, r)
+	}
+	if len(readers) == 0 {
+		return nil, nil
+	}
+	return NewEnricher(readers...), nil
+}
+
+// EnrichValues implements pantherlog.ValueEnricher interface
+func (e *Enricher) EnrichValues(values *pantherlog.ValueBuffer) {
+	for _, addr := range values.Get(pantherlog.FieldIPAddress) {
+		if ind := e.lookup(addr); ind != nil {
+			ind.WriteValuesTo(values)
+		}
+	}
+}
+
+func (e *Enricher) lookup(addr string) *indicators {
+	if cached, ok := e.cache.Get(addr); ok {
+		return cached.(*indicators)
+	}
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return nil
+	}
+	ind := indicators{}
+	for _, r := range e.readers {
+		rec := record{}
+		if err := r.Lookup(ip, &rec); err != nil {
+			zap.L().Debug("failed to look up ip address", zap.String("ip", addr), zap.Error(err))
+			continue
+		}
+		ind.merge(&rec)
+	}
+	e.cache.Add(addr, &ind)
+	return &ind
+}
+
+// record decodes only the fields of GeoIP2/GeoLite2 Country, City and ASN records used for enrichment
+type record struct {
+	Country struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"country"`
+	RegisteredCountry struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"registered_country"`
+	ASN            uint64 `maxminddb:"autonomous_system_number"`
+	ASOrganization string `maxminddb:"autonomous_system_organization"`
+}
+
+// indicators are the values derived for an IP address from all databases
+type indicators struct {
+	Country        string
+	ASN            string
+	ASOrganization string
+}
+
+func (ind *indicators) merge(rec *record) {
+	// Prefer the country where the address is located over the country where the network is registered
+	if ind.Country == "" {
+		if rec.Country.ISOCode != "" {
+			ind.Country = rec.Country.ISOCode
+		} else {
+			ind.Country = rec.RegisteredCountry.ISOCode
+		}
+	}
+	if ind.ASN == "" && rec.ASN != 0 {
+		ind.ASN = strconv.FormatUint(rec.ASN, 10)
+	}
+	if ind.ASOrganization == "" {
+		ind.ASOrganization = rec.ASOrganization
+	}
+}
+
+// WriteValuesTo implements pantherlog.ValueWriterTo interface
+func (ind *indicators) WriteValuesTo(w pantherlog.ValueWriter) {
+	if ind.Country != "" {
+		w.WriteValues(pantherlog.FieldCountry, ind.Country)
+	}
+	if ind.ASN != "" {
+		w.WriteValues(pantherlog.FieldASN, ind.ASN)
+	}
+	if ind.ASOrganization != "" {
+		w.WriteValues(pantherlog.FieldASOrganization, ind.ASOrganization)
+	}
+}
@@ -0,0 +1,111 @@
+package geoip
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/oschwald/maxminddb-golang"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+func TestEnricher(t *testing.T) {
+	country, err := maxminddb.FromBytes(buildTestDB(t, 6, 24, map[string]interface{}{
+		"1.2.3.0/24": map[string]interface{}{
+			"city": map[string]interface{}{
+				"geoname_id": uint32(2147714),
+				"names": map[string]interface{}{
+					"en": "Sydney",
+				},
+			},
+			"country": map[string]interface{}{
+				"iso_code": "AU",
+			},
+			"subdivisions": []interface{}{
+				map[string]interface{}{
+					"iso_code": "NSW",
+				},
+			},
+		},
+		"2.2.0.0/16": map[string]interface{}{
+			"registered_country": map[string]interface{}{
+				"iso_code": "FR",
+			},
+		},
+		"2001:db8::/32": map[string]interface{}{
+			"country": map[string]interface{}{
+				"iso_code": "NL",
+			},
+		},
+	}))
+	require.NoError(t, err)
+	asn, err := maxminddb.FromBytes(buildTestDB(t, 6, 28, map[string]interface{}{
+		"1.2.3.0/24": map[string]interface{}{
+			"autonomous_system_number":       uint32(13335),
+			"autonomous_system_organization": "Example Networks",
+		},
+	}))
+	require.NoError(t, err)
+
+	enricher := NewEnricher(country, asn)
+	values := pantherlog.ValueBuffer{}
+	values.WriteValues(pantherlog.FieldIPAddress, "1.2.3.4", "2.2.2.2", "2001:db8::1", "10.0.0.1", "not-an-ip")
+	enricher.EnrichValues(&values)
+	expect := map[pantherlog.FieldID][]string{
+		pantherlog.FieldIPAddress:      {"1.2.3.4", "10.0.0.1", "2.2.2.2", "2001:db8::1", "not-an-ip"},
+		pantherlog.FieldCountry:        {"AU", "FR", "NL"},
+		pantherlog.FieldASN:            {"13335"},
+		pantherlog.FieldASOrganization: {"Example Networks"},
+	}
+	require.Equal(t, expect, values.Inspect())
+	// Invalid addresses are not cached
+	require.Equal(t, 4, enricher.cache.Len())
+
+	// Cached lookups produce the same values
+	values.Reset()
+	values.WriteValues(pantherlog.FieldIPAddress, "1.2.3.4", "2.2.2.2", "2001:db8::1", "10.0.0.1", "not-an-ip")
+	enricher.EnrichValues(&values)
+	require.Equal(t, expect, values.Inspect())
+	require.Equal(t, 4, enricher.cache.Len())
+}
+
+func TestOpenEnricher(t *testing.T) {
+	enricher, err := OpenEnricher()
+	require.NoError(t, err)
+	require.Nil(t, enricher)
+
+	dir, err := ioutil.TempDir("", "geoip")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "test.mmdb")
+	require.NoError(t, ioutil.WriteFile(path, buildTestDB(t, 4, 24, map[string]interface{}{
+		"1.2.3.0/24": map[string]interface{}{},
+	}), 0600))
+	enricher, err = OpenEnricher(path)
+	require.NoError(t, err)
+	require.NotNil(t, enricher)
+
+	_, err = OpenEnricher(filepath.Join(dir, "missing.mmdb"))
+	require.Error(t, err)
+}
@@ -0,0 +1,205 @@
+package geoip
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Data types of the MaxMind DB data section used by the test databases
+// See https://maxmind.github.io/MaxMind-DB/
+const (
+	typeString = 2
+	typeUint16 = 5
+	typeUint32 = 6
+	typeMap    = 7
+	typeUint64 = 9
+	typeArray  = 11
+	typeBool   = 14
+)
+
+// The search tree is followed by 16 zero bytes before the data section starts
+const dataSectionSeparatorSize = 16
+
+// metadataStartMarker marks the start of the metadata section at the end of a MaxMind DB file
+var metadataStartMarker = []byte("\xAB\xCD\xEFMaxMind.com")
+
+// buildTestDB writes a MaxMind DB file with the records for the networks
+func buildTestDB(t *testing.T, ipVersion int, recordSize uint, networks map[string]interface{}) []byte {
+	t.Helper()
+	const (
+		empty = -1
+	)
+	// Records are node indexes, empty or a reference to a data record (-2 - index)
+	nodes := [][2]int{{empty, empty}}
+	var data bytes.Buffer
+	var dataOffsets []int
+
+	cidrs := make([]string, 0, len(networks))
+	for cidr := range networks {
+		cidrs = append(cidrs, cidr)
+	}
+	sort.Strings(cidrs)
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		require.NoError(t, err)
+		ip := network.IP
+		ones, _ := network.Mask.Size()
+		if ipVersion == 6 {
+			// IPv4 networks are stored under ::/96
+			if ipv4 := ip.To4(); ipv4 != nil {
+				ip = append(make(net.IP, 12), ipv4...)
+				ones += 96
+			}
+		}
+
+		dataOffsets = append(dataOffsets, data.Len())
+		encodeTestValue(&data, networks[cidr])
+
+		node := 0
+		for i := 0; i < ones; i++ {
+			bit := int(ip[i>>3]>>(7-(i&7))) & 1
+			if i == ones-1 {
+				nodes[node][bit] = -2 - (len(dataOffsets) - 1)
+				break
+			}
+			next := nodes[node][bit]
+			if next == empty {
+				next = len(nodes)
+				nodes = append(nodes, [2]int{empty, empty})
+				nodes[node][bit] = next
+			}
+			node = next
+		}
+	}
+
+	var db bytes.Buffer
+	nodeCount := len(nodes)
+	for _, node := range nodes {
+		var records [2]uint
+		for i, record := range node {
+			switch {
+			case record == empty:
+				records[i] = uint(nodeCount)
+			case record < 0:
+				records[i] = uint(nodeCount + dataSectionSeparatorSize + dataOffsets[-2-record])
+			default:
+				records[i] = uint(record)
+			}
+		}
+		left, right := records[0], records[1]
+		switch recordSize {
+		case 24:
+			db.Write([]byte{byte(left >> 16), byte(left >> 8), byte(left), byte(right >> 16), byte(right >> 8), byte(right)})
+		case 28:
+			db.Write([]byte{byte(left >> 16), byte(left >> 8), byte(left)})
+			db.WriteByte(byte((left>>24)<<4) | byte((right>>24)&0x0F))
+			db.Write([]byte{byte(right >> 16), byte(right >> 8), byte(right)})
+		case 32:
+			var b [8]byte
+			binary.BigEndian.PutUint32(b[:4], uint32(left))
+			binary.BigEndian.PutUint32(b[4:], uint32(right))
+			db.Write(b[:])
+		}
+	}
+	db.Write(make([]byte, dataSectionSeparatorSize))
+	db.Write(data.Bytes())
+	db.Write(metadataStartMarker)
+	encodeTestValue(&db, map[string]interface{}{
+		"binary_format_major_version": uint16(2),
+		"binary_format_minor_version": uint16(0),
+		"database_type":               "Test",
+		"ip_version":                  uint16(ipVersion),
+		"node_count":                  uint32(nodeCount),
+		"record_size":                 uint16(recordSize),
+	})
+	return db.Bytes()
+}
+
+func encodeTestValue(buf *bytes.Buffer, value interface{}) {
+	writeControl := func(typ int, size int) {
+		var extra []byte
+		switch {
+		case size < 29:
+		case size < 285:
+			extra = []byte{byte(size - 29)}
+			size = 29
+		default:
+			size -= 285
+			extra = []byte{byte(size >> 8), byte(size)}
+			size = 30
+		}
+		if typ > 7 {
+			buf.WriteByte(byte(size))
+			buf.WriteByte(byte(typ - 7))
+		} else {
+			buf.WriteByte(byte(typ<<5 | size))
+		}
+		buf.Write(extra)
+	}
+	writeUint := func(typ int, n uint64) {
+		var b []byte
+		for ; n > 0; n >>= 8 {
+			b = append([]byte{byte(n)}, b...)
+		}
+		writeControl(typ, len(b))
+		buf.Write(b)
+	}
+	switch v := value.(type) {
+	case string:
+		writeControl(typeString, len(v))
+		buf.WriteString(v)
+	case bool:
+		size := 0
+		if v {
+			size = 1
+		}
+		writeControl(typeBool, size)
+	case uint16:
+		writeUint(typeUint16, uint64(v))
+	case uint32:
+		writeUint(typeUint32, uint64(v))
+	case uint64:
+		writeUint(typeUint64, v)
+	case []interface{}:
+		writeControl(typeArray, len(v))
+		for _, el := range v {
+			encodeTestValue(buf, el)
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		writeControl(typeMap, len(v))
+		for _, key := range keys {
+			encodeTestValue(buf, key)
+			encodeTestValue(buf, v[key])
+		}
+	default:
+		panic("unsupported test value")
+	}
+}
@@ -95,7 +95,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _schemaJson = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x1a\x7b\x6f\x14\xb7\xf3\xff\x7c\x0a\x6b\xc9\x4f\xfa\x15\x2e\x5c\xd2\x94\x56\x44\xaa\x2a\x48\xa1\x20\xf1\x88\x48\x01\x95\xdc\x25\x72\x76\x67\xef\x4c\xbd\xf6\xd6\xf6\x26\x39\xd0\x7d\xf7\x6a\xdf\x6b\xaf\xbd\x8f\x5c\x42\x55\xca\x1f\x90\x3b\x7b\x66\x3c\x33\x9e\xa7\xe7\x3e\x6f\x21\xe4\x6d\x4b\x7f\x09\x11\xf6\x0e\x90\xb7\x54\x2a\x3e\x98\x4e\x3f\x4a\xce\x76\xf2\xd5\xfb\x5c\x2c\xa6\x81\xc0\xa1\xda\xd9\xfd\x69\x9a\xaf\xdd\xf1\x26\x29\x9e\x22\x8a\x42\x8a\x75\x84\x99\x5a\x82\x40\x94\x2f\x50\x41\x2b\x03\xd8\x26\x41\x49\x54\x1e\x4c\xa7\x22\x61\x71\x0e\x79\x9f\xf0\x82\x94\x9c\x52\xbe\x90\x31\xf8\xd3\x8b\xdd\x02\x49\x40\x98\x62\xdd\x99\x06\x10\x12\x46\x14\xe1\x4c\x16\xd0\xc7\x31\xf8\x39\x54\x63\xcf\x3b\x40\xa9\x18\x08\x79\x0d\xa0\x72\x2d\x65\x73\x15\x67\x5c\xf2\xf3\x8f\xe0\xab\x0c\x3d\x5b\x8f\x05\x8f\x41\x28\x02\xb2\x01\x8d\x90\x77\x01\x42\x12\xce\xb4\x45\x84\x3c\x9f\x33\xa9\xbc\x03\xb4\x5b\x2d\xae\x27\x35\x52\xa5\x42\x0d\xa7\x3c\x5a\x2a\x41\xd8\xc2\x9b\x34\xf7\x22\xc2\x5e\x00\x5b\xa8\xa5\x77\x80\xf6\xb5\x9d\x18\x2b\x05\x22\x65\xc0\x3b\x3d\x79\xb4\xf3\x61\x9e\xfe\x87\x77\x3e\xed\xee\x3c\x9c\xdf\xfb\xff\x6c\x76\xbf\xb5\xf8\xdd\x2f\xdb\x9e\x95\xad\x00\xa4\x2f\x48\xac\x2c\xf2\x18\xbc\x59\xd1\x05\x84\x20\x80\xf9\xf0\xf6\xcd\x8b\x31\xb2\x85\x5c\x44\x38\x55\x96\x97\x08\x62\x27\x1d\x63\x21\x41\xb8\x88\x1a\x77\x95\x2b\x0c\x5f\x1d\x35\xaf\x6c\xcf\x54\x67\xc7\xae\xe3\xae\xf3\x8b\x95\x17\xad\x45\x84\x3c\xce\xe0\x75\x6a\x88\x27\xc6\x06\x6a\x81\x66\xe0\x76\xb3\xcd\xa5\x3c\x3c\x7e\xf7\x9e\xa8\xe5\x33\xc0\x01\x08\xaf\x85\xbd\x9e\xdc\xd8\x11\x3c\x51\xce\x53\x8c\x95\xf9\x56\x07\x0f\x5e\x88\xa5\x8a\xb0\xf2\x97\x36\xd5\x74\x31\xf2\x14\x4b\xf5\x32\x43\xec\xa4\x2f\x60\x01\x57\x63\x69\xbf\x49\x91\x06\x10\x67\x58\x91\x0b\x18\x4b\xfd\x55\x8e\xb5\xe5\x52\xda\xda\x6a\xc7\x21\x01\x1a\x98\x56\xe5\x38\x27\xb7\xea\xa7\x39\x86\xc3\x5f\xdb\x61\xad\xd8\xea\x72\x8d\x22\x62\x1c\x75\x58\x79\x3b\x92\x6c\x0f\x57\xd0\x05\xa6\x09\x64\x71\xd5\xad\x1d\x8d\x21\x1c\x04\x19\x2a\xa6\x1a\x4f\x21\xa6\x12\xb6\x4c\xf4\x0a\xd5\x13\xf0\x57\x42\x04\xa4\x59\xe3\xa4\x8a\xc3\x93\x4a\xc9\xb9\xc9\x16\xe0\x9e\xa6\x4d\x4b\xbc\xc7\x42\xe0\x55\x1d\xee\x23\xc2\x9e\x2b\x88\xb4\xd8\xe0\x91\x62\xa5\x11\xfe\xed\x1a\xc8\x38\x68\x6a\x60\xad\xf1\x52\x6f\x37\x18\xc1\x94\x1a\x11\x64\xf8\x85\x76\xdc\x24\xc3\x91\xd5\xb6\x5d\x01\xbd\x75\x3b\xba\xa2\x9d\x74\xce\x39\xa7\x80\x59\x37\xa1\x02\x78\xa0\x1d\xa5\xd0\xc7\x7e\xcb\x8c\x0c\x9a\xee\xa4\xd5\x2f\xa7\xd3\x22\x35\xd3\xca\x54\x38\x29\x48\xcd\x6d\x9e\x38\xc0\x9b\x2d\x4e\x51\x1e\xaf\x1b\x6a\x0d\xd8\x30\x8e\x76\x7a\x19\x70\x64\x2e\xb2\x71\xe6\x28\xa6\x73\x63\xdb\x84\x42\xe6\x56\x9b\x10\x90\x3e\xa6\x58\x6c\x42\x41\x91\xc8\x54\xfc\x28\x7c\x01\xe1\x90\x7b\xab\xac\xd5\x12\x5c\x8c\xaa\xc7\x03\x96\x44\xda\x6d\xb6\xeb\xa2\xb6\xa3\x1b\x21\x0a\x21\x2f\x2d\xbc\x9b\xdf\x09\xd3\xe0\x43\xca\xb1\xb6\x20\x23\x4c\xa9\x01\x74\x4e\x16\xe6\x4a\xe1\xc9\x8d\xa5\x54\x85\x52\xe1\x28\xf6\xf4\x72\xcf\xb3\x6a\xa2\x61\x35\x1b\x14\xd6\x96\x58\x51\x55\xd5\x25\x91\xdb\xca\xb1\xdd\xa9\x26\xe3\xcc\x95\x67\x6a\x83\xbf\x2d\xd9\x73\x33\xb0\x8a\x0e\x14\x22\x60\x6a\x98\xec\x1d\x11\xa9\x47\xf0\xf2\x18\x5d\xf2\x86\xa7\xde\xb0\xe8\x5d\xcd\x43\xe9\x4a\xb9\xf1\x57\x46\x5f\x1b\x76\xd3\xec\x4b\x97\xa9\x8d\x7c\x3e\x4a\xf6\x56\xa4\x26\x01\x56\x63\x83\x75\x57\x26\xd7\x8e\xc4\x94\xf2\x4b\x6f\x3e\x38\xd5\xe7\xf0\x1d\x39\xd0\x0c\x20\xc5\xb6\xad\xd6\xa9\x36\xdb\x35\xcf\x90\x6b\xa9\x29\x57\x5d\xeb\x9e\xb1\xbd\x76\xa7\xe2\xde\x40\x3d\x58\x85\x01\xb0\xd5\x08\x0d\x66\xe0\x5f\x95\x02\xad\x01\xba\x51\x18\x0c\xf0\x54\x8b\x1b\x6c\x16\xc0\xba\xde\x10\x08\x0b\x88\x8f\x15\x17\xce\x8e\xa6\x7d\x07\xba\x96\xb4\x1d\xbb\xf2\x1d\x01\xb1\x3a\xdb\xeb\x33\xc6\xca\xf5\x07\x47\xda\x3a\x50\xb4\x03\x8e\x76\x37\x35\x13\xd7\xaa\x23\x88\x96\xa2\x03\x1e\x61\xa2\x65\xf2\x25\x97\x2a\xaf\x67\xeb\xb5\x44\xd0\xe6\xd7\x28\x78\xa0\x15\x0d\x4b\xbc\x67\x7c\xff\xfe\xc1\x8f\x5a\x5d\x72\x29\xcf\xb0\x60\xad\x25\xdf\xe7\x09\x53\x67\x24\x30\x77\x08\x93\x0a\x33\x1f\x2c\x5b\x0a\x6b\x45\x90\x12\xb8\x05\x96\x48\x10\xa6\x08\x10\x61\xa2\x09\xc1\x40\x9d\xe1\x20\x10\xf6\x0a\xa5\x2a\x09\x6f\x2b\x47\xd7\x05\x93\xdd\xca\xe5\x93\x0b\x60\xea\x77\xd2\xea\xcd\xdc\xfd\xd4\xda\xa8\xc7\x9e\x96\xaf\x66\x1a\xba\xfd\x1d\xaa\xaf\x2b\x6a\x05\xad\xea\xb1\xf6\x71\x42\xa8\xda\x21\x0c\x55\x12\xa1\xe2\xb9\xae\x85\xa3\x37\x62\xde\x21\x8f\x22\xde\xc6\x93\x6d\xc4\x2a\x85\x8b\xd0\xdf\xdf\xdf\x7f\x98\xe6\xe7\x84\x91\xab\xf2\xef\x59\x24\xab\x8f\x49\xfd\x91\x65\x1f\x7d\xca\x93\x20\xa4\x58\x34\x5b\x33\xd4\xee\x13\xaf\xaf\x82\xc3\x44\x2a\x1e\x8d\x57\xc0\x23\xe4\xd7\x98\x05\x12\x22\x0c\x49\x25\xc2\x6c\x89\x71\x85\x33\xe0\x16\xa5\xc6\x9b\xee\xff\x4e\xf0\xa3\xf3\xc7\xfe\x61\x10\x3e\x7b\xfe\x31\x7a\x19\x1f\xbf\xbd\x7c\x7f\xb5\xfa\xe3\xd3\x87\x79\x67\x5b\x7c\x7d\x71\x7f\xe3\x88\xe2\x15\x4f\xd4\xcd\x49\xbc\xa8\x48\x0e\x12\xf9\x34\x07\xfe\xd9\xdd\xac\x8f\xab\xda\x26\x9a\xc3\xe8\x91\xa0\x6c\xee\x6e\x2b\x10\x34\x9a\x24\xc3\x85\xb1\x58\x40\xcb\x7d\x87\x8e\x00\xf6\x06\x2b\x20\x3f\x46\x7f\xbd\x28\x85\xb7\xbd\x37\x8f\x2d\x08\x96\x58\x16\x98\xfd\x55\x41\x0d\xeb\x50\x97\x12\x09\x58\xb5\x15\x00\x25\x11\x51\xee\x11\x40\x67\x6b\x30\x49\xe5\x9f\x65\x5a\x40\x46\x35\x18\x40\x88\x13\x9a\x5d\xd5\xc4\x7e\x51\x3e\xa7\x49\xe4\x7e\x60\xb5\x97\x23\xd6\x72\xd0\x55\x8d\x74\xba\xa6\xb3\x04\xb4\x17\x26\xf2\x4f\x12\x1f\x09\x08\x89\xf9\x5c\x7e\x1d\xd3\xd2\x12\x6c\xac\x56\xef\xd2\x8e\xf1\x0b\x6a\xa2\x57\x5a\x25\x48\x74\x1c\x63\xff\x7a\x59\x14\xae\x62\xcc\x82\xd6\x93\x30\x72\x17\x72\x0a\xae\xd4\x51\xe6\x34\x4f\x9a\xb8\x3d\x25\x9d\x63\xe6\x32\xd6\xd3\x4a\x43\xec\xf7\xb3\x7f\xd0\x5b\x7a\x5d\xdc\x78\xd4\xff\xe6\x68\xdf\x1c\xed\x86\x1d\xad\x9e\x29\x8e\xf5\xb0\x7c\x84\xd9\xef\x5f\xb6\x51\xe7\x4d\xde\x8e\x63\xa8\x54\x0e\x59\x8f\x8a\x52\xe9\xbf\x65\xa3\x1b\x79\xeb\xbf\xc9\x7e\x5f\x99\xf3\x68\xcb\x34\xaa\xdf\x46\x2d\x73\x3f\xf7\x8f\x38\x3a\xb8\x69\x4c\xd1\x6f\xcb\x9d\x8a\xd2\xff\xd7\x54\x7f\xb7\x3b\xcc\xde\xdd\x79\x78\x36\xbf\x6b\x1d\x65\xf7\xf5\x47\xee\x97\xb8\x4d\xe7\xda\x93\x2f\x17\x58\xc6\x86\xfd\xaf\x2a\x80\x7c\x25\x41\xc2\x81\x35\xc0\x39\x1d\xe6\xd8\x6e\x65\x0d\x8d\x19\x3f\x5e\x30\xd3\xd0\xf8\xdf\x30\xf4\x19\xcd\x0f\x36\x05\x0f\xa6\xd4\xf8\xa1\x61\xc6\x20\xba\x24\x6a\x89\x62\x8a\x7d\x58\x72\x9a\x96\xa6\x1a\xf8\xb6\xcf\xa3\x62\x68\xe6\xbd\x4c\xa4\x42\x3e\x67\x0a\x13\x86\xb0\x42\x14\xb0\x54\x88\x33\x70\xa3\x37\x9f\x6a\x3e\xcf\x66\xf2\xee\xc9\xe9\x7a\x7e\x2f\xfd\x30\x9b\xad\xfb\x47\xce\xa3\x05\xc9\x5e\x51\xe0\x92\x12\x06\xd2\x2d\xc8\x6b\x46\x57\x28\x9b\x07\x95\xc0\xa9\x38\x6a\x09\x08\x58\xe0\x14\xe0\xf4\xe4\x74\x36\x63\x29\xf7\x4c\xfb\x89\x60\xf1\xa9\x78\x3c\xd8\x4a\xff\xad\xb7\xfe\x0e\x00\x00\xff\xff\x20\x81\x22\xc9\x0d\x2a\x00\x00")
+var _schemaJson = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x5c\x79\x6f\xdc\x36\xda\xff\x5f\x9f\x82\x50\x5d\x20\x69\xc7\xb1\xf3\xe6\xcd\x16\x31\x50\x2c\x92\x6c\xb2\x2d\xd6\x6e\x8d\xba\xc7\x6e\x3d\x63\x83\x96\x38\x33\x4c\x24\x52\x25\x29\x7b\x26\xee\x7c\xf7\x05\x75\xf1\x10\xa9\xc3\x33\x6e\x36\x81\x8b\x41\x3a\x43\x3d\xe7\xef\x39\x48\x51\x94\x6f\x03\x00\xc2\x3d\x1e\x2d\x51\x0a\xc3\x23\x10\x2e\x85\xc8\x8e\x0e\x0e\xde\x71\x4a\xf6\xcb\xd1\x27\x94\x2d\x0e\x62\x06\xe7\x62\xff\xf0\x9b\x83\x72\xec\x8b\x70\x22\xf9\x04\x16\x09\x92\x5c\xa7\x90\x88\x25\x62\x20\xa1\x0b\x50\xc9\x2a\x08\xf6\x70\x5c\x0b\xe5\x47\x07\x07\x2c\x27\x59\x49\xf9\x04\xd3\x4a\x14\x3f\x48\xe8\x82\x67\x28\x3a\xb8\x3e\x2c\xa5\xee\x31\x34\x97\x5c\x5f\x1c\xc4\x68\x8e\x09\x16\x98\x12\x5e\x51\x9f\x65\x28\x2a\xa9\xb4\x6b\xe1\x11\x90\x6e\x00\x10\x6a\x44\xf5\x98\x34\x73\x9d\x15\x56\xd2\xab\x77\x28\x12\x05\x7b\x31\x9e\x31\x9a\x21\x26\x30\x52\x12\xe4\x27\xbc\x46\x8c\x63\x4a\x8c\x41\x00\xc2\x88\x12\x2e\xc2\x23\x70\xd8\x0c\x6e\x6a\x51\x8d\x6a\x9b\xa7\x56\xcd\x05\xc3\x64\xd1\xa8\x96\x9f\x30\xc5\xe4\x18\x91\x85\x58\x86\x47\xe0\x99\x71\x25\x83\x42\x20\x26\x0d\x08\x2f\xce\x5f\xee\xff\x3e\x93\xff\xc0\xfd\x0f\x87\xfb\x2f\x66\x5f\x3f\x9a\x4e\x9f\xb4\x06\x1f\xff\x7d\x2f\x74\x9a\x15\x23\x1e\x31\x9c\x09\x87\x3f\x96\x6d\x4e\x76\x86\xe6\x88\x21\x12\xa1\x5f\x7e\x3a\x1e\xe3\xdb\x9c\xb2\x14\x4a\xb0\xc2\x9c\x61\xb7\x65\x19\x64\x1c\x31\x9f\x50\x2b\x56\x35\x60\xa7\x7a\xc8\x9e\x1a\x57\x29\x41\x3f\xca\xbc\x39\xd7\x06\x01\xb8\x05\x21\x43\x7f\xe4\x98\x21\x99\x89\xe7\x61\xc4\xaf\xc3\x99\x6e\x87\x8b\x68\x0e\xb9\x48\xa1\x88\x96\xfd\xa4\x0c\x2d\xd0\xaa\x9f\xec\x3d\x5a\x5f\xc3\x24\x47\xfd\x94\x11\x9a\xf7\x13\x25\x68\x08\xd5\x2a\x4d\xfa\x89\x08\x14\xf8\x7a\x80\x5d\x69\x9e\x08\x9c\x60\x82\xc2\xd9\x04\x84\x29\x5c\x99\xa1\x00\x1b\x8d\x7b\xa6\x8b\xf2\x95\x99\xfc\x14\xe1\xb0\x07\xbd\xb1\x94\x1f\x9b\x14\x00\x6f\xc7\x28\x13\xec\xf5\xd9\xaf\xbf\x61\xb1\xfc\x0e\xc1\x18\xb1\x30\xb0\x58\x6d\xaf\xb7\x51\x41\x73\xe1\xd5\x62\x8d\xcc\x82\x0e\x1b\xb4\xf4\x73\x40\xd3\x65\xc8\x5b\xc8\xc5\x49\xc1\xd8\x29\xbf\xcc\xd9\x91\xb2\x7f\x92\x4c\x03\x84\x37\x99\x3e\x52\xfe\xbf\xd0\xfa\xd7\x82\xaf\x53\xba\xac\x8e\x91\x82\x5f\xbf\x79\xdb\x6d\x71\x82\xc6\x0b\x3d\x7e\xd3\x27\x55\x16\xdf\x48\xa1\xff\x3e\x39\xee\x96\x59\xd5\xea\x48\xb1\x3f\x94\x5c\x9d\x92\x55\x75\x8f\x14\x7e\x22\x19\x8f\x25\xa3\xc1\xb5\x09\x5c\xdf\x35\xad\xe1\x1c\xa3\x24\xb6\x3b\x82\x47\x55\x39\x19\xbc\x2d\x39\x9c\xd2\x34\xea\x31\x33\x4a\x35\xd1\x1a\xad\x4c\x67\x06\xae\x09\x78\x6f\x38\x46\x45\xcf\x2f\x96\x23\x7e\x74\x0c\x83\x60\x1c\x17\x3e\xc3\xc4\xb0\x69\x0e\x13\x8e\x02\x9b\xbd\x61\x35\x3b\x75\xbd\x7c\x99\x34\x20\xcf\x02\x8d\x3c\x34\xd0\x54\xae\x34\x40\x41\xc6\xe0\xba\xc1\x49\xce\xba\xdf\x0b\x94\x1a\x13\x6e\x88\xab\x91\xdb\xa0\x07\x81\xc2\x02\x1d\x81\x8d\x61\x8b\xba\xac\x19\x02\x93\xc4\xea\xfe\xc3\x03\xda\x11\x49\x02\x53\x67\x7a\x5b\xeb\x18\xe3\xf2\x66\x62\xfc\xd4\x81\xf6\xca\xb9\xa2\x34\x41\x90\x74\x0b\xaa\x88\x07\xe6\x91\xa4\x3e\x8b\x50\xd4\x2d\xd3\xbf\xd6\x1b\xed\xa7\x60\x90\x70\xb9\x88\xeb\x12\x64\x26\x0a\x00\xde\xdc\xe8\xf3\xae\x56\xd6\xae\x14\x95\xec\xed\x5f\x86\xcd\x66\x09\x14\xa1\x9e\x54\x96\xce\x02\x07\xc7\x6d\xd0\x6b\x96\xa3\x78\x6b\xf5\x66\x41\x29\x42\xe5\xb4\x63\x09\x33\x40\x65\x99\x82\x96\xce\x51\x46\x97\xc5\xbd\x8d\x84\x22\xaa\xdb\x08\xe0\x11\x4c\x20\xdb\x46\x82\xc0\x29\xda\x86\x9f\xa1\xb9\xc5\xee\x8c\x5b\x53\x55\x5a\xd8\xac\x22\xa9\xd5\x86\x88\xe4\xa9\x11\x4d\x9b\xa2\x69\xac\xfa\x88\x5d\x21\xa1\xbc\xaf\xd6\x7f\x63\xa2\x37\xb0\x70\x9e\x50\x68\x0c\xf0\x14\x26\x89\x45\x74\x85\x17\xf6\x48\xd5\x71\xb4\x21\x09\x21\x17\x30\xcd\x74\x3a\x09\x96\x13\x09\x2d\x6b\x1c\x58\x58\x7e\xf9\x9a\x6c\x4d\xef\xbc\x69\xae\xc1\x69\xae\xed\x78\x2d\x10\x58\x52\xcd\x7e\x50\x58\xe6\x9b\x0f\x55\xc2\xdf\x97\xef\x85\x06\xb7\xeb\x28\x41\x29\x22\x62\x98\xef\x1d\x1d\xa9\xc7\xf1\x5a\x8d\xe9\xb9\x56\xa9\x3b\x76\xdd\x53\x46\x46\x29\x85\x45\x16\x37\x49\xaf\x12\x5b\x4f\xfb\xba\x64\x54\x92\x6b\xed\x7c\x80\xef\xa6\xc3\xd7\x30\xc1\x31\x14\x63\x9b\xb5\x07\x91\xb6\x4a\x98\x24\xf4\x26\x1c\x7e\xfb\x5b\xd2\xdb\xc3\xfd\x53\xac\x6b\x4d\x36\x64\xfe\xed\x08\x8b\x63\x53\xea\xa9\x75\x79\x13\xf8\x7e\xa9\xef\x9b\xc9\x96\x10\xc6\x88\xac\x47\x20\x58\x90\xb7\x7d\xfd\x84\x01\xac\xbe\x59\x95\xaa\x16\x06\xca\x2e\x1f\xa8\x26\xa0\x05\x51\x03\xa8\x0f\xcc\x5a\x96\xbb\x81\x55\xee\xba\xa2\x1c\x62\x12\xe3\x08\x0a\xca\x6c\xc8\x3a\x62\x60\xa2\x34\x09\x7a\xc1\xf7\x34\xc4\x46\x77\xe8\xc6\x52\x49\x6e\x4a\x7f\x70\xa7\x55\x8d\xc2\xe9\x76\x42\xe9\xfb\x3c\x1b\x26\xad\xa4\xf5\x34\x6e\x23\xce\x1a\xa5\x92\x3c\x30\xce\xf0\x2a\x29\x7a\xbd\x80\x6c\x81\x84\xaa\xa1\xce\x9b\xca\xfe\xbc\x28\xc4\x7a\x22\xeb\x28\x83\xfa\x9e\x5a\xa2\x70\x71\x0e\xf7\x3f\xcc\xe4\x3f\x87\xfb\x2f\x2e\x67\x5f\x79\xf6\xa8\x2b\x83\xef\xaa\xe3\x62\x22\xef\xc9\x7b\x80\x35\xef\x31\x94\x2a\x2f\xb6\xde\xcd\xe6\xf6\xde\xe7\x6e\x80\x66\x28\x86\x91\x17\x05\xcb\xbc\x76\xf8\x6b\x48\x9a\xb0\x0f\xb6\xa8\xcb\x2a\x0b\x6d\xf3\x42\x77\x90\xda\x75\x6e\x5c\xd4\x12\xa0\x72\x26\x4b\x60\x84\x06\x28\x31\x2e\x6f\x02\xd7\x77\x4d\x78\xb8\x84\xdc\xde\x48\xed\x44\x75\x27\x90\x71\x98\x88\xdd\xbb\x22\x58\x4e\x22\x47\x0f\x1b\x9c\x24\x49\x39\x33\xdd\x43\x8e\x54\x92\x3b\x5c\xc6\x44\xa0\x05\x62\xce\x1c\xc1\x69\x9e\xb6\x33\x24\x70\x7d\xd7\xf1\x88\x19\xcd\x7c\x73\x96\x60\x39\x72\x32\x45\x34\x5b\x0f\x6b\xdb\x4d\xcf\xf8\xb9\x6c\x50\x4e\x71\x0c\x39\xf6\x95\x46\x0b\xec\xee\x59\x15\xb9\xd2\xe1\x8b\xb8\x19\xed\x5d\x4f\x04\x96\x11\xae\x84\x9e\x04\x66\x60\x1d\xc5\xef\xf6\x55\xcd\xe4\x0e\x2f\xfb\x6f\xc6\xb1\x71\x9f\x1b\xd3\x14\x62\xe3\x76\x78\x49\xb9\x28\xe2\xa4\x8d\xe5\x2c\xd1\x49\xd2\xf8\xb9\xfe\x93\x2f\xe1\x53\xeb\xf7\xff\x3d\xff\x9b\x3e\x02\x6f\xf8\x25\x64\x86\x9a\x62\x28\x8a\x68\x4e\xc4\x25\x8e\xed\x2b\x98\x70\x01\x49\x84\x1c\x97\x04\xd4\xb1\x93\x91\x6f\x91\xe5\x1c\x31\xdb\x05\x94\x42\x6c\x38\x41\x90\xb8\x84\x71\xcc\x42\xe7\x2a\xb2\xd9\x57\x19\x90\x49\x77\x5a\x27\xaa\x5d\x87\xe6\x72\xa5\x5b\x7e\x42\xcc\xdf\x5c\x23\x22\x7e\xc6\xed\x82\xa9\xcd\xa8\xef\xf2\x9c\xfc\x52\xfc\xdb\xfa\xc9\xb2\xc1\xde\xbe\x81\xb3\xef\x40\xba\x12\xaa\xfe\x4f\x1d\x68\x78\x95\xe3\x44\xec\x63\x02\x1a\x8f\x40\xf5\x48\xbb\xc5\x63\xee\xba\x86\xaf\x69\x9a\xd2\x36\x1f\x6f\x2b\x6b\xee\x83\xd9\x3c\x7a\xf6\xec\xd9\x0b\xb9\x76\xcb\x09\x5e\xd5\xff\xbf\x4c\x79\xf3\x35\x57\x5f\x49\xf1\x35\x4a\x68\x1e\xcf\x13\xc8\xf4\xfd\x4d\x0b\xaf\xed\x20\x78\x9d\x73\x41\xd3\xf1\x00\xbc\x04\x91\xe2\xac\x98\x00\x26\x80\x0b\x36\x2f\x86\x08\x15\xb0\x20\x6e\x49\x52\x0b\x8d\xf0\xcb\x73\xf8\xf2\xea\x55\xf4\x3a\x9e\x7f\xf7\xfd\xbb\xf4\x24\x3b\xfb\xe5\xe6\xb7\xd5\xfa\x3f\x1f\x7e\x9f\x85\xf7\xe3\xee\x3f\x29\x48\xe0\x9a\xe6\x62\x77\x1e\x2f\x1a\x91\x83\x5c\xbe\x28\x89\xbf\xb5\x1c\xd4\x7e\x8d\xdb\xfa\x98\x18\x05\x63\x76\x82\x7a\x87\x54\x95\xd1\x6e\x1b\x81\xb6\xd3\xb8\xed\x5a\xbf\x67\x16\xf1\x00\x50\x4e\x7d\xe6\x23\x80\xda\x79\xd7\xc1\x00\x65\x90\x17\x08\x43\xc1\x12\xf2\x8a\x73\xd6\x8b\x94\xa2\xf5\xc0\xe5\x5d\xab\xc4\x28\xc1\x29\x16\x88\x8d\x01\xac\xe9\x2b\x13\xd9\x28\xa6\x05\x0a\xc0\x5a\xf3\xc5\x68\x0e\xf3\x62\x89\x1a\x4e\xdc\x81\x8a\x68\x92\xa7\x64\xe4\x3d\x7d\xeb\x41\x61\xd7\x2d\x7d\x87\x0f\xde\xb0\xab\xc0\x9b\xd6\xf2\xf7\x38\x3b\x65\x68\x8e\x57\x3e\x83\x47\xa4\x96\x26\x17\xa5\x99\x28\xcf\x25\xfc\x85\x48\xf4\x7a\x2b\x18\x4e\xcf\xb2\xf6\xdd\xd2\xb0\x59\x14\xad\x32\x48\xe2\xd6\xf3\xdf\xae\x75\x2b\x5a\x89\xd3\xa2\x68\xde\xe8\xbc\x81\x6d\xe5\xc6\x5f\x66\xea\x70\x8c\xd2\x38\xac\xd2\xea\x44\xec\xaf\xb3\x8f\x58\x2d\xbd\x25\x6e\x3d\xc1\x7f\x28\xb4\x87\x42\xdb\x71\xa1\xa9\xc3\x5f\x4a\xd5\xb0\x0a\xab\x8e\x3a\xf6\xd6\x97\xeb\x4c\xda\x2e\xa3\xe3\xc6\xa4\x39\x0d\x77\x5a\x2d\x95\x7a\xa3\xf6\x59\xe5\xa8\xce\xe2\xb5\xf2\x73\xc8\xdf\xe6\x00\xa0\xd2\xe4\x4d\xdf\x9d\xce\x00\x06\xa4\xbe\xbe\x0f\xdc\x10\x71\x94\x41\x66\x6d\x5b\xec\x46\xe1\xb7\x6e\x85\x7f\xe4\x54\x20\xbe\x73\x6d\xd3\xd0\xad\xae\x78\x32\xff\x03\x4c\xfd\x2a\xad\xd0\x74\xed\x33\xe9\xfc\xdd\x46\xdb\x66\xf7\x26\xba\x2c\x79\x79\x0a\xd1\x6b\xa6\x6b\xf7\x51\xdb\x79\x3c\xfc\xfc\x1b\x49\x2f\x86\x9f\x52\xb3\x90\x87\x7a\x95\x12\x5f\x32\x7a\x67\xb2\x65\xb1\x56\x73\x1b\x3b\x2c\x02\xdf\x98\x17\xe0\xca\x7d\xe1\x2f\xe8\xf1\x9f\x52\x89\x3e\x14\xd3\xff\x62\x31\x15\x87\xd9\x95\x16\x5f\xde\xdc\x63\x35\x3d\xf7\x55\xd3\xf3\x3b\xc4\x66\xab\x2c\xdd\x7e\x05\xe1\x14\xfb\x50\xa4\x0f\x45\xba\x5d\x91\xca\x97\x43\x94\x12\x5f\xda\x78\x6b\xd4\x73\xce\xd0\x42\x6c\x64\x54\x6f\x18\xcc\x32\xc4\xde\xdc\x87\x6c\x28\x04\xc3\x57\xb9\x40\x83\xd2\xd1\x29\x42\xc2\x5d\x40\x7c\x67\xcb\x7c\xcb\xe5\x6b\xf3\x4d\x25\xc3\x6e\x99\xc6\x15\x22\xdc\xa7\x78\xf7\xb9\xae\xb3\x78\xf1\x55\x19\xf6\x50\xf5\xa3\xaa\x3e\xb0\xa4\xf4\xbf\xa7\x63\x16\xaf\x7a\x4b\x4a\xa9\xf4\x96\x30\x24\xeb\xf2\x30\xaa\xfd\x0a\x26\x17\x90\x89\x7a\x17\x44\xbe\xb0\xd9\x7a\x49\x33\xa2\x44\x60\x92\x17\x8f\xd7\x34\xc2\x59\x6f\x7b\x30\x44\x7b\x60\xb9\x5b\x0e\xb8\x4c\xda\xa9\x82\x14\xae\xb6\xb9\xf3\xf3\x0a\x7d\xb5\x16\x3b\x16\x3a\x4f\x72\xbe\x94\x0f\xbf\x69\x3e\xaa\x59\xea\xcf\x08\x1f\x9d\xab\x97\xc0\xab\x37\xbf\x1f\xa5\xfc\x4f\xfe\x67\xfa\xf8\xb1\xf3\x14\xdc\x5d\x53\xb6\x7a\x6b\x50\x99\x69\x66\x5a\x71\x12\xa1\x3f\xaf\x5c\x67\x63\x2c\x57\xdb\x26\x3b\xac\xd1\xde\x3e\x55\xd2\xbc\x15\x74\xa7\xdd\xcd\x0a\xe5\x7f\xc8\xed\xd8\xfb\x7d\x91\xb0\x3e\x0c\x69\x93\xb4\xb1\xd1\x75\x74\x94\x83\x8e\x9e\x86\xdd\xd0\xc0\x5b\x2c\x15\x62\x1e\xef\x3f\x42\xc3\x7e\x98\x9e\x7a\xa6\xa7\x8f\xb0\x28\xf5\x70\x29\x8d\xbe\x72\xf1\xa5\xe3\x6d\xd0\x72\xd4\x44\xcc\xd4\xdf\x7a\x2a\x30\xfe\xfd\xd1\x4a\xbc\x37\x69\xfe\xbf\xb9\xb0\x99\x8c\x97\xd4\x1c\x2c\xa9\x0c\x04\x37\x58\x2c\x41\x71\xc6\x75\x49\x93\xd8\x9e\x3b\xf6\x22\x9a\x56\x8b\xe8\xf0\x24\xe7\x02\xc8\x79\x13\x62\x02\xa0\x00\x09\x82\x5c\x00\x4a\x90\x9f\xbd\xea\x3f\x92\xfb\xcb\xdb\xe9\x94\x7f\x75\x7e\xb1\x99\x7d\x2d\xbf\x4c\xa7\x1b\x2d\x96\xbb\x72\x44\x9e\x93\x21\xe8\x46\xfe\x3d\x08\xf3\x64\x93\xe1\xc8\x8f\x24\x59\x83\xe2\x1d\x97\x9a\x58\xba\x23\x96\x08\x20\x12\x7b\x1d\xb8\x38\xbf\x98\x4e\x89\xb4\x9e\x18\x7f\xd5\xa4\xfa\x56\x9d\xe5\x08\x00\xd8\x04\x9b\xe0\xbf\x03\x00\xbb\x91\x3e\xf4\xc0\x46\x00\x00")
 
 func schemaJsonBytes() ([]byte, error) {
 	return bindataRead(
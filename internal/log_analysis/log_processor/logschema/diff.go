@@ -140,6 +140,17 @@ func diffWalk(from, to *ValueSchema, walk func(c Change) bool, path []string) bo
 				From: from,
 				To:   to,
 			}
+			if !walk(ch) {
+				return false
+			}
+		}
+		if !reflect.DeepEqual(from.Lookup, to.Lookup) {
+			ch := Change{
+				Type: UpdateValueMeta,
+				Path: append(path, "Lookup"),
+				From: from.Lookup,
+				To:   to.Lookup,
+			}
 			return walk(ch)
 		}
 		return true
@@ -178,6 +189,17 @@ func walkObject(from, to []FieldSchema, walk func(c Change) bool, path []string)
 					return false
 				}
 			}
+			if !reflect.DeepEqual(A.Transform, B.Transform) {
+				ch := Change{
+					Type: UpdateFieldMeta,
+					Path: append(path, A.Name, "Transform"),
+					From: A.Transform,
+					To:   B.Transform,
+				}
+				if !walk(ch) {
+					return false
+				}
+			}
 		case A != nil:
 			ch := Change{
 				Type: DeleteField,
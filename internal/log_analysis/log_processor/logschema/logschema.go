@@ -26,6 +26,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/preprocessors"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/processor/logstream"
 	"github.com/panther-labs/panther/pkg/stringset"
 
 	// Force dependency on go-bindata to avoid fetching during mage gen
@@ -89,7 +90,14 @@ type Parser struct {
 	CSV       *preprocessors.CSVMatchConfig  `json:"csv,omitempty" yaml:"csv,omitempty"`
 	FastMatch *preprocessors.FastMatchConfig `json:"fastmatch,omitempty" yaml:"fastmatch,omitempty"`
 	Regex     *preprocessors.RegexConfig     `json:"regex,omitempty" yaml:"regex,omitempty"`
+	KeyValue  *preprocessors.KeyValueConfig  `json:"keyvalue,omitempty" yaml:"keyvalue,omitempty"`
+	CEF       *preprocessors.CEFConfig       `json:"cef,omitempty" yaml:"cef,omitempty"`
+	LEEF      *preprocessors.LEEFConfig      `json:"leef,omitempty" yaml:"leef,omitempty"`
+	XML       *preprocessors.XMLConfig       `json:"xml,omitempty" yaml:"xml,omitempty"`
 	Native    *NativeParser                  `json:"native,omitempty" taml:"native,omitempty"`
+	// MultiLine opts into reassembling events that span multiple lines before parsing.
+	// It can be combined with any of the other parsers.
+	MultiLine *logstream.MultiLineConfig `json:"multiline,omitempty" yaml:"multiline,omitempty"`
 }
 
 type NativeParser struct {
@@ -105,6 +113,14 @@ type ValueSchema struct {
 	TimeFormat  string        `json:"timeFormat,omitempty" yaml:"timeFormat,omitempty"`
 	IsEventTime bool          `json:"isEventTime,omitempty" yaml:"isEventTime,omitempty"`
 	Validate    *Validation   `json:"validate,omitempty" yaml:"validate,omitempty"`
+	Lookup      *Lookup       `json:"lookup,omitempty" yaml:"lookup,omitempty"`
+}
+
+// Lookup joins the row of a lookup table matching the value of a string field to the event.
+// The row is decoded into Target, the name of a sibling field in the same object.
+type Lookup struct {
+	Table  string `json:"table" yaml:"table"`
+	Target string `json:"target" yaml:"target"`
 }
 
 type Validation struct {
@@ -140,9 +156,15 @@ func (v *ValueSchema) Clone() *ValueSchema {
 			IsEventTime: v.IsEventTime,
 		}
 	case TypeString:
+		var lookup *Lookup
+		if v.Lookup != nil {
+			cp := *v.Lookup
+			lookup = &cp
+		}
 		return &ValueSchema{
 			Type:       TypeString,
 			Indicators: stringset.New(v.Indicators...),
+			Lookup:     lookup,
 		}
 	case TypeRef:
 		return &ValueSchema{
@@ -160,9 +182,60 @@ type FieldSchema struct {
 	Name        string `json:"name" yaml:"name"`
 	Required    bool   `json:"required,omitempty" yaml:"required,omitempty"`
 	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// Transform is applied in order to the field value after it is parsed
+	Transform   []Transform `json:"transform,omitempty" yaml:"transform,omitempty"`
 	ValueSchema `yaml:",inline"`
 }
 
+// Transform modifies a field value at ingest time, before indicators are scanned.
+// Exactly one of the transformations should be set.
+// Redact, Hash and Truncate apply to string fields or arrays of strings.
+type Transform struct {
+	Redact   *RedactTransform   `json:"redact,omitempty" yaml:"redact,omitempty"`
+	Hash     *HashTransform     `json:"hash,omitempty" yaml:"hash,omitempty"`
+	Truncate *TruncateTransform `json:"truncate,omitempty" yaml:"truncate,omitempty"`
+	// Drop removes the field value
+	Drop bool `json:"drop,omitempty" yaml:"drop,omitempty"`
+	// Copy copies the field value to a sibling field
+	Copy *CopyTransform `json:"copy,omitempty" yaml:"copy,omitempty"`
+	// Rename moves the field value to a sibling field
+	Rename *CopyTransform `json:"rename,omitempty" yaml:"rename,omitempty"`
+}
+
+// RedactTransform replaces all matches of a regular expression.
+// The replacement can reference capture groups using `$1` notation.
+type RedactTransform struct {
+	Pattern string `json:"pattern" yaml:"pattern"`
+	Replace string `json:"replace,omitempty" yaml:"replace,omitempty"`
+}
+
+// HashTransform replaces the value with the hex-encoded SHA-256 hash of the salt followed by the value
+type HashTransform struct {
+	Salt string `json:"salt,omitempty" yaml:"salt,omitempty"`
+}
+
+// TruncateTransform limits the value to a maximum number of characters
+type TruncateTransform struct {
+	Length int `json:"length" yaml:"length"`
+}
+
+// CopyTransform names the sibling field that receives the value
+type CopyTransform struct {
+	Target string `json:"target" yaml:"target"`
+}
+
+// Target returns the target field of a Copy or Rename transform
+func (t *Transform) Target() string {
+	switch {
+	case t.Copy != nil:
+		return t.Copy.Target
+	case t.Rename != nil:
+		return t.Rename.Target
+	default:
+		return ""
+	}
+}
+
 // ValidateSchema validates the schema using the JSON schema in schema.json
 func ValidateSchema(s *Schema) error {
 	source, err := json.Marshal(s)
@@ -183,6 +183,9 @@ func extendStructTag(parts []string, schema *ValueSchema) []string {
 		if len(schema.Indicators) > 0 {
 			parts = append(parts, structfields.FormatTag("panther", schema.Indicators[0], schema.Indicators[1:]...))
 		}
+		if lookup := schema.Lookup; lookup != nil {
+			parts = append(parts, structfields.FormatTag("lookup", lookup.Table, "target="+lookup.Target))
+		}
 		return parts
 	case TypeTimestamp:
 		if schema.IsEventTime {
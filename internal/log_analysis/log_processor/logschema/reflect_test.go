@@ -63,6 +63,27 @@ func TestArrayIndicators(t *testing.T) {
 	assert.Equal(`json:"remote_ips,omitempty"  panther:"ip" description:"remote ip addresses"`, string(goFields[0].Tag))
 }
 
+func TestLookupTag(t *testing.T) {
+	schemaFields := []FieldSchema{
+		{
+			Name:        "user",
+			Description: "user name",
+			ValueSchema: ValueSchema{
+				Type: TypeString,
+				Lookup: &Lookup{
+					Table:  "users",
+					Target: "user_info",
+				},
+			},
+		},
+	}
+	goFields, err := objectFields(schemaFields)
+	assert := require.New(t)
+	assert.NoError(err)
+	assert.Equal(1, len(goFields))
+	assert.Equal(`json:"user,omitempty"  lookup:"users,target=user_info" description:"user name"`, string(goFields[0].Tag))
+}
+
 func TestAllowDeny(t *testing.T) {
 	validate := validator.New()
 	null.RegisterValidators(validate)
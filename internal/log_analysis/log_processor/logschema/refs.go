@@ -104,6 +104,7 @@ func safeBuild(input *ValueSchema, manifest map[string]*ValueSchema, path, visit
 			Type:       TypeString,
 			Indicators: append([]string(nil), input.Indicators...),
 			Validate:   input.Validate,
+			Lookup:     input.Lookup,
 		}, nil
 	case TypeTimestamp:
 		return &ValueSchema{
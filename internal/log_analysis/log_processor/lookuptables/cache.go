@@ -0,0 +1,309 @@
+package lookuptables
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+
+	DefaultRefreshInterval = 5 * time.Minute
+	DefaultMaxMemoryBytes  = 64 * 1024 * 1024
+)
+
+// TableConfig describes where to load the rows of a lookup table from
+type TableConfig struct {
+	Name     string
+	S3Bucket string
+	S3Key    string
+	// Format of the S3 object, either FormatCSV or FormatJSON
+	Format string
+	// KeyField is the column (or JSON field) of each row that is matched against event values
+	KeyField string
+}
+
+// Cache keeps the rows of all lookup tables in memory.
+//
+// Tables are reloaded from S3 at most once every RefreshInterval and only when the S3 object has changed.
+// The total size of rows kept in memory is bounded by MaxMemoryBytes.
+// Tables that do not fit in the memory budget are not loaded.
+// If a table fails to reload, the previously loaded version is kept.
+type Cache struct {
+	S3 s3iface.S3API
+	// ListTables returns the tables to load
+	ListTables      func(ctx context.Context) ([]TableConfig, error)
+	RefreshInterval time.Duration
+	MaxMemoryBytes  int64
+
+	refreshMu   sync.Mutex
+	lastRefresh time.Time
+
+	mu     sync.RWMutex
+	tables map[string]*table
+}
+
+type table struct {
+	config TableConfig
+	etag   string
+	size   int64
+	rows   map[string][]byte
+}
+
+// Lookup returns the JSON object of the row matching key in the named table
+func (c *Cache) Lookup(tableName, key string) ([]byte, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	t, ok := c.tables[tableName]
+	if !ok {
+		return nil, false
+	}
+	row, ok := t.rows[key]
+	return row, ok
+}
+
+// Refresh reloads the tables if RefreshInterval has passed since the last refresh.
+func (c *Cache) Refresh(ctx context.Context) error {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	interval := c.RefreshInterval
+	if interval <= 0 {
+		interval = DefaultRefreshInterval
+	}
+	now := time.Now()
+	if now.Sub(c.lastRefresh) < interval {
+		return nil
+	}
+	// We update the refresh time even on failure so that a failing API does not get called on every refresh
+	c.lastRefresh = now
+
+	configs, err := c.ListTables(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to list lookup tables")
+	}
+	// Load tables in a stable order so that the same tables are left out if the memory budget is exceeded
+	sort.Slice(configs, func(i, j int) bool {
+		return configs[i].Name < configs[j].Name
+	})
+
+	c.mu.RLock()
+	current := c.tables
+	c.mu.RUnlock()
+
+	budget := c.MaxMemoryBytes
+	if budget <= 0 {
+		budget = DefaultMaxMemoryBytes
+	}
+	tables := make(map[string]*table, len(configs))
+	for _, config := range configs {
+		t, err := c.loadTable(ctx, config, current[config.Name], budget)
+		if err != nil {
+			zap.L().Warn("failed to load lookup table",
+				zap.String("table", config.Name),
+				zap.String("s3Bucket", config.S3Bucket),
+				zap.String("s3Key", config.S3Key),
+				zap.Error(err))
+			// Keep the previous version of the table if it still fits
+			if prev := current[config.Name]; prev != nil && prev.config == config && prev.size <= budget {
+				t = prev
+			}
+		}
+		if t == nil {
+			continue
+		}
+		budget -= t.size
+		tables[config.Name] = t
+	}
+
+	c.mu.Lock()
+	c.tables = tables
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *Cache) loadTable(ctx context.Context, config TableConfig, prev *table, budget int64) (*table, error) {
+	head, err := c.S3.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(config.S3Bucket),
+		Key:    aws.String(config.S3Key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	etag := aws.StringValue(head.ETag)
+	if prev != nil && prev.config == config && prev.etag == etag {
+		if prev.size > budget {
+			return nil, errors.Errorf("table size %d exceeds the remaining memory budget %d", prev.size, budget)
+		}
+		return prev, nil
+	}
+	if size := aws.Int64Value(head.ContentLength); size > budget {
+		return nil, errors.Errorf("object size %d exceeds the remaining memory budget %d", size, budget)
+	}
+	obj, err := c.S3.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket:  aws.String(config.S3Bucket),
+		Key:     aws.String(config.S3Key),
+		IfMatch: head.ETag,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer obj.Body.Close()
+
+	t := table{
+		config: config,
+		etag:   etag,
+		rows:   make(map[string][]byte),
+	}
+	addRow := func(key string, row []byte) error {
+		if key == "" {
+			return nil
+		}
+		if old, duplicate := t.rows[key]; duplicate {
+			t.size -= int64(len(key) + len(old))
+		}
+		t.size += int64(len(key) + len(row))
+		if t.size > budget {
+			return errors.Errorf("table size exceeds the remaining memory budget %d", budget)
+		}
+		t.rows[key] = row
+		return nil
+	}
+	switch config.Format {
+	case FormatCSV:
+		err = readCSV(obj.Body, config.KeyField, addRow)
+	case FormatJSON:
+		err = readJSON(obj.Body, config.KeyField, addRow)
+	default:
+		err = errors.Errorf("invalid lookup table format %q", config.Format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// readCSV reads CSV rows using the first row as the header.
+// Each row is converted to a JSON object with string values.
+func readCSV(r io.Reader, keyField string, addRow func(key string, row []byte) error) error {
+	rd := csv.NewReader(r)
+	rd.ReuseRecord = true
+	header, err := rd.Read()
+	if err != nil {
+		return errors.Wrap(err, "failed to read CSV header")
+	}
+	header = append([]string(nil), header...)
+	keyIndex := -1
+	for i, name := range header {
+		if name == keyField {
+			keyIndex = i
+			break
+		}
+	}
+	if keyIndex == -1 {
+		return errors.Errorf("key field %q not found in CSV header", keyField)
+	}
+	// CSV rows can have a variable number of columns, we match them to the header by position
+	rd.FieldsPerRecord = -1
+	stream := jsoniter.ConfigDefault.BorrowStream(nil)
+	defer jsoniter.ConfigDefault.ReturnStream(stream)
+	for {
+		record, err := rd.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to read CSV row")
+		}
+		if keyIndex >= len(record) {
+			continue
+		}
+		stream.Reset(nil)
+		stream.WriteObjectStart()
+		for i, value := range record {
+			if i >= len(header) {
+				break
+			}
+			if i > 0 {
+				stream.WriteMore()
+			}
+			stream.WriteObjectField(header[i])
+			stream.WriteString(value)
+		}
+		stream.WriteObjectEnd()
+		row := append([]byte(nil), stream.Buffer()...)
+		if err := addRow(record[keyIndex], row); err != nil {
+			return err
+		}
+	}
+}
+
+// readJSON reads JSON objects either as elements of a top-level array or as a sequence of objects.
+func readJSON(r io.Reader, keyField string, addRow func(key string, row []byte) error) error {
+	iter := jsoniter.Parse(jsoniter.ConfigDefault, r, 4096)
+	add := func(raw []byte) error {
+		if iter.Error != nil {
+			return iter.Error
+		}
+		key := jsoniter.Get(raw, keyField)
+		if key.LastError() != nil {
+			// Skip rows without a key
+			return nil
+		}
+		return addRow(key.ToString(), append([]byte(nil), raw...))
+	}
+	for {
+		switch iter.WhatIsNext() {
+		case jsoniter.ArrayValue:
+			for iter.ReadArray() {
+				if err := add(iter.SkipAndReturnBytes()); err != nil {
+					return err
+				}
+			}
+		case jsoniter.ObjectValue:
+			if err := add(iter.SkipAndReturnBytes()); err != nil {
+				return err
+			}
+		case jsoniter.InvalidValue:
+			if iter.Error == nil || iter.Error == io.EOF {
+				return nil
+			}
+			return errors.Wrap(iter.Error, "failed to read JSON rows")
+		default:
+			return errors.New("lookup table JSON rows must be objects")
+		}
+		if iter.Error != nil && iter.Error != io.EOF {
+			return errors.Wrap(iter.Error, "failed to read JSON rows")
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package lookuptables
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type testS3 struct {
+	s3iface.S3API
+	objects map[string]string
+	gets    int
+}
+
+func (s *testS3) HeadObjectWithContext(_ aws.Context, input *s3.HeadObjectInput, _ ...request.Option) (*s3.HeadObjectOutput, error) {
+	body, ok := s.objects[aws.StringValue(input.Key)]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return &s3.HeadObjectOutput{
+		ETag:          aws.String(body),
+		ContentLength: aws.Int64(int64(len(body))),
+	}, nil
+}
+
+func (s *testS3) GetObjectWithContext(_ aws.Context, input *s3.GetObjectInput, _ ...request.Option) (*s3.GetObjectOutput, error) {
+	body, ok := s.objects[aws.StringValue(input.Key)]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	s.gets++
+	return &s3.GetObjectOutput{
+		Body: ioutil.NopCloser(strings.NewReader(body)),
+	}, nil
+}
+
+func newTestCache(api *testS3, tables ...TableConfig) *Cache {
+	return &Cache{
+		S3: api,
+		ListTables: func(_ context.Context) ([]TableConfig, error) {
+			return tables, nil
+		},
+		RefreshInterval: time.Nanosecond,
+	}
+}
+
+func TestCacheCSV(t *testing.T) {
+	assert := require.New(t)
+	api := &testS3{
+		objects: map[string]string{
+			"lookup_tables/users.csv": "user,team\nalice,security\nbob,\"infra, ops\"\n",
+		},
+	}
+	cache := newTestCache(api, TableConfig{
+		Name:     "users",
+		S3Key:    "lookup_tables/users.csv",
+		Format:   FormatCSV,
+		KeyField: "user",
+	})
+	assert.NoError(cache.Refresh(context.Background()))
+	row, ok := cache.Lookup("users", "bob")
+	assert.True(ok)
+	assert.JSONEq(`{"user":"bob","team":"infra, ops"}`, string(row))
+	_, ok = cache.Lookup("users", "eve")
+	assert.False(ok)
+	_, ok = cache.Lookup("assets", "bob")
+	assert.False(ok)
+
+	// Unchanged objects are not downloaded again
+	time.Sleep(time.Millisecond)
+	assert.NoError(cache.Refresh(context.Background()))
+	assert.Equal(1, api.gets)
+	_, ok = cache.Lookup("users", "alice")
+	assert.True(ok)
+}
+
+func TestCacheJSON(t *testing.T) {
+	assert := require.New(t)
+	api := &testS3{
+		objects: map[string]string{
+			"lookup_tables/array.json":  `[{"id":"a","owner":"alice"},{"id":2,"owner":"bob"},{"owner":"nobody"}]`,
+			"lookup_tables/ndjson.json": "{\"id\":\"a\",\"owner\":\"alice\"}\n{\"id\":\"b\",\"owner\":\"bob\"}\n",
+		},
+	}
+	cache := newTestCache(api, TableConfig{
+		Name:     "array",
+		S3Key:    "lookup_tables/array.json",
+		Format:   FormatJSON,
+		KeyField: "id",
+	}, TableConfig{
+		Name:     "ndjson",
+		S3Key:    "lookup_tables/ndjson.json",
+		Format:   FormatJSON,
+		KeyField: "id",
+	})
+	assert.NoError(cache.Refresh(context.Background()))
+	row, ok := cache.Lookup("array", "2")
+	assert.True(ok)
+	assert.JSONEq(`{"id":2,"owner":"bob"}`, string(row))
+	row, ok = cache.Lookup("ndjson", "b")
+	assert.True(ok)
+	assert.JSONEq(`{"id":"b","owner":"bob"}`, string(row))
+}
+
+func TestCacheMemoryBudget(t *testing.T) {
+	assert := require.New(t)
+	api := &testS3{
+		objects: map[string]string{
+			"lookup_tables/a.csv": "k,v\n1,a\n",
+			"lookup_tables/b.csv": "k,v\n1," + strings.Repeat("b", 100) + "\n",
+		},
+	}
+	cache := newTestCache(api, TableConfig{
+		Name:     "a",
+		S3Key:    "lookup_tables/a.csv",
+		Format:   FormatCSV,
+		KeyField: "k",
+	}, TableConfig{
+		Name:     "b",
+		S3Key:    "lookup_tables/b.csv",
+		Format:   FormatCSV,
+		KeyField: "k",
+	})
+	cache.MaxMemoryBytes = 64
+	assert.NoError(cache.Refresh(context.Background()))
+	_, ok := cache.Lookup("a", "1")
+	assert.True(ok)
+	_, ok = cache.Lookup("b", "1")
+	assert.False(ok)
+}
+
+func TestCacheKeepPrevious(t *testing.T) {
+	assert := require.New(t)
+	api := &testS3{
+		objects: map[string]string{
+			"lookup_tables/a.csv": "k,v\n1,a\n",
+		},
+	}
+	cache := newTestCache(api, TableConfig{
+		Name:     "a",
+		S3Key:    "lookup_tables/a.csv",
+		Format:   FormatCSV,
+		KeyField: "k",
+	})
+	assert.NoError(cache.Refresh(context.Background()))
+	api.objects["lookup_tables/a.csv"] = "x,v\n1,a\n"
+	time.Sleep(time.Millisecond)
+	assert.NoError(cache.Refresh(context.Background()))
+	row, ok := cache.Lookup("a", "1")
+	assert.True(ok)
+	assert.JSONEq(`{"k":"1","v":"a"}`, string(row))
+}
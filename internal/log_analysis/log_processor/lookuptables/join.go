@@ -0,0 +1,263 @@
+package lookuptables
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+// TagName is the struct tag used to mark fields whose value is looked up in a table.
+//
+// The tag has the form `lookup:"table_name,target=field"` where `field` is the JSON name of a sibling field.
+// The matching row is decoded into the target field.
+const TagName = "lookup"
+
+// Joiner attaches the matching lookup table rows to log events
+type Joiner struct {
+	Cache *Cache
+
+	mu    sync.Mutex
+	plans map[reflect.Type]*joinPlan
+}
+
+// Refresh reloads the lookup tables if needed.
+// Errors are logged and the previously loaded tables are kept.
+func (j *Joiner) Refresh(ctx context.Context) {
+	if j == nil {
+		return
+	}
+	if err := j.Cache.Refresh(ctx); err != nil {
+		zap.L().Warn("failed to refresh lookup tables", zap.Error(err))
+	}
+}
+
+// Join decodes the matching lookup table rows into the target fields of an event.
+// Target fields that already have a value are not modified.
+func (j *Joiner) Join(event interface{}) {
+	if j == nil || event == nil {
+		return
+	}
+	v := reflect.ValueOf(event)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return
+	}
+	v = v.Elem()
+	plan := j.plan(v.Type())
+	if plan == nil {
+		return
+	}
+	plan.join(j.Cache, v)
+}
+
+func (j *Joiner) plan(typ reflect.Type) *joinPlan {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if plan, ok := j.plans[typ]; ok {
+		return plan
+	}
+	if j.plans == nil {
+		j.plans = make(map[reflect.Type]*joinPlan)
+	}
+	plan, err := buildJoinPlan(typ, map[reflect.Type]bool{})
+	if err != nil {
+		// Event types are checked when they are built, this should not happen
+		zap.L().Error("invalid lookup fields", zap.String("type", typ.String()), zap.Error(err))
+		plan = nil
+	}
+	j.plans[typ] = plan
+	return plan
+}
+
+// CheckEventType checks that all lookup fields of an event type reference a valid target field.
+func CheckEventType(typ reflect.Type) error {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil
+	}
+	_, err := buildJoinPlan(typ, map[reflect.Type]bool{})
+	return err
+}
+
+type joinPlan struct {
+	lookups []fieldLookup
+	nested  []nestedPlan
+}
+
+type fieldLookup struct {
+	table  string
+	key    int
+	target int
+}
+
+type nestedPlan struct {
+	index int
+	plan  *joinPlan
+}
+
+func (p *joinPlan) join(cache *Cache, v reflect.Value) {
+	for _, l := range p.lookups {
+		target := v.Field(l.target)
+		if !target.IsZero() {
+			continue
+		}
+		key, ok := keyString(v.Field(l.key))
+		if !ok {
+			continue
+		}
+		row, ok := cache.Lookup(l.table, key)
+		if !ok {
+			continue
+		}
+		if err := pantherlog.ConfigJSON().Unmarshal(row, target.Addr().Interface()); err != nil {
+			// Discard partially decoded values
+			target.Set(reflect.Zero(target.Type()))
+		}
+	}
+	for _, n := range p.nested {
+		field := v.Field(n.index)
+		if field.Kind() == reflect.Ptr {
+			if field.IsNil() {
+				continue
+			}
+			field = field.Elem()
+		}
+		n.plan.join(cache, field)
+	}
+}
+
+func keyString(v reflect.Value) (string, bool) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "", false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.String {
+		return v.String(), v.Len() > 0
+	}
+	if !v.CanAddr() {
+		return "", false
+	}
+	if n, ok := v.Addr().Interface().(interface{ IsNull() bool }); ok && n.IsNull() {
+		return "", false
+	}
+	if s, ok := v.Addr().Interface().(fmt.Stringer); ok {
+		key := s.String()
+		return key, key != ""
+	}
+	return "", false
+}
+
+// buildJoinPlan returns nil if there are no lookup fields in typ
+func buildJoinPlan(typ reflect.Type, visited map[reflect.Type]bool) (*joinPlan, error) {
+	if visited[typ] {
+		return nil, nil
+	}
+	visited[typ] = true
+	defer delete(visited, typ)
+
+	plan := joinPlan{}
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		if tag, ok := field.Tag.Lookup(TagName); ok {
+			table, targetName, err := parseTag(tag)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid lookup field %s", field.Name)
+			}
+			target := findFieldJSON(typ, targetName)
+			if target == -1 {
+				return nil, errors.Errorf("lookup target %q of field %s not found", targetName, field.Name)
+			}
+			if target == i {
+				return nil, errors.Errorf("lookup target of field %s cannot be the field itself", field.Name)
+			}
+			plan.lookups = append(plan.lookups, fieldLookup{
+				table:  table,
+				key:    i,
+				target: target,
+			})
+			continue
+		}
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() != reflect.Struct {
+			continue
+		}
+		nested, err := buildJoinPlan(fieldType, visited)
+		if err != nil {
+			return nil, err
+		}
+		if nested != nil {
+			plan.nested = append(plan.nested, nestedPlan{
+				index: i,
+				plan:  nested,
+			})
+		}
+	}
+	if len(plan.lookups) == 0 && len(plan.nested) == 0 {
+		return nil, nil
+	}
+	return &plan, nil
+}
+
+func parseTag(tag string) (table, target string, err error) {
+	parts := strings.Split(tag, ",")
+	table = parts[0]
+	if table == "" {
+		return "", "", errors.New("empty table name")
+	}
+	for _, opt := range parts[1:] {
+		if strings.HasPrefix(opt, "target=") {
+			target = strings.TrimPrefix(opt, "target=")
+		}
+	}
+	if target == "" {
+		return "", "", errors.New("missing target field")
+	}
+	return table, target, nil
+}
+
+func findFieldJSON(typ reflect.Type, name string) int {
+	for i := 0; i < typ.NumField(); i++ {
+		tag := typ.Field(i).Tag.Get("json")
+		if idx := strings.IndexByte(tag, ','); idx != -1 {
+			tag = tag[:idx]
+		}
+		if tag == name {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,116 @@
+package lookuptables
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logschema"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+func TestJoiner(t *testing.T) {
+	assert := require.New(t)
+	schema := logschema.Schema{}
+	const spec = `
+version: 0
+fields:
+- name: user
+  type: string
+  lookup:
+    table: users
+    target: user_info
+- name: user_info
+  type: object
+  fields:
+  - name: team
+    type: string
+- name: host
+  type: object
+  fields:
+  - name: name
+    type: string
+    lookup:
+      table: assets
+      target: asset
+  - name: asset
+    type: json
+`
+	assert.NoError(yaml.Unmarshal([]byte(spec), &schema))
+	valueSchema, err := logschema.Resolve(&schema)
+	assert.NoError(err)
+	typ, err := valueSchema.GoType()
+	assert.NoError(err)
+	assert.NoError(CheckEventType(typ))
+
+	api := &testS3{
+		objects: map[string]string{
+			"lookup_tables/users.csv":   "user,team\nalice,security\n",
+			"lookup_tables/assets.json": `[{"hostname":"web-1","env":"prod"}]`,
+		},
+	}
+	joiner := Joiner{
+		Cache: newTestCache(api, TableConfig{
+			Name:     "users",
+			S3Key:    "lookup_tables/users.csv",
+			Format:   FormatCSV,
+			KeyField: "user",
+		}, TableConfig{
+			Name:     "assets",
+			S3Key:    "lookup_tables/assets.json",
+			Format:   FormatJSON,
+			KeyField: "hostname",
+		}),
+	}
+	joiner.Refresh(context.Background())
+
+	event := reflect.New(typ.Elem()).Interface()
+	assert.NoError(pantherlog.ConfigJSON().UnmarshalFromString(`{"user":"alice","host":{"name":"web-1"}}`, event))
+	joiner.Join(event)
+	actual, err := pantherlog.ConfigJSON().MarshalToString(event)
+	assert.NoError(err)
+	expect := `{"user":"alice","user_info":{"team":"security"},"host":{"name":"web-1","asset":{"hostname":"web-1","env":"prod"}}}`
+	assert.JSONEq(expect, actual)
+
+	// Existing values are not replaced
+	event = reflect.New(typ.Elem()).Interface()
+	input := `{"user":"alice","user_info":{"team":"red"}}`
+	assert.NoError(pantherlog.ConfigJSON().UnmarshalFromString(input, event))
+	joiner.Join(event)
+	actual, err = pantherlog.ConfigJSON().MarshalToString(event)
+	assert.NoError(err)
+	assert.JSONEq(input, actual)
+}
+
+func TestCheckEventType(t *testing.T) {
+	type invalid struct {
+		User string `json:"user" lookup:"users,target=missing"`
+	}
+	require.Error(t, CheckEventType(reflect.TypeOf(invalid{})))
+	type valid struct {
+		User     string                 `json:"user" lookup:"users,target=user_info"`
+		UserInfo map[string]interface{} `json:"user_info,omitempty"`
+	}
+	require.NoError(t, CheckEventType(reflect.TypeOf(&valid{})))
+}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/aws/aws-lambda-go/lambda"
 	"github.com/aws/aws-lambda-go/lambdacontext"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"go.uber.org/zap"
 	"gopkg.in/go-playground/validator.v9"
 
@@ -31,6 +32,8 @@ import (
 	"github.com/panther-labs/panther/internal/core/logtypesapi"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/quarantine"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/lookuptables"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/metrics"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/processor"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
@@ -42,11 +45,50 @@ const (
 	defaultScalingDecisionInterval = 30 * time.Second
 )
 
+// Lookup tables are kept across invocations so that they are only reloaded when stale
+var lookups *lookuptables.Joiner
+
 func main() {
 	common.Setup()
+	lookups = newLookupTablesJoiner()
 	lambda.Start(handle)
 }
 
+func newLookupTablesJoiner() *lookuptables.Joiner {
+	client := &logtypesapi.LogTypesAPILambdaClient{
+		LambdaName: logtypesapi.LambdaName,
+		LambdaAPI:  common.LambdaClient,
+		Validate:   validator.New().Struct,
+	}
+	return &lookuptables.Joiner{
+		Cache: &lookuptables.Cache{
+			S3: s3.New(common.Session),
+			ListTables: func(ctx context.Context) ([]lookuptables.TableConfig, error) {
+				reply, err := client.ListLookupTables(ctx)
+				if err != nil {
+					return nil, err
+				}
+				if reply.Error != nil {
+					return nil, reply.Error
+				}
+				tables := make([]lookuptables.TableConfig, 0, len(reply.Records))
+				for _, r := range reply.Records {
+					tables = append(tables, lookuptables.TableConfig{
+						Name:     r.Name,
+						S3Bucket: r.S3Bucket,
+						S3Key:    r.S3Key,
+						Format:   r.Format,
+						KeyField: r.KeyField,
+					})
+				}
+				return tables, nil
+			},
+			RefreshInterval: common.Config.LookupTablesRefreshInterval,
+			MaxMemoryBytes:  int64(common.Config.LookupTablesMaxMemoryMB) * 1024 * 1024,
+		},
+	}
+}
+
 func handle(ctx context.Context) error {
 	lambdalogger.ConfigureGlobal(ctx, nil)
 	return process(ctx, defaultScalingDecisionInterval)
@@ -73,11 +115,12 @@ func process(ctx context.Context, scalingDecisionInterval time.Duration) (err er
 			LambdaAPI:  common.LambdaClient,
 			Validate:   validator.New().Struct,
 		},
-		NativeLogTypes: logtypes.MustMerge("native", registry.NativeLogTypes(), snapshotlogs.LogTypes()),
+		NativeLogTypes: logtypes.MustMerge("native", registry.NativeLogTypes(), snapshotlogs.LogTypes(), quarantine.LogTypes()),
 	}
 
 	// We also need the cloud-security resolvers to handle their delivered S3 objects
-	resolver := logtypes.ChainResolvers(apiResolver, snapshotlogs.Resolver())
+	// and the quarantine resolver to write log lines that could not be classified
+	resolver := logtypes.ChainResolvers(apiResolver, snapshotlogs.Resolver(), quarantine.Resolver())
 
 	// Log cases where a log type failed to resolve. Almost certainly something is amiss in the DDB.
 	logTypesResolver := logtypes.ResolverFunc(func(ctx context.Context, name string) (logtypes.Entry, error) {
@@ -105,8 +148,7 @@ func process(ctx context.Context, scalingDecisionInterval time.Duration) (err er
 		}
 	}()
 
-	parsersResolver := logtypes.ParserResolver(logTypesResolver)
-	sqsMessageCount, err = processor.PollEvents(ctx, common.SqsClient, parsersResolver)
+	sqsMessageCount, err = processor.PollEvents(ctx, common.SqsClient, logTypesResolver, lookups)
 
 	return err
 }
@@ -31,6 +31,7 @@ const (
 	MetricLogProcessorOutputBytes     = "OutputBytes"
 	MetricLogProcessorBytesProcessed  = "BytesProcessed"
 	MetricLogProcessorEventsProcessed = "EventsProcessed"
+	MetricLogProcessorEventsDropped   = "EventsDropped"
 	MetricLogProcessorEventLatency    = "EventLatency"
 
 	// StatusDimension indicating that a subsystem operation is well
@@ -46,6 +47,7 @@ var (
 	GetObject           metrics.Counter
 	BytesProcessed      metrics.Counter
 	EventsProcessed     metrics.Counter
+	EventsDropped       metrics.Counter
 	EventLatencySeconds metrics.Counter
 	OutputFiles         metrics.Counter
 	OutputBytes         metrics.Counter
@@ -65,5 +67,7 @@ func Setup() {
 	// Note that these don't have all the dimensions
 	BytesProcessed = CWManager.NewCounter(MetricLogProcessorBytesProcessed, metrics.UnitBytes)
 	EventsProcessed = CWManager.NewCounter(MetricLogProcessorEventsProcessed, metrics.UnitCount)
+	// Events dropped by the event filters of a source
+	EventsDropped = CWManager.NewCounter(MetricLogProcessorEventsDropped, metrics.UnitCount)
 	EventLatencySeconds = CWManager.NewCounter(MetricLogProcessorEventLatency, metrics.UnitSeconds)
 }
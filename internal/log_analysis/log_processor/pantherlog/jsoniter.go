@@ -78,6 +78,11 @@ func (e *resultEncoder) Encode(ptr unsafe.Pointer, stream *jsoniter.Stream) {
 	// Hack around events with embedded parsers.PantherLog.
 	// TODO: Remove this once all parsers are ported to not use parsers.PantherLog
 	if result.EventIncludesPantherFields {
+		if enricher, ok := stream.Attachment.(ValueEnricher); ok {
+			if event, ok := result.Event.(PantherFieldsEnricher); ok {
+				event.EnrichPantherFields(enricher)
+			}
+		}
 		stream.WriteVal(result.Event)
 		return
 	}
@@ -94,6 +99,11 @@ func (e *resultEncoder) Encode(ptr unsafe.Pointer, stream *jsoniter.Stream) {
 	stream.WriteVal(result.Event)
 	stream.Attachment = att
 
+	// Derive additional indicator values if the stream has an enrichment stage attached
+	if enricher, ok := att.(ValueEnricher); ok && stream.Error == nil {
+		enricher.EnrichValues(result.values)
+	}
+
 	// Extend the JSON object in the stream buffer with the required Panther fields
 	e.writePantherFields(result, stream)
 
@@ -171,6 +171,51 @@ func TestResultEncoder(t *testing.T) {
 	assert.JSONEq(expect, actual)
 }
 
+type testEnricher map[string]string
+
+func (e testEnricher) EnrichValues(values *ValueBuffer) {
+	for _, ip := range values.Get(FieldIPAddress) {
+		values.WriteValues(FieldCountry, e[ip])
+	}
+}
+
+func TestResultEncoderEnricher(t *testing.T) {
+	now := time.Now().UTC()
+	assert := require.New(t)
+	type T struct {
+		RemoteIP string `json:"remote_ip" panther:"ip"`
+		LocalIP  string `json:"local_ip" panther:"ip"`
+	}
+	result := Result{
+		CoreFields: CoreFields{
+			PantherLogType:   "Foo.Bar",
+			PantherRowID:     "id",
+			PantherParseTime: now,
+		},
+		Event: &T{
+			RemoteIP: "2.2.2.2",
+			LocalIP:  "10.0.0.1",
+		},
+	}
+	stream := jsoniter.ConfigDefault.BorrowStream(nil)
+	defer jsoniter.ConfigDefault.ReturnStream(stream)
+	stream.Attachment = testEnricher{"2.2.2.2": "GR"}
+	stream.WriteVal(&result)
+	assert.NoError(stream.Error)
+	expect := fmt.Sprintf(`{
+		"remote_ip":"2.2.2.2",
+		"local_ip":"10.0.0.1",
+		"p_row_id": "id",
+		"p_event_time": "%s",
+		"p_parse_time": "%s",
+		"p_any_ip_addresses": ["10.0.0.1", "2.2.2.2"],
+		"p_any_countries": ["GR"],
+		"p_log_type": "Foo.Bar"
+	}`, now.Format(time.RFC3339Nano), now.Format(time.RFC3339Nano))
+	assert.JSONEq(expect, string(stream.Buffer()))
+	assert.Equal(testEnricher{"2.2.2.2": "GR"}, stream.Attachment, "attachment is restored")
+}
+
 func TestResultEncoderEmptyEvent(t *testing.T) {
 	now := time.Now()
 	assert := require.New(t)
@@ -72,6 +72,9 @@ const (
 	FieldAWSTag
 	FieldEmail
 	FieldUsername
+	FieldCountry
+	FieldASN
+	FieldASOrganization
 )
 
 // ScanValues implements ValueScanner interface
@@ -210,6 +213,21 @@ func init() {
 		NameJSON:    "p_any_usernames",
 		Description: "Panther added field with collection of usernames associated with the row",
 	})
+	MustRegisterIndicator(FieldCountry, FieldMeta{
+		Name:        "PantherAnyCountries",
+		NameJSON:    "p_any_countries",
+		Description: "Panther added field with collection of ISO country codes of the ip addresses associated with the row",
+	})
+	MustRegisterIndicator(FieldASN, FieldMeta{
+		Name:        "PantherAnyASNs",
+		NameJSON:    "p_any_asns",
+		Description: "Panther added field with collection of autonomous system numbers of the ip addresses associated with the row",
+	})
+	MustRegisterIndicator(FieldASOrganization, FieldMeta{
+		Name:        "PantherAnyASOrganizations",
+		NameJSON:    "p_any_as_organizations",
+		Description: "Panther added field with collection of autonomous system organizations of the ip addresses associated with the row",
+	})
 	MustRegisterScannerFunc("ip", ScanIPAddress, FieldIPAddress)
 	MustRegisterScannerFunc("domain", ScanDomainName, FieldDomainName)
 	MustRegisterScannerFunc("md5", ScanMD5Hash, FieldMD5Hash)
@@ -283,6 +301,21 @@ func DefaultIndicators() FieldSet {
 	}
 }
 
+// enrichedFields maps indicator fields to the fields an enrichment stage derives from their values.
+var enrichedFields = map[FieldID]FieldSet{
+	FieldIPAddress: {FieldCountry, FieldASN, FieldASOrganization},
+}
+
+// Enriched returns the fields that enrichment can derive from the values of the fields in the set.
+func (fields FieldSet) Enriched() (enriched FieldSet) {
+	for _, field := range fields {
+		for _, id := range enrichedFields[field] {
+			enriched = enriched.Add(id)
+		}
+	}
+	return
+}
+
 // FieldMeta describes a panther field.
 type FieldMeta struct {
 	Name        string
@@ -340,6 +373,8 @@ func BuildEventTypeSchema(eventType reflect.Type, indicators ...FieldID) (reflec
 	// Auto-detect required field ids
 	indicators = append(indicators, FieldSetFromType(eventType)...)
 	indicators = FieldSet(indicators).Indicators()
+	// Add the fields that enrichment derives from the detected fields
+	indicators = append(indicators, FieldSet(indicators).Enriched()...)
 	// Sort field set to make sure struct fields have strict order
 	sort.Sort(FieldSet(indicators))
 
@@ -39,17 +39,20 @@ func TestMetaEventStruct(t *testing.T) {
 	require.NoError(t, err)
 	// nolint:lll
 	expectMappings := map[string]string{
-		"addr":               "addr",
-		"foo":                "foo",
-		"p_any_domain_names": "p_any_domain_names",
-		"p_any_ip_addresses": "p_any_ip_addresses",
-		"p_event_time":       "p_event_time",
-		"p_log_type":         "p_log_type",
-		"p_parse_time":       "p_parse_time",
-		"p_row_id":           "p_row_id",
-		"p_source_id":        "p_source_id",
-		"p_source_label":     "p_source_label",
-		"ts":                 "ts",
+		"addr":                   "addr",
+		"foo":                    "foo",
+		"p_any_as_organizations": "p_any_as_organizations",
+		"p_any_asns":             "p_any_asns",
+		"p_any_countries":        "p_any_countries",
+		"p_any_domain_names":     "p_any_domain_names",
+		"p_any_ip_addresses":     "p_any_ip_addresses",
+		"p_event_time":           "p_event_time",
+		"p_log_type":             "p_log_type",
+		"p_parse_time":           "p_parse_time",
+		"p_row_id":               "p_row_id",
+		"p_source_id":            "p_source_id",
+		"p_source_label":         "p_source_label",
+		"ts":                     "ts",
 	}
 	require.Equal(t, expectMappings, mappings)
 	// nolint: lll,govet
@@ -65,6 +68,9 @@ func TestMetaEventStruct(t *testing.T) {
 		{"p_source_label", "string", "Panther added field with the source label", false},
 		{"p_any_ip_addresses", "array<string>", "Panther added field with collection of ip addresses associated with the row", false},
 		{"p_any_domain_names", "array<string>", "Panther added field with collection of domain names associated with the row", false},
+		{"p_any_countries", "array<string>", "Panther added field with collection of ISO country codes of the ip addresses associated with the row", false},
+		{"p_any_asns", "array<string>", "Panther added field with collection of autonomous system numbers of the ip addresses associated with the row", false},
+		{"p_any_as_organizations", "array<string>", "Panther added field with collection of autonomous system organizations of the ip addresses associated with the row", false},
 	}, columns)
 }
 
@@ -33,6 +33,18 @@ type ValueWriterTo interface {
 	WriteValuesTo(w ValueWriter)
 }
 
+// ValueEnricher derives additional field values from the values collected for a result.
+// To enrich results, set a ValueEnricher as the attachment of the jsoniter.Stream used to encode them.
+type ValueEnricher interface {
+	EnrichValues(values *ValueBuffer)
+}
+
+// PantherFieldsEnricher is implemented by events that store the Panther fields in the event struct (parsers.PantherLog).
+// These events are enriched by the result encoder before they are written.
+type PantherFieldsEnricher interface {
+	EnrichPantherFields(enricher ValueEnricher)
+}
+
 // ValueBuffer is a reusable buffer of field values.
 // It provides helper methods to collect fields from log entries.
 // A ValueBuffer can be reset and used in a pool.
@@ -22,6 +22,7 @@ import (
 	"net"
 	"regexp"
 	"sort"
+	"sync"
 	"time"
 
 	jsoniter "github.com/json-iterator/go"
@@ -41,6 +42,8 @@ const (
 var (
 	ipv4Regex  = regexp.MustCompile(`(([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5])\.){3}([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5])*`)
 	rowCounter rowid.RowID // number of rows generated in this lambda execution (used to generate p_row_id)
+
+	eventTransforms sync.Map // log type -> func(event interface{})
 )
 
 // All log parsers should extend from this to get standardized fields (all prefixed with 'p_' as JSON for uniqueness)
@@ -64,6 +67,11 @@ type PantherLog struct {
 	PantherAnySHA1Hashes   PantherAnyString `json:"p_any_sha1_hashes,omitempty" description:"Panther added field with collection of SHA1 hashes associated with the row"`
 	PantherAnyMD5Hashes    PantherAnyString `json:"p_any_md5_hashes,omitempty" description:"Panther added field with collection of MD5 hashes associated with the row"`
 	PantherAnySHA256Hashes PantherAnyString `json:"p_any_sha256_hashes,omitempty" description:"Panther added field with collection of SHA256 hashes of any algorithm associated with the row"`
+
+	// enriched (any)
+	PantherAnyCountries       PantherAnyString `json:"p_any_countries,omitempty" description:"Panther added field with collection of ISO country codes of the ip addresses associated with the row"`
+	PantherAnyASNs            PantherAnyString `json:"p_any_asns,omitempty" description:"Panther added field with collection of autonomous system numbers of the ip addresses associated with the row"`
+	PantherAnyASOrganizations PantherAnyString `json:"p_any_as_organizations,omitempty" description:"Panther added field with collection of autonomous system organizations of the ip addresses associated with the row"`
 }
 
 type PantherAnyString []string
@@ -97,7 +105,21 @@ func (pl *PantherLog) Logs() []*PantherLog {
 	return []*PantherLog{pl}
 }
 
+// SetEventTransform sets a function that modifies the events of a log type in place before their p_any_* fields are collected.
+// Parsers based on PantherLog collect indicators right after calling SetCoreFields, so ingest-time transforms
+// need to run there for the indicators to reflect the transformed values. A nil fn removes the transform.
+func SetEventTransform(logType string, fn func(event interface{})) {
+	if fn == nil {
+		eventTransforms.Delete(logType)
+		return
+	}
+	eventTransforms.Store(logType, fn)
+}
+
 func (pl *PantherLog) SetCoreFields(logType string, eventTime *timestamp.RFC3339, event interface{}) {
+	if fn, ok := eventTransforms.Load(logType); ok {
+		fn.(func(interface{}))(event)
+	}
 	parseTime := timestamp.Now()
 
 	if eventTime == nil {
@@ -210,6 +232,17 @@ func (pl *PantherLog) AppendAnySHA256HashesPtr(values ...*string) {
 	}
 }
 
+// EnrichPantherFields implements pantherlog.PantherFieldsEnricher interface
+func (pl *PantherLog) EnrichPantherFields(enricher pantherlog.ValueEnricher) {
+	values := pantherlog.BlankValueBuffer()
+	defer values.Recycle()
+	values.WriteValues(pantherlog.FieldIPAddress, pl.PantherAnyIPAddresses...)
+	enricher.EnrichValues(values)
+	AppendAnyString(&pl.PantherAnyCountries, values.Get(pantherlog.FieldCountry)...)
+	AppendAnyString(&pl.PantherAnyASNs, values.Get(pantherlog.FieldASN)...)
+	AppendAnyString(&pl.PantherAnyASOrganizations, values.Get(pantherlog.FieldASOrganization)...)
+}
+
 func AppendAnyString(any *PantherAnyString, values ...string) {
 	// add new if not present
 	for _, v := range values {
@@ -26,6 +26,7 @@ import (
 	jsoniter "github.com/json-iterator/go"
 	"github.com/stretchr/testify/require"
 
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
 )
 
@@ -236,3 +237,42 @@ func TestAppendAnyMD5Hashes(t *testing.T) {
 	event.AppendAnyMD5HashPtrs(&value)
 	require.Equal(t, expectedAny, event.PantherAnyMD5Hashes)
 }
+
+type testEnricher map[string]string
+
+func (e testEnricher) EnrichValues(values *pantherlog.ValueBuffer) {
+	for _, ip := range values.Get(pantherlog.FieldIPAddress) {
+		values.WriteValues(pantherlog.FieldCountry, e[ip])
+		values.WriteValues(pantherlog.FieldASN, "13335")
+	}
+}
+
+func TestEnrichPantherFields(t *testing.T) {
+	type testEvent struct {
+		RemoteIP string `json:"remote_ip"`
+		PantherLog
+	}
+	event := testEvent{
+		RemoteIP: "2.2.2.2",
+	}
+	event.SetCoreFields("Foo.Bar", nil, &event)
+	event.AppendAnyIPAddress(event.RemoteIP)
+	event.AppendAnyIPAddress("10.0.0.1")
+	result := event.Result()
+
+	stream := jsoniter.ConfigDefault.BorrowStream(nil)
+	defer jsoniter.ConfigDefault.ReturnStream(stream)
+	stream.Attachment = testEnricher{"2.2.2.2": "GR"}
+	stream.WriteVal(result)
+	require.NoError(t, stream.Error)
+	require.Equal(t, PantherAnyString{"GR"}, event.PantherAnyCountries)
+	require.Equal(t, PantherAnyString{"13335"}, event.PantherAnyASNs)
+	require.Empty(t, event.PantherAnyASOrganizations)
+	actual := struct {
+		Countries []string `json:"p_any_countries"`
+		ASNs      []string `json:"p_any_asns"`
+	}{}
+	require.NoError(t, jsoniter.Unmarshal(stream.Buffer(), &actual))
+	require.Equal(t, []string{"GR"}, actual.Countries)
+	require.Equal(t, []string{"13335"}, actual.ASNs)
+}
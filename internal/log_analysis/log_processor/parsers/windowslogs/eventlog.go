@@ -0,0 +1,387 @@
+package windowslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"encoding/xml"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+// nolint:lll
+type EventLog struct {
+	System          System            `json:"System" validate:"required" description:"Information about the event that is common to all events"`
+	EventData       map[string]string `json:"EventData,omitempty" description:"The named data items of the event (the Name attribute of each Data element is used as the key)"`
+	EventDataValues []string          `json:"EventDataValues,omitempty" description:"The data items of the event that have no name (ie events of classic providers)"`
+	UserData        map[string]string `json:"UserData,omitempty" description:"The provider specific data of the event"`
+	RenderingInfo   *RenderingInfo    `json:"RenderingInfo,omitempty" description:"The localized message and names of the event (only when exported with rendering info)"`
+}
+
+// nolint:lll
+type System struct {
+	ProviderName      pantherlog.String `json:"ProviderName,omitempty" description:"The name of the event provider that logged the event"`
+	ProviderGUID      pantherlog.String `json:"ProviderGuid,omitempty" description:"The GUID of the event provider that logged the event"`
+	EventSourceName   pantherlog.String `json:"EventSourceName,omitempty" description:"The name of the event source (for classic event providers)"`
+	EventID           pantherlog.Uint16 `json:"EventID" validate:"required" description:"The identifier of the event"`
+	Qualifiers        pantherlog.Uint16 `json:"Qualifiers,omitempty" description:"The qualifiers of the event identifier (for classic event providers)"`
+	Version           pantherlog.Uint8  `json:"Version,omitempty" description:"The version of the event definition"`
+	Level             pantherlog.Uint8  `json:"Level,omitempty" description:"The severity level of the event"`
+	Task              pantherlog.Uint16 `json:"Task,omitempty" description:"The task of the event"`
+	Opcode            pantherlog.Uint8  `json:"Opcode,omitempty" description:"The opcode of the event"`
+	Keywords          pantherlog.String `json:"Keywords,omitempty" description:"The bitmask of the keywords of the event"`
+	TimeCreated       pantherlog.Time   `json:"TimeCreated" validate:"required" tcodec:"rfc3339" event_time:"true" description:"The time the event was logged"`
+	EventRecordID     pantherlog.Uint64 `json:"EventRecordID,omitempty" description:"The number of the event record in the log"`
+	ActivityID        pantherlog.String `json:"ActivityID,omitempty" description:"The identifier of the activity the event belongs to"`
+	RelatedActivityID pantherlog.String `json:"RelatedActivityID,omitempty" description:"The identifier of a related activity"`
+	ProcessID         pantherlog.Uint32 `json:"ProcessID,omitempty" description:"The identifier of the process that logged the event"`
+	ThreadID          pantherlog.Uint32 `json:"ThreadID,omitempty" description:"The identifier of the thread that logged the event"`
+	Channel           pantherlog.String `json:"Channel,omitempty" description:"The channel the event was logged to"`
+	Computer          pantherlog.String `json:"Computer,omitempty" panther:"hostname" description:"The name of the computer on which the event occurred"`
+	UserID            pantherlog.String `json:"UserID,omitempty" description:"The security identifier (SID) of the user the event was logged for"`
+}
+
+// nolint:lll
+type RenderingInfo struct {
+	Culture  pantherlog.String `json:"Culture,omitempty" description:"The language of the rendered values"`
+	Message  pantherlog.String `json:"Message,omitempty" description:"The rendered message of the event"`
+	Level    pantherlog.String `json:"Level,omitempty" description:"The rendered name of the level"`
+	Task     pantherlog.String `json:"Task,omitempty" description:"The rendered name of the task"`
+	Opcode   pantherlog.String `json:"Opcode,omitempty" description:"The rendered name of the opcode"`
+	Channel  pantherlog.String `json:"Channel,omitempty" description:"The rendered name of the channel"`
+	Provider pantherlog.String `json:"Provider,omitempty" description:"The rendered name of the provider"`
+	Keywords []string          `json:"Keywords,omitempty" description:"The rendered names of the keywords"`
+}
+
+var _ pantherlog.ValueWriterTo = (*EventLog)(nil)
+
+// WriteValuesTo extracts indicators from the data items based on their names
+func (event *EventLog) WriteValuesTo(w pantherlog.ValueWriter) {
+	writeDataValues(w, event.EventData)
+	writeDataValues(w, event.UserData)
+}
+
+func writeDataValues(w pantherlog.ValueWriter, data map[string]string) {
+	for name, value := range data {
+		if value == "" || value == "-" {
+			continue
+		}
+		switch {
+		case hasSuffix(name, "UserName", "AccountName", "User"):
+			w.WriteValues(pantherlog.FieldUsername, value)
+		case hasSuffix(name, "IpAddress", "Address", "Ip"):
+			pantherlog.ScanIPAddress(w, value)
+		case hasSuffix(name, "WorkstationName", "Workstation", "HostName", "Hostname", "ComputerName", "ServerName"):
+			pantherlog.ScanHostname(w, value)
+		}
+	}
+}
+
+func hasSuffix(s string, suffixes ...string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(s, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+type eventLogParser struct {
+	builder pantherlog.ResultBuilder
+}
+
+var _ pantherlog.LogParser = (*eventLogParser)(nil)
+
+// exportFramingRx matches lines of an exported log that hold no event: the XML declaration and the tags of the Events element.
+var exportFramingRx = regexp.MustCompile(`^\s*(<\?xml\s[^>]*\?>)?\s*(<Events(\s[^>]*)?>|</Events>)?\s*$`)
+
+// ParseLog parses the first Event element in a log line.
+// The XML declaration and the tags of the Events element of an export produce no results, all other lines must hold an Event.
+func (p *eventLogParser) ParseLog(log string) ([]*pantherlog.Result, error) {
+	if strings.TrimSpace(log) != "" && exportFramingRx.MatchString(log) {
+		return nil, nil
+	}
+	d := xml.NewDecoder(strings.NewReader(log))
+	// The log line is already decoded so we ignore the encoding in the XML declaration
+	d.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+	start, err := findEvent(d)
+	if err != nil {
+		return nil, err
+	}
+	x := xmlEvent{}
+	if err := d.DecodeElement(&x, start); err != nil {
+		return nil, errors.Wrap(err, "failed to decode Event XML")
+	}
+	event, err := x.EventLog()
+	if err != nil {
+		return nil, err
+	}
+	if err := pantherlog.ValidateStruct(event); err != nil {
+		return nil, err
+	}
+	result, err := p.builder.BuildResult(TypeEventLog, event)
+	if err != nil {
+		return nil, err
+	}
+	return []*pantherlog.Result{result}, nil
+}
+
+func findEvent(d *xml.Decoder) (*xml.StartElement, error) {
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			if err == io.EOF {
+				return nil, errors.New("no Event element in log line")
+			}
+			return nil, errors.Wrap(err, "failed to read XML")
+		}
+		if start, ok := tok.(xml.StartElement); ok && start.Name.Local == "Event" {
+			return &start, nil
+		}
+	}
+}
+
+// xmlEvent mirrors the structure of the Event XML
+type xmlEvent struct {
+	System struct {
+		Provider struct {
+			Name            string `xml:"Name,attr"`
+			GUID            string `xml:"Guid,attr"`
+			EventSourceName string `xml:"EventSourceName,attr"`
+		} `xml:"Provider"`
+		EventID struct {
+			Value      string `xml:",chardata"`
+			Qualifiers string `xml:"Qualifiers,attr"`
+		} `xml:"EventID"`
+		Version     string `xml:"Version"`
+		Level       string `xml:"Level"`
+		Task        string `xml:"Task"`
+		Opcode      string `xml:"Opcode"`
+		Keywords    string `xml:"Keywords"`
+		TimeCreated struct {
+			SystemTime string `xml:"SystemTime,attr"`
+		} `xml:"TimeCreated"`
+		EventRecordID string `xml:"EventRecordID"`
+		Correlation   struct {
+			ActivityID        string `xml:"ActivityID,attr"`
+			RelatedActivityID string `xml:"RelatedActivityID,attr"`
+		} `xml:"Correlation"`
+		Execution struct {
+			ProcessID string `xml:"ProcessID,attr"`
+			ThreadID  string `xml:"ThreadID,attr"`
+		} `xml:"Execution"`
+		Channel  string `xml:"Channel"`
+		Computer string `xml:"Computer"`
+		Security struct {
+			UserID string `xml:"UserID,attr"`
+		} `xml:"Security"`
+	} `xml:"System"`
+	EventData     xmlEventData `xml:"EventData"`
+	UserData      xmlUserData  `xml:"UserData"`
+	RenderingInfo *struct {
+		Culture  string   `xml:"Culture,attr"`
+		Message  string   `xml:"Message"`
+		Level    string   `xml:"Level"`
+		Task     string   `xml:"Task"`
+		Opcode   string   `xml:"Opcode"`
+		Channel  string   `xml:"Channel"`
+		Provider string   `xml:"Provider"`
+		Keywords []string `xml:"Keywords>Keyword"`
+	} `xml:"RenderingInfo"`
+}
+
+// EventLog converts the XML values to an EventLog
+func (x *xmlEvent) EventLog() (*EventLog, error) {
+	sys := &x.System
+	tm, err := time.Parse(time.RFC3339Nano, sys.TimeCreated.SystemTime)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid TimeCreated")
+	}
+	var p uintParser
+	event := EventLog{
+		System: System{
+			ProviderName:      optionalString(sys.Provider.Name),
+			ProviderGUID:      optionalString(sys.Provider.GUID),
+			EventSourceName:   optionalString(sys.Provider.EventSourceName),
+			EventID:           p.Uint16("EventID", sys.EventID.Value),
+			Qualifiers:        p.Uint16("Qualifiers", sys.EventID.Qualifiers),
+			Version:           p.Uint8("Version", sys.Version),
+			Level:             p.Uint8("Level", sys.Level),
+			Task:              p.Uint16("Task", sys.Task),
+			Opcode:            p.Uint8("Opcode", sys.Opcode),
+			Keywords:          optionalString(sys.Keywords),
+			TimeCreated:       tm,
+			EventRecordID:     p.Uint64("EventRecordID", sys.EventRecordID),
+			ActivityID:        optionalString(sys.Correlation.ActivityID),
+			RelatedActivityID: optionalString(sys.Correlation.RelatedActivityID),
+			ProcessID:         p.Uint32("ProcessID", sys.Execution.ProcessID),
+			ThreadID:          p.Uint32("ThreadID", sys.Execution.ThreadID),
+			Channel:           optionalString(sys.Channel),
+			Computer:          optionalString(sys.Computer),
+			UserID:            optionalString(sys.Security.UserID),
+		},
+		EventData:       x.EventData.Named,
+		EventDataValues: x.EventData.Values,
+		UserData:        x.UserData.Values,
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	if r := x.RenderingInfo; r != nil {
+		event.RenderingInfo = &RenderingInfo{
+			Culture:  optionalString(r.Culture),
+			Message:  optionalString(r.Message),
+			Level:    optionalString(r.Level),
+			Task:     optionalString(r.Task),
+			Opcode:   optionalString(r.Opcode),
+			Channel:  optionalString(r.Channel),
+			Provider: optionalString(r.Provider),
+			Keywords: r.Keywords,
+		}
+	}
+	return &event, nil
+}
+
+// optionalString keeps missing or empty XML values null
+func optionalString(s string) pantherlog.String {
+	if s == "" {
+		return pantherlog.String{}
+	}
+	return null.FromString(s)
+}
+
+// uintParser parses optional unsigned integers keeping the first error
+type uintParser struct {
+	err error
+}
+
+func (p *uintParser) parse(name, value string, bitSize int) (uint64, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" || p.err != nil {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(value, 10, bitSize)
+	if err != nil {
+		p.err = errors.Wrapf(err, "invalid %s", name)
+		return 0, false
+	}
+	return n, true
+}
+
+func (p *uintParser) Uint8(name, value string) pantherlog.Uint8 {
+	n, ok := p.parse(name, value, 8)
+	return pantherlog.Uint8{Value: uint8(n), Exists: ok}
+}
+
+func (p *uintParser) Uint16(name, value string) pantherlog.Uint16 {
+	n, ok := p.parse(name, value, 16)
+	return pantherlog.Uint16{Value: uint16(n), Exists: ok}
+}
+
+func (p *uintParser) Uint32(name, value string) pantherlog.Uint32 {
+	n, ok := p.parse(name, value, 32)
+	return pantherlog.Uint32{Value: uint32(n), Exists: ok}
+}
+
+func (p *uintParser) Uint64(name, value string) pantherlog.Uint64 {
+	n, ok := p.parse(name, value, 64)
+	return pantherlog.Uint64{Value: n, Exists: ok}
+}
+
+// xmlEventData maps `<Data Name="foo">bar</Data>` elements to key/value pairs.
+// Data elements without a name and other elements (ie Binary) are kept in order as values.
+type xmlEventData struct {
+	Named  map[string]string
+	Values []string
+}
+
+func (data *xmlEventData) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var item struct {
+		Name  string `xml:"Name,attr"`
+		Value string `xml:",chardata"`
+	}
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			item.Name, item.Value = "", ""
+			if err := d.DecodeElement(&item, &t); err != nil {
+				return err
+			}
+			if item.Name == "" {
+				data.Values = append(data.Values, item.Value)
+				continue
+			}
+			if data.Named == nil {
+				data.Named = make(map[string]string)
+			}
+			data.Named[item.Name] = item.Value
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// xmlUserData maps the elements of the provider specific data to key/value pairs using the element names.
+type xmlUserData struct {
+	Values map[string]string
+}
+
+func (data *xmlUserData) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var (
+		name  string
+		value []byte
+	)
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			// Nested elements override their parent so that only leaf elements are kept
+			name, value = t.Name.Local, value[:0]
+		case xml.CharData:
+			value = append(value, t...)
+		case xml.EndElement:
+			if t.Name.Local == start.Name.Local {
+				return nil
+			}
+			if name != "" {
+				if data.Values == nil {
+					data.Values = make(map[string]string)
+				}
+				data.Values[name] = string(value)
+			}
+			name, value = "", value[:0]
+		}
+	}
+}
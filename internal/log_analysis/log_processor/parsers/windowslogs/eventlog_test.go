@@ -0,0 +1,55 @@
+package windowslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes/logtesting"
+)
+
+func TestEventLogParser(t *testing.T) {
+	logtesting.RunTestsFromYAML(t, LogTypes(), "./testdata/eventlog_tests.yml")
+}
+
+func TestEventLogParserFraming(t *testing.T) {
+	p, err := LogTypes().Find(TypeEventLog).NewParser(nil)
+	require.NoError(t, err)
+	for _, line := range []string{
+		`<?xml version="1.0" encoding="UTF-8"?>`,
+		`<Events>`,
+		`<Events xmlns="http://schemas.microsoft.com/win/2004/08/events/event">`,
+		`</Events>`,
+	} {
+		results, err := p.ParseLog(line)
+		require.NoError(t, err, line)
+		require.Nil(t, results, line)
+	}
+	for _, line := range []string{
+		``,
+		`{"foo":"bar"}`,
+		`<items><item/></items>`,
+		`<?xml version="1.0"?><items>`,
+	} {
+		_, err := p.ParseLog(line)
+		require.Error(t, err, line)
+	}
+}
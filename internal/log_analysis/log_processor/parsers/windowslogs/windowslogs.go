@@ -0,0 +1,50 @@
+package windowslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+const (
+	TypeEventLog = "Windows.EventLog"
+)
+
+// LogTypes exports the available log type entries
+func LogTypes() logtypes.Group {
+	return logTypes
+}
+
+// nolint:lll
+var logTypes = logtypes.Must("Windows",
+	logtypes.Config{
+		Name:         TypeEventLog,
+		Description:  `Windows Event Log events exported as XML, one event per line (ie with 'wevtutil qe <channel> /f:xml').`,
+		ReferenceURL: `https://docs.microsoft.com/en-us/windows/win32/wes/eventschema-schema`,
+		Schema: pantherlog.MustBuildEventSchema(EventLog{},
+			pantherlog.FieldIPAddress,
+			pantherlog.FieldDomainName,
+			pantherlog.FieldUsername,
+		),
+		NewParser: pantherlog.FactoryFunc(func(_ interface{}) (pantherlog.LogParser, error) {
+			return &eventLogParser{}, nil
+		}),
+	},
+)
@@ -0,0 +1,178 @@
+package preprocessors
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// CEFConfig parses ArcSight Common Event Format lines
+// `CEF:Version|Device Vendor|Device Product|Device Version|Device Event Class ID|Name|Severity|Extension`
+// nolint:lll
+type CEFConfig struct {
+	HeaderFields []string          `json:"headerFields,omitempty" yaml:"headerFields,omitempty" description:"Field names for the 7 CEF header fields"`
+	FieldNames   map[string]string `json:"fieldNames,omitempty" yaml:"fieldNames,omitempty" description:"Rename extension keys to field names"`
+	SkipPrefix   string            `json:"skipPrefix,omitempty" yaml:"skipPrefix,omitempty" description:"Skip comment lines by prefix"`
+	EmptyValues  []string          `json:"emptyValues,omitempty" yaml:"emptyValues,omitempty" description:"Placeholder value for empty or missing data"`
+	ExpandFields map[string]string `json:"expandFields,omitempty" yaml:"expandFields,omitempty" description:"Add fields by text templates"`
+	TrimSpace    bool              `json:"trimSpace,omitempty" yaml:"trimSpace,omitempty" description:"Trim space surrounding values"`
+}
+
+const cefPrefix = "CEF:"
+
+// DefaultCEFHeaderFields are the field names used for CEF header fields if no names are configured.
+var DefaultCEFHeaderFields = []string{
+	"version",
+	"deviceVendor",
+	"deviceProduct",
+	"deviceVersion",
+	"deviceEventClassId",
+	"name",
+	"severity",
+}
+
+func (config CEFConfig) BuildPreprocessor() (Interface, error) {
+	headers := DefaultCEFHeaderFields
+	if config.HeaderFields != nil {
+		headers = config.HeaderFields
+	}
+	if len(headers) != len(DefaultCEFHeaderFields) {
+		return nil, errors.Errorf("CEF header has %d fields", len(DefaultCEFHeaderFields))
+	}
+	fieldNames := config.FieldNames
+	return &matchTextPreprocessor{
+		match: func(dst []string, src string) ([]string, error) {
+			fields, err := splitCEF(dst, headers, src)
+			if err != nil {
+				return fields, err
+			}
+			// Only rename extension keys
+			renameFieldsInPlace(fields[len(dst)+2*len(headers):], fieldNames)
+			return fields, nil
+		},
+		skipPrefix:   config.SkipPrefix,
+		emptyValues:  config.EmptyValues,
+		expandFields: compileFieldTemplates(config.ExpandFields),
+		trimSpace:    config.TrimSpace,
+		stream:       buildJSONStream(),
+	}, nil
+}
+
+// appends the header and extension fields of a CEF line to dst
+// Any text before the `CEF:` prefix (ie a syslog header) is ignored.
+func splitCEF(dst []string, headers []string, src string) ([]string, error) {
+	pos := strings.Index(src, cefPrefix)
+	if pos == -1 {
+		return dst, errors.New("missing CEF prefix")
+	}
+	src = src[pos+len(cefPrefix):]
+	for _, name := range headers {
+		pos := indexUnescaped(src, '|')
+		if pos == -1 {
+			return dst, errors.New("invalid CEF header")
+		}
+		dst = append(dst, name, unescapeCEF(src[:pos]))
+		src = src[pos+1:]
+	}
+	return splitCEFExtension(dst, src), nil
+}
+
+// appends the key/value pairs of a CEF extension to dst
+// Values can contain spaces so a key starts after the last space before an unescaped `=`.
+func splitCEFExtension(dst []string, src string) []string {
+	src = strings.TrimLeft(src, " ")
+	var (
+		key   string
+		value int // start of the current value
+	)
+	for i := 0; i < len(src); i++ {
+		switch src[i] {
+		case '\\':
+			// skip escaped character
+			i++
+		case '=':
+			start := strings.LastIndexByte(src[:i], ' ') + 1
+			if start < value || !isCEFKey(src[start:i]) {
+				// unescaped '=' inside a value
+				continue
+			}
+			if key != "" {
+				dst = append(dst, key, unescapeCEF(strings.TrimRight(src[value:start], " ")))
+			}
+			key, value = src[start:i], i+1
+		}
+	}
+	if key != "" {
+		dst = append(dst, key, unescapeCEF(strings.TrimRight(src[value:], " ")))
+	}
+	return dst
+}
+
+func isCEFKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		switch c := key[i]; {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		case c == '_', c == '.', c == '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// finds the first occurrence of c that is not escaped with a backslash
+func indexUnescaped(s string, c byte) int {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case c:
+			return i
+		}
+	}
+	return -1
+}
+
+// replaces CEF escape sequences (`\|`, `\\`, `\=`, `\n`, `\r`)
+func unescapeCEF(s string) string {
+	if strings.IndexByte(s, '\\') == -1 {
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '\\' && i+1 < len(s) {
+			i++
+			switch c = s[i]; c {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			}
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
@@ -0,0 +1,57 @@
+package preprocessors
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// nolint:lll
+func TestCEF(t *testing.T) {
+	assert := require.New(t)
+	pp, err := CEFConfig{
+		FieldNames: map[string]string{"src": "sourceAddress"},
+	}.BuildPreprocessor()
+	assert.NoError(err)
+	out, err := pp.PreProcessLog(`Sep 19 08:26:10 host CEF:0|Security|threat\|manager|1.0|100|worm successfully stopped|10|src=10.0.0.1 dst=2.1.2.2 msg=Detected a threat\=worm. No action needed request=http://example.com/?a=b`)
+	assert.NoError(err)
+	assert.JSONEq(`{
+		"version": "0",
+		"deviceVendor": "Security",
+		"deviceProduct": "threat|manager",
+		"deviceVersion": "1.0",
+		"deviceEventClassId": "100",
+		"name": "worm successfully stopped",
+		"severity": "10",
+		"sourceAddress": "10.0.0.1",
+		"dst": "2.1.2.2",
+		"msg": "Detected a threat=worm. No action needed",
+		"request": "http://example.com/?a=b"
+	}`, out)
+
+	_, err = pp.PreProcessLog(`CEF:0|Security|threat`)
+	assert.Error(err)
+	_, err = pp.PreProcessLog(`foo`)
+	assert.Error(err)
+
+	_, err = CEFConfig{HeaderFields: []string{"version"}}.BuildPreprocessor()
+	assert.Error(err)
+}
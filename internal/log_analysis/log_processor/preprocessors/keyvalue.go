@@ -0,0 +1,165 @@
+package preprocessors
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// nolint:lll
+type KeyValueConfig struct {
+	Delimiter    string            `json:"delimiter,omitempty" yaml:"delimiter,omitempty" description:"Delimiter between key/value pairs (defaults to space)"`
+	Separator    string            `json:"separator,omitempty" yaml:"separator,omitempty" description:"Separator between a key and its value (defaults to '=')"`
+	Quotes       string            `json:"quotes,omitempty" yaml:"quotes,omitempty" description:"Characters used to quote values containing delimiters (defaults to '\"')"`
+	FieldNames   map[string]string `json:"fieldNames,omitempty" yaml:"fieldNames,omitempty" description:"Rename keys to field names"`
+	SkipLines    int               `json:"skipLines,omitempty" yaml:"skipLines,omitempty" description:"Number of lines to skip at start of file"`
+	SkipPrefix   string            `json:"skipPrefix,omitempty" yaml:"skipPrefix,omitempty" description:"Skip comment lines by prefix"`
+	EmptyValues  []string          `json:"emptyValues,omitempty" yaml:"emptyValues,omitempty" description:"Placeholder value for empty or missing data"`
+	ExpandFields map[string]string `json:"expandFields,omitempty" yaml:"expandFields,omitempty" description:"Add fields by text templates"`
+	TrimSpace    bool              `json:"trimSpace,omitempty" yaml:"trimSpace,omitempty" description:"Trim space surrounding values"`
+}
+
+const (
+	defaultKeyValueDelimiter = " "
+	defaultKeyValueSeparator = "="
+	defaultKeyValueQuotes    = `"`
+)
+
+func (config KeyValueConfig) BuildPreprocessor() (Interface, error) {
+	s := kvSplitter{
+		delimiter: config.Delimiter,
+		separator: config.Separator,
+		quotes:    config.Quotes,
+	}
+	if s.delimiter == "" {
+		s.delimiter = defaultKeyValueDelimiter
+	}
+	if s.separator == "" {
+		s.separator = defaultKeyValueSeparator
+	}
+	if s.quotes == "" {
+		s.quotes = defaultKeyValueQuotes
+	}
+	if strings.Contains(s.delimiter, s.separator) || strings.Contains(s.separator, s.delimiter) {
+		return nil, errors.New("key/value delimiter and separator overlap")
+	}
+	fieldNames := config.FieldNames
+	return &matchTextPreprocessor{
+		match: func(dst []string, src string) ([]string, error) {
+			fields, err := s.split(dst, src)
+			if err != nil {
+				return fields, err
+			}
+			renameFieldsInPlace(fields, fieldNames)
+			return fields, nil
+		},
+		skipLines:    config.SkipLines,
+		skipPrefix:   config.SkipPrefix,
+		emptyValues:  config.EmptyValues,
+		expandFields: compileFieldTemplates(config.ExpandFields),
+		trimSpace:    config.TrimSpace,
+		stream:       buildJSONStream(),
+	}, nil
+}
+
+// kvSplitter splits logfmt style lines (`foo=bar baz="qux quux"`) to key/value pairs
+type kvSplitter struct {
+	delimiter string
+	separator string
+	quotes    string
+}
+
+// appends key/value pairs found in src to dst
+func (s *kvSplitter) split(dst []string, src string) ([]string, error) {
+	for {
+		// skip repeated delimiters
+		for strings.HasPrefix(src, s.delimiter) {
+			src = src[len(s.delimiter):]
+		}
+		if src == "" {
+			return dst, nil
+		}
+		pos := strings.Index(src, s.separator)
+		if pos == -1 || strings.Contains(src[:pos], s.delimiter) {
+			return dst, errors.New("missing key/value separator")
+		}
+		if pos == 0 {
+			return dst, errors.New("empty key")
+		}
+		key := src[:pos]
+		value, tail, err := s.value(src[pos+len(s.separator):])
+		if err != nil {
+			return dst, err
+		}
+		dst = append(dst, key, value)
+		src = tail
+	}
+}
+
+// reads a value that is either quoted or ends at the next delimiter
+func (s *kvSplitter) value(src string) (value, tail string, err error) {
+	if src != "" && strings.IndexByte(s.quotes, src[0]) != -1 {
+		value, tail, err = unquoteValue(src)
+		if err != nil {
+			return "", "", err
+		}
+		if tail != "" && !strings.HasPrefix(tail, s.delimiter) {
+			return "", "", errors.New("missing delimiter after quoted value")
+		}
+		return value, tail, nil
+	}
+	if pos := strings.Index(src, s.delimiter); pos != -1 {
+		return src[:pos], src[pos:], nil
+	}
+	return src, "", nil
+}
+
+// unquotes a value that starts with a quote character, handling backslash escapes of the quote and the backslash itself
+func unquoteValue(src string) (value, tail string, err error) {
+	quote := src[0]
+	var (
+		b       strings.Builder
+		escaped bool
+	)
+	for i := 1; i < len(src); i++ {
+		c := src[i]
+		if c == quote {
+			if !escaped {
+				// avoid allocation if there were no escapes
+				return src[1:i], src[i+1:], nil
+			}
+			return b.String(), src[i+1:], nil
+		}
+		if c == '\\' && i+1 < len(src) && (src[i+1] == quote || src[i+1] == '\\') {
+			if !escaped {
+				b.WriteString(src[1:i])
+				escaped = true
+			}
+			b.WriteByte(src[i+1])
+			i++
+			continue
+		}
+		if escaped {
+			b.WriteByte(c)
+		}
+	}
+	return "", "", errors.New("unterminated quoted value")
+}
@@ -0,0 +1,60 @@
+package preprocessors
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyValue(t *testing.T) {
+	assert := require.New(t)
+	pp, err := KeyValueConfig{
+		FieldNames:  map[string]string{"msg": "message"},
+		EmptyValues: []string{"-"},
+	}.BuildPreprocessor()
+	assert.NoError(err)
+	out, err := pp.PreProcessLog(`level=info  msg="hello \"world\"" user=- path=/foo?a=b empty=""`)
+	assert.NoError(err)
+	assert.JSONEq(`{"level":"info","message":"hello \"world\"","path":"/foo?a=b","empty":""}`, out)
+
+	for _, invalid := range []string{
+		`foo bar=baz`,
+		`=bar`,
+		`foo="bar`,
+		`foo="bar"baz`,
+	} {
+		_, err = pp.PreProcessLog(invalid)
+		assert.Error(err, invalid)
+	}
+
+	pp, err = KeyValueConfig{
+		Delimiter: ", ",
+		Separator: ": ",
+		Quotes:    `'`,
+	}.BuildPreprocessor()
+	assert.NoError(err)
+	out, err = pp.PreProcessLog(`foo: bar, baz: 'a, b'`)
+	assert.NoError(err)
+	assert.JSONEq(`{"foo":"bar","baz":"a, b"}`, out)
+
+	_, err = KeyValueConfig{Delimiter: "=", Separator: "="}.BuildPreprocessor()
+	assert.Error(err)
+}
@@ -0,0 +1,147 @@
+package preprocessors
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// LEEFConfig parses IBM QRadar Log Event Extended Format lines
+// `LEEF:Version|Vendor|Product|Version|EventID|[Delimiter|]Attributes`
+// nolint:lll
+type LEEFConfig struct {
+	HeaderFields []string          `json:"headerFields,omitempty" yaml:"headerFields,omitempty" description:"Field names for the 5 LEEF header fields"`
+	Delimiter    string            `json:"delimiter,omitempty" yaml:"delimiter,omitempty" description:"Delimiter between attributes (overrides the LEEF 2.0 header delimiter, defaults to tab)"`
+	FieldNames   map[string]string `json:"fieldNames,omitempty" yaml:"fieldNames,omitempty" description:"Rename attribute keys to field names"`
+	SkipPrefix   string            `json:"skipPrefix,omitempty" yaml:"skipPrefix,omitempty" description:"Skip comment lines by prefix"`
+	EmptyValues  []string          `json:"emptyValues,omitempty" yaml:"emptyValues,omitempty" description:"Placeholder value for empty or missing data"`
+	ExpandFields map[string]string `json:"expandFields,omitempty" yaml:"expandFields,omitempty" description:"Add fields by text templates"`
+	TrimSpace    bool              `json:"trimSpace,omitempty" yaml:"trimSpace,omitempty" description:"Trim space surrounding values"`
+}
+
+const (
+	leefPrefix           = "LEEF:"
+	defaultLEEFDelimiter = "\t"
+)
+
+// DefaultLEEFHeaderFields are the field names used for LEEF header fields if no names are configured.
+var DefaultLEEFHeaderFields = []string{
+	"version",
+	"vendor",
+	"product",
+	"productVersion",
+	"eventId",
+}
+
+func (config LEEFConfig) BuildPreprocessor() (Interface, error) {
+	headers := DefaultLEEFHeaderFields
+	if config.HeaderFields != nil {
+		headers = config.HeaderFields
+	}
+	if len(headers) != len(DefaultLEEFHeaderFields) {
+		return nil, errors.Errorf("LEEF header has %d fields", len(DefaultLEEFHeaderFields))
+	}
+	fieldNames := config.FieldNames
+	delimiter := config.Delimiter
+	return &matchTextPreprocessor{
+		match: func(dst []string, src string) ([]string, error) {
+			fields, err := splitLEEF(dst, headers, delimiter, src)
+			if err != nil {
+				return fields, err
+			}
+			// Only rename attribute keys
+			renameFieldsInPlace(fields[len(dst)+2*len(headers):], fieldNames)
+			return fields, nil
+		},
+		skipPrefix:   config.SkipPrefix,
+		emptyValues:  config.EmptyValues,
+		expandFields: compileFieldTemplates(config.ExpandFields),
+		trimSpace:    config.TrimSpace,
+		stream:       buildJSONStream(),
+	}, nil
+}
+
+// appends the header and attribute fields of a LEEF line to dst
+// Any text before the `LEEF:` prefix (ie a syslog header) is ignored.
+func splitLEEF(dst []string, headers []string, delimiter, src string) ([]string, error) {
+	pos := strings.Index(src, leefPrefix)
+	if pos == -1 {
+		return dst, errors.New("missing LEEF prefix")
+	}
+	src = src[pos+len(leefPrefix):]
+	for _, name := range headers {
+		pos := strings.IndexByte(src, '|')
+		if pos == -1 {
+			return dst, errors.New("invalid LEEF header")
+		}
+		dst = append(dst, name, src[:pos])
+		src = src[pos+1:]
+	}
+	// LEEF 2.0 headers specify the attribute delimiter
+	if version := dst[len(dst)-2*len(headers)+1]; strings.HasPrefix(version, "2") {
+		pos := strings.IndexByte(src, '|')
+		if pos == -1 {
+			return dst, errors.New("missing LEEF delimiter")
+		}
+		d, err := parseLEEFDelimiter(src[:pos])
+		if err != nil {
+			return dst, err
+		}
+		if delimiter == "" {
+			delimiter = d
+		}
+		src = src[pos+1:]
+	}
+	if delimiter == "" {
+		delimiter = defaultLEEFDelimiter
+	}
+	for _, attr := range strings.Split(src, delimiter) {
+		if attr == "" {
+			continue
+		}
+		pos := strings.IndexByte(attr, '=')
+		if pos < 1 {
+			return dst, errors.New("invalid LEEF attribute")
+		}
+		dst = append(dst, attr[:pos], attr[pos+1:])
+	}
+	return dst, nil
+}
+
+// parses the delimiter of a LEEF 2.0 header, either a single character or its hex code (ie `x09` or `0x09`)
+func parseLEEFDelimiter(s string) (string, error) {
+	switch {
+	case s == "":
+		return "", nil
+	case len(s) == 1:
+		return s, nil
+	case strings.HasPrefix(strings.ToLower(s), "x"), strings.HasPrefix(strings.ToLower(s), "0x"):
+		hex := s[strings.IndexAny(s, "xX")+1:]
+		code, err := strconv.ParseUint(hex, 16, 8)
+		if err != nil {
+			return "", errors.Wrap(err, "invalid LEEF delimiter")
+		}
+		return string([]byte{byte(code)}), nil
+	default:
+		return "", errors.Errorf("invalid LEEF delimiter %q", s)
+	}
+}
@@ -0,0 +1,75 @@
+package preprocessors
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLEEF(t *testing.T) {
+	assert := require.New(t)
+	pp, err := LEEFConfig{
+		FieldNames: map[string]string{"src": "sourceAddress"},
+	}.BuildPreprocessor()
+	assert.NoError(err)
+	out, err := pp.PreProcessLog("LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|src=10.50.1.1\tdst=2.10.20.20\tsev=5")
+	assert.NoError(err)
+	assert.JSONEq(`{
+		"version": "1.0",
+		"vendor": "Microsoft",
+		"product": "MSExchange",
+		"productVersion": "4.0 SP1",
+		"eventId": "15345",
+		"sourceAddress": "10.50.1.1",
+		"dst": "2.10.20.20",
+		"sev": "5"
+	}`, out)
+
+	out, err = pp.PreProcessLog("LEEF:2.0|Lancope|StealthWatch|1.0|41|^|src=10.0.1.8^dst=10.0.0.5^sev=5")
+	assert.NoError(err)
+	assert.JSONEq(`{
+		"version": "2.0",
+		"vendor": "Lancope",
+		"product": "StealthWatch",
+		"productVersion": "1.0",
+		"eventId": "41",
+		"sourceAddress": "10.0.1.8",
+		"dst": "10.0.0.5",
+		"sev": "5"
+	}`, out)
+
+	out, err = pp.PreProcessLog("LEEF:2.0|Lancope|StealthWatch|1.0|41|x7C|src=10.0.1.8|sev=5")
+	assert.NoError(err)
+	assert.JSONEq(`{
+		"version": "2.0",
+		"vendor": "Lancope",
+		"product": "StealthWatch",
+		"productVersion": "1.0",
+		"eventId": "41",
+		"sourceAddress": "10.0.1.8",
+		"sev": "5"
+	}`, out)
+
+	_, err = pp.PreProcessLog("LEEF:2.0|Lancope|StealthWatch|1.0|41|xZZ|src=10.0.1.8")
+	assert.Error(err)
+	_, err = pp.PreProcessLog("LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|foo")
+	assert.Error(err)
+}
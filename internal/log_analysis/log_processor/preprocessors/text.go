@@ -68,6 +68,8 @@ func (p *matchTextPreprocessor) PreProcessLog(log string) (string, error) {
 			}
 		}
 	}
+	// Reset the stream so that the buffer only holds the JSON for this log line
+	p.stream.Reset(nil)
 	writeFieldsJSON(p.stream, matches)
 	// Reuse buffer
 	p.matches = matches
@@ -174,6 +176,18 @@ func trimSpacesInPlace(fields []string) {
 	}
 }
 
+// renames keys in a key/value pairs slice in-place
+func renameFieldsInPlace(fields []string, names map[string]string) {
+	if len(names) == 0 {
+		return
+	}
+	for i := 0; 0 <= i && i < len(fields); i += 2 {
+		if name, ok := names[fields[i]]; ok {
+			fields[i] = name
+		}
+	}
+}
+
 // compiles templates removing space surrounding tags
 func compileFieldTemplates(src map[string]string) map[string]*fasttemplate.Template {
 	if len(src) == 0 {
@@ -0,0 +1,285 @@
+package preprocessors
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"encoding/xml"
+	"io"
+	"regexp"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+)
+
+// XMLConfig converts an XML element to a JSON object.
+// Attributes and child elements become fields of the object, elements holding only text become strings.
+// Repeated child elements become arrays.
+// nolint:lll
+type XMLConfig struct {
+	Element         string   `json:"element,omitempty" yaml:"element,omitempty" description:"Name of the element holding each event (defaults to the root element)"`
+	WrapperElement  string   `json:"wrapperElement,omitempty" yaml:"wrapperElement,omitempty" description:"Name of the element wrapping all events, lines with only its opening or closing tag are skipped"`
+	AttributePrefix string   `json:"attributePrefix,omitempty" yaml:"attributePrefix,omitempty" description:"Prefix for the field names of attributes"`
+	TextField       string   `json:"textField,omitempty" yaml:"textField,omitempty" description:"Field name for the text of elements that also have attributes or child elements (defaults to 'value')"`
+	ArrayElements   []string `json:"arrayElements,omitempty" yaml:"arrayElements,omitempty" description:"Elements that are always converted to arrays"`
+	SkipPrefix      string   `json:"skipPrefix,omitempty" yaml:"skipPrefix,omitempty" description:"Skip comment lines by prefix"`
+	EmptyValues     []string `json:"emptyValues,omitempty" yaml:"emptyValues,omitempty" description:"Placeholder value for empty or missing data"`
+	TrimSpace       bool     `json:"trimSpace,omitempty" yaml:"trimSpace,omitempty" description:"Trim space surrounding values"`
+}
+
+const defaultXMLTextField = "value"
+
+func (config XMLConfig) BuildPreprocessor() (Interface, error) {
+	p := xmlPreprocessor{
+		element:         config.Element,
+		attributePrefix: config.AttributePrefix,
+		textField:       config.TextField,
+		skipPrefix:      config.SkipPrefix,
+		emptyValues:     config.EmptyValues,
+		trimSpace:       config.TrimSpace,
+		stream:          buildJSONStream(),
+		skipRx:          buildXMLSkipRx(config.WrapperElement),
+	}
+	if p.textField == "" {
+		p.textField = defaultXMLTextField
+	}
+	if len(config.ArrayElements) > 0 {
+		p.arrayElements = make(map[string]bool, len(config.ArrayElements))
+		for _, name := range config.ArrayElements {
+			p.arrayElements[name] = true
+		}
+	}
+	return &p, nil
+}
+
+// buildXMLSkipRx matches lines holding no event, ie the XML declaration and the tags of the wrapper element
+func buildXMLSkipRx(wrapper string) *regexp.Regexp {
+	if wrapper == "" {
+		return regexp.MustCompile(`^\s*<\?xml\s[^>]*\?>\s*$`)
+	}
+	name := regexp.QuoteMeta(wrapper)
+	return regexp.MustCompile(`^\s*(<\?xml\s[^>]*\?>)?\s*(<` + name + `(\s[^>]*)?>|</` + name + `\s*>)?\s*$`)
+}
+
+type xmlPreprocessor struct {
+	element         string
+	attributePrefix string
+	textField       string
+	arrayElements   map[string]bool
+	skipPrefix      string
+	emptyValues     []string
+	trimSpace       bool
+	stream          *jsoniter.Stream
+	skipRx          *regexp.Regexp
+}
+
+type xmlNode struct {
+	name     string
+	attrs    []xml.Attr
+	text     []byte
+	children []*xmlNode
+}
+
+func (p *xmlPreprocessor) PreProcessLog(log string) (string, error) {
+	if prefix := p.skipPrefix; prefix != "" && strings.HasPrefix(log, prefix) {
+		return "", nil
+	}
+	if strings.TrimSpace(log) != "" && p.skipRx.MatchString(log) {
+		return "", nil
+	}
+	d := xml.NewDecoder(strings.NewReader(log))
+	// The log line is already decoded so we ignore the encoding in the XML declaration
+	d.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+	start, err := p.findElement(d)
+	if err != nil {
+		return "", err
+	}
+	node, err := readXMLNode(d, start)
+	if err != nil {
+		return "", err
+	}
+	p.stream.Reset(nil)
+	if node.isLeaf() {
+		// We always write an object for the event
+		p.stream.WriteObjectStart()
+		if text := p.textValue(node.text); !p.isEmptyValue(text) {
+			p.stream.WriteObjectField(p.textField)
+			p.stream.WriteString(text)
+		}
+		p.stream.WriteObjectEnd()
+	} else {
+		p.writeNode(node)
+	}
+	return string(p.stream.Buffer()), nil
+}
+
+// finds the start of the event element
+func (p *xmlPreprocessor) findElement(d *xml.Decoder) (*xml.StartElement, error) {
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			if p.element != "" {
+				return nil, errors.Errorf("no <%s> element in log line", p.element)
+			}
+			return nil, errors.New("no XML element in log line")
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read XML")
+		}
+		if start, ok := tok.(xml.StartElement); ok && (p.element == "" || start.Name.Local == p.element) {
+			return &start, nil
+		}
+	}
+}
+
+func readXMLNode(d *xml.Decoder, start *xml.StartElement) (*xmlNode, error) {
+	node := xmlNode{
+		name: start.Name.Local,
+	}
+	for _, attr := range start.Attr {
+		// Skip namespace declarations
+		if attr.Name.Local == "xmlns" || attr.Name.Space == "xmlns" {
+			continue
+		}
+		node.attrs = append(node.attrs, attr)
+	}
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return nil, errors.Errorf("unexpected end of XML element %q", node.name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			child, err := readXMLNode(d, &t)
+			if err != nil {
+				return nil, err
+			}
+			node.children = append(node.children, child)
+		case xml.CharData:
+			node.text = append(node.text, t...)
+		case xml.EndElement:
+			return &node, nil
+		}
+	}
+}
+
+func (n *xmlNode) isLeaf() bool {
+	return len(n.attrs) == 0 && len(n.children) == 0
+}
+
+func (p *xmlPreprocessor) textValue(text []byte) string {
+	if p.trimSpace {
+		return strings.TrimSpace(string(text))
+	}
+	return string(text)
+}
+
+func (p *xmlPreprocessor) isEmptyValue(value string) bool {
+	return value == "" || contains(p.emptyValues, value)
+}
+
+// checks if a node has no values to write
+func (p *xmlPreprocessor) isEmpty(n *xmlNode) bool {
+	if n.isLeaf() {
+		return p.isEmptyValue(p.textValue(n.text))
+	}
+	for i := range n.attrs {
+		if !p.isEmptyValue(n.attrs[i].Value) {
+			return false
+		}
+	}
+	for _, child := range n.children {
+		if !p.isEmpty(child) {
+			return false
+		}
+	}
+	return strings.TrimSpace(string(n.text)) == ""
+}
+
+func (p *xmlPreprocessor) writeNode(n *xmlNode) {
+	stream := p.stream
+	if n.isLeaf() {
+		stream.WriteString(p.textValue(n.text))
+		return
+	}
+	more := false
+	writeField := func(name string) {
+		if more {
+			stream.WriteMore()
+		}
+		stream.WriteObjectField(name)
+		more = true
+	}
+	stream.WriteObjectStart()
+	for i := range n.attrs {
+		attr := &n.attrs[i]
+		if p.isEmptyValue(attr.Value) {
+			continue
+		}
+		writeField(p.attributePrefix + attr.Name.Local)
+		stream.WriteString(attr.Value)
+	}
+	for i, child := range n.children {
+		if p.isEmpty(child) || p.hasSibling(n.children[:i], child.name) {
+			continue
+		}
+		var group []*xmlNode
+		for _, sibling := range n.children[i:] {
+			if sibling.name == child.name && !p.isEmpty(sibling) {
+				group = append(group, sibling)
+			}
+		}
+		writeField(child.name)
+		if len(group) == 1 && !p.arrayElements[child.name] {
+			p.writeNode(child)
+			continue
+		}
+		stream.WriteArrayStart()
+		for j, sibling := range group {
+			if j > 0 {
+				stream.WriteMore()
+			}
+			p.writeNode(sibling)
+		}
+		stream.WriteArrayEnd()
+	}
+	// Mixed content is rare, we only keep the text if it is not whitespace between child elements
+	if strings.TrimSpace(string(n.text)) != "" {
+		if text := p.textValue(n.text); !p.isEmptyValue(text) {
+			writeField(p.textField)
+			stream.WriteString(text)
+		}
+	}
+	stream.WriteObjectEnd()
+}
+
+// checks if a non-empty node with the same name was already written
+func (p *xmlPreprocessor) hasSibling(nodes []*xmlNode, name string) bool {
+	for _, n := range nodes {
+		if n.name == name && !p.isEmpty(n) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package preprocessors
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestXML(t *testing.T) {
+	assert := require.New(t)
+	pp, err := XMLConfig{
+		Element:         "item",
+		WrapperElement:  "items",
+		AttributePrefix: "@",
+		ArrayElements:   []string{"tag"},
+		EmptyValues:     []string{"-"},
+		TrimSpace:       true,
+	}.BuildPreprocessor()
+	assert.NoError(err)
+
+	out, err := pp.PreProcessLog(`<?xml version="1.0" encoding="UTF-16"?><items xmlns="urn:test"><item id="1">
+	<name> foo </name>
+	<price currency="EUR">10.5</price>
+	<tag>a</tag>
+	<user>-</user>
+	<empty/>
+	<part><sku>x</sku></part>
+	<part><sku>y</sku></part>
+</item></items>`)
+	assert.NoError(err)
+	assert.JSONEq(`{
+		"@id": "1",
+		"name": "foo",
+		"price": {"@currency": "EUR", "value": "10.5"},
+		"tag": ["a"],
+		"part": [{"sku": "x"}, {"sku": "y"}]
+	}`, out)
+
+	// The XML declaration and the tags of the wrapper element are skipped
+	for _, line := range []string{`<?xml version="1.0"?>`, `<items>`, `<items xmlns="urn:test">`, `</items>`} {
+		out, err = pp.PreProcessLog(line)
+		assert.NoError(err, line)
+		assert.Equal("", out, line)
+	}
+	// Other lines without the event element are errors
+	for _, line := range []string{``, `{"item":1}`, `<other/>`, `<?xml version="1.0"?><other>`} {
+		_, err = pp.PreProcessLog(line)
+		assert.Error(err, line)
+	}
+
+	_, err = pp.PreProcessLog(`<item><name>foo</name>`)
+	assert.Error(err)
+	_, err = pp.PreProcessLog(`<item><name>foo</item>`)
+	assert.Error(err)
+
+	pp, err = XMLConfig{}.BuildPreprocessor()
+	assert.NoError(err)
+	out, err = pp.PreProcessLog(`<msg>hello</msg>`)
+	assert.NoError(err)
+	assert.JSONEq(`{"value":"hello"}`, out)
+	_, err = pp.PreProcessLog(`</msg>`)
+	assert.Error(err)
+	out, err = pp.PreProcessLog(`<?xml version="1.0"?>`)
+	assert.NoError(err)
+	assert.Equal("", out)
+}
@@ -22,24 +22,38 @@ import (
 	"fmt"
 	"io"
 	"strconv"
+	"strings"
 
 	jsoniter "github.com/json-iterator/go"
 	"github.com/pkg/errors"
 )
 
+// JSONPathWildcard is a JSON array path element that matches all values of an object or an array.
+const JSONPathWildcard = "*"
+
 // NewJSONArrayStream creates a new JSON Array stream.
 // r is the underlying io.Reader
 // size is the read buffer size for the jsoniter.Iterator
 // path is a path to the array value to extract elements from (empty means the input JSON is an array itself)
+// If the path contains JSONPathWildcard elements, the elements of all matching arrays are extracted in document order
+// and values that do not match the rest of the path are skipped.
 func NewJSONArrayStream(r io.Reader, size int, path ...string) *JSONArrayStream {
 	if size <= 0 {
 		size = DefaultBufferSize
 	} else if size < MinBufferSize {
 		size = MinBufferSize
 	}
+	wildcard := false
+	for _, p := range path {
+		if p == JSONPathWildcard {
+			wildcard = true
+			break
+		}
+	}
 	return &JSONArrayStream{
-		iter: jsoniter.Parse(jsoniter.ConfigDefault, r, size),
-		seek: path,
+		iter:     jsoniter.Parse(jsoniter.ConfigDefault, r, size),
+		seek:     path,
+		wildcard: wildcard,
 	}
 }
 
@@ -48,11 +62,22 @@ func NewJSONArrayStream(r io.Reader, size int, path ...string) *JSONArrayStream
 type JSONArrayStream struct {
 	iter       *jsoniter.Iterator
 	seek       []string
+	wildcard   bool
+	started    bool
+	inArray    bool
+	frames     []jsonPathFrame
 	err        error
 	entry      []byte
 	numEntries int64
 }
 
+// jsonPathFrame is an object or array value along the path that is still being iterated.
+type jsonPathFrame struct {
+	depth    int
+	array    bool
+	wildcard bool
+}
+
 // Err implements the Stream interface
 func (s *JSONArrayStream) Err() error {
 	if errors.Is(s.err, io.EOF) {
@@ -67,24 +92,43 @@ func (s *JSONArrayStream) Next() []byte {
 		return nil
 	}
 	// On first entry we seek to the key that holds the Array (if a path is set)
-	if s.numEntries == 0 && len(s.seek) > 0 {
-		// Advances the iterator to the value at path
-		if !seekJSONPath(s.iter, s.seek) {
-			// seekJSONPath reports any seek errors without a stack on the iterator
-			s.err = errors.WithStack(s.iter.Error)
-			return nil
+	if !s.started {
+		s.started = true
+		if !s.wildcard {
+			// Advances the iterator to the value at path
+			if !seekJSONPath(s.iter, s.seek) {
+				// seekJSONPath reports any seek errors without a stack on the iterator
+				s.err = errors.WithStack(s.iter.Error)
+				return nil
+			}
+			s.inArray = true
+		} else {
+			s.walk(0)
 		}
 	}
-	// Check that the array has elements
-	if !s.iter.ReadArray() {
-		// If the value was not an array the iterator reports an error
+	for {
 		if err := s.iter.Error; err != nil {
 			s.err = errors.WithStack(err)
 			return nil
 		}
-		// The value was an empty array or null
-		s.err = io.EOF
-		return nil
+		if s.inArray {
+			// Check that the array has more elements
+			if s.iter.ReadArray() {
+				break
+			}
+			// If the value was not an array the iterator reports an error
+			if err := s.iter.Error; err != nil {
+				s.err = errors.WithStack(err)
+				return nil
+			}
+			// The array was consumed, was empty or was null
+			s.inArray = false
+		}
+		if len(s.frames) == 0 {
+			s.err = io.EOF
+			return nil
+		}
+		s.advance()
 	}
 
 	// Initialize the entry buffer
@@ -104,6 +148,153 @@ func (s *JSONArrayStream) Next() []byte {
 	return s.entry
 }
 
+// advance moves to the next value of the innermost object or array along the path.
+// Wildcard frames continue matching the path on each of their values, other frames skip their remaining values.
+func (s *JSONArrayStream) advance() {
+	frame := s.frames[len(s.frames)-1]
+	switch {
+	case frame.wildcard && frame.array:
+		if s.iter.ReadArray() {
+			s.walk(frame.depth + 1)
+			return
+		}
+	case frame.wildcard:
+		if key := s.iter.ReadObject(); key != "" {
+			s.walk(frame.depth + 1)
+			return
+		}
+	case frame.array:
+		for s.iter.ReadArray() {
+			s.iter.Skip()
+		}
+	default:
+		for key := s.iter.ReadObject(); key != ""; key = s.iter.ReadObject() {
+			s.iter.Skip()
+		}
+	}
+	s.frames = s.frames[:len(s.frames)-1]
+}
+
+// walk matches the value at the current iterator position against the path starting at depth.
+// It stops at the first array matching the whole path or skips the value if it does not match.
+func (s *JSONArrayStream) walk(depth int) {
+	iter := s.iter
+	for ; depth < len(s.seek); depth++ {
+		seek := s.seek[depth]
+		switch iter.WhatIsNext() {
+		case jsoniter.ObjectValue:
+			if seek == JSONPathWildcard {
+				if key := iter.ReadObject(); key == "" {
+					return
+				}
+				s.frames = append(s.frames, jsonPathFrame{depth: depth, wildcard: true})
+				continue
+			}
+			if !seekJSONKey(iter, seek) {
+				return
+			}
+			s.frames = append(s.frames, jsonPathFrame{depth: depth})
+		case jsoniter.ArrayValue:
+			if seek == JSONPathWildcard {
+				if !iter.ReadArray() {
+					return
+				}
+				s.frames = append(s.frames, jsonPathFrame{depth: depth, array: true, wildcard: true})
+				continue
+			}
+			n, err := strconv.ParseInt(seek, 10, 64)
+			if err != nil || n < 0 {
+				iter.Skip()
+				return
+			}
+			if !seekJSONIndex(iter, n) {
+				return
+			}
+			s.frames = append(s.frames, jsonPathFrame{depth: depth, array: true})
+		default:
+			iter.Skip()
+			return
+		}
+	}
+	if iter.WhatIsNext() == jsoniter.ArrayValue {
+		s.inArray = true
+		return
+	}
+	iter.Skip()
+}
+
+// seekJSONKey advances a JSON iterator to the value of key in an object.
+// If the key is not found the whole object is consumed.
+func seekJSONKey(iter *jsoniter.Iterator, key string) bool {
+	for k := iter.ReadObject(); k != "" && iter.Error == nil; k = iter.ReadObject() {
+		if k == key {
+			return true
+		}
+		iter.Skip()
+	}
+	return false
+}
+
+// seekJSONIndex advances a JSON iterator to the nth element of an array.
+// If the array has fewer elements the whole array is consumed.
+func seekJSONIndex(iter *jsoniter.Iterator, n int64) bool {
+	for i := int64(0); iter.ReadArray() && iter.Error == nil; i++ {
+		if i == n {
+			return true
+		}
+		iter.Skip()
+	}
+	return false
+}
+
+// ParseJSONArrayPath parses the path to the array of log events in a JSON document.
+// Path elements are object keys separated by '.' and array indexes in brackets, i.e. `data.results[0].events`.
+// A '*' key or index matches all values of an object or an array, i.e. `data.*.events` or `results[*].events`.
+// An optional '$' prefix denotes the document root, so `$` alone means the document is an array itself.
+func ParseJSONArrayPath(path string) ([]string, error) {
+	rest := strings.TrimPrefix(strings.TrimSpace(path), "$")
+	if rest != "" && rest[0] == '.' {
+		rest = rest[1:]
+		if rest == "" {
+			return nil, errors.Errorf("invalid JSON array path %q: empty key", path)
+		}
+	}
+	var elements []string
+	for rest != "" {
+		var element string
+		if rest[0] == '[' {
+			end := strings.IndexByte(rest, ']')
+			if end == -1 {
+				return nil, errors.Errorf("invalid JSON array path %q: unterminated index", path)
+			}
+			element, rest = rest[1:end], rest[end+1:]
+			if element != JSONPathWildcard {
+				if n, err := strconv.ParseUint(element, 10, 32); err != nil || strconv.FormatUint(n, 10) != element {
+					return nil, errors.Errorf("invalid JSON array path %q: invalid index %q", path, element)
+				}
+			}
+		} else {
+			end := strings.IndexAny(rest, ".[")
+			if end == -1 {
+				end = len(rest)
+			}
+			element, rest = rest[:end], rest[end:]
+			if element == "" {
+				return nil, errors.Errorf("invalid JSON array path %q: empty key", path)
+			}
+		}
+		elements = append(elements, element)
+		if rest == "" || rest[0] == '[' {
+			continue
+		}
+		if rest[0] != '.' || len(rest) == 1 {
+			return nil, errors.Errorf("invalid JSON array path %q: unexpected %q", path, rest)
+		}
+		rest = rest[1:]
+	}
+	return elements, nil
+}
+
 // seekJSONPath advances a JSON iterator to the value at path.
 // Array indexes should be passed as strings
 func seekJSONPath(iter *jsoniter.Iterator, path []string) bool {
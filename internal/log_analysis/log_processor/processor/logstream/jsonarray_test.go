@@ -63,6 +63,121 @@ func TestNewJSONArrayStreamUnexpectedEOF(t *testing.T) {
 	assert.Contains(t, s.Err().Error(), `ReadArray: expect [ or , or ] or n, but found`)
 }
 
+func TestNewJSONArrayStreamTopLevelArray(t *testing.T) {
+	input := `[` + eventA + "," + eventB + "]"
+	r := strings.NewReader(input)
+	s := NewJSONArrayStream(r, 512)
+	assert.Equal(t, eventA, string(s.Next()))
+	assert.Equal(t, eventB, string(s.Next()))
+	assert.Nil(t, s.Next())
+	assert.NoError(t, s.Err())
+}
+
+func TestNewJSONArrayStreamWildcard(t *testing.T) {
+	type testCase struct {
+		Name    string
+		Input   string
+		Path    []string
+		Expect  []string
+		WantErr bool
+	}
+	for _, tc := range []testCase{
+		{
+			"Wildcard object",
+			`{"a":{"events":[1,2]},"b":{"other":[3]},"c":{"events":[]},"d":{"events":[4]},"e":"foo"}`,
+			[]string{"*", "events"},
+			[]string{"1", "2", "4"},
+			false,
+		},
+		{
+			"Wildcard array",
+			`{"results":[{"events":[1]},{"events":null},{"foo":"bar","events":[2,3],"baz":{}}],"after":[5]}`,
+			[]string{"results", "*", "events"},
+			[]string{"1", "2", "3"},
+			false,
+		},
+		{
+			"Nested wildcards",
+			`[[{"x":[1]},{"x":[2]}],[],{"x":[3]},[{"x":[4]}]]`,
+			[]string{"*", "*", "x"},
+			[]string{"1", "2", "4"},
+			false,
+		},
+		{
+			"Wildcard with index",
+			`{"pages":[{"items":[[1,2],[3]]},{"items":[[4]]}]}`,
+			[]string{"pages", "*", "items", "0"},
+			[]string{"1", "2", "4"},
+			false,
+		},
+		{
+			"No match",
+			`{"a":{"b":1}}`,
+			[]string{"*", "events"},
+			nil,
+			false,
+		},
+		{
+			"Invalid JSON",
+			`{"a":{"events":[1,}`,
+			[]string{"*", "events"},
+			[]string{"1"},
+			true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			s := NewJSONArrayStream(strings.NewReader(tc.Input), 512, tc.Path...)
+			var actual []string
+			for entry := s.Next(); entry != nil; entry = s.Next() {
+				actual = append(actual, string(entry))
+			}
+			assert.Equal(t, tc.Expect, actual)
+			if tc.WantErr {
+				assert.Error(t, s.Err())
+				return
+			}
+			assert.NoError(t, s.Err())
+		})
+	}
+}
+
+func TestParseJSONArrayPath(t *testing.T) {
+	type testCase struct {
+		Path    string
+		Expect  []string
+		WantErr bool
+	}
+	for _, tc := range []testCase{
+		{"", nil, false},
+		{"$", nil, false},
+		{"Records", []string{"Records"}, false},
+		{"$.Records", []string{"Records"}, false},
+		{"data.results[0].events", []string{"data", "results", "0", "events"}, false},
+		{"data.*.events", []string{"data", "*", "events"}, false},
+		{"$[*].events", []string{"*", "events"}, false},
+		{"results[*][2]", []string{"results", "*", "2"}, false},
+		{"$.", nil, true},
+		{"a..b", nil, true},
+		{"a.", nil, true},
+		{"a[", nil, true},
+		{"a[-1]", nil, true},
+		{"a[foo]", nil, true},
+		{"a[0]b", nil, true},
+	} {
+		tc := tc
+		t.Run(tc.Path, func(t *testing.T) {
+			actual, err := ParseJSONArrayPath(tc.Path)
+			if tc.WantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Expect, actual)
+		})
+	}
+}
+
 func TestSeekJSONPath(t *testing.T) {
 	type testCase struct {
 		Name    string
@@ -0,0 +1,267 @@
+package logstream
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"io"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultMultiLineMaxLines is the maximum number of lines in a multi-line event if no limit is configured.
+	DefaultMultiLineMaxLines = 1000
+	// DefaultMultiLineMaxBytes is the maximum size of a multi-line event if no limit is configured.
+	DefaultMultiLineMaxBytes = 1024 * 1024
+)
+
+// MultiLineConfig configures how lines are reassembled into multi-line events.
+//
+// A line continues the current event if it matches ContinuationPattern or if it does not match StartPattern.
+// At least one of the two patterns must be set.
+type MultiLineConfig struct {
+	// StartPattern matches the first line of an event
+	StartPattern string `json:"startPattern,omitempty" yaml:"startPattern,omitempty"`
+	// ContinuationPattern matches lines that belong to the current event
+	ContinuationPattern string `json:"continuationPattern,omitempty" yaml:"continuationPattern,omitempty"`
+	// MaxLines is the maximum number of lines in an event. Excess lines start a new event.
+	MaxLines int `json:"maxLines,omitempty" yaml:"maxLines,omitempty"`
+	// MaxBytes is the maximum size of an event. Excess lines start a new event.
+	MaxBytes int `json:"maxBytes,omitempty" yaml:"maxBytes,omitempty"`
+	// FlushTimeout is the time to wait for the next line before emitting a pending event (ie '5s').
+	// This only applies to unbounded streams (ie HTTP and SQS sources).
+	// Events in S3 objects are only flushed once the next event starts or at the end of the object.
+	FlushTimeout string `json:"flushTimeout,omitempty" yaml:"flushTimeout,omitempty"`
+}
+
+// MultiLineLogType is implemented by log types whose events span multiple lines.
+type MultiLineLogType interface {
+	MultiLineConfig() *MultiLineConfig
+}
+
+// Validate checks that the configuration is valid
+func (c *MultiLineConfig) Validate() error {
+	_, err := c.compile()
+	return err
+}
+
+type multiLineMatcher struct {
+	start        *regexp.Regexp
+	continuation *regexp.Regexp
+	maxLines     int
+	maxBytes     int
+	flushTimeout time.Duration
+}
+
+func (c *MultiLineConfig) compile() (*multiLineMatcher, error) {
+	if c.StartPattern == "" && c.ContinuationPattern == "" {
+		return nil, errors.New("multi-line configuration requires a start or a continuation pattern")
+	}
+	m := multiLineMatcher{
+		maxLines: c.MaxLines,
+		maxBytes: c.MaxBytes,
+	}
+	if m.maxLines <= 0 {
+		m.maxLines = DefaultMultiLineMaxLines
+	}
+	if m.maxBytes <= 0 {
+		m.maxBytes = DefaultMultiLineMaxBytes
+	}
+	var err error
+	if c.StartPattern != "" {
+		if m.start, err = regexp.Compile(c.StartPattern); err != nil {
+			return nil, errors.Wrap(err, "invalid multi-line start pattern")
+		}
+	}
+	if c.ContinuationPattern != "" {
+		if m.continuation, err = regexp.Compile(c.ContinuationPattern); err != nil {
+			return nil, errors.Wrap(err, "invalid multi-line continuation pattern")
+		}
+	}
+	if c.FlushTimeout != "" {
+		if m.flushTimeout, err = time.ParseDuration(c.FlushTimeout); err != nil {
+			return nil, errors.Wrap(err, "invalid multi-line flush timeout")
+		}
+	}
+	return &m, nil
+}
+
+func (m *multiLineMatcher) isContinuation(line []byte) bool {
+	if m.continuation != nil && m.continuation.Match(line) {
+		return true
+	}
+	return m.start != nil && !m.start.Match(line)
+}
+
+// MultiLineStream is a log entry stream that joins consecutive lines into a single event.
+// Lines of an event are joined with '\n'.
+type MultiLineStream struct {
+	lines   *LineStream
+	matcher *multiLineMatcher
+	// flushTimeout is only set for unbounded streams
+	flushTimeout time.Duration
+	// pending is the event being assembled
+	pending      []byte
+	pendingLines int
+	// entry is the last event returned by Next
+	entry []byte
+	done  bool
+	// only used when a flush timeout is set
+	ch       chan []byte
+	stop     chan struct{}
+	stopOnce sync.Once
+	mu       sync.Mutex
+	err      error
+}
+
+// NewMultiLineStream creates a new multi-line stream for a bounded reader (ie an S3 object).
+// The flush timeout of the configuration is ignored, pending events are flushed at the end of the reader.
+// r is the underlying io.Reader
+// size is the read buffer size for the underlying LineStream
+func NewMultiLineStream(r io.Reader, size int, config MultiLineConfig) (*MultiLineStream, error) {
+	matcher, err := config.compile()
+	if err != nil {
+		return nil, err
+	}
+	return &MultiLineStream{
+		lines:   NewLineStream(r, size),
+		matcher: matcher,
+		stop:    make(chan struct{}),
+	}, nil
+}
+
+// NewUnboundedMultiLineStream creates a new multi-line stream for a reader that can block
+// indefinitely while waiting for more lines (ie HTTP and SQS sources).
+// Pending events are flushed if no line is read within the flush timeout of the configuration.
+func NewUnboundedMultiLineStream(r io.Reader, size int, config MultiLineConfig) (*MultiLineStream, error) {
+	s, err := NewMultiLineStream(r, size, config)
+	if err != nil {
+		return nil, err
+	}
+	s.flushTimeout = s.matcher.flushTimeout
+	return s, nil
+}
+
+// Err implements the Stream interface
+func (s *MultiLineStream) Err() error {
+	if s.flushTimeout <= 0 {
+		return s.lines.Err()
+	}
+	// With a flush timeout lines are read in a separate goroutine
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Close stops reading lines in the background if a flush timeout is set.
+// It waits for a pending read to finish but does not close the underlying reader.
+func (s *MultiLineStream) Close() error {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
+	if s.ch != nil {
+		for range s.ch {
+		}
+	}
+	return nil
+}
+
+// Next implements the Stream interface
+func (s *MultiLineStream) Next() []byte {
+	for !s.done {
+		line, timeout := s.nextLine()
+		switch {
+		case timeout:
+			if s.pendingLines > 0 {
+				return s.flush(nil)
+			}
+		case line == nil:
+			s.done = true
+		case s.pendingLines == 0:
+			s.flush(line)
+		case s.matcher.isContinuation(line) && s.fits(line):
+			s.pending = append(s.pending, '\n')
+			s.pending = append(s.pending, line...)
+			s.pendingLines++
+		default:
+			// The line starts a new event
+			return s.flush(line)
+		}
+	}
+	if s.pendingLines > 0 {
+		return s.flush(nil)
+	}
+	return nil
+}
+
+func (s *MultiLineStream) fits(line []byte) bool {
+	return s.pendingLines < s.matcher.maxLines && len(s.pending)+1+len(line) <= s.matcher.maxBytes
+}
+
+// flush returns the pending event and starts a new one with line
+func (s *MultiLineStream) flush(line []byte) []byte {
+	s.entry, s.pending = s.pending, s.entry
+	s.pending = append(s.pending[:0], line...)
+	s.pendingLines = 0
+	if line != nil {
+		s.pendingLines = 1
+	}
+	return s.entry
+}
+
+// nextLine reads the next line waiting at most for the flush timeout if one is set.
+// The returned line is valid until the next call.
+func (s *MultiLineStream) nextLine() (line []byte, timeout bool) {
+	if s.flushTimeout <= 0 {
+		return s.lines.Next(), false
+	}
+	if s.ch == nil {
+		s.ch = make(chan []byte, 1)
+		go func() {
+			defer close(s.ch)
+			for line := s.lines.Next(); line != nil; line = s.lines.Next() {
+				// Lines are only valid until the next call so we need to copy them.
+				// Empty lines must be non-nil to not be mistaken for the end of the stream.
+				cp := make([]byte, len(line))
+				copy(cp, line)
+				select {
+				case s.ch <- cp:
+				case <-s.stop:
+					return
+				}
+			}
+			s.mu.Lock()
+			s.err = s.lines.Err()
+			s.mu.Unlock()
+		}()
+	}
+	timer := time.NewTimer(s.flushTimeout)
+	defer timer.Stop()
+	select {
+	case line := <-s.ch:
+		return line, false
+	case <-s.stop:
+		return nil, false
+	case <-timer.C:
+		return nil, true
+	}
+}
@@ -0,0 +1,182 @@
+package logstream
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiLineStream(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Config MultiLineConfig
+		Input  string
+		Expect []string
+	}
+	javaTrace := `2020-10-01 12:00:00 ERROR Something failed
+java.lang.NullPointerException: boom
+	at com.example.Foo.bar(Foo.java:12)
+	at com.example.Foo.main(Foo.java:5)
+2020-10-01 12:00:01 INFO Recovered`
+	for _, tc := range []testCase{
+		{
+			Name:   "Start pattern",
+			Config: MultiLineConfig{StartPattern: `^\d{4}-\d{2}-\d{2}`},
+			Input:  javaTrace,
+			Expect: []string{
+				strings.Join(strings.Split(javaTrace, "\n")[:4], "\n"),
+				"2020-10-01 12:00:01 INFO Recovered",
+			},
+		},
+		{
+			Name:   "Continuation pattern",
+			Config: MultiLineConfig{ContinuationPattern: `^\s+at `},
+			Input:  javaTrace,
+			Expect: []string{
+				"2020-10-01 12:00:00 ERROR Something failed",
+				strings.Join(strings.Split(javaTrace, "\n")[1:4], "\n"),
+				"2020-10-01 12:00:01 INFO Recovered",
+			},
+		},
+		{
+			Name:   "Pretty printed JSON",
+			Config: MultiLineConfig{StartPattern: `^\{`},
+			Input:  "{\n  \"a\": 1\n}\n{\n  \"b\": 2\n}\n",
+			Expect: []string{
+				"{\n  \"a\": 1\n}",
+				"{\n  \"b\": 2\n}",
+			},
+		},
+		{
+			Name:   "Max lines",
+			Config: MultiLineConfig{StartPattern: `^start`, MaxLines: 2},
+			Input:  "start\na\nb\nc\nstart",
+			Expect: []string{"start\na", "b\nc", "start"},
+		},
+		{
+			Name:   "Max bytes",
+			Config: MultiLineConfig{StartPattern: `^start`, MaxBytes: 8},
+			Input:  "start\na\nbbbbbbbbbbbb\nc",
+			Expect: []string{"start\na", "bbbbbbbbbbbb", "c"},
+		},
+		{
+			Name:   "Empty input",
+			Config: MultiLineConfig{StartPattern: `^start`},
+			Input:  "",
+		},
+	} {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			s, err := NewMultiLineStream(strings.NewReader(tc.Input), 16, tc.Config)
+			require.NoError(t, err)
+			var result []string
+			for entry := s.Next(); entry != nil; entry = s.Next() {
+				result = append(result, string(entry))
+			}
+			require.NoError(t, s.Err())
+			require.Equal(t, tc.Expect, result)
+		})
+	}
+}
+
+func TestMultiLineStreamFlushTimeout(t *testing.T) {
+	r, w := io.Pipe()
+	s, err := NewUnboundedMultiLineStream(r, 16, MultiLineConfig{
+		StartPattern: `^start`,
+		FlushTimeout: "10ms",
+	})
+	require.NoError(t, err)
+	go func() {
+		_, _ = w.Write([]byte("start\na\n"))
+		time.Sleep(100 * time.Millisecond)
+		_, _ = w.Write([]byte("b\n"))
+		_ = w.Close()
+	}()
+	require.Equal(t, "start\na", string(s.Next()))
+	require.Equal(t, "b", string(s.Next()))
+	require.Nil(t, s.Next())
+	require.NoError(t, s.Err())
+}
+
+func TestMultiLineStreamIgnoresFlushTimeout(t *testing.T) {
+	r, w := io.Pipe()
+	s, err := NewMultiLineStream(r, 16, MultiLineConfig{
+		StartPattern: `^start`,
+		FlushTimeout: "10ms",
+	})
+	require.NoError(t, err)
+	go func() {
+		_, _ = w.Write([]byte("start\na\n"))
+		// Slow reads of a bounded stream must not split events
+		time.Sleep(100 * time.Millisecond)
+		_, _ = w.Write([]byte("b\n"))
+		_ = w.Close()
+	}()
+	require.Equal(t, "start\na\nb", string(s.Next()))
+	require.Nil(t, s.Next())
+	require.NoError(t, s.Err())
+}
+
+func TestMultiLineStreamClose(t *testing.T) {
+	r, w := io.Pipe()
+	s, err := NewUnboundedMultiLineStream(r, 16, MultiLineConfig{
+		StartPattern: `^start`,
+		FlushTimeout: "10ms",
+	})
+	require.NoError(t, err)
+	go func() {
+		_, _ = w.Write([]byte("start\na\nstart\nb\nstart\nc\n"))
+	}()
+	require.Equal(t, "start\na", string(s.Next()))
+	require.NoError(t, s.Close())
+	// The reading goroutine closes the channel once it stops
+	_, ok := <-s.ch
+	require.False(t, ok)
+	require.NoError(t, s.Err())
+	_ = r.Close()
+}
+
+func TestMultiLineStreamFlushTimeoutErr(t *testing.T) {
+	r, w := io.Pipe()
+	s, err := NewUnboundedMultiLineStream(r, 16, MultiLineConfig{
+		StartPattern: `^start`,
+		FlushTimeout: "10ms",
+	})
+	require.NoError(t, err)
+	go func() {
+		_, _ = w.Write([]byte("start\na\n"))
+		_ = w.CloseWithError(errors.New("failed"))
+	}()
+	require.Equal(t, "start\na", string(s.Next()))
+	require.Nil(t, s.Next())
+	require.Error(t, s.Err())
+}
+
+func TestMultiLineConfig_Validate(t *testing.T) {
+	require.Error(t, (&MultiLineConfig{}).Validate())
+	require.Error(t, (&MultiLineConfig{StartPattern: `(`}).Validate())
+	require.Error(t, (&MultiLineConfig{StartPattern: `^a`, FlushTimeout: "soon"}).Validate())
+	require.NoError(t, (&MultiLineConfig{ContinuationPattern: `^\s`, FlushTimeout: "5s"}).Validate())
+}
@@ -30,9 +30,12 @@ import (
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/classification"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/destinations"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/filters"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/lookuptables"
 	logmetrics "github.com/panther-labs/panther/internal/log_analysis/log_processor/metrics"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/quarantine"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/sources"
 	"github.com/panther-labs/panther/pkg/metrics"
 	"github.com/panther-labs/panther/pkg/oplog"
@@ -152,23 +155,43 @@ type Processor struct {
 	input      *common.DataStream
 	classifier classification.ClassifierAPI
 	operation  *oplog.Operation
+	// lookups attaches lookup table rows to events (nil if lookup tables are not used)
+	lookups *lookuptables.Joiner
+	// eventFilter returns the event filters of a source (nil if the source has no filters)
+	eventFilter func(sourceID string) *filters.Filter
+	// number of events dropped by filters per source and log type
+	dropped map[droppedEvents]uint64
+}
+
+type droppedEvents struct {
+	sourceID string
+	logType  string
 }
 
 type Factory func(r *common.DataStream) (*Processor, error)
 
-func NewFactory(resolver pantherlog.ParserResolver) Factory {
+func NewFactory(resolver pantherlog.ParserResolver, lookups *lookuptables.Joiner) Factory {
 	return func(input *common.DataStream) (*Processor, error) {
 		switch src := input.Source; src.IntegrationType {
-		case models.IntegrationTypeSqs:
+		case models.IntegrationTypeSqs, models.IntegrationTypeHTTP:
+			// Both SQS and HTTP sources deliver data wrapped in forwarder messages.
+			// The stream holds messages of all sources so the filters of each message source are used.
+			classifier := &sources.SQSClassifier{
+				Resolver:   resolver,
+				LoadSource: sources.LoadSource,
+			}
 			return &Processor{
-				operation: common.OpLogManager.Start(operationName),
-				input:     input,
-				classifier: &sources.SQSClassifier{
-					Resolver:   resolver,
-					LoadSource: sources.LoadSource,
-				},
+				operation:   common.OpLogManager.Start(operationName),
+				input:       input,
+				classifier:  classifier,
+				lookups:     lookups,
+				eventFilter: classifier.EventFilter,
 			}, nil
 		case models.IntegrationTypeAWS3:
+			filter, err := filters.Build(src.EventFilters)
+			if err != nil {
+				return nil, err
+			}
 			var availableLogTypes []string
 			// S3 sources has multiple prefix<>logtypes mappings specified.
 			if m, matched := src.S3PrefixLogTypes.LongestPrefixMatch(input.S3ObjectKey); matched {
@@ -179,19 +202,27 @@ func NewFactory(resolver pantherlog.ParserResolver) Factory {
 				return nil, err
 			}
 			return &Processor{
-				operation:  common.OpLogManager.Start(operationName),
-				input:      input,
-				classifier: c,
+				operation:   common.OpLogManager.Start(operationName),
+				input:       input,
+				classifier:  c,
+				lookups:     lookups,
+				eventFilter: sourceFilter(filter),
 			}, nil
 		case models.IntegrationTypeAWSScan:
+			filter, err := filters.Build(src.EventFilters)
+			if err != nil {
+				return nil, err
+			}
 			c, err := sources.BuildClassifier(src.RequiredLogTypes(), src, resolver)
 			if err != nil {
 				return nil, err
 			}
 			return &Processor{
-				operation:  common.OpLogManager.Start(operationName),
-				input:      input,
-				classifier: c,
+				operation:   common.OpLogManager.Start(operationName),
+				input:       input,
+				classifier:  c,
+				lookups:     lookups,
+				eventFilter: sourceFilter(filter),
 			}, nil
 
 		default:
@@ -200,6 +231,13 @@ func NewFactory(resolver pantherlog.ParserResolver) Factory {
 	}
 }
 
+// sourceFilter is used for streams where all events belong to the same source
+func sourceFilter(filter *filters.Filter) func(string) *filters.Filter {
+	return func(_ string) *filters.Filter {
+		return filter
+	}
+}
+
 // processStream reads the data from an S3 the dataStream, parses it and writes events to the output channel
 func (p *Processor) run(ctx context.Context, outputChan chan<- *parsers.Result) (err error) {
 	// Instrument downloads. The time will include time to parse the file.
@@ -212,9 +250,12 @@ func (p *Processor) run(ctx context.Context, outputChan chan<- *parsers.Result)
 			// s3 dim info
 			zap.String("bucket", p.input.S3Bucket),
 			zap.String("key", p.input.S3ObjectKey),
+			zap.String("archiveFile", p.input.ArchiveFile),
 			zap.String("sourceID", p.input.Source.IntegrationID),
 		)
 	}()
+	// Reload lookup tables if they are stale before processing the stream
+	p.lookups.Refresh(ctx)
 	stream := p.input.Stream
 	for {
 		line := stream.Next()
@@ -234,19 +275,40 @@ func (p *Processor) processLogLine(ctx context.Context, line string, outputChan
 	// A classifier returns an error when it cannot classify a non-empty log line
 	if err != nil {
 		// make easy to troubleshoot but do not add log line (even partial) to avoid leaking data into CW
+		lineNum := p.classifier.Stats().LogLineCount
 		p.operation.LogWarn(errors.New("failed to classify log line"),
-			zap.Uint64("lineNum", p.classifier.Stats().LogLineCount),
+			zap.Uint64("lineNum", lineNum),
 			zap.String("sourceId", p.input.Source.IntegrationID),
 			zap.String("sourceLabel", p.input.Source.IntegrationLabel),
 			zap.String("s3Bucket", p.input.S3Bucket),
 			zap.String("s3ObjectKey", p.input.S3ObjectKey),
+			zap.String("archiveFile", p.input.ArchiveFile),
 		)
+		// Keep the log line in the quarantine table so that it can be re-driven once the log type is fixed
+		if e, ok := err.(*classification.ClassificationError); ok {
+			select {
+			case outputChan <- quarantine.NewResult(p.input, lineNum, e):
+			case <-ctx.Done():
+			}
+		}
 		return
 	}
 	if result == nil {
 		return
 	}
 	for _, event := range result.Events {
+		sourceID := event.PantherSourceID
+		if sourceID == "" {
+			sourceID = p.input.Source.IntegrationID
+		}
+		if !p.eventFilter(sourceID).Keep(event) {
+			if p.dropped == nil {
+				p.dropped = make(map[droppedEvents]uint64)
+			}
+			p.dropped[droppedEvents{sourceID: sourceID, logType: event.PantherLogType}]++
+			continue
+		}
+		p.lookups.Join(event.Event)
 		select {
 		case outputChan <- event:
 		case <-ctx.Done():
@@ -262,4 +324,10 @@ func (p *Processor) logStats(err error) {
 		logmetrics.BytesProcessed.With(metrics.LogTypeDimension, stats.LogType).Add(float64(stats.BytesProcessedCount))
 		logmetrics.EventsProcessed.With(metrics.LogTypeDimension, stats.LogType).Add(float64(stats.EventCount))
 	}
+	for dropped, n := range p.dropped {
+		logmetrics.EventsDropped.With(
+			metrics.LogTypeDimension, dropped.logType,
+			metrics.SourceIDDimension, dropped.sourceID,
+		).Add(float64(n))
+	}
 }
@@ -26,6 +26,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -42,6 +43,7 @@ import (
 	logmetrics "github.com/panther-labs/panther/internal/log_analysis/log_processor/metrics"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/quarantine"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/processor/logstream"
@@ -100,7 +102,7 @@ func TestProcess(t *testing.T) {
 	metrics.eventsProcessed.On("Add", float64(testLogLines)).Once()
 
 	dataStream := makeDataStream()
-	f := NewFactory(testResolver)
+	f := NewFactory(testResolver, nil)
 	p, err := f(dataStream)
 	require.NoError(t, err)
 	mockClassifier := &testClassifier{}
@@ -138,12 +140,60 @@ func TestProcess(t *testing.T) {
 	assert.True(t, dataStream.Closer.(*dummyCloser).closed)
 }
 
+func TestProcessEventFilters(t *testing.T) {
+	destination := (&testDestination{}).standardMock()
+	metrics := setupMockMetrics()
+	metrics.bytesProcessed.On("With", mock.Anything).Return(metrics.bytesProcessed).Once()
+	metrics.bytesProcessed.On("Add", mock.Anything).Once()
+	metrics.eventsProcessed.On("With", mock.Anything).Return(metrics.eventsProcessed).Once()
+	metrics.eventsProcessed.On("Add", mock.Anything).Once()
+	metrics.eventsDropped.On("With", []string{"LogType", testLogType, "ID", testSourceID}).Return(metrics.eventsDropped).Once()
+	metrics.eventsDropped.On("Add", float64(testLogEvents)).Once()
+
+	dataStream := makeDataStream()
+	source := *testSource
+	source.EventFilters = []models.EventFilter{
+		{
+			LogType:     testLogType,
+			SampleRatio: aws.Float64(0),
+		},
+	}
+	dataStream.Source = &source
+	f := NewFactory(testResolver, nil)
+	p, err := f(dataStream)
+	require.NoError(t, err)
+	mockClassifier := &testClassifier{}
+	p.classifier = mockClassifier
+
+	mockStats := &classification.ClassifierStats{
+		LogLineCount: testLogLines,
+		EventCount:   testLogLines,
+	}
+	mockParserStats := map[string]*classification.ParserStats{
+		testLogType: {
+			LogLineCount: testLogLines,
+			EventCount:   testLogLines,
+			LogType:      testLogType,
+		},
+	}
+	mockClassifier.standardMocks(mockStats, mockParserStats)
+
+	newProcessorFunc := func(*common.DataStream) (*Processor, error) { return p, nil }
+	streamChan := make(chan *common.DataStream, 1)
+	streamChan <- dataStream
+	close(streamChan)
+	err = Process(context.Background(), streamChan, destination, newProcessorFunc)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), destination.nEvents)
+	metrics.eventsDropped.AssertExpectations(t)
+}
+
 func TestProcessDataStreamError(t *testing.T) {
 	logs := mockLogger()
 
 	destination := (&testDestination{}).standardMock()
 	dataStream := makeBadDataStream() // failure to read data, never hits classifier
-	f := NewFactory(testResolver)
+	f := NewFactory(testResolver, nil)
 	p, err := f(dataStream)
 	require.NoError(t, err)
 	mockClassifier := &testClassifier{}
@@ -219,7 +269,7 @@ func TestProcessDestinationError(t *testing.T) {
 	})
 
 	dataStream := makeDataStream()
-	f := NewFactory(testResolver)
+	f := NewFactory(testResolver, nil)
 	p, err := f(dataStream)
 	require.NoError(t, err)
 	mockClassifier := &testClassifier{}
@@ -259,6 +309,36 @@ func TestProcessDestinationErrorNoChannelBuffers(t *testing.T) {
 }
 
 // test we properly log parse failures so we can see which file and where in the file there was a failure
+func TestProcessLogLineQuarantine(t *testing.T) {
+	mockLogger()
+	dataStream := makeDataStream()
+	f := NewFactory(testResolver, nil)
+	p, err := f(dataStream)
+	require.NoError(t, err)
+	mockClassifier := &testClassifier{}
+	p.classifier = mockClassifier
+	mockClassifier.On("Classify", mock.Anything).Return(&classification.ClassifierResult{}, &classification.ClassificationError{
+		Log: "foo",
+		Errors: []classification.ParserError{
+			{LogType: testLogType, Err: errors.New("invalid log")},
+		},
+	})
+	mockClassifier.On("Stats", mock.Anything).Return(&classification.ClassifierStats{LogLineCount: 3})
+
+	outputChan := make(chan *parsers.Result, 1)
+	p.processLogLine(context.Background(), " foo ", outputChan)
+	require.Len(t, outputChan, 1)
+	result := <-outputChan
+	require.Equal(t, quarantine.TypeUnclassified, result.PantherLogType)
+	require.Equal(t, testSourceID, result.PantherSourceID)
+	event := result.Event.(*quarantine.Unclassified)
+	require.Equal(t, "foo", event.Line.Value)
+	require.Equal(t, testKey, event.S3Key.Value)
+	require.Equal(t, uint64(3), event.LineNumber.Value)
+	require.Len(t, event.Errors, 1)
+	require.Equal(t, "invalid log", event.Errors[0].Error.Value)
+}
+
 func TestProcessClassifyFailure(t *testing.T) {
 	logs := mockLogger()
 	metrics := setupMockMetrics()
@@ -270,7 +350,7 @@ func TestProcessClassifyFailure(t *testing.T) {
 
 	destination := (&testDestination{}).standardMock()
 	dataStream := makeDataStream()
-	f := NewFactory(testResolver)
+	f := NewFactory(testResolver, nil)
 	p, err := f(dataStream)
 	require.NoError(t, err)
 	mockClassifier := &testClassifier{}
@@ -511,6 +591,7 @@ func mockLogger() *observer.ObservedLogs {
 type mockMetrics struct {
 	bytesProcessed  *testutils.CounterMock
 	eventsProcessed *testutils.CounterMock
+	eventsDropped   *testutils.CounterMock
 }
 
 func setupMockMetrics() *mockMetrics {
@@ -520,8 +601,12 @@ func setupMockMetrics() *mockMetrics {
 	eventsProcessedMock := &testutils.CounterMock{}
 	logmetrics.EventsProcessed = eventsProcessedMock
 
+	eventsDroppedMock := &testutils.CounterMock{}
+	logmetrics.EventsDropped = eventsDroppedMock
+
 	return &mockMetrics{
 		bytesProcessed:  bytesProcessedMock,
 		eventsProcessed: eventsProcessedMock,
+		eventsDropped:   eventsDroppedMock,
 	}
 }
@@ -34,8 +34,9 @@ import (
 
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/destinations"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/lookuptables"
 	logmetrics "github.com/panther-labs/panther/internal/log_analysis/log_processor/metrics"
-	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/sources"
 	"github.com/panther-labs/panther/pkg/awsbatch/sqsbatch"
 	"github.com/panther-labs/panther/pkg/awsutils"
@@ -56,20 +57,25 @@ Fewer, bigger files makes Athena queries much faster.
 func PollEvents(
 	ctx context.Context,
 	sqsClient sqsiface.SQSAPI,
-	resolver pantherlog.ParserResolver,
+	resolver logtypes.Resolver,
+	lookups *lookuptables.Joiner,
 ) (sqsMessageCount int, err error) {
 
-	newProcessor := NewFactory(resolver)
+	newProcessor := NewFactory(logtypes.ParserResolver(resolver), lookups)
 	process := func(streams <-chan *common.DataStream, dest destinations.Destination) error {
 		return Process(ctx, streams, dest, newProcessor)
 	}
-	return pollEvents(ctx, sqsClient, process, sources.ReadSnsMessage)
+	readSnsMessage := func(ctx context.Context, message string) ([]*common.DataStream, error) {
+		return sources.ReadSnsMessage(ctx, message, resolver)
+	}
+	return pollEvents(ctx, sqsClient, resolver, process, readSnsMessage)
 }
 
 // entry point for unit testing, pass in read/process functions
 func pollEvents(
 	ctx context.Context,
 	sqsClient sqsiface.SQSAPI,
+	resolver logtypes.Resolver,
 	processFunc ProcessFunc,
 	generateDataStreamsFunc func(context.Context, string) ([]*common.DataStream, error)) (int, error) {
 
@@ -144,12 +150,13 @@ func pollEvents(
 					continue
 				}
 
-				for _, s := range dataStreams {
-					select {
-					case streamChan <- s:
-					case <-ctx.Done():
+				if err := sendDataStreams(ctx, streamChan, dataStreams); err != nil {
+					if ctx.Err() != nil {
 						return
 					}
+					// The message will reappear in the queue after the Visibility Timeout has expired
+					zap.L().Warn("Skipping event due to error reading archive", zap.Error(err))
+					continue
 				}
 
 				accumulatedMessageReceipts = append(accumulatedMessageReceipts, msg.ReceiptHandle)
@@ -160,7 +167,7 @@ func pollEvents(
 	// Use a properly configured JSON API for Athena quirks
 	jsonAPI := common.ConfigForDataLakeWriters()
 	// process streamChan until closed (blocks)
-	dest := destinations.CreateS3Destination(jsonAPI)
+	dest := destinations.CreateS3Destination(jsonAPI, resolver)
 	if err := processFunc(streamChan, dest); err != nil {
 		return 0, err
 	}
@@ -170,6 +177,50 @@ func pollEvents(
 	return len(accumulatedMessageReceipts), nil
 }
 
+// sendDataStreams sends data streams for processing.
+// Archives are expanded to a separate data stream for each file they contain.
+func sendDataStreams(ctx context.Context, streamChan chan<- *common.DataStream, dataStreams []*common.DataStream) error {
+	for i, s := range dataStreams {
+		if err := sendDataStream(ctx, streamChan, s); err != nil {
+			// Release the streams that will not be processed
+			for _, s := range dataStreams[i+1:] {
+				if s.Closer != nil {
+					_ = s.Closer.Close()
+				}
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func sendDataStream(ctx context.Context, streamChan chan<- *common.DataStream, s *common.DataStream) error {
+	if s.Archive == nil {
+		select {
+		case streamChan <- s:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if s.Closer != nil {
+		defer s.Closer.Close()
+	}
+	for {
+		file, err := s.Archive.NextDataStream(ctx)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to read archive s3://%s/%s", s.S3Bucket, s.S3ObjectKey)
+		}
+		if err := sendDataStream(ctx, streamChan, file); err != nil {
+			_ = file.Closer.Close()
+			return err
+		}
+	}
+}
+
 func highMemoryUsage() (heapUsedMB, memAvailableMB float32, isHigh bool) {
 	const (
 		threshold  = 0.8
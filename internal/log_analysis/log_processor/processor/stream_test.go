@@ -22,6 +22,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"testing"
 	"time"
 
@@ -75,7 +76,7 @@ func TestStreamEvents(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 	defer cancel()
-	count, err := pollEvents(ctx, sqsMock, noopProcessorFunc, noopGenerateDataStream)
+	count, err := pollEvents(ctx, sqsMock, nil, noopProcessorFunc, noopGenerateDataStream)
 	require.NoError(t, err)
 	assert.Equal(t, len(streamTestReceiveMessageOutput.Messages), count)
 
@@ -88,7 +89,7 @@ func TestStreamEventsProcessingTimeLimitExceeded(t *testing.T) {
 
 	ctx, cancel := context.WithDeadline(context.Background(), time.Now()) // set to current time so code exits immediately
 	defer cancel()
-	count, err := pollEvents(ctx, sqsMock, noopProcessorFunc, noopGenerateDataStream)
+	count, err := pollEvents(ctx, sqsMock, nil, noopProcessorFunc, noopGenerateDataStream)
 	require.NoError(t, err)
 	assert.Equal(t, 0, count)
 	sqsMock.AssertExpectations(t)
@@ -105,7 +106,7 @@ func TestStreamEventsReadEventError(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 	defer cancel()
-	count, err := pollEvents(ctx, sqsMock, noopProcessorFunc, failGenerateDataStream)
+	count, err := pollEvents(ctx, sqsMock, nil, noopProcessorFunc, failGenerateDataStream)
 	// Failure in the generateDataStreamsFunc should no cause the function invocation to fail
 	// but we shouldn't invoke the DeleteBatch operation neither since the messages haven't been processed
 	require.NoError(t, err)
@@ -124,7 +125,7 @@ func TestStreamEventsProcessError(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 	defer cancel()
-	count, err := pollEvents(ctx, sqsMock, failProcessorFunc, noopGenerateDataStream)
+	count, err := pollEvents(ctx, sqsMock, nil, failProcessorFunc, noopGenerateDataStream)
 	require.Error(t, err)
 	assert.Equal(t, "processError", err.Error())
 	require.Equal(t, 0, count)
@@ -142,7 +143,7 @@ func TestStreamEventsProcessErrorAndReadEventError(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 	defer cancel()
-	count, err := pollEvents(ctx, sqsMock, failProcessorFunc, failGenerateDataStream)
+	count, err := pollEvents(ctx, sqsMock, nil, failProcessorFunc, failGenerateDataStream)
 	require.Error(t, err)
 	assert.Equal(t, "processError", err.Error())
 	require.Equal(t, 0, count)
@@ -166,7 +167,7 @@ func TestStreamEventsReceiveSQSError(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 	defer cancel()
-	count, err := pollEvents(ctx, sqsMock, noopProcessorFunc, noopGenerateDataStream)
+	count, err := pollEvents(ctx, sqsMock, nil, noopProcessorFunc, noopGenerateDataStream)
 	assert.NoError(t, err)
 	require.Equal(t, len(streamTestReceiveMessageOutput.Messages), count)
 
@@ -192,7 +193,7 @@ func TestStreamEventsDeleteSQSError(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 	defer cancel()
-	count, err := pollEvents(ctx, sqsMock, noopProcessorFunc, noopGenerateDataStream)
+	count, err := pollEvents(ctx, sqsMock, nil, noopProcessorFunc, noopGenerateDataStream)
 
 	// keep sure we get error logging
 	actualLogs := logs.AllUntimed()
@@ -250,3 +251,74 @@ func noopGenerateDataStream(_ context.Context, _ string) ([]*common.DataStream,
 func failGenerateDataStream(_ context.Context, _ string) ([]*common.DataStream, error) {
 	return nil, fmt.Errorf("readEventError")
 }
+
+func TestSendDataStreamsArchive(t *testing.T) {
+	t.Parallel()
+	archive := &testArchive{files: []string{"a.log", "b.log"}}
+	archiveCloser := newTestCloser()
+	streams := []*common.DataStream{
+		{S3ObjectKey: "archive.tar", Archive: archive, Closer: archiveCloser},
+		{S3ObjectKey: "file.log"},
+	}
+	streamChan := make(chan *common.DataStream)
+	var files []string
+	go func() {
+		defer close(streamChan)
+		require.NoError(t, sendDataStreams(context.Background(), streamChan, streams))
+	}()
+	for s := range streamChan {
+		files = append(files, s.S3ObjectKey+":"+s.ArchiveFile)
+		if s.Closer != nil {
+			require.NoError(t, s.Closer.Close())
+		}
+	}
+	require.Equal(t, []string{"archive.tar:a.log", "archive.tar:b.log", "file.log:"}, files)
+	require.True(t, archiveCloser.closed())
+}
+
+type testArchive struct {
+	files []string
+	open  *testCloser
+}
+
+func (a *testArchive) NextDataStream(_ context.Context) (*common.DataStream, error) {
+	// Files must be read one at a time
+	if a.open != nil {
+		<-a.open.done
+	}
+	if len(a.files) == 0 {
+		return nil, io.EOF
+	}
+	name := a.files[0]
+	a.files = a.files[1:]
+	a.open = newTestCloser()
+	return &common.DataStream{
+		S3ObjectKey: "archive.tar",
+		ArchiveFile: name,
+		Closer:      a.open,
+	}, nil
+}
+
+type testCloser struct {
+	done chan struct{}
+}
+
+func newTestCloser() *testCloser {
+	return &testCloser{
+		done: make(chan struct{}),
+	}
+}
+
+func (c *testCloser) Close() error {
+	close(c.done)
+	return nil
+}
+
+func (c *testCloser) closed() bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+		return false
+	}
+}
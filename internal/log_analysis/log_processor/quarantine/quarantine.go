@@ -0,0 +1,101 @@
+package quarantine
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/classification"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+// TypeUnclassified is the log type of log lines that could not be classified.
+// Its events are stored in the `unclassified` table of the data lake.
+const TypeUnclassified = "Unclassified"
+
+var logTypeUnclassified = logtypes.MustBuild(logtypes.ConfigJSON{
+	Name:         TypeUnclassified,
+	Description:  `Contains log lines that could not be classified by any of the log types of their source`,
+	ReferenceURL: `https://docs.runpanther.io/log-analysis/log-processing`,
+	NewEvent: func() interface{} {
+		return &Unclassified{}
+	},
+	Validate: pantherlog.ValidateStruct,
+})
+
+// LogTypes exports the available log type entries
+func LogTypes() logtypes.Group {
+	return logTypes
+}
+
+const QuarantineGroup = "quarantine"
+
+var logTypes = logtypes.Must(QuarantineGroup, logTypeUnclassified)
+
+func Resolver() logtypes.Resolver {
+	return logtypes.LocalResolver(logTypes)
+}
+
+// nolint:lll
+type Unclassified struct {
+	S3Bucket    pantherlog.String `json:"s3Bucket" description:"The S3 bucket of the object the log line was read from."`
+	S3Key       pantherlog.String `json:"s3Key" description:"The key of the S3 object the log line was read from."`
+	ArchiveFile pantherlog.String `json:"archiveFile" description:"The name of the file in an archive S3 object the log line was read from."`
+	LineNumber  pantherlog.Uint64 `json:"lineNumber" description:"The number of the log line in the file."`
+	Line        pantherlog.String `json:"line" validate:"required" description:"The log line that could not be classified."`
+	Errors      []ParserError     `json:"errors" description:"The errors of the log type parsers that failed to parse the log line."`
+}
+
+// nolint:lll
+type ParserError struct {
+	LogType pantherlog.String `json:"logType" description:"The log type of the parser."`
+	Error   pantherlog.String `json:"error" description:"The error of the parser."`
+}
+
+var resultBuilder = pantherlog.ResultBuilder{}
+
+// NewResult builds the result to quarantine a log line of a data stream that could not be classified.
+func NewResult(input *common.DataStream, lineNumber uint64, err *classification.ClassificationError) *pantherlog.Result {
+	event := Unclassified{
+		S3Bucket:    pantherlog.String{Value: input.S3Bucket, Exists: input.S3Bucket != ""},
+		S3Key:       pantherlog.String{Value: input.S3ObjectKey, Exists: input.S3ObjectKey != ""},
+		ArchiveFile: pantherlog.String{Value: input.ArchiveFile, Exists: input.ArchiveFile != ""},
+		LineNumber:  null.FromUint64(lineNumber),
+		Line:        null.FromString(err.Log),
+		Errors:      make([]ParserError, 0, len(err.Errors)),
+	}
+	for _, e := range err.Errors {
+		event.Errors = append(event.Errors, ParserError{
+			LogType: null.FromString(e.LogType),
+			Error:   null.FromString(e.Err.Error()),
+		})
+	}
+	// ResultBuilder never fails
+	result, _ := resultBuilder.BuildResult(TypeUnclassified, &event)
+	if input.Source != nil {
+		result.PantherSourceID = input.Source.IntegrationID
+		result.PantherSourceLabel = input.Source.IntegrationLabel
+	}
+	if err.SourceID != "" {
+		result.PantherSourceID = err.SourceID
+		result.PantherSourceLabel = ""
+	}
+	return result
+}
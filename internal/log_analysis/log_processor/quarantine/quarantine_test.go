@@ -0,0 +1,125 @@
+package quarantine
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue/glueparquet"
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue/glueschema"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/classification"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/processor/logstream"
+)
+
+func TestRedrive(t *testing.T) {
+	assert := require.New(t)
+	input := &common.DataStream{
+		Source: &models.SourceIntegration{
+			SourceIntegrationMetadata: models.SourceIntegrationMetadata{
+				IntegrationID:    "source-id",
+				IntegrationLabel: "source-label",
+			},
+		},
+		S3Bucket:    "bucket",
+		S3ObjectKey: "key",
+	}
+	result := NewResult(input, 42, &classification.ClassificationError{
+		Log: `{"foo":"bar"}`,
+		Errors: []classification.ParserError{
+			{LogType: "Foo.Bar", Err: errors.New("invalid log")},
+		},
+	})
+	assert.Equal(TypeUnclassified, result.PantherLogType)
+	assert.Equal("source-id", result.PantherSourceID)
+	assert.Equal("source-label", result.PantherSourceLabel)
+
+	forwarded := NewResult(input, 1, &classification.ClassificationError{
+		Log:      "baz",
+		SourceID: "forwarded-source-id",
+	})
+	assert.Equal("forwarded-source-id", forwarded.PantherSourceID)
+	assert.Empty(forwarded.PantherSourceLabel)
+
+	var lines []string
+	for _, r := range []interface{}{result, "invalid", forwarded} {
+		data, err := jsoniter.MarshalToString(r)
+		assert.NoError(err)
+		lines = append(lines, data)
+	}
+	expect := []string{
+		`{"payload":"{\"foo\":\"bar\"}","sourceId":"source-id"}`,
+		`{"payload":"baz","sourceId":"forwarded-source-id"}`,
+	}
+	stream := NewRedriveStream(logstream.NewLineStream(strings.NewReader(strings.Join(lines, "\n")), 4096))
+	var actual []string
+	for entry := stream.Next(); entry != nil; entry = stream.Next() {
+		actual = append(actual, string(entry))
+	}
+	assert.NoError(stream.Err())
+	assert.Equal(expect, actual)
+}
+
+func TestIsRedriveObject(t *testing.T) {
+	assert := require.New(t)
+	common.Config.ProcessedDataBucket = "processed"
+	defer func() {
+		common.Config.ProcessedDataBucket = ""
+	}()
+	assert.Equal("logs/unclassified/", S3Prefix)
+	assert.True(IsRedriveObject("processed", "logs/unclassified/year=2020/month=10/day=01/hour=00/20201001T000000Z-uuid.json.gz"))
+	assert.True(IsRedriveObject("processed", "logs/unclassified/year=2020/month=10/day=01/hour=00/_20201001T000000Z-uuid.json.gz"))
+	assert.True(IsRedriveObject("processed", "logs/unclassified/year=2020/month=10/day=01/hour=00/20201001T000000Z-uuid.parquet"))
+	assert.False(IsRedriveObject("processed", "logs/unclassified/year=2020/month=10/day=01/hour=00/20201001T000000Z-uuid.json"))
+	assert.False(IsRedriveObject("processed", "logs/aws_cloudtrail/year=2020/month=10/day=01/hour=00/20201001T000000Z-uuid.json.gz"))
+	assert.False(IsRedriveObject("input", "logs/unclassified/year=2020/month=10/day=01/hour=00/20201001T000000Z-uuid.json.gz"))
+}
+
+func TestLogTypes(t *testing.T) {
+	assert := require.New(t)
+	entry := LogTypes().Find(TypeUnclassified)
+	assert.NotNil(entry)
+	assert.Equal(TypeUnclassified, entry.String())
+}
+
+func TestRedriveParquet(t *testing.T) {
+	assert := require.New(t)
+	columns, err := glueschema.InferColumns(LogTypes().Find(TypeUnclassified).Schema())
+	assert.NoError(err)
+	var buf bytes.Buffer
+	w, err := glueparquet.NewWriter(&buf, columns)
+	assert.NoError(err)
+	assert.NoError(w.WriteJSON([]byte(`{"line":"{\"foo\":\"bar\"}","p_source_id":"source-id","errors":[{"logType":"Foo.Bar"}]}`)))
+	assert.NoError(w.Close())
+
+	r, err := glueparquet.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(err)
+	defer r.Close()
+	stream := NewRedriveStream(r)
+	assert.Equal(`{"payload":"{\"foo\":\"bar\"}","sourceId":"source-id"}`, string(stream.Next()))
+	assert.Nil(stream.Next())
+	assert.NoError(stream.Err())
+}
@@ -0,0 +1,119 @@
+package quarantine
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/processor/logstream"
+	"github.com/panther-labs/panther/internal/log_analysis/message_forwarder/forwarder"
+	"github.com/panther-labs/panther/internal/log_analysis/pantherdb"
+)
+
+// RedriveSourceID is the id of the source used for data streams that re-drive quarantined log lines
+const RedriveSourceID = "quarantine-redrive"
+
+// S3Prefix is the prefix of the quarantined log lines in the processed data bucket
+var S3Prefix = awsglue.TablePrefix(pantherdb.LogProcessingDatabase, pantherdb.TableName(TypeUnclassified))
+
+// IsRedriveObject checks if an S3 object contains quarantined log lines.
+// When such an object is sent to the log processor, its log lines are classified again using their original source.
+// The objects are either gzipped JSON lines or Parquet files, depending on the data format of the data lake.
+func IsRedriveObject(bucket, key string) bool {
+	return bucket == common.Config.ProcessedDataBucket &&
+		strings.HasPrefix(key, S3Prefix) &&
+		(strings.HasSuffix(key, ".json.gz") || IsParquetObject(key))
+}
+
+// IsParquetObject checks if an S3 object with quarantined log lines is a Parquet file
+func IsParquetObject(key string) bool {
+	return strings.HasSuffix(key, ".parquet")
+}
+
+// RedriveSource returns the source for data streams that re-drive quarantined log lines.
+// The source forwards each log line to its original source for classification, the same way SQS sources do.
+func RedriveSource(bucket string) *models.SourceIntegration {
+	return &models.SourceIntegration{
+		SourceIntegrationMetadata: models.SourceIntegrationMetadata{
+			IntegrationID:    RedriveSourceID,
+			IntegrationLabel: "Quarantine re-drive",
+			IntegrationType:  models.IntegrationTypeSqs,
+			SqsConfig: &models.SqsConfig{
+				S3Bucket: bucket,
+			},
+		},
+	}
+}
+
+// NewRedriveStream converts a stream of quarantined events to a stream of forwarded messages for their original source.
+// Events that cannot be decoded are skipped.
+func NewRedriveStream(stream logstream.Stream) logstream.Stream {
+	return &redriveStream{
+		stream: stream,
+	}
+}
+
+type redriveStream struct {
+	stream logstream.Stream
+}
+
+type redriveRecord struct {
+	SourceID string `json:"p_source_id"`
+	Line     string `json:"line"`
+}
+
+// Next implements logstream.Stream interface
+func (s *redriveStream) Next() []byte {
+	for {
+		entry := s.stream.Next()
+		if entry == nil {
+			return nil
+		}
+		record := redriveRecord{}
+		if err := jsoniter.Unmarshal(entry, &record); err != nil {
+			zap.L().Warn("failed to decode quarantined log line", zap.Error(err))
+			continue
+		}
+		if record.SourceID == "" || record.Line == "" {
+			zap.L().Warn("skipping quarantined log line without source or data")
+			continue
+		}
+		msg := forwarder.Message{
+			Payload:             record.Line,
+			SourceIntegrationID: record.SourceID,
+		}
+		data, err := jsoniter.ConfigDefault.Marshal(&msg)
+		if err != nil {
+			zap.L().Warn("failed to encode quarantined log line", zap.Error(err))
+			continue
+		}
+		return data
+	}
+}
+
+// Err implements logstream.Stream interface
+func (s *redriveStream) Err() error {
+	return s.stream.Err()
+}
@@ -46,6 +46,7 @@ import (
 	suricatalogs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/suricatalogs"
 	sysloglogs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/sysloglogs"
 	umbrellalogs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/umbrellalogs"
+	windowslogs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/windowslogs"
 	zeeklogs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/zeeklogs"
 )
 
@@ -101,6 +102,8 @@ func init() {
 
 		umbrellalogs.LogTypes(),
 
+		windowslogs.LogTypes(),
+
 		zeeklogs.LogTypes(),
 	)
 }
@@ -0,0 +1,148 @@
+package s3pipe
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"io"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression is a compression format that is transparently uncompressed while downloading.
+type Compression int
+
+const (
+	CompressionNone Compression = iota
+	CompressionGZIP
+	CompressionZSTD
+	CompressionBZIP2
+	CompressionSnappy
+)
+
+var (
+	magicGZIP = []byte{0x1f, 0x8b, 0x08}
+	magicZSTD = []byte{0x28, 0xb5, 0x2f, 0xfd}
+	// bzip2 streams start with 'BZh' followed by the block size ('1' to '9')
+	magicBZIP2 = []byte("BZh")
+	// Snappy streams in the framing format start with a stream identifier chunk
+	magicSnappy = []byte("\xff\x06\x00\x00sNaPpY")
+)
+
+// DetectCompression detects the compression format of a stream by inspecting the first bytes of the stream.
+func DetectCompression(p []byte) Compression {
+	switch {
+	case bytes.HasPrefix(p, magicGZIP):
+		return CompressionGZIP
+	case bytes.HasPrefix(p, magicZSTD):
+		return CompressionZSTD
+	case bytes.HasPrefix(p, magicBZIP2) && len(p) > len(magicBZIP2) && '1' <= p[3] && p[3] <= '9':
+		return CompressionBZIP2
+	case bytes.HasPrefix(p, magicSnappy):
+		return CompressionSnappy
+	default:
+		return CompressionNone
+	}
+}
+
+// String implements fmt.Stringer
+func (c Compression) String() string {
+	switch c {
+	case CompressionGZIP:
+		return "gzip"
+	case CompressionZSTD:
+		return "zstd"
+	case CompressionBZIP2:
+		return "bzip2"
+	case CompressionSnappy:
+		return "snappy"
+	default:
+		return "none"
+	}
+}
+
+// NewUncompressReader detects the compression format of r and returns a reader that uncompresses the data.
+// Closing the returned reader releases any resources held by the reader and closes r.
+func NewUncompressReader(r io.ReadCloser) (io.ReadCloser, error) {
+	br := bufio.NewReaderSize(r, DefaultReadBufferSize)
+	// Peek returns io.EOF for streams shorter than the longest magic, these are detected as uncompressed.
+	p, err := br.Peek(len(magicSnappy))
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	out, closeOut, err := DetectCompression(p).newReader(br)
+	if err != nil {
+		return nil, err
+	}
+	return &uncompressReader{
+		Reader:   out,
+		closeOut: closeOut,
+		closer:   r,
+	}, nil
+}
+
+type uncompressReader struct {
+	io.Reader
+	closeOut func()
+	closer   io.Closer
+}
+
+// Close implements io.Closer
+func (r *uncompressReader) Close() error {
+	r.closeOut()
+	return r.closer.Close()
+}
+
+// newReader wraps r with a reader that uncompresses the data.
+// The returned close function releases any resources held by the reader.
+func (c Compression) newReader(r io.Reader) (io.Reader, func(), error) {
+	if c == CompressionNone {
+		return r, nop, nil
+	}
+	// Wrap the reader in a buffered reader
+	// 64K should provide smooth decompression without raising the overall memory requirements.
+	r = bufio.NewReaderSize(r, DefaultReadBufferSize)
+	switch c {
+	case CompressionGZIP:
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, nil, err
+		}
+		return gz, nop, nil
+	case CompressionZSTD:
+		// A single goroutine is enough since we read the stream sequentially
+		zr, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, nil, err
+		}
+		return zr, zr.Close, nil
+	case CompressionBZIP2:
+		return bzip2.NewReader(r), nop, nil
+	case CompressionSnappy:
+		return snappy.NewReader(r), nop, nil
+	default:
+		return r, nop, nil
+	}
+}
+
+func nop() {}
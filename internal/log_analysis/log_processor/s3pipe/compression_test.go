@@ -0,0 +1,111 @@
+package s3pipe
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+)
+
+// printf 'foo bar baz' | bzip2 -9
+var bzip2FooBarBaz = []byte{
+	0x42, 0x5a, 0x68, 0x39, 0x31, 0x41, 0x59, 0x26, 0x53, 0x59, 0x68, 0x8b,
+	0x39, 0xbd, 0x00, 0x00, 0x03, 0x11, 0x80, 0x40, 0x00, 0x31, 0x00, 0x90,
+	0x10, 0x20, 0x00, 0x31, 0x0c, 0x00, 0x94, 0x1e, 0xa6, 0x8f, 0x26, 0x91,
+	0x90, 0xf1, 0x77, 0x24, 0x53, 0x85, 0x09, 0x06, 0x88, 0xb3, 0x9b, 0xd0,
+}
+
+func TestDownloadCompressed(t *testing.T) {
+	const data = "foo bar baz"
+	for _, tc := range []struct {
+		Compression Compression
+		Body        []byte
+	}{
+		{CompressionNone, []byte(data)},
+		{CompressionGZIP, gzipData(t, data)},
+		{CompressionZSTD, zstdData(t, data)},
+		{CompressionBZIP2, bzip2FooBarBaz},
+		{CompressionSnappy, snappyData(t, data)},
+	} {
+		tc := tc
+		t.Run(tc.Compression.String(), func(t *testing.T) {
+			assert := require.New(t)
+			assert.Equal(tc.Compression, DetectCompression(tc.Body))
+			s3Mock := mockS3(tc.Body, 512, false)
+			dl := Downloader{
+				S3:       s3Mock,
+				PartSize: 512,
+			}
+			rc := dl.Download(context.Background(), &s3.GetObjectInput{
+				Bucket: aws.String("bucket"),
+				Key:    aws.String("key"),
+			})
+			defer rc.Close()
+			body := bytes.Buffer{}
+			_, err := body.ReadFrom(rc)
+			assert.NoError(err)
+			assert.Equal(data, body.String())
+			s3Mock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDetectCompression(t *testing.T) {
+	assert := require.New(t)
+	assert.Equal(CompressionNone, DetectCompression(nil))
+	assert.Equal(CompressionNone, DetectCompression([]byte("BZh")))
+	assert.Equal(CompressionNone, DetectCompression([]byte("BZhello")))
+	assert.Equal(CompressionNone, DetectCompression([]byte{0x1f, 0x8b}))
+}
+
+func gzipData(t *testing.T, data string) []byte {
+	buf := bytes.Buffer{}
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write([]byte(data))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func zstdData(t *testing.T, data string) []byte {
+	buf := bytes.Buffer{}
+	w, err := zstd.NewWriter(&buf)
+	require.NoError(t, err)
+	_, err = w.Write([]byte(data))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func snappyData(t *testing.T, data string) []byte {
+	buf := bytes.Buffer{}
+	w := snappy.NewBufferedWriter(&buf)
+	_, err := w.Write([]byte(data))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
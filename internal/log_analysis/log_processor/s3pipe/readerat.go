@@ -0,0 +1,117 @@
+package s3pipe
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/pkg/errors"
+)
+
+// ReaderAt provides random access to the contents of an S3 object using ranged requests.
+// It is used to read formats that cannot be read sequentially (ie zip archives).
+// To reduce S3 API calls on sequential reads, each request reads a whole block that is kept until the next miss.
+type ReaderAt struct {
+	ctx       context.Context
+	s3        s3iface.S3API
+	input     s3.GetObjectInput
+	size      int64
+	blockSize int64
+
+	mu          sync.Mutex
+	block       []byte
+	blockOffset int64
+}
+
+var _ io.ReaderAt = (*ReaderAt)(nil)
+
+// NewReaderAt creates a new ReaderAt for an S3 object of known size.
+func NewReaderAt(ctx context.Context, s3API s3iface.S3API, input *s3.GetObjectInput, size, blockSize int64) *ReaderAt {
+	if blockSize < MinPartSize {
+		blockSize = MinPartSize
+	}
+	return &ReaderAt{
+		ctx:       ctx,
+		s3:        s3API,
+		input:     *input,
+		size:      size,
+		blockSize: blockSize,
+	}
+}
+
+// Size returns the size of the S3 object
+func (r *ReaderAt) Size() int64 {
+	return r.size
+}
+
+// ReadAt implements io.ReaderAt
+func (r *ReaderAt) ReadAt(p []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, errors.New("negative offset")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for n < len(p) {
+		pos := off + int64(n)
+		if pos >= r.size {
+			return n, io.EOF
+		}
+		if pos < r.blockOffset || pos >= r.blockOffset+int64(len(r.block)) {
+			if err := r.fetchBlock(pos); err != nil {
+				return n, err
+			}
+		}
+		n += copy(p[n:], r.block[pos-r.blockOffset:])
+	}
+	return n, nil
+}
+
+func (r *ReaderAt) fetchBlock(offset int64) error {
+	end := offset + r.blockSize
+	if end > r.size {
+		end = r.size
+	}
+	input := r.input
+	// HTTP ranges are inclusive
+	input.Range = aws.String(fmt.Sprintf("bytes=%d-%d", offset, end-1))
+	output, err := r.s3.GetObjectWithContext(r.ctx, &input)
+	if err != nil {
+		return err
+	}
+	defer output.Body.Close()
+
+	size := int(end - offset)
+	if cap(r.block) < size {
+		r.block = make([]byte, size)
+	}
+	r.block = r.block[:size]
+	if _, err := io.ReadFull(output.Body, r.block); err != nil {
+		// Invalidate the block so we do not serve partial data
+		r.block = r.block[:0]
+		return errors.Wrapf(err, "failed to read range %s", *input.Range)
+	}
+	r.blockOffset = offset
+	return nil
+}
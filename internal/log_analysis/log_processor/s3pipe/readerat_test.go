@@ -0,0 +1,80 @@
+package s3pipe
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+func TestReaderAt(t *testing.T) {
+	assert := require.New(t)
+	body := repeatedLines("foo bar baz", 2000)
+	s3Mock := &testutils.S3Mock{}
+	mockRange := func(start, end int) {
+		s3Mock.On("GetObjectWithContext", mock.Anything, &s3.GetObjectInput{
+			Bucket: aws.String("bucket"),
+			Key:    aws.String("key"),
+			Range:  aws.String(fmt.Sprintf("bytes=%d-%d", start, end-1)),
+		}, mock.Anything).Return(&s3.GetObjectOutput{
+			Body: ioutil.NopCloser(bytes.NewReader(body[start:end])),
+		}, nil).Once()
+	}
+	r := NewReaderAt(context.Background(), s3Mock, &s3.GetObjectInput{
+		Bucket: aws.String("bucket"),
+		Key:    aws.String("key"),
+	}, int64(len(body)), 1024)
+
+	// Reads within the same block are served from the cache
+	mockRange(100, 1124)
+	p := make([]byte, 100)
+	n, err := r.ReadAt(p, 100)
+	assert.NoError(err)
+	assert.Equal(100, n)
+	assert.Equal(body[100:200], p)
+	n, err = r.ReadAt(p, 1000)
+	assert.NoError(err)
+	assert.Equal(100, n)
+	assert.Equal(body[1000:1100], p)
+
+	// Reads spanning blocks fetch the next block
+	mockRange(1124, len(body))
+	n, err = r.ReadAt(p, 1100)
+	assert.NoError(err)
+	assert.Equal(100, n)
+	assert.Equal(body[1100:1200], p)
+
+	// Reads past the end of the object
+	n, err = r.ReadAt(p, int64(len(body))-10)
+	assert.Equal(io.EOF, err)
+	assert.Equal(10, n)
+	assert.Equal(body[len(body)-10:], p[:n])
+	s3Mock.AssertExpectations(t)
+}
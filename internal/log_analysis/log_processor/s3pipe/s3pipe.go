@@ -19,9 +19,7 @@ package s3pipe
  */
 
 import (
-	"bufio"
 	"bytes"
-	"compress/gzip"
 	"context"
 	"io"
 	"sync"
@@ -62,7 +60,7 @@ func (dl Downloader) Download(ctx context.Context, input *s3.GetObjectInput) io.
 		// Set both pipe and out to the piped reader.
 		pipe:  r,
 		ready: make(chan struct{}),
-		// If a gzip stream is detected on the first chunk, out will be replaced with an *gzip.Reader
+		// If a compressed stream is detected on the first chunk, out will be replaced with a reader that uncompresses it
 		out: r,
 	}
 	// defer the downloading until the first call to Read
@@ -109,12 +107,7 @@ func (dr *downloadReader) peekFirstChunk(p []byte) {
 	if p == nil {
 		return
 	}
-	r := bytes.NewReader(p)
-	gz, err := gzip.NewReader(r)
-	if err != nil {
-		return
-	}
-	dr.out = gz
+	dr.compression = DetectCompression(p)
 }
 
 func copyBuffers(w *io.PipeWriter, parts <-chan *bytes.Buffer, peek func([]byte)) {
@@ -154,8 +147,12 @@ type downloadReader struct {
 
 	// Closed after peekFirstChunk() has ran
 	ready chan struct{}
+	// compression is the compression format detected by peekFirstChunk()
+	compression Compression
 	// out is the transparently uncompressed reader to read data from
 	out io.Reader
+	// closeOut releases resources held by out
+	closeOut func()
 }
 
 var _ io.ReadCloser = (*downloadReader)(nil)
@@ -175,17 +172,14 @@ func (dr *downloadReader) startDownloading() {
 	// Kick off downloading
 	go download()
 	<-dr.ready
-	// It is important to only call gz.Reset **after** 'ready' is closed to avoid blocking copyBuffers
-	if gz, ok := dr.out.(*gzip.Reader); ok {
-		// Wrap the pipe reader in a buffered reader
-		// 64K should provide smooth decompression without raising the overall memory requirements.
-		r := bufio.NewReaderSize(dr.pipe, DefaultReadBufferSize)
-		if err := gz.Reset(r); err != nil {
-			// we already know it is gzipped, but the pipe might have been closed in between then and now
-			_ = dr.pipe.CloseWithError(err)
-			return
-		}
+	// It is important to only start reading compressed data **after** 'ready' is closed to avoid blocking copyBuffers
+	out, closeOut, err := dr.compression.newReader(dr.pipe)
+	if err != nil {
+		// we already know it is compressed, but the pipe might have been closed in between then and now
+		_ = dr.pipe.CloseWithError(err)
+		return
 	}
+	dr.out, dr.closeOut = out, closeOut
 }
 
 // Close implements io.ReadCloser
@@ -198,6 +192,11 @@ func (dr *downloadReader) Close() error {
 		// This way context errors from the Download do not override the pipe closed error on Read().
 		defer cancel()
 	}
+	var closeOut func()
+	closeOut, dr.closeOut = dr.closeOut, nil
+	if closeOut != nil {
+		defer closeOut()
+	}
 	return dr.pipe.Close()
 }
 
@@ -0,0 +1,179 @@
+package sources
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"sync"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/s3pipe"
+)
+
+// archivePeekSize is the number of bytes needed to detect an archive format
+const archivePeekSize = 512
+
+type archiveFormat int
+
+const (
+	archiveNone archiveFormat = iota
+	archiveZIP
+	archiveTAR
+)
+
+var (
+	magicZIP = []byte("PK\x03\x04")
+	// The magic of tar archives is at offset 257 for both POSIX ('ustar\x00') and GNU ('ustar ') formats
+	magicTAR       = []byte("ustar")
+	magicTAROffset = 257
+)
+
+// detectArchive detects the archive format of an (uncompressed) S3 object by inspecting its first bytes
+func detectArchive(p []byte) archiveFormat {
+	if bytes.HasPrefix(p, magicZIP) {
+		return archiveZIP
+	}
+	if len(p) >= magicTAROffset+len(magicTAR) && bytes.HasPrefix(p[magicTAROffset:], magicTAR) {
+		return archiveTAR
+	}
+	return archiveNone
+}
+
+// archiveFiles serializes access to the files of an archive.
+// Each file is read as a separate DataStream that must be closed before the next file is read.
+type archiveFiles struct {
+	// newDataStream builds the DataStream for a file in the archive
+	newDataStream func(name string, r io.ReadCloser) (*common.DataStream, error)
+	// closed is closed once the DataStream of the previous file is closed
+	closed chan struct{}
+}
+
+// next waits for the previous file to be closed before opening the next one
+func (a *archiveFiles) next(ctx context.Context, name string, open func() (io.ReadCloser, error)) (*common.DataStream, error) {
+	if err := a.wait(ctx); err != nil {
+		return nil, err
+	}
+	f, err := open()
+	if err != nil {
+		return nil, err
+	}
+	// Files in archives can be compressed themselves (e.g. a tar of gzipped log files)
+	r, err := s3pipe.NewUncompressReader(f)
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	closed := make(chan struct{})
+	stream, err := a.newDataStream(name, &archiveFile{
+		ReadCloser: r,
+		closed:     closed,
+	})
+	if err != nil {
+		_ = r.Close()
+		return nil, err
+	}
+	a.closed = closed
+	return stream, nil
+}
+
+func (a *archiveFiles) wait(ctx context.Context) error {
+	if a.closed == nil {
+		return nil
+	}
+	select {
+	case <-a.closed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// archiveFile signals that reading a file of an archive has finished when it is closed.
+type archiveFile struct {
+	io.ReadCloser
+	once   sync.Once
+	closed chan struct{}
+}
+
+// Close implements io.Closer
+func (f *archiveFile) Close() error {
+	err := f.ReadCloser.Close()
+	f.once.Do(func() {
+		close(f.closed)
+	})
+	return err
+}
+
+// tarArchive reads the regular files of a tar archive in sequence.
+type tarArchive struct {
+	archiveFiles
+	r *tar.Reader
+}
+
+var _ common.Archive = (*tarArchive)(nil)
+
+// NextDataStream implements common.Archive
+func (a *tarArchive) NextDataStream(ctx context.Context) (*common.DataStream, error) {
+	// Wait before advancing the tar reader, it would otherwise skip the data of the file being processed.
+	if err := a.wait(ctx); err != nil {
+		return nil, err
+	}
+	for {
+		hdr, err := a.r.Next()
+		if err != nil {
+			return nil, err
+		}
+		if !hdr.FileInfo().Mode().IsRegular() || hdr.Size == 0 {
+			continue
+		}
+		return a.next(ctx, hdr.Name, func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(a.r), nil
+		})
+	}
+}
+
+// zipArchive reads the regular files of a zip archive in sequence.
+type zipArchive struct {
+	archiveFiles
+	files []*zip.File
+}
+
+var _ common.Archive = (*zipArchive)(nil)
+
+// NextDataStream implements common.Archive
+func (a *zipArchive) NextDataStream(ctx context.Context) (*common.DataStream, error) {
+	// Wait before picking the next file so that it is not lost if the context is canceled.
+	if err := a.wait(ctx); err != nil {
+		return nil, err
+	}
+	for len(a.files) > 0 {
+		f := a.files[0]
+		a.files = a.files[1:]
+		if !f.Mode().IsRegular() || f.UncompressedSize64 == 0 {
+			continue
+		}
+		return a.next(ctx, f.Name, f.Open)
+	}
+	return nil, io.EOF
+}
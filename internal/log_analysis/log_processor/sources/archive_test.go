@@ -0,0 +1,166 @@
+package sources
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/processor/logstream"
+)
+
+var testArchiveFiles = []struct {
+	Name string
+	Body string
+}{
+	{"logs/a.log", "a1\na2\n"},
+	{"logs/empty.log", ""},
+	{"logs/b.log", "b1\n"},
+}
+
+func TestTarArchive(t *testing.T) {
+	assert := require.New(t)
+	buf := bytes.Buffer{}
+	w := tar.NewWriter(&buf)
+	assert.NoError(w.WriteHeader(&tar.Header{Name: "logs/", Typeflag: tar.TypeDir, Mode: 0755}))
+	for _, f := range testArchiveFiles {
+		assert.NoError(w.WriteHeader(&tar.Header{Name: f.Name, Mode: 0644, Size: int64(len(f.Body))}))
+		_, err := w.Write([]byte(f.Body))
+		assert.NoError(err)
+	}
+	assert.NoError(w.Close())
+	assert.Equal(archiveTAR, detectArchive(buf.Bytes()[:archivePeekSize]))
+
+	archive := &tarArchive{
+		archiveFiles: archiveFiles{newDataStream: newTestDataStream},
+		r:            tar.NewReader(&buf),
+	}
+	assertArchiveFiles(t, archive)
+}
+
+func TestZipArchive(t *testing.T) {
+	assert := require.New(t)
+	buf := bytes.Buffer{}
+	w := zip.NewWriter(&buf)
+	_, err := w.Create("logs/")
+	assert.NoError(err)
+	for _, f := range testArchiveFiles {
+		fw, err := w.Create(f.Name)
+		assert.NoError(err)
+		_, err = fw.Write([]byte(f.Body))
+		assert.NoError(err)
+	}
+	assert.NoError(w.Close())
+	assert.Equal(archiveZIP, detectArchive(buf.Bytes()))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(err)
+	archive := &zipArchive{
+		archiveFiles: archiveFiles{newDataStream: newTestDataStream},
+		files:        zr.File,
+	}
+	assertArchiveFiles(t, archive)
+}
+
+func TestArchiveCompressedFile(t *testing.T) {
+	assert := require.New(t)
+	gzBuf := bytes.Buffer{}
+	gz := gzip.NewWriter(&gzBuf)
+	_, err := gz.Write([]byte("c1\nc2\n"))
+	assert.NoError(err)
+	assert.NoError(gz.Close())
+
+	buf := bytes.Buffer{}
+	w := tar.NewWriter(&buf)
+	assert.NoError(w.WriteHeader(&tar.Header{Name: "logs/c.log.gz", Mode: 0644, Size: int64(gzBuf.Len())}))
+	_, err = w.Write(gzBuf.Bytes())
+	assert.NoError(err)
+	assert.NoError(w.Close())
+
+	archive := &tarArchive{
+		archiveFiles: archiveFiles{newDataStream: newTestDataStream},
+		r:            tar.NewReader(&buf),
+	}
+	c, err := archive.NextDataStream(context.Background())
+	assert.NoError(err)
+	assert.Equal("logs/c.log.gz", c.ArchiveFile)
+	assert.Equal("c1", string(c.Stream.Next()))
+	assert.Equal("c2", string(c.Stream.Next()))
+	assert.Nil(c.Stream.Next())
+	assert.NoError(c.Stream.Err())
+	assert.NoError(c.Closer.Close())
+}
+
+func TestDetectArchive(t *testing.T) {
+	assert := require.New(t)
+	assert.Equal(archiveNone, detectArchive(nil))
+	assert.Equal(archiveNone, detectArchive([]byte("foo\nbar\n")))
+	assert.Equal(archiveNone, detectArchive(bytes.Repeat([]byte("ustar"), 100)))
+}
+
+func newTestDataStream(name string, r io.ReadCloser) (*common.DataStream, error) {
+	return &common.DataStream{
+		Stream:      logstream.NewLineStream(r, 512),
+		Closer:      r,
+		ArchiveFile: name,
+	}, nil
+}
+
+func assertArchiveFiles(t *testing.T, archive common.Archive) {
+	t.Helper()
+	assert := require.New(t)
+	ctx := context.Background()
+
+	a, err := archive.NextDataStream(ctx)
+	assert.NoError(err)
+	assert.Equal("logs/a.log", a.ArchiveFile)
+	assert.Equal("a1", string(a.Stream.Next()))
+
+	// The next file should not be available until the previous one is closed
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = archive.NextDataStream(timeoutCtx)
+	assert.Equal(context.DeadlineExceeded, err)
+
+	assert.Equal("a2", string(a.Stream.Next()))
+	assert.Nil(a.Stream.Next())
+	assert.NoError(a.Stream.Err())
+	assert.NoError(a.Closer.Close())
+
+	b, err := archive.NextDataStream(ctx)
+	assert.NoError(err)
+	assert.Equal("logs/b.log", b.ArchiveFile)
+	data, err := ioutil.ReadAll(b.Closer.(io.Reader))
+	assert.NoError(err)
+	assert.Equal("b1\n", string(data))
+	assert.NoError(b.Closer.Close())
+
+	_, err = archive.NextDataStream(ctx)
+	assert.Equal(io.EOF, err)
+}
@@ -19,7 +19,11 @@ package sources
  */
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
 	"context"
+	"io"
 	"net/url"
 	"path"
 	"regexp"
@@ -36,8 +40,11 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue/glueparquet"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/processor/logstream"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/quarantine"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/s3pipe"
 	"github.com/panther-labs/panther/pkg/stringset"
 )
@@ -50,8 +57,9 @@ const (
 	cloudTrailValidationMessage = "CloudTrail validation message."
 )
 
-// ReadSnsMessage reads incoming messages containing SNS notifications and returns a slice of DataStream items
-func ReadSnsMessage(ctx context.Context, message string) (result []*common.DataStream, err error) {
+// ReadSnsMessage reads incoming messages containing SNS notifications and returns a slice of DataStream items.
+// The resolver is used to find log types that need special handling of their streams. It can be nil.
+func ReadSnsMessage(ctx context.Context, message string, resolver logtypes.Resolver) (result []*common.DataStream, err error) {
 	snsNotificationMessage := &SnsNotification{}
 	if err := jsoniter.UnmarshalFromString(message, snsNotificationMessage); err != nil {
 		return nil, err
@@ -59,7 +67,7 @@ func ReadSnsMessage(ctx context.Context, message string) (result []*common.DataS
 
 	switch snsNotificationMessage.Type {
 	case "Notification":
-		streams, err := handleNotificationMessage(ctx, snsNotificationMessage)
+		streams, err := handleNotificationMessage(ctx, snsNotificationMessage, resolver)
 		if err != nil {
 			return nil, err
 		}
@@ -101,7 +109,9 @@ func ConfirmSubscription(notification *SnsNotification) (err error) {
 	return nil
 }
 
-func handleNotificationMessage(ctx context.Context, notification *SnsNotification) (result []*common.DataStream, err error) {
+func handleNotificationMessage(ctx context.Context, notification *SnsNotification, resolver logtypes.Resolver) (
+	result []*common.DataStream, err error) {
+
 	s3Objects, err := ParseNotification(notification.Message)
 	if err != nil {
 		return nil, err
@@ -111,7 +121,7 @@ func handleNotificationMessage(ctx context.Context, notification *SnsNotificatio
 			continue
 		}
 		var dataStream *common.DataStream
-		dataStream, err = buildStream(ctx, s3Object)
+		dataStream, err = buildStream(ctx, s3Object, resolver)
 		if err != nil {
 			return
 		}
@@ -130,8 +140,11 @@ func shouldIgnoreS3Object(s3Object *S3ObjectInfo) bool {
 	return s3Object.S3ObjectSize == 0 || strings.HasSuffix(s3Object.S3ObjectKey, "/")
 }
 
-func buildStream(ctx context.Context, s3Object *S3ObjectInfo) (*common.DataStream, error) {
+func buildStream(ctx context.Context, s3Object *S3ObjectInfo, resolver logtypes.Resolver) (*common.DataStream, error) {
 	key, bucket := s3Object.S3ObjectKey, s3Object.S3Bucket
+	if quarantine.IsRedriveObject(bucket, key) {
+		return buildRedriveStream(ctx, s3Object)
+	}
 	s3Client, src, err := getS3Client(bucket, key)
 	if err != nil {
 		err = errors.Wrapf(err, "failed to get S3 client for s3://%s/%s", bucket, key)
@@ -148,34 +161,203 @@ func buildStream(ctx context.Context, s3Object *S3ObjectInfo) (*common.DataStrea
 		S3:       s3Client,
 		PartSize: calculatePartSize(s3Object.S3ObjectSize),
 	}
-	// gzip streams are transparently uncompressed
-	r := downloader.Download(ctx, &s3.GetObjectInput{
+	input := &s3.GetObjectInput{
 		Bucket: &bucket,
 		Key:    &key,
-	})
-	var stream logstream.Stream
-	switch src.IntegrationType {
-	case models.IntegrationTypeAWS3:
-		if isCloudTrailLog(key) && stringset.Contains(src.RequiredLogTypes(), "AWS.CloudTrail") {
-			zap.L().Debug("detected CloudTrail logs", zap.String("bucket", bucket), zap.String("key", key))
-			stream = logstream.NewJSONArrayStream(r, DownloadMinPartSize, "Records")
-		} else {
-			stream = logstream.NewLineStream(r, DownloadMinPartSize)
+	}
+	// gzip, zstd, bzip2 and snappy streams are transparently uncompressed
+	r := downloader.Download(ctx, input)
+	if src.IntegrationType != models.IntegrationTypeAWS3 {
+		// Set the buffer size to something big to avoid multiple fill() calls if possible
+		return &common.DataStream{
+			Stream:      logstream.NewLineStream(r, DownloadMinPartSize),
+			Closer:      r,
+			Source:      src,
+			S3Bucket:    s3Object.S3Bucket,
+			S3ObjectKey: s3Object.S3ObjectKey,
+		}, nil
+	}
+
+	// Look at the first bytes of the object to detect archives.
+	// If peeking fails we carry on as usual, the error will resurface when the stream is read.
+	br := bufio.NewReaderSize(r, archivePeekSize)
+	head, _ := br.Peek(archivePeekSize)
+	dataStream := &common.DataStream{
+		Closer:      r,
+		Source:      src,
+		S3Bucket:    s3Object.S3Bucket,
+		S3ObjectKey: s3Object.S3ObjectKey,
+	}
+	// Files in an archive are read as separate data streams so that classification and metrics are per file.
+	files := archiveFiles{
+		newDataStream: func(name string, f io.ReadCloser) (*common.DataStream, error) {
+			stream, err := newS3Stream(ctx, f, src, key, name, resolver)
+			if err != nil {
+				return nil, err
+			}
+			return &common.DataStream{
+				Stream:      stream,
+				Closer:      withStreamCloser(f, stream),
+				Source:      src,
+				S3Bucket:    s3Object.S3Bucket,
+				S3ObjectKey: s3Object.S3ObjectKey,
+				ArchiveFile: name,
+			}, nil
+		},
+	}
+	switch detectArchive(head) {
+	case archiveTAR:
+		zap.L().Debug("detected tar archive", zap.String("bucket", bucket), zap.String("key", key))
+		dataStream.Archive = &tarArchive{
+			archiveFiles: files,
+			r:            tar.NewReader(br),
+		}
+	case archiveZIP:
+		zap.L().Debug("detected zip archive", zap.String("bucket", bucket), zap.String("key", key))
+		// Zip archives need random access to read the central directory at the end of the file.
+		_ = r.Close()
+		ra := s3pipe.NewReaderAt(ctx, s3Client, input, s3Object.S3ObjectSize, DownloadMinPartSize)
+		zr, err := zip.NewReader(ra, ra.Size())
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read zip archive s3://%s/%s", bucket, key)
+		}
+		dataStream.Closer = nil
+		dataStream.Archive = &zipArchive{
+			archiveFiles: files,
+			files:        zr.File,
 		}
 	default:
-		// Set the buffer size to something big to avoid multiple fill() calls if possible
-		stream = logstream.NewLineStream(r, DownloadMinPartSize)
+		stream, err := newS3Stream(ctx, br, src, key, "", resolver)
+		if err != nil {
+			_ = r.Close()
+			return nil, err
+		}
+		dataStream.Stream = stream
+		dataStream.Closer = withStreamCloser(r, stream)
+	}
+	return dataStream, nil
+}
+
+// withStreamCloser closes the log stream before the reader if the stream needs closing (ie multi-line streams).
+func withStreamCloser(r io.ReadCloser, stream logstream.Stream) io.ReadCloser {
+	if c, ok := stream.(io.Closer); ok {
+		return &streamCloser{
+			ReadCloser: r,
+			stream:     c,
+		}
 	}
+	return r
+}
 
+type streamCloser struct {
+	io.ReadCloser
+	stream io.Closer
+}
+
+// Close implements io.Closer
+func (c *streamCloser) Close() error {
+	_ = c.stream.Close()
+	return c.ReadCloser.Close()
+}
+
+// buildRedriveStream builds the data stream for an S3 object with quarantined log lines
+func buildRedriveStream(ctx context.Context, s3Object *S3ObjectInfo) (*common.DataStream, error) {
+	input := &s3.GetObjectInput{
+		Bucket: &s3Object.S3Bucket,
+		Key:    &s3Object.S3ObjectKey,
+	}
+	if quarantine.IsParquetObject(s3Object.S3ObjectKey) {
+		// Parquet files need random access and their column chunks are read in turns,
+		// so the whole object is fetched in a single block.
+		ra := s3pipe.NewReaderAt(ctx, common.S3Client, input, s3Object.S3ObjectSize, s3Object.S3ObjectSize)
+		r, err := glueparquet.NewReader(ra, ra.Size())
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read parquet file s3://%s/%s", s3Object.S3Bucket, s3Object.S3ObjectKey)
+		}
+		return &common.DataStream{
+			Stream:      quarantine.NewRedriveStream(r),
+			Closer:      r,
+			Source:      quarantine.RedriveSource(s3Object.S3Bucket),
+			S3Bucket:    s3Object.S3Bucket,
+			S3ObjectKey: s3Object.S3ObjectKey,
+		}, nil
+	}
+	downloader := s3pipe.Downloader{
+		S3:       common.S3Client,
+		PartSize: calculatePartSize(s3Object.S3ObjectSize),
+	}
+	r := downloader.Download(ctx, input)
 	return &common.DataStream{
-		Stream:      stream,
+		Stream:      quarantine.NewRedriveStream(logstream.NewLineStream(r, DownloadMinPartSize)),
 		Closer:      r,
-		Source:      src,
+		Source:      quarantine.RedriveSource(s3Object.S3Bucket),
 		S3Bucket:    s3Object.S3Bucket,
 		S3ObjectKey: s3Object.S3ObjectKey,
 	}, nil
 }
 
+// newS3Stream builds the log stream for an S3 object or a file in an archive S3 object.
+func newS3Stream(ctx context.Context, r io.Reader, src *models.SourceIntegration, key, archiveFile string,
+	resolver logtypes.Resolver) (logstream.Stream, error) {
+
+	if path := findJSONArrayPath(src, key); path != nil {
+		zap.L().Debug("using configured JSON array path", zap.String("key", key), zap.Strings("path", path))
+		return logstream.NewJSONArrayStream(r, DownloadMinPartSize, path...), nil
+	}
+	if archiveFile == "" && isCloudTrailLog(key) && stringset.Contains(src.RequiredLogTypes(), "AWS.CloudTrail") {
+		zap.L().Debug("detected CloudTrail logs", zap.String("key", key))
+		return logstream.NewJSONArrayStream(r, DownloadMinPartSize, "Records"), nil
+	}
+	if config := findMultiLineConfig(ctx, resolver, src, key); config != nil {
+		zap.L().Debug("detected multi-line logs", zap.String("key", key), zap.String("archiveFile", archiveFile))
+		stream, err := logstream.NewMultiLineStream(r, DownloadMinPartSize, *config)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to build multi-line stream for %s", key)
+		}
+		return stream, nil
+	}
+	return logstream.NewLineStream(r, DownloadMinPartSize), nil
+}
+
+// findJSONArrayPath finds the path to the array of log events configured for the prefix of an S3 object.
+// It returns nil if the objects under the prefix are not JSON documents with an array of log events.
+func findJSONArrayPath(src *models.SourceIntegration, key string) []string {
+	m, matched := src.S3PrefixLogTypes.LongestPrefixMatch(key)
+	if !matched {
+		return nil
+	}
+	path, err := m.JSONArrayPathElements()
+	if err != nil {
+		// The path is validated by the source API, this should only happen for manually edited sources
+		zap.L().Warn("invalid JSON array path", zap.String("prefix", m.S3Prefix), zap.Error(err))
+		return nil
+	}
+	return path
+}
+
+// findMultiLineConfig finds the multi-line configuration of the log types mapped to the prefix of an S3 object.
+// Only the first log type that opts into multi-line events is taken into account.
+func findMultiLineConfig(ctx context.Context, resolver logtypes.Resolver, src *models.SourceIntegration, key string) *logstream.MultiLineConfig {
+	if resolver == nil {
+		return nil
+	}
+	m, matched := src.S3PrefixLogTypes.LongestPrefixMatch(key)
+	if !matched {
+		return nil
+	}
+	for _, logType := range m.LogTypes {
+		entry, err := resolver.Resolve(ctx, logType)
+		if err != nil {
+			zap.L().Warn("failed to resolve log type", zap.String("logType", logType), zap.Error(err))
+			continue
+		}
+		if entry, ok := entry.(logstream.MultiLineLogType); ok {
+			return entry.MultiLineConfig()
+		}
+	}
+	return nil
+}
+
 func calculatePartSize(size int64) int64 {
 	// we want this as large as possible to minimize S3 api calls, not more than DownloadMaxPartSize to control memory use
 	partSize := size / 2 // use 1/2 to allow processing first half while reading second half on small files
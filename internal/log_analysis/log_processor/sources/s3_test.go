@@ -162,7 +162,7 @@ func TestHandleUnsupportedFileType(t *testing.T) {
 	}
 	s3Mock.On("GetObjectWithContext", mock.Anything, mock.Anything, mock.Anything).Return(getObjectOutput, nil)
 
-	dataStreams, err := ReadSnsMessage(context.TODO(), marshaledNotification)
+	dataStreams, err := ReadSnsMessage(context.TODO(), marshaledNotification, nil)
 	// Method shouldn't return error
 	require.NoError(t, err)
 	// Method should not return data stream
@@ -188,7 +188,7 @@ func TestHandleS3Folder(t *testing.T) {
 	marshaledNotification, err := jsoniter.MarshalToString(notification)
 	require.NoError(t, err)
 
-	dataStreams, err := ReadSnsMessage(context.TODO(), marshaledNotification)
+	dataStreams, err := ReadSnsMessage(context.TODO(), marshaledNotification, nil)
 	// Method shouldn't return error
 	require.NoError(t, err)
 	// Method should not return data stream
@@ -212,7 +212,7 @@ func TestHandleEmptyObject(t *testing.T) {
 	marshaledNotification, err := jsoniter.MarshalToString(notification)
 	require.NoError(t, err)
 
-	dataStreams, err := ReadSnsMessage(context.TODO(), marshaledNotification)
+	dataStreams, err := ReadSnsMessage(context.TODO(), marshaledNotification, nil)
 	// Method shouldn't return error
 	require.NoError(t, err)
 	// Method should not return data stream
@@ -250,7 +250,7 @@ func TestHandleUnregisteredSource(t *testing.T) {
 	// Getting the list of available sources
 	lambdaMock.On("Invoke", mock.Anything).Return(lambdaOutput, nil).Once()
 
-	dataStreams, err := ReadSnsMessage(context.TODO(), marshaledNotification)
+	dataStreams, err := ReadSnsMessage(context.TODO(), marshaledNotification, nil)
 	// Method shouldn't return error
 	require.NoError(t, err)
 	// Method should not return data stream
@@ -264,3 +264,29 @@ func TestIsCloudTrailLog(t *testing.T) {
 	require.True(t, isCloudTrailLog("AWSLogs/342363560528/CloudTrail/eu-west-1/2020/12/17/342363560528_CloudTrail_eu-west-1_20201217T1535Z_ZUnDvAcFwNysSIsp.json.gz"))
 	require.True(t, isCloudTrailLog("AWSLogs/342363560528/CloudTrail/eu-west-1/2020/12/17/342363560528_CloudTrail_us-west-2-lax-1a_20201217T1535Z_ZUnDvAcFwNysSIsp.json.gz"))
 }
+
+func TestNewS3StreamJSONArrayPath(t *testing.T) {
+	src := &models.SourceIntegration{
+		SourceIntegrationMetadata: models.SourceIntegrationMetadata{
+			IntegrationType: models.IntegrationTypeAWS3,
+			S3PrefixLogTypes: models.S3PrefixLogtypes{
+				{S3Prefix: "okta/", LogTypes: []string{"Okta.SystemLog"}, JSONArrayPath: "pages[*].events"},
+				{S3Prefix: "", LogTypes: []string{"AWS.VPCFlow"}},
+			},
+		},
+	}
+	input := `{"pages":[{"events":[{"a":1},{"b":2}]},{"events":[{"c":3}]}]}`
+	stream, err := newS3Stream(context.Background(), bytes.NewReader([]byte(input)), src, "okta/export.json", "", nil)
+	require.NoError(t, err)
+	var entries []string
+	for entry := stream.Next(); entry != nil; entry = stream.Next() {
+		entries = append(entries, string(entry))
+	}
+	require.NoError(t, stream.Err())
+	require.Equal(t, []string{`{"a":1}`, `{"b":2}`, `{"c":3}`}, entries)
+
+	// Objects outside the prefix are read line by line
+	stream, err = newS3Stream(context.Background(), bytes.NewReader([]byte(input)), src, "vpc/flow.log", "", nil)
+	require.NoError(t, err)
+	require.Equal(t, input, string(stream.Next()))
+}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/panther-labs/panther/api/lambda/source/models"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/classification"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/filters"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
 	"github.com/panther-labs/panther/internal/log_analysis/message_forwarder/forwarder"
 )
@@ -34,6 +35,8 @@ type SQSClassifier struct {
 	LoadSource  func(id string) (*models.SourceIntegration, error)
 	stats       classification.ClassifierStats
 	classifiers map[string]classification.ClassifierAPI
+	// event filters of each source that has any
+	eventFilters map[string]*filters.Filter
 }
 
 var _ classification.ClassifierAPI = (*SQSClassifier)(nil)
@@ -62,7 +65,11 @@ func (c *SQSClassifier) Classify(log string) (*classification.ClassifierResult,
 		}
 		c.classifiers[msg.SourceIntegrationID] = cls
 	}
-	return cls.Classify(msg.Payload)
+	result, err := cls.Classify(msg.Payload)
+	if e, ok := err.(*classification.ClassificationError); ok {
+		e.SourceID = msg.SourceIntegrationID
+	}
+	return result, err
 }
 
 func (c *SQSClassifier) buildSourceClassifier(id string) (classification.ClassifierAPI, error) {
@@ -70,7 +77,24 @@ func (c *SQSClassifier) buildSourceClassifier(id string) (classification.Classif
 	if err != nil {
 		return nil, err
 	}
-	return BuildClassifier(src.SqsConfig.LogTypes, src, c.Resolver)
+	// Messages of all SQS and HTTP sources share a stream, so filters are resolved for each source
+	filter, err := filters.Build(src.EventFilters)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "invalid event filters for source %s", id)
+	}
+	if filter != nil {
+		if c.eventFilters == nil {
+			c.eventFilters = map[string]*filters.Filter{}
+		}
+		c.eventFilters[id] = filter
+	}
+	return BuildClassifier(src.RequiredLogTypes(), src, c.Resolver)
+}
+
+// EventFilter returns the event filters of a source that messages were classified for.
+// It returns nil if the source has no filters.
+func (c *SQSClassifier) EventFilter(sourceID string) *filters.Filter {
+	return c.eventFilters[sourceID]
 }
 
 func (c *SQSClassifier) Stats() *classification.ClassifierStats {
@@ -20,8 +20,10 @@ package sources
 
 import (
 	"errors"
+	"strings"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/stretchr/testify/require"
 
 	"github.com/panther-labs/panther/api/lambda/source/models"
@@ -31,10 +33,11 @@ import (
 
 func TestSQSClassifier(t *testing.T) {
 	const (
-		testLogType     = "testLog"
-		testBucket      = "testBucket"
-		testSourceID    = "testSource"
-		testSourceLabel = "testSourceLabel"
+		testLogType      = "testLog"
+		testBucket       = "testBucket"
+		testSourceID     = "testSource"
+		testSourceLabel  = "testSourceLabel"
+		filteredSourceID = "filteredSource"
 	)
 	testSource := &models.SourceIntegration{
 		SourceIntegrationMetadata: models.SourceIntegrationMetadata{
@@ -48,6 +51,20 @@ func TestSQSClassifier(t *testing.T) {
 		},
 	}
 
+	// A source sharing the stream that drops all events
+	filteredSource := &models.SourceIntegration{
+		SourceIntegrationMetadata: models.SourceIntegrationMetadata{
+			IntegrationID:   filteredSourceID,
+			IntegrationType: models.IntegrationTypeHTTP,
+			HTTPConfig: &models.HTTPConfig{
+				LogTypes: []string{testLogType},
+			},
+			EventFilters: []models.EventFilter{
+				{LogType: testLogType, SampleRatio: aws.Float64(0)},
+			},
+		},
+	}
+
 	testGroup := logtypes.Must("test", logtypes.ConfigJSON{
 		Name:         testLogType,
 		Description:  "Test log type",
@@ -62,8 +79,11 @@ func TestSQSClassifier(t *testing.T) {
 	c := SQSClassifier{
 		Resolver: logtypes.ParserResolver(logtypes.LocalResolver(testGroup)),
 		LoadSource: func(id string) (*models.SourceIntegration, error) {
-			if id == testSourceID {
+			switch id {
+			case testSourceID:
 				return testSource, nil
+			case filteredSourceID:
+				return filteredSource, nil
 			}
 			return nil, errors.New("source not found")
 		},
@@ -71,4 +91,14 @@ func TestSQSClassifier(t *testing.T) {
 	result, err := c.Classify(logData)
 	require.NoError(t, err)
 	require.NotNil(t, result)
+	require.Len(t, result.Events, 1)
+	require.True(t, c.EventFilter(result.Events[0].PantherSourceID).Keep(result.Events[0]))
+
+	// Filters are resolved for the source of each message
+	result, err = c.Classify(strings.Replace(logData, `"sourceId":"testSource"`, `"sourceId":"filteredSource"`, 1))
+	require.NoError(t, err)
+	require.Len(t, result.Events, 1)
+	require.Equal(t, filteredSourceID, result.Events[0].PantherSourceID)
+	require.False(t, c.EventFilter(result.Events[0].PantherSourceID).Keep(result.Events[0]))
+	require.Nil(t, c.EventFilter(testSourceID))
 }
@@ -0,0 +1,94 @@
+package transform
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+// WrapEntry returns an entry that applies a plan to all events parsed by the parsers of entry.
+// If plan is nil the entry is returned as is.
+//
+// Legacy log types collect their p_any_* fields while parsing, so the plan is also registered
+// with parsers.SetEventTransform to be applied to their events before the indicators are collected.
+func WrapEntry(entry logtypes.Entry, plan *Plan) logtypes.Entry {
+	if plan == nil {
+		parsers.SetEventTransform(entry.String(), nil)
+		return entry
+	}
+	parsers.SetEventTransform(entry.String(), plan.Apply)
+	return &transformEntry{
+		Entry: entry,
+		plan:  plan,
+	}
+}
+
+type transformEntry struct {
+	logtypes.Entry
+	plan *Plan
+}
+
+func (e *transformEntry) NewParser(params interface{}) (pantherlog.LogParser, error) {
+	p, err := e.Entry.NewParser(params)
+	if err != nil {
+		return nil, err
+	}
+	return &parser{
+		parser: p,
+		plan:   e.plan,
+	}, nil
+}
+
+func (e *transformEntry) BuildEntry() (logtypes.Entry, error) {
+	return e, nil
+}
+
+func (e *transformEntry) Find(logType string) logtypes.Entry {
+	if e.String() == logType {
+		return e
+	}
+	return nil
+}
+
+func (e *transformEntry) Entries() []logtypes.Entry {
+	return []logtypes.Entry{e}
+}
+
+type parser struct {
+	parser pantherlog.LogParser
+	plan   *Plan
+}
+
+// ParseLog implements pantherlog.LogParser
+func (p *parser) ParseLog(log string) ([]*pantherlog.Result, error) {
+	results, err := p.parser.ParseLog(log)
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		// Legacy events were transformed by the parser before their indicators were collected.
+		// Indicators of other events are collected from the transformed event when the result is encoded.
+		if !result.EventIncludesPantherFields {
+			p.plan.Apply(result.Event)
+		}
+	}
+	return results, nil
+}
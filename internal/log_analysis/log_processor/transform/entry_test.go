@@ -0,0 +1,67 @@
+package transform
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/awslogs"
+)
+
+func TestWrapEntryLegacyAnyFields(t *testing.T) {
+	plan := mustBuild(t, `
+version: 0
+fields:
+- name: srcAddr
+  type: string
+  transform:
+  - hash: {}
+- name: pktSrcAddr
+  type: string
+  transform:
+  - redact:
+      pattern: '\d+$'
+`)
+	entry := WrapEntry(logtypes.MustFind(awslogs.LogTypes(), awslogs.TypeVPCFlow), plan)
+	defer parsers.SetEventTransform(awslogs.TypeVPCFlow, nil)
+	p, err := entry.NewParser(nil)
+	require.NoError(t, err)
+	// nolint:lll
+	header := "version account-id interface-id srcaddr dstaddr srcport dstport protocol packets bytes start end action log-status vpc-id subnet-id instance-id tcp-flags type pkt-srcaddr pkt-dstaddr"
+	results, err := p.ParseLog(header)
+	require.NoError(t, err)
+	require.Empty(t, results)
+	// nolint:lll
+	log := "3 348372346321 eni-00184058652e5a320 52.119.169.95 172.31.20.31 443 48316 6 19 7119 1573642242 1573642284 ACCEPT OK vpc-4a486c30 subnet-48998e66 i-038407d32b0f38c60 0 IPv4 76.198.154.105 172.31.20.31"
+	results, err = p.ParseLog(log)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	event, ok := results[0].Event.(*awslogs.VPCFlow)
+	require.True(t, ok)
+	assert := require.New(t)
+	assert.Equal("76.198.154.[REDACTED]", *event.PacketSrcAddr)
+	assert.NotEqual("52.119.169.95", *event.SrcAddr)
+	assert.Equal(parsers.PantherAnyString{"172.31.20.31"}, event.PantherAnyIPAddresses)
+	assert.Equal(parsers.PantherAnyString{"348372346321"}, event.PantherAnyAWSAccountIds)
+	assert.Equal(parsers.PantherAnyString{"i-038407d32b0f38c60"}, event.PantherAnyAWSInstanceIds)
+}
@@ -0,0 +1,427 @@
+package transform
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logschema"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+// DefaultRedactReplace is used to replace redacted values if no replacement is specified
+const DefaultRedactReplace = "[REDACTED]"
+
+// Plan applies the field transforms of a log schema to parsed log events.
+//
+// Fields are matched by their JSON name so a plan can be applied to events of native log types as well as
+// events of custom log types.
+type Plan struct {
+	object *objectPlan
+}
+
+// Build builds a plan for the field transforms of a schema.
+// It returns nil if the schema does not have any transforms.
+func Build(schema *logschema.Schema) (*Plan, error) {
+	value, err := logschema.Resolve(schema)
+	if err != nil {
+		return nil, err
+	}
+	object, err := buildObjectPlan(value.Fields, nil)
+	if err != nil {
+		return nil, err
+	}
+	if object == nil {
+		return nil, nil
+	}
+	return &Plan{
+		object: object,
+	}, nil
+}
+
+// Apply transforms the fields of an event in place.
+func (p *Plan) Apply(event interface{}) {
+	if p == nil || event == nil {
+		return
+	}
+	v := reflect.ValueOf(event)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return
+	}
+	if v = v.Elem(); v.Kind() != reflect.Struct {
+		return
+	}
+	p.object.apply(v)
+}
+
+type objectPlan struct {
+	fields []fieldPlan
+
+	mu      sync.Mutex
+	indexes map[reflect.Type]map[string][]int
+}
+
+type fieldPlan struct {
+	name  string
+	steps []step
+	// nested is set for object fields and arrays of objects with transforms in their fields
+	nested *objectPlan
+}
+
+type step struct {
+	// set for transforms modifying string values
+	value func(s string) string
+	drop  bool
+	// set for copy or rename transforms
+	target string
+	move   bool
+}
+
+func buildObjectPlan(fields []logschema.FieldSchema, path []string) (*objectPlan, error) {
+	plan := objectPlan{}
+	for i := range fields {
+		field := &fields[i]
+		fieldPath := append(path, field.Name)
+		steps, err := buildSteps(field, fields)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid transform for field %q", strings.Join(fieldPath, "."))
+		}
+		var nested *objectPlan
+		switch value := &field.ValueSchema; value.Type {
+		case logschema.TypeObject:
+			nested, err = buildObjectPlan(value.Fields, fieldPath)
+		case logschema.TypeArray:
+			if el := value.Element; el != nil && el.Type == logschema.TypeObject {
+				nested, err = buildObjectPlan(el.Fields, fieldPath)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(steps) == 0 && nested == nil {
+			continue
+		}
+		plan.fields = append(plan.fields, fieldPlan{
+			name:   field.Name,
+			steps:  steps,
+			nested: nested,
+		})
+	}
+	if len(plan.fields) == 0 {
+		return nil, nil
+	}
+	return &plan, nil
+}
+
+func buildSteps(field *logschema.FieldSchema, siblings []logschema.FieldSchema) ([]step, error) {
+	var steps []step
+	for i := range field.Transform {
+		t := &field.Transform[i]
+		if n := countTransforms(t); n != 1 {
+			return nil, errors.Errorf("transform #%d must specify exactly one transformation", i+1)
+		}
+		switch {
+		case t.Redact != nil:
+			if !isStringValue(&field.ValueSchema) {
+				return nil, errors.New("redact requires a string value")
+			}
+			re, err := regexp.Compile(t.Redact.Pattern)
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid redact pattern")
+			}
+			replace := t.Redact.Replace
+			if replace == "" {
+				replace = DefaultRedactReplace
+			}
+			steps = append(steps, step{
+				value: func(s string) string {
+					return re.ReplaceAllString(s, replace)
+				},
+			})
+		case t.Hash != nil:
+			if !isStringValue(&field.ValueSchema) {
+				return nil, errors.New("hash requires a string value")
+			}
+			salt := t.Hash.Salt
+			steps = append(steps, step{
+				value: func(s string) string {
+					h := sha256.New()
+					h.Write([]byte(salt))
+					h.Write([]byte(s))
+					return hex.EncodeToString(h.Sum(nil))
+				},
+			})
+		case t.Truncate != nil:
+			if !isStringValue(&field.ValueSchema) {
+				return nil, errors.New("truncate requires a string value")
+			}
+			length := t.Truncate.Length
+			if length < 1 {
+				return nil, errors.New("truncate length must be positive")
+			}
+			steps = append(steps, step{
+				value: func(s string) string {
+					return truncate(s, length)
+				},
+			})
+		case t.Drop:
+			steps = append(steps, step{
+				drop: true,
+			})
+		case t.Copy != nil, t.Rename != nil:
+			move := t.Rename != nil
+			target := t.Target()
+			if target == field.Name {
+				return nil, errors.Errorf("cannot copy field %q to itself", target)
+			}
+			sibling := findField(target, siblings)
+			if sibling == nil {
+				return nil, errors.Errorf("target field %q not found", target)
+			}
+			if sibling.Type != field.Type {
+				return nil, errors.Errorf("target field %q has type %q instead of %q", target, sibling.Type, field.Type)
+			}
+			steps = append(steps, step{
+				target: target,
+				move:   move,
+			})
+		}
+	}
+	return steps, nil
+}
+
+func countTransforms(t *logschema.Transform) (n int) {
+	for _, set := range []bool{t.Redact != nil, t.Hash != nil, t.Truncate != nil, t.Drop, t.Copy != nil, t.Rename != nil} {
+		if set {
+			n++
+		}
+	}
+	return n
+}
+
+func isStringValue(v *logschema.ValueSchema) bool {
+	switch v.Type {
+	case logschema.TypeString:
+		return true
+	case logschema.TypeArray:
+		return v.Element != nil && isStringValue(v.Element)
+	default:
+		return false
+	}
+}
+
+func findField(name string, fields []logschema.FieldSchema) *logschema.FieldSchema {
+	for i := range fields {
+		if fields[i].Name == name {
+			return &fields[i]
+		}
+	}
+	return nil
+}
+
+func truncate(s string, length int) string {
+	n := 0
+	for i := range s {
+		if n == length {
+			return s[:i]
+		}
+		n++
+	}
+	return s
+}
+
+func (p *objectPlan) apply(v reflect.Value) {
+	index := p.fieldIndex(v.Type())
+	for i := range p.fields {
+		f := &p.fields[i]
+		fieldIndex, ok := index[f.name]
+		if !ok {
+			continue
+		}
+		field := v.FieldByIndex(fieldIndex)
+		if f.nested != nil {
+			applyNested(f.nested, field)
+		}
+		for _, s := range f.steps {
+			switch {
+			case s.value != nil:
+				applyString(field, s.value)
+			case s.drop:
+				field.Set(reflect.Zero(field.Type()))
+			default:
+				targetIndex, ok := index[s.target]
+				if !ok {
+					continue
+				}
+				target := v.FieldByIndex(targetIndex)
+				if target.Type() != field.Type() {
+					continue
+				}
+				target.Set(cloneValue(field))
+				if s.move {
+					field.Set(reflect.Zero(field.Type()))
+				}
+			}
+		}
+	}
+}
+
+// fieldIndex maps the JSON names of the fields of a struct type to their index
+func (p *objectPlan) fieldIndex(typ reflect.Type) map[string][]int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if index, ok := p.indexes[typ]; ok {
+		return index
+	}
+	if p.indexes == nil {
+		p.indexes = make(map[reflect.Type]map[string][]int)
+	}
+	index := make(map[string][]int)
+	indexFields(index, typ, nil)
+	p.indexes[typ] = index
+	return index
+}
+
+func indexFields(index map[string][]int, typ reflect.Type, path []int) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		fieldPath := append(append([]int(nil), path...), i)
+		tag, hasTag := field.Tag.Lookup("json")
+		name := tag
+		if pos := strings.IndexByte(tag, ','); pos != -1 {
+			name = tag[:pos]
+		}
+		if name == "-" {
+			continue
+		}
+		// Fields of embedded structs are promoted unless the embedded struct is named by a tag
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && name == "" {
+			indexFields(index, field.Type, fieldPath)
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		if !hasTag || name == "" {
+			name = field.Name
+		}
+		if _, duplicate := index[name]; !duplicate {
+			index[name] = fieldPath
+		}
+	}
+}
+
+func applyNested(plan *objectPlan, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			applyNested(plan, v.Elem())
+		}
+	case reflect.Struct:
+		plan.apply(v)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			applyNested(plan, v.Index(i))
+		}
+	}
+}
+
+var (
+	typString   = reflect.TypeOf(null.String{})
+	typNonEmpty = reflect.TypeOf(null.NonEmpty{})
+)
+
+func applyString(v reflect.Value, fn func(s string) string) {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(fn(v.String()))
+	case reflect.Ptr:
+		if !v.IsNil() {
+			// Do not modify values that might be shared with other fields
+			cp := cloneValue(v)
+			applyString(cp.Elem(), fn)
+			v.Set(cp)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			applyString(v.Index(i), fn)
+		}
+	case reflect.Struct:
+		switch v.Type() {
+		case typString:
+			if s := v.Addr().Interface().(*null.String); s.Exists {
+				s.Value = fn(s.Value)
+			}
+		case typNonEmpty:
+			if s := v.Addr().Interface().(*null.NonEmpty); s.Exists {
+				s.Value = fn(s.Value)
+			}
+		}
+	}
+}
+
+// cloneValue copies a value so that the copy does not share memory with the original
+func cloneValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		cp := reflect.New(v.Type().Elem())
+		cp.Elem().Set(cloneValue(v.Elem()))
+		return cp
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		cp := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			cp.Index(i).Set(cloneValue(v.Index(i)))
+		}
+		return cp
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		cp := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			cp.SetMapIndex(iter.Key(), cloneValue(iter.Value()))
+		}
+		return cp
+	case reflect.Struct:
+		cp := reflect.New(v.Type()).Elem()
+		cp.Set(v)
+		for i := 0; i < cp.NumField(); i++ {
+			if f := cp.Field(i); f.CanSet() {
+				f.Set(cloneValue(f))
+			}
+		}
+		return cp
+	default:
+		return v
+	}
+}
@@ -0,0 +1,139 @@
+package transform
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logschema"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+type testEmbedded struct {
+	Secret null.String `json:"secret"`
+}
+
+type testItem struct {
+	Name   *string `json:"name,omitempty"`
+	Copied *string `json:"copied,omitempty"`
+}
+
+type testNativeEvent struct {
+	testEmbedded
+	Message string      `json:"message"`
+	Items   []testItem  `json:"items"`
+	Other   null.String `json:"other"`
+}
+
+func mustBuild(t *testing.T, spec string) *Plan {
+	t.Helper()
+	schema := logschema.Schema{}
+	require.NoError(t, yaml.Unmarshal([]byte(spec), &schema))
+	plan, err := Build(&schema)
+	require.NoError(t, err)
+	return plan
+}
+
+func TestPlanNativeEvent(t *testing.T) {
+	plan := mustBuild(t, `
+version: 0
+fields:
+- name: secret
+  type: string
+  transform:
+  - redact:
+      pattern: '.+'
+- name: message
+  type: string
+  transform:
+  - truncate:
+      length: 4
+- name: items
+  type: array
+  element:
+    type: object
+    fields:
+    - name: name
+      type: string
+      transform:
+      - copy:
+          target: copied
+      - hash: {}
+    - name: copied
+      type: string
+`)
+	require.NotNil(t, plan)
+	name := "foo"
+	event := testNativeEvent{
+		testEmbedded: testEmbedded{
+			Secret: null.FromString("hunter2"),
+		},
+		Message: "ünïcode",
+		Items: []testItem{
+			{Name: &name},
+			{},
+		},
+		Other: null.FromString("other"),
+	}
+	plan.Apply(&event)
+	assert := require.New(t)
+	assert.Equal(null.FromString(DefaultRedactReplace), event.Secret)
+	assert.Equal("ünïc", event.Message)
+	// sha256("foo")
+	assert.Equal("2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae", *event.Items[0].Name)
+	assert.Equal("foo", *event.Items[0].Copied)
+	assert.Equal("foo", name)
+	assert.Equal(testItem{}, event.Items[1])
+	assert.Equal(null.FromString("other"), event.Other)
+}
+
+func TestBuildNoTransforms(t *testing.T) {
+	plan := mustBuild(t, `
+version: 0
+fields:
+- name: message
+  type: string
+`)
+	require.Nil(t, plan)
+	// Applying a nil plan is a noop
+	plan.Apply(&testNativeEvent{})
+}
+
+func TestBuildErrors(t *testing.T) {
+	for _, spec := range []string{
+		// hash on non-string
+		`{"version":0,"fields":[{"name":"a","type":"int","transform":[{"hash":{}}]}]}`,
+		// invalid regex
+		`{"version":0,"fields":[{"name":"a","type":"string","transform":[{"redact":{"pattern":"["}}]}]}`,
+		// missing target
+		`{"version":0,"fields":[{"name":"a","type":"string","transform":[{"rename":{"target":"b"}}]}]}`,
+		// target type mismatch
+		`{"version":0,"fields":[{"name":"a","type":"string","transform":[{"copy":{"target":"b"}}]},{"name":"b","type":"int"}]}`,
+		// multiple transformations in one transform
+		`{"version":0,"fields":[{"name":"a","type":"string","transform":[{"drop":true,"hash":{}}]}]}`,
+	} {
+		schema := logschema.Schema{}
+		require.NoError(t, yaml.Unmarshal([]byte(spec), &schema))
+		_, err := Build(&schema)
+		require.Error(t, err, spec)
+	}
+}
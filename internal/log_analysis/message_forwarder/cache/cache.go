@@ -29,7 +29,9 @@ type Refreshable struct {
 	kv              map[string]interface{}
 	refreshFunc     func() (map[string]interface{}, error)
 	minimumInterval time.Duration
+	maxAge          time.Duration
 	lastRefresh     time.Time
+	lastAttempt     time.Time
 }
 
 func New(refreshFunc func() (map[string]interface{}, error)) *Refreshable {
@@ -40,10 +42,22 @@ func New(refreshFunc func() (map[string]interface{}, error)) *Refreshable {
 	}
 }
 
+// NewWithMaxAge returns a cache that is also refreshed on lookups once its contents are older than maxAge.
+// This allows changes to existing entries (e.g. revoked or rotated credentials) to take effect.
+func NewWithMaxAge(refreshFunc func() (map[string]interface{}, error), maxAge time.Duration) *Refreshable {
+	c := New(refreshFunc)
+	c.maxAge = maxAge
+	return c
+}
+
 // Retrieves the value for the provided key from the cache. It will return an empty string if no value was present.
 // If the key is not present in the cache and more than `lastRefresh` time has passed since the last time
 // the cache was refreshed, we try to refresh the cache again.
+// If the cache has a max age and its contents are stale, it is refreshed regardless of the key being present.
 func (c *Refreshable) Get(key string) (value interface{}, found bool) {
+	if c.isExpired() {
+		c.runRefresh()
+	}
 	value, found = c.kv[key]
 	// Invoke refresh function if the value was not found
 	// Avoid invoking the refresh function multiple times
@@ -54,8 +68,18 @@ func (c *Refreshable) Get(key string) (value interface{}, found bool) {
 	return value, found
 }
 
+// Checks if the cache contents are older than the max age.
+// Failed refresh attempts are retried at most once every `minimumInterval`, serving the stale contents meanwhile.
+func (c *Refreshable) isExpired() bool {
+	if c.maxAge <= 0 || c.lastRefresh.IsZero() {
+		return false
+	}
+	return time.Since(c.lastRefresh) > c.maxAge && time.Since(c.lastAttempt) > c.minimumInterval
+}
+
 // Runs the fresh method and repopulate the cache
 func (c *Refreshable) runRefresh() {
+	c.lastAttempt = time.Now()
 	newMap, err := c.refreshFunc()
 	if err != nil {
 		zap.L().Warn("failed to refresh cache", zap.Error(err))
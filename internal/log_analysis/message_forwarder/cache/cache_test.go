@@ -21,6 +21,7 @@ package cache
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -84,3 +85,59 @@ func TestRetrieveValueShouldRespectMinimumInterval(t *testing.T) {
 	assert.False(t, ok)
 	assert.Equal(t, 1, timesCalled)
 }
+
+func TestRetrieveValueShouldRefreshAfterMaxAge(t *testing.T) {
+	timesCalled := 0
+	refreshFunc := func() (map[string]interface{}, error) {
+		timesCalled++
+		if timesCalled > 1 {
+			return map[string]interface{}{}, nil
+		}
+		return cacheFuncReturnValue, nil
+	}
+	cache := NewWithMaxAge(refreshFunc, time.Hour)
+	value, ok := cache.Get("key")
+	assert.Equal(t, "value", value)
+	assert.True(t, ok)
+	assert.Equal(t, 1, timesCalled)
+
+	// Contents are still fresh
+	value, ok = cache.Get("key")
+	assert.Equal(t, "value", value)
+	assert.True(t, ok)
+	assert.Equal(t, 1, timesCalled)
+
+	// Contents are stale, the entry was removed upstream
+	cache.lastRefresh = cache.lastRefresh.Add(-2 * time.Hour)
+	cache.lastAttempt = cache.lastAttempt.Add(-2 * time.Hour)
+	value, ok = cache.Get("key")
+	assert.Nil(t, value)
+	assert.False(t, ok)
+	assert.Equal(t, 2, timesCalled)
+}
+
+func TestRetrieveValueShouldServeStaleOnRefreshError(t *testing.T) {
+	timesCalled := 0
+	refreshFunc := func() (map[string]interface{}, error) {
+		timesCalled++
+		if timesCalled > 1 {
+			return nil, errors.New("error")
+		}
+		return cacheFuncReturnValue, nil
+	}
+	cache := NewWithMaxAge(refreshFunc, time.Hour)
+	_, ok := cache.Get("key")
+	assert.True(t, ok)
+
+	cache.lastRefresh = cache.lastRefresh.Add(-2 * time.Hour)
+	cache.lastAttempt = cache.lastAttempt.Add(-2 * time.Hour)
+	value, ok := cache.Get("key")
+	assert.Equal(t, "value", value)
+	assert.True(t, ok)
+	assert.Equal(t, 2, timesCalled)
+
+	// Failed refresh should not be retried before the minimum interval
+	_, ok = cache.Get("key")
+	assert.True(t, ok)
+	assert.Equal(t, 2, timesCalled)
+}
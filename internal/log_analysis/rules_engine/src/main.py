@@ -19,7 +19,7 @@ import json
 from gzip import GzipFile
 from io import TextIOWrapper
 from timeit import default_timer
-from typing import Any, Dict, List, Optional, Tuple
+from typing import Any, Dict, Iterable, List, Optional, Tuple, Union
 
 from .analysis_api import AnalysisAPIClient
 from .aws_clients import S3_CLIENT
@@ -71,11 +71,14 @@ def log_analysis(event: Dict[str, Any]) -> None:
     for log_type, data_streams in log_type_to_data.items():
         for data_stream in data_streams:
             for data in data_stream:
-                try:  # Bad json data can cause exceptions to be thrown. Best effort: log and continue
-                    json_data = json.loads(data)
-                except Exception as err:  # pylint: disable=broad-except
-                    _LOGGER.error("data is not valid JSON %s", err)  # do not log data!
-                    continue
+                if isinstance(data, dict):  # events read from Parquet files
+                    json_data = data
+                else:
+                    try:  # Bad json data can cause exceptions to be thrown. Best effort: log and continue
+                        json_data = json.loads(data)
+                    except Exception as err:  # pylint: disable=broad-except
+                        _LOGGER.error("data is not valid JSON %s", err)  # do not log data!
+                        continue
 
                 for analysis_result in _RULES_ENGINE.analyze(log_type, json_data):
                     # The analysis results can be either a. Rule matches b. Rule errors
@@ -87,9 +90,9 @@ def log_analysis(event: Dict[str, Any]) -> None:
     _LOGGER.info("Matched %d events in %s seconds", matches, end - start)
 
 
-# Reads lambda events wrapping s3 notifications, returns dictionary containing mapping from log type to list of TextIOWrapper's
-def _load_event(event: Dict[str, Any]) -> Dict[str, List[TextIOWrapper]]:
-    log_type_to_data: Dict[str, List[TextIOWrapper]] = collections.defaultdict(list)
+# Reads lambda events wrapping s3 notifications, returns dictionary containing mapping from log type to list of data streams
+def _load_event(event: Dict[str, Any]) -> Dict[str, List[Iterable[Union[str, Dict[str, Any]]]]]:
+    log_type_to_data: Dict[str, List[Iterable[Union[str, Dict[str, Any]]]]] = collections.defaultdict(list)
     for record in event['Records']:
         record_body = json.loads(record['body'])
         log_type = record['messageAttributes']['id']['stringValue']  # id attr holds log type
@@ -110,8 +113,13 @@ def _load_s3_notifications(records: List[Dict[str, Any]]) -> List[Tuple[str, str
     return events
 
 
-# Returns a TextIOWrapper for the S3 data. This makes sure that we don't have to keep all contents of S3 object in memory
-def _load_contents(bucket: str, key: str) -> TextIOWrapper:
+# Returns a TextIOWrapper for the S3 data. This makes sure that we don't have to keep all contents of S3 object in memory.
+# Parquet files need random access, so they are read in memory and their rows are returned as events.
+def _load_contents(bucket: str, key: str) -> Iterable[Union[str, Dict[str, Any]]]:
     response = S3_CLIENT.get_object(Bucket=bucket, Key=key)
+    if key.endswith('.parquet'):
+        # pyarrow is only needed for Parquet tables
+        from .parquet import read_events  # pylint: disable=import-outside-toplevel
+        return read_events(response['Body'].read())
     gzipped = GzipFile(None, 'rb', fileobj=response['Body'])
     return TextIOWrapper(gzipped)  # type: ignore
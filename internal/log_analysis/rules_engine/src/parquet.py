@@ -0,0 +1,87 @@
+# Panther is a Cloud-Native SIEM for the Modern Security Team.
+# Copyright (C) 2020 Panther Labs Inc
+#
+# This program is free software: you can redistribute it and/or modify
+# it under the terms of the GNU Affero General Public License as
+# published by the Free Software Foundation, either version 3 of the
+# License, or (at your option) any later version.
+#
+# This program is distributed in the hope that it will be useful,
+# but WITHOUT ANY WARRANTY; without even the implied warranty of
+# MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+# GNU Affero General Public License for more details.
+#
+# You should have received a copy of the GNU Affero General Public License
+# along with this program.  If not, see <https://www.gnu.org/licenses/>.
+"""Reads the Parquet files of the processed log tables as events.
+
+The events match the JSON written by the log processor for JSON tables:
+null fields and empty lists or maps are omitted, timestamps use the Glue layout
+and string columns that store raw JSON values are decoded.
+"""
+
+import json
+import math
+from typing import Any, Dict, Iterator, List, Set
+
+import pyarrow
+import pyarrow.parquet
+
+# Key of the file metadata listing the string columns that store raw JSON values (see glueparquet.RawJSONColumnsKey)
+_RAW_JSON_COLUMNS_KEY = b'panther.raw_json_columns'
+
+# The Glue timestamp layout has nanosecond precision, timestamps are read with microsecond precision
+_TIMESTAMP_FORMAT = '%Y-%m-%d %H:%M:%S.%f000'
+
+
+def read_events(data: bytes) -> Iterator[Dict[str, Any]]:
+    """Yields the rows of a Parquet file as events"""
+    metadata = pyarrow.parquet.read_metadata(pyarrow.BufferReader(data)).metadata or {}
+    raw_columns: Set[str] = set(json.loads(metadata.get(_RAW_JSON_COLUMNS_KEY, b'[]')))
+    # INT96 timestamps are read as nanoseconds by default, which do not convert to datetime values
+    table = pyarrow.parquet.read_table(pyarrow.BufferReader(data), coerce_int96_timestamp_unit='us')
+    columns = table.to_pydict()
+    for i in range(table.num_rows):
+        yield _convert_fields({field.name: columns[field.name][i] for field in table.schema}, table.schema, [], raw_columns)
+
+
+def _convert_fields(value: Dict[str, Any], fields: Any, path: List[str], raw_columns: Set[str]) -> Dict[str, Any]:
+    event: Dict[str, Any] = {}
+    for field in fields:
+        field_value = _convert(value.get(field.name), field.type, path + [field.name], raw_columns)
+        if field_value is None:
+            continue
+        if (pyarrow.types.is_list(field.type) or pyarrow.types.is_map(field.type)) and len(field_value) == 0:
+            continue
+        event[field.name] = field_value
+    return event
+
+
+def _convert(value: Any, value_type: Any, path: List[str], raw_columns: Set[str]) -> Any:
+    if value is None:
+        return None
+    if pyarrow.types.is_struct(value_type):
+        return _convert_fields(value, value_type, path, raw_columns)
+    if pyarrow.types.is_map(value_type):
+        # Maps are read as lists of key-value tuples
+        value_path = path + ['key_value', 'value']
+        return {key: _convert(item, value_type.item_type, value_path, raw_columns) for key, item in sorted(value)}
+    if pyarrow.types.is_list(value_type):
+        element_path = path + ['list', 'element']
+        return [_convert(item, value_type.value_type, element_path, raw_columns) for item in value]
+    if pyarrow.types.is_timestamp(value_type):
+        return value.strftime(_TIMESTAMP_FORMAT)
+    if pyarrow.types.is_floating(value_type) and not math.isfinite(value):
+        return None
+    if pyarrow.types.is_string(value_type) and '.'.join(path) in raw_columns:
+        return _load_raw_json(value)
+    return value
+
+
+def _load_raw_json(value: str) -> Any:
+    if not value.startswith(('{', '[')):
+        return value
+    try:
+        return json.loads(value)
+    except ValueError:
+        return value
@@ -0,0 +1,87 @@
+# Panther is a Cloud-Native SIEM for the Modern Security Team.
+# Copyright (C) 2020 Panther Labs Inc
+#
+# This program is free software: you can redistribute it and/or modify
+# it under the terms of the GNU Affero General Public License as
+# published by the Free Software Foundation, either version 3 of the
+# License, or (at your option) any later version.
+#
+# This program is distributed in the hope that it will be useful,
+# but WITHOUT ANY WARRANTY; without even the implied warranty of
+# MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+# GNU Affero General Public License for more details.
+#
+# You should have received a copy of the GNU Affero General Public License
+# along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+from datetime import datetime
+from unittest import TestCase
+
+import pyarrow
+import pyarrow.parquet
+
+from ..src.parquet import read_events
+
+
+def _write_parquet(table: pyarrow.Table) -> bytes:
+    sink = pyarrow.BufferOutputStream()
+    # The log processor writes timestamps as INT96 values
+    pyarrow.parquet.write_table(table, sink, use_deprecated_int96_timestamps=True)
+    return sink.getvalue().to_pybytes()
+
+
+class TestReadEvents(TestCase):
+
+    def test_read_events(self) -> None:
+        schema = pyarrow.schema(
+            [
+                ('ts', pyarrow.timestamp('ns')),
+                ('name', pyarrow.string()),
+                ('tags', pyarrow.list_(pyarrow.string())),
+                ('attrs', pyarrow.map_(pyarrow.string(), pyarrow.int64())),
+                ('nested', pyarrow.struct([('a', pyarrow.bool_()), ('raw', pyarrow.string())])),
+                ('raw', pyarrow.string()),
+                ('ratio', pyarrow.float64()),
+            ],
+            metadata={'panther.raw_json_columns': '["nested.raw","raw"]'},
+        )
+        table = pyarrow.Table.from_pydict(
+            {
+                'ts': [datetime(2020, 1, 1, 10, 20, 30, 42), None],
+                'name': ['{"not":"raw"}', None],
+                'tags': [['a', None], []],
+                'attrs': [[('y', 2), ('x', 1)], []],
+                'nested': [{
+                    'a': True,
+                    'raw': '[1,2]'
+                }, None],
+                'raw': ['{"k":"v"}', 'plain'],
+                'ratio': [float('nan'), None],
+            },
+            schema=schema,
+        )
+        events = list(read_events(_write_parquet(table)))
+        self.assertEqual(
+            [
+                {
+                    'ts': '2020-01-01 10:20:30.000042000',
+                    'name': '{"not":"raw"}',
+                    'tags': ['a', None],
+                    'attrs': {
+                        'x': 1,
+                        'y': 2
+                    },
+                    'nested': {
+                        'a': True,
+                        'raw': [1, 2]
+                    },
+                    'raw': {
+                        'k': 'v'
+                    },
+                },
+                {
+                    'raw': 'plain'
+                },
+            ],
+            events,
+        )
@@ -39,6 +39,8 @@ import (
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager/s3manageriface"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
 	"github.com/aws/aws-sdk-go/service/sns"
 	"github.com/aws/aws-sdk-go/service/sns/snsiface"
 	"github.com/aws/aws-sdk-go/service/sqs"
@@ -71,6 +73,27 @@ func (m *S3Mock) DeleteObjects(input *s3.DeleteObjectsInput) (*s3.DeleteObjectsO
 	return args.Get(0).(*s3.DeleteObjectsOutput), args.Error(1)
 }
 
+func (m *S3Mock) DeleteObjectsWithContext(ctx aws.Context, input *s3.DeleteObjectsInput,
+	options ...request.Option) (*s3.DeleteObjectsOutput, error) {
+
+	args := m.Called(ctx, input, options)
+	return args.Get(0).(*s3.DeleteObjectsOutput), args.Error(1)
+}
+
+func (m *S3Mock) CopyObjectWithContext(ctx aws.Context, input *s3.CopyObjectInput,
+	options ...request.Option) (*s3.CopyObjectOutput, error) {
+
+	args := m.Called(ctx, input, options)
+	return args.Get(0).(*s3.CopyObjectOutput), args.Error(1)
+}
+
+func (m *S3Mock) PutObjectWithContext(ctx aws.Context, input *s3.PutObjectInput,
+	options ...request.Option) (*s3.PutObjectOutput, error) {
+
+	args := m.Called(ctx, input, options)
+	return args.Get(0).(*s3.PutObjectOutput), args.Error(1)
+}
+
 func (m *S3Mock) GetObject(input *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
 	args := m.Called(input)
 	return args.Get(0).(*s3.GetObjectOutput), args.Error(1)
@@ -86,6 +109,20 @@ func (m *S3Mock) HeadObject(i *s3.HeadObjectInput) (*s3.HeadObjectOutput, error)
 	return args.Get(0).(*s3.HeadObjectOutput), args.Error(1)
 }
 
+func (m *S3Mock) GetObjectTaggingWithContext(ctx aws.Context, input *s3.GetObjectTaggingInput,
+	options ...request.Option) (*s3.GetObjectTaggingOutput, error) {
+
+	args := m.Called(ctx, input, options)
+	return args.Get(0).(*s3.GetObjectTaggingOutput), args.Error(1)
+}
+
+func (m *S3Mock) PutObjectTaggingWithContext(ctx aws.Context, input *s3.PutObjectTaggingInput,
+	options ...request.Option) (*s3.PutObjectTaggingOutput, error) {
+
+	args := m.Called(ctx, input, options)
+	return args.Get(0).(*s3.PutObjectTaggingOutput), args.Error(1)
+}
+
 func (m *S3Mock) GetBucketLocation(input *s3.GetBucketLocationInput) (*s3.GetBucketLocationOutput, error) {
 	args := m.Called(input)
 	return args.Get(0).(*s3.GetBucketLocationOutput), args.Error(1)
@@ -221,6 +258,11 @@ func (m *DynamoDBMock) Scan(input *dynamodb.ScanInput) (*dynamodb.ScanOutput, er
 	return args.Get(0).(*dynamodb.ScanOutput), args.Error(1)
 }
 
+func (m *DynamoDBMock) BatchWriteItem(input *dynamodb.BatchWriteItemInput) (*dynamodb.BatchWriteItemOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*dynamodb.BatchWriteItemOutput), args.Error(1)
+}
+
 type SqsMock struct {
 	sqsiface.SQSAPI
 	mock.Mock
@@ -371,6 +413,11 @@ func (m *GlueMock) GetTable(input *glue.GetTableInput) (*glue.GetTableOutput, er
 	return args.Get(0).(*glue.GetTableOutput), args.Error(1)
 }
 
+func (m *GlueMock) GetTableWithContext(ctx aws.Context, input *glue.GetTableInput, _ ...request.Option) (*glue.GetTableOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*glue.GetTableOutput), args.Error(1)
+}
+
 func (m *GlueMock) DeleteTable(input *glue.DeleteTableInput) (*glue.DeleteTableOutput, error) {
 	args := m.Called(input)
 	return args.Get(0).(*glue.DeleteTableOutput), args.Error(1)
@@ -396,6 +443,21 @@ func (m *GlueMock) UpdatePartition(input *glue.UpdatePartitionInput) (*glue.Upda
 	return args.Get(0).(*glue.UpdatePartitionOutput), args.Error(1)
 }
 
+func (m *GlueMock) UpdatePartitionWithContext(ctx aws.Context, input *glue.UpdatePartitionInput,
+	_ ...request.Option) (*glue.UpdatePartitionOutput, error) {
+
+	args := m.Called(ctx, input)
+	return args.Get(0).(*glue.UpdatePartitionOutput), args.Error(1)
+}
+
+func (m *GlueMock) GetPartitionsPagesWithContext(ctx aws.Context, input *glue.GetPartitionsInput,
+	f func(page *glue.GetPartitionsOutput, isLast bool) bool, _ ...request.Option) error {
+
+	args := m.Called(ctx, input, f)
+	f(args.Get(0).(*glue.GetPartitionsOutput), true)
+	return args.Error(1)
+}
+
 // nolint:lll
 func (m *GlueMock) GetTablesPagesWithContext(
 	ctx aws.Context,
@@ -471,3 +533,37 @@ func (m *FirehoseMock) PutRecordBatchWithContext(
 	args := m.Called(ctx, input, options)
 	return args.Get(0).(*firehose.PutRecordBatchOutput), args.Error(1)
 }
+
+type SecretsManagerMock struct {
+	secretsmanageriface.SecretsManagerAPI
+	mock.Mock
+}
+
+func (m *SecretsManagerMock) CreateSecret(input *secretsmanager.CreateSecretInput) (*secretsmanager.CreateSecretOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*secretsmanager.CreateSecretOutput), args.Error(1)
+}
+
+func (m *SecretsManagerMock) PutSecretValue(input *secretsmanager.PutSecretValueInput) (*secretsmanager.PutSecretValueOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*secretsmanager.PutSecretValueOutput), args.Error(1)
+}
+
+func (m *SecretsManagerMock) GetSecretValue(input *secretsmanager.GetSecretValueInput) (*secretsmanager.GetSecretValueOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*secretsmanager.GetSecretValueOutput), args.Error(1)
+}
+
+func (m *SecretsManagerMock) GetSecretValueWithContext(
+	ctx aws.Context,
+	input *secretsmanager.GetSecretValueInput,
+	options ...request.Option) (*secretsmanager.GetSecretValueOutput, error) {
+
+	args := m.Called(ctx, input)
+	return args.Get(0).(*secretsmanager.GetSecretValueOutput), args.Error(1)
+}
+
+func (m *SecretsManagerMock) DeleteSecret(input *secretsmanager.DeleteSecretInput) (*secretsmanager.DeleteSecretOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*secretsmanager.DeleteSecretOutput), args.Error(1)
+}
@@ -38,6 +38,8 @@ type Infra struct {
 	LoadBalancerSecurityGroupCidr      string   `yaml:"LoadBalancerSecurityGroupCidr"`
 	LogProcessorLambdaMemorySize       int      `yaml:"LogProcessorLambdaMemorySize"`
 	LogProcessorLambdaSQSReadBatchSize string   `yaml:"LogProcessorLambdaSQSReadBatchSize"`
+	ProcessedDataFormat                string   `yaml:"ProcessedDataFormat"`
+	GeoIPDatabases                     string   `yaml:"GeoIPDatabases"`
 	PipLayer                           []string `yaml:"PipLayer"`
 	KvTableBillingMode                 string   `yaml:"KvTableBillingMode"`
 	PythonLayerVersionArn              string   `yaml:"PythonLayerVersionArn"`
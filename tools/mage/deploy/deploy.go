@@ -537,12 +537,14 @@ func deployLogAnalysisStack(settings *PantherConfig, packager *pkg.Packager, out
 		"CloudWatchLogRetentionDays":         strconv.Itoa(settings.Monitoring.CloudWatchLogRetentionDays),
 		"CustomResourceVersion":              customResourceVersion(),
 		"Debug":                              strconv.FormatBool(settings.Monitoring.Debug),
+		"GeoIPDatabases":                     settings.Infra.GeoIPDatabases,
 		"InputDataBucket":                    outputs["InputDataBucket"],
 		"InputDataTopicArn":                  outputs["InputDataTopicArn"],
 		"LayerVersionArns":                   settings.Infra.BaseLayerVersionArns,
 		"LogProcessorLambdaMemorySize":       strconv.Itoa(settings.Infra.LogProcessorLambdaMemorySize),
 		"LogProcessorLambdaSQSReadBatchSize": settings.Infra.LogProcessorLambdaSQSReadBatchSize,
 		"ProcessedDataBucket":                outputs["ProcessedDataBucket"],
+		"ProcessedDataFormat":                settings.Infra.ProcessedDataFormat,
 		"ProcessedDataTopicArn":              outputs["ProcessedDataTopicArn"],
 		"PythonAssumableRoleArns":            strings.Join(settings.Infra.PythonAssumableRoleArns, ","),
 		"PythonLayerVersionArn":              outputs["PythonLayerVersionArn"],
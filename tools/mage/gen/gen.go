@@ -27,13 +27,14 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/panther-labs/panther/tools/mage/gen/dashboards"
+	"github.com/panther-labs/panther/tools/mage/gen/resourcetypes"
 	"github.com/panther-labs/panther/tools/mage/logger"
 	"github.com/panther-labs/panther/tools/mage/util"
 )
 
 var log = logger.Build("[gen]")
 
-// Autogenerate parts of the source code: API SDKs, GraphQL types, CW dashboards
+// Autogenerate parts of the source code: API SDKs, GraphQL types, CW dashboards, resource types
 func Gen() error {
 	results := make(chan util.TaskResult)
 	count := 0
@@ -53,6 +54,11 @@ func Gen() error {
 		c <- util.TaskResult{Summary: "cw dashboards", Err: cwDashboards()}
 	}(results)
 
+	count++
+	go func(c chan util.TaskResult) {
+		c <- util.TaskResult{Summary: "resource types", Err: resourceTypes()}
+	}(results)
+
 	return util.WaitForTasks(log, results, 1, count, count)
 }
 
@@ -100,3 +106,9 @@ func cwDashboards() error {
 	util.MustWriteFile(target, body)
 	return nil
 }
+
+// Generate web/__generated__/resourceTypes.ts from the resource type registry
+func resourceTypes() error {
+	util.MustWriteFile(resourcetypes.Target, resourcetypes.Generate())
+	return nil
+}
@@ -0,0 +1,85 @@
+package resourcetypes
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/resourcetypes"
+)
+
+// Target is the path of the generated frontend module, relative to the repo root
+var Target = filepath.Join("web", "__generated__", "resourceTypes.ts")
+
+type resourceType struct {
+	name        string
+	displayName string
+}
+
+// Generate renders the resource type registries as a TypeScript module for the web frontend
+func Generate() []byte {
+	var buf bytes.Buffer
+	buf.WriteString(licenseHeader)
+	buf.WriteString("\n// NOTE: file auto-generated by 'mage gen', DO NOT EDIT\n\n")
+
+	registry := resourceTypes()
+	buf.WriteString("export const RESOURCE_TYPES = [\n")
+	for _, info := range registry {
+		fmt.Fprintf(&buf, "  '%s',\n", info.name)
+	}
+	buf.WriteString("] as const;\n\n")
+
+	buf.WriteString("export type ResourceType = typeof RESOURCE_TYPES[number];\n\n")
+
+	buf.WriteString("export const RESOURCE_TYPE_DISPLAY_NAMES: Record<ResourceType, string> = {\n")
+	for _, info := range registry {
+		fmt.Fprintf(&buf, "  '%s': '%s',\n", info.name, info.displayName)
+	}
+	buf.WriteString("};\n")
+	return buf.Bytes()
+}
+
+// resourceTypes lists the registered resource types sorted by name
+func resourceTypes() (result []resourceType) {
+	for _, info := range resourcetypes.Registered() {
+		result = append(result, resourceType{name: info.Name, displayName: info.DisplayName})
+	}
+	return result
+}
+
+const licenseHeader = `/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+`
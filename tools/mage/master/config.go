@@ -38,7 +38,7 @@ const (
 )
 
 var (
-	defaultPipLayer = []string{"jsonpath-ng==1.5.2", "policyuniverse==1.3.2.2", "requests==2.23.0"}
+	defaultPipLayer = []string{"jsonpath-ng==1.5.2", "policyuniverse==1.3.2.2", "pyarrow==6.0.1", "requests==2.23.0"}
 	rootConfigPath  = filepath.Join("deployments", "root_config.yml")
 )
 
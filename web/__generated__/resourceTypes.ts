@@ -0,0 +1,143 @@
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// NOTE: file auto-generated by 'mage gen', DO NOT EDIT
+
+export const RESOURCE_TYPES = [
+  'AWS.ACM.Certificate',
+  'AWS.APIGateway.HttpAPI',
+  'AWS.APIGateway.RestAPI',
+  'AWS.CloudFormation.Stack',
+  'AWS.CloudFront.Distribution',
+  'AWS.CloudTrail',
+  'AWS.CloudTrail.Meta',
+  'AWS.CloudWatch.LogGroup',
+  'AWS.Config.Recorder',
+  'AWS.Config.Recorder.Meta',
+  'AWS.DynamoDB.Table',
+  'AWS.EC2.AMI',
+  'AWS.EC2.Instance',
+  'AWS.EC2.NetworkACL',
+  'AWS.EC2.SecurityGroup',
+  'AWS.EC2.TransitGateway',
+  'AWS.EC2.VPC',
+  'AWS.EC2.Volume',
+  'AWS.ECR.Repository',
+  'AWS.ECS.Cluster',
+  'AWS.EFS.FileSystem',
+  'AWS.EKS.Cluster',
+  'AWS.ELBV2.ApplicationLoadBalancer',
+  'AWS.Elasticsearch.Domain',
+  'AWS.GuardDuty.Detector',
+  'AWS.GuardDuty.Detector.Meta',
+  'AWS.IAM.Group',
+  'AWS.IAM.Policy',
+  'AWS.IAM.Role',
+  'AWS.IAM.RootUser',
+  'AWS.IAM.User',
+  'AWS.KMS.Key',
+  'AWS.Lambda.Function',
+  'AWS.PasswordPolicy',
+  'AWS.RDS.Instance',
+  'AWS.Redshift.Cluster',
+  'AWS.Route53.HostedZone',
+  'AWS.S3.Bucket',
+  'AWS.SNS.Topic',
+  'AWS.SQS.Queue',
+  'AWS.SSM.Parameter',
+  'AWS.SecretsManager.Secret',
+  'AWS.WAF.Regional.WebACL',
+  'AWS.WAF.WebACL',
+  'GCP.Compute.Firewall',
+  'GCP.Compute.Instance',
+  'GCP.IAM.Binding',
+  'GCP.SQL.Instance',
+  'GCP.Storage.Bucket',
+  'K8s.Apps.Deployment',
+  'K8s.Core.Namespace',
+  'K8s.Core.Pod',
+  'K8s.Core.ServiceAccount',
+  'K8s.Networking.NetworkPolicy',
+  'K8s.RBAC.ClusterRole',
+  'K8s.RBAC.ClusterRoleBinding',
+  'K8s.RBAC.Role',
+  'K8s.RBAC.RoleBinding',
+] as const;
+
+export type ResourceType = typeof RESOURCE_TYPES[number];
+
+export const RESOURCE_TYPE_DISPLAY_NAMES: Record<ResourceType, string> = {
+  'AWS.ACM.Certificate': 'ACM Certificate',
+  'AWS.APIGateway.HttpAPI': 'API Gateway HTTP API',
+  'AWS.APIGateway.RestAPI': 'API Gateway REST API',
+  'AWS.CloudFormation.Stack': 'CloudFormation Stack',
+  'AWS.CloudFront.Distribution': 'CloudFront Distribution',
+  'AWS.CloudTrail': 'CloudTrail',
+  'AWS.CloudTrail.Meta': 'CloudTrail Meta',
+  'AWS.CloudWatch.LogGroup': 'CloudWatch Log Group',
+  'AWS.Config.Recorder': 'Config Recorder',
+  'AWS.Config.Recorder.Meta': 'Config Recorder Meta',
+  'AWS.DynamoDB.Table': 'DynamoDB Table',
+  'AWS.EC2.AMI': 'EC2 AMI',
+  'AWS.EC2.Instance': 'EC2 Instance',
+  'AWS.EC2.NetworkACL': 'EC2 Network ACL',
+  'AWS.EC2.SecurityGroup': 'EC2 Security Group',
+  'AWS.EC2.TransitGateway': 'EC2 Transit Gateway',
+  'AWS.EC2.VPC': 'EC2 VPC',
+  'AWS.EC2.Volume': 'EC2 Volume',
+  'AWS.ECR.Repository': 'ECR Repository',
+  'AWS.ECS.Cluster': 'ECS Cluster',
+  'AWS.EFS.FileSystem': 'EFS File System',
+  'AWS.EKS.Cluster': 'EKS Cluster',
+  'AWS.ELBV2.ApplicationLoadBalancer': 'ELBV2 Application Load Balancer',
+  'AWS.Elasticsearch.Domain': 'Elasticsearch Domain',
+  'AWS.GuardDuty.Detector': 'GuardDuty Detector',
+  'AWS.GuardDuty.Detector.Meta': 'GuardDuty Detector Meta',
+  'AWS.IAM.Group': 'IAM Group',
+  'AWS.IAM.Policy': 'IAM Policy',
+  'AWS.IAM.Role': 'IAM Role',
+  'AWS.IAM.RootUser': 'IAM Root User',
+  'AWS.IAM.User': 'IAM User',
+  'AWS.KMS.Key': 'KMS Key',
+  'AWS.Lambda.Function': 'Lambda Function',
+  'AWS.PasswordPolicy': 'Password Policy',
+  'AWS.RDS.Instance': 'RDS Instance',
+  'AWS.Redshift.Cluster': 'Redshift Cluster',
+  'AWS.Route53.HostedZone': 'Route 53 Hosted Zone',
+  'AWS.S3.Bucket': 'S3 Bucket',
+  'AWS.SNS.Topic': 'SNS Topic',
+  'AWS.SQS.Queue': 'SQS Queue',
+  'AWS.SSM.Parameter': 'SSM Parameter',
+  'AWS.SecretsManager.Secret': 'Secrets Manager Secret',
+  'AWS.WAF.Regional.WebACL': 'WAF Regional Web ACL',
+  'AWS.WAF.WebACL': 'WAF Web ACL',
+  'GCP.Compute.Firewall': 'Compute Firewall Rule',
+  'GCP.Compute.Instance': 'Compute Instance',
+  'GCP.IAM.Binding': 'IAM Binding',
+  'GCP.SQL.Instance': 'Cloud SQL Instance',
+  'GCP.Storage.Bucket': 'GCS Bucket',
+  'K8s.Apps.Deployment': 'Kubernetes Deployment',
+  'K8s.Core.Namespace': 'Kubernetes Namespace',
+  'K8s.Core.Pod': 'Kubernetes Pod',
+  'K8s.Core.ServiceAccount': 'Kubernetes Service Account',
+  'K8s.Networking.NetworkPolicy': 'Kubernetes Network Policy',
+  'K8s.RBAC.ClusterRole': 'Kubernetes Cluster Role',
+  'K8s.RBAC.ClusterRoleBinding': 'Kubernetes Cluster Role Binding',
+  'K8s.RBAC.Role': 'Kubernetes Role',
+  'K8s.RBAC.RoleBinding': 'Kubernetes Role Binding',
+};
@@ -342,10 +342,12 @@ export type CustomLogRecord = {
 export type CustomWebhookConfig = {
   __typename?: 'CustomWebhookConfig';
   webhookURL: Scalars['String'];
+  template?: Maybe<NotificationTemplate>;
 };
 
 export type CustomWebhookConfigInput = {
   webhookURL: Scalars['String'];
+  template?: Maybe<NotificationTemplateInput>;
 };
 
 export type DataModel = {
@@ -411,6 +413,7 @@ export type DeliveryResponse = {
   statusCode: Scalars['Int'];
   success: Scalars['Boolean'];
   dispatchedAt: Scalars['AWSDateTime'];
+  preview?: Maybe<NotificationPreview>;
 };
 
 export type Destination = {
@@ -426,6 +429,8 @@ export type Destination = {
   verificationStatus?: Maybe<Scalars['String']>;
   defaultForSeverity: Array<Maybe<SeverityEnum>>;
   alertTypes: Array<AlertTypesEnum>;
+  rateLimit?: Maybe<DestinationRateLimit>;
+  digest?: Maybe<DestinationDigest>;
 };
 
 export type DestinationConfig = {
@@ -440,6 +445,11 @@ export type DestinationConfig = {
   msTeams?: Maybe<MsTeamsConfig>;
   asana?: Maybe<AsanaConfig>;
   customWebhook?: Maybe<CustomWebhookConfig>;
+  email?: Maybe<EmailConfig>;
+  splunk?: Maybe<SplunkConfig>;
+  elasticsearch?: Maybe<ElasticsearchConfig>;
+  googleChat?: Maybe<GoogleChatConfig>;
+  serviceNow?: Maybe<ServiceNowConfig>;
 };
 
 export type DestinationConfigInput = {
@@ -453,6 +463,11 @@ export type DestinationConfigInput = {
   msTeams?: Maybe<MsTeamsConfigInput>;
   asana?: Maybe<AsanaConfigInput>;
   customWebhook?: Maybe<CustomWebhookConfigInput>;
+  email?: Maybe<EmailConfigInput>;
+  splunk?: Maybe<SplunkConfigInput>;
+  elasticsearch?: Maybe<ElasticsearchConfigInput>;
+  googleChat?: Maybe<GoogleChatConfigInput>;
+  serviceNow?: Maybe<ServiceNowConfigInput>;
 };
 
 export type DestinationInput = {
@@ -462,6 +477,30 @@ export type DestinationInput = {
   outputType: Scalars['String'];
   defaultForSeverity: Array<Maybe<SeverityEnum>>;
   alertTypes: Array<Maybe<AlertTypesEnum>>;
+  rateLimit?: Maybe<DestinationRateLimitInput>;
+  digest?: Maybe<DestinationDigestInput>;
+};
+
+export type DestinationDigest = {
+  __typename?: 'DestinationDigest';
+  intervalMinutes: Scalars['Int'];
+};
+
+export type DestinationDigestInput = {
+  intervalMinutes: Scalars['Int'];
+};
+
+export type DestinationRateLimit = {
+  __typename?: 'DestinationRateLimit';
+  alertsPerMinute: Scalars['Int'];
+  burst: Scalars['Int'];
+  overflow?: Maybe<Scalars['String']>;
+};
+
+export type DestinationRateLimitInput = {
+  alertsPerMinute: Scalars['Int'];
+  burst: Scalars['Int'];
+  overflow?: Maybe<Scalars['String']>;
 };
 
 export enum DestinationTypeEnum {
@@ -475,6 +514,11 @@ export enum DestinationTypeEnum {
   Sqs = 'sqs',
   Asana = 'asana',
   Customwebhook = 'customwebhook',
+  Email = 'email',
+  Splunk = 'splunk',
+  Elasticsearch = 'elasticsearch',
+  Googlechat = 'googlechat',
+  Servicenow = 'servicenow',
 }
 
 export type Detection = {
@@ -515,6 +559,42 @@ export enum DetectionTypeEnum {
   Policy = 'POLICY',
 }
 
+export type ElasticsearchConfig = {
+  __typename?: 'ElasticsearchConfig';
+  url: Scalars['String'];
+  index: Scalars['String'];
+  userName?: Maybe<Scalars['String']>;
+  password?: Maybe<Scalars['String']>;
+  apiKey?: Maybe<Scalars['String']>;
+};
+
+export type ElasticsearchConfigInput = {
+  url: Scalars['String'];
+  index: Scalars['String'];
+  userName?: Maybe<Scalars['String']>;
+  password?: Maybe<Scalars['String']>;
+  apiKey?: Maybe<Scalars['String']>;
+};
+
+export type EmailConfig = {
+  __typename?: 'EmailConfig';
+  host: Scalars['String'];
+  port?: Maybe<Scalars['Int']>;
+  userName?: Maybe<Scalars['String']>;
+  password?: Maybe<Scalars['String']>;
+  from: Scalars['String'];
+  to: Array<Scalars['String']>;
+};
+
+export type EmailConfigInput = {
+  host: Scalars['String'];
+  port?: Maybe<Scalars['Int']>;
+  userName?: Maybe<Scalars['String']>;
+  password?: Maybe<Scalars['String']>;
+  from: Scalars['String'];
+  to: Array<Scalars['String']>;
+};
+
 export type Error = {
   __typename?: 'Error';
   code?: Maybe<Scalars['String']>;
@@ -616,6 +696,15 @@ export type GlobalPythonModule = {
   lastModified: Scalars['AWSDateTime'];
 };
 
+export type GoogleChatConfig = {
+  __typename?: 'GoogleChatConfig';
+  webhookURL: Scalars['String'];
+};
+
+export type GoogleChatConfigInput = {
+  webhookURL: Scalars['String'];
+};
+
 export type IntegrationItemHealthStatus = {
   __typename?: 'IntegrationItemHealthStatus';
   healthy: Scalars['Boolean'];
@@ -645,6 +734,7 @@ export type JiraConfig = {
   assigneeId?: Maybe<Scalars['String']>;
   issueType: Scalars['String'];
   labels: Array<Scalars['String']>;
+  template?: Maybe<NotificationTemplate>;
 };
 
 export type JiraConfigInput = {
@@ -655,6 +745,7 @@ export type JiraConfigInput = {
   assigneeId?: Maybe<Scalars['String']>;
   issueType: Scalars['String'];
   labels?: Maybe<Array<Scalars['String']>>;
+  template?: Maybe<NotificationTemplateInput>;
 };
 
 export type ListAlertsInput = {
@@ -880,10 +971,12 @@ export type ModifyGlobalPythonModuleInput = {
 export type MsTeamsConfig = {
   __typename?: 'MsTeamsConfig';
   webhookURL: Scalars['String'];
+  template?: Maybe<NotificationTemplate>;
 };
 
 export type MsTeamsConfigInput = {
   webhookURL: Scalars['String'];
+  template?: Maybe<NotificationTemplateInput>;
 };
 
 export type Mutation = {
@@ -1080,6 +1173,23 @@ export type MutationUpdateAnalysisPackArgs = {
   input: UpdateAnalysisPackInput;
 };
 
+export type NotificationPreview = {
+  __typename?: 'NotificationPreview';
+  title: Scalars['String'];
+  body: Scalars['String'];
+};
+
+export type NotificationTemplate = {
+  __typename?: 'NotificationTemplate';
+  title?: Maybe<Scalars['String']>;
+  body?: Maybe<Scalars['String']>;
+};
+
+export type NotificationTemplateInput = {
+  title?: Maybe<Scalars['String']>;
+  body?: Maybe<Scalars['String']>;
+};
+
 export type OpsgenieConfig = {
   __typename?: 'OpsgenieConfig';
   apiKey: Scalars['String'];
@@ -1396,11 +1506,13 @@ export type S3PrefixLogTypes = {
   __typename?: 'S3PrefixLogTypes';
   prefix: Scalars['String'];
   logTypes: Array<Scalars['String']>;
+  jsonArrayPath?: Maybe<Scalars['String']>;
 };
 
 export type S3PrefixLogTypesInput = {
   prefix: Scalars['String'];
   logTypes: Array<Scalars['String']>;
+  jsonArrayPath?: Maybe<Scalars['String']>;
 };
 
 export type ScannedResources = {
@@ -1416,6 +1528,22 @@ export type ScannedResourceStats = {
 
 export type SendTestAlertInput = {
   outputIds: Array<Scalars['ID']>;
+  preview?: Maybe<Scalars['Boolean']>;
+};
+
+export type ServiceNowConfig = {
+  __typename?: 'ServiceNowConfig';
+  instanceURL: Scalars['String'];
+  userName: Scalars['String'];
+  password: Scalars['String'];
+  assignmentGroup?: Maybe<Scalars['String']>;
+};
+
+export type ServiceNowConfigInput = {
+  instanceURL: Scalars['String'];
+  userName: Scalars['String'];
+  password: Scalars['String'];
+  assignmentGroup?: Maybe<Scalars['String']>;
 };
 
 export enum SeverityEnum {
@@ -1435,10 +1563,12 @@ export type SingleValue = {
 export type SlackConfig = {
   __typename?: 'SlackConfig';
   webhookURL: Scalars['String'];
+  template?: Maybe<NotificationTemplate>;
 };
 
 export type SlackConfigInput = {
   webhookURL: Scalars['String'];
+  template?: Maybe<NotificationTemplateInput>;
 };
 
 export type SnsConfig = {
@@ -1455,6 +1585,23 @@ export enum SortDirEnum {
   Descending = 'descending',
 }
 
+export type SplunkConfig = {
+  __typename?: 'SplunkConfig';
+  url: Scalars['String'];
+  token: Scalars['String'];
+  index?: Maybe<Scalars['String']>;
+  source?: Maybe<Scalars['String']>;
+  sourceType?: Maybe<Scalars['String']>;
+};
+
+export type SplunkConfigInput = {
+  url: Scalars['String'];
+  token: Scalars['String'];
+  index?: Maybe<Scalars['String']>;
+  source?: Maybe<Scalars['String']>;
+  sourceType?: Maybe<Scalars['String']>;
+};
+
 export type SqsConfig = {
   __typename?: 'SqsConfig';
   logTypes: Array<Scalars['String']>;
@@ -1787,6 +1934,7 @@ export type ResolversTypes = {
   Alert: ResolversTypes['AlertDetails'] | ResolversTypes['AlertSummary'];
   AWSDateTime: ResolverTypeWrapper<Scalars['AWSDateTime']>;
   DeliveryResponse: ResolverTypeWrapper<DeliveryResponse>;
+  NotificationPreview: ResolverTypeWrapper<NotificationPreview>;
   Boolean: ResolverTypeWrapper<Scalars['Boolean']>;
   SeverityEnum: SeverityEnum;
   AlertStatusesEnum: AlertStatusesEnum;
@@ -1826,11 +1974,19 @@ export type ResolversTypes = {
   PagerDutyConfig: ResolverTypeWrapper<PagerDutyConfig>;
   GithubConfig: ResolverTypeWrapper<GithubConfig>;
   JiraConfig: ResolverTypeWrapper<JiraConfig>;
+  NotificationTemplate: ResolverTypeWrapper<NotificationTemplate>;
   OpsgenieConfig: ResolverTypeWrapper<OpsgenieConfig>;
   OpsgenieServiceRegionEnum: OpsgenieServiceRegionEnum;
   MsTeamsConfig: ResolverTypeWrapper<MsTeamsConfig>;
   AsanaConfig: ResolverTypeWrapper<AsanaConfig>;
   CustomWebhookConfig: ResolverTypeWrapper<CustomWebhookConfig>;
+  EmailConfig: ResolverTypeWrapper<EmailConfig>;
+  SplunkConfig: ResolverTypeWrapper<SplunkConfig>;
+  ElasticsearchConfig: ResolverTypeWrapper<ElasticsearchConfig>;
+  GoogleChatConfig: ResolverTypeWrapper<GoogleChatConfig>;
+  ServiceNowConfig: ResolverTypeWrapper<ServiceNowConfig>;
+  DestinationRateLimit: ResolverTypeWrapper<DestinationRateLimit>;
+  DestinationDigest: ResolverTypeWrapper<DestinationDigest>;
   GeneralSettings: ResolverTypeWrapper<GeneralSettings>;
   ComplianceIntegration: ResolverTypeWrapper<ComplianceIntegration>;
   ComplianceIntegrationHealth: ResolverTypeWrapper<ComplianceIntegrationHealth>;
@@ -1908,10 +2064,18 @@ export type ResolversTypes = {
   PagerDutyConfigInput: PagerDutyConfigInput;
   GithubConfigInput: GithubConfigInput;
   JiraConfigInput: JiraConfigInput;
+  NotificationTemplateInput: NotificationTemplateInput;
   OpsgenieConfigInput: OpsgenieConfigInput;
   MsTeamsConfigInput: MsTeamsConfigInput;
   AsanaConfigInput: AsanaConfigInput;
   CustomWebhookConfigInput: CustomWebhookConfigInput;
+  EmailConfigInput: EmailConfigInput;
+  SplunkConfigInput: SplunkConfigInput;
+  ElasticsearchConfigInput: ElasticsearchConfigInput;
+  GoogleChatConfigInput: GoogleChatConfigInput;
+  ServiceNowConfigInput: ServiceNowConfigInput;
+  DestinationRateLimitInput: DestinationRateLimitInput;
+  DestinationDigestInput: DestinationDigestInput;
   AddComplianceIntegrationInput: AddComplianceIntegrationInput;
   AddS3LogIntegrationInput: AddS3LogIntegrationInput;
   S3PrefixLogTypesInput: S3PrefixLogTypesInput;
@@ -1979,6 +2143,7 @@ export type ResolversParentTypes = {
   Alert: ResolversParentTypes['AlertDetails'] | ResolversParentTypes['AlertSummary'];
   AWSDateTime: Scalars['AWSDateTime'];
   DeliveryResponse: DeliveryResponse;
+  NotificationPreview: NotificationPreview;
   Boolean: Scalars['Boolean'];
   SeverityEnum: SeverityEnum;
   AlertStatusesEnum: AlertStatusesEnum;
@@ -2018,11 +2183,19 @@ export type ResolversParentTypes = {
   PagerDutyConfig: PagerDutyConfig;
   GithubConfig: GithubConfig;
   JiraConfig: JiraConfig;
+  NotificationTemplate: NotificationTemplate;
   OpsgenieConfig: OpsgenieConfig;
   OpsgenieServiceRegionEnum: OpsgenieServiceRegionEnum;
   MsTeamsConfig: MsTeamsConfig;
   AsanaConfig: AsanaConfig;
   CustomWebhookConfig: CustomWebhookConfig;
+  EmailConfig: EmailConfig;
+  SplunkConfig: SplunkConfig;
+  ElasticsearchConfig: ElasticsearchConfig;
+  GoogleChatConfig: GoogleChatConfig;
+  ServiceNowConfig: ServiceNowConfig;
+  DestinationRateLimit: DestinationRateLimit;
+  DestinationDigest: DestinationDigest;
   GeneralSettings: GeneralSettings;
   ComplianceIntegration: ComplianceIntegration;
   ComplianceIntegrationHealth: ComplianceIntegrationHealth;
@@ -2102,10 +2275,18 @@ export type ResolversParentTypes = {
   PagerDutyConfigInput: PagerDutyConfigInput;
   GithubConfigInput: GithubConfigInput;
   JiraConfigInput: JiraConfigInput;
+  NotificationTemplateInput: NotificationTemplateInput;
   OpsgenieConfigInput: OpsgenieConfigInput;
   MsTeamsConfigInput: MsTeamsConfigInput;
   AsanaConfigInput: AsanaConfigInput;
   CustomWebhookConfigInput: CustomWebhookConfigInput;
+  EmailConfigInput: EmailConfigInput;
+  SplunkConfigInput: SplunkConfigInput;
+  ElasticsearchConfigInput: ElasticsearchConfigInput;
+  GoogleChatConfigInput: GoogleChatConfigInput;
+  ServiceNowConfigInput: ServiceNowConfigInput;
+  DestinationRateLimitInput: DestinationRateLimitInput;
+  DestinationDigestInput: DestinationDigestInput;
   AddComplianceIntegrationInput: AddComplianceIntegrationInput;
   AddS3LogIntegrationInput: AddS3LogIntegrationInput;
   S3PrefixLogTypesInput: S3PrefixLogTypesInput;
@@ -2479,6 +2660,7 @@ export type CustomWebhookConfigResolvers<
   ParentType extends ResolversParentTypes['CustomWebhookConfig'] = ResolversParentTypes['CustomWebhookConfig']
 > = {
   webhookURL?: Resolver<ResolversTypes['String'], ParentType, ContextType>;
+  template?: Resolver<Maybe<ResolversTypes['NotificationTemplate']>, ParentType, ContextType>;
   __isTypeOf?: IsTypeOfResolverFn<ParentType>;
 };
 
@@ -2524,6 +2706,7 @@ export type DeliveryResponseResolvers<
   statusCode?: Resolver<ResolversTypes['Int'], ParentType, ContextType>;
   success?: Resolver<ResolversTypes['Boolean'], ParentType, ContextType>;
   dispatchedAt?: Resolver<ResolversTypes['AWSDateTime'], ParentType, ContextType>;
+  preview?: Resolver<Maybe<ResolversTypes['NotificationPreview']>, ParentType, ContextType>;
   __isTypeOf?: IsTypeOfResolverFn<ParentType>;
 };
 
@@ -2546,6 +2729,8 @@ export type DestinationResolvers<
     ContextType
   >;
   alertTypes?: Resolver<Array<ResolversTypes['AlertTypesEnum']>, ParentType, ContextType>;
+  rateLimit?: Resolver<Maybe<ResolversTypes['DestinationRateLimit']>, ParentType, ContextType>;
+  digest?: Resolver<Maybe<ResolversTypes['DestinationDigest']>, ParentType, ContextType>;
   __isTypeOf?: IsTypeOfResolverFn<ParentType>;
 };
 
@@ -2566,6 +2751,24 @@ export type DestinationConfigResolvers<
   __isTypeOf?: IsTypeOfResolverFn<ParentType>;
 };
 
+export type DestinationDigestResolvers<
+  ContextType = any,
+  ParentType extends ResolversParentTypes['DestinationDigest'] = ResolversParentTypes['DestinationDigest']
+> = {
+  intervalMinutes?: Resolver<ResolversTypes['Int'], ParentType, ContextType>;
+  __isTypeOf?: IsTypeOfResolverFn<ParentType>;
+};
+
+export type DestinationRateLimitResolvers<
+  ContextType = any,
+  ParentType extends ResolversParentTypes['DestinationRateLimit'] = ResolversParentTypes['DestinationRateLimit']
+> = {
+  alertsPerMinute?: Resolver<ResolversTypes['Int'], ParentType, ContextType>;
+  burst?: Resolver<ResolversTypes['Int'], ParentType, ContextType>;
+  overflow?: Resolver<Maybe<ResolversTypes['String']>, ParentType, ContextType>;
+  __isTypeOf?: IsTypeOfResolverFn<ParentType>;
+};
+
 export type DetectionResolvers<
   ContextType = any,
   ParentType extends ResolversParentTypes['Detection'] = ResolversParentTypes['Detection']
@@ -2600,6 +2803,31 @@ export type DetectionTestDefinitionResolvers<
   __isTypeOf?: IsTypeOfResolverFn<ParentType>;
 };
 
+export type ElasticsearchConfigResolvers<
+  ContextType = any,
+  ParentType extends ResolversParentTypes['ElasticsearchConfig'] = ResolversParentTypes['ElasticsearchConfig']
+> = {
+  url?: Resolver<ResolversTypes['String'], ParentType, ContextType>;
+  index?: Resolver<ResolversTypes['String'], ParentType, ContextType>;
+  userName?: Resolver<Maybe<ResolversTypes['String']>, ParentType, ContextType>;
+  password?: Resolver<Maybe<ResolversTypes['String']>, ParentType, ContextType>;
+  apiKey?: Resolver<Maybe<ResolversTypes['String']>, ParentType, ContextType>;
+  __isTypeOf?: IsTypeOfResolverFn<ParentType>;
+};
+
+export type EmailConfigResolvers<
+  ContextType = any,
+  ParentType extends ResolversParentTypes['EmailConfig'] = ResolversParentTypes['EmailConfig']
+> = {
+  host?: Resolver<ResolversTypes['String'], ParentType, ContextType>;
+  port?: Resolver<Maybe<ResolversTypes['Int']>, ParentType, ContextType>;
+  userName?: Resolver<Maybe<ResolversTypes['String']>, ParentType, ContextType>;
+  password?: Resolver<Maybe<ResolversTypes['String']>, ParentType, ContextType>;
+  from?: Resolver<ResolversTypes['String'], ParentType, ContextType>;
+  to?: Resolver<Array<ResolversTypes['String']>, ParentType, ContextType>;
+  __isTypeOf?: IsTypeOfResolverFn<ParentType>;
+};
+
 export type ErrorResolvers<
   ContextType = any,
   ParentType extends ResolversParentTypes['Error'] = ResolversParentTypes['Error']
@@ -2668,6 +2896,14 @@ export type GlobalPythonModuleResolvers<
   __isTypeOf?: IsTypeOfResolverFn<ParentType>;
 };
 
+export type GoogleChatConfigResolvers<
+  ContextType = any,
+  ParentType extends ResolversParentTypes['GoogleChatConfig'] = ResolversParentTypes['GoogleChatConfig']
+> = {
+  webhookURL?: Resolver<ResolversTypes['String'], ParentType, ContextType>;
+  __isTypeOf?: IsTypeOfResolverFn<ParentType>;
+};
+
 export type IntegrationItemHealthStatusResolvers<
   ContextType = any,
   ParentType extends ResolversParentTypes['IntegrationItemHealthStatus'] = ResolversParentTypes['IntegrationItemHealthStatus']
@@ -2698,6 +2934,7 @@ export type JiraConfigResolvers<
   assigneeId?: Resolver<Maybe<ResolversTypes['String']>, ParentType, ContextType>;
   issueType?: Resolver<ResolversTypes['String'], ParentType, ContextType>;
   labels?: Resolver<Array<ResolversTypes['String']>, ParentType, ContextType>;
+  template?: Resolver<Maybe<ResolversTypes['NotificationTemplate']>, ParentType, ContextType>;
   __isTypeOf?: IsTypeOfResolverFn<ParentType>;
 };
 
@@ -2842,6 +3079,7 @@ export type MsTeamsConfigResolvers<
   ParentType extends ResolversParentTypes['MsTeamsConfig'] = ResolversParentTypes['MsTeamsConfig']
 > = {
   webhookURL?: Resolver<ResolversTypes['String'], ParentType, ContextType>;
+  template?: Resolver<Maybe<ResolversTypes['NotificationTemplate']>, ParentType, ContextType>;
   __isTypeOf?: IsTypeOfResolverFn<ParentType>;
 };
 
@@ -3079,6 +3317,24 @@ export type MutationResolvers<
   >;
 };
 
+export type NotificationPreviewResolvers<
+  ContextType = any,
+  ParentType extends ResolversParentTypes['NotificationPreview'] = ResolversParentTypes['NotificationPreview']
+> = {
+  title?: Resolver<ResolversTypes['String'], ParentType, ContextType>;
+  body?: Resolver<ResolversTypes['String'], ParentType, ContextType>;
+  __isTypeOf?: IsTypeOfResolverFn<ParentType>;
+};
+
+export type NotificationTemplateResolvers<
+  ContextType = any,
+  ParentType extends ResolversParentTypes['NotificationTemplate'] = ResolversParentTypes['NotificationTemplate']
+> = {
+  title?: Resolver<Maybe<ResolversTypes['String']>, ParentType, ContextType>;
+  body?: Resolver<Maybe<ResolversTypes['String']>, ParentType, ContextType>;
+  __isTypeOf?: IsTypeOfResolverFn<ParentType>;
+};
+
 export type OpsgenieConfigResolvers<
   ContextType = any,
   ParentType extends ResolversParentTypes['OpsgenieConfig'] = ResolversParentTypes['OpsgenieConfig']
@@ -3461,6 +3717,7 @@ export type S3PrefixLogTypesResolvers<
 > = {
   prefix?: Resolver<ResolversTypes['String'], ParentType, ContextType>;
   logTypes?: Resolver<Array<ResolversTypes['String']>, ParentType, ContextType>;
+  jsonArrayPath?: Resolver<Maybe<ResolversTypes['String']>, ParentType, ContextType>;
   __isTypeOf?: IsTypeOfResolverFn<ParentType>;
 };
 
@@ -3485,6 +3742,17 @@ export type ScannedResourceStatsResolvers<
   __isTypeOf?: IsTypeOfResolverFn<ParentType>;
 };
 
+export type ServiceNowConfigResolvers<
+  ContextType = any,
+  ParentType extends ResolversParentTypes['ServiceNowConfig'] = ResolversParentTypes['ServiceNowConfig']
+> = {
+  instanceURL?: Resolver<ResolversTypes['String'], ParentType, ContextType>;
+  userName?: Resolver<ResolversTypes['String'], ParentType, ContextType>;
+  password?: Resolver<ResolversTypes['String'], ParentType, ContextType>;
+  assignmentGroup?: Resolver<Maybe<ResolversTypes['String']>, ParentType, ContextType>;
+  __isTypeOf?: IsTypeOfResolverFn<ParentType>;
+};
+
 export type SingleValueResolvers<
   ContextType = any,
   ParentType extends ResolversParentTypes['SingleValue'] = ResolversParentTypes['SingleValue']
@@ -3499,6 +3767,7 @@ export type SlackConfigResolvers<
   ParentType extends ResolversParentTypes['SlackConfig'] = ResolversParentTypes['SlackConfig']
 > = {
   webhookURL?: Resolver<ResolversTypes['String'], ParentType, ContextType>;
+  template?: Resolver<Maybe<ResolversTypes['NotificationTemplate']>, ParentType, ContextType>;
   __isTypeOf?: IsTypeOfResolverFn<ParentType>;
 };
 
@@ -3510,6 +3779,18 @@ export type SnsConfigResolvers<
   __isTypeOf?: IsTypeOfResolverFn<ParentType>;
 };
 
+export type SplunkConfigResolvers<
+  ContextType = any,
+  ParentType extends ResolversParentTypes['SplunkConfig'] = ResolversParentTypes['SplunkConfig']
+> = {
+  url?: Resolver<ResolversTypes['String'], ParentType, ContextType>;
+  token?: Resolver<ResolversTypes['String'], ParentType, ContextType>;
+  index?: Resolver<Maybe<ResolversTypes['String']>, ParentType, ContextType>;
+  source?: Resolver<Maybe<ResolversTypes['String']>, ParentType, ContextType>;
+  sourceType?: Resolver<Maybe<ResolversTypes['String']>, ParentType, ContextType>;
+  __isTypeOf?: IsTypeOfResolverFn<ParentType>;
+};
+
 export type SqsConfigResolvers<
   ContextType = any,
   ParentType extends ResolversParentTypes['SqsConfig'] = ResolversParentTypes['SqsConfig']
@@ -3745,8 +4026,12 @@ export type Resolvers<ContextType = any> = {
   DeliveryResponse?: DeliveryResponseResolvers<ContextType>;
   Destination?: DestinationResolvers<ContextType>;
   DestinationConfig?: DestinationConfigResolvers<ContextType>;
+  DestinationDigest?: DestinationDigestResolvers<ContextType>;
+  DestinationRateLimit?: DestinationRateLimitResolvers<ContextType>;
   Detection?: DetectionResolvers;
   DetectionTestDefinition?: DetectionTestDefinitionResolvers<ContextType>;
+  ElasticsearchConfig?: ElasticsearchConfigResolvers<ContextType>;
+  EmailConfig?: EmailConfigResolvers<ContextType>;
   Error?: ErrorResolvers<ContextType>;
   FloatSeries?: FloatSeriesResolvers<ContextType>;
   FloatSeriesData?: FloatSeriesDataResolvers<ContextType>;
@@ -3754,6 +4039,7 @@ export type Resolvers<ContextType = any> = {
   GetCustomLogOutput?: GetCustomLogOutputResolvers<ContextType>;
   GithubConfig?: GithubConfigResolvers<ContextType>;
   GlobalPythonModule?: GlobalPythonModuleResolvers<ContextType>;
+  GoogleChatConfig?: GoogleChatConfigResolvers<ContextType>;
   IntegrationItemHealthStatus?: IntegrationItemHealthStatusResolvers<ContextType>;
   IntegrationTemplate?: IntegrationTemplateResolvers<ContextType>;
   JiraConfig?: JiraConfigResolvers<ContextType>;
@@ -3773,6 +4059,8 @@ export type Resolvers<ContextType = any> = {
   ManagedS3Resources?: ManagedS3ResourcesResolvers<ContextType>;
   MsTeamsConfig?: MsTeamsConfigResolvers<ContextType>;
   Mutation?: MutationResolvers<ContextType>;
+  NotificationPreview?: NotificationPreviewResolvers<ContextType>;
+  NotificationTemplate?: NotificationTemplateResolvers<ContextType>;
   OpsgenieConfig?: OpsgenieConfigResolvers<ContextType>;
   OrganizationReportBySeverity?: OrganizationReportBySeverityResolvers<ContextType>;
   OrganizationStatsResponse?: OrganizationStatsResponseResolvers<ContextType>;
@@ -3788,9 +4076,11 @@ export type Resolvers<ContextType = any> = {
   S3PrefixLogTypes?: S3PrefixLogTypesResolvers<ContextType>;
   ScannedResources?: ScannedResourcesResolvers<ContextType>;
   ScannedResourceStats?: ScannedResourceStatsResolvers<ContextType>;
+  ServiceNowConfig?: ServiceNowConfigResolvers<ContextType>;
   SingleValue?: SingleValueResolvers<ContextType>;
   SlackConfig?: SlackConfigResolvers<ContextType>;
   SnsConfig?: SnsConfigResolvers<ContextType>;
+  SplunkConfig?: SplunkConfigResolvers<ContextType>;
   SqsConfig?: SqsConfigResolvers<ContextType>;
   SqsDestinationConfig?: SqsDestinationConfigResolvers<ContextType>;
   SqsLogIntegrationHealth?: SqsLogIntegrationHealthResolvers<ContextType>;
@@ -68,40 +68,8 @@ export const DEFAULT_DEDUP_FUNCTION =
 export const DEFAULT_ALERT_CONTEXT_FUNCTION =
   "# def alert_context(event):\n\t#  (Optional) Return a dictionary with additional data to be included in the alert sent to the SNS/SQS/Webhook destination\n\t# return {'key':'value'}";
 
-export const RESOURCE_TYPES = [
-  'AWS.ACM.Certificate',
-  'AWS.CloudFormation.Stack',
-  'AWS.CloudTrail',
-  'AWS.CloudTrail.Meta',
-  'AWS.CloudWatch.LogGroup',
-  'AWS.Config.Recorder',
-  'AWS.Config.Recorder.Meta',
-  'AWS.DynamoDB.Table',
-  'AWS.EC2.AMI',
-  'AWS.EC2.Instance',
-  'AWS.EC2.NetworkACL',
-  'AWS.EC2.SecurityGroup',
-  'AWS.EC2.Volume',
-  'AWS.EC2.VPC',
-  'AWS.ECS.Cluster',
-  'AWS.EKS.Cluster',
-  'AWS.ELBV2.ApplicationLoadBalancer',
-  'AWS.GuardDuty.Detector',
-  'AWS.GuardDuty.Detector.Meta',
-  'AWS.IAM.Group',
-  'AWS.IAM.Policy',
-  'AWS.IAM.Role',
-  'AWS.IAM.RootUser',
-  'AWS.IAM.User',
-  'AWS.KMS.Key',
-  'AWS.Lambda.Function',
-  'AWS.PasswordPolicy',
-  'AWS.RDS.Instance',
-  'AWS.Redshift.Cluster',
-  'AWS.S3.Bucket',
-  'AWS.WAF.Regional.WebACL',
-  'AWS.WAF.WebACL',
-] as const;
+// Resource types are generated from the resource type registry of the snapshot poller
+export { RESOURCE_TYPES } from 'Generated/resourceTypes';
 
 export const AWS_REGIONS = [
   'us-east-1',
@@ -37,7 +37,7 @@ export type S3LogIntegrationDetails = Pick<
   | 'managedBucketNotifications'
   | 'notificationsConfigurationSucceeded'
 > & {
-  s3PrefixLogTypes: Array<Pick<Types.S3PrefixLogTypes, 'prefix' | 'logTypes'>>;
+  s3PrefixLogTypes: Array<Pick<Types.S3PrefixLogTypes, 'prefix' | 'logTypes' | 'jsonArrayPath'>>;
   health: {
     processingRoleStatus: IntegrationItemHealthDetails;
     s3BucketStatus: IntegrationItemHealthDetails;
@@ -61,6 +61,7 @@ export const S3LogIntegrationDetails = gql`
     s3PrefixLogTypes {
       prefix
       logTypes
+      jsonArrayPath
     }
     stackName
     managedBucketNotifications